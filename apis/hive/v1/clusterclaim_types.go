@@ -32,6 +32,13 @@ type ClusterClaimSpec struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// Priority determines the order in which pending claims are assigned clusters when the pool cannot satisfy all
+	// of them at once. Claims with a higher priority are assigned before claims with a lower priority; claims of
+	// equal priority are assigned oldest first, subject to the pool's ClaimFairShare policy, if any.
+	// The default is zero. Negative values are permitted.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ClusterClaimStatus defines the observed state of ClusterClaim.
@@ -44,6 +51,30 @@ type ClusterClaimStatus struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// ExpirationTime is when the lifetime of the claim elapses, and the claim will be deleted by Hive. It is
+	// set once the claim has been assigned a cluster, if the claim has a lifetime.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// Renewals records the renewals of the claim, oldest first.
+	// +optional
+	Renewals []ClusterClaimRenewal `json:"renewals,omitempty"`
+
+	// QueuePosition is the position of the claim among the claims waiting for a cluster of the pool to become
+	// ready, starting at 1 for the next claim to be assigned a cluster. It is only set while the claim is waiting
+	// for a cluster.
+	// +optional
+	QueuePosition *int32 `json:"queuePosition,omitempty"`
+}
+
+// ClusterClaimRenewal records a renewal of a ClusterClaim.
+type ClusterClaimRenewal struct {
+	// Time is when the claim was renewed.
+	Time metav1.Time `json:"time"`
+
+	// ExpirationTime is when the lifetime of the claim elapses as a result of the renewal.
+	ExpirationTime metav1.Time `json:"expirationTime"`
 }
 
 // ClusterClaimCondition contains details for the current condition of a cluster claim.
@@ -84,6 +115,9 @@ const (
 	ClusterClaimPendingCondition ClusterClaimConditionType = "Pending"
 	// ClusterRunningCondition is true when a claimed cluster is running and ready for use.
 	ClusterRunningCondition ClusterClaimConditionType = "ClusterRunning"
+	// ClusterClaimExpiringCondition is true when the lifetime of the claim will soon elapse. It is only set for claims
+	// from pools with a ClaimLifetime.ExpiryWarning.
+	ClusterClaimExpiringCondition ClusterClaimConditionType = "Expiring"
 )
 
 // +genclient
@@ -94,9 +128,11 @@ const (
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clusterclaims
 // +kubebuilder:printcolumn:name="Pool",type="string",JSONPath=".spec.clusterPoolName"
+// +kubebuilder:printcolumn:name="Priority",type="integer",JSONPath=".spec.priority",priority=1
 // +kubebuilder:printcolumn:name="Pending",type="string",JSONPath=".status.conditions[?(@.type=='Pending')].reason"
 // +kubebuilder:printcolumn:name="ClusterNamespace",type="string",JSONPath=".spec.namespace"
 // +kubebuilder:printcolumn:name="ClusterRunning",type="string",JSONPath=".status.conditions[?(@.type=='ClusterRunning')].reason"
+// +kubebuilder:printcolumn:name="Expires",type="string",JSONPath=".status.expirationTime",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type ClusterClaim struct {
 	metav1.TypeMeta   `json:",inline"`
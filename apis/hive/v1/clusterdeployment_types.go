@@ -188,6 +188,13 @@ type ClusterDeploymentSpec struct {
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernationSchedule defines recurring windows during which the cluster should be running. Outside of those
+	// windows the cluster is kept in the Hibernating power state. The schedule is enforced by updating PowerState,
+	// so HibernateAfter and manual changes to PowerState continue to work within a running window.
+	// For ClusterPool clusters, the schedule only takes effect once the cluster has been claimed.
+	// +optional
+	HibernationSchedule *HibernationSchedule `json:"hibernationSchedule,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
@@ -198,6 +205,12 @@ type ClusterDeploymentSpec struct {
 	// provision AWS clusters to use Amazon's Security Token Service.
 	// +optional
 	BoundServiceAccountSigningKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
+
+	// Upgrade requests the upgrade of the installed cluster to a release. Hive updates the desired update (and
+	// channel) of the cluster's ClusterVersion and reports progress in Status.Upgrade. It is set by ClusterUpgrades
+	// for the clusters they select.
+	// +optional
+	Upgrade *ClusterUpgradeTarget `json:"upgrade,omitempty"`
 }
 
 // ClusterInstallLocalReference provides reference to an object that implements
@@ -293,6 +306,75 @@ type ClusterPoolReference struct {
 	CustomizationRef *corev1.LocalObjectReference `json:"clusterDeploymentCustomization,omitempty"`
 }
 
+// HibernationSchedule defines recurring windows during which a cluster should be running, along with one-off
+// exceptions to those windows.
+type HibernationSchedule struct {
+	// TimeZone is the IANA time zone name (e.g. "America/New_York") in which RunningWindows are evaluated.
+	// When omitted, windows are evaluated in UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// RunningWindows are the recurring windows during which the cluster should be running. The cluster is
+	// resumed at the start of each window and hibernated when no window (or Exception) requires it to be running.
+	// +kubebuilder:validation:MinItems=1
+	RunningWindows []HibernationScheduleWindow `json:"runningWindows"`
+
+	// Exceptions override RunningWindows for a fixed period of time, e.g. to keep a cluster hibernating over a
+	// holiday or running overnight for a release. While an Exception is in effect its PowerState is enforced.
+	// If Exceptions overlap, the first one listed wins.
+	// +optional
+	Exceptions []HibernationScheduleException `json:"exceptions,omitempty"`
+}
+
+// HibernationScheduleWindow is a daily window of time during which a cluster should be running.
+type HibernationScheduleWindow struct {
+	// Days are the days of the week on which the window starts. When omitted, the window applies to every day.
+	// +optional
+	Days []HibernationScheduleDay `json:"days,omitempty"`
+
+	// Start is the time of day, in 24-hour "HH:MM" format, at which the window opens.
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	Start string `json:"start"`
+
+	// End is the time of day, in 24-hour "HH:MM" format, at which the window closes. If End is not after Start,
+	// the window closes on the following day.
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	End string `json:"end"`
+}
+
+// HibernationScheduleDay is a day of the week on which a HibernationScheduleWindow applies.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type HibernationScheduleDay string
+
+// HibernationScheduleException overrides a HibernationSchedule's RunningWindows between Start and End.
+type HibernationScheduleException struct {
+	// Start is the time at which the exception takes effect.
+	Start metav1.Time `json:"start"`
+
+	// End is the time at which the exception stops taking effect.
+	End metav1.Time `json:"end"`
+
+	// PowerState is the power state to enforce while the exception is in effect.
+	// +kubebuilder:validation:Enum=Running;Hibernating
+	PowerState ClusterPowerState `json:"powerState"`
+}
+
+// HibernationScheduleStatus reports the state of a ClusterDeployment's HibernationSchedule.
+type HibernationScheduleStatus struct {
+	// LastScheduledResume is the start of the most recent running window for which the schedule resumed the cluster.
+	// +optional
+	LastScheduledResume *metav1.Time `json:"lastScheduledResume,omitempty"`
+
+	// NextTransitionTime is the next time at which the schedule will change the cluster's power state. It is
+	// unset if the schedule will not change the power state within the next week.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+
+	// NextPowerState is the power state the schedule will transition the cluster to at NextTransitionTime.
+	// +optional
+	NextPowerState ClusterPowerState `json:"nextPowerState,omitempty"`
+}
+
 // ClusterMetadata contains metadata information about the installed cluster.
 type ClusterMetadata struct {
 
@@ -334,12 +416,27 @@ type ClusterPlatformMetadata struct {
 	GCP *gcp.Metadata `json:"gcp,omitempty"`
 }
 
+// InstallFailureCount is the number of install attempts of a ClusterDeployment which failed for a reason.
+type InstallFailureCount struct {
+	// Reason is the failure reason of the install attempts.
+	Reason string `json:"reason"`
+	// Category is the install failure category of the reason.
+	Category InstallFailureCategory `json:"category"`
+	// Count is the number of install attempts which failed for the reason.
+	Count int32 `json:"count"`
+}
+
 // ClusterDeploymentStatus defines the observed state of ClusterDeployment
 type ClusterDeploymentStatus struct {
 
 	// InstallRestarts is the total count of container restarts on the clusters install job.
 	InstallRestarts int `json:"installRestarts,omitempty"`
 
+	// InstallFailureCounts counts the failed install attempts by failure reason. The MaxAttempts of a retry policy
+	// in the FailedProvisionConfig of HiveConfig is compared against the attempts failing as matched by the policy.
+	// +optional
+	InstallFailureCounts []InstallFailureCount `json:"installFailureCounts,omitempty"`
+
 	// APIURL is the URL where the cluster's API can be accessed.
 	APIURL string `json:"apiURL,omitempty"`
 
@@ -380,6 +477,10 @@ type ClusterDeploymentStatus struct {
 	// +optional
 	PowerState ClusterPowerState `json:"powerState,omitempty"`
 
+	// HibernationSchedule reports the state of the HibernationSchedule, if one is configured.
+	// +optional
+	HibernationSchedule *HibernationScheduleStatus `json:"hibernationSchedule,omitempty"`
+
 	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
 	// +optional
 	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
@@ -394,6 +495,10 @@ type ClusterDeploymentStatus struct {
 	// on request.
 	// +optional
 	ClusterVersionStatus *configv1.ClusterVersionStatus `json:"clusterVersionStatus,omitempty"`
+
+	// Upgrade reports the progress of the upgrade requested by Spec.Upgrade.
+	// +optional
+	Upgrade *ClusterUpgradeProgress `json:"upgrade,omitempty"`
 }
 
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
@@ -443,6 +548,20 @@ const (
 	// secrets required by an Ingress is not available.
 	IngressCertificateNotFoundCondition ClusterDeploymentConditionType = "IngressCertificateNotFound"
 
+	// ControlPlaneCertificateUnhealthyCondition is set when a certificate bundle used by the control plane
+	// has an invalid certificate chain, has expired or is about to, or does not cover the domains it serves.
+	// Control plane certificates are not synced to the cluster while any of their chains is invalid.
+	ControlPlaneCertificateUnhealthyCondition ClusterDeploymentConditionType = "ControlPlaneCertificateUnhealthy"
+
+	// IngressCertificateUnhealthyCondition is set when a certificate bundle used by an Ingress has an
+	// invalid certificate chain, has expired or is about to, or does not cover the Ingress domain.
+	// Ingress configuration is not synced to the cluster while any of its certificate chains is invalid.
+	IngressCertificateUnhealthyCondition ClusterDeploymentConditionType = "IngressCertificateUnhealthy"
+
+	// CertificateGenerationFailedCondition is set when Hive is unable to generate one of the
+	// CertificateBundles which have generate set.
+	CertificateGenerationFailedCondition ClusterDeploymentConditionType = "CertificateGenerationFailed"
+
 	// UnreachableCondition indicates that Hive is unable to establish an API connection to the remote cluster.
 	UnreachableCondition ClusterDeploymentConditionType = "Unreachable"
 
@@ -695,6 +814,9 @@ type PlatformStatus struct {
 	// AWS is the observed state on AWS.
 	AWS *aws.PlatformStatus `json:"aws,omitempty"`
 
+	// Azure is the observed state on Azure.
+	Azure *azure.PlatformStatus `json:"azure,omitempty"`
+
 	// GCP is the observed state on GCP
 	GCP *gcp.PlatformStatus `json:"gcp,omitempty"`
 }
@@ -797,6 +919,12 @@ type CertificateBundleSpec struct {
 	// reference. Otherwise, it is expected that the secret should exist in the same namespace
 	// as the ClusterDeployment
 	CertificateSecretRef corev1.LocalObjectReference `json:"certificateSecretRef"`
+
+	// DNSNames are the names a generated certificate is issued for. If not set, the certificate is
+	// issued for the domains of the control plane serving certificates and ingresses using this bundle,
+	// or for the API and default ingress domains of the cluster if none use it.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
 }
 
 // CertificateBundleStatus specifies whether a certificate bundle was generated for this
@@ -807,6 +935,29 @@ type CertificateBundleStatus struct {
 
 	// Generated indicates whether the certificate bundle was generated
 	Generated bool `json:"generated"`
+
+	// NotAfter is when the generated certificate expires.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is when the generated certificate will be renewed.
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// ACMEOrder is the ACME order being fulfilled to generate the certificate bundle, if any.
+	// +optional
+	ACMEOrder *ACMEOrderStatus `json:"acmeOrder,omitempty"`
+}
+
+// ACMEOrderStatus is the progress of an ACME order for a certificate bundle.
+type ACMEOrderStatus struct {
+	// URL of the order on the ACME server.
+	URL string `json:"url"`
+
+	// ChallengeRecordsSetTime is when the DNS-01 challenge records of the order were set. The challenges are only
+	// accepted once the records have had time to propagate.
+	// +optional
+	ChallengeRecordsSetTime *metav1.Time `json:"challengeRecordsSetTime,omitempty"`
 }
 
 // RelocateStatus is the status of a cluster relocate.
@@ -40,6 +40,48 @@ type ClusterDeprovisionStatus struct {
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
+
+	// Progress is reported periodically by the uninstall job while it deletes the cluster's resources. It is
+	// reset each time a new uninstall job starts.
+	// +optional
+	Progress *ClusterDeprovisionProgress `json:"progress,omitempty"`
+}
+
+// ClusterDeprovisionProgress is the progress of the uninstall job, as observed from the uninstaller's log.
+type ClusterDeprovisionProgress struct {
+	// LastUpdateTime is the last time the uninstall job reported progress.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// DeletedResources is the number of resources the uninstall job has deleted.
+	// +optional
+	DeletedResources int32 `json:"deletedResources,omitempty"`
+
+	// RemainingResourceTypes are the types of the resources which the uninstaller failed to delete and has not
+	// deleted since. The uninstaller does not report the resources it has yet to attempt, so this is empty until
+	// a deletion fails.
+	// +optional
+	RemainingResourceTypes []string `json:"remainingResourceTypes,omitempty"`
+
+	// BlockingResource is the resource the uninstaller most recently failed to delete.
+	// +optional
+	BlockingResource *ClusterDeprovisionBlockingResource `json:"blockingResource,omitempty"`
+}
+
+// ClusterDeprovisionBlockingResource is a resource the uninstaller failed to delete.
+type ClusterDeprovisionBlockingResource struct {
+	// Name identifies the resource, as logged by the uninstaller.
+	Name string `json:"name"`
+
+	// Type is the type of the resource, when the uninstaller's log gives it.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Error is the last error the uninstaller got deleting the resource.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Since is when the uninstaller first failed to delete the resource.
+	Since metav1.Time `json:"since"`
 }
 
 // ClusterDeprovisionPlatform contains platform-specific configuration for the
@@ -219,8 +261,15 @@ const (
 	// AuthenticationFailureClusterDeprovisionCondition is true when credentials cannot be used because of authentication failure
 	AuthenticationFailureClusterDeprovisionCondition ClusterDeprovisionConditionType = "AuthenticationFailure"
 
+	// CloudUnreachableClusterDeprovisionCondition is true when the cloud could not be reached to check the credentials
+	CloudUnreachableClusterDeprovisionCondition ClusterDeprovisionConditionType = "CloudUnreachable"
+
 	// DeprovisionFailedClusterDeprovisionCondition is true when deprovision attempt failed
 	DeprovisionFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "DeprovisionFailed"
+
+	// DeprovisionStuckClusterDeprovisionCondition is true when the uninstall job has been failing to delete the same
+	// resource for too long
+	DeprovisionStuckClusterDeprovisionCondition ClusterDeprovisionConditionType = "DeprovisionStuck"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
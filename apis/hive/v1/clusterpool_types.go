@@ -17,6 +17,7 @@ type ClusterPoolSpec struct {
 	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
 
 	// Size is the default number of clusters that we should keep provisioned and waiting for use.
+	// When Autoscaling is configured, Size is ignored in favor of the computed Status.Autoscaling.TargetSize.
 	// +kubebuilder:validation:Minimum=0
 	// +required
 	Size int32 `json:"size"`
@@ -39,6 +40,11 @@ type ClusterPoolSpec struct {
 	// +optional
 	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
 
+	// Autoscaling, if set, causes the pool to derive the number of clusters to keep waiting for use from recent
+	// ClusterClaim demand rather than from Size.
+	// +optional
+	Autoscaling *ClusterPoolAutoscaling `json:"autoscaling,omitempty"`
+
 	// BaseDomain is the base domain to use for all clusters created in this pool.
 	// +required
 	BaseDomain string `json:"baseDomain"`
@@ -77,6 +83,11 @@ type ClusterPoolSpec struct {
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernationSchedule will be applied to new ClusterDeployments created for the pool. The schedule takes effect
+	// once a ClusterDeployment has been claimed; until then, hibernation of pool clusters is governed by RunningCount.
+	// +optional
+	HibernationSchedule *HibernationSchedule `json:"hibernationSchedule,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
@@ -89,6 +100,11 @@ type ClusterPoolSpec struct {
 	// +optional
 	ClaimLifetime *ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
 
+	// ClaimFairShare configures how clusters are shared among tenants when the pool cannot satisfy all pending
+	// ClusterClaims at once.
+	// +optional
+	ClaimFairShare *ClusterPoolClaimFairShare `json:"claimFairShare,omitempty"`
+
 	// HibernationConfig configures the hibernation/resume behavior of ClusterDeployments owned by the ClusterPool.
 	// +optional
 	HibernationConfig *HibernationConfig `json:"hibernationConfig"`
@@ -109,6 +125,50 @@ type ClusterPoolSpec struct {
 	// ClusterDeployment generated by the ClusterPool.
 	// +optional
 	CustomizationRef *corev1.LocalObjectReference `json:"customizationRef,omitempty"`
+
+	// Recycle, if set, returns clusters to the pool once their ClusterClaims are deleted, instead of deprovisioning
+	// them. A cleanup job is run against each returned cluster. If it succeeds and the cluster is healthy, the
+	// cluster becomes available to be claimed again; otherwise it is deprovisioned as usual.
+	// +optional
+	Recycle *ClusterPoolRecycle `json:"recycle,omitempty"`
+}
+
+// ClusterPoolRecycle configures the cleanup of clusters returned to a ClusterPool.
+type ClusterPoolRecycle struct {
+	// Image is the container image of the cleanup job. The job runs in the namespace of the ClusterDeployment, with
+	// the KUBECONFIG environment variable pointing at the cluster's admin kubeconfig. It is expected to remove all
+	// traces of the previous claimant, for example by deleting user namespaces and resetting identity providers. Hive
+	// rotates the kubeadmin password and admin kubeconfig itself before the job runs. The job's service account has no
+	// permissions on the hub.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Image string `json:"image"`
+
+	// Command is the entrypoint of the cleanup container. The image's entrypoint is used if unset.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments to the entrypoint of the cleanup container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are extra environment variables to set in the cleanup container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Timeout is how long a cluster may take to be cleaned up, including resuming it from hibernation, before it
+	// is deprovisioned instead. The default is one hour.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxRecycles is the number of times each cluster may be returned to the pool. Once a cluster has been recycled
+	// this many times, it is deprovisioned when its next claim is deleted. By default there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRecycles *int32 `json:"maxRecycles,omitempty"`
 }
 
 type HibernationConfig struct {
@@ -127,6 +187,46 @@ type HibernationConfig struct {
 	ResumeTimeout metav1.Duration `json:"resumeTimeout"`
 }
 
+// ClusterPoolAutoscaling configures demand-driven sizing of a ClusterPool. The target size is computed as the number
+// of ClusterClaims created within DemandWindow that have been assigned a cluster, plus TargetIdle; and is then bounded
+// by MinSize and MaxSize. ClusterClaims still waiting for a cluster are not counted, as the pool provisions a cluster
+// for each of them on top of the target size. Increases to the target size take effect immediately.
+// Decreases are deferred until ScaleDownCooldown has elapsed since the target size last changed.
+type ClusterPoolAutoscaling struct {
+	// MinSize is the smallest number of unclaimed clusters the pool will keep, regardless of demand.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSize int32 `json:"minSize,omitempty"`
+
+	// MaxSize is the largest number of unclaimed clusters the pool will keep, regardless of demand. This is
+	// distinct from Spec.MaxSize, which bounds the total number of clusters including claimed ones.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxSize int32 `json:"maxSize"`
+
+	// TargetIdle is the number of unclaimed clusters to keep on top of the expected demand, as headroom for
+	// bursts of claims.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TargetIdle int32 `json:"targetIdle,omitempty"`
+
+	// DemandWindow is how far back to look when counting recent ClusterClaims to estimate demand.
+	// The default is one hour.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	DemandWindow *metav1.Duration `json:"demandWindow,omitempty"`
+
+	// ScaleDownCooldown is the minimum amount of time that must pass after the target size changes before it
+	// may be decreased. The default is fifteen minutes.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	ScaleDownCooldown *metav1.Duration `json:"scaleDownCooldown,omitempty"`
+}
+
 // InventoryEntryKind is the Kind of the inventory entry.
 // +kubebuilder:validation:Enum="";ClusterDeploymentCustomization
 type InventoryEntryKind string
@@ -168,6 +268,50 @@ type ClusterPoolClaimLifetime struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	Maximum *metav1.Duration `json:"maximum,omitempty"`
+
+	// MaxRenewals is the number of times each claim may be renewed. A claim is renewed by setting the
+	// hive.openshift.io/renew-claim annotation on it; each renewal restarts the claim's lifetime, so that it
+	// expires one lifetime after the renewal. The default is zero, which means claims cannot be renewed.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRenewals *int32 `json:"maxRenewals,omitempty"`
+
+	// ExpiryWarning is how long before a claim's lifetime elapses to warn of its deletion. When the warning
+	// period begins, the claim's Expiring condition becomes true and a Warning event is emitted for it.
+	// By default no warning is given.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
+}
+
+// ClusterPoolClaimFairShare balances the assignment of clusters to pending ClusterClaims across tenants. Because
+// ClusterClaims must be created in the namespace of their ClusterPool, tenants sharing a pool are distinguished by a
+// label on their ClusterClaims rather than by namespace.
+// Among pending claims of equal priority, those belonging to the tenant currently holding the fewest clusters from
+// the pool are assigned first.
+type ClusterPoolClaimFairShare struct {
+	// TenantLabelKey is the key of the ClusterClaim label whose value identifies the tenant to which the claim
+	// belongs. Claims without this label are treated as belonging to a single, unnamed tenant.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	TenantLabelKey string `json:"tenantLabelKey"`
+
+	// MaxClaimedPerTenant is the maximum number of clusters from the pool that may be assigned to the claims of any
+	// one tenant at a time. Claims that would exceed this limit remain pending, and are not counted as demand for new
+	// clusters, until the tenant releases a cluster.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxClaimedPerTenant *int32 `json:"maxClaimedPerTenant,omitempty"`
+
+	// EnforceTenantMembership, if true, has the hiveadmission webhook admit a ClusterClaim against the pool only if
+	// its TenantLabelKey label names a group to which the user creating the claim belongs, and reject changes to that
+	// label. Otherwise the label is not validated: the tenant of a claim is whatever its creator says it is, so the
+	// fair share policy and per-tenant quotas are not enforced against users who set the label of another tenant.
+	// +optional
+	EnforceTenantMembership bool `json:"enforceTenantMembership,omitempty"`
 }
 
 // ClusterPoolStatus defines the observed state of ClusterPool
@@ -182,9 +326,34 @@ type ClusterPoolStatus struct {
 	// Ready is the number of unclaimed clusters that are installed and are running and ready to be claimed.
 	Ready int32 `json:"ready"`
 
+	// Recycling is the number of previously claimed clusters being cleaned up to be returned to the pool.
+	// +optional
+	Recycling int32 `json:"recycling,omitempty"`
+
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
+
+	// Autoscaling reports the state of demand-driven sizing when Spec.Autoscaling is configured.
+	// +optional
+	Autoscaling *ClusterPoolAutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+// ClusterPoolAutoscalingStatus reports the state of demand-driven sizing for a ClusterPool.
+type ClusterPoolAutoscalingStatus struct {
+	// TargetSize is the number of unclaimed clusters the pool is currently maintaining, in place of Spec.Size.
+	TargetSize int32 `json:"targetSize"`
+
+	// RecentClaims is the number of ClusterClaims created within the demand window and assigned a cluster as of the
+	// last computation.
+	RecentClaims int32 `json:"recentClaims"`
+
+	// PendingClaims is the number of ClusterClaims waiting for a cluster as of the last computation.
+	PendingClaims int32 `json:"pendingClaims"`
+
+	// LastScaleTime is the last time TargetSize changed.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 // ClusterPoolCondition contains details for the current condition of a cluster pool
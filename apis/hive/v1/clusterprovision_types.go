@@ -65,6 +65,54 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// InstallLogsURL is the location the logs gathered after this provision failed were stored at, as configured
+	// in HiveConfig.Spec.FailedProvisionConfig.
+	// +optional
+	InstallLogsURL string `json:"installLogsURL,omitempty"`
+
+	// InstallFailures lists the known failures found in the install log of the failed provision, in the order of the
+	// entries of the install-log-regexes ConfigMaps that matched them. The first failure is the one reported in the
+	// ProvisionFailed condition.
+	// +optional
+	InstallFailures []InstallFailure `json:"installFailures,omitempty"`
+}
+
+// InstallFailureCategory is a broad, machine-readable classification of an install failure.
+// +kubebuilder:validation:Enum=Quota;Credentials;Network;CloudAPIOutage;Bootstrap;Unknown
+type InstallFailureCategory string
+
+const (
+	// InstallFailureCategoryQuota indicates the cloud account ran out of quota or capacity for a resource.
+	InstallFailureCategoryQuota InstallFailureCategory = "Quota"
+	// InstallFailureCategoryCredentials indicates the credentials used for the install were invalid or lacked
+	// permissions.
+	InstallFailureCategoryCredentials InstallFailureCategory = "Credentials"
+	// InstallFailureCategoryNetwork indicates a problem with the networking, DNS or proxy configuration of the cluster.
+	InstallFailureCategoryNetwork InstallFailureCategory = "Network"
+	// InstallFailureCategoryCloudAPIOutage indicates the cloud provider's API failed or throttled requests.
+	InstallFailureCategoryCloudAPIOutage InstallFailureCategory = "CloudAPIOutage"
+	// InstallFailureCategoryBootstrap indicates the cluster failed to bootstrap or its operators failed to become
+	// available.
+	InstallFailureCategoryBootstrap InstallFailureCategory = "Bootstrap"
+	// InstallFailureCategoryUnknown is used for failures whose install log regex does not specify a category.
+	InstallFailureCategoryUnknown InstallFailureCategory = "Unknown"
+)
+
+// InstallFailure is a known failure found in an install log.
+type InstallFailure struct {
+	// Name is the name of the install log regex that matched.
+	Name string `json:"name"`
+	// Reason is a unique, one-word, CamelCase reason for the failure.
+	Reason string `json:"reason"`
+	// Category is the broad classification of the failure.
+	Category InstallFailureCategory `json:"category"`
+	// Message is a human-readable message describing the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Excerpt holds the lines of the install log which matched, truncated if they are long.
+	// +optional
+	Excerpt string `json:"excerpt,omitempty"`
 }
 
 // ClusterProvisionStage is the stage of provisioning.
@@ -0,0 +1,186 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterQuotaSpec defines the limits imposed by a ClusterQuota. Each limit applies to each selected namespace
+// individually.
+type ClusterQuotaSpec struct {
+	// NamespaceSelector selects the namespaces to which the quota applies. If unset, the quota applies to all
+	// namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// MaxClaimsPerPool is the maximum number of ClusterClaims any one tenant may hold against any one ClusterPool
+	// in a namespace. The tenant of a ClusterClaim is the value of its label named by the ClusterPool's
+	// ClaimFairShare.TenantLabelKey. Claims against a ClusterPool without ClaimFairShare all belong to the same
+	// tenant, so the limit applies to the ClusterPool as a whole.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClaimsPerPool *int32 `json:"maxClaimsPerPool,omitempty"`
+
+	// MaxClusterDeployments is the maximum number of ClusterDeployments that may exist in a namespace at once.
+	// ClusterDeployments which are being deleted are not counted.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClusterDeployments *int32 `json:"maxClusterDeployments,omitempty"`
+
+	// VCPULimits bounds the total number of vCPUs of the MachinePools of the ClusterDeployments in a namespace,
+	// by platform.
+	// +optional
+	VCPULimits []ClusterQuotaVCPULimit `json:"vcpuLimits,omitempty"`
+}
+
+// ClusterQuotaVCPULimit bounds the total number of vCPUs of the MachinePools in a namespace for one platform.
+type ClusterQuotaVCPULimit struct {
+	// Platform is the platform to which the limit applies, e.g. "aws", "azure", "gcp", "ibmcloud", "nutanix",
+	// "openstack" or "vsphere".
+	// +required
+	Platform string `json:"platform"`
+
+	// MaxVCPUs is the maximum total number of vCPUs, counting each MachinePool at its maximum number of replicas,
+	// and the control plane of each ClusterDeployment per ControlPlaneVCPUs.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxVCPUs int32 `json:"maxVCPUs"`
+
+	// InstanceTypeVCPUs maps instance types (or flavors) to the number of vCPUs they provide. It is used to count
+	// vCPUs for platforms whose MachinePools are sized by instance type rather than by CPU count. MachinePools using
+	// instance types not listed here may not be created or scaled up.
+	// +optional
+	InstanceTypeVCPUs map[string]int32 `json:"instanceTypeVCPUs,omitempty"`
+
+	// ControlPlaneVCPUs is the number of vCPUs counted for the control plane of each ClusterDeployment on the
+	// platform: its control plane replicas times the vCPUs of each control plane machine. Control planes are not
+	// counted if it is unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ControlPlaneVCPUs int32 `json:"controlPlaneVCPUs,omitempty"`
+}
+
+// ClusterQuotaStatus defines the observed state of a ClusterQuota.
+type ClusterQuotaStatus struct {
+	// Namespaces reports the usage of each namespace selected by the quota.
+	// +optional
+	Namespaces []ClusterQuotaNamespaceUsage `json:"namespaces,omitempty"`
+
+	// Conditions includes more detailed status for the quota.
+	// +optional
+	Conditions []ClusterQuotaCondition `json:"conditions,omitempty"`
+}
+
+// ClusterQuotaNamespaceUsage reports the usage of a namespace counted against a ClusterQuota.
+type ClusterQuotaNamespaceUsage struct {
+	// Namespace is the name of the namespace.
+	Namespace string `json:"namespace"`
+
+	// ClusterDeployments is the number of ClusterDeployments in the namespace, excluding those being deleted.
+	ClusterDeployments int32 `json:"clusterDeployments"`
+
+	// Claims lists the number of ClusterClaims in the namespace for each ClusterPool and tenant.
+	// +optional
+	Claims []ClusterQuotaPoolUsage `json:"claims,omitempty"`
+
+	// VCPUs lists the number of vCPUs counted in the namespace for each platform limited by the quota.
+	// +optional
+	VCPUs []ClusterQuotaVCPUUsage `json:"vcpus,omitempty"`
+}
+
+// ClusterQuotaPoolUsage reports the number of ClusterClaims held by a tenant against a ClusterPool.
+type ClusterQuotaPoolUsage struct {
+	// ClusterPoolName is the name of the ClusterPool.
+	ClusterPoolName string `json:"clusterPoolName"`
+
+	// Tenant is the tenant holding the ClusterClaims, per the ClusterPool's ClaimFairShare.TenantLabelKey. It is
+	// empty for claims against a ClusterPool without ClaimFairShare, and for claims without the tenant label.
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+
+	// Claims is the number of ClusterClaims referencing the ClusterPool.
+	Claims int32 `json:"claims"`
+}
+
+// ClusterQuotaVCPUUsage reports the number of vCPUs counted for a platform.
+type ClusterQuotaVCPUUsage struct {
+	// Platform is the platform to which the usage applies.
+	Platform string `json:"platform"`
+
+	// VCPUs is the total number of vCPUs counted.
+	VCPUs int32 `json:"vcpus"`
+}
+
+// ClusterQuotaCondition contains details for the current condition of a ClusterQuota.
+type ClusterQuotaCondition struct {
+	// Type is the type of the condition.
+	Type ClusterQuotaConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterQuotaConditionType is a valid value for ClusterQuotaCondition.Type
+type ClusterQuotaConditionType string
+
+// ConditionType satisfies the conditions.Condition interface
+func (c ClusterQuotaCondition) ConditionType() ConditionType {
+	return c.Type
+}
+
+// String satisfies the conditions.ConditionType interface
+func (t ClusterQuotaConditionType) String() string {
+	return string(t)
+}
+
+const (
+	// ClusterQuotaExceededCondition is true when the usage of at least one selected namespace exceeds a limit of
+	// the quota. This can happen when the quota is created or lowered after the resources it counts.
+	ClusterQuotaExceededCondition ClusterQuotaConditionType = "Exceeded"
+)
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterQuota limits the number of ClusterClaims and ClusterDeployments, and the MachinePool vCPUs, that the
+// namespaces it selects may hold.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterquotas,scope=Cluster
+// +kubebuilder:printcolumn:name="MaxClaimsPerPool",type="integer",JSONPath=".spec.maxClaimsPerPool"
+// +kubebuilder:printcolumn:name="MaxClusterDeployments",type="integer",JSONPath=".spec.maxClusterDeployments"
+// +kubebuilder:printcolumn:name="Exceeded",type="string",JSONPath=".status.conditions[?(@.type=='Exceeded')].status"
+type ClusterQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterQuotaSpec   `json:"spec,omitempty"`
+	Status ClusterQuotaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterQuotaList contains a list of ClusterQuotas.
+type ClusterQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterQuota{}, &ClusterQuotaList{})
+}
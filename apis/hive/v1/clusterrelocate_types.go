@@ -12,6 +12,18 @@ type ClusterRelocateSpec struct {
 
 	// ClusterDeploymentSelector is a LabelSelector indicating which clusters will be relocated.
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// DryRun, if true, does not relocate any cluster. Instead, the objects which would be copied to the destination
+	// Hive instance for each selected cluster, and those which conflict with objects already there, are listed in
+	// the status.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// MaxConcurrent is the maximum number of clusters being relocated at once.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
 }
 
 // KubeconfigSecretReference is a reference to a secret containing the kubeconfig for a remote cluster.
@@ -23,7 +35,82 @@ type KubeconfigSecretReference struct {
 }
 
 // ClusterRelocateStatus defines the observed state of ClusterRelocate.
-type ClusterRelocateStatus struct{}
+type ClusterRelocateStatus struct {
+	// Clusters reports the progress of the relocation of each cluster selected by the ClusterRelocate.
+	// +optional
+	Clusters []ClusterRelocateClusterStatus `json:"clusters,omitempty"`
+}
+
+// ClusterRelocateClusterState is the state of the relocation of a cluster.
+// +kubebuilder:validation:Enum=Pending;Relocating;Completed;Failed;Planned
+type ClusterRelocateClusterState string
+
+const (
+	// ClusterRelocateStatePending means the cluster is waiting for fewer clusters to be relocating.
+	ClusterRelocateStatePending ClusterRelocateClusterState = "Pending"
+	// ClusterRelocateStateRelocating means the cluster's objects are being copied to the destination.
+	ClusterRelocateStateRelocating ClusterRelocateClusterState = "Relocating"
+	// ClusterRelocateStateCompleted means the cluster has been relocated to the destination.
+	ClusterRelocateStateCompleted ClusterRelocateClusterState = "Completed"
+	// ClusterRelocateStateFailed means the cluster could not be relocated. The objects created in the destination
+	// during the failed attempt have been removed, and the relocation will be retried.
+	ClusterRelocateStateFailed ClusterRelocateClusterState = "Failed"
+	// ClusterRelocateStatePlanned means the objects which would be copied have been listed by a dry run.
+	ClusterRelocateStatePlanned ClusterRelocateClusterState = "Planned"
+)
+
+// ClusterRelocateClusterStatus reports the progress of the relocation of a cluster.
+type ClusterRelocateClusterStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// State is the state of the relocation of the cluster.
+	State ClusterRelocateClusterState `json:"state"`
+
+	// Message is a human-readable description of the state of the relocation.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the last time the state changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Objects are the objects a dry run would copy to the destination, and what would be done with each of them.
+	// +optional
+	Objects []ClusterRelocateObject `json:"objects,omitempty"`
+}
+
+// ClusterRelocateObjectAction is what relocating a cluster would do with one of its objects.
+// +kubebuilder:validation:Enum=Create;Replace;Unchanged;Conflict
+type ClusterRelocateObjectAction string
+
+const (
+	// ClusterRelocateObjectCreate means the object would be created in the destination.
+	ClusterRelocateObjectCreate ClusterRelocateObjectAction = "Create"
+	// ClusterRelocateObjectReplace means a different object with the same name exists in the destination and would
+	// be replaced.
+	ClusterRelocateObjectReplace ClusterRelocateObjectAction = "Replace"
+	// ClusterRelocateObjectUnchanged means the same object already exists in the destination.
+	ClusterRelocateObjectUnchanged ClusterRelocateObjectAction = "Unchanged"
+	// ClusterRelocateObjectConflict means an object with the same name exists in the destination and cannot be
+	// replaced, e.g. a ClusterDeployment for a different cluster, which blocks the relocation.
+	ClusterRelocateObjectConflict ClusterRelocateObjectAction = "Conflict"
+)
+
+// ClusterRelocateObject is an object copied when relocating a cluster.
+type ClusterRelocateObject struct {
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+
+	// Name is the name of the object, in the namespace of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// Action is what relocating the cluster would do with the object.
+	Action ClusterRelocateObjectAction `json:"action"`
+}
 
 // +genclient:nonNamespaced
 // +genclient
@@ -0,0 +1,250 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ClusterUpgradeTarget is the release a ClusterDeployment should be upgraded to.
+type ClusterUpgradeTarget struct {
+	// ImageSetRef is a reference to the ClusterImageSet of the release to upgrade to. The upgrade is blocked
+	// until the ClusterImageSet exists.
+	ImageSetRef ClusterImageSetReference `json:"imageSetRef"`
+
+	// Channel is the update channel to set on the cluster before upgrading. When omitted, the cluster's current
+	// channel is kept.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Force upgrades the cluster even if the release is not one of the available updates of the cluster in its
+	// channel, and skips the verification of the release image signature.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// ClusterUpgradeState is the state of the upgrade of a cluster.
+// +kubebuilder:validation:Enum=Pending;Blocked;Progressing;Failed;Completed
+type ClusterUpgradeState string
+
+const (
+	// ClusterUpgradeStatePending means the upgrade has not been requested from the cluster yet.
+	ClusterUpgradeStatePending ClusterUpgradeState = "Pending"
+	// ClusterUpgradeStateBlocked means the upgrade cannot be requested, e.g. because the ClusterImageSet does not
+	// exist or the release is not an available update of the cluster.
+	ClusterUpgradeStateBlocked ClusterUpgradeState = "Blocked"
+	// ClusterUpgradeStateProgressing means the cluster is upgrading.
+	ClusterUpgradeStateProgressing ClusterUpgradeState = "Progressing"
+	// ClusterUpgradeStateFailed means the cluster reports that the upgrade is failing. The cluster keeps trying,
+	// so a failed upgrade may still complete.
+	ClusterUpgradeStateFailed ClusterUpgradeState = "Failed"
+	// ClusterUpgradeStateCompleted means the cluster runs the target release.
+	ClusterUpgradeStateCompleted ClusterUpgradeState = "Completed"
+)
+
+// ClusterUpgradeProgress reports the progress of the upgrade of a ClusterDeployment.
+type ClusterUpgradeProgress struct {
+	// State is the state of the upgrade.
+	State ClusterUpgradeState `json:"state"`
+
+	// Image is the release image the cluster is upgrading to.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Version is the version the cluster is upgrading to, once known.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// StartedTime is the time the upgrade was requested from the cluster.
+	// +optional
+	StartedTime *metav1.Time `json:"startedTime,omitempty"`
+
+	// CompletedTime is the time the cluster finished upgrading.
+	// +optional
+	CompletedTime *metav1.Time `json:"completedTime,omitempty"`
+
+	// Message is a human-readable description of the state of the upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradeSpec defines a rollout of a release to the ClusterDeployments it selects.
+type ClusterUpgradeSpec struct {
+	// ClusterDeploymentSelector selects the installed ClusterDeployments, in all namespaces, to upgrade.
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// Target is the release to upgrade the selected clusters to.
+	Target ClusterUpgradeTarget `json:"target"`
+
+	// Canary configures a first wave of clusters which must all complete their upgrade before the rest of the
+	// fleet is upgraded.
+	// +optional
+	Canary *ClusterUpgradeCanary `json:"canary,omitempty"`
+
+	// MaxUnavailable is the maximum number of selected clusters upgrading at once, as a number or a percentage of
+	// the selected clusters (rounded up). Clusters whose upgrade is failing or blocked do not count. Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// PauseOnFailure stops starting new upgrades while any cluster's upgrade is failing.
+	// +optional
+	PauseOnFailure bool `json:"pauseOnFailure,omitempty"`
+
+	// Paused stops starting new upgrades. Upgrades already started are not interrupted.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ClusterUpgradeCanary configures the canary wave of a ClusterUpgrade.
+type ClusterUpgradeCanary struct {
+	// Selector selects the canary clusters among the selected clusters. When omitted, the first Count clusters,
+	// sorted by namespace and name, are the canaries.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Count is the number of canary clusters when Selector is omitted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Count int32 `json:"count,omitempty"`
+}
+
+// ClusterUpgradePhase is the phase of a ClusterUpgrade.
+// +kubebuilder:validation:Enum=Canary;Fleet;Paused;Completed
+type ClusterUpgradePhase string
+
+const (
+	// ClusterUpgradePhaseCanary means the canary clusters are upgrading.
+	ClusterUpgradePhaseCanary ClusterUpgradePhase = "Canary"
+	// ClusterUpgradePhaseFleet means the rest of the selected clusters are upgrading.
+	ClusterUpgradePhaseFleet ClusterUpgradePhase = "Fleet"
+	// ClusterUpgradePhasePaused means no new upgrades are started, because the ClusterUpgrade is paused or an
+	// upgrade is failing.
+	ClusterUpgradePhasePaused ClusterUpgradePhase = "Paused"
+	// ClusterUpgradePhaseCompleted means all the selected clusters run the target release.
+	ClusterUpgradePhaseCompleted ClusterUpgradePhase = "Completed"
+)
+
+// ClusterUpgradeStatus defines the observed state of a ClusterUpgrade.
+type ClusterUpgradeStatus struct {
+	// Phase is the phase of the rollout.
+	// +optional
+	Phase ClusterUpgradePhase `json:"phase,omitempty"`
+
+	// Total is the number of selected clusters.
+	Total int32 `json:"total"`
+
+	// Completed is the number of selected clusters running the target release.
+	Completed int32 `json:"completed"`
+
+	// Progressing is the number of selected clusters upgrading.
+	Progressing int32 `json:"progressing"`
+
+	// Failed is the number of selected clusters whose upgrade is failing or blocked.
+	Failed int32 `json:"failed"`
+
+	// Clusters reports the progress of each selected cluster.
+	// +optional
+	Clusters []ClusterUpgradeClusterStatus `json:"clusters,omitempty"`
+
+	// Conditions includes more detailed status for the ClusterUpgrade.
+	// +optional
+	Conditions []ClusterUpgradeCondition `json:"conditions,omitempty"`
+}
+
+// ClusterUpgradeClusterStatus reports the progress of the upgrade of a cluster selected by a ClusterUpgrade.
+type ClusterUpgradeClusterStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// Canary is true if the cluster is in the canary wave.
+	// +optional
+	Canary bool `json:"canary,omitempty"`
+
+	// State is the state of the upgrade of the cluster.
+	State ClusterUpgradeState `json:"state"`
+
+	// Message is a human-readable description of the state of the upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradeCondition contains details for the current condition of a ClusterUpgrade.
+type ClusterUpgradeCondition struct {
+	// Type is the type of the condition.
+	Type ClusterUpgradeConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterUpgradeConditionType is a valid value for ClusterUpgradeCondition.Type
+type ClusterUpgradeConditionType string
+
+// ConditionType satisfies the conditions.Condition interface
+func (c ClusterUpgradeCondition) ConditionType() ConditionType {
+	return c.Type
+}
+
+// String satisfies the conditions.ConditionType interface
+func (t ClusterUpgradeConditionType) String() string {
+	return string(t)
+}
+
+const (
+	// ClusterUpgradePausedCondition is true when no new upgrades are started, either because the ClusterUpgrade
+	// is paused or because an upgrade is failing and PauseOnFailure is set.
+	ClusterUpgradePausedCondition ClusterUpgradeConditionType = "Paused"
+
+	// ClusterUpgradeConflictCondition is true when some selected clusters are already being upgraded by another
+	// ClusterUpgrade, and are left alone.
+	ClusterUpgradeConflictCondition ClusterUpgradeConditionType = "Conflict"
+)
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgrade rolls a release out to the ClusterDeployments it selects, canaries first, a few clusters at a
+// time.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterupgrades,scope=Cluster
+// +kubebuilder:printcolumn:name="ImageSet",type="string",JSONPath=".spec.target.imageSetRef.name"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="Completed",type="integer",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed"
+type ClusterUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUpgradeSpec   `json:"spec,omitempty"`
+	Status ClusterUpgradeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgradeList contains a list of ClusterUpgrades.
+type ClusterUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUpgrade{}, &ClusterUpgradeList{})
+}
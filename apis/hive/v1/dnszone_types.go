@@ -46,6 +46,14 @@ type DNSZoneSpec struct {
 	// Azure specifes Azure-specific cloud configuration
 	// +optional
 	Azure *AzureDNSZoneSpec `json:"azure,omitempty"`
+
+	// IBMCloud specifies IBM Cloud Internet Services-specific configuration
+	// +optional
+	IBMCloud *IBMCloudDNSZoneSpec `json:"ibmcloud,omitempty"`
+
+	// RFC2136 specifies that the zone is served by a name server accepting RFC2136 dynamic updates.
+	// +optional
+	RFC2136 *RFC2136DNSZoneSpec `json:"rfc2136,omitempty"`
 }
 
 // AWSDNSZoneSpec contains AWS-specific DNSZone specifications
@@ -107,6 +115,41 @@ type AzureDNSZoneSpec struct {
 	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
 }
 
+// IBMCloudDNSZoneSpec contains IBM Cloud-specific DNSZone specifications
+type IBMCloudDNSZoneSpec struct {
+	// CredentialsSecretRef references a secret that will be used to authenticate with
+	// IBM Cloud Internet Services. It will need permission to create and manage zones.
+	// Secret should have a key named 'ibmcloud_api_key'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CISInstanceCRN is the CRN of the IBM Cloud Internet Services instance in which the zone should be created.
+	CISInstanceCRN string `json:"cisInstanceCRN"`
+}
+
+// RFC2136DNSZoneSpec contains RFC2136-specific DNSZone specifications
+type RFC2136DNSZoneSpec struct {
+	// Server is the address of the name server accepting dynamic updates for the zone, as host or
+	// host:port. The port defaults to 53.
+	// The server must be configured as an RFC2136 managed domain in HiveConfig, whose TSIG key is
+	// used to sign updates. Records are written into the zone of the managed domain.
+	Server string `json:"server"`
+
+	// Records are address records published in the zone, such as the API and ingress records of a cluster on
+	// a platform whose installer does not create DNS records. Records dropped from the list are only removed
+	// from the zone when the DNSZone is deleted.
+	// +optional
+	Records []RFC2136AddressRecord `json:"records,omitempty"`
+}
+
+// RFC2136AddressRecord is a set of A and AAAA records published in an RFC2136 zone.
+type RFC2136AddressRecord struct {
+	// Name is the fully qualified domain name of the records. It may be a wildcard, such as *.apps.mycluster.example.com.
+	Name string `json:"name"`
+
+	// Addresses are the IPv4 and IPv6 addresses of the name.
+	Addresses []string `json:"addresses"`
+}
+
 // DNSZoneStatus defines the observed state of DNSZone
 type DNSZoneStatus struct {
 	// LastSyncTimestamp is the time that the zone was last sync'd.
@@ -133,6 +176,10 @@ type DNSZoneStatus struct {
 	// AzureDNSZoneStatus contains status information specific to Azure
 	Azure *AzureDNSZoneStatus `json:"azure,omitempty"`
 
+	// IBMCloudDNSZoneStatus contains status information specific to IBM Cloud
+	// +optional
+	IBMCloud *IBMCloudDNSZoneStatus `json:"ibmcloud,omitempty"`
+
 	// Conditions includes more detailed status for the DNSZone
 	// +optional
 	Conditions []DNSZoneCondition `json:"conditions,omitempty"`
@@ -149,6 +196,13 @@ type AWSDNSZoneStatus struct {
 type AzureDNSZoneStatus struct {
 }
 
+// IBMCloudDNSZoneStatus contains status information specific to IBM Cloud Internet Services zones
+type IBMCloudDNSZoneStatus struct {
+	// ZoneID is the ID of the zone in IBM Cloud Internet Services
+	// +optional
+	ZoneID *string `json:"zoneID,omitempty"`
+}
+
 // GCPDNSZoneStatus contains status information specific to GCP Cloud DNS zones
 type GCPDNSZoneStatus struct {
 	// ZoneName is the name of the zone in GCP Cloud DNS
@@ -209,6 +263,10 @@ const (
 	// GenericDNSErrorsCondition is true when there's some DNS Zone related error that isn't related to
 	// authentication or credentials, and needs to be bubbled up to ClusterDeployment
 	GenericDNSErrorsCondition DNSZoneConditionType = "DNSError"
+	// DelegationVerifiedCondition is true if an audit of the zone found the parent domain's delegation NS set
+	// matching the status name servers, and every delegated name server answering authoritatively with
+	// consistent SOA and NS records. It is only set when HiveConfig enables DNS delegation audits.
+	DelegationVerifiedCondition DNSZoneConditionType = "DelegationVerified"
 )
 
 // +genclient
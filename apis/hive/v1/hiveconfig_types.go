@@ -2,6 +2,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift/hive/apis/hive/v1/azure"
@@ -203,6 +204,16 @@ type HiveConfigSpec struct {
 	// MetricsConfig encapsulates metrics specific configurations, like opting in for certain metrics.
 	// +optional
 	MetricsConfig *metricsconfig.MetricsConfig `json:"metricsConfig,omitempty"`
+
+	// CertificateGeneration configures how Hive issues the certificate bundles of ClusterDeployments which
+	// have generate set. If not set, such certificate bundles are not generated.
+	// +optional
+	CertificateGeneration *CertificateGenerationConfig `json:"certificateGeneration,omitempty"`
+
+	// DNSDelegationAudit configures the periodic audit of the delegation of DNSZones linked to their parent domain.
+	// If not set, delegations are not audited.
+	// +optional
+	DNSDelegationAudit *DNSDelegationAuditConfig `json:"dnsDelegationAudit,omitempty"`
 }
 
 // ReleaseImageVerificationConfigMapReference is a reference to the ConfigMap that
@@ -219,6 +230,10 @@ type PrivateLinkConfig struct {
 	// GCP is the configuration for GCP hub and link resources.
 	// +optional
 	GCP *GCPPrivateServiceConnectConfig `json:"gcp,omitempty"`
+
+	// Azure is the configuration for Azure hub and link resources.
+	// +optional
+	Azure *AzurePrivateLinkConfig `json:"azure,omitempty"`
 }
 
 // AWSPrivateLinkConfig defines the configuration for the aws-private-link controller.
@@ -344,6 +359,58 @@ type GCPPrivateServiceConnectSubnet struct {
 	Region string `json:"region"`
 }
 
+// AzurePrivateLinkConfig defines the azure private link config for the private-link controller.
+type AzurePrivateLinkConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure for creating the hub resources for Azure Private Link.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CloudName is the name of the Azure cloud environment containing the hub resources.
+	// If empty, the value is equal to "AzurePublicCloud".
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+
+	// EndpointVNetInventory is a list of VNets and the corresponding subnets in various Azure regions.
+	// The controller uses this list to choose a subnet for creating Azure Private Endpoints. Since the
+	// Private Endpoints must be in the same region as the ClusterDeployment, we must have VNets in that
+	// region to be able to setup Private Link.
+	// +optional
+	EndpointVNetInventory []AzurePrivateLinkInventory `json:"endpointVNetInventory,omitempty"`
+
+	// DNSZoneResourceGroup is the resource group in which the private DNS zones for the cluster API
+	// are created. Defaults to the resource group of the VNet chosen for the Private Endpoint.
+	// +optional
+	DNSZoneResourceGroup string `json:"dnsZoneResourceGroup,omitempty"`
+
+	// AssociatedVNets is the list of VNets that should be able to resolve the DNS addresses
+	// setup for Private Link, in addition to the VNet containing the Private Endpoint.
+	// +optional
+	AssociatedVNets []AzurePrivateLinkVNet `json:"associatedVNets,omitempty"`
+}
+
+// AzurePrivateLinkInventory is a VNet and its corresponding subnets.
+// This VNet will be used to create an Azure Private Endpoint whenever there is a Private Link
+// Service created for a ClusterDeployment.
+type AzurePrivateLinkInventory struct {
+	VNet    AzurePrivateLinkVNet     `json:"vnet"`
+	Subnets []AzurePrivateLinkSubnet `json:"subnets"`
+}
+
+// AzurePrivateLinkVNet identifies an Azure VNet in the hub subscription.
+type AzurePrivateLinkVNet struct {
+	// ResourceGroup is the resource group containing the VNet.
+	ResourceGroup string `json:"resourceGroup"`
+
+	// Name is the name of the VNet.
+	Name string `json:"name"`
+}
+
+// AzurePrivateLinkSubnet defines subnet and the corresponding Azure region.
+type AzurePrivateLinkSubnet struct {
+	Subnet string `json:"subnet"`
+	Region string `json:"region"`
+}
+
 // FeatureSet defines the set of feature gates that should be used.
 // +kubebuilder:validation:Enum="";Custom
 type FeatureSet string
@@ -482,15 +549,90 @@ type FailedProvisionConfig struct {
 	// TODO: Figure out how to mark SkipGatherLogs as deprecated (more than just a comment)
 
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
-	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
-	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+	SkipGatherLogs bool `json:"skipGatherLogs,omitempty"`
+
+	// Only one of AWS, Azure, GCP and InCluster should be set to choose where the logs of failed provisions are
+	// stored. If more than one is set, the first of them in that order is used.
+
+	// AWS configures uploading the logs of failed provisions to AWS S3.
+	// +optional
+	AWS *FailedProvisionAWSConfig `json:"aws,omitempty"`
+	// Azure configures uploading the logs of failed provisions to Azure Blob Storage.
+	// +optional
+	Azure *FailedProvisionAzureConfig `json:"azure,omitempty"`
+	// GCP configures uploading the logs of failed provisions to Google Cloud Storage.
+	// +optional
+	GCP *FailedProvisionGCPConfig `json:"gcp,omitempty"`
+	// InCluster configures storing the logs of failed provisions on the hub cluster, in the namespace of the
+	// ClusterDeployment.
+	// +optional
+	InCluster *FailedProvisionInClusterConfig `json:"inCluster,omitempty"`
+	// LogRetention is how long the logs of failed provisions are kept. Whenever the logs of a failed provision are
+	// stored, logs of earlier provisions of the same ClusterDeployment older than this are deleted. If not set, logs
+	// are kept until they are removed by other means, such as a bucket lifecycle policy or, for logs stored in
+	// Secrets or ConfigMaps, the deletion of their ClusterProvision.
+	// +optional
+	LogRetention *metav1.Duration `json:"logRetention,omitempty"`
 	// RetryReasons is a list of installFailingReason strings from the [additional-]install-log-regexes ConfigMaps.
 	// If specified, Hive will only retry a failed installation if it results in one of the listed reasons. If
 	// omitted (not the same thing as empty!), Hive will retry regardless of the failure reason. (The total number
 	// of install attempts is still constrained by ClusterDeployment.Spec.InstallAttemptsLimit.)
 	RetryReasons *[]string `json:"retryReasons,omitempty"`
+	// RetryPolicies customize how failed installations are retried depending on their failure reason or category.
+	// The first policy matching a failed provision applies, taking precedence over RetryReasons. Failed provisions
+	// matching no policy are retried according to RetryReasons.
+	// +optional
+	RetryPolicies []FailedProvisionRetryPolicy `json:"retryPolicies,omitempty"`
+}
+
+// FailedProvisionRetryPolicy describes how to retry installations which failed for certain reasons.
+type FailedProvisionRetryPolicy struct {
+	// Reasons is a list of installFailingReason strings from the [additional-]install-log-regexes ConfigMaps the
+	// policy applies to.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+	// Categories is a list of install failure categories the policy applies to. A failed provision has the category of
+	// the install log regex which produced its failure reason.
+	// If neither Reasons nor Categories are specified, the policy applies to all failed provisions.
+	// +optional
+	Categories []InstallFailureCategory `json:"categories,omitempty"`
+	// DoNotRetry stops provisioning after a failure matching the policy.
+	// +optional
+	DoNotRetry bool `json:"doNotRetry,omitempty"`
+	// MaxAttempts is the number of install attempts failing as matched by the policy after which provisioning is
+	// stopped. Attempts which failed for other reasons are not counted. ClusterDeployment.Spec.InstallAttemptsLimit
+	// still applies to all attempts.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+	// Backoff overrides the delay before the next install attempt after a failure matching the policy.
+	// +optional
+	Backoff *FailedProvisionRetryBackoff `json:"backoff,omitempty"`
+	// Hint suggests a change to make before provisioning again. It is reported in the ProvisionFailed condition of
+	// the ClusterDeployment.
+	// +optional
+	Hint FailedProvisionRetryHint `json:"hint,omitempty"`
+}
+
+// FailedProvisionRetryBackoff is an exponential backoff between install attempts.
+type FailedProvisionRetryBackoff struct {
+	// InitialDelay is the delay after the first failed install attempt. It doubles with each subsequent attempt.
+	InitialDelay metav1.Duration `json:"initialDelay"`
+	// MaxDelay caps the delay between install attempts. Defaults to 24h.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
 }
 
+// FailedProvisionRetryHint is a change suggested before provisioning again.
+// +kubebuilder:validation:Enum=SwitchRegionOrZone
+type FailedProvisionRetryHint string
+
+const (
+	// FailedProvisionRetryHintSwitchRegionOrZone suggests installing in a different region or availability zone, for
+	// failures such as capacity or quota shortages local to a region or zone.
+	FailedProvisionRetryHintSwitchRegionOrZone FailedProvisionRetryHint = "SwitchRegionOrZone"
+)
+
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
 // details for accessing/managing the domain.
 type ManageDNSConfig struct {
@@ -510,11 +652,78 @@ type ManageDNSConfig struct {
 	// +optional
 	Azure *ManageDNSAzureConfig `json:"azure,omitempty"`
 
+	// IBMCloud contains IBM Cloud Internet Services-specific settings for external DNS
+	// +optional
+	IBMCloud *ManageDNSIBMCloudConfig `json:"ibmcloud,omitempty"`
+
+	// RFC2136 contains settings for external DNS served by a name server that accepts RFC2136
+	// dynamic updates.
+	// +optional
+	RFC2136 *ManageDNSRFC2136Config `json:"rfc2136,omitempty"`
+
 	// As other cloud providers are supported, additional fields will be
 	// added for each of those cloud providers. Only a single cloud provider
 	// may be configured at a time.
 }
 
+// DNSDelegationAuditConfig contains the configuration for auditing the delegation of DNSZones. When set, the
+// root zones of managed domains are also checked for dangling delegations whenever their name servers are scraped.
+type DNSDelegationAuditConfig struct {
+	// Resolvers are the addresses, with optional ports, of the recursive resolvers used to find the name servers of
+	// parent domains and the addresses of name servers. Defaults to the resolvers used to check zone availability.
+	// +optional
+	Resolvers []string `json:"resolvers,omitempty"`
+
+	// Interval is how often the delegation of each DNSZone is audited. Defaults to 2 hours.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// CertificateGenerationConfig contains the configuration for generating ClusterDeployment certificate bundles.
+// Exactly one issuer must be set.
+type CertificateGenerationConfig struct {
+	// ACME issues certificates from an ACME server, such as Let's Encrypt, answering DNS-01 challenges in the
+	// managed DNS zone of the cluster. Only ClusterDeployments with manageDNS set can use it.
+	// +optional
+	ACME *ACMECertificateIssuer `json:"acme,omitempty"`
+
+	// CA issues certificates signed by a local certificate authority.
+	// +optional
+	CA *CACertificateIssuer `json:"ca,omitempty"`
+
+	// RenewBefore is how long before a generated certificate expires that it is renewed.
+	// Defaults to 30 days.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// ACMECertificateIssuer contains the settings for issuing certificates from an ACME server.
+type ACMECertificateIssuer struct {
+	// DirectoryURL is the URL of the directory of the ACME server, for example
+	// https://acme-v02.api.letsencrypt.org/directory.
+	DirectoryURL string `json:"directoryURL"`
+
+	// Email is the contact email address registered with the ACME account.
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// AccountKeySecretRef references a secret in the TargetNamespace holding the PEM encoded private key of the
+	// ACME account under the key "tls.key". If the secret does not exist, it is created with a new key.
+	AccountKeySecretRef corev1.LocalObjectReference `json:"accountKeySecretRef"`
+}
+
+// CACertificateIssuer contains the settings for issuing certificates signed by a local certificate authority.
+type CACertificateIssuer struct {
+	// SecretRef references a secret in the TargetNamespace holding the PEM encoded certificate and private key of
+	// the certificate authority under the keys "tls.crt" and "tls.key".
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// Duration is how long issued certificates are valid for. Certificates never outlive the certificate
+	// authority. Defaults to 90 days.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
 // FailedProvisionAWSConfig contains AWS-specific info to upload log files.
 type FailedProvisionAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -539,6 +748,76 @@ type FailedProvisionAWSConfig struct {
 	Bucket string `json:"bucket,omitempty"`
 }
 
+// FailedProvisionAzureConfig contains Azure-specific info to upload log files.
+type FailedProvisionAzureConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure Blob Storage. It will need permission to write, list and delete blobs in the container.
+	// Secret should have a key named 'osServicePrincipal.json'
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// StorageAccount is the name of the Azure storage account to store the logs in.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the blob container in the storage account to store the logs in.
+	Container string `json:"container"`
+
+	// CloudName is the name of the Azure cloud environment which can be used to configure the Azure SDK
+	// with the appropriate Azure API endpoints.
+	// If empty, the value is equal to "AzurePublicCloud".
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+}
+
+// FailedProvisionGCPConfig contains GCP-specific info to upload log files.
+type FailedProvisionGCPConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Google Cloud Storage. It will need permission to create, list and delete objects in the bucket.
+	// Secret should have a key named 'osServiceAccount.json'
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Bucket is the GCS bucket to store the logs in.
+	Bucket string `json:"bucket"`
+}
+
+// FailedProvisionLogStorageType is a kind of object on the hub cluster that logs of failed provisions are stored in.
+// +kubebuilder:validation:Enum=Secret;ConfigMap;PersistentVolumeClaim
+type FailedProvisionLogStorageType string
+
+const (
+	// FailedProvisionLogStorageSecret stores logs in Secrets owned by the ClusterProvision.
+	FailedProvisionLogStorageSecret FailedProvisionLogStorageType = "Secret"
+	// FailedProvisionLogStorageConfigMap stores logs in ConfigMaps owned by the ClusterProvision.
+	FailedProvisionLogStorageConfigMap FailedProvisionLogStorageType = "ConfigMap"
+	// FailedProvisionLogStoragePersistentVolumeClaim stores logs in a PersistentVolumeClaim owned by the
+	// ClusterDeployment.
+	FailedProvisionLogStoragePersistentVolumeClaim FailedProvisionLogStorageType = "PersistentVolumeClaim"
+)
+
+// FailedProvisionInClusterConfig contains info to store log files on the hub cluster.
+type FailedProvisionInClusterConfig struct {
+	// StorageType is the kind of object the logs are stored in. Logs stored in Secrets or ConfigMaps are split
+	// into chunks small enough to fit in an object. Defaults to Secret.
+	// +optional
+	StorageType FailedProvisionLogStorageType `json:"storageType,omitempty"`
+
+	// PersistentVolumeClaim configures the claim created for each ClusterDeployment when StorageType is
+	// PersistentVolumeClaim.
+	// +optional
+	PersistentVolumeClaim *FailedProvisionPersistentVolumeClaimConfig `json:"persistentVolumeClaim,omitempty"`
+}
+
+// FailedProvisionPersistentVolumeClaimConfig contains the settings of the claims logs of failed provisions are
+// stored in.
+type FailedProvisionPersistentVolumeClaimConfig struct {
+	// StorageClassName is the storage class of the claims. If not set, the default storage class is used.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Size is the requested size of the claims. Defaults to 1Gi.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
 // ManageDNSAWSConfig contains AWS-specific info to manage a given domain.
 type ManageDNSAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -590,6 +869,42 @@ type ManageDNSAzureConfig struct {
 	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
 }
 
+// ManageDNSIBMCloudConfig contains IBM Cloud-specific info to manage a given domain.
+type ManageDNSIBMCloudConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// IBM Cloud Internet Services. It will need permission to manage entries in each of the
+	// managed domains listed in the parent ManageDNSConfig object.
+	// Secret should have a key named 'ibmcloud_api_key'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CISInstanceCRN is the CRN of the IBM Cloud Internet Services instance containing the DNS zones
+	// for the domains being managed. The DNS zones of clusters are created in this instance.
+	CISInstanceCRN string `json:"cisInstanceCRN"`
+}
+
+// ManageDNSRFC2136Config contains the info to manage a given domain on a name server using RFC2136
+// dynamic updates authenticated with TSIG.
+// Records for clusters are written directly into the zone of the managed domain, as RFC2136 does
+// not allow creating zones.
+type ManageDNSRFC2136Config struct {
+	// Server is the address of the name server accepting dynamic updates for the managed domains, as
+	// host or host:port. The port defaults to 53.
+	Server string `json:"server"`
+
+	// TSIGKeyName is the name of the TSIG key used to sign updates.
+	TSIGKeyName string `json:"tsigKeyName"`
+
+	// TSIGAlgorithm is the algorithm of the TSIG key.
+	// Defaults to hmac-sha256.
+	// +kubebuilder:validation:Enum="";hmac-sha1;hmac-sha224;hmac-sha256;hmac-sha384;hmac-sha512
+	// +optional
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+
+	// TSIGSecretRef references a secret in the TargetNamespace containing the base64 encoded TSIG
+	// key under the key 'tsig-secret'.
+	TSIGSecretRef corev1.LocalObjectReference `json:"tsigSecretRef"`
+}
+
 // ControllerConfig contains the configuration for a controller
 type ControllerConfig struct {
 	// ConcurrentReconciles specifies number of concurrent reconciles for a controller
@@ -619,7 +934,7 @@ type ControllerConfig struct {
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=certificateBundle;clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;clusterquota;clusterupgrade;hibernation;hubfederation;clusterclaim;metrics;clustersync
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -642,11 +957,15 @@ func (c ControllerNames) Contains(controllerName ControllerName) bool {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
+	CertificateBundleControllerName    ControllerName = "certificateBundle"
 	ClusterClaimControllerName         ControllerName = "clusterclaim"
 	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
 	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
 	ClusterpoolControllerName          ControllerName = "clusterpool"
 	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
+	ClusterQuotaControllerName         ControllerName = "clusterquota"
+	ClusterUpgradeControllerName       ControllerName = "clusterupgrade"
+	HubFederationControllerName        ControllerName = "hubfederation"
 	ClusterProvisionControllerName     ControllerName = "clusterProvision"
 	ClusterRelocateControllerName      ControllerName = "clusterRelocate"
 	ClusterStateControllerName         ControllerName = "clusterState"
@@ -0,0 +1,188 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HubFederationSpec defines how a Hive hub federates with other Hive hubs.
+type HubFederationSpec struct {
+	// HubName is the name of this hub in the federation. Peers list this hub under this name.
+	HubName string `json:"hubName"`
+
+	// Capacity is the maximum number of ClusterDeployments this hub should manage. Clusters over capacity are
+	// moved to peers with spare capacity, and peers do not move clusters here once it is reached.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Capacity *int32 `json:"capacity,omitempty"`
+
+	// Draining, if true, moves all the clusters selected by the placement policy off this hub, e.g. ahead of its
+	// maintenance. Peers do not move clusters to a draining hub.
+	// +optional
+	Draining bool `json:"draining,omitempty"`
+
+	// Peers are the other hubs in the federation. A peer is only used once it has registered this hub too, i.e. its
+	// own HubFederation lists a peer named after this hub's HubName.
+	// +optional
+	Peers []HubFederationPeer `json:"peers,omitempty"`
+
+	// Placement is the policy for moving clusters between hubs. Without it, clusters are never moved and the
+	// HubFederation only reports on the federation.
+	// +optional
+	Placement *HubFederationPlacement `json:"placement,omitempty"`
+}
+
+// HubFederationPeer is another hub in the federation.
+type HubFederationPeer struct {
+	// Name is the HubName of the peer.
+	Name string `json:"name"`
+
+	// KubeconfigSecretRef is a reference to the secret containing the kubeconfig for the peer. The kubeconfig must
+	// be in a data field where the key is "kubeconfig".
+	KubeconfigSecretRef KubeconfigSecretReference `json:"kubeconfigSecretRef"`
+}
+
+// HubFederationPlacement is the policy for moving clusters between hubs.
+type HubFederationPlacement struct {
+	// ClusterDeploymentSelector selects the clusters which may be moved to another hub. Clusters belonging to a
+	// ClusterPool are never moved.
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// MaxConcurrent is the maximum number of clusters being moved to each peer at once.
+	// By default there is no limit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+}
+
+// HubFederationStatus defines the observed state of HubFederation.
+type HubFederationStatus struct {
+	// Hubs reports on this hub and each of its peers.
+	// +optional
+	Hubs []HubFederationHubStatus `json:"hubs,omitempty"`
+
+	// ClusterDeployments is the number of ClusterDeployments across the hubs of the federation which could be
+	// reached.
+	// +optional
+	ClusterDeployments int32 `json:"clusterDeployments,omitempty"`
+
+	// Moving is the number of clusters being moved off this hub.
+	// +optional
+	Moving int32 `json:"moving,omitempty"`
+
+	// Conditions includes more detailed status for the HubFederation.
+	// +optional
+	Conditions []HubFederationCondition `json:"conditions,omitempty"`
+}
+
+// HubFederationHubStatus reports on a hub of the federation.
+type HubFederationHubStatus struct {
+	// Name is the HubName of the hub.
+	Name string `json:"name"`
+
+	// Local is true for this hub.
+	// +optional
+	Local bool `json:"local,omitempty"`
+
+	// Reachable is true if the hub could be queried.
+	Reachable bool `json:"reachable"`
+
+	// Registered is true if the hub's HubFederation lists this hub as a peer, i.e. clusters may be moved to it.
+	// Always true for this hub.
+	Registered bool `json:"registered"`
+
+	// Capacity is the maximum number of ClusterDeployments the hub should manage. Unset if there is no limit.
+	// +optional
+	Capacity *int32 `json:"capacity,omitempty"`
+
+	// Draining is true if the hub is being drained.
+	// +optional
+	Draining bool `json:"draining,omitempty"`
+
+	// ClusterDeployments is the number of ClusterDeployments on the hub.
+	// +optional
+	ClusterDeployments int32 `json:"clusterDeployments,omitempty"`
+
+	// Message is a human-readable explanation of why the hub could not be queried or is not registered.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HubFederationCondition contains details for the current condition of a HubFederation.
+type HubFederationCondition struct {
+	// Type is the type of the condition.
+	Type HubFederationConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// HubFederationConditionType is a valid value for HubFederationCondition.Type
+type HubFederationConditionType string
+
+// ConditionType satisfies the conditions.Condition interface
+func (c HubFederationCondition) ConditionType() ConditionType {
+	return c.Type
+}
+
+// String satisfies the conditions.ConditionType interface
+func (t HubFederationConditionType) String() string {
+	return string(t)
+}
+
+const (
+	// HubFederationRebalancingCondition is true when clusters are being moved off this hub because it is over
+	// capacity or draining.
+	HubFederationRebalancingCondition HubFederationConditionType = "Rebalancing"
+
+	// HubFederationInsufficientCapacityCondition is true when clusters should be moved off this hub but no peer
+	// has the capacity to take them.
+	HubFederationInsufficientCapacityCondition HubFederationConditionType = "InsufficientCapacity"
+)
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HubFederation registers a Hive hub with other Hive hubs, reports on the ClusterDeployments across them, and
+// moves clusters to the other hubs when this hub is over capacity or draining. Each hub has a single
+// HubFederation.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=hubfederations,scope=Cluster
+// +kubebuilder:printcolumn:name="Hub",type="string",JSONPath=".spec.hubName"
+// +kubebuilder:printcolumn:name="Draining",type="boolean",JSONPath=".spec.draining"
+// +kubebuilder:printcolumn:name="ClusterDeployments",type="integer",JSONPath=".status.clusterDeployments"
+// +kubebuilder:printcolumn:name="Moving",type="integer",JSONPath=".status.moving"
+type HubFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HubFederationSpec   `json:"spec,omitempty"`
+	Status HubFederationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HubFederationList contains a list of HubFederations.
+type HubFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HubFederation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HubFederation{}, &HubFederationList{})
+}
@@ -23,7 +23,7 @@ const (
 
 // SyncSetApplyBehavior is a string representing the behavior to use when
 // aplying a syncset to target cluster.
-// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate
+// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate;ServerSideApply
 type SyncSetApplyBehavior string
 
 const (
@@ -42,8 +42,76 @@ const (
 	// is not added to the target resource with the "lastApplied" value. It allows
 	// for syncing larger resources, but loses the ability to sync map entry deletes.
 	CreateOrUpdateSyncSetApplyBehavior SyncSetApplyBehavior = "CreateOrUpdate"
+
+	// ServerSideApplySyncSetApplyBehavior results in resources getting applied
+	// using server-side apply. The target cluster tracks which fields hive
+	// manages, so fields set by other managers are left alone, and no
+	// "lastApplied" annotation is added to the target resource.
+	ServerSideApplySyncSetApplyBehavior SyncSetApplyBehavior = "ServerSideApply"
 )
 
+// SyncSetServerSideApply configures how resources are applied when the
+// ApplyBehavior is "ServerSideApply".
+type SyncSetServerSideApply struct {
+	// FieldManager is the name of the field manager that owns the fields hive
+	// applies to the target cluster. Defaults to "hive-clustersync".
+	// +optional
+	FieldManager string `json:"fieldManager,omitempty"`
+
+	// ForceConflicts, if true, causes hive to take ownership of fields owned
+	// by other field managers when their values conflict. Otherwise, applying
+	// a resource with conflicting fields fails.
+	// +optional
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+}
+
+// SyncSetDriftPolicy is a string representing how to handle resources which
+// have been changed on the target cluster since they were applied.
+// +kubebuilder:validation:Enum="";Ignore;Report;Block
+type SyncSetDriftPolicy string
+
+const (
+	// IgnoreSyncSetDriftPolicy is the default drift policy. Resources are
+	// reapplied without being compared to the target cluster, silently
+	// overwriting any changes made there.
+	IgnoreSyncSetDriftPolicy SyncSetDriftPolicy = "Ignore"
+
+	// ReportSyncSetDriftPolicy results in resources being compared to the
+	// target cluster before they are reapplied. Differences are recorded in
+	// the ClusterSync status and the drift metric, and then overwritten.
+	ReportSyncSetDriftPolicy SyncSetDriftPolicy = "Report"
+
+	// BlockSyncSetDriftPolicy results in resources being compared to the
+	// target cluster before they are reapplied. Differences are recorded in
+	// the ClusterSync status and the drift metric, and resources which differ
+	// are not reapplied, preserving the changes made on the target cluster.
+	BlockSyncSetDriftPolicy SyncSetDriftPolicy = "Block"
+)
+
+// SyncResourceReadinessCheck identifies a resource which must be ready on the
+// target cluster before SyncSets in later waves are applied.
+type SyncResourceReadinessCheck struct {
+	// APIVersion is the Group and Version of the resource.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the Kind of the resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ConditionType is the type of the status condition which must be "True"
+	// for the resource to be ready. Defaults to "Established" for
+	// CustomResourceDefinitions, "Available" for Deployments, and "Ready"
+	// otherwise.
+	// +optional
+	ConditionType string `json:"conditionType,omitempty"`
+}
+
 // SyncObjectPatch represents a patch to be applied to a specific object
 type SyncObjectPatch struct {
 	// APIVersion is the Group and Version of the object to be patched.
@@ -227,9 +295,40 @@ type SyncSetCommonSpec struct {
 	// the use of the 'oc apply' command, allowing larger resources to be synced, but losing
 	// some functionality of the 'oc apply' command such as the ability to remove annotations,
 	// labels, and other map entries in general.
+	// A value of "ServerSideApply" indicates that the resource will be applied using server-side
+	// apply, with field ownership tracked by the target cluster.
 	// +optional
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
 
+	// ServerSideApply configures server-side apply. It may only be set when ApplyBehavior is
+	// "ServerSideApply".
+	// +optional
+	ServerSideApply *SyncSetServerSideApply `json:"serverSideApply,omitempty"`
+
+	// Wave is the sync wave of the SyncSet. The SyncSets and SelectorSyncSets for a cluster are
+	// applied in order of their waves, lowest first: those in a wave are not applied until all of
+	// those in earlier waves have been applied successfully and pass their ReadinessChecks.
+	// Defaults to 0.
+	// +optional
+	Wave int32 `json:"wave,omitempty"`
+
+	// ReadinessChecks is the list of resources on the target cluster which must be ready for this
+	// SyncSet to be complete, allowing SyncSets in later waves to be applied. A resource is ready
+	// when it has a status condition of the check's type with status "True".
+	// +optional
+	ReadinessChecks []SyncResourceReadinessCheck `json:"readinessChecks,omitempty"`
+
+	// DriftPolicy indicates how to handle Resources which have been changed on the target
+	// cluster since they were applied. The default value of "Ignore" indicates that resources
+	// are periodically reapplied without checking for changes.
+	// A value of "Report" indicates that, before resources are reapplied, they are compared with
+	// the target cluster and any differences are recorded in the ClusterSync status.
+	// A value of "Block" additionally indicates that resources which differ are not reapplied.
+	// Drift is only checked when the SyncSet is reapplied unchanged; Secrets and Patches are not
+	// checked.
+	// +optional
+	DriftPolicy SyncSetDriftPolicy `json:"driftPolicy,omitempty"`
+
 	// EnableResourceTemplates, if True, causes hive to honor golang text/templates in Resources.
 	// While the standard syntax is supported, it won't do you a whole lot of good as the parser
 	// does not pass a data object (i.e. there is no "dot" for you to use). This currently exists
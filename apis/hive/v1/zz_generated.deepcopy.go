@@ -23,8 +23,46 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMECertificateIssuer) DeepCopyInto(out *ACMECertificateIssuer) {
+	*out = *in
+	out.AccountKeySecretRef = in.AccountKeySecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMECertificateIssuer.
+func (in *ACMECertificateIssuer) DeepCopy() *ACMECertificateIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMECertificateIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEOrderStatus) DeepCopyInto(out *ACMEOrderStatus) {
+	*out = *in
+	if in.ChallengeRecordsSetTime != nil {
+		in, out := &in.ChallengeRecordsSetTime, &out.ChallengeRecordsSetTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEOrderStatus.
+func (in *ACMEOrderStatus) DeepCopy() *ACMEOrderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEOrderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSAssociatedVPC) DeepCopyInto(out *AWSAssociatedVPC) {
 	*out = *in
@@ -329,6 +367,89 @@ func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkConfig) DeepCopyInto(out *AzurePrivateLinkConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointVNetInventory != nil {
+		in, out := &in.EndpointVNetInventory, &out.EndpointVNetInventory
+		*out = make([]AzurePrivateLinkInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AssociatedVNets != nil {
+		in, out := &in.AssociatedVNets, &out.AssociatedVNets
+		*out = make([]AzurePrivateLinkVNet, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkConfig.
+func (in *AzurePrivateLinkConfig) DeepCopy() *AzurePrivateLinkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkInventory) DeepCopyInto(out *AzurePrivateLinkInventory) {
+	*out = *in
+	out.VNet = in.VNet
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]AzurePrivateLinkSubnet, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkInventory.
+func (in *AzurePrivateLinkInventory) DeepCopy() *AzurePrivateLinkInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkSubnet) DeepCopyInto(out *AzurePrivateLinkSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkSubnet.
+func (in *AzurePrivateLinkSubnet) DeepCopy() *AzurePrivateLinkSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePrivateLinkVNet) DeepCopyInto(out *AzurePrivateLinkVNet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePrivateLinkVNet.
+func (in *AzurePrivateLinkVNet) DeepCopy() *AzurePrivateLinkVNet {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePrivateLinkVNet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 	*out = *in
@@ -367,10 +488,37 @@ func (in *BackupReference) DeepCopy() *BackupReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CACertificateIssuer) DeepCopyInto(out *CACertificateIssuer) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CACertificateIssuer.
+func (in *CACertificateIssuer) DeepCopy() *CACertificateIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CACertificateIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
 	*out = *in
 	out.CertificateSecretRef = in.CertificateSecretRef
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -387,6 +535,19 @@ func (in *CertificateBundleSpec) DeepCopy() *CertificateBundleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateBundleStatus) DeepCopyInto(out *CertificateBundleStatus) {
 	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ACMEOrder != nil {
+		in, out := &in.ACMEOrder, &out.ACMEOrder
+		*out = new(ACMEOrderStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -400,6 +561,37 @@ func (in *CertificateBundleStatus) DeepCopy() *CertificateBundleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateGenerationConfig) DeepCopyInto(out *CertificateGenerationConfig) {
+	*out = *in
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(ACMECertificateIssuer)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CACertificateIssuer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateGenerationConfig.
+func (in *CertificateGenerationConfig) DeepCopy() *CertificateGenerationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateGenerationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Checkpoint) DeepCopyInto(out *Checkpoint) {
 	*out = *in
@@ -574,6 +766,24 @@ func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimRenewal) DeepCopyInto(out *ClusterClaimRenewal) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimRenewal.
+func (in *ClusterClaimRenewal) DeepCopy() *ClusterClaimRenewal {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimRenewal)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	*out = *in
@@ -615,6 +825,22 @@ func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Renewals != nil {
+		in, out := &in.Renewals, &out.Renewals
+		*out = make([]ClusterClaimRenewal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueuePosition != nil {
+		in, out := &in.QueuePosition, &out.QueuePosition
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -849,7 +1075,9 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 	if in.CertificateBundles != nil {
 		in, out := &in.CertificateBundles, &out.CertificateBundles
 		*out = make([]CertificateBundleSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ClusterMetadata != nil {
 		in, out := &in.ClusterMetadata, &out.ClusterMetadata
@@ -876,6 +1104,11 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
@@ -886,6 +1119,11 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ClusterUpgradeTarget)
+		**out = **in
+	}
 	return
 }
 
@@ -902,6 +1140,11 @@ func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 	*out = *in
+	if in.InstallFailureCounts != nil {
+		in, out := &in.InstallFailureCounts, &out.InstallFailureCounts
+		*out = make([]InstallFailureCount, len(*in))
+		copy(*out, *in)
+	}
 	if in.InstallerImage != nil {
 		in, out := &in.InstallerImage, &out.InstallerImage
 		*out = new(string)
@@ -927,7 +1170,9 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 	if in.CertificateBundles != nil {
 		in, out := &in.CertificateBundles, &out.CertificateBundles
 		*out = make([]CertificateBundleStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.InstallStartedTimestamp != nil {
 		in, out := &in.InstallStartedTimestamp, &out.InstallStartedTimestamp
@@ -937,6 +1182,11 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ProvisionRef != nil {
 		in, out := &in.ProvisionRef, &out.ProvisionRef
 		*out = new(corev1.LocalObjectReference)
@@ -952,6 +1202,11 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(configv1.ClusterVersionStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ClusterUpgradeProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -993,6 +1248,23 @@ func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionBlockingResource) DeepCopyInto(out *ClusterDeprovisionBlockingResource) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionBlockingResource.
+func (in *ClusterDeprovisionBlockingResource) DeepCopy() *ClusterDeprovisionBlockingResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionBlockingResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
 	*out = *in
@@ -1095,6 +1367,33 @@ func (in *ClusterDeprovisionPlatform) DeepCopy() *ClusterDeprovisionPlatform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionProgress) DeepCopyInto(out *ClusterDeprovisionProgress) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.RemainingResourceTypes != nil {
+		in, out := &in.RemainingResourceTypes, &out.RemainingResourceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockingResource != nil {
+		in, out := &in.BlockingResource, &out.BlockingResource
+		*out = new(ClusterDeprovisionBlockingResource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionProgress.
+func (in *ClusterDeprovisionProgress) DeepCopy() *ClusterDeprovisionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDeprovisionSpec) DeepCopyInto(out *ClusterDeprovisionSpec) {
 	*out = *in
@@ -1127,6 +1426,11 @@ func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(ClusterDeprovisionProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1433,6 +1737,73 @@ func (in *ClusterPool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscaling) DeepCopyInto(out *ClusterPoolAutoscaling) {
+	*out = *in
+	if in.DemandWindow != nil {
+		in, out := &in.DemandWindow, &out.DemandWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownCooldown != nil {
+		in, out := &in.ScaleDownCooldown, &out.ScaleDownCooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscaling.
+func (in *ClusterPoolAutoscaling) DeepCopy() *ClusterPoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscalingStatus) DeepCopyInto(out *ClusterPoolAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscalingStatus.
+func (in *ClusterPoolAutoscalingStatus) DeepCopy() *ClusterPoolAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimFairShare) DeepCopyInto(out *ClusterPoolClaimFairShare) {
+	*out = *in
+	if in.MaxClaimedPerTenant != nil {
+		in, out := &in.MaxClaimedPerTenant, &out.MaxClaimedPerTenant
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimFairShare.
+func (in *ClusterPoolClaimFairShare) DeepCopy() *ClusterPoolClaimFairShare {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimFairShare)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
 	*out = *in
@@ -1446,6 +1817,16 @@ func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime)
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.MaxRenewals != nil {
+		in, out := &in.MaxRenewals, &out.MaxRenewals
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExpiryWarning != nil {
+		in, out := &in.ExpiryWarning, &out.ExpiryWarning
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1511,12 +1892,55 @@ func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+func (in *ClusterPoolRecycle) DeepCopyInto(out *ClusterPoolRecycle) {
 	*out = *in
-	if in.ClaimedTimestamp != nil {
-		in, out := &in.ClaimedTimestamp, &out.ClaimedTimestamp
-		*out = (*in).DeepCopy()
-	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxRecycles != nil {
+		in, out := &in.MaxRecycles, &out.MaxRecycles
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolRecycle.
+func (in *ClusterPoolRecycle) DeepCopy() *ClusterPoolRecycle {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolRecycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+	*out = *in
+	if in.ClaimedTimestamp != nil {
+		in, out := &in.ClaimedTimestamp, &out.ClaimedTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.CustomizationRef != nil {
 		in, out := &in.CustomizationRef, &out.CustomizationRef
 		*out = new(corev1.LocalObjectReference)
@@ -1554,6 +1978,11 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 	out.ImageSetRef = in.ImageSetRef
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
@@ -1579,6 +2008,11 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
@@ -1589,6 +2023,11 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(ClusterPoolClaimLifetime)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClaimFairShare != nil {
+		in, out := &in.ClaimFairShare, &out.ClaimFairShare
+		*out = new(ClusterPoolClaimFairShare)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HibernationConfig != nil {
 		in, out := &in.HibernationConfig, &out.HibernationConfig
 		*out = new(HibernationConfig)
@@ -1611,6 +2050,11 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.Recycle != nil {
+		in, out := &in.Recycle, &out.Recycle
+		*out = new(ClusterPoolRecycle)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1634,6 +2078,11 @@ func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1809,6 +2258,11 @@ func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InstallFailures != nil {
+		in, out := &in.InstallFailures, &out.InstallFailures
+		*out = make([]InstallFailure, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1823,27 +2277,27 @@ func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+func (in *ClusterQuota) DeepCopyInto(out *ClusterQuota) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
-func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuota.
+func (in *ClusterQuota) DeepCopy() *ClusterQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocate)
+	out := new(ClusterQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
+func (in *ClusterQuota) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1851,13 +2305,31 @@ func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+func (in *ClusterQuotaCondition) DeepCopyInto(out *ClusterQuotaCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaCondition.
+func (in *ClusterQuotaCondition) DeepCopy() *ClusterQuotaCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaList) DeepCopyInto(out *ClusterQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterRelocate, len(*in))
+		*out = make([]ClusterQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1865,18 +2337,18 @@ func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
-func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaList.
+func (in *ClusterQuotaList) DeepCopy() *ClusterQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateList)
+	out := new(ClusterQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+func (in *ClusterQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1884,94 +2356,176 @@ func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+func (in *ClusterQuotaNamespaceUsage) DeepCopyInto(out *ClusterQuotaNamespaceUsage) {
 	*out = *in
-	out.KubeconfigSecretRef = in.KubeconfigSecretRef
-	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]ClusterQuotaPoolUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.VCPUs != nil {
+		in, out := &in.VCPUs, &out.VCPUs
+		*out = make([]ClusterQuotaVCPUUsage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
-func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaNamespaceUsage.
+func (in *ClusterQuotaNamespaceUsage) DeepCopy() *ClusterQuotaNamespaceUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateSpec)
+	out := new(ClusterQuotaNamespaceUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+func (in *ClusterQuotaPoolUsage) DeepCopyInto(out *ClusterQuotaPoolUsage) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
-func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaPoolUsage.
+func (in *ClusterQuotaPoolUsage) DeepCopy() *ClusterQuotaPoolUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateStatus)
+	out := new(ClusterQuotaPoolUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+func (in *ClusterQuotaSpec) DeepCopyInto(out *ClusterQuotaSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxClaimsPerPool != nil {
+		in, out := &in.MaxClaimsPerPool, &out.MaxClaimsPerPool
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxClusterDeployments != nil {
+		in, out := &in.MaxClusterDeployments, &out.MaxClusterDeployments
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VCPULimits != nil {
+		in, out := &in.VCPULimits, &out.VCPULimits
+		*out = make([]ClusterQuotaVCPULimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
-func (in *ClusterState) DeepCopy() *ClusterState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaSpec.
+func (in *ClusterQuotaSpec) DeepCopy() *ClusterQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterState)
+	out := new(ClusterQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterState) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaStatus) DeepCopyInto(out *ClusterQuotaStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]ClusterQuotaNamespaceUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	return nil
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterQuotaCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaStatus.
+func (in *ClusterQuotaStatus) DeepCopy() *ClusterQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+func (in *ClusterQuotaVCPULimit) DeepCopyInto(out *ClusterQuotaVCPULimit) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterState, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.InstanceTypeVCPUs != nil {
+		in, out := &in.InstanceTypeVCPUs, &out.InstanceTypeVCPUs
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
-func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaVCPULimit.
+func (in *ClusterQuotaVCPULimit) DeepCopy() *ClusterQuotaVCPULimit {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateList)
+	out := new(ClusterQuotaVCPULimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaVCPUUsage) DeepCopyInto(out *ClusterQuotaVCPUUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaVCPUUsage.
+func (in *ClusterQuotaVCPUUsage) DeepCopy() *ClusterQuotaVCPUUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaVCPUUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
+func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterStateList) DeepCopyObject() runtime.Object {
+func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1979,31 +2533,35 @@ func (in *ClusterStateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+func (in *ClusterRelocateClusterStatus) DeepCopyInto(out *ClusterRelocateClusterStatus) {
 	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ClusterRelocateObject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
-func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateClusterStatus.
+func (in *ClusterRelocateClusterStatus) DeepCopy() *ClusterRelocateClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateSpec)
+	out := new(ClusterRelocateClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
+func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
 	*out = *in
-	if in.LastUpdated != nil {
-		in, out := &in.LastUpdated, &out.LastUpdated
-		*out = (*in).DeepCopy()
-	}
-	if in.ClusterOperators != nil {
-		in, out := &in.ClusterOperators, &out.ClusterOperators
-		*out = make([]ClusterOperatorState, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRelocate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2011,152 +2569,173 @@ func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
-func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
+func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateStatus)
+	out := new(ClusterRelocateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+func (in *ClusterRelocateObject) DeepCopyInto(out *ClusterRelocateObject) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
-func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateObject.
+func (in *ClusterRelocateObject) DeepCopy() *ClusterRelocateObject {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneAdditionalCertificate)
+	out := new(ClusterRelocateObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
 	*out = *in
-	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
-func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
+func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneConfigSpec)
+	out := new(ClusterRelocateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
 	*out = *in
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
-		copy(*out, *in)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterRelocateClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
-func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
+func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneServingCertificateSpec)
+	out := new(ClusterRelocateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+func (in *ClusterState) DeepCopyInto(out *ClusterState) {
 	*out = *in
-	if in.ConcurrentReconciles != nil {
-		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientQPS != nil {
-		in, out := &in.ClientQPS, &out.ClientQPS
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientBurst != nil {
-		in, out := &in.ClientBurst, &out.ClientBurst
-		*out = new(int32)
-		**out = **in
-	}
-	if in.QueueQPS != nil {
-		in, out := &in.QueueQPS, &out.QueueQPS
-		*out = new(int32)
-		**out = **in
-	}
-	if in.QueueBurst != nil {
-		in, out := &in.QueueBurst, &out.QueueBurst
-		*out = new(int32)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
+func (in *ClusterState) DeepCopy() *ClusterState {
+	if in == nil {
+		return nil
 	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	out := new(ClusterState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
-func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
+func (in *ClusterStateList) DeepCopy() *ClusterStateList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerConfig)
+	out := new(ClusterStateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ControllerNames) DeepCopyInto(out *ControllerNames) {
-	{
-		in := &in
-		*out = make(ControllerNames, len(*in))
-		copy(*out, *in)
-		return
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerNames.
-func (in ControllerNames) DeepCopy() ControllerNames {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
+func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerNames)
+	out := new(ClusterStateSpec)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 	*out = *in
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(ControllerConfig)
-		(*in).DeepCopyInto(*out)
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
 	}
-	if in.Controllers != nil {
-		in, out := &in.Controllers, &out.Controllers
-		*out = make([]SpecificControllerConfig, len(*in))
+	if in.ClusterOperators != nil {
+		in, out := &in.ClusterOperators, &out.ClusterOperators
+		*out = make([]ClusterOperatorState, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2164,18 +2743,18 @@ func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
-func (in *ControllersConfig) DeepCopy() *ControllersConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
+func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllersConfig)
+	out := new(ClusterStateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+func (in *ClusterUpgrade) DeepCopyInto(out *ClusterUpgrade) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -2184,18 +2763,18 @@ func (in *DNSZone) DeepCopyInto(out *DNSZone) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
-func (in *DNSZone) DeepCopy() *DNSZone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgrade.
+func (in *ClusterUpgrade) DeepCopy() *ClusterUpgrade {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZone)
+	out := new(ClusterUpgrade)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZone) DeepCopyObject() runtime.Object {
+func (in *ClusterUpgrade) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2203,31 +2782,68 @@ func (in *DNSZone) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
+func (in *ClusterUpgradeCanary) DeepCopyInto(out *ClusterUpgradeCanary) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCanary.
+func (in *ClusterUpgradeCanary) DeepCopy() *ClusterUpgradeCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeClusterStatus) DeepCopyInto(out *ClusterUpgradeClusterStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeClusterStatus.
+func (in *ClusterUpgradeClusterStatus) DeepCopy() *ClusterUpgradeClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCondition) DeepCopyInto(out *ClusterUpgradeCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
-func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCondition.
+func (in *ClusterUpgradeCondition) DeepCopy() *ClusterUpgradeCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneCondition)
+	out := new(ClusterUpgradeCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+func (in *ClusterUpgradeList) DeepCopyInto(out *ClusterUpgradeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSZone, len(*in))
+		*out = make([]ClusterUpgrade, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2235,18 +2851,18 @@ func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
-func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeList.
+func (in *ClusterUpgradeList) DeepCopy() *ClusterUpgradeList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneList)
+	out := new(ClusterUpgradeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+func (in *ClusterUpgradeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2254,147 +2870,678 @@ func (in *DNSZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+func (in *ClusterUpgradeProgress) DeepCopyInto(out *ClusterUpgradeProgress) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneSpec)
-		**out = **in
+	if in.StartedTime != nil {
+		in, out := &in.StartedTime, &out.StartedTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneSpec)
-		**out = **in
+	if in.CompletedTime != nil {
+		in, out := &in.CompletedTime, &out.CompletedTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
-func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeProgress.
+func (in *ClusterUpgradeProgress) DeepCopy() *ClusterUpgradeProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneSpec)
+	out := new(ClusterUpgradeProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+func (in *ClusterUpgradeSpec) DeepCopyInto(out *ClusterUpgradeSpec) {
 	*out = *in
-	if in.LastSyncTimestamp != nil {
-		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.NameServers != nil {
-		in, out := &in.NameServers, &out.NameServers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneStatus)
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	out.Target = in.Target
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(ClusterUpgradeCanary)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneStatus)
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DNSZoneCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
-func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeSpec.
+func (in *ClusterUpgradeSpec) DeepCopy() *ClusterUpgradeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneStatus)
+	out := new(ClusterUpgradeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentConfig) DeepCopyInto(out *DeploymentConfig) {
+func (in *ClusterUpgradeStatus) DeepCopyInto(out *ClusterUpgradeStatus) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterUpgradeClusterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterUpgradeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentConfig.
-func (in *DeploymentConfig) DeepCopy() *DeploymentConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStatus.
+func (in *ClusterUpgradeStatus) DeepCopy() *ClusterUpgradeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentConfig)
+	out := new(ClusterUpgradeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
+func (in *ClusterUpgradeTarget) DeepCopyInto(out *ClusterUpgradeTarget) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	out.ImageSetRef = in.ImageSetRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
-func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeTarget.
+func (in *ClusterUpgradeTarget) DeepCopy() *ClusterUpgradeTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionAWSConfig)
+	out := new(ClusterUpgradeTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
+func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(FailedProvisionAWSConfig)
-		**out = **in
-	}
-	if in.RetryReasons != nil {
-		in, out := &in.RetryReasons, &out.RetryReasons
-		*out = new([]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]string, len(*in))
-			copy(*out, *in)
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
-func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
+func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionConfig)
+	out := new(ControlPlaneAdditionalCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+	*out = *in
+	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
+func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+	*out = *in
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
+func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneServingCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+	*out = *in
+	if in.ConcurrentReconciles != nil {
+		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientQPS != nil {
+		in, out := &in.ClientQPS, &out.ClientQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientBurst != nil {
+		in, out := &in.ClientBurst, &out.ClientBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueQPS != nil {
+		in, out := &in.QueueQPS, &out.QueueQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueBurst != nil {
+		in, out := &in.QueueBurst, &out.QueueBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
+func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ControllerNames) DeepCopyInto(out *ControllerNames) {
+	{
+		in := &in
+		*out = make(ControllerNames, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerNames.
+func (in ControllerNames) DeepCopy() ControllerNames {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerNames)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ControllerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]SpecificControllerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
+func (in *ControllersConfig) DeepCopy() *ControllersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSDelegationAuditConfig) DeepCopyInto(out *DNSDelegationAuditConfig) {
+	*out = *in
+	if in.Resolvers != nil {
+		in, out := &in.Resolvers, &out.Resolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSDelegationAuditConfig.
+func (in *DNSDelegationAuditConfig) DeepCopy() *DNSDelegationAuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSDelegationAuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
+func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
+func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneSpec)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneSpec)
+		**out = **in
+	}
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(IBMCloudDNSZoneSpec)
+		**out = **in
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(RFC2136DNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
+func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+	*out = *in
+	if in.LastSyncTimestamp != nil {
+		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneStatus)
+		**out = **in
+	}
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(IBMCloudDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DNSZoneCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
+func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentConfig) DeepCopyInto(out *DeploymentConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentConfig.
+func (in *DeploymentConfig) DeepCopy() *DeploymentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
+func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAWSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAzureConfig) DeepCopyInto(out *FailedProvisionAzureConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAzureConfig.
+func (in *FailedProvisionAzureConfig) DeepCopy() *FailedProvisionAzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(FailedProvisionAWSConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(FailedProvisionAzureConfig)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(FailedProvisionGCPConfig)
+		**out = **in
+	}
+	if in.InCluster != nil {
+		in, out := &in.InCluster, &out.InCluster
+		*out = new(FailedProvisionInClusterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogRetention != nil {
+		in, out := &in.LogRetention, &out.LogRetention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryReasons != nil {
+		in, out := &in.RetryReasons, &out.RetryReasons
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.RetryPolicies != nil {
+		in, out := &in.RetryPolicies, &out.RetryPolicies
+		*out = make([]FailedProvisionRetryPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
+func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionGCPConfig) DeepCopyInto(out *FailedProvisionGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionGCPConfig.
+func (in *FailedProvisionGCPConfig) DeepCopy() *FailedProvisionGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionInClusterConfig) DeepCopyInto(out *FailedProvisionInClusterConfig) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(FailedProvisionPersistentVolumeClaimConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionInClusterConfig.
+func (in *FailedProvisionInClusterConfig) DeepCopy() *FailedProvisionInClusterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionInClusterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionPersistentVolumeClaimConfig) DeepCopyInto(out *FailedProvisionPersistentVolumeClaimConfig) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionPersistentVolumeClaimConfig.
+func (in *FailedProvisionPersistentVolumeClaimConfig) DeepCopy() *FailedProvisionPersistentVolumeClaimConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionPersistentVolumeClaimConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionRetryBackoff) DeepCopyInto(out *FailedProvisionRetryBackoff) {
+	*out = *in
+	out.InitialDelay = in.InitialDelay
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionRetryBackoff.
+func (in *FailedProvisionRetryBackoff) DeepCopy() *FailedProvisionRetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionRetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionRetryPolicy) DeepCopyInto(out *FailedProvisionRetryPolicy) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]InstallFailureCategory, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(FailedProvisionRetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionRetryPolicy.
+func (in *FailedProvisionRetryPolicy) DeepCopy() *FailedProvisionRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionRetryPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2410,233 +3557,665 @@ func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateSelection.
-func (in *FeatureGateSelection) DeepCopy() *FeatureGateSelection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateSelection.
+func (in *FeatureGateSelection) DeepCopy() *FeatureGateSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureGateSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureGatesEnabled) DeepCopyInto(out *FeatureGatesEnabled) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGatesEnabled.
+func (in *FeatureGatesEnabled) DeepCopy() *FeatureGatesEnabled {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureGatesEnabled)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.NetworkProjectID != nil {
+		in, out := &in.NetworkProjectID, &out.NetworkProjectID
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
+func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
+func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPDNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
+	*out = *in
+	if in.ZoneName != nil {
+		in, out := &in.ZoneName, &out.ZoneName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
+func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPDNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectConfig) DeepCopyInto(out *GCPPrivateServiceConnectConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.EndpointVPCInventory != nil {
+		in, out := &in.EndpointVPCInventory, &out.EndpointVPCInventory
+		*out = make([]GCPPrivateServiceConnectInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectConfig.
+func (in *GCPPrivateServiceConnectConfig) DeepCopy() *GCPPrivateServiceConnectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectInventory) DeepCopyInto(out *GCPPrivateServiceConnectInventory) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]GCPPrivateServiceConnectSubnet, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectInventory.
+func (in *GCPPrivateServiceConnectInventory) DeepCopy() *GCPPrivateServiceConnectInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPrivateServiceConnectSubnet) DeepCopyInto(out *GCPPrivateServiceConnectSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectSubnet.
+func (in *GCPPrivateServiceConnectSubnet) DeepCopy() *GCPPrivateServiceConnectSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPrivateServiceConnectSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationConfig) DeepCopyInto(out *HibernationConfig) {
+	*out = *in
+	out.ResumeTimeout = in.ResumeTimeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationConfig.
+func (in *HibernationConfig) DeepCopy() *HibernationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSchedule) DeepCopyInto(out *HibernationSchedule) {
+	*out = *in
+	if in.RunningWindows != nil {
+		in, out := &in.RunningWindows, &out.RunningWindows
+		*out = make([]HibernationScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exceptions != nil {
+		in, out := &in.Exceptions, &out.Exceptions
+		*out = make([]HibernationScheduleException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSchedule.
+func (in *HibernationSchedule) DeepCopy() *HibernationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationScheduleException) DeepCopyInto(out *HibernationScheduleException) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationScheduleException.
+func (in *HibernationScheduleException) DeepCopy() *HibernationScheduleException {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationScheduleException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationScheduleStatus) DeepCopyInto(out *HibernationScheduleStatus) {
+	*out = *in
+	if in.LastScheduledResume != nil {
+		in, out := &in.LastScheduledResume, &out.LastScheduledResume
+		*out = (*in).DeepCopy()
+	}
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationScheduleStatus.
+func (in *HibernationScheduleStatus) DeepCopy() *HibernationScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationScheduleWindow) DeepCopyInto(out *HibernationScheduleWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]HibernationScheduleDay, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationScheduleWindow.
+func (in *HibernationScheduleWindow) DeepCopy() *HibernationScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
+func (in *HiveConfig) DeepCopy() *HiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfigCondition) DeepCopyInto(out *HiveConfigCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigCondition.
+func (in *HiveConfigCondition) DeepCopy() *HiveConfigCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureGateSelection)
+	out := new(HiveConfigCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureGatesEnabled) DeepCopyInto(out *FeatureGatesEnabled) {
+func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HiveConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGatesEnabled.
-func (in *FeatureGatesEnabled) DeepCopy() *FeatureGatesEnabled {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
+func (in *HiveConfigList) DeepCopy() *HiveConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureGatesEnabled)
+	out := new(HiveConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
+func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+	if in.ManagedDomains != nil {
+		in, out := &in.ManagedDomains, &out.ManagedDomains
+		*out = make([]ManageDNSConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
+		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.GlobalPullSecretRef != nil {
+		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.NetworkProjectID != nil {
-		in, out := &in.NetworkProjectID, &out.NetworkProjectID
-		*out = new(string)
+	if in.HiveImagePullSecretRef != nil {
+		in, out := &in.HiveImagePullSecretRef, &out.HiveImagePullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	in.Backup.DeepCopyInto(&out.Backup)
+	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
+	in.ServiceProviderCredentialsConfig.DeepCopyInto(&out.ServiceProviderCredentialsConfig)
+	if in.MaintenanceMode != nil {
+		in, out := &in.MaintenanceMode, &out.MaintenanceMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeprovisionsDisabled != nil {
+		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisabledControllers != nil {
+		in, out := &in.DisabledControllers, &out.DisabledControllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllersConfig != nil {
+		in, out := &in.ControllersConfig, &out.ControllersConfig
+		*out = new(ControllersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentConfig != nil {
+		in, out := &in.DeploymentConfig, &out.DeploymentConfig
+		*out = new([]DeploymentConfig)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]DeploymentConfig, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.PrivateLink != nil {
+		in, out := &in.PrivateLink, &out.PrivateLink
+		*out = new(PrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSPrivateLink != nil {
+		in, out := &in.AWSPrivateLink, &out.AWSPrivateLink
+		*out = new(AWSPrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReleaseImageVerificationConfigMapRef != nil {
+		in, out := &in.ReleaseImageVerificationConfigMapRef, &out.ReleaseImageVerificationConfigMapRef
+		*out = new(ReleaseImageVerificationConfigMapReference)
 		**out = **in
 	}
+	out.ArgoCD = in.ArgoCD
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = new(FeatureGateSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsConfig != nil {
+		in, out := &in.MetricsConfig, &out.MetricsConfig
+		*out = new(metricsconfig.MetricsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateGeneration != nil {
+		in, out := &in.CertificateGeneration, &out.CertificateGeneration
+		*out = new(CertificateGenerationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSDelegationAudit != nil {
+		in, out := &in.DNSDelegationAudit, &out.DNSDelegationAudit
+		*out = new(DNSDelegationAuditConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
-func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
+func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterDeprovision)
+	out := new(HiveConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
+func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]HiveConfigCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
-func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
+func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneSpec)
+	out := new(HiveConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
+func (in *HubFederation) DeepCopyInto(out *HubFederation) {
 	*out = *in
-	if in.ZoneName != nil {
-		in, out := &in.ZoneName, &out.ZoneName
-		*out = new(string)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
-func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederation.
+func (in *HubFederation) DeepCopy() *HubFederation {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneStatus)
+	out := new(HubFederation)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HubFederation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPPrivateServiceConnectConfig) DeepCopyInto(out *GCPPrivateServiceConnectConfig) {
+func (in *HubFederationCondition) DeepCopyInto(out *HubFederationCondition) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
-	if in.EndpointVPCInventory != nil {
-		in, out := &in.EndpointVPCInventory, &out.EndpointVPCInventory
-		*out = make([]GCPPrivateServiceConnectInventory, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectConfig.
-func (in *GCPPrivateServiceConnectConfig) DeepCopy() *GCPPrivateServiceConnectConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederationCondition.
+func (in *HubFederationCondition) DeepCopy() *HubFederationCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPPrivateServiceConnectConfig)
+	out := new(HubFederationCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPPrivateServiceConnectInventory) DeepCopyInto(out *GCPPrivateServiceConnectInventory) {
+func (in *HubFederationHubStatus) DeepCopyInto(out *HubFederationHubStatus) {
 	*out = *in
-	if in.Subnets != nil {
-		in, out := &in.Subnets, &out.Subnets
-		*out = make([]GCPPrivateServiceConnectSubnet, len(*in))
-		copy(*out, *in)
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(int32)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectInventory.
-func (in *GCPPrivateServiceConnectInventory) DeepCopy() *GCPPrivateServiceConnectInventory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederationHubStatus.
+func (in *HubFederationHubStatus) DeepCopy() *HubFederationHubStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPPrivateServiceConnectInventory)
+	out := new(HubFederationHubStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPPrivateServiceConnectSubnet) DeepCopyInto(out *GCPPrivateServiceConnectSubnet) {
+func (in *HubFederationList) DeepCopyInto(out *HubFederationList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HubFederation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPrivateServiceConnectSubnet.
-func (in *GCPPrivateServiceConnectSubnet) DeepCopy() *GCPPrivateServiceConnectSubnet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederationList.
+func (in *HubFederationList) DeepCopy() *HubFederationList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPPrivateServiceConnectSubnet)
+	out := new(HubFederationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HubFederationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HibernationConfig) DeepCopyInto(out *HibernationConfig) {
+func (in *HubFederationPeer) DeepCopyInto(out *HubFederationPeer) {
 	*out = *in
-	out.ResumeTimeout = in.ResumeTimeout
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationConfig.
-func (in *HibernationConfig) DeepCopy() *HibernationConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederationPeer.
+func (in *HubFederationPeer) DeepCopy() *HubFederationPeer {
 	if in == nil {
 		return nil
 	}
-	out := new(HibernationConfig)
+	out := new(HubFederationPeer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
+func (in *HubFederationPlacement) DeepCopyInto(out *HubFederationPlacement) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
-func (in *HiveConfig) DeepCopy() *HiveConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederationPlacement.
+func (in *HubFederationPlacement) DeepCopy() *HubFederationPlacement {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfig)
+	out := new(HubFederationPlacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigCondition) DeepCopyInto(out *HiveConfigCondition) {
+func (in *HubFederationSpec) DeepCopyInto(out *HubFederationSpec) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]HubFederationPeer, len(*in))
+		copy(*out, *in)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(HubFederationPlacement)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigCondition.
-func (in *HiveConfigCondition) DeepCopy() *HiveConfigCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederationSpec.
+func (in *HubFederationSpec) DeepCopy() *HubFederationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigCondition)
+	out := new(HubFederationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
+func (in *HubFederationStatus) DeepCopyInto(out *HubFederationStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]HiveConfig, len(*in))
+	if in.Hubs != nil {
+		in, out := &in.Hubs, &out.Hubs
+		*out = make([]HubFederationHubStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]HubFederationCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2644,141 +4223,50 @@ func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
-func (in *HiveConfigList) DeepCopy() *HiveConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubFederationStatus.
+func (in *HubFederationStatus) DeepCopy() *HubFederationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigList)
+	out := new(HubFederationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
+func (in *IBMCloudDNSZoneSpec) DeepCopyInto(out *IBMCloudDNSZoneSpec) {
 	*out = *in
-	if in.ManagedDomains != nil {
-		in, out := &in.ManagedDomains, &out.ManagedDomains
-		*out = make([]ManageDNSConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
-		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.GlobalPullSecretRef != nil {
-		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.HiveImagePullSecretRef != nil {
-		in, out := &in.HiveImagePullSecretRef, &out.HiveImagePullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	in.Backup.DeepCopyInto(&out.Backup)
-	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
-	in.ServiceProviderCredentialsConfig.DeepCopyInto(&out.ServiceProviderCredentialsConfig)
-	if in.MaintenanceMode != nil {
-		in, out := &in.MaintenanceMode, &out.MaintenanceMode
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DeprovisionsDisabled != nil {
-		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DisabledControllers != nil {
-		in, out := &in.DisabledControllers, &out.DisabledControllers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ControllersConfig != nil {
-		in, out := &in.ControllersConfig, &out.ControllersConfig
-		*out = new(ControllersConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DeploymentConfig != nil {
-		in, out := &in.DeploymentConfig, &out.DeploymentConfig
-		*out = new([]DeploymentConfig)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]DeploymentConfig, len(*in))
-			for i := range *in {
-				(*in)[i].DeepCopyInto(&(*out)[i])
-			}
-		}
-	}
-	if in.PrivateLink != nil {
-		in, out := &in.PrivateLink, &out.PrivateLink
-		*out = new(PrivateLinkConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AWSPrivateLink != nil {
-		in, out := &in.AWSPrivateLink, &out.AWSPrivateLink
-		*out = new(AWSPrivateLinkConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ReleaseImageVerificationConfigMapRef != nil {
-		in, out := &in.ReleaseImageVerificationConfigMapRef, &out.ReleaseImageVerificationConfigMapRef
-		*out = new(ReleaseImageVerificationConfigMapReference)
-		**out = **in
-	}
-	out.ArgoCD = in.ArgoCD
-	if in.FeatureGates != nil {
-		in, out := &in.FeatureGates, &out.FeatureGates
-		*out = new(FeatureGateSelection)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.MetricsConfig != nil {
-		in, out := &in.MetricsConfig, &out.MetricsConfig
-		*out = new(metricsconfig.MetricsConfig)
-		(*in).DeepCopyInto(*out)
-	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
-func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCloudDNSZoneSpec.
+func (in *IBMCloudDNSZoneSpec) DeepCopy() *IBMCloudDNSZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigSpec)
+	out := new(IBMCloudDNSZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
+func (in *IBMCloudDNSZoneStatus) DeepCopyInto(out *IBMCloudDNSZoneStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]HiveConfigCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ZoneID != nil {
+		in, out := &in.ZoneID, &out.ZoneID
+		*out = new(string)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
-func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMCloudDNSZoneStatus.
+func (in *IBMCloudDNSZoneStatus) DeepCopy() *IBMCloudDNSZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigStatus)
+	out := new(IBMCloudDNSZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2816,6 +4304,38 @@ func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallFailure) DeepCopyInto(out *InstallFailure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallFailure.
+func (in *InstallFailure) DeepCopy() *InstallFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallFailureCount) DeepCopyInto(out *InstallFailureCount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallFailureCount.
+func (in *InstallFailureCount) DeepCopy() *InstallFailureCount {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallFailureCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstallerManifestPatch) DeepCopyInto(out *InstallerManifestPatch) {
 	*out = *in
@@ -3291,6 +4811,16 @@ func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 		*out = new(ManageDNSAzureConfig)
 		**out = **in
 	}
+	if in.IBMCloud != nil {
+		in, out := &in.IBMCloud, &out.IBMCloud
+		*out = new(ManageDNSIBMCloudConfig)
+		**out = **in
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(ManageDNSRFC2136Config)
+		**out = **in
+	}
 	return
 }
 
@@ -3321,6 +4851,40 @@ func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSIBMCloudConfig) DeepCopyInto(out *ManageDNSIBMCloudConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSIBMCloudConfig.
+func (in *ManageDNSIBMCloudConfig) DeepCopy() *ManageDNSIBMCloudConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSIBMCloudConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSRFC2136Config) DeepCopyInto(out *ManageDNSRFC2136Config) {
+	*out = *in
+	out.TSIGSecretRef = in.TSIGSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSRFC2136Config.
+func (in *ManageDNSRFC2136Config) DeepCopy() *ManageDNSRFC2136Config {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSRFC2136Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManifestSelector) DeepCopyInto(out *ManifestSelector) {
 	*out = *in
@@ -3409,7 +4973,7 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(azure.Platform)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.BareMetal != nil {
 		in, out := &in.BareMetal, &out.BareMetal
@@ -3472,6 +5036,11 @@ func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 		*out = new(aws.PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
 		*out = new(gcp.PlatformStatus)
@@ -3498,6 +5067,11 @@ func (in *PrivateLinkConfig) DeepCopyInto(out *PrivateLinkConfig) {
 		*out = new(GCPPrivateServiceConnectConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzurePrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3569,6 +5143,50 @@ func (in *Provisioning) DeepCopy() *Provisioning {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RFC2136AddressRecord) DeepCopyInto(out *RFC2136AddressRecord) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136AddressRecord.
+func (in *RFC2136AddressRecord) DeepCopy() *RFC2136AddressRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(RFC2136AddressRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RFC2136DNSZoneSpec) DeepCopyInto(out *RFC2136DNSZoneSpec) {
+	*out = *in
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]RFC2136AddressRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RFC2136DNSZoneSpec.
+func (in *RFC2136DNSZoneSpec) DeepCopy() *RFC2136DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RFC2136DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReleaseImageVerificationConfigMapReference) DeepCopyInto(out *ReleaseImageVerificationConfigMapReference) {
 	*out = *in
@@ -3971,6 +5589,22 @@ func (in *SyncObjectPatch) DeepCopy() *SyncObjectPatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceReadinessCheck) DeepCopyInto(out *SyncResourceReadinessCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceReadinessCheck.
+func (in *SyncResourceReadinessCheck) DeepCopy() *SyncResourceReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSet) DeepCopyInto(out *SyncSet) {
 	*out = *in
@@ -4019,6 +5653,16 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 		*out = make([]SecretMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServerSideApply != nil {
+		in, out := &in.ServerSideApply, &out.ServerSideApply
+		*out = new(SyncSetServerSideApply)
+		**out = **in
+	}
+	if in.ReadinessChecks != nil {
+		in, out := &in.ReadinessChecks, &out.ReadinessChecks
+		*out = make([]SyncResourceReadinessCheck, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4109,6 +5753,22 @@ func (in *SyncSetObjectStatus) DeepCopy() *SyncSetObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetServerSideApply) DeepCopyInto(out *SyncSetServerSideApply) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetServerSideApply.
+func (in *SyncSetServerSideApply) DeepCopy() *SyncSetServerSideApply {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetServerSideApply)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetSpec) DeepCopyInto(out *SyncSetSpec) {
 	*out = *in
@@ -16,6 +16,7 @@ import (
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[0].reason`
 // +kubebuilder:printcolumn:name="ControllerReplica",type=string,JSONPath=`.status.controlledByReplica`
 // +kubebuilder:printcolumn:name="Message",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Failed")].message`
+// +kubebuilder:printcolumn:name="BlockingWave",type=integer,priority=1,JSONPath=`.status.blockingWave`
 type ClusterSync struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -45,6 +46,16 @@ type ClusterSyncStatus struct {
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
 
+	// BlockingWave is the sync wave holding back the SyncSets and SelectorSyncSets in later waves, because some of
+	// its SyncSets or SelectorSyncSets have not been applied successfully or are not ready. It is unset when no wave
+	// is holding back others.
+	// +optional
+	BlockingWave *int32 `json:"blockingWave,omitempty"`
+
+	// BlockingMessage names the SyncSets and SelectorSyncSets in the BlockingWave which are not yet complete.
+	// +optional
+	BlockingMessage string `json:"blockingMessage,omitempty"`
+
 	// ControlledByReplica indicates which replica of the hive-clustersync StatefulSet is responsible
 	// for (the CD related to) this clustersync. Note that this value indicates the replica that most
 	// recently handled the ClusterSync. If the hive-clustersync statefulset is scaled up or down, the
@@ -79,6 +90,37 @@ type SyncStatus struct {
 	// FirstSuccessTime is the time when the SyncSet or SelectorSyncSet was first successfully applied to the cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// UnreadyResources is the list of resources whose readiness checks are not passing.
+	// +optional
+	UnreadyResources []SyncResourceReference `json:"unreadyResources,omitempty"`
+
+	// Drift is the list of resources which were found to differ from the SyncSet or SelectorSyncSet when it was last
+	// reapplied. It is only set when the drift policy is Report or Block.
+	// +optional
+	Drift []SyncResourceDrift `json:"drift,omitempty"`
+}
+
+// SyncResourceDrift describes how a resource on the cluster differs from the SyncSet or SelectorSyncSet which applied it.
+type SyncResourceDrift struct {
+	// Resource is the resource which differs.
+	Resource SyncResourceReference `json:"resource"`
+
+	// Fields is the list of paths of the fields which differ, for example "spec.replicas" or "data.key". Only fields
+	// set by the SyncSet or SelectorSyncSet are compared.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+
+	// Missing is true if the resource has been deleted from the cluster.
+	// +optional
+	Missing bool `json:"missing,omitempty"`
+
+	// Reverted is true if the resource was reapplied, overwriting the differences.
+	// +optional
+	Reverted bool `json:"reverted,omitempty"`
+
+	// DetectionTime is the time when the differences were first found.
+	DetectionTime metav1.Time `json:"detectionTime"`
 }
 
 // SyncResourceReference is a reference to a resource that is synced to a cluster via a SyncSet or SelectorSyncSet.
@@ -210,6 +210,11 @@ func (in *ClusterSyncStatus) DeepCopyInto(out *ClusterSyncStatus) {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
 		*out = (*in).DeepCopy()
 	}
+	if in.BlockingWave != nil {
+		in, out := &in.BlockingWave, &out.BlockingWave
+		*out = new(int32)
+		**out = **in
+	}
 	if in.ControlledByReplica != nil {
 		in, out := &in.ControlledByReplica, &out.ControlledByReplica
 		*out = new(int64)
@@ -335,6 +340,29 @@ func (in *FakeClusterInstallStatus) DeepCopy() *FakeClusterInstallStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceDrift) DeepCopyInto(out *SyncResourceDrift) {
+	*out = *in
+	out.Resource = in.Resource
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DetectionTime.DeepCopyInto(&out.DetectionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceDrift.
+func (in *SyncResourceDrift) DeepCopy() *SyncResourceDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncResourceReference) DeepCopyInto(out *SyncResourceReference) {
 	*out = *in
@@ -364,6 +392,18 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
 		*out = (*in).DeepCopy()
 	}
+	if in.UnreadyResources != nil {
+		in, out := &in.UnreadyResources, &out.UnreadyResources
+		*out = make([]SyncResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]SyncResourceDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
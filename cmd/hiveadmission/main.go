@@ -1,6 +1,9 @@
 package main
 
 import (
+	// Embed the time zone database so HibernationSchedule time zones can be validated regardless of the base image.
+	_ "time/tzdata"
+
 	admissionCmd "github.com/openshift/generic-admission-server/pkg/cmd"
 	log "github.com/sirupsen/logrus"
 
@@ -24,12 +27,14 @@ func main() {
 		hivevalidatingwebhooks.NewDNSZoneValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterPoolValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterClaimValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterImageSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterProvisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewMachinePoolValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSelectorSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentCustomizationValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewHubFederationValidatingAdmissionHook(decoder),
 	)
 }
 
@@ -8,6 +8,8 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"time"
+	// Embed the time zone database so HibernationSchedule time zones can be evaluated regardless of the base image.
+	_ "time/tzdata"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -27,21 +29,25 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/argocdregister"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/certificatebundle"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
 	"github.com/openshift/hive/pkg/controller/clusterpool"
 	"github.com/openshift/hive/pkg/controller/clusterpoolnamespace"
 	"github.com/openshift/hive/pkg/controller/clusterprovision"
+	"github.com/openshift/hive/pkg/controller/clusterquota"
 	"github.com/openshift/hive/pkg/controller/clusterrelocate"
 	"github.com/openshift/hive/pkg/controller/clusterstate"
 	"github.com/openshift/hive/pkg/controller/clustersync"
+	"github.com/openshift/hive/pkg/controller/clusterupgrade"
 	"github.com/openshift/hive/pkg/controller/clusterversion"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
 	"github.com/openshift/hive/pkg/controller/dnszone"
 	"github.com/openshift/hive/pkg/controller/fakeclusterinstall"
 	"github.com/openshift/hive/pkg/controller/hibernation"
+	"github.com/openshift/hive/pkg/controller/hubfederation"
 	"github.com/openshift/hive/pkg/controller/machinepool"
 	"github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/controller/privatelink"
@@ -65,14 +71,17 @@ const (
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
+	certificatebundle.ControllerName:    certificatebundle.Add,
 	clusterclaim.ControllerName:         clusterclaim.Add,
 	clusterdeployment.ControllerName:    clusterdeployment.Add,
 	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
 	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
 	clusterprovision.ControllerName:     clusterprovision.Add,
+	clusterquota.ControllerName:         clusterquota.Add,
 	clusterrelocate.ControllerName:      clusterrelocate.Add,
 	clusterstate.ControllerName:         clusterstate.Add,
 	clustersync.ControllerName:          clustersync.Add,
+	clusterupgrade.ControllerName:       clusterupgrade.Add,
 	clusterversion.ControllerName:       clusterversion.Add,
 	controlplanecerts.ControllerName:    controlplanecerts.Add,
 	dnsendpoint.ControllerName:          dnsendpoint.Add,
@@ -86,6 +95,7 @@ var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
 	velerobackup.ControllerName:         velerobackup.Add,
 	clusterpool.ControllerName:          clusterpool.Add,
 	hibernation.ControllerName:          hibernation.Add,
+	hubfederation.ControllerName:        hubfederation.Add,
 	privatelink.ControllerName:          privatelink.Add,
 	awsprivatelink.ControllerName:       awsprivatelink.Add,
 	argocdregister.ControllerName:       argocdregister.Add,
@@ -13,6 +13,7 @@ import (
 	"github.com/openshift/hive/contrib/pkg/clusterpool"
 	"github.com/openshift/hive/contrib/pkg/createcluster"
 	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/contrib/pkg/installlog"
 	"github.com/openshift/hive/contrib/pkg/report"
 	"github.com/openshift/hive/contrib/pkg/testresource"
 	"github.com/openshift/hive/contrib/pkg/verification"
@@ -44,6 +45,7 @@ func newHiveutilCommand() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(deprovision.NewDeprovisionAWSWithTagsCommand())
+	cmd.AddCommand(deprovision.NewAWSOrphanScanCommand())
 	cmd.AddCommand(deprovision.NewDeprovisionCommand())
 	cmd.AddCommand(verification.NewVerifyImportsCommand())
 	cmd.AddCommand(installmanager.NewInstallManagerCommand())
@@ -56,6 +58,7 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(clusterpool.NewClusterPoolCommand())
 	cmd.AddCommand(awsprivatelink.NewAWSPrivateLinkCommand())
+	cmd.AddCommand(installlog.NewInstallLogCommand())
 
 	return cmd
 }
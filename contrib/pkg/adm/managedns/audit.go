@@ -0,0 +1,244 @@
+package managedns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/dnsendpoint"
+	"github.com/openshift/hive/pkg/manageddns"
+)
+
+const auditLongDesc = `
+OVERVIEW
+The audit command reports on the delegation of managed DNS zones.
+
+For every DNSZone linked to its parent domain, the name servers of the parent
+zone and of the DNSZone are queried directly to verify that the delegation
+matches the name servers in the DNSZone status, and that every delegated name
+server answers authoritatively with consistent SOA and NS records.
+
+The root zones of the managed domains in HiveConfig are also listed with the
+cloud credentials configured there, and every delegation to name servers that
+do not serve the subdomain, with no DNSZone in this Hive, is reported as
+dangling. Such delegations are usually left behind by deleted DNSZones.
+
+The command exits with an error if any problem is found.
+`
+
+// AuditOptions is the set of options for auditing managed DNS delegations.
+type AuditOptions struct {
+	Namespace    string
+	Resolvers    []string
+	SkipDangling bool
+	Output       string
+
+	hiveClient client.Client
+}
+
+// auditReport is the report printed by the audit command.
+type auditReport struct {
+	Zones               []zoneAudit          `json:"zones"`
+	DanglingDelegations []danglingDelegation `json:"danglingDelegations,omitempty"`
+}
+
+// zoneAudit is the result of auditing the delegation of a DNSZone.
+type zoneAudit struct {
+	Namespace string                       `json:"namespace"`
+	Name      string                       `json:"name"`
+	Report    *manageddns.DelegationReport `json:"report,omitempty"`
+	Error     string                       `json:"error,omitempty"`
+}
+
+// danglingDelegation is a delegation in the root zone of a managed domain to name servers that do not serve the
+// subdomain.
+type danglingDelegation struct {
+	RootDomain  string   `json:"rootDomain"`
+	Subdomain   string   `json:"subdomain"`
+	NameServers []string `json:"nameServers"`
+}
+
+// NewAuditManageDNSCommand creates a command that audits the delegation of managed DNS zones.
+func NewAuditManageDNSCommand() *cobra.Command {
+	opt := &AuditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit the delegation of managed DNS zones.",
+		Long:  auditLongDesc,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if opt.Output != "text" && opt.Output != "json" {
+				cmd.Usage()
+				log.Fatalf("unsupported output format %q", opt.Output)
+			}
+			hiveClient, err := utils.GetClient("hiveutil-managedns-audit")
+			if err != nil {
+				log.WithError(err).Fatal("error creating hive client")
+			}
+			opt.hiveClient = hiveClient
+
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("audit failed")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only audit the DNSZones in this namespace (defaults to all namespaces)")
+	flags.StringSliceVar(&opt.Resolvers, "resolvers", nil, "Resolvers used to find name servers (defaults to the resolvers in HiveConfig, then the system resolvers)")
+	flags.BoolVar(&opt.SkipDangling, "skip-dangling", false, "Do not look for dangling delegations in the root zones of managed domains")
+	flags.StringVarP(&opt.Output, "output", "o", "text", "Output format: text|json")
+	return cmd
+}
+
+// Run executes the command
+func (o *AuditOptions) Run() error {
+	hc := &hivev1.HiveConfig{}
+	if err := o.hiveClient.Get(context.TODO(), types.NamespacedName{Name: hiveConfigName}, hc); err != nil {
+		return fmt.Errorf("error looking up HiveConfig 'hive': %w", err)
+	}
+
+	resolvers := o.Resolvers
+	if len(resolvers) == 0 && hc.Spec.DNSDelegationAudit != nil {
+		resolvers = hc.Spec.DNSDelegationAudit.Resolvers
+	}
+	auditor, err := manageddns.NewDelegationAuditor(resolvers)
+	if err != nil {
+		return err
+	}
+
+	dnsZones := &hivev1.DNSZoneList{}
+	if err := o.hiveClient.List(context.TODO(), dnsZones); err != nil {
+		return fmt.Errorf("error listing DNSZones: %w", err)
+	}
+
+	report := &auditReport{Zones: []zoneAudit{}}
+	problems := 0
+	claimed := sets.New[string]()
+	for i := range dnsZones.Items {
+		dnsZone := &dnsZones.Items[i]
+		claimed.Insert(strings.ToLower(dnsZone.Spec.Zone))
+		if !dnsZone.Spec.LinkToParentDomain || dnsZone.DeletionTimestamp != nil {
+			continue
+		}
+		if o.Namespace != "" && dnsZone.Namespace != o.Namespace {
+			continue
+		}
+		audit := zoneAudit{Namespace: dnsZone.Namespace, Name: dnsZone.Name}
+		audit.Report, err = auditor.AuditDelegation(dnsZone.Spec.Zone, dnsZone.Status.NameServers)
+		if err != nil {
+			audit.Error = err.Error()
+		}
+		if audit.Report == nil || !audit.Report.Verified() {
+			problems++
+		}
+		report.Zones = append(report.Zones, audit)
+	}
+
+	if !o.SkipDangling {
+		dangling, err := o.findDanglingDelegations(hc, auditor, claimed)
+		if err != nil {
+			return err
+		}
+		report.DanglingDelegations = dangling
+		problems += len(dangling)
+	}
+
+	if err := o.print(report); err != nil {
+		return err
+	}
+	if problems > 0 {
+		return fmt.Errorf("found %d problem(s)", problems)
+	}
+	return nil
+}
+
+// findDanglingDelegations lists the root zones of the managed domains and returns the delegations to name servers
+// that do not serve the subdomain, for subdomains without a DNSZone.
+func (o *AuditOptions) findDanglingDelegations(hc *hivev1.HiveConfig, auditor *manageddns.DelegationAuditor, claimed sets.Set[string]) ([]danglingDelegation, error) {
+	// The name server queries read the managed domain credentials from the hive namespace.
+	if os.Getenv(constants.HiveNamespaceEnvVar) == "" && hc.Spec.TargetNamespace != "" {
+		os.Setenv(constants.HiveNamespaceEnvVar, hc.Spec.TargetNamespace)
+	}
+	logger := log.WithField("command", "adm manage-dns audit")
+
+	var result []danglingDelegation
+	for _, md := range hc.Spec.ManagedDomains {
+		if md.RFC2136 != nil {
+			// Zones in RFC2136 managed domains are not delegated
+			continue
+		}
+		query := dnsendpoint.NewNameServerQuery(o.hiveClient, logger, md)
+		if query == nil {
+			continue
+		}
+		for _, rootDomain := range md.Domains {
+			nameServers, err := query.Get(rootDomain)
+			if err != nil {
+				return nil, fmt.Errorf("error listing the name servers in root domain %s: %w", rootDomain, err)
+			}
+			for _, subdomain := range sets.List(sets.KeySet(nameServers)) {
+				if subdomain == rootDomain || claimed.Has(strings.ToLower(subdomain)) {
+					continue
+				}
+				values := sets.List(nameServers[subdomain])
+				lame, err := auditor.IsLameDelegation(subdomain, values)
+				if err != nil {
+					return nil, err
+				}
+				if lame {
+					result = append(result, danglingDelegation{RootDomain: rootDomain, Subdomain: subdomain, NameServers: values})
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (o *AuditOptions) print(report *auditReport) error {
+	if o.Output == "json" {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tZONE\tPARENT\tRESULT\tPROBLEMS")
+	for _, z := range report.Zones {
+		switch {
+		case z.Report == nil:
+			fmt.Fprintf(w, "%s\t%s\t\t\tFailed\t%s\n", z.Namespace, z.Name, z.Error)
+		case z.Report.Verified():
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\tVerified\t\n", z.Namespace, z.Name, z.Report.Zone, z.Report.ParentZone)
+		default:
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\tMismatch\t%s\n", z.Namespace, z.Name, z.Report.Zone, z.Report.ParentZone,
+				strings.Join(z.Report.Problems, "; "))
+		}
+	}
+	if !o.SkipDangling {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "ROOT DOMAIN\tDANGLING SUBDOMAIN\tNAME SERVERS")
+		for _, d := range report.DanglingDelegations {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", d.RootDomain, d.Subdomain, strings.Join(d.NameServers, ","))
+		}
+	}
+	return w.Flush()
+}
@@ -3,8 +3,10 @@ package managedns
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -49,6 +51,8 @@ const (
 	hiveAdmissionDeployment = "hiveadmission"
 	hiveConfigName          = "hive"
 	waitTime                = time.Minute * 2
+	// cloudRFC2136 selects a name server accepting RFC2136 dynamic updates rather than a cloud provider.
+	cloudRFC2136 = "rfc2136"
 )
 
 // Options is the set of options to generate and apply a new cluster deployment
@@ -59,6 +63,13 @@ type Options struct {
 
 	AzureResourceGroup string
 
+	IBMCloudCISInstanceCRN string
+
+	RFC2136Server        string
+	RFC2136TSIGKeyName   string
+	RFC2136TSIGAlgorithm string
+	RFC2136TSIGSecret    string
+
 	hiveClient client.WithWatch
 }
 
@@ -92,9 +103,14 @@ func NewEnableManageDNSCommand() *cobra.Command {
 	}
 
 	flags := cmd.Flags()
-	flags.StringVar(&opt.Cloud, "cloud", constants.PlatformAWS, "Cloud provider: aws(default)|gcp|azure)")
+	flags.StringVar(&opt.Cloud, "cloud", constants.PlatformAWS, "Cloud provider: aws(default)|gcp|azure|ibmcloud|rfc2136)")
 	flags.StringVar(&opt.CredsFile, "creds-file", "", "Cloud credentials file (defaults vary depending on cloud)")
 	flags.StringVar(&opt.AzureResourceGroup, "azure-resource-group-name", "os4-common", "Azure Resource Group (Only applicable if --cloud azure)")
+	flags.StringVar(&opt.IBMCloudCISInstanceCRN, "ibmcloud-cis-instance-crn", "", "CRN of the IBM Cloud Internet Services instance serving the domains (Only applicable if --cloud ibmcloud)")
+	flags.StringVar(&opt.RFC2136Server, "rfc2136-server", "", "Address of the name server accepting dynamic updates, with an optional port (Only applicable if --cloud rfc2136)")
+	flags.StringVar(&opt.RFC2136TSIGKeyName, "rfc2136-tsig-key-name", "", "Name of the TSIG key used to sign updates (Only applicable if --cloud rfc2136)")
+	flags.StringVar(&opt.RFC2136TSIGAlgorithm, "rfc2136-tsig-algorithm", "hmac-sha256", "Algorithm of the TSIG key (Only applicable if --cloud rfc2136)")
+	flags.StringVar(&opt.RFC2136TSIGSecret, "rfc2136-tsig-secret", "", "Base64 encoded secret of the TSIG key (Only applicable if --cloud rfc2136)")
 	return cmd
 }
 
@@ -110,6 +126,20 @@ func (o *Options) Complete(cmd *cobra.Command, args []string) error {
 
 // Validate ensures that option values make sense
 func (o *Options) Validate(cmd *cobra.Command) error {
+	switch o.Cloud {
+	case constants.PlatformIBMCloud:
+		if o.IBMCloudCISInstanceCRN == "" {
+			cmd.Usage()
+			log.Info("--ibmcloud-cis-instance-crn is required when using --cloud ibmcloud")
+			return fmt.Errorf("missing IBM Cloud CIS instance CRN")
+		}
+	case cloudRFC2136:
+		if o.RFC2136Server == "" || o.RFC2136TSIGKeyName == "" || o.RFC2136TSIGSecret == "" {
+			cmd.Usage()
+			log.Info("--rfc2136-server, --rfc2136-tsig-key-name and --rfc2136-tsig-secret are required when using --cloud rfc2136")
+			return fmt.Errorf("missing RFC2136 name server settings")
+		}
+	}
 	return nil
 }
 
@@ -162,6 +192,23 @@ func (o *Options) Run(args []string) error {
 			CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecret.Name},
 			ResourceGroupName:    o.AzureResourceGroup,
 		}
+	case constants.PlatformIBMCloud:
+		credsSecret, err = o.generateIBMCloudCredentialsSecret()
+		if err != nil {
+			log.WithError(err).Fatal("error generating manageDNS credentials secret")
+		}
+		dnsConf.IBMCloud = &hivev1.ManageDNSIBMCloudConfig{
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: credsSecret.Name},
+			CISInstanceCRN:       o.IBMCloudCISInstanceCRN,
+		}
+	case cloudRFC2136:
+		credsSecret = o.generateRFC2136TSIGSecret()
+		dnsConf.RFC2136 = &hivev1.ManageDNSRFC2136Config{
+			Server:        o.RFC2136Server,
+			TSIGKeyName:   o.RFC2136TSIGKeyName,
+			TSIGAlgorithm: o.RFC2136TSIGAlgorithm,
+			TSIGSecretRef: corev1.LocalObjectReference{Name: credsSecret.Name},
+		}
 	default:
 		log.WithField("cloud", o.Cloud).Fatal("unsupported cloud")
 	}
@@ -369,6 +416,49 @@ func (o *Options) generateAzureCredentialsSecret() (*corev1.Secret, error) {
 	}, nil
 }
 
+func (o *Options) generateIBMCloudCredentialsSecret() (*corev1.Secret, error) {
+	apiKey := os.Getenv(constants.IBMCloudAPIKeyEnvVar)
+	if o.CredsFile != "" {
+		contents, err := os.ReadFile(o.CredsFile)
+		if err != nil {
+			return nil, err
+		}
+		apiKey = strings.TrimSpace(string(contents))
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s env var or --creds-file is required when using --cloud=%q", constants.IBMCloudAPIKeyEnvVar, constants.PlatformIBMCloud)
+	}
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("ibmcloud-dns-creds-%s", uuid.New().String()[:5]),
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			constants.IBMCloudAPIKeySecretKey: apiKey,
+		},
+	}, nil
+}
+
+func (o *Options) generateRFC2136TSIGSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("rfc2136-dns-tsig-%s", uuid.New().String()[:5]),
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			constants.RFC2136TSIGSecretKey: o.RFC2136TSIGSecret,
+		},
+	}
+}
+
 func (o *Options) getResourceHelper() (resource.Helper, error) {
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -14,5 +14,6 @@ func NewManageDNSCommand() *cobra.Command {
 		},
 	}
 	cmd.AddCommand(NewEnableManageDNSCommand())
+	cmd.AddCommand(NewAuditManageDNSCommand())
 	return cmd
 }
@@ -0,0 +1,302 @@
+package deprovision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	awsv2config "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/costmodel"
+)
+
+// clusterTagPrefix prefixes the key of the tag the installer sets on the AWS resources of a cluster, followed by the
+// cluster's infraID.
+const clusterTagPrefix = "kubernetes.io/cluster/"
+
+// AWSOrphanScanOptions is the set of options to scan for orphaned AWS resources
+type AWSOrphanScanOptions struct {
+	logLevel string
+	// Region is the AWS region to scan.
+	Region string
+	// HiveNamespace is the namespace of the hive-cost-model configmap.
+	HiveNamespace string
+	// MinAge excludes infraIDs whose oldest instance or volume is younger, e.g. clusters still being installed.
+	MinAge time.Duration
+	// IncludeUnknownAge includes infraIDs with no instances or volumes, whose age is unknown. These are excluded by
+	// default, as they may belong to a cluster whose installation has only just started.
+	IncludeUnknownAge bool
+	// Output is the output format: text or json.
+	Output string
+}
+
+// orphan is a set of AWS resources owned by an infraID which no cluster on the hub has.
+type orphan struct {
+	InfraID   string   `json:"infraID"`
+	Resources []string `json:"resources"`
+	// Instances is the number of running or stopped instances.
+	Instances int `json:"instances"`
+	// CreationTime is the creation time of the oldest instance or volume, if there are any.
+	CreationTime *time.Time `json:"creationTime,omitempty"`
+	// HourlyCost is the estimated hourly cost of the running instances.
+	HourlyCost float64 `json:"hourlyCost"`
+	// Unpriced are the instance types missing from the cost model, which are not part of the cost.
+	Unpriced []string `json:"unpricedInstanceTypes,omitempty"`
+}
+
+// NewAWSOrphanScanCommand is the entrypoint to create the 'aws-orphan-scan' subcommand
+func NewAWSOrphanScanCommand() *cobra.Command {
+	opt := &AWSOrphanScanOptions{}
+	cmd := &cobra.Command{
+		Use:   "aws-orphan-scan",
+		Short: "Find AWS resources of clusters which are no longer managed by the Hive hub",
+		Long: `Find AWS resources tagged kubernetes.io/cluster/<infraID>=owned whose infraID does not belong to any
+ClusterDeployment, ClusterProvision or ClusterDeprovision on the Hive hub, and report them by infraID with their age
+and estimated hourly cost.
+
+Nothing is deleted. Once an orphan has been reviewed, delete its resources with:
+
+  hiveutil aws-tag-deprovision --region REGION kubernetes.io/cluster/<infraID>=owned`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			c, err := utils.GetClient("hiveutil-aws-orphan-scan")
+			if err != nil {
+				log.WithError(err).Fatal("failed to create kube client")
+			}
+
+			if err := opt.Run(c); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.Region, "region", "us-east-1", "AWS region to scan")
+	flags.StringVar(&opt.HiveNamespace, "hive-namespace", constants.DefaultHiveNamespace, "namespace of the hive-cost-model configmap")
+	flags.DurationVar(&opt.MinAge, "min-age", time.Hour, "only report infraIDs whose oldest instance or volume is at least this old")
+	flags.BoolVar(&opt.IncludeUnknownAge, "include-unknown-age", false, "also report infraIDs with no instances or volumes, whose age is unknown")
+	flags.StringVarP(&opt.Output, "output", "o", "text", "output format: text|json")
+	return cmd
+}
+
+// Validate ensures that option values make sense
+func (o *AWSOrphanScanOptions) Validate(cmd *cobra.Command) error {
+	if o.Output != "text" && o.Output != "json" {
+		cmd.Usage()
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	return nil
+}
+
+// Run scans the region for orphaned resources and prints them.
+func (o *AWSOrphanScanOptions) Run(c client.Client) error {
+	logger, err := utils.NewLogger(o.logLevel)
+	if err != nil {
+		return err
+	}
+
+	known, err := knownInfraIDs(c)
+	if err != nil {
+		return err
+	}
+	model, err := costmodel.Load(context.Background(), c, o.HiveNamespace)
+	if err != nil {
+		logger.WithError(err).Warn("could not load the cost model, costs will not be estimated")
+	}
+
+	awsSession, err := session.NewSession(awssdk.NewConfig().WithRegion(o.Region))
+	if err != nil {
+		return err
+	}
+	tagClient := resourcegroupstaggingapi.New(awsSession)
+	awsConfig, err := awsv2config.LoadDefaultConfig(context.Background(), awsv2config.WithRegion(o.Region))
+	if err != nil {
+		return err
+	}
+	ec2Client := ec2.NewFromConfig(awsConfig)
+
+	var infraIDs []string
+	if err := tagClient.GetTagKeysPagesWithContext(context.Background(), &resourcegroupstaggingapi.GetTagKeysInput{},
+		func(page *resourcegroupstaggingapi.GetTagKeysOutput, lastPage bool) bool {
+			for _, key := range page.TagKeys {
+				if infraID := strings.TrimPrefix(awssdk.StringValue(key), clusterTagPrefix); infraID != awssdk.StringValue(key) && !known.Has(infraID) {
+					infraIDs = append(infraIDs, infraID)
+				}
+			}
+			return !lastPage
+		}); err != nil {
+		return fmt.Errorf("failed to list tag keys: %w", err)
+	}
+	sort.Strings(infraIDs)
+
+	var orphans []*orphan
+	for _, infraID := range infraIDs {
+		l := logger.WithField("infraID", infraID)
+		l.Debug("scanning resources of unknown infraID")
+		orphan, err := scanOrphan(tagClient, ec2Client, infraID, model)
+		if err != nil {
+			l.WithError(err).Warn("could not scan resources")
+			continue
+		}
+		if len(orphan.Resources) == 0 {
+			// The tag key outlives the resources for a while
+			continue
+		}
+		if !o.oldEnough(orphan, time.Now()) {
+			l.Debug("skipping recently created resources, or resources of unknown age")
+			continue
+		}
+		orphans = append(orphans, orphan)
+	}
+
+	if o.Output == "json" {
+		out, err := json.MarshalIndent(map[string]any{"region": o.Region, "items": orphans}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "INFRAID\tRESOURCES\tINSTANCES\tAGE\tHOURLY\tUNPRICED INSTANCE TYPES")
+	for _, orphan := range orphans {
+		age := "unknown"
+		if orphan.CreationTime != nil {
+			age = time.Since(*orphan.CreationTime).Round(time.Hour).String()
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%.2f\t%s\n", orphan.InfraID, len(orphan.Resources), orphan.Instances, age,
+			orphan.HourlyCost, strings.Join(orphan.Unpriced, ","))
+	}
+	return w.Flush()
+}
+
+// oldEnough returns true if the orphan's resources are at least MinAge old, or their age is unknown and
+// IncludeUnknownAge is set.
+func (o *AWSOrphanScanOptions) oldEnough(orphan *orphan, now time.Time) bool {
+	if orphan.CreationTime == nil {
+		return o.IncludeUnknownAge
+	}
+	return now.Sub(*orphan.CreationTime) >= o.MinAge
+}
+
+// knownInfraIDs returns the infraIDs of the ClusterDeployments, ClusterProvisions and ClusterDeprovisions on the hub.
+// ClusterProvisions cover the clusters being installed, whose ClusterDeployment has no infraID yet.
+func knownInfraIDs(c client.Client) (sets.Set[string], error) {
+	known := sets.New[string]()
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList); err != nil {
+		return nil, err
+	}
+	for _, cd := range cdList.Items {
+		if cd.Spec.ClusterMetadata != nil {
+			known.Insert(cd.Spec.ClusterMetadata.InfraID)
+		}
+	}
+	provisionList := &hivev1.ClusterProvisionList{}
+	if err := c.List(context.Background(), provisionList); err != nil {
+		return nil, err
+	}
+	for _, provision := range provisionList.Items {
+		if provision.Spec.InfraID != nil {
+			known.Insert(*provision.Spec.InfraID)
+		}
+	}
+	cdpList := &hivev1.ClusterDeprovisionList{}
+	if err := c.List(context.Background(), cdpList); err != nil {
+		return nil, err
+	}
+	for _, cdp := range cdpList.Items {
+		known.Insert(cdp.Spec.InfraID)
+	}
+	return known, nil
+}
+
+// scanOrphan finds the resources tagged for the infraID, and estimates their age and cost from the instances and
+// volumes among them.
+func scanOrphan(tagClient *resourcegroupstaggingapi.ResourceGroupsTaggingAPI, ec2Client *ec2.Client, infraID string, model *costmodel.Model) (*orphan, error) {
+	o := &orphan{InfraID: infraID}
+	key := clusterTagPrefix + infraID
+	if err := tagClient.GetResourcesPagesWithContext(context.Background(), &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{{Key: awssdk.String(key), Values: []*string{awssdk.String("owned")}}},
+	}, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, resource := range page.ResourceTagMappingList {
+			o.Resources = append(o.Resources, awssdk.StringValue(resource.ResourceARN))
+		}
+		return !lastPage
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list tagged resources: %w", err)
+	}
+	sort.Strings(o.Resources)
+
+	prices := map[string]float64{}
+	if model != nil && model.Platforms[constants.PlatformAWS] != nil {
+		prices = model.Platforms[constants.PlatformAWS].InstanceTypes
+	}
+	observe := func(created *time.Time) {
+		if created != nil && (o.CreationTime == nil || created.Before(*o.CreationTime)) {
+			o.CreationTime = created
+		}
+	}
+	tagFilter := []ec2types.Filter{{Name: awssdk.String("tag:" + key), Values: []string{"owned"}}}
+	instances := ec2.NewDescribeInstancesPaginator(ec2Client, &ec2.DescribeInstancesInput{Filters: tagFilter})
+	for instances.HasMorePages() {
+		page, err := instances.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instances: %w", err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.State == nil {
+					continue
+				}
+				state := instance.State.Name
+				if state == ec2types.InstanceStateNameTerminated || state == ec2types.InstanceStateNameShuttingDown {
+					continue
+				}
+				o.Instances++
+				observe(instance.LaunchTime)
+				if state != ec2types.InstanceStateNameRunning {
+					continue
+				}
+				instanceType := string(instance.InstanceType)
+				if price, ok := prices[instanceType]; ok {
+					o.HourlyCost += price
+				} else if !sets.New(o.Unpriced...).Has(instanceType) {
+					o.Unpriced = append(o.Unpriced, instanceType)
+				}
+			}
+		}
+	}
+	volumes := ec2.NewDescribeVolumesPaginator(ec2Client, &ec2.DescribeVolumesInput{Filters: tagFilter})
+	for volumes.HasMorePages() {
+		page, err := volumes.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe volumes: %w", err)
+		}
+		for _, volume := range page.Volumes {
+			observe(volume.CreateTime)
+		}
+	}
+	return o, nil
+}
@@ -0,0 +1,90 @@
+package deprovision
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+func TestKnownInfraIDs(t *testing.T) {
+	existing := []runtime.Object{
+		&hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "installed"},
+			Spec: hivev1.ClusterDeploymentSpec{
+				ClusterMetadata: &hivev1.ClusterMetadata{InfraID: "installed-abcde"},
+			},
+		},
+		&hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "installing"},
+		},
+		&hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "installing-0-fghij"},
+			Spec:       hivev1.ClusterProvisionSpec{InfraID: ptr.To("installing-fghij")},
+		},
+		&hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "installing-1-klmno"},
+		},
+		&hivev1.ClusterDeprovision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns3", Name: "deleting"},
+			Spec:       hivev1.ClusterDeprovisionSpec{InfraID: "deleting-pqrst"},
+		},
+	}
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+
+	known, err := knownInfraIDs(c)
+	require.NoError(t, err)
+	assert.Equal(t, sets.New("installed-abcde", "installing-fghij", "deleting-pqrst"), known)
+}
+
+func TestOrphanOldEnough(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name              string
+		creationTime      *time.Time
+		includeUnknownAge bool
+		expected          bool
+	}{
+		{
+			name:         "old",
+			creationTime: ptr.To(now.Add(-2 * time.Hour)),
+			expected:     true,
+		},
+		{
+			name:         "exactly min age",
+			creationTime: ptr.To(now.Add(-time.Hour)),
+			expected:     true,
+		},
+		{
+			name:         "young",
+			creationTime: ptr.To(now.Add(-time.Minute)),
+		},
+		{
+			name: "unknown age",
+		},
+		{
+			name:              "unknown age included",
+			includeUnknownAge: true,
+			expected:          true,
+		},
+		{
+			name:              "young with unknown age included",
+			creationTime:      ptr.To(now.Add(-time.Minute)),
+			includeUnknownAge: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := &AWSOrphanScanOptions{MinAge: time.Hour, IncludeUnknownAge: test.includeUnknownAge}
+			assert.Equal(t, test.expected, o.oldEnough(&orphan{InfraID: "test-infra-id", CreationTime: test.creationTime}, now))
+		})
+	}
+}
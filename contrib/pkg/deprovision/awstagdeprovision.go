@@ -1,11 +1,18 @@
 package deprovision
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openshift/hive/contrib/pkg/utils"
 	awscreds "github.com/openshift/hive/pkg/creds/aws"
@@ -17,19 +24,34 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	opt := &aws.ClusterUninstaller{}
 	var credsDir string
 	var logLevel string
+	var dryRun bool
+	var clusterDeprovisionName string
 	cmd := &cobra.Command{
 		Use:   "aws-tag-deprovision KEY=VALUE ...",
 		Short: "Deprovision AWS assets (as created by openshift-installer) with the given tag(s)",
 		Long:  "Deprovision AWS assets (as created by openshift-installer) with the given tag(s).  A resource matches the filter if any of the key/value pairs are in its tags.",
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := completeAWSUninstaller(opt, logLevel, args); err != nil {
+			logger, err := utils.NewLogger(logLevel)
+			if err != nil {
 				log.WithError(err).Error("Cannot complete command")
 				return
 			}
+			if err := completeAWSUninstaller(opt, logger, args); err != nil {
+				log.WithError(err).Error("Cannot complete command")
+				return
+			}
+
+			if dryRun {
+				resources, err := findAWSResources(opt)
+				if err != nil {
+					log.WithError(err).Fatal("Failed to find resources")
+				}
+				printResources(resources)
+				return
+			}
 
 			log.Infof("Running destroyer with ClusterUninstall %#v", *opt)
-			// ClusterQuota stomped in return
-			if _, err := opt.Run(); err != nil {
+			if err := runDestroyer(opt, clusterDeprovisionName, logger); err != nil {
 				log.WithError(err).Fatal("Runtime error")
 			}
 		},
@@ -40,10 +62,37 @@ func NewDeprovisionAWSWithTagsCommand() *cobra.Command {
 	flags.StringVar(&credsDir, "creds-dir", "", "directory of the creds. Changes in the creds will cause the program to terminate")
 	flags.StringVar(&opt.HostedZoneRole, "hosted-zone-role", "", "the role to assume when performing operations on a hosted zone owned by another account.")
 	flags.StringVar(&opt.ClusterDomain, "cluster-domain", "", "the parent DNS domain of the cluster (e.g. the thing after `api.`).")
+	flags.StringVar(&clusterDeprovisionName, "clusterdeprovision-name", "", "name of a ClusterDeprovision in the current namespace to report the progress of the deprovision to")
+	flags.BoolVar(&dryRun, "dry-run", false, "list the tagged resources and IAM roles which would be deleted, without deleting them")
 	return cmd
 }
 
-func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []string) error {
+// findAWSResources returns the ARNs of the tagged resources and IAM roles the uninstaller would delete. Like the
+// destroyer, it also searches us-east-1 for global resources. Resources which cannot be tagged, such as IAM instance
+// profiles and the records in a shared hosted zone, are not included.
+func findAWSResources(o *aws.ClusterUninstaller) ([]string, error) {
+	awsSession, err := session.NewSession(awssdk.NewConfig().WithRegion(o.Region))
+	if err != nil {
+		return nil, err
+	}
+	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{resourcegroupstaggingapi.New(awsSession)}
+	if partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), o.Region); ok &&
+		partition.ID() == endpoints.AwsPartitionID && o.Region != endpoints.UsEast1RegionID {
+		tagClients = append(tagClients, resourcegroupstaggingapi.New(awsSession, awssdk.NewConfig().WithRegion(endpoints.UsEast1RegionID)))
+	}
+	iamRoleSearch := &aws.IamRoleSearch{
+		Client:  iam.New(awsSession),
+		Filters: o.Filters,
+		Logger:  o.Logger,
+	}
+	resources, _, err := aws.FindTaggedResourcesToDelete(context.Background(), o.Logger, tagClients, o.Filters, iamRoleSearch, nil, sets.New[string]())
+	if err != nil {
+		return nil, err
+	}
+	return sets.List(resources), nil
+}
+
+func completeAWSUninstaller(o *aws.ClusterUninstaller, logger *log.Entry, args []string) error {
 
 	for _, arg := range args {
 		filter := aws.Filter{}
@@ -54,10 +103,7 @@ func completeAWSUninstaller(o *aws.ClusterUninstaller, logLevel string, args []s
 		o.Filters = append(o.Filters, filter)
 	}
 
-	var err error
-	if o.Logger, err = utils.NewLogger(logLevel); err != nil {
-		return err
-	}
+	o.Logger = logger
 
 	client, err := utils.GetClient("hiveutil-aws-tag-deprovision")
 	if err != nil {
@@ -1,6 +1,11 @@
 package deprovision
 
 import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -20,6 +25,7 @@ type AzureOptions struct {
 	cloudName                   string
 	resourceGroupName           string
 	baseDomainResourceGroupName string
+	dryRun                      bool
 }
 
 // NewDeprovisionAzureCommand is the entrypoint to create the azure deprovision subcommand
@@ -32,7 +38,12 @@ func NewDeprovisionAzureCommand(logLevel string) *cobra.Command {
 		Short: "Deprovision Azure assets (as created by openshift-installer)",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			uninstaller, err := opt.completeAzureUninstaller(args)
+			logger, err := utils.NewLogger(opt.logLevel)
+			if err != nil {
+				log.WithError(err).Error("Cannot complete command")
+				return
+			}
+			uninstaller, err := opt.completeAzureUninstaller(logger, args)
 			if err != nil {
 				log.WithError(err).Error("Cannot complete command")
 				return
@@ -41,8 +52,17 @@ func NewDeprovisionAzureCommand(logLevel string) *cobra.Command {
 				log.WithError(err).Fatal("Failed validating Azure credentials")
 			}
 
-			// ClusterQuota stomped in return
-			if _, err := uninstaller.Run(); err != nil {
+			if opt.dryRun {
+				resources, err := findAzureResources(uninstaller.(*azure.ClusterUninstaller))
+				if err != nil {
+					log.WithError(err).Fatal("Failed to find resources")
+				}
+				printResources(resources)
+				return
+			}
+
+			clusterDeprovisionName, _ := cmd.Flags().GetString("clusterdeprovision-name")
+			if err := runDestroyer(uninstaller, clusterDeprovisionName, logger); err != nil {
 				log.WithError(err).Fatal("Runtime error")
 			}
 		},
@@ -51,9 +71,39 @@ func NewDeprovisionAzureCommand(logLevel string) *cobra.Command {
 	flags.StringVar(&opt.cloudName, "azure-cloud-name", installertypesazure.PublicCloud.Name(), "The name of the Azure cloud environment used to configure the Azure SDK")
 	flags.StringVar(&opt.resourceGroupName, "azure-resource-group-name", "", "The name of the custom Azure resource group in which the cluster was created when not using the default installer-created resource group")
 	flags.StringVar(&opt.baseDomainResourceGroupName, "azure-base-domain-resource-group-name", "", "The name of the custom Azure resource group in which the cluster's DNS records were created when not using the default installer-created resource group or custom resource group")
+	flags.BoolVar(&opt.dryRun, "dry-run", false, "List the resources in the cluster's resource group which would be deleted, without deleting them")
 	return cmd
 }
 
+// findAzureResources returns the IDs of the resources in the cluster's resource group, which the uninstaller deletes
+// along with the group. The DNS records in the base domain resource group and the cluster's service principals are
+// not included.
+func findAzureResources(uninstaller *azure.ClusterUninstaller) ([]string, error) {
+	resourceGroup := uninstaller.ResourceGroupName
+	if resourceGroup == "" {
+		resourceGroup = uninstaller.InfraID + "-rg"
+	}
+	client, err := armresources.NewClient(uninstaller.Session.Credentials.SubscriptionID, uninstaller.Session.TokenCreds,
+		&arm.ClientOptions{ClientOptions: policy.ClientOptions{Cloud: uninstaller.Session.CloudConfig}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create resources client")
+	}
+	var resources []string
+	pager := client.NewListByResourceGroupPager(resourceGroup, nil)
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list resources in resource group %s", resourceGroup)
+		}
+		for _, resource := range page.Value {
+			if resource.ID != nil {
+				resources = append(resources, *resource.ID)
+			}
+		}
+	}
+	return resources, nil
+}
+
 func validate() error {
 	_, err := azurecreds.GetCreds("")
 	if err != nil {
@@ -63,13 +113,7 @@ func validate() error {
 	return nil
 }
 
-func (opt *AzureOptions) completeAzureUninstaller(args []string) (providers.Destroyer, error) {
-
-	logger, err := utils.NewLogger(opt.logLevel)
-	if err != nil {
-		return nil, err
-	}
-
+func (opt *AzureOptions) completeAzureUninstaller(logger *log.Entry, args []string) (providers.Destroyer, error) {
 	client, err := utils.GetClient("hiveutil-deprovision-azure")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get client")
@@ -19,6 +19,7 @@ import (
 func NewDeprovisionCommand() *cobra.Command {
 	var credsDir string
 	var mjSecretName string
+	var clusterDeprovisionName string
 	var logLevel string
 	cmd := &cobra.Command{
 		Use:   "deprovision",
@@ -78,9 +79,7 @@ To run the generic destroyer, use the --metadata-json-secret-name parameter.`,
 				logger.WithError(err).Fatal("failed to create destroyer")
 			}
 
-			// Ignore quota return
-			_, err = destroyer.Run()
-			if err != nil {
+			if err := runDestroyer(destroyer, clusterDeprovisionName, logger); err != nil {
 				logger.WithError(err).Fatal("destroyer returned an error")
 			}
 		},
@@ -90,6 +89,7 @@ To run the generic destroyer, use the --metadata-json-secret-name parameter.`,
 	flags.StringVar(&credsDir, "creds-dir", "", "directory of the creds. Changes in the creds will cause the program to terminate")
 	// TODO: Make this more useful to CLI users by accepting a path to a metadata.json file in the file system
 	flags.StringVar(&mjSecretName, "metadata-json-secret-name", "", "name of a Secret in the current namespace containing `metadata.json` from the installer")
+	flags.StringVar(&clusterDeprovisionName, "clusterdeprovision-name", "", "name of a ClusterDeprovision in the current namespace to report the progress of the deprovision to")
 	flags.StringVar(&logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 
 	// Legacy destroyers
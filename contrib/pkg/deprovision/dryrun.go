@@ -0,0 +1,68 @@
+package deprovision
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// addUnsupportedDryRunFlag adds a --dry-run flag to the deprovision command of a platform which cannot yet list the
+// resources it would delete. Passing it makes the command fail before deleting anything. Dry runs are supported by
+// aws-tag-deprovision and azure.
+func addUnsupportedDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "Not supported for this platform: fail without deleting anything. Dry runs are supported by aws-tag-deprovision and azure")
+	cmd.PreRun = func(cmd *cobra.Command, args []string) {
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			log.Fatalf("--dry-run is not supported by %s deprovision; it is supported by aws-tag-deprovision and azure", cmd.Name())
+		}
+	}
+}
+
+// printResources prints the resources a dry run found, followed by the number of resources of each type.
+// Resources are identified by an ARN or an Azure resource ID, and typed by the part before the resource name.
+func printResources(resources []string) {
+	sort.Strings(resources)
+	counts := map[string]int{}
+	for _, resource := range resources {
+		fmt.Println(resource)
+		counts[resourceType(resource)]++
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tCOUNT")
+	for _, t := range types {
+		fmt.Fprintf(w, "%s\t%d\n", t, counts[t])
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\n", len(resources))
+	w.Flush()
+}
+
+// resourceType returns the type of the resource, e.g. "ec2:instance" for an ARN or "Microsoft.Compute/virtualMachines"
+// for an Azure resource ID.
+func resourceType(resource string) string {
+	if parsed, err := arn.Parse(resource); err == nil {
+		if i := strings.IndexAny(parsed.Resource, "/:"); i > 0 {
+			return parsed.Service + ":" + parsed.Resource[:i]
+		}
+		return parsed.Service
+	}
+	if i := strings.LastIndex(resource, "/providers/"); i >= 0 {
+		parts := strings.Split(resource[i+len("/providers/"):], "/")
+		if len(parts) >= 2 {
+			return parts[0] + "/" + parts[1]
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,67 @@
+package deprovision
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceType(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		expected string
+	}{
+		{
+			name:     "arn with slash",
+			resource: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0",
+			expected: "ec2:instance",
+		},
+		{
+			name:     "arn with colon",
+			resource: "arn:aws:logs:us-east-1:123456789012:log-group:/aws/test",
+			expected: "logs:log-group",
+		},
+		{
+			name:     "arn without resource type",
+			resource: "arn:aws:s3:::test-bucket",
+			expected: "s3",
+		},
+		{
+			name:     "azure resource",
+			resource: "/subscriptions/sub/resourceGroups/test-infra-id-rg/providers/Microsoft.Compute/virtualMachines/test-infra-id-master-0",
+			expected: "Microsoft.Compute/virtualMachines",
+		},
+		{
+			name:     "azure child resource",
+			resource: "/subscriptions/sub/resourceGroups/test-infra-id-rg/providers/Microsoft.Network/privateDnsZones/example.com/virtualNetworkLinks/link",
+			expected: "Microsoft.Network/privateDnsZones",
+		},
+		{
+			name:     "unknown",
+			resource: "test-infra-id",
+			expected: "unknown",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, resourceType(test.resource))
+		})
+	}
+}
+
+func TestUnsupportedDryRunFlag(t *testing.T) {
+	for _, cmd := range []*cobra.Command{
+		NewDeprovisionGCPCommand("info"),
+		NewDeprovisionIBMCloudCommand("info"),
+		NewDeprovisionOpenStackCommand("info"),
+		NewDeprovisionvSphereCommand("info"),
+		NewDeprovisionNutanixCommand("info"),
+	} {
+		t.Run(cmd.Name(), func(t *testing.T) {
+			assert.NotNil(t, cmd.Flags().Lookup("dry-run"), "expected a dry-run flag")
+			assert.NotNil(t, cmd.PreRun, "expected dry runs to be refused before running")
+		})
+	}
+}
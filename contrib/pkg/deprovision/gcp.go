@@ -18,11 +18,12 @@ import (
 
 // gcpOptions is the set of options to deprovision a GCP cluster
 type gcpOptions struct {
-	logLevel         string
-	infraID          string
-	region           string
-	projectID        string
-	networkProjectID string
+	logLevel               string
+	infraID                string
+	region                 string
+	projectID              string
+	networkProjectID       string
+	clusterDeprovisionName string
 }
 
 // NewDeprovisionGCPCommand is the entrypoint to create the GCP deprovision subcommand
@@ -49,12 +50,14 @@ func NewDeprovisionGCPCommand(logLevel string) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&opt.region, "region", "", "GCP region where the cluster is installed")
 	flags.StringVar(&opt.networkProjectID, "network-project-id", "", "For shared VPC setups")
+	addUnsupportedDryRunFlag(cmd)
 	return cmd
 }
 
 // Complete finishes parsing arguments for the command
 func (o *gcpOptions) Complete(cmd *cobra.Command, args []string) error {
 	o.infraID = args[0]
+	o.clusterDeprovisionName, _ = cmd.Flags().GetString("clusterdeprovision-name")
 
 	client, err := utils.GetClient("hiveutil-deprovision-gcp")
 	if err != nil {
@@ -108,7 +111,5 @@ func (o *gcpOptions) Run() error {
 		return err
 	}
 
-	// ClusterQuota stomped in return
-	_, err = destroyer.Run()
-	return err
+	return runDestroyer(destroyer, o.clusterDeprovisionName, logger)
 }
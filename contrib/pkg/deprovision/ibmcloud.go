@@ -20,13 +20,14 @@ import (
 
 // ibmCloudDeprovisionOptions is the set of options to deprovision an IBM Cloud cluster
 type ibmCloudDeprovisionOptions struct {
-	accountID      string
-	baseDomain     string
-	cisInstanceCRN string
-	clusterName    string
-	infraID        string
-	logLevel       string
-	region         string
+	accountID              string
+	baseDomain             string
+	cisInstanceCRN         string
+	clusterName            string
+	infraID                string
+	logLevel               string
+	region                 string
+	clusterDeprovisionName string
 }
 
 // NewDeprovisionIBMCloudCommand is the entrypoint to create the IBM Cloud deprovision subcommand
@@ -58,12 +59,14 @@ func NewDeprovisionIBMCloudCommand(logLevel string) *cobra.Command {
 	flags.StringVar(&opt.clusterName, "cluster-name", "", "cluster's name")
 	flags.StringVar(&opt.region, "region", "", "region in which to deprovision cluster")
 
+	addUnsupportedDryRunFlag(cmd)
 	return cmd
 }
 
 // Complete finishes parsing arguments for the command
 func (o *ibmCloudDeprovisionOptions) Complete(cmd *cobra.Command, args []string) error {
 	o.infraID = args[0]
+	o.clusterDeprovisionName, _ = cmd.Flags().GetString("clusterdeprovision-name")
 
 	client, err := utils.GetClient("hiveutil-deprovision-ibmcloud")
 	if err != nil {
@@ -141,7 +144,5 @@ func (o *ibmCloudDeprovisionOptions) Run() error {
 		return err
 	}
 
-	// ClusterQuota stomped in return
-	_, err = destroyer.Run()
-	return err
+	return runDestroyer(destroyer, o.clusterDeprovisionName, logger)
 }
@@ -18,12 +18,13 @@ import (
 
 // nutanixOptions
 type nutanixOptions struct {
-	logLevel string
-	infraID  string
-	endpoint string
-	port     string
-	username string
-	password string
+	logLevel               string
+	infraID                string
+	endpoint               string
+	port                   string
+	username               string
+	password               string
+	clusterDeprovisionName string
 }
 
 func NewDeprovisionNutanixCommand(logLevel string) *cobra.Command {
@@ -50,12 +51,14 @@ func NewDeprovisionNutanixCommand(logLevel string) *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&opt.endpoint, constants.CliNutanixPcAddressOpt, "", "Domain name or IP address of the Nutanix Prism Central endpoint")
 	flags.StringVar(&opt.port, constants.CliNutanixPcPortOpt, "", "Port of the Nutanix Prism Central endpoint")
+	addUnsupportedDryRunFlag(cmd)
 	return cmd
 }
 
 // Complete finishes parsing arguments for the command
 func (o *nutanixOptions) Complete(cmd *cobra.Command, args []string) error {
 	o.infraID = args[0]
+	o.clusterDeprovisionName, _ = cmd.Flags().GetString("clusterdeprovision-name")
 
 	client, err := utils.GetClient("hiveutil-deprovision-nutanix")
 	if err != nil {
@@ -103,7 +106,5 @@ func (o *nutanixOptions) Run() error {
 		return err
 	}
 
-	// ClusterQuota stomped in return
-	_, err = destroyer.Run()
-	return err
+	return runDestroyer(destroyer, o.clusterDeprovisionName, logger)
 }
@@ -17,9 +17,10 @@ import (
 
 // openStackOptions is the set of options to deprovision an OpenStack cluster
 type openStackOptions struct {
-	logLevel string
-	infraID  string
-	cloud    string
+	logLevel               string
+	infraID                string
+	cloud                  string
+	clusterDeprovisionName string
 }
 
 // NewDeprovisionOpenStackCommand is the entrypoint to create the OpenStack deprovision subcommand
@@ -45,12 +46,14 @@ func NewDeprovisionOpenStackCommand(logLevel string) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.StringVar(&opt.cloud, "cloud", "", "OpenStack cloud entry name from clouds.yaml for access/authentication")
+	addUnsupportedDryRunFlag(cmd)
 	return cmd
 }
 
 // Complete finishes parsing arguments for the command
 func (o *openStackOptions) Complete(cmd *cobra.Command, args []string) error {
 	o.infraID = args[0]
+	o.clusterDeprovisionName, _ = cmd.Flags().GetString("clusterdeprovision-name")
 
 	client, err := utils.GetClient("hiveutil-deprovision-openstack")
 	if err != nil {
@@ -99,7 +102,5 @@ func (o *openStackOptions) Run() error {
 		return err
 	}
 
-	// ClusterQuota stomped in return
-	_, err = destroyer.Run()
-	return err
+	return runDestroyer(destroyer, o.clusterDeprovisionName, logger)
 }
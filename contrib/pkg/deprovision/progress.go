@@ -0,0 +1,257 @@
+package deprovision
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/installer/pkg/destroy/providers"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+)
+
+const (
+	// progressReportInterval is how often the progress is written to the ClusterDeprovision, when it has changed.
+	progressReportInterval = 30 * time.Second
+
+	// failureExpiry is how long a resource which failed to be deleted is reported for without failing again. The
+	// destroyers only log a repeated failure at warning level every few minutes, and do not always log the deletion
+	// of a resource under the name they logged its failures with.
+	failureExpiry = 15 * time.Minute
+)
+
+// progressHook is a logrus hook which follows the destroyer's log to track the progress of the deprovision:
+//   - An entry whose message starts with "Deleted" is a deleted resource.
+//   - An entry at warning level or above, or with an error, is a resource which failed to be deleted.
+//
+// A resource is named after the "arn" field of the entry if it has one, or else its other fields, or else its
+// message.
+type progressHook struct {
+	mutex   sync.Mutex
+	deleted int32
+	// failing are the resources which failed to be deleted and have not been deleted since, by name, with the last
+	// time each failed.
+	failing  map[string]*hivev1.ClusterDeprovisionBlockingResource
+	lastSeen map[string]time.Time
+	// blocking is the name of the resource which most recently failed to be deleted.
+	blocking string
+	now      func() time.Time
+}
+
+func newProgressHook() *progressHook {
+	return &progressHook{
+		failing:  map[string]*hivev1.ClusterDeprovisionBlockingResource{},
+		lastSeen: map[string]time.Time{},
+		now:      time.Now,
+	}
+}
+
+// Levels implements logrus.Hook
+func (h *progressHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire implements logrus.Hook
+func (h *progressHook) Fire(entry *log.Entry) error {
+	name := logResourceName(entry)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	switch {
+	case strings.HasPrefix(entry.Message, "Deleted"):
+		h.deleted++
+		delete(h.failing, name)
+		delete(h.lastSeen, name)
+	case entry.Level <= log.WarnLevel || entry.Data[log.ErrorKey] != nil:
+		now := h.now()
+		resource, ok := h.failing[name]
+		if !ok {
+			resource = &hivev1.ClusterDeprovisionBlockingResource{
+				Name:  name,
+				Type:  logResourceType(entry),
+				Since: metav1.NewTime(now),
+			}
+			h.failing[name] = resource
+		}
+		resource.Error = entry.Message
+		if err, ok := entry.Data[log.ErrorKey]; ok {
+			resource.Error = fmt.Sprintf("%s: %v", entry.Message, err)
+		}
+		h.lastSeen[name] = now
+		h.blocking = name
+	}
+	return nil
+}
+
+// progress returns the progress so far, without its LastUpdateTime.
+func (h *progressHook) progress() *hivev1.ClusterDeprovisionProgress {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	now := h.now()
+	for name, lastSeen := range h.lastSeen {
+		if now.Sub(lastSeen) > failureExpiry {
+			delete(h.failing, name)
+			delete(h.lastSeen, name)
+		}
+	}
+	progress := &hivev1.ClusterDeprovisionProgress{DeletedResources: h.deleted}
+	types := sets.New[string]()
+	for _, resource := range h.failing {
+		if resource.Type != "" {
+			types.Insert(resource.Type)
+		}
+	}
+	if types.Len() > 0 {
+		progress.RemainingResourceTypes = sets.List(types)
+	}
+	if resource, ok := h.failing[h.blocking]; ok {
+		progress.BlockingResource = resource.DeepCopy()
+	}
+	return progress
+}
+
+// logResourceName names the resource a log entry is about.
+func logResourceName(entry *log.Entry) string {
+	if arn, ok := entry.Data["arn"]; ok {
+		return fmt.Sprint(arn)
+	}
+	var fields []string
+	for key, value := range entry.Data {
+		if key != log.ErrorKey {
+			fields = append(fields, fmt.Sprintf("%s=%v", key, value))
+		}
+	}
+	if len(fields) == 0 {
+		return entry.Message
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, " ")
+}
+
+// logResourceType returns the type of the resource a log entry is about: the service and resource type of its ARN,
+// e.g. "ec2:instance", or else the key of its first field, e.g. "NAT gateway".
+func logResourceType(entry *log.Entry) string {
+	if value, ok := entry.Data["arn"]; ok {
+		parsed, err := arn.Parse(fmt.Sprint(value))
+		if err != nil {
+			return ""
+		}
+		if i := strings.IndexAny(parsed.Resource, "/:"); i > 0 {
+			return parsed.Service + ":" + parsed.Resource[:i]
+		}
+		return parsed.Service
+	}
+	var keys []string
+	for key := range entry.Data {
+		if key != log.ErrorKey {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
+
+// progressReporter writes the progress tracked by a progressHook to the status of a ClusterDeprovision.
+type progressReporter struct {
+	client    client.Client
+	namespace string
+	name      string
+	hook      *progressHook
+	// last is the last progress written.
+	last *hivev1.ClusterDeprovisionProgress
+}
+
+// run reports the progress every progressReportInterval until the context is done. The first report resets the
+// progress of any previous uninstall job.
+func (r *progressReporter) run(ctx context.Context) {
+	r.report(true)
+	ticker := time.NewTicker(progressReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.report(false)
+		}
+	}
+}
+
+// report writes the progress if it has changed since the last report, or if forced. Failures are logged to the
+// standard logger, which the hook does not follow, and retried at the next report.
+func (r *progressReporter) report(force bool) {
+	progress := r.hook.progress()
+	if !force && apiequality.Semantic.DeepEqual(progress, r.last) {
+		return
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cdp := &hivev1.ClusterDeprovision{}
+		if err := r.client.Get(context.Background(), client.ObjectKey{Namespace: r.namespace, Name: r.name}, cdp); err != nil {
+			return err
+		}
+		cdp.Status.Progress = progress.DeepCopy()
+		cdp.Status.Progress.LastUpdateTime = metav1.Now()
+		return r.client.Status().Update(context.Background(), cdp)
+	})
+	if err != nil {
+		log.WithError(err).WithField("clusterDeprovision", r.name).Warn("could not report deprovision progress")
+		return
+	}
+	r.last = progress
+}
+
+// runDestroyer runs the destroyer, reporting its progress to the named ClusterDeprovision if a name is given. Reporting
+// stops, with a final report, before runDestroyer returns, so the caller may exit on error without losing it.
+func runDestroyer(destroyer providers.Destroyer, clusterDeprovisionName string, logger *log.Entry) error {
+	if clusterDeprovisionName != "" {
+		c, err := utils.GetClient("hiveutil-deprovision-progress")
+		if err != nil {
+			return errors.Wrap(err, "failed to create kube client to report deprovision progress")
+		}
+		stopProgressReporting := startProgressReporting(c, clusterDeprovisionName, logger)
+		defer stopProgressReporting()
+	}
+	// ClusterQuota stomped in return
+	_, err := destroyer.Run()
+	return err
+}
+
+// startProgressReporting follows the logger to report the progress of the deprovision to the named ClusterDeprovision
+// in the namespace of the ClusterDeployment. It returns a function which reports the final progress and stops.
+func startProgressReporting(c client.Client, clusterDeprovisionName string, logger *log.Entry) func() {
+	hook := newProgressHook()
+	logger.Logger.AddHook(hook)
+	reporter := &progressReporter{
+		client:    c,
+		namespace: os.Getenv("CLUSTERDEPLOYMENT_NAMESPACE"),
+		name:      clusterDeprovisionName,
+		hook:      hook,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reporter.run(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+		reporter.report(false)
+	}
+}
@@ -0,0 +1,288 @@
+package deprovision
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+const (
+	testInstanceARN = "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0"
+	testRoleARN     = "arn:aws:iam::123456789012:role/test-infra-id-master-role"
+)
+
+func testProgressLogger(hook *progressHook) *log.Entry {
+	logger := log.New()
+	logger.Out = io.Discard
+	logger.Level = log.DebugLevel
+	logger.AddHook(hook)
+	return log.NewEntry(logger)
+}
+
+func TestProgressHook(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	deleteErr := errors.New("DependencyViolation")
+
+	tests := []struct {
+		name string
+		// log writes the destroyer's log. advance moves the clock forward.
+		log                    func(logger *log.Entry, advance func(time.Duration))
+		expectedDeleted        int32
+		expectedRemainingTypes []string
+		expectedBlocking       *hivev1.ClusterDeprovisionBlockingResource
+	}{
+		{
+			name: "nothing logged",
+			log:  func(*log.Entry, func(time.Duration)) {},
+		},
+		{
+			name: "deleted resources",
+			log: func(logger *log.Entry, _ func(time.Duration)) {
+				logger.WithField("arn", testInstanceARN).Info("Deleted")
+				logger.WithField("arn", testRoleARN).Info("Deleted")
+				logger.WithField("arn", testRoleARN).Debug("search for IAM roles")
+			},
+			expectedDeleted: 2,
+		},
+		{
+			name: "failed resource",
+			log: func(logger *log.Entry, _ func(time.Duration)) {
+				logger.WithField("arn", testRoleARN).Info("Deleted")
+				logger.WithField("arn", testInstanceARN).WithError(deleteErr).Debug("delete failed")
+			},
+			expectedDeleted:        1,
+			expectedRemainingTypes: []string{"ec2:instance"},
+			expectedBlocking: &hivev1.ClusterDeprovisionBlockingResource{
+				Name:  testInstanceARN,
+				Type:  "ec2:instance",
+				Error: "delete failed: DependencyViolation",
+				Since: metav1.NewTime(start),
+			},
+		},
+		{
+			name: "warning without error",
+			log: func(logger *log.Entry, _ func(time.Duration)) {
+				logger.WithField("NAT gateway", "nat-0123").Warn("still in use")
+			},
+			expectedRemainingTypes: []string{"NAT gateway"},
+			expectedBlocking: &hivev1.ClusterDeprovisionBlockingResource{
+				Name:  "NAT gateway=nat-0123",
+				Type:  "NAT gateway",
+				Error: "still in use",
+				Since: metav1.NewTime(start),
+			},
+		},
+		{
+			name: "repeated failure keeps first time and latest error",
+			log: func(logger *log.Entry, advance func(time.Duration)) {
+				logger.WithField("arn", testInstanceARN).WithError(deleteErr).Warn("delete failed")
+				advance(5 * time.Minute)
+				logger.WithField("arn", testInstanceARN).WithError(errors.New("Throttling")).Warn("delete failed")
+			},
+			expectedRemainingTypes: []string{"ec2:instance"},
+			expectedBlocking: &hivev1.ClusterDeprovisionBlockingResource{
+				Name:  testInstanceARN,
+				Type:  "ec2:instance",
+				Error: "delete failed: Throttling",
+				Since: metav1.NewTime(start),
+			},
+		},
+		{
+			name: "most recent failure is blocking",
+			log: func(logger *log.Entry, advance func(time.Duration)) {
+				logger.WithField("arn", testInstanceARN).WithError(deleteErr).Warn("delete failed")
+				advance(time.Minute)
+				logger.WithField("arn", testRoleARN).WithError(deleteErr).Warn("delete failed")
+			},
+			expectedRemainingTypes: []string{"ec2:instance", "iam:role"},
+			expectedBlocking: &hivev1.ClusterDeprovisionBlockingResource{
+				Name:  testRoleARN,
+				Type:  "iam:role",
+				Error: "delete failed: DependencyViolation",
+				Since: metav1.NewTime(start.Add(time.Minute)),
+			},
+		},
+		{
+			name: "deleted after failure",
+			log: func(logger *log.Entry, _ func(time.Duration)) {
+				logger.WithField("arn", testInstanceARN).WithError(deleteErr).Warn("delete failed")
+				logger.WithField("arn", testInstanceARN).Info("Deleted")
+			},
+			expectedDeleted: 1,
+		},
+		{
+			name: "failure expires",
+			log: func(logger *log.Entry, advance func(time.Duration)) {
+				logger.WithField("arn", testInstanceARN).WithError(deleteErr).Warn("delete failed")
+				advance(failureExpiry + time.Second)
+			},
+		},
+		{
+			name: "failure not yet expired",
+			log: func(logger *log.Entry, advance func(time.Duration)) {
+				logger.WithField("arn", testInstanceARN).WithError(deleteErr).Warn("delete failed")
+				advance(failureExpiry - time.Second)
+			},
+			expectedRemainingTypes: []string{"ec2:instance"},
+			expectedBlocking: &hivev1.ClusterDeprovisionBlockingResource{
+				Name:  testInstanceARN,
+				Type:  "ec2:instance",
+				Error: "delete failed: DependencyViolation",
+				Since: metav1.NewTime(start),
+			},
+		},
+		{
+			name: "only expired failures are dropped",
+			log: func(logger *log.Entry, advance func(time.Duration)) {
+				logger.WithField("arn", testRoleARN).WithError(deleteErr).Warn("delete failed")
+				advance(failureExpiry / 2)
+				logger.WithField("arn", testInstanceARN).WithError(deleteErr).Warn("delete failed")
+				advance(failureExpiry/2 + time.Second)
+				logger.WithField("arn", testRoleARN).WithError(deleteErr).Warn("delete failed")
+				advance(failureExpiry/2 + time.Second)
+			},
+			expectedRemainingTypes: []string{"iam:role"},
+			expectedBlocking: &hivev1.ClusterDeprovisionBlockingResource{
+				Name:  testRoleARN,
+				Type:  "iam:role",
+				Error: "delete failed: DependencyViolation",
+				Since: metav1.NewTime(start),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now := start
+			hook := newProgressHook()
+			hook.now = func() time.Time { return now }
+
+			test.log(testProgressLogger(hook), func(d time.Duration) { now = now.Add(d) })
+
+			progress := hook.progress()
+			assert.Equal(t, test.expectedDeleted, progress.DeletedResources, "unexpected deleted resources")
+			assert.Equal(t, test.expectedRemainingTypes, progress.RemainingResourceTypes, "unexpected remaining resource types")
+			assert.Equal(t, test.expectedBlocking, progress.BlockingResource, "unexpected blocking resource")
+		})
+	}
+}
+
+func TestLogResourceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     log.Fields
+		message  string
+		expected string
+	}{
+		{
+			name:     "arn",
+			data:     log.Fields{"arn": testInstanceARN, "id": "i-0123456789abcdef0"},
+			expected: testInstanceARN,
+		},
+		{
+			name:     "fields sorted without error",
+			data:     log.Fields{"zone": "us-east1-b", "instance": "infra-master-0", log.ErrorKey: errors.New("boom")},
+			expected: "instance=infra-master-0 zone=us-east1-b",
+		},
+		{
+			name:     "message",
+			data:     log.Fields{log.ErrorKey: errors.New("boom")},
+			message:  "failed to list instances",
+			expected: "failed to list instances",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry := &log.Entry{Data: test.data, Message: test.message}
+			assert.Equal(t, test.expected, logResourceName(entry))
+		})
+	}
+}
+
+func TestLogResourceType(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     log.Fields
+		expected string
+	}{
+		{
+			name:     "arn with slash",
+			data:     log.Fields{"arn": testInstanceARN},
+			expected: "ec2:instance",
+		},
+		{
+			name:     "arn with colon",
+			data:     log.Fields{"arn": "arn:aws:logs:us-east-1:123456789012:log-group:/aws/test"},
+			expected: "logs:log-group",
+		},
+		{
+			name:     "arn without resource type",
+			data:     log.Fields{"arn": "arn:aws:s3:::test-bucket"},
+			expected: "s3",
+		},
+		{
+			name:     "invalid arn",
+			data:     log.Fields{"arn": "not-an-arn"},
+			expected: "",
+		},
+		{
+			name:     "first field key",
+			data:     log.Fields{"virtualMachine": "infra-master-0", "disk": "infra-master-0-os", log.ErrorKey: errors.New("boom")},
+			expected: "disk",
+		},
+		{
+			name: "no fields",
+			data: log.Fields{log.ErrorKey: errors.New("boom")},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, logResourceType(&log.Entry{Data: test.data}))
+		})
+	}
+}
+
+func TestProgressReporterReport(t *testing.T) {
+	cdp := &hivev1.ClusterDeprovision{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-deprovision"},
+		Status: hivev1.ClusterDeprovisionStatus{
+			Progress: &hivev1.ClusterDeprovisionProgress{DeletedResources: 42},
+		},
+	}
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(cdp).Build()
+	hook := newProgressHook()
+	reporter := &progressReporter{client: c, namespace: cdp.Namespace, name: cdp.Name, hook: hook}
+	logger := testProgressLogger(hook)
+	getProgress := func() *hivev1.ClusterDeprovisionProgress {
+		current := &hivev1.ClusterDeprovision{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cdp), current))
+		return current.Status.Progress
+	}
+
+	reporter.report(true)
+	progress := getProgress()
+	require.NotNil(t, progress, "expected progress to be reported")
+	assert.Zero(t, progress.DeletedResources, "expected the progress of a previous job to be reset")
+	assert.False(t, progress.LastUpdateTime.IsZero(), "expected last update time to be set")
+
+	logger.WithField("arn", testInstanceARN).Info("Deleted")
+	reporter.report(false)
+	assert.Equal(t, int32(1), getProgress().DeletedResources, "expected changed progress to be reported")
+
+	current := &hivev1.ClusterDeprovision{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cdp), current))
+	current.Status.Progress = nil
+	require.NoError(t, c.Status().Update(context.Background(), current))
+	reporter.report(false)
+	assert.Nil(t, getProgress(), "expected unchanged progress not to be reported")
+}
@@ -19,11 +19,12 @@ import (
 
 // vSphereOptions is the set of options to deprovision an vSphere cluster
 type vSphereOptions struct {
-	logLevel string
-	infraID  string
-	vCenter  string
-	username string
-	password string
+	logLevel               string
+	infraID                string
+	vCenter                string
+	username               string
+	password               string
+	clusterDeprovisionName string
 }
 
 // NewDeprovisionvSphereCommand is the entrypoint to create the vSphere deprovision subcommand
@@ -49,12 +50,14 @@ func NewDeprovisionvSphereCommand(logLevel string) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.StringVar(&opt.vCenter, "vsphere-vcenter", "", "Domain name or IP address of the vCenter")
+	addUnsupportedDryRunFlag(cmd)
 	return cmd
 }
 
 // Complete finishes parsing arguments for the command
 func (o *vSphereOptions) Complete(cmd *cobra.Command, args []string) error {
 	o.infraID = args[0]
+	o.clusterDeprovisionName, _ = cmd.Flags().GetString("clusterdeprovision-name")
 
 	client, err := utils.GetClient("hiveutil-deprovision-vsphere")
 	if err != nil {
@@ -107,7 +110,5 @@ func (o *vSphereOptions) Run() error {
 		return err
 	}
 
-	// ClusterQuota stomped in return
-	_, err = destroyer.Run()
-	return err
+	return runDestroyer(destroyer, o.clusterDeprovisionName, logger)
 }
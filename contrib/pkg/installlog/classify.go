@@ -0,0 +1,129 @@
+package installlog
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/pkg/controller/clusterprovision"
+)
+
+const (
+	defaultRegexesFile = "config/configmaps/install-log-regexes-configmap.yaml"
+	regexesDataKey     = "regexes"
+)
+
+// ClassifyOptions is the set of options to classify an install log
+type ClassifyOptions struct {
+	LogFile      string
+	RegexesFiles []string
+	out          io.Writer
+}
+
+// NewClassifyCommand returns a command that replays a saved install log through the install failure classifier
+// used by the clusterprovision controller.
+func NewClassifyCommand() *cobra.Command {
+	opt := &ClassifyOptions{out: os.Stdout}
+	cmd := &cobra.Command{
+		Use:   "classify LOG_FILE",
+		Short: "Lists the known failures found in a saved install log",
+		Long: `Lists the known failures found in a saved install log, as recorded in the status of a failed ClusterProvision.
+Regexes are read from ConfigMap manifests such as the install-log-regexes ConfigMap, or from files holding just the
+list of regexes, and are matched in the order given. Use this to test new regexes offline.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringArrayVar(&opt.RegexesFiles, "regexes", []string{defaultRegexesFile}, "File with install log regexes. May be repeated")
+	return cmd
+}
+
+// Complete finalizes command options
+func (o *ClassifyOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.LogFile = args[0]
+	return nil
+}
+
+// Run executes the command
+func (o *ClassifyOptions) Run() error {
+	installLog, err := os.ReadFile(o.LogFile)
+	if err != nil {
+		return errors.Wrap(err, "cannot read install log")
+	}
+	regexes := []string{}
+	for _, filename := range o.RegexesFiles {
+		raw, err := readRegexes(filename)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read install log regexes from %s", filename)
+		}
+		regexes = append(regexes, raw)
+	}
+	failures, err := clusterprovision.ClassifyInstallLog(string(installLog), log.StandardLogger(), regexes...)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		fmt.Fprintln(o.out, "No known failures found")
+		return nil
+	}
+	out, err := yaml.Marshal(failures)
+	if err != nil {
+		return err
+	}
+	_, err = o.out.Write(out)
+	return err
+}
+
+// regexesManifest holds the fields of a ConfigMap, or of a Template of ConfigMaps, needed to find install log regexes.
+type regexesManifest struct {
+	Kind    string            `json:"kind"`
+	Data    map[string]string `json:"data"`
+	Objects []regexesManifest `json:"objects"`
+}
+
+// readRegexes returns the install log regexes in the file, which is a ConfigMap with the regexes in its "regexes" data
+// entry, a Template holding such a ConfigMap, or the list of regexes itself.
+func readRegexes(filename string) (string, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	manifest := &regexesManifest{}
+	if err := yaml.Unmarshal(contents, manifest); err != nil {
+		// Not an object, so expect a list of regexes.
+		return string(contents), nil
+	}
+	switch manifest.Kind {
+	case "ConfigMap":
+	case "Template":
+		for _, obj := range manifest.Objects {
+			if obj.Kind == "ConfigMap" {
+				manifest = &obj
+				break
+			}
+		}
+		if manifest.Kind != "ConfigMap" {
+			return "", errors.New("template does not have a configmap")
+		}
+	default:
+		return "", fmt.Errorf("unsupported kind %q", manifest.Kind)
+	}
+	raw, ok := manifest.Data[regexesDataKey]
+	if !ok {
+		return "", fmt.Errorf("configmap does not have a %q data entry", regexesDataKey)
+	}
+	return raw, nil
+}
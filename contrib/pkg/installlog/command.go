@@ -0,0 +1,18 @@
+package installlog
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewInstallLogCommand returns a utility command to work with the install logs of cluster provisions.
+func NewInstallLogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-log",
+		Short: "Utilities for working with cluster install logs",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewClassifyCommand())
+	return cmd
+}
@@ -0,0 +1,196 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/costmodel"
+)
+
+const (
+	groupByCluster   = "cluster"
+	groupByPool      = "pool"
+	groupByNamespace = "namespace"
+
+	hoursPerDay   = 24
+	hoursPerMonth = 730
+)
+
+// CostReportOptions is the set of options for the desired report.
+type CostReportOptions struct {
+	// Namespace limits the report to the clusters in the given namespace.
+	Namespace string
+	// HiveNamespace is the namespace of the hive-cost-model configmap.
+	HiveNamespace string
+	// GroupBy is how the estimated costs are summarized: cluster, pool or namespace.
+	GroupBy string
+	// Output is the output format: text or json.
+	Output string
+}
+
+// costLine is a line of the cost report.
+type costLine struct {
+	Name       string  `json:"name"`
+	Clusters   int     `json:"clusters"`
+	Running    int     `json:"running"`
+	HourlyCost float64 `json:"hourlyCost"`
+	DailyCost  float64 `json:"dailyCost"`
+	// Unpriced are the instance types missing from the cost model, which are not part of the cost.
+	Unpriced []string `json:"unpricedInstanceTypes,omitempty"`
+}
+
+// NewCostReportCommand creates a command that generates and outputs the estimated cost report.
+func NewCostReportCommand() *cobra.Command {
+
+	opt := &CostReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Prints a report on the estimated cost of installed clusters",
+		Long: `Prints the estimated cost of installed clusters, summarized by cluster, cluster pool or namespace.
+
+Costs are estimated from the per-platform instance type prices in the hive-cost-model configmap, the MachinePools
+and install config of each cluster, and whether it is hibernating. Pool costs only include unclaimed clusters.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			dynClient, err := contributils.GetClient("hiveutil-report-cost")
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Error("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only include clusters in the given namespace.")
+	flags.StringVar(&opt.HiveNamespace, "hive-namespace", constants.DefaultHiveNamespace, "Namespace of the hive-cost-model configmap.")
+	flags.StringVar(&opt.GroupBy, "group-by", groupByNamespace, "Summarize the estimated costs by cluster, pool or namespace.")
+	flags.StringVarP(&opt.Output, "output", "o", "text", "Output format: text|json")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *CostReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *CostReportOptions) Validate(cmd *cobra.Command) error {
+	switch o.GroupBy {
+	case groupByCluster, groupByPool, groupByNamespace:
+	default:
+		cmd.Usage()
+		return fmt.Errorf("unsupported --group-by %q", o.GroupBy)
+	}
+	if o.Output != "text" && o.Output != "json" {
+		cmd.Usage()
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *CostReportOptions) Run(dynClient client.Client) error {
+	model, err := costmodel.Load(context.Background(), dynClient, o.HiveNamespace)
+	if err != nil {
+		return err
+	}
+	if model == nil {
+		return fmt.Errorf("no %s configmap found in namespace %s", costmodel.ConfigMapName, o.HiveNamespace)
+	}
+	estimates, err := model.EstimateAll(context.Background(), dynClient, o.Namespace, log.StandardLogger())
+	if err != nil {
+		return err
+	}
+
+	lines := map[string]*costLine{}
+	total := &costLine{Name: "TOTAL"}
+	for _, e := range estimates {
+		var key string
+		switch o.GroupBy {
+		case groupByCluster:
+			key = e.Namespace + "/" + e.Name
+		case groupByPool:
+			if e.PoolRef == nil || e.PoolRef.ClaimName != "" {
+				continue
+			}
+			key = e.PoolRef.Namespace + "/" + e.PoolRef.PoolName
+		case groupByNamespace:
+			key = e.Namespace
+		}
+		line, ok := lines[key]
+		if !ok {
+			line = &costLine{Name: key}
+			lines[key] = line
+		}
+		for _, l := range []*costLine{line, total} {
+			l.add(e.Estimate)
+		}
+	}
+
+	sorted := make([]*costLine, 0, len(lines))
+	for _, line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].HourlyCost != sorted[j].HourlyCost {
+			return sorted[i].HourlyCost > sorted[j].HourlyCost
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	if o.Output == "json" {
+		out, err := json.MarshalIndent(map[string]any{"groupBy": o.GroupBy, "items": sorted, "total": total}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tCLUSTERS\tRUNNING\tHOURLY\tDAILY\tMONTHLY\tUNPRICED INSTANCE TYPES\n", strings.ToUpper(o.GroupBy))
+	for _, line := range append(sorted, total) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f\t%.2f\t%.2f\t%s\n", line.Name, line.Clusters, line.Running,
+			line.HourlyCost, line.DailyCost, line.HourlyCost*hoursPerMonth, strings.Join(line.Unpriced, ","))
+	}
+	return w.Flush()
+}
+
+func (l *costLine) add(estimate *costmodel.Estimate) {
+	l.Clusters++
+	if estimate.PowerState != hivev1.ClusterPowerStateHibernating {
+		l.Running++
+	}
+	l.HourlyCost += estimate.HourlyCost
+	l.DailyCost = l.HourlyCost * hoursPerDay
+	for _, instanceType := range estimate.UnpricedInstanceTypes {
+		if !slices.Contains(l.Unpriced, instanceType) {
+			l.Unpriced = append(l.Unpriced, instanceType)
+		}
+	}
+}
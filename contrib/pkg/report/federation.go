@@ -0,0 +1,197 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+// FederationReportOptions is the set of options for the desired report.
+type FederationReportOptions struct {
+	// Namespace limits the report to the clusters in the given namespace.
+	Namespace string
+	// Output is the output format: text or json.
+	Output string
+}
+
+// federatedCluster is a line of the federation report.
+type federatedCluster struct {
+	Hub        string `json:"hub"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Platform   string `json:"platform,omitempty"`
+	Region     string `json:"region,omitempty"`
+	Version    string `json:"version,omitempty"`
+	Installed  bool   `json:"installed"`
+	PowerState string `json:"powerState,omitempty"`
+	// MovingTo is the hub the cluster is being moved to.
+	MovingTo string `json:"movingTo,omitempty"`
+}
+
+// NewFederationReportCommand creates a command that lists the ClusterDeployments across the hubs of the federation.
+func NewFederationReportCommand() *cobra.Command {
+
+	opt := &FederationReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "federation",
+		Short: "Prints the clusters across the hubs of the federation",
+		Long: `Prints the ClusterDeployments of this hub and of each peer listed in its HubFederation.
+
+The peers are queried with the kubeconfig secrets referenced by the HubFederation. Peers which cannot be queried
+are reported and skipped.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			dynClient, err := contributils.GetClient("hiveutil-report-federation")
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Error("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only include clusters in the given namespace.")
+	flags.StringVarP(&opt.Output, "output", "o", "text", "Output format: text|json")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *FederationReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *FederationReportOptions) Validate(cmd *cobra.Command) error {
+	if o.Output != "text" && o.Output != "json" {
+		cmd.Usage()
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	return nil
+}
+
+// Run executes the command
+func (o *FederationReportOptions) Run(dynClient client.Client) error {
+	fedList := &hivev1.HubFederationList{}
+	if err := dynClient.List(context.Background(), fedList); err != nil {
+		return err
+	}
+	if len(fedList.Items) == 0 {
+		return fmt.Errorf("no HubFederation found")
+	}
+	fed := &fedList.Items[0]
+
+	clusters, err := o.listClusters(dynClient, fed.Spec.HubName)
+	if err != nil {
+		return err
+	}
+	for _, peer := range fed.Spec.Peers {
+		logger := log.WithField("peer", peer.Name)
+		peerClient, err := peerClient(dynClient, peer)
+		if err != nil {
+			logger.WithError(err).Warn("could not connect to peer")
+			continue
+		}
+		peerClusters, err := o.listClusters(peerClient, peer.Name)
+		if err != nil {
+			logger.WithError(err).Warn("could not list clusters of peer")
+			continue
+		}
+		clusters = append(clusters, peerClusters...)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Hub != clusters[j].Hub {
+			return clusters[i].Hub < clusters[j].Hub
+		}
+		if clusters[i].Namespace != clusters[j].Namespace {
+			return clusters[i].Namespace < clusters[j].Namespace
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	if o.Output == "json" {
+		out, err := json.MarshalIndent(map[string]any{"items": clusters}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "HUB\tNAMESPACE\tNAME\tPLATFORM\tREGION\tVERSION\tINSTALLED\tPOWERSTATE\tMOVING TO")
+	for _, c := range clusters {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\t%s\n", c.Hub, c.Namespace, c.Name, c.Platform, c.Region,
+			c.Version, c.Installed, c.PowerState, c.MovingTo)
+	}
+	return w.Flush()
+}
+
+// listClusters lists the ClusterDeployments of a hub.
+func (o *FederationReportOptions) listClusters(c client.Client, hubName string) ([]federatedCluster, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cdList, client.InNamespace(o.Namespace)); err != nil {
+		return nil, err
+	}
+	clusters := make([]federatedCluster, 0, len(cdList.Items))
+	for _, cd := range cdList.Items {
+		movingTo := cd.Labels[constants.HubFederationDestinationLabel]
+		if movingTo == hubName {
+			movingTo = ""
+		}
+		clusters = append(clusters, federatedCluster{
+			Hub:        hubName,
+			Namespace:  cd.Namespace,
+			Name:       cd.Name,
+			Platform:   cd.Labels[hivev1.HiveClusterPlatformLabel],
+			Region:     cd.Labels[hivev1.HiveClusterRegionLabel],
+			Version:    cd.Labels[constants.VersionLabel],
+			Installed:  cd.Spec.Installed,
+			PowerState: string(cd.Status.PowerState),
+			MovingTo:   movingTo,
+		})
+	}
+	return clusters, nil
+}
+
+// peerClient returns a client for the peer, from the kubeconfig secret referenced by the HubFederation.
+func peerClient(c client.Client, peer hivev1.HubFederationPeer) (client.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), types.NamespacedName{
+		Namespace: peer.KubeconfigSecretRef.Namespace,
+		Name:      peer.KubeconfigSecretRef.Name,
+	}, secret); err != nil {
+		return nil, err
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[constants.KubeconfigSecretKey])
+	if err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme.GetScheme()})
+}
@@ -95,6 +95,8 @@ type Client interface {
 
 	// S3
 	Upload(input *s3.PutObjectInput) (*s3manager.UploadOutput, error)
+	ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
+	DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error)
 
 	// Route53
 	CreateHostedZone(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error)
@@ -304,6 +306,16 @@ func (c *awsClient) Upload(input *s3.PutObjectInput) (*s3manager.UploadOutput, e
 	return c.s3Uploader.Upload(context.TODO(), input)
 }
 
+func (c *awsClient) ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	metricAWSAPICalls.WithLabelValues("ListObjectsV2").Inc()
+	return c.s3Client.ListObjectsV2(context.TODO(), input)
+}
+
+func (c *awsClient) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DeleteObject").Inc()
+	return c.s3Client.DeleteObject(context.TODO(), input)
+}
+
 func (c *awsClient) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ListHostedZonesByName").Inc()
 	return c.route53Client.ListHostedZonesByName(context.TODO(), input)
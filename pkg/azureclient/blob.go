@@ -0,0 +1,159 @@
+package azureclient
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// blobAPIVersion is the version of the Azure Blob Storage REST API used by the blob client.
+const blobAPIVersion = "2021-08-06"
+
+// BlobClient is a client for the blobs of a single Azure Blob Storage container.
+type BlobClient interface {
+	// Put uploads size bytes read from body as a block blob with the given name, replacing any existing blob.
+	Put(ctx context.Context, name string, body io.Reader, size int64) error
+
+	// List returns the names of the blobs with the given prefix, mapped to when they were last modified.
+	List(ctx context.Context, prefix string) (map[string]time.Time, error)
+
+	// Delete deletes the named blob. Deleting a blob which does not exist is not an error.
+	Delete(ctx context.Context, name string) error
+
+	// URL returns the URL of the blob with the given name, or of the container if the name is empty.
+	URL(name string) string
+}
+
+type blobClient struct {
+	containerURL string
+	authorizer   autorest.Authorizer
+}
+
+// NewBlobClientFromSecret creates a client for the blobs of a container in an Azure storage account. The Azure creds
+// are read from the specified secret.
+func NewBlobClientFromSecret(secret *corev1.Secret, environmentName, storageAccount, container string) (BlobClient, error) {
+	creds, env, err := readCredentials(authJSONFromSecretSource(secret), environmentName)
+	if err != nil {
+		return nil, err
+	}
+	authorizer, err := getAuthorizer(creds.ClientID, creds.ClientSecret, creds.TenantID, env.ResourceIdentifiers.Storage, env)
+	if err != nil {
+		return nil, err
+	}
+	return &blobClient{
+		containerURL: fmt.Sprintf("https://%s.blob.%s/%s", storageAccount, env.StorageEndpointSuffix, url.PathEscape(container)),
+		authorizer:   authorizer,
+	}, nil
+}
+
+func (c *blobClient) URL(name string) string {
+	if name == "" {
+		return c.containerURL + "/"
+	}
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return c.containerURL + "/" + strings.Join(segments, "/")
+}
+
+func (c *blobClient) Put(ctx context.Context, name string, body io.Reader, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.URL(name), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	_, err = c.do(req, http.StatusCreated)
+	return err
+}
+
+type blobList struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified string `xml:"Last-Modified"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (c *blobClient) List(ctx context.Context, prefix string) (map[string]time.Time, error) {
+	blobs := map[string]time.Time{}
+	marker := ""
+	for {
+		query := url.Values{
+			"restype": {"container"},
+			"comp":    {"list"},
+			"prefix":  {prefix},
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.containerURL+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.do(req, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		list := &blobList{}
+		if err := xml.Unmarshal(body, list); err != nil {
+			return nil, fmt.Errorf("could not parse blob list: %w", err)
+		}
+		for _, blob := range list.Blobs {
+			lastModified, err := time.Parse(time.RFC1123, blob.Properties.LastModified)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse last modified time of blob %s: %w", blob.Name, err)
+			}
+			blobs[blob.Name] = lastModified
+		}
+		if list.NextMarker == "" {
+			return blobs, nil
+		}
+		marker = list.NextMarker
+	}
+}
+
+func (c *blobClient) Delete(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.URL(name), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, http.StatusAccepted, http.StatusNotFound)
+	return err
+}
+
+// do authorizes and sends the request, returning the body of the response if its status is one of those expected.
+func (c *blobClient) do(req *http.Request, expectedStatus ...int) ([]byte, error) {
+	req.Header.Set("x-ms-version", blobAPIVersion)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req, err := autorest.Prepare(req, c.authorizer.WithAuthorization())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := autorest.Send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range expectedStatus {
+		if resp.StatusCode == status {
+			return body, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s: unexpected status %d: %s", req.Method, req.URL.Path, resp.StatusCode, string(body))
+}
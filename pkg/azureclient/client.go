@@ -8,8 +8,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -48,6 +50,31 @@ type Client interface {
 
 	// Images
 	ListImagesByResourceGroup(ctx context.Context, resourceGroupName string) (ImageListResultPage, error)
+
+	// Private Link
+	GetLoadBalancer(ctx context.Context, resourceGroupName string, loadBalancerName string) (*armnetwork.LoadBalancer, error)
+	GetSubnet(ctx context.Context, resourceGroupName string, virtualNetworkName string, subnetName string) (*armnetwork.Subnet, error)
+	CreateOrUpdateSubnet(ctx context.Context, resourceGroupName string, virtualNetworkName string, subnetName string, subnet armnetwork.Subnet) (*armnetwork.Subnet, error)
+	GetPrivateLinkService(ctx context.Context, resourceGroupName string, serviceName string) (*armnetwork.PrivateLinkService, error)
+	CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, serviceName string, service armnetwork.PrivateLinkService) (*armnetwork.PrivateLinkService, error)
+	DeletePrivateLinkService(ctx context.Context, resourceGroupName string, serviceName string) error
+	GetPrivateEndpoint(ctx context.Context, resourceGroupName string, endpointName string) (*armnetwork.PrivateEndpoint, error)
+	ListPrivateEndpoints(ctx context.Context, resourceGroupName string) ([]*armnetwork.PrivateEndpoint, error)
+	CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpointName string, endpoint armnetwork.PrivateEndpoint) (*armnetwork.PrivateEndpoint, error)
+	DeletePrivateEndpoint(ctx context.Context, resourceGroupName string, endpointName string) error
+	GetNetworkInterface(ctx context.Context, resourceGroupName string, interfaceName string) (*armnetwork.Interface, error)
+
+	// Private DNS
+	GetPrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error)
+	CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error)
+	DeletePrivateZone(ctx context.Context, resourceGroupName string, zone string) error
+	CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error)
+	ListVirtualNetworkLinks(ctx context.Context, resourceGroupName string, zone string) ([]privatedns.VirtualNetworkLink, error)
+	CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string, virtualNetworkID string) (privatedns.VirtualNetworkLink, error)
+	DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string) error
+
+	// SubscriptionID returns the ID of the subscription the client was created for.
+	SubscriptionID() string
 }
 
 // ResourceSKUsPage is a page of results from listing resource SKUs.
@@ -76,6 +103,13 @@ type azureClient struct {
 	zonesClient           *dns.ZonesClient
 	virtualMachinesClient *compute.VirtualMachinesClient
 	imagesClient          *compute.ImagesClient
+
+	privateZonesClient        *privatedns.PrivateZonesClient
+	privateRecordSetsClient   *privatedns.RecordSetsClient
+	virtualNetworkLinksClient *privatedns.VirtualNetworkLinksClient
+	networkClientFactory      *armnetwork.ClientFactory
+
+	subscriptionID string
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
@@ -201,39 +235,12 @@ func NewClient(creds []byte, environmentName string) (Client, error) {
 }
 
 func newClient(authJSONSource func() ([]byte, error), environmentName string) (*azureClient, error) {
-	authJSON, err := authJSONSource()
+	creds, env, err := readCredentials(authJSONSource, environmentName)
 	if err != nil {
 		return nil, err
 	}
-	var creds installerazure.Credentials
-	// json.Unmarshal is case-insensitive. ACM-14248.
-	if err := json.Unmarshal(authJSON, &creds); err != nil {
-		return nil, err
-	}
-	// TODO: Installer supports cert and MSI auth as well; we currently only support client secret.
-	if creds.ClientID == "" {
-		return nil, errors.New("missing clientId in auth")
-	}
-	if creds.ClientSecret == "" {
-		return nil, errors.New("missing clientSecret in auth")
-	}
-	if creds.TenantID == "" {
-		return nil, errors.New("missing tenantId in auth")
-	}
-	if creds.SubscriptionID == "" {
-		return nil, errors.New("missing subscriptionId in auth")
-	}
-
-	if environmentName == "" {
-		environmentName = azure.PublicCloud.Name
-	}
 
-	env, err := azure.EnvironmentFromName(environmentName)
-	if err != nil {
-		return nil, err
-	}
-
-	authorizer, err := getAuthorizer(creds.ClientID, creds.ClientSecret, creds.TenantID, env)
+	authorizer, err := getAuthorizer(creds.ClientID, creds.ClientSecret, creds.TenantID, env.ResourceManagerEndpoint, env)
 	if err != nil {
 		return nil, err
 	}
@@ -253,15 +260,71 @@ func newClient(authJSONSource func() ([]byte, error), environmentName string) (*
 	imagesClient := compute.NewImagesClientWithBaseURI(env.ResourceManagerEndpoint, creds.SubscriptionID)
 	imagesClient.Authorizer = authorizer
 
+	privateZonesClient := privatedns.NewPrivateZonesClientWithBaseURI(env.ResourceManagerEndpoint, creds.SubscriptionID)
+	privateZonesClient.Authorizer = authorizer
+
+	privateRecordSetsClient := privatedns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, creds.SubscriptionID)
+	privateRecordSetsClient.Authorizer = authorizer
+
+	virtualNetworkLinksClient := privatedns.NewVirtualNetworkLinksClientWithBaseURI(env.ResourceManagerEndpoint, creds.SubscriptionID)
+	virtualNetworkLinksClient.Authorizer = authorizer
+
+	networkClientFactory, err := newNetworkClientFactory(creds, env)
+	if err != nil {
+		return nil, err
+	}
+
 	return &azureClient{
 		resourceSKUsClient:    &resourceSKUsClient,
 		recordSetsClient:      &recordSetsClient,
 		zonesClient:           &zonesClient,
 		virtualMachinesClient: &virtualMachinesClient,
 		imagesClient:          &imagesClient,
+
+		privateZonesClient:        &privateZonesClient,
+		privateRecordSetsClient:   &privateRecordSetsClient,
+		virtualNetworkLinksClient: &virtualNetworkLinksClient,
+		networkClientFactory:      networkClientFactory,
+
+		subscriptionID: creds.SubscriptionID,
 	}, nil
 }
 
+func readCredentials(authJSONSource func() ([]byte, error), environmentName string) (*installerazure.Credentials, azure.Environment, error) {
+	authJSON, err := authJSONSource()
+	if err != nil {
+		return nil, azure.Environment{}, err
+	}
+	var creds installerazure.Credentials
+	// json.Unmarshal is case-insensitive. ACM-14248.
+	if err := json.Unmarshal(authJSON, &creds); err != nil {
+		return nil, azure.Environment{}, err
+	}
+	// TODO: Installer supports cert and MSI auth as well; we currently only support client secret.
+	if creds.ClientID == "" {
+		return nil, azure.Environment{}, errors.New("missing clientId in auth")
+	}
+	if creds.ClientSecret == "" {
+		return nil, azure.Environment{}, errors.New("missing clientSecret in auth")
+	}
+	if creds.TenantID == "" {
+		return nil, azure.Environment{}, errors.New("missing tenantId in auth")
+	}
+	if creds.SubscriptionID == "" {
+		return nil, azure.Environment{}, errors.New("missing subscriptionId in auth")
+	}
+
+	if environmentName == "" {
+		environmentName = azure.PublicCloud.Name
+	}
+
+	env, err := azure.EnvironmentFromName(environmentName)
+	if err != nil {
+		return nil, azure.Environment{}, err
+	}
+	return &creds, env, nil
+}
+
 func authJSONFromBytes(creds []byte) func() ([]byte, error) {
 	return func() ([]byte, error) {
 		return creds, nil
@@ -284,9 +347,9 @@ func authJSONFromFileSource(filename string) func() ([]byte, error) {
 	}
 }
 
-func getAuthorizer(clientID, clientSecret, tenantID string, env azure.Environment) (autorest.Authorizer, error) {
+func getAuthorizer(clientID, clientSecret, tenantID, resource string, env azure.Environment) (autorest.Authorizer, error) {
 	config := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
-	config.Resource = env.ResourceManagerEndpoint
+	config.Resource = resource
 	config.AADEndpoint = env.ActiveDirectoryEndpoint
 	return config.Authorizer()
 }
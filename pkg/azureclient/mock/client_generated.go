@@ -8,8 +8,10 @@ import (
 	context "context"
 	reflect "reflect"
 
+	armnetwork "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	privatedns "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 )
@@ -37,6 +39,66 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CreateOrUpdatePrivateEndpoint mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string, endpoint armnetwork.PrivateEndpoint) (*armnetwork.PrivateEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateEndpoint", ctx, resourceGroupName, endpointName, endpoint)
+	ret0, _ := ret[0].(*armnetwork.PrivateEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateEndpoint indicates an expected call of CreateOrUpdatePrivateEndpoint.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateEndpoint(ctx, resourceGroupName, endpointName, endpoint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateEndpoint", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateEndpoint), ctx, resourceGroupName, endpointName, endpoint)
+}
+
+// CreateOrUpdatePrivateLinkService mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName, serviceName string, service armnetwork.PrivateLinkService) (*armnetwork.PrivateLinkService, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateLinkService", ctx, resourceGroupName, serviceName, service)
+	ret0, _ := ret[0].(*armnetwork.PrivateLinkService)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateLinkService indicates an expected call of CreateOrUpdatePrivateLinkService.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateLinkService(ctx, resourceGroupName, serviceName, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateLinkService", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateLinkService), ctx, resourceGroupName, serviceName, service)
+}
+
+// CreateOrUpdatePrivateRecordSet mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateRecordSet", ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+	ret0, _ := ret[0].(privatedns.RecordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateRecordSet indicates an expected call of CreateOrUpdatePrivateRecordSet.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateRecordSet(ctx, resourceGroupName, zone, recordSetName, recordType, recordSet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateRecordSet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
+}
+
+// CreateOrUpdatePrivateZone mocks base method.
+func (m *MockClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdatePrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(privatedns.PrivateZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdatePrivateZone indicates an expected call of CreateOrUpdatePrivateZone.
+func (mr *MockClientMockRecorder) CreateOrUpdatePrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdatePrivateZone", reflect.TypeOf((*MockClient)(nil).CreateOrUpdatePrivateZone), ctx, resourceGroupName, zone)
+}
+
 // CreateOrUpdateRecordSet mocks base method.
 func (m *MockClient) CreateOrUpdateRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType dns.RecordType, recordSet dns.RecordSet) (dns.RecordSet, error) {
 	m.ctrl.T.Helper()
@@ -52,6 +114,36 @@ func (mr *MockClientMockRecorder) CreateOrUpdateRecordSet(ctx, resourceGroupName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateRecordSet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType, recordSet)
 }
 
+// CreateOrUpdateSubnet mocks base method.
+func (m *MockClient) CreateOrUpdateSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string, subnet armnetwork.Subnet) (*armnetwork.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateSubnet", ctx, resourceGroupName, virtualNetworkName, subnetName, subnet)
+	ret0, _ := ret[0].(*armnetwork.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdateSubnet indicates an expected call of CreateOrUpdateSubnet.
+func (mr *MockClientMockRecorder) CreateOrUpdateSubnet(ctx, resourceGroupName, virtualNetworkName, subnetName, subnet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateSubnet", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateSubnet), ctx, resourceGroupName, virtualNetworkName, subnetName, subnet)
+}
+
+// CreateOrUpdateVirtualNetworkLink mocks base method.
+func (m *MockClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName, virtualNetworkID string) (privatedns.VirtualNetworkLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateVirtualNetworkLink", ctx, resourceGroupName, zone, linkName, virtualNetworkID)
+	ret0, _ := ret[0].(privatedns.VirtualNetworkLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdateVirtualNetworkLink indicates an expected call of CreateOrUpdateVirtualNetworkLink.
+func (mr *MockClientMockRecorder) CreateOrUpdateVirtualNetworkLink(ctx, resourceGroupName, zone, linkName, virtualNetworkID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateVirtualNetworkLink), ctx, resourceGroupName, zone, linkName, virtualNetworkID)
+}
+
 // CreateOrUpdateZone mocks base method.
 func (m *MockClient) CreateOrUpdateZone(ctx context.Context, resourceGroupName, zone string) (dns.Zone, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +174,48 @@ func (mr *MockClientMockRecorder) DeallocateVirtualMachine(ctx, resourceGroup, n
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeallocateVirtualMachine", reflect.TypeOf((*MockClient)(nil).DeallocateVirtualMachine), ctx, resourceGroup, name)
 }
 
+// DeletePrivateEndpoint mocks base method.
+func (m *MockClient) DeletePrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateEndpoint", ctx, resourceGroupName, endpointName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateEndpoint indicates an expected call of DeletePrivateEndpoint.
+func (mr *MockClientMockRecorder) DeletePrivateEndpoint(ctx, resourceGroupName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateEndpoint", reflect.TypeOf((*MockClient)(nil).DeletePrivateEndpoint), ctx, resourceGroupName, endpointName)
+}
+
+// DeletePrivateLinkService mocks base method.
+func (m *MockClient) DeletePrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateLinkService", ctx, resourceGroupName, serviceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateLinkService indicates an expected call of DeletePrivateLinkService.
+func (mr *MockClientMockRecorder) DeletePrivateLinkService(ctx, resourceGroupName, serviceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateLinkService", reflect.TypeOf((*MockClient)(nil).DeletePrivateLinkService), ctx, resourceGroupName, serviceName)
+}
+
+// DeletePrivateZone mocks base method.
+func (m *MockClient) DeletePrivateZone(ctx context.Context, resourceGroupName, zone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrivateZone indicates an expected call of DeletePrivateZone.
+func (mr *MockClientMockRecorder) DeletePrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateZone", reflect.TypeOf((*MockClient)(nil).DeletePrivateZone), ctx, resourceGroupName, zone)
+}
+
 // DeleteRecordSet mocks base method.
 func (m *MockClient) DeleteRecordSet(ctx context.Context, resourceGroupName, zone, recordSetName string, recordType dns.RecordType) error {
 	m.ctrl.T.Helper()
@@ -96,6 +230,20 @@ func (mr *MockClientMockRecorder) DeleteRecordSet(ctx, resourceGroupName, zone,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecordSet", reflect.TypeOf((*MockClient)(nil).DeleteRecordSet), ctx, resourceGroupName, zone, recordSetName, recordType)
 }
 
+// DeleteVirtualNetworkLink mocks base method.
+func (m *MockClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName, zone, linkName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirtualNetworkLink", ctx, resourceGroupName, zone, linkName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVirtualNetworkLink indicates an expected call of DeleteVirtualNetworkLink.
+func (mr *MockClientMockRecorder) DeleteVirtualNetworkLink(ctx, resourceGroupName, zone, linkName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirtualNetworkLink", reflect.TypeOf((*MockClient)(nil).DeleteVirtualNetworkLink), ctx, resourceGroupName, zone, linkName)
+}
+
 // DeleteZone mocks base method.
 func (m *MockClient) DeleteZone(ctx context.Context, resourceGroupName, zone string) error {
 	m.ctrl.T.Helper()
@@ -110,6 +258,96 @@ func (mr *MockClientMockRecorder) DeleteZone(ctx, resourceGroupName, zone interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockClient)(nil).DeleteZone), ctx, resourceGroupName, zone)
 }
 
+// GetLoadBalancer mocks base method.
+func (m *MockClient) GetLoadBalancer(ctx context.Context, resourceGroupName, loadBalancerName string) (*armnetwork.LoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadBalancer", ctx, resourceGroupName, loadBalancerName)
+	ret0, _ := ret[0].(*armnetwork.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancer indicates an expected call of GetLoadBalancer.
+func (mr *MockClientMockRecorder) GetLoadBalancer(ctx, resourceGroupName, loadBalancerName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockClient)(nil).GetLoadBalancer), ctx, resourceGroupName, loadBalancerName)
+}
+
+// GetNetworkInterface mocks base method.
+func (m *MockClient) GetNetworkInterface(ctx context.Context, resourceGroupName, interfaceName string) (*armnetwork.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkInterface", ctx, resourceGroupName, interfaceName)
+	ret0, _ := ret[0].(*armnetwork.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkInterface indicates an expected call of GetNetworkInterface.
+func (mr *MockClientMockRecorder) GetNetworkInterface(ctx, resourceGroupName, interfaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkInterface", reflect.TypeOf((*MockClient)(nil).GetNetworkInterface), ctx, resourceGroupName, interfaceName)
+}
+
+// GetPrivateEndpoint mocks base method.
+func (m *MockClient) GetPrivateEndpoint(ctx context.Context, resourceGroupName, endpointName string) (*armnetwork.PrivateEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateEndpoint", ctx, resourceGroupName, endpointName)
+	ret0, _ := ret[0].(*armnetwork.PrivateEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateEndpoint indicates an expected call of GetPrivateEndpoint.
+func (mr *MockClientMockRecorder) GetPrivateEndpoint(ctx, resourceGroupName, endpointName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateEndpoint", reflect.TypeOf((*MockClient)(nil).GetPrivateEndpoint), ctx, resourceGroupName, endpointName)
+}
+
+// GetPrivateLinkService mocks base method.
+func (m *MockClient) GetPrivateLinkService(ctx context.Context, resourceGroupName, serviceName string) (*armnetwork.PrivateLinkService, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateLinkService", ctx, resourceGroupName, serviceName)
+	ret0, _ := ret[0].(*armnetwork.PrivateLinkService)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateLinkService indicates an expected call of GetPrivateLinkService.
+func (mr *MockClientMockRecorder) GetPrivateLinkService(ctx, resourceGroupName, serviceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateLinkService", reflect.TypeOf((*MockClient)(nil).GetPrivateLinkService), ctx, resourceGroupName, serviceName)
+}
+
+// GetPrivateZone mocks base method.
+func (m *MockClient) GetPrivateZone(ctx context.Context, resourceGroupName, zone string) (privatedns.PrivateZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateZone", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].(privatedns.PrivateZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateZone indicates an expected call of GetPrivateZone.
+func (mr *MockClientMockRecorder) GetPrivateZone(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateZone", reflect.TypeOf((*MockClient)(nil).GetPrivateZone), ctx, resourceGroupName, zone)
+}
+
+// GetSubnet mocks base method.
+func (m *MockClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetworkName, subnetName string) (*armnetwork.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnet", ctx, resourceGroupName, virtualNetworkName, subnetName)
+	ret0, _ := ret[0].(*armnetwork.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnet indicates an expected call of GetSubnet.
+func (mr *MockClientMockRecorder) GetSubnet(ctx, resourceGroupName, virtualNetworkName, subnetName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnet", reflect.TypeOf((*MockClient)(nil).GetSubnet), ctx, resourceGroupName, virtualNetworkName, subnetName)
+}
+
 // GetVMCapabilities mocks base method.
 func (m *MockClient) GetVMCapabilities(ctx context.Context, instanceType, region string) (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +423,21 @@ func (mr *MockClientMockRecorder) ListImagesByResourceGroup(ctx, resourceGroupNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesByResourceGroup", reflect.TypeOf((*MockClient)(nil).ListImagesByResourceGroup), ctx, resourceGroupName)
 }
 
+// ListPrivateEndpoints mocks base method.
+func (m *MockClient) ListPrivateEndpoints(ctx context.Context, resourceGroupName string) ([]*armnetwork.PrivateEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrivateEndpoints", ctx, resourceGroupName)
+	ret0, _ := ret[0].([]*armnetwork.PrivateEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrivateEndpoints indicates an expected call of ListPrivateEndpoints.
+func (mr *MockClientMockRecorder) ListPrivateEndpoints(ctx, resourceGroupName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrivateEndpoints", reflect.TypeOf((*MockClient)(nil).ListPrivateEndpoints), ctx, resourceGroupName)
+}
+
 // ListRecordSetsByZone mocks base method.
 func (m *MockClient) ListRecordSetsByZone(ctx context.Context, resourceGroupName, zone, suffix string) (azureclient.RecordSetPage, error) {
 	m.ctrl.T.Helper()
@@ -215,6 +468,21 @@ func (mr *MockClientMockRecorder) ListResourceSKUs(ctx, filter interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceSKUs", reflect.TypeOf((*MockClient)(nil).ListResourceSKUs), ctx, filter)
 }
 
+// ListVirtualNetworkLinks mocks base method.
+func (m *MockClient) ListVirtualNetworkLinks(ctx context.Context, resourceGroupName, zone string) ([]privatedns.VirtualNetworkLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVirtualNetworkLinks", ctx, resourceGroupName, zone)
+	ret0, _ := ret[0].([]privatedns.VirtualNetworkLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVirtualNetworkLinks indicates an expected call of ListVirtualNetworkLinks.
+func (mr *MockClientMockRecorder) ListVirtualNetworkLinks(ctx, resourceGroupName, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVirtualNetworkLinks", reflect.TypeOf((*MockClient)(nil).ListVirtualNetworkLinks), ctx, resourceGroupName, zone)
+}
+
 // StartVirtualMachine mocks base method.
 func (m *MockClient) StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error) {
 	m.ctrl.T.Helper()
@@ -230,6 +498,20 @@ func (mr *MockClientMockRecorder) StartVirtualMachine(ctx, resourceGroup, name i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVirtualMachine", reflect.TypeOf((*MockClient)(nil).StartVirtualMachine), ctx, resourceGroup, name)
 }
 
+// SubscriptionID mocks base method.
+func (m *MockClient) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockClientMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockClient)(nil).SubscriptionID))
+}
+
 // MockResourceSKUsPage is a mock of ResourceSKUsPage interface.
 type MockResourceSKUsPage struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,230 @@
+package azureclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	installerazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	"github.com/pkg/errors"
+)
+
+// IsNotFound returns true if the error returned by the Azure API indicates that the
+// requested resource does not exist.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusNotFound
+	}
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) {
+		return detailedErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+func newNetworkClientFactory(creds *installerazure.Credentials, env azure.Environment) (*armnetwork.ClientFactory, error) {
+	cloudConfig := cloud.Configuration{
+		ActiveDirectoryAuthorityHost: env.ActiveDirectoryEndpoint,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Audience: env.TokenAudience,
+				Endpoint: env.ResourceManagerEndpoint,
+			},
+		},
+	}
+	credential, err := azidentity.NewClientSecretCredential(creds.TenantID, creds.ClientID, creds.ClientSecret,
+		&azidentity.ClientSecretCredentialOptions{ClientOptions: azcore.ClientOptions{Cloud: cloudConfig}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Azure credential")
+	}
+	return armnetwork.NewClientFactory(creds.SubscriptionID, credential,
+		&arm.ClientOptions{ClientOptions: azcore.ClientOptions{Cloud: cloudConfig}})
+}
+
+func (c *azureClient) SubscriptionID() string {
+	return c.subscriptionID
+}
+
+func (c *azureClient) GetLoadBalancer(ctx context.Context, resourceGroupName string, loadBalancerName string) (*armnetwork.LoadBalancer, error) {
+	resp, err := c.networkClientFactory.NewLoadBalancersClient().Get(ctx, resourceGroupName, loadBalancerName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.LoadBalancer, nil
+}
+
+func (c *azureClient) GetSubnet(ctx context.Context, resourceGroupName string, virtualNetworkName string, subnetName string) (*armnetwork.Subnet, error) {
+	resp, err := c.networkClientFactory.NewSubnetsClient().Get(ctx, resourceGroupName, virtualNetworkName, subnetName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Subnet, nil
+}
+
+func (c *azureClient) CreateOrUpdateSubnet(ctx context.Context, resourceGroupName string, virtualNetworkName string, subnetName string, subnet armnetwork.Subnet) (*armnetwork.Subnet, error) {
+	poller, err := c.networkClientFactory.NewSubnetsClient().BeginCreateOrUpdate(ctx, resourceGroupName, virtualNetworkName, subnetName, subnet, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Subnet, nil
+}
+
+func (c *azureClient) GetPrivateLinkService(ctx context.Context, resourceGroupName string, serviceName string) (*armnetwork.PrivateLinkService, error) {
+	resp, err := c.networkClientFactory.NewPrivateLinkServicesClient().Get(ctx, resourceGroupName, serviceName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.PrivateLinkService, nil
+}
+
+func (c *azureClient) CreateOrUpdatePrivateLinkService(ctx context.Context, resourceGroupName string, serviceName string, service armnetwork.PrivateLinkService) (*armnetwork.PrivateLinkService, error) {
+	poller, err := c.networkClientFactory.NewPrivateLinkServicesClient().BeginCreateOrUpdate(ctx, resourceGroupName, serviceName, service, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.PrivateLinkService, nil
+}
+
+func (c *azureClient) DeletePrivateLinkService(ctx context.Context, resourceGroupName string, serviceName string) error {
+	poller, err := c.networkClientFactory.NewPrivateLinkServicesClient().BeginDelete(ctx, resourceGroupName, serviceName, nil)
+	if err != nil {
+		return err
+	}
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *azureClient) GetPrivateEndpoint(ctx context.Context, resourceGroupName string, endpointName string) (*armnetwork.PrivateEndpoint, error) {
+	resp, err := c.networkClientFactory.NewPrivateEndpointsClient().Get(ctx, resourceGroupName, endpointName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.PrivateEndpoint, nil
+}
+
+func (c *azureClient) ListPrivateEndpoints(ctx context.Context, resourceGroupName string) ([]*armnetwork.PrivateEndpoint, error) {
+	var endpoints []*armnetwork.PrivateEndpoint
+	pager := c.networkClientFactory.NewPrivateEndpointsClient().NewListPager(resourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, page.Value...)
+	}
+	return endpoints, nil
+}
+
+func (c *azureClient) CreateOrUpdatePrivateEndpoint(ctx context.Context, resourceGroupName string, endpointName string, endpoint armnetwork.PrivateEndpoint) (*armnetwork.PrivateEndpoint, error) {
+	poller, err := c.networkClientFactory.NewPrivateEndpointsClient().BeginCreateOrUpdate(ctx, resourceGroupName, endpointName, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.PrivateEndpoint, nil
+}
+
+func (c *azureClient) DeletePrivateEndpoint(ctx context.Context, resourceGroupName string, endpointName string) error {
+	poller, err := c.networkClientFactory.NewPrivateEndpointsClient().BeginDelete(ctx, resourceGroupName, endpointName, nil)
+	if err != nil {
+		return err
+	}
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (c *azureClient) GetNetworkInterface(ctx context.Context, resourceGroupName string, interfaceName string) (*armnetwork.Interface, error) {
+	resp, err := c.networkClientFactory.NewInterfacesClient().Get(ctx, resourceGroupName, interfaceName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Interface, nil
+}
+
+func (c *azureClient) GetPrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error) {
+	return c.privateZonesClient.Get(ctx, resourceGroupName, zone)
+}
+
+func (c *azureClient) CreateOrUpdatePrivateZone(ctx context.Context, resourceGroupName string, zone string) (privatedns.PrivateZone, error) {
+	future, err := c.privateZonesClient.CreateOrUpdate(ctx, resourceGroupName, zone, privatedns.PrivateZone{
+		Location: to.StringPtr("global"),
+	}, "", "")
+	if err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	if err := future.WaitForCompletionRef(ctx, c.privateZonesClient.Client); err != nil {
+		return privatedns.PrivateZone{}, err
+	}
+	return future.Result(*c.privateZonesClient)
+}
+
+func (c *azureClient) DeletePrivateZone(ctx context.Context, resourceGroupName string, zone string) error {
+	future, err := c.privateZonesClient.Delete(ctx, resourceGroupName, zone, "")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, c.privateZonesClient.Client)
+}
+
+func (c *azureClient) CreateOrUpdatePrivateRecordSet(ctx context.Context, resourceGroupName string, zone string, recordSetName string, recordType privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error) {
+	return c.privateRecordSetsClient.CreateOrUpdate(ctx, resourceGroupName, zone, recordType, recordSetName, recordSet, "", "")
+}
+
+func (c *azureClient) ListVirtualNetworkLinks(ctx context.Context, resourceGroupName string, zone string) ([]privatedns.VirtualNetworkLink, error) {
+	var links []privatedns.VirtualNetworkLink
+	for page, err := c.virtualNetworkLinksClient.List(ctx, resourceGroupName, zone, nil); page.NotDone(); err = page.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, page.Values()...)
+	}
+	return links, nil
+}
+
+func (c *azureClient) CreateOrUpdateVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string, virtualNetworkID string) (privatedns.VirtualNetworkLink, error) {
+	future, err := c.virtualNetworkLinksClient.CreateOrUpdate(ctx, resourceGroupName, zone, linkName, privatedns.VirtualNetworkLink{
+		Location: to.StringPtr("global"),
+		VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+			VirtualNetwork:      &privatedns.SubResource{ID: to.StringPtr(virtualNetworkID)},
+			RegistrationEnabled: to.BoolPtr(false),
+		},
+	}, "", "")
+	if err != nil {
+		return privatedns.VirtualNetworkLink{}, err
+	}
+	if err := future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client); err != nil {
+		return privatedns.VirtualNetworkLink{}, err
+	}
+	return future.Result(*c.virtualNetworkLinksClient)
+}
+
+func (c *azureClient) DeleteVirtualNetworkLink(ctx context.Context, resourceGroupName string, zone string, linkName string) error {
+	future, err := c.virtualNetworkLinksClient.Delete(ctx, resourceGroupName, zone, linkName, "")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(ctx, c.virtualNetworkLinksClient.Client)
+}
@@ -76,6 +76,9 @@ type Builder struct {
 	// HibernateAfter is the duration after which a running cluster should be automatically hibernated.
 	HibernateAfter *time.Duration
 
+	// HibernationSchedule defines recurring windows during which the cluster should be running.
+	HibernationSchedule *hivev1.HibernationSchedule
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	InstallAttemptsLimit *int32
 
@@ -338,6 +341,10 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		cd.Spec.HibernateAfter = &metav1.Duration{Duration: *o.HibernateAfter}
 	}
 
+	if o.HibernationSchedule != nil {
+		cd.Spec.HibernationSchedule = o.HibernationSchedule.DeepCopy()
+	}
+
 	cd.Spec.InstallAttemptsLimit = o.InstallAttemptsLimit
 
 	cd.Spec.Provisioning.InstallerEnv = o.InstallerEnv
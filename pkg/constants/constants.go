@@ -82,6 +82,10 @@ const (
 	// JobTypeProvision is used as a value of JobTypeLabel that says the Job is specifically running the provisioner.
 	JobTypeProvision = "provision"
 
+	// JobTypeRecycle is used as a value of JobTypeLabel that says the Job is specifically cleaning up a cluster being
+	// returned to its pool.
+	JobTypeRecycle = "recycle"
+
 	// DNSZoneTypeLabel is the label that is used to identify what a DNSZone is being used for.
 	DNSZoneTypeLabel = "hive.openshift.io/dnszone-type"
 
@@ -130,6 +134,10 @@ const (
 	// The default is defined above.
 	HiveNamespaceEnvVar = "HIVE_NS"
 
+	// HiveControllersServiceAccountName is the name of the service account, in the hive namespace, as which the core
+	// hive-controllers run.
+	HiveControllersServiceAccountName = "hive-controllers"
+
 	// CheckpointName is the name of the object in each namespace in which the namespace's backup information is stored.
 	CheckpointName = "hive"
 
@@ -140,6 +148,9 @@ const (
 	// the cluster, causing its machines to remain in their current state (unless acted on externally) regardless of CD.Spec.PowerState.
 	PowerStatePauseAnnotation = "hive.openshift.io/powerstate-pause"
 
+	// HibernationScheduleTimeFormat is the layout of the Start and End times of a HibernationScheduleWindow.
+	HibernationScheduleTimeFormat = "15:04"
+
 	// ReconcilePauseAnnotation is an annotation used by ClusterDeployment. If "true", (most) controllers will (mostly) ignore the CD until
 	// the annotation is cleared/non-truthy. Exceptions:
 	// - clusterclaim: We'll still mark the CD for deletion by the clusterpool controller when the claim is deleted.
@@ -380,6 +391,19 @@ const (
 	// InstallLogsUploadProviderAWS is used to specify that AWS is the cloud provider to upload logs to.
 	InstallLogsUploadProviderAWS = PlatformAWS
 
+	// InstallLogsUploadProviderAzure is used to specify that Azure is the cloud provider to upload logs to.
+	InstallLogsUploadProviderAzure = PlatformAzure
+
+	// InstallLogsUploadProviderGCP is used to specify that GCP is the cloud provider to upload logs to.
+	InstallLogsUploadProviderGCP = PlatformGCP
+
+	// InstallLogsUploadProviderInCluster is used to specify that logs are stored on the hub cluster.
+	InstallLogsUploadProviderInCluster = "incluster"
+
+	// InstallLogsRetentionEnvVar is the environment variable specifying how long uploaded logs are kept, as a
+	// duration string.
+	InstallLogsRetentionEnvVar = "HIVE_INSTALL_LOGS_RETENTION"
+
 	// InstallLogsCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for storing logs.
 	InstallLogsCredentialsSecretRefEnvVar = "HIVE_INSTALL_LOGS_CREDENTIALS_SECRET"
 
@@ -392,6 +416,40 @@ const (
 	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
 	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
 
+	// InstallLogsAzureStorageAccountEnvVar is the environment variable specifying the Azure storage account to use.
+	InstallLogsAzureStorageAccountEnvVar = "HIVE_INSTALL_LOGS_AZURE_STORAGE_ACCOUNT"
+
+	// InstallLogsAzureContainerEnvVar is the environment variable specifying the Azure blob container to use.
+	InstallLogsAzureContainerEnvVar = "HIVE_INSTALL_LOGS_AZURE_CONTAINER"
+
+	// InstallLogsAzureCloudNameEnvVar is the environment variable specifying the Azure cloud environment to use.
+	InstallLogsAzureCloudNameEnvVar = "HIVE_INSTALL_LOGS_AZURE_CLOUD_NAME"
+
+	// InstallLogsGCSBucketEnvVar is the environment variable specifying the GCS bucket to use.
+	InstallLogsGCSBucketEnvVar = "HIVE_INSTALL_LOGS_GCS_BUCKET"
+
+	// InstallLogsInClusterStorageTypeEnvVar is the environment variable specifying the kind of object logs stored on
+	// the hub cluster are written to. See hivev1.FailedProvisionLogStorageType.
+	InstallLogsInClusterStorageTypeEnvVar = "HIVE_INSTALL_LOGS_STORAGE_TYPE"
+
+	// InstallLogsPVCNameEnvVar is the environment variable specifying the PersistentVolumeClaim logs are written to.
+	// When set, the claim is mounted in the installer pod at InstallLogsPVCMountPath.
+	InstallLogsPVCNameEnvVar = "HIVE_INSTALL_LOGS_PVC_NAME"
+
+	// InstallLogsPVCMountPath is where the PersistentVolumeClaim logs are written to is mounted in the installer pod.
+	InstallLogsPVCMountPath = "/install-logs"
+
+	// InstallLogsLabel is the label identifying Secrets and ConfigMaps holding logs of failed provisions.
+	InstallLogsLabel = "hive.openshift.io/install-logs"
+
+	// InstallLogsFileAnnotation is the annotation on Secrets and ConfigMaps holding logs of failed provisions naming
+	// the log file whose contents, or chunk of them, they hold.
+	InstallLogsFileAnnotation = "hive.openshift.io/install-logs-file"
+
+	// InstallLogsChunkAnnotation is the annotation on Secrets and ConfigMaps holding logs of failed provisions giving
+	// the index of the chunk of the log file they hold, starting from 0.
+	InstallLogsChunkAnnotation = "hive.openshift.io/install-logs-chunk"
+
 	// HiveFakeClusterAnnotation can be set to true on a cluster deployment to create a fake cluster that never
 	// provisions resources, and all communication with the cluster will be faked.
 	HiveFakeClusterAnnotation = "hive.openshift.io/fake-cluster"
@@ -404,6 +462,11 @@ const (
 	// group for which first applied metrics can be reported
 	SyncSetMetricsGroupAnnotation = "hive.openshift.io/syncset-metrics-group"
 
+	// ServingCertificateHashAnnotation is set by the remoteingress controller on the IngressControllers it syncs to
+	// a cluster to a hash of the serving certificate bundle secret, so that the secret is synced again when its
+	// contents change, for example when a generated certificate is renewed.
+	ServingCertificateHashAnnotation = "hive.openshift.io/serving-certificate-hash"
+
 	// RemovePoolClusterAnnotation is used on a ClusterDeployment to indicate that the cluster
 	// is no longer required and therefore should be removed/deprovisioned and removed from the pool.
 	// The ClusterPool must observe its MaxConcurrent budget; so this annotation is used to delegate the
@@ -412,6 +475,26 @@ const (
 	// CD when the claim is deleted).
 	RemovePoolClusterAnnotation = "hive.openshift.io/remove-cluster-from-pool"
 
+	// ClaimRenewAnnotation is set on a ClusterClaim to request that it be renewed, restarting its lifetime. The
+	// clusterclaim controller removes the annotation once it has acted on the request. Renewals are limited by
+	// the ClusterPool's ClaimLifetime.MaxRenewals, and rejected for claims without a lifetime. The value of the
+	// annotation is ignored.
+	ClaimRenewAnnotation = "hive.openshift.io/renew-claim"
+
+	// RecyclePoolClusterAnnotation is set by the clusterpool controller on a previously claimed ClusterDeployment which
+	// is being cleaned up to be returned to its pool. Its value is the time at which the cleanup began.
+	RecyclePoolClusterAnnotation = "hive.openshift.io/recycle-cluster-for-pool"
+
+	// PoolClusterRecycleCountAnnotation records on a ClusterDeployment the number of times it has been returned to its
+	// pool.
+	PoolClusterRecycleCountAnnotation = "hive.openshift.io/cluster-pool-recycle-count"
+
+	// RecycleCredentialsRotatedAnnotation is set by the clusterpool controller on a recycling ClusterDeployment once
+	// it has rotated the cluster's admin credentials, so that the previous claimant can no longer use them. Its value
+	// is "switched" while the cluster still trusts the previous admin client CA, and "true" once the rotation is
+	// complete. It is removed when the recycle finishes.
+	RecycleCredentialsRotatedAnnotation = "hive.openshift.io/recycle-credentials-rotated"
+
 	// ClusterDeploymentPoolSpecHashAnnotation annotates a ClusterDeployment. It is an opaque value representing
 	// the state of the important (to ClusterDeployments) fields of the ClusterPool at the time this CD was created.
 	// It is used by the clusterpool controller to determine whether its unclaimed ClusterDeployments are current or
@@ -442,6 +525,14 @@ const (
 	// configurations. See HiveConfig.Spec.MetricsConfig.
 	MetricsConfigFileEnvVar = "METRICS_CONFIG_FILE"
 
+	// CertificateGenerationConfigFileEnvVar points to a text file containing configuration for generating
+	// certificate bundles. See HiveConfig.Spec.CertificateGeneration.
+	CertificateGenerationConfigFileEnvVar = "CERTIFICATE_GENERATION_CONFIG_FILE"
+
+	// DNSDelegationAuditConfigFileEnvVar points to a text file containing configuration for auditing the
+	// delegation of DNSZones. See HiveConfig.Spec.DNSDelegationAudit.
+	DNSDelegationAuditConfigFileEnvVar = "DNS_DELEGATION_AUDIT_CONFIG_FILE"
+
 	// HiveReleaseImageVerificationConfigMapNamespaceEnvVar is used to configure the config map that will be used
 	// to verify the release images being used for cluster deployments.
 	HiveReleaseImageVerificationConfigMapNamespaceEnvVar = "HIVE_RELEASE_IMAGE_VERIFICATION_CONFIGMAP_NS"
@@ -476,6 +567,10 @@ const (
 	// IBMCloudCredentialsSecretKey is a key used to store IBM environment variable credentials
 	IBMCloudCredentialsEnvSecretKey = "ibm-credentials.env"
 
+	// RFC2136TSIGSecretKey is a key used to store the base64 encoded TSIG key used to sign RFC2136
+	// dynamic updates within a secret
+	RFC2136TSIGSecretKey = "tsig-secret"
+
 	// DisableCreationWebHookForDisasterRecovery is a label that can be added to CRs for which we
 	// normally validate creation. Specific hooks can be disabled by setting this label to (string)
 	// "true".
@@ -563,11 +658,24 @@ const (
 	// ClusterDeployment.Status.ClusterVersionStatus.
 	SyncClusterVersionStatusAnnotation = "hive.openshift.io/sync-clusterversion-status"
 
+	// ClusterUpgradeAnnotation is set on a ClusterDeployment to the name of the ClusterUpgrade which set its
+	// spec.upgrade. Other ClusterUpgrades leave the ClusterDeployment alone until that upgrade has completed.
+	ClusterUpgradeAnnotation = "hive.openshift.io/cluster-upgrade"
+
+	// HubFederationDestinationLabel is set on a ClusterDeployment being moved to another hub of the federation by
+	// the hubfederation controller, to the HubName of that hub. It selects the ClusterDeployment for the
+	// ClusterRelocate moving clusters to that hub, and is removed by the destination hub once the cluster arrives.
+	HubFederationDestinationLabel = "hive.openshift.io/federation-destination"
+
 	// LegacyDeprovisionAnnotation, if set to "true" on a ClusterDeployment, causes hive to revert to the legacy
 	// deprovision algorithm whereby individual cluster metadata fields are fed into the deprovision pod. This is
 	// provided as a workaround for speculative problems using the new algorithm whereby the metadata.json from
 	// the provisioning process is passed through directly to the destroyer.
 	LegacyDeprovisionAnnotation = "hive.openshift.io/legacy-deprovision"
+
+	// DefaultMachinePoolReplicas is the number of machines the installer gives a compute pool which specifies neither
+	// replicas nor autoscaling. Hive counts such pools as this many machines wherever it sizes clusters.
+	DefaultMachinePoolReplicas = 3
 )
 
 // GetMergedPullSecretName returns name for merged pull secret name per cluster deployment
@@ -0,0 +1,309 @@
+package certificatebundle
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/acme"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/dnszone"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const acmeChallengeRecordPrefix = "_acme-challenge."
+
+// dnsPropagationDelay is how long to wait after setting the DNS-01 challenge records before asking the ACME server
+// to validate them, so that they have reached all of the zone's name servers.
+var dnsPropagationDelay = 30 * time.Second
+
+// acmePollInterval is how often an ACME order is checked on while its challenges are being validated.
+const acmePollInterval = 10 * time.Second
+
+// acmeIssuer issues certificates from an ACME server, answering DNS-01 challenges in the managed DNS zone of a
+// cluster.
+type acmeIssuer struct {
+	client *acme.Client
+	email  string
+	zone   string
+	dns    dnszone.Actuator
+	logger log.FieldLogger
+}
+
+var _ issuer = &acmeIssuer{}
+
+// newACMEIssuer returns an issuer for the ACME server of the configuration, which sets challenge records in the
+// managed DNS zone of the ClusterDeployment.
+func newACMEIssuer(c client.Client, config *hivev1.ACMECertificateIssuer, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*acmeIssuer, error) {
+	if !cd.Spec.ManageDNS {
+		return nil, errors.New("the ACME issuer can only be used for clusters with manageDNS set")
+	}
+	dnsZone := &hivev1.DNSZone{}
+	if err := c.Get(
+		context.TODO(),
+		client.ObjectKey{Namespace: cd.Namespace, Name: controllerutils.DNSZoneName(cd.Name)},
+		dnsZone,
+	); err != nil {
+		return nil, errors.Wrap(err, "could not get managed DNS zone")
+	}
+	actuator, err := dnszone.NewActuator(c, dnsZone, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create DNS actuator")
+	}
+	if err := actuator.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "could not get managed DNS zone from the DNS provider")
+	}
+	if exists, err := actuator.Exists(); err != nil || !exists {
+		return nil, errors.New("managed DNS zone does not exist in the DNS provider yet")
+	}
+	key, err := getACMEAccountKey(c, config, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &acmeIssuer{
+		client: &acme.Client{Key: key, DirectoryURL: config.DirectoryURL},
+		email:  config.Email,
+		zone:   dnsZone.Spec.Zone,
+		dns:    actuator,
+		logger: logger,
+	}, nil
+}
+
+// getACMEAccountKey returns the ACME account key from the secret referenced by the configuration, creating the secret
+// with a new key if it does not exist.
+func getACMEAccountKey(c client.Client, config *hivev1.ACMECertificateIssuer, logger log.FieldLogger) (crypto.Signer, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: config.AccountKeySecretRef.Name}
+	switch err := c.Get(context.TODO(), key, secret); {
+	case apierrors.IsNotFound(err):
+		accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not generate ACME account key")
+		}
+		der, err := x509.MarshalECPrivateKey(accountKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not encode ACME account key")
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}),
+			},
+		}
+		logger.WithField("secret", key).Info("creating ACME account key secret")
+		if err := c.Create(context.TODO(), secret); err != nil {
+			return nil, errors.Wrap(err, "could not create ACME account key secret")
+		}
+		return accountKey, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get ACME account key secret")
+	}
+	accountKey, err := parsePrivateKey(secret.Data[corev1.TLSPrivateKeyKey])
+	return accountKey, errors.Wrap(err, "could not parse ACME account key")
+}
+
+// issue advances the ACME order for the DNS names by one step: it creates the order and sets its DNS-01 challenge
+// records, accepts the challenges once the records have had time to propagate, waits for the challenges to be
+// validated, and finally has the certificate issued. The order is recorded in status between steps.
+func (i *acmeIssuer) issue(ctx context.Context, dnsNames []string, status *hivev1.CertificateBundleStatus) (*issuedCertificate, time.Duration, error) {
+	if status.ACMEOrder == nil {
+		requeueAfter, err := i.createOrder(ctx, dnsNames, status)
+		return nil, requeueAfter, err
+	}
+	logger := i.logger.WithField("order", status.ACMEOrder.URL)
+	order, err := i.client.GetOrder(ctx, status.ACMEOrder.URL)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not get ACME order")
+	}
+	var orderNames []string
+	for _, id := range order.Identifiers {
+		orderNames = append(orderNames, id.Value)
+	}
+	if !sets.New(orderNames...).Equal(sets.New(dnsNames...)) {
+		logger.Info("abandoning ACME order for changed DNS names")
+		i.abandonOrder(order, status)
+		requeueAfter, err := i.createOrder(ctx, dnsNames, status)
+		return nil, requeueAfter, err
+	}
+
+	switch order.Status {
+	case acme.StatusPending:
+		if setTime := status.ACMEOrder.ChallengeRecordsSetTime; setTime != nil {
+			if wait := time.Until(setTime.Add(dnsPropagationDelay)); wait > 0 {
+				logger.WithField("delay", wait).Debug("waiting for DNS-01 challenge records to propagate")
+				return nil, wait, nil
+			}
+		}
+		for _, url := range order.AuthzURLs {
+			authz, err := i.client.GetAuthorization(ctx, url)
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "could not get ACME authorization")
+			}
+			if authz.Status != acme.StatusPending {
+				continue
+			}
+			for _, challenge := range authz.Challenges {
+				if challenge.Type != "dns-01" || challenge.Status != acme.StatusPending {
+					continue
+				}
+				logger.WithField("identifier", authz.Identifier.Value).Debug("accepting DNS-01 challenge")
+				if _, err := i.client.Accept(ctx, challenge); err != nil {
+					return nil, 0, errors.Wrap(err, "could not accept ACME challenge")
+				}
+			}
+		}
+		logger.Debug("waiting for ACME challenges to be validated")
+		return nil, acmePollInterval, nil
+	case acme.StatusReady:
+		cert, err := i.finalizeOrder(ctx, order, dnsNames)
+		i.abandonOrder(order, status)
+		if err != nil {
+			return nil, 0, err
+		}
+		logger.Info("ACME order fulfilled")
+		return cert, 0, nil
+	case acme.StatusProcessing, acme.StatusValid:
+		// The order was finalized by an earlier attempt whose private key was not kept, so its certificate is of no
+		// use.
+		logger.WithField("status", order.Status).Info("abandoning ACME order finalized by an earlier attempt")
+		i.abandonOrder(order, status)
+		requeueAfter, err := i.createOrder(ctx, dnsNames, status)
+		return nil, requeueAfter, err
+	default:
+		i.abandonOrder(order, status)
+		if order.Error != nil {
+			return nil, 0, errors.Wrap(order.Error, "ACME order failed")
+		}
+		return nil, 0, fmt.Errorf("ACME order is %s", order.Status)
+	}
+}
+
+// createOrder creates an ACME order for the DNS names, sets the DNS-01 challenge records of its pending
+// authorizations, and records the order in status. It returns how long to wait before checking on the order.
+func (i *acmeIssuer) createOrder(ctx context.Context, dnsNames []string, status *hivev1.CertificateBundleStatus) (time.Duration, error) {
+	account := &acme.Account{}
+	if i.email != "" {
+		account.Contact = []string{"mailto:" + i.email}
+	}
+	if _, err := i.client.Register(ctx, account, acme.AcceptTOS); err != nil && err != acme.ErrAccountAlreadyExists {
+		return 0, errors.Wrap(err, "could not register ACME account")
+	}
+
+	order, err := i.client.AuthorizeOrder(ctx, acme.DomainIDs(dnsNames...))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not create ACME order")
+	}
+	logger := i.logger.WithField("order", order.URI)
+	logger.Info("created ACME order")
+	records := map[string][]string{}
+	for _, url := range order.AuthzURLs {
+		authz, err := i.client.GetAuthorization(ctx, url)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not get ACME authorization")
+		}
+		if authz.Status == acme.StatusValid {
+			continue
+		}
+		var challenge *acme.Challenge
+		for _, c := range authz.Challenges {
+			if c.Type == "dns-01" {
+				challenge = c
+				break
+			}
+		}
+		if challenge == nil {
+			return 0, fmt.Errorf("ACME server offered no DNS-01 challenge for %s", authz.Identifier.Value)
+		}
+		name := acmeChallengeRecordPrefix + authz.Identifier.Value
+		if !strings.HasSuffix(name, "."+i.zone) {
+			return 0, fmt.Errorf("%s is not in the managed DNS zone %s", authz.Identifier.Value, i.zone)
+		}
+		value, err := i.client.DNS01ChallengeRecord(challenge.Token)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not compute DNS-01 challenge record")
+		}
+		records[name] = append(records[name], value)
+	}
+
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := i.dns.SetTXTRecord(name, records[name]); err != nil {
+			i.deleteChallengeRecords(names)
+			return 0, errors.Wrap(err, "could not set DNS-01 challenge record")
+		}
+	}
+	status.ACMEOrder = &hivev1.ACMEOrderStatus{URL: order.URI}
+	if len(names) == 0 {
+		return acmePollInterval, nil
+	}
+	now := metav1.Now()
+	status.ACMEOrder.ChallengeRecordsSetTime = &now
+	logger.WithField("delay", dnsPropagationDelay).Debug("waiting for DNS-01 challenge records to propagate")
+	return dnsPropagationDelay, nil
+}
+
+// finalizeOrder has the certificate of a ready ACME order issued for a new private key.
+func (i *acmeIssuer) finalizeOrder(ctx context.Context, order *acme.Order, dnsNames []string) (*issuedCertificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, certificateKeyBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate private key")
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: dnsNames[0]},
+		DNSNames: dnsNames,
+	}, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create certificate signing request")
+	}
+	chain, _, err := i.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not finalize ACME order")
+	}
+	return &issuedCertificate{chain: chain, key: key}, nil
+}
+
+// abandonOrder deletes the DNS-01 challenge records of an ACME order and removes the order from status.
+func (i *acmeIssuer) abandonOrder(order *acme.Order, status *hivev1.CertificateBundleStatus) {
+	names := sets.New[string]()
+	for _, id := range order.Identifiers {
+		names.Insert(acmeChallengeRecordPrefix + strings.TrimPrefix(id.Value, "*."))
+	}
+	i.deleteChallengeRecords(sets.List(names))
+	status.ACMEOrder = nil
+}
+
+func (i *acmeIssuer) deleteChallengeRecords(names []string) {
+	for _, name := range names {
+		if err := i.dns.DeleteTXTRecord(name); err != nil {
+			i.logger.WithError(err).WithField("record", name).Warn("could not delete DNS-01 challenge record")
+		}
+	}
+}
+
+// signed returns true, as certificates from an ACME server are only replaced when they are due for renewal.
+func (i *acmeIssuer) signed(*x509.Certificate) bool {
+	return true
+}
@@ -0,0 +1,300 @@
+package certificatebundle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/dnszone"
+)
+
+func TestACMEIssuerIssue(t *testing.T) {
+	caCert, caKey := buildTestCA(t)
+	server := newFakeACMEServer(t, caCert, caKey)
+	defer server.Close()
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "unexpected error generating account key")
+	dns := &fakeTXTRecords{records: map[string][]string{}}
+	iss := &acmeIssuer{
+		client: &acme.Client{Key: accountKey, DirectoryURL: server.URL + "/directory"},
+		zone:   "cluster.example.com",
+		dns:    dns,
+		logger: log.WithField("test", t.Name()),
+	}
+	dnsNames := []string{"api.cluster.example.com", "*.apps.cluster.example.com"}
+	status := &hivev1.CertificateBundleStatus{Name: "default"}
+
+	// The order is created and its challenge records are set.
+	cert, requeueAfter, err := iss.issue(context.Background(), dnsNames, status)
+	require.NoError(t, err, "unexpected error creating order")
+	assert.Nil(t, cert, "unexpected certificate before challenges are validated")
+	assert.Equal(t, dnsPropagationDelay, requeueAfter, "unexpected requeue while records propagate")
+	require.NotNil(t, status.ACMEOrder, "expected order in status")
+	assert.NotNil(t, status.ACMEOrder.ChallengeRecordsSetTime, "expected challenge records set time")
+	assert.ElementsMatch(t,
+		[]string{"_acme-challenge.api.cluster.example.com", "_acme-challenge.apps.cluster.example.com"},
+		dns.names(), "unexpected challenge records")
+
+	// The challenges are not accepted before the records have propagated.
+	cert, requeueAfter, err = iss.issue(context.Background(), dnsNames, status)
+	require.NoError(t, err, "unexpected error waiting for propagation")
+	assert.Nil(t, cert, "unexpected certificate before challenges are validated")
+	assert.True(t, requeueAfter > 0 && requeueAfter <= dnsPropagationDelay, "unexpected requeue while records propagate: %v", requeueAfter)
+	assert.Zero(t, server.acceptedCount(), "unexpected accepted challenges")
+
+	// Once the records have propagated, the challenges are accepted.
+	past := metav1.NewTime(time.Now().Add(-dnsPropagationDelay))
+	status.ACMEOrder.ChallengeRecordsSetTime = &past
+	cert, requeueAfter, err = iss.issue(context.Background(), dnsNames, status)
+	require.NoError(t, err, "unexpected error accepting challenges")
+	assert.Nil(t, cert, "unexpected certificate before challenges are validated")
+	assert.Equal(t, acmePollInterval, requeueAfter, "unexpected requeue while challenges are validated")
+	assert.Equal(t, 2, server.acceptedCount(), "expected challenges to be accepted")
+
+	// Once the challenges are validated, the certificate is issued and the challenge records are removed.
+	server.validate()
+	cert, requeueAfter, err = iss.issue(context.Background(), dnsNames, status)
+	require.NoError(t, err, "unexpected error finalizing order")
+	require.NotNil(t, cert, "expected certificate")
+	assert.Zero(t, requeueAfter, "unexpected requeue after issuance")
+	assert.Nil(t, status.ACMEOrder, "expected order to be removed from status")
+	assert.Empty(t, dns.names(), "expected challenge records to be removed")
+	leaf, err := x509.ParseCertificate(cert.chain[0])
+	require.NoError(t, err, "unexpected error parsing certificate")
+	assert.ElementsMatch(t, dnsNames, leaf.DNSNames, "unexpected certificate DNS names")
+	assert.True(t, cert.key.PublicKey.Equal(leaf.PublicKey), "certificate does not match private key")
+}
+
+func TestACMEIssuerIssueFailedOrder(t *testing.T) {
+	caCert, caKey := buildTestCA(t)
+	server := newFakeACMEServer(t, caCert, caKey)
+	defer server.Close()
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "unexpected error generating account key")
+	dns := &fakeTXTRecords{records: map[string][]string{}}
+	iss := &acmeIssuer{
+		client: &acme.Client{Key: accountKey, DirectoryURL: server.URL + "/directory"},
+		zone:   "cluster.example.com",
+		dns:    dns,
+		logger: log.WithField("test", t.Name()),
+	}
+	dnsNames := []string{"api.cluster.example.com"}
+	status := &hivev1.CertificateBundleStatus{Name: "default"}
+
+	_, _, err = iss.issue(context.Background(), dnsNames, status)
+	require.NoError(t, err, "unexpected error creating order")
+	require.NotNil(t, status.ACMEOrder, "expected order in status")
+
+	server.invalidate()
+	_, _, err = iss.issue(context.Background(), dnsNames, status)
+	assert.Error(t, err, "expected error for invalid order")
+	assert.Nil(t, status.ACMEOrder, "expected failed order to be removed from status")
+	assert.Empty(t, dns.names(), "expected challenge records to be removed")
+}
+
+// fakeTXTRecords is a DNS actuator which only keeps TXT records.
+type fakeTXTRecords struct {
+	dnszone.Actuator
+	records map[string][]string
+}
+
+func (f *fakeTXTRecords) SetTXTRecord(name string, values []string) error {
+	f.records[name] = values
+	return nil
+}
+
+func (f *fakeTXTRecords) DeleteTXTRecord(name string) error {
+	delete(f.records, name)
+	return nil
+}
+
+func (f *fakeTXTRecords) names() []string {
+	var names []string
+	for name := range f.records {
+		names = append(names, name)
+	}
+	return names
+}
+
+// fakeACMEServer is an ACME server with a single order, whose challenges are validated when the test says so.
+type fakeACMEServer struct {
+	*httptest.Server
+	t      *testing.T
+	caCert *x509.Certificate
+	caKey  interface{}
+
+	mu          sync.Mutex
+	identifiers []acme.AuthzID
+	status      string
+	accepted    map[int]bool
+	chain       []byte
+}
+
+func newFakeACMEServer(t *testing.T, caCert *x509.Certificate, caKey interface{}) *fakeACMEServer {
+	s := &fakeACMEServer{t: t, caCert: caCert, caKey: caKey, accepted: map[int]bool{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *fakeACMEServer) acceptedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.accepted)
+}
+
+// validate marks the challenges of the order valid, making the order ready.
+func (s *fakeACMEServer) validate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = acme.StatusReady
+}
+
+// invalidate fails the order.
+func (s *fakeACMEServer) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = acme.StatusInvalid
+}
+
+func (s *fakeACMEServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", time.Now().UnixNano()))
+	var payload []byte
+	if r.Method == http.MethodPost {
+		var jws struct {
+			Payload string `json:"payload"`
+		}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&jws), "unexpected error decoding request")
+		var err error
+		payload, err = base64.RawURLEncoding.DecodeString(jws.Payload)
+		require.NoError(s.t, err, "unexpected error decoding payload")
+	}
+
+	switch path := r.URL.Path; {
+	case path == "/directory":
+		s.writeJSON(w, http.StatusOK, map[string]string{
+			"newNonce":   s.URL + "/nonce",
+			"newAccount": s.URL + "/account",
+			"newOrder":   s.URL + "/order/new",
+		})
+	case path == "/nonce":
+		w.WriteHeader(http.StatusOK)
+	case path == "/account":
+		w.Header().Set("Location", s.URL+"/account/1")
+		s.writeJSON(w, http.StatusCreated, map[string]string{"status": acme.StatusValid})
+	case path == "/order/new":
+		var req struct {
+			Identifiers []acme.AuthzID `json:"identifiers"`
+		}
+		require.NoError(s.t, json.Unmarshal(payload, &req), "unexpected error decoding order")
+		s.identifiers = req.Identifiers
+		s.status = acme.StatusPending
+		s.accepted = map[int]bool{}
+		w.Header().Set("Location", s.URL+"/order/1")
+		s.writeJSON(w, http.StatusCreated, s.order())
+	case path == "/order/1":
+		s.writeJSON(w, http.StatusOK, s.order())
+	case strings.HasPrefix(path, "/authz/"):
+		var i int
+		fmt.Sscanf(path, "/authz/%d", &i)
+		id := s.identifiers[i]
+		status := acme.StatusPending
+		if s.status != acme.StatusPending {
+			status = acme.StatusValid
+		}
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"identifier": map[string]string{"type": id.Type, "value": strings.TrimPrefix(id.Value, "*.")},
+			"status":     status,
+			"wildcard":   strings.HasPrefix(id.Value, "*."),
+			"challenges": []map[string]string{s.challenge(i)},
+		})
+	case strings.HasPrefix(path, "/challenge/"):
+		var i int
+		fmt.Sscanf(path, "/challenge/%d", &i)
+		s.accepted[i] = true
+		s.writeJSON(w, http.StatusOK, s.challenge(i))
+	case path == "/finalize":
+		var req struct {
+			CSR string `json:"csr"`
+		}
+		require.NoError(s.t, json.Unmarshal(payload, &req), "unexpected error decoding finalize request")
+		der, err := base64.RawURLEncoding.DecodeString(req.CSR)
+		require.NoError(s.t, err, "unexpected error decoding CSR")
+		csr, err := x509.ParseCertificateRequest(der)
+		require.NoError(s.t, err, "unexpected error parsing CSR")
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		}
+		leaf, err := x509.CreateCertificate(rand.Reader, template, s.caCert, csr.PublicKey, s.caKey)
+		require.NoError(s.t, err, "unexpected error signing certificate")
+		s.chain = encodeCertificates([][]byte{leaf, s.caCert.Raw})
+		s.status = acme.StatusValid
+		w.Header().Set("Location", s.URL+"/order/1")
+		s.writeJSON(w, http.StatusOK, s.order())
+	case path == "/certificate":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.Write(s.chain)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *fakeACMEServer) order() map[string]interface{} {
+	authorizations := make([]string, len(s.identifiers))
+	for i := range s.identifiers {
+		authorizations[i] = fmt.Sprintf("%s/authz/%d", s.URL, i)
+	}
+	order := map[string]interface{}{
+		"status":         s.status,
+		"identifiers":    s.identifiers,
+		"authorizations": authorizations,
+		"finalize":       s.URL + "/finalize",
+	}
+	if s.status == acme.StatusValid {
+		order["certificate"] = s.URL + "/certificate"
+	}
+	return order
+}
+
+func (s *fakeACMEServer) challenge(i int) map[string]string {
+	status := acme.StatusPending
+	if s.accepted[i] {
+		status = acme.StatusProcessing
+	}
+	return map[string]string{
+		"type":   "dns-01",
+		"url":    fmt.Sprintf("%s/challenge/%d", s.URL, i),
+		"token":  fmt.Sprintf("token-%d", i),
+		"status": status,
+	}
+}
+
+func (s *fakeACMEServer) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	require.NoError(s.t, json.NewEncoder(w).Encode(v), "unexpected error encoding response")
+}
@@ -0,0 +1,415 @@
+package certificatebundle
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.CertificateBundleControllerName
+
+	defaultRenewBefore = 30 * 24 * time.Hour
+
+	// issueTimeout bounds how long a single step of issuing a certificate may take. Issuers which need longer, such as
+	// an ACME server validating challenges, record their progress and are called again later.
+	issueTimeout = 2 * time.Minute
+
+	certificateKeyBits = 2048
+
+	certificatesGeneratedReason = "CertificatesGenerated"
+	noIssuerConfiguredReason    = "NoIssuerConfigured"
+	issuerUnavailableReason     = "IssuerUnavailable"
+	generationFailedReason      = "GenerationFailed"
+)
+
+// Add creates a new CertificateBundle Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	config, err := ReadCertificateGenerationConfigFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read certificate generation configuration")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter, config), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter, config *hivev1.CertificateGenerationConfig) *ReconcileCertificateBundle {
+	return &ReconcileCertificateBundle{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		config: config,
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileCertificateBundle, concurrentReconciles int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) error {
+	// Create a new controller
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployment
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}, &handler.TypedEnqueueRequestForObject[*hivev1.ClusterDeployment]{})); err != nil {
+		return err
+	}
+
+	// Watch for changes to the issuer's secrets, so that certificates are reissued when the certificate authority is
+	// rotated.
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Secret{}, handler.TypedEnqueueRequestsFromMapFunc(r.requestsForIssuerSecret))); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForIssuerSecret returns requests for all ClusterDeployments with generated certificate bundles if secret is
+// one of the secrets of the configured issuer.
+func (r *ReconcileCertificateBundle) requestsForIssuerSecret(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+	if r.config == nil || secret.Namespace != controllerutils.GetHiveNamespace() {
+		return nil
+	}
+	switch {
+	case r.config.ACME != nil && secret.Name == r.config.ACME.AccountKeySecretRef.Name:
+	case r.config.ACME == nil && r.config.CA != nil && secret.Name == r.config.CA.SecretRef.Name:
+	default:
+		return nil
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(ctx, cdList); err != nil {
+		r.logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterDeployments")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, cd := range cdList.Items {
+		for _, bundle := range cd.Spec.CertificateBundles {
+			if bundle.Generate {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileCertificateBundle{}
+
+// ReconcileCertificateBundle generates and renews the certificate bundles of a ClusterDeployment which have generate
+// set. The controlplanecerts and remoteingress controllers sync the generated secrets to the cluster, and pick up
+// renewed certificates when the bundle status of the ClusterDeployment changes.
+type ReconcileCertificateBundle struct {
+	client.Client
+	config *hivev1.CertificateGenerationConfig
+	logger log.FieldLogger
+}
+
+// Reconcile generates the certificate bundles of a ClusterDeployment which have generate set and are missing, about to
+// expire, or no longer cover the bundle's DNS names.
+func (r *ReconcileCertificateBundle) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.TODO(), request.NamespacedName, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		cdLog.WithError(err).Error("error looking up cluster deployment")
+		return reconcile.Result{}, err
+	}
+	cdLog = controllerutils.AddLogFields(controllerutils.MetaObjectLogTagger{Object: cd}, cdLog)
+
+	if paused, err := strconv.ParseBool(cd.Annotations[constants.ReconcilePauseAnnotation]); err == nil && paused {
+		cdLog.Info("skipping reconcile due to ClusterDeployment pause annotation")
+		return reconcile.Result{}, nil
+	}
+	if cd.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	var bundles []hivev1.CertificateBundleSpec
+	for _, bundle := range cd.Spec.CertificateBundles {
+		if bundle.Generate {
+			bundles = append(bundles, bundle)
+		}
+	}
+	if len(bundles) == 0 && len(cd.Status.CertificateBundles) == 0 {
+		cdLog.Debug("no certificate bundles to generate")
+		return reconcile.Result{}, nil
+	}
+
+	original := cd.DeepCopy()
+	if len(bundles) == 0 {
+		cd.Status.CertificateBundles = nil
+		return reconcile.Result{}, r.updateStatus(original, cd, cdLog)
+	}
+
+	if r.config == nil || (r.config.ACME == nil && r.config.CA == nil) {
+		cdLog.Warn("certificate bundles are to be generated, but no issuer is configured")
+		r.setGenerationFailedCondition(cd, corev1.ConditionTrue, noIssuerConfiguredReason, "HiveConfig does not configure certificate generation")
+		return reconcile.Result{}, r.updateStatus(original, cd, cdLog)
+	}
+	iss, err := r.newIssuer(cd, cdLog)
+	if err != nil {
+		cdLog.WithError(err).Error("could not set up certificate issuer")
+		r.setGenerationFailedCondition(cd, corev1.ConditionTrue, issuerUnavailableReason, err.Error())
+		if statusErr := r.updateStatus(original, cd, cdLog); statusErr != nil {
+			return reconcile.Result{}, statusErr
+		}
+		return reconcile.Result{}, err
+	}
+
+	renewBefore := defaultRenewBefore
+	if r.config.RenewBefore != nil {
+		renewBefore = r.config.RenewBefore.Duration
+	}
+	var statuses []hivev1.CertificateBundleStatus
+	var requeueAfter time.Duration
+	var generationErr error
+	pending := false
+	for _, bundle := range bundles {
+		logger := cdLog.WithField("certificateBundle", bundle.Name)
+		status, bundleRequeueAfter, err := r.reconcileBundle(ctx, cd, bundle, iss, renewBefore, logger)
+		if err != nil {
+			logger.WithError(err).Error("failed to generate certificate bundle")
+			r.setGenerationFailedCondition(cd, corev1.ConditionTrue, generationFailedReason, fmt.Sprintf("failed to generate certificate bundle %s: %v", bundle.Name, err))
+			generationErr = err
+		}
+		if status.ACMEOrder != nil {
+			pending = true
+		}
+		statuses = append(statuses, status)
+		if bundleRequeueAfter > 0 && (requeueAfter == 0 || bundleRequeueAfter < requeueAfter) {
+			requeueAfter = bundleRequeueAfter
+		}
+	}
+	cd.Status.CertificateBundles = statuses
+	if generationErr == nil && !pending {
+		r.setGenerationFailedCondition(cd, corev1.ConditionFalse, certificatesGeneratedReason, "Certificate bundles have been generated")
+	}
+	if err := r.updateStatus(original, cd, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+	if generationErr != nil {
+		return reconcile.Result{}, generationErr
+	}
+	if pending {
+		cdLog.WithField("requeueAfter", requeueAfter).Debug("certificate bundles are being generated")
+	} else {
+		cdLog.WithField("requeueAfter", requeueAfter).Debug("certificate bundles are current")
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// newIssuer returns the issuer configured in HiveConfig for the ClusterDeployment.
+func (r *ReconcileCertificateBundle) newIssuer(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (issuer, error) {
+	if r.config.ACME != nil {
+		return newACMEIssuer(r.Client, r.config.ACME, cd, logger)
+	}
+	return newCAIssuer(r.Client, r.config.CA)
+}
+
+// reconcileBundle generates a certificate bundle if its secret does not hold a certificate for the bundle's DNS names,
+// signed by the issuer, which is not yet due for renewal. It returns the status of the bundle, and how long to wait
+// before reconciling the bundle again. If the issuer is still working on the certificate, the status records the
+// issuer's progress and the previous certificate, if any, is kept until the new one is issued.
+func (r *ReconcileCertificateBundle) reconcileBundle(
+	ctx context.Context,
+	cd *hivev1.ClusterDeployment,
+	bundle hivev1.CertificateBundleSpec,
+	iss issuer,
+	renewBefore time.Duration,
+	logger log.FieldLogger,
+) (hivev1.CertificateBundleStatus, time.Duration, error) {
+	status := previousBundleStatus(cd, bundle.Name)
+	dnsNames := certificateDNSNames(cd, bundle)
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: cd.Namespace, Name: bundle.CertificateSecretRef.Name}, secret); {
+	case apierrors.IsNotFound(err):
+		logger.Info("generating missing certificate bundle")
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: bundle.CertificateSecretRef.Name},
+		}
+	case err != nil:
+		return status, 0, errors.Wrap(err, "could not get certificate bundle secret")
+	default:
+		cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+		switch {
+		case err != nil:
+			logger.WithError(err).Info("regenerating certificate bundle which cannot be parsed")
+		case !sets.New(cert.DNSNames...).Equal(sets.New(dnsNames...)):
+			logger.WithField("dnsNames", dnsNames).Info("regenerating certificate bundle for changed DNS names")
+		case !iss.signed(cert):
+			logger.Info("regenerating certificate bundle which was not signed by the current issuer")
+		case time.Now().Before(cert.NotAfter.Add(-renewBefore)):
+			logger.Debug("certificate bundle is current")
+			current := bundleStatus(bundle.Name, cert, renewBefore)
+			return current, time.Until(current.RenewalTime.Time), nil
+		default:
+			logger.WithField("notAfter", cert.NotAfter).Info("renewing certificate bundle")
+		}
+	}
+
+	issueCtx, cancel := context.WithTimeout(ctx, issueTimeout)
+	defer cancel()
+	issued, requeueAfter, err := iss.issue(issueCtx, dnsNames, &status)
+	if err != nil {
+		return status, 0, err
+	}
+	if issued == nil {
+		logger.WithField("requeueAfter", requeueAfter).Info("certificate bundle is being issued")
+		return status, requeueAfter, nil
+	}
+	cert, err := x509.ParseCertificate(issued.chain[0])
+	if err != nil {
+		return status, 0, errors.Wrap(err, "could not parse issued certificate")
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[corev1.TLSCertKey] = encodeCertificates(issued.chain)
+	secret.Data[corev1.TLSPrivateKeyKey] = encodePrivateKey(issued.key)
+	secret.Labels = k8slabels.AddLabel(secret.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	if secret.ResourceVersion == "" {
+		// Only secrets created here are typed and owned by the ClusterDeployment. The type of an existing secret
+		// cannot be changed, and it may be controlled by another object.
+		secret.Type = corev1.SecretTypeTLS
+		if err := controllerutil.SetControllerReference(cd, secret, r.Scheme()); err != nil {
+			return status, 0, errors.Wrap(err, "could not set owner reference on certificate bundle secret")
+		}
+		err = r.Create(context.TODO(), secret)
+	} else {
+		err = r.Update(context.TODO(), secret)
+	}
+	if err != nil {
+		return status, 0, errors.Wrap(err, "could not save certificate bundle secret")
+	}
+	logger.WithField("notAfter", cert.NotAfter).Info("generated certificate bundle")
+	current := bundleStatus(bundle.Name, cert, renewBefore)
+	return current, time.Until(current.RenewalTime.Time), nil
+}
+
+// certificateDNSNames returns the DNS names a certificate bundle is generated for: those listed in the bundle, or else
+// the domains of the control plane serving certificates and ingresses which use the bundle, or else the API and
+// default ingress domains of the cluster.
+func certificateDNSNames(cd *hivev1.ClusterDeployment, bundle hivev1.CertificateBundleSpec) []string {
+	if len(bundle.DNSNames) > 0 {
+		return bundle.DNSNames
+	}
+	clusterDomain := strings.Join([]string{cd.Spec.ClusterName, cd.Spec.BaseDomain}, ".")
+	names := sets.New[string]()
+	servingCertificates := cd.Spec.ControlPlaneConfig.ServingCertificates
+	if servingCertificates.Default == bundle.Name {
+		names.Insert("api." + clusterDomain)
+	}
+	for _, additional := range servingCertificates.Additional {
+		if additional.Name == bundle.Name {
+			names.Insert(additional.Domain)
+		}
+	}
+	for _, ingress := range cd.Spec.Ingress {
+		if ingress.ServingCertificate == bundle.Name {
+			names.Insert("*." + ingress.Domain)
+		}
+	}
+	if names.Len() == 0 {
+		names.Insert("api."+clusterDomain, "*.apps."+clusterDomain)
+	}
+	result := sets.List(names)
+	// Put the API domain first so that it is used as the common name.
+	sort.SliceStable(result, func(i, j int) bool {
+		return !strings.HasPrefix(result[i], "*") && strings.HasPrefix(result[j], "*")
+	})
+	return result
+}
+
+func bundleStatus(name string, cert *x509.Certificate, renewBefore time.Duration) hivev1.CertificateBundleStatus {
+	notAfter := metav1.NewTime(cert.NotAfter)
+	renewalTime := metav1.NewTime(cert.NotAfter.Add(-renewBefore))
+	return hivev1.CertificateBundleStatus{
+		Name:        name,
+		Generated:   true,
+		NotAfter:    &notAfter,
+		RenewalTime: &renewalTime,
+	}
+}
+
+func previousBundleStatus(cd *hivev1.ClusterDeployment, name string) hivev1.CertificateBundleStatus {
+	for _, status := range cd.Status.CertificateBundles {
+		if status.Name == name {
+			return *status.DeepCopy()
+		}
+	}
+	return hivev1.CertificateBundleStatus{Name: name}
+}
+
+func (r *ReconcileCertificateBundle) setGenerationFailedCondition(cd *hivev1.ClusterDeployment, status corev1.ConditionStatus, reason, message string) {
+	cd.Status.Conditions, _ = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.CertificateGenerationFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+func (r *ReconcileCertificateBundle) updateStatus(original, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	if reflect.DeepEqual(original.Status, cd.Status) {
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,396 @@
+package certificatebundle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testName       = "test-cd"
+	testSecretName = "test-cert"
+	caSecretName   = "test-ca"
+)
+
+func TestReconcileCertificateBundle(t *testing.T) {
+	caCert, caKey := buildTestCA(t)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: controllerutils.GetHiveNamespace(), Name: caSecretName},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       encodeCertificates([][]byte{caCert.Raw}),
+			corev1.TLSPrivateKeyKey: encodePrivateKey(caKey),
+		},
+	}
+	caConfig := &hivev1.CertificateGenerationConfig{
+		CA: &hivev1.CACertificateIssuer{SecretRef: corev1.LocalObjectReference{Name: caSecretName}},
+	}
+	defaultNames := []string{"api.cluster.example.com", "*.apps.cluster.example.com"}
+
+	cases := []struct {
+		name              string
+		config            *hivev1.CertificateGenerationConfig
+		noCASecret        bool
+		existingNames     []string
+		existingDuration  time.Duration
+		existingOtherCA   bool
+		existingOpaque    bool
+		expectError       bool
+		expectGenerated   bool
+		expectCondition   corev1.ConditionStatus
+		expectReason      string
+		expectUnchanged   bool
+		expectNoSecret    bool
+		expectStatusCount int
+	}{
+		{
+			name:            "no issuer configured",
+			expectCondition: corev1.ConditionTrue,
+			expectReason:    noIssuerConfiguredReason,
+			expectNoSecret:  true,
+		},
+		{
+			name:            "issuer unavailable",
+			config:          caConfig,
+			noCASecret:      true,
+			expectError:     true,
+			expectCondition: corev1.ConditionTrue,
+			expectReason:    issuerUnavailableReason,
+			expectNoSecret:  true,
+		},
+		{
+			name:            "generate missing certificate",
+			config:          caConfig,
+			expectGenerated: true,
+			expectCondition: corev1.ConditionFalse,
+			expectReason:    certificatesGeneratedReason,
+		},
+		{
+			name:             "keep current certificate",
+			config:           caConfig,
+			existingNames:    defaultNames,
+			existingDuration: 90 * 24 * time.Hour,
+			expectCondition:  corev1.ConditionFalse,
+			expectReason:     certificatesGeneratedReason,
+			expectUnchanged:  true,
+		},
+		{
+			name:             "renew expiring certificate",
+			config:           caConfig,
+			existingNames:    defaultNames,
+			existingDuration: 10 * 24 * time.Hour,
+			expectGenerated:  true,
+			expectCondition:  corev1.ConditionFalse,
+			expectReason:     certificatesGeneratedReason,
+		},
+		{
+			name:             "reissue certificate for changed names",
+			config:           caConfig,
+			existingNames:    []string{"other.example.com"},
+			existingDuration: 90 * 24 * time.Hour,
+			expectGenerated:  true,
+			expectCondition:  corev1.ConditionFalse,
+			expectReason:     certificatesGeneratedReason,
+		},
+		{
+			name:             "reissue certificate into existing opaque secret owned by another object",
+			config:           caConfig,
+			existingNames:    defaultNames,
+			existingDuration: 10 * 24 * time.Hour,
+			existingOpaque:   true,
+			expectGenerated:  true,
+			expectCondition:  corev1.ConditionFalse,
+			expectReason:     certificatesGeneratedReason,
+		},
+		{
+			name:             "reissue certificate signed by rotated CA",
+			config:           caConfig,
+			existingNames:    defaultNames,
+			existingDuration: 90 * 24 * time.Hour,
+			existingOtherCA:  true,
+			expectGenerated:  true,
+			expectCondition:  corev1.ConditionFalse,
+			expectReason:     certificatesGeneratedReason,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := scheme.GetScheme()
+			cd := testcd.FullBuilder(testNamespace, testName, scheme).Build()
+			cd.Spec.ClusterName = "cluster"
+			cd.Spec.BaseDomain = "example.com"
+			cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{{
+				Name:                 "default",
+				Generate:             true,
+				CertificateSecretRef: corev1.LocalObjectReference{Name: testSecretName},
+			}}
+			existing := []runtime.Object{cd}
+			if !tc.noCASecret {
+				existing = append(existing, caSecret)
+			}
+			var existingData map[string][]byte
+			if tc.existingNames != nil {
+				iss := &caIssuer{cert: caCert, key: caKey, duration: tc.existingDuration}
+				if tc.existingOtherCA {
+					iss.cert, iss.key = buildTestCA(t)
+				}
+				issued, _, err := iss.issue(context.Background(), tc.existingNames, &hivev1.CertificateBundleStatus{})
+				require.NoError(t, err, "unexpected error issuing existing certificate")
+				existingData = map[string][]byte{
+					corev1.TLSCertKey:       encodeCertificates(issued.chain),
+					corev1.TLSPrivateKeyKey: encodePrivateKey(issued.key),
+				}
+				existingSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecretName},
+					Type:       corev1.SecretTypeTLS,
+					Data:       existingData,
+				}
+				if tc.existingOpaque {
+					existingSecret.Type = corev1.SecretTypeOpaque
+					existingSecret.OwnerReferences = []metav1.OwnerReference{{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Name:       "other-owner",
+						UID:        "other-owner-uid",
+						Controller: ptr.To(true),
+					}}
+				}
+				existing = append(existing, existingSecret)
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			r := &ReconcileCertificateBundle{
+				Client: c,
+				config: tc.config,
+				logger: log.WithField("controller", ControllerName),
+			}
+
+			_, err := r.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+			})
+			if tc.expectError {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			cd = &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testName}, cd))
+			cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.CertificateGenerationFailedCondition)
+			if assert.NotNil(t, cond, "missing certificate generation condition") {
+				assert.Equal(t, tc.expectCondition, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectReason, cond.Reason, "unexpected condition reason")
+			}
+
+			secret := &corev1.Secret{}
+			err = c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testSecretName}, secret)
+			if tc.expectNoSecret {
+				assert.Error(t, err, "expected secret not to exist")
+				assert.Empty(t, cd.Status.CertificateBundles, "unexpected certificate bundle status")
+				return
+			}
+			require.NoError(t, err, "unexpected error getting certificate secret")
+			if tc.expectUnchanged {
+				assert.Equal(t, existingData, secret.Data, "expected secret to be unchanged")
+			}
+			if tc.expectGenerated {
+				assert.NotEqual(t, existingData, secret.Data, "expected secret to be regenerated")
+				if tc.existingOpaque {
+					assert.Equal(t, corev1.SecretTypeOpaque, secret.Type, "expected existing secret type to be kept")
+					if assert.Len(t, secret.OwnerReferences, 1, "unexpected owner references") {
+						assert.Equal(t, "other-owner", secret.OwnerReferences[0].Name, "expected existing controller to be kept")
+					}
+				} else {
+					assert.Equal(t, corev1.SecretTypeTLS, secret.Type, "unexpected secret type")
+				}
+				if tc.existingNames == nil {
+					assert.True(t, metav1.IsControlledBy(secret, cd), "expected created secret to be controlled by the cluster deployment")
+				}
+				assert.Equal(t, testName, secret.Labels["hive.openshift.io/cluster-deployment-name"], "unexpected cluster deployment label")
+			}
+			cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+			require.NoError(t, err, "unexpected error parsing certificate")
+			assert.ElementsMatch(t, defaultNames, cert.DNSNames, "unexpected certificate DNS names")
+			roots := x509.NewCertPool()
+			roots.AddCert(caCert)
+			_, err = cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "api.cluster.example.com"})
+			assert.NoError(t, err, "certificate does not verify against the CA")
+			_, err = parsePrivateKey(secret.Data[corev1.TLSPrivateKeyKey])
+			assert.NoError(t, err, "unexpected error parsing private key")
+
+			if assert.Len(t, cd.Status.CertificateBundles, 1, "unexpected certificate bundle statuses") {
+				status := cd.Status.CertificateBundles[0]
+				assert.Equal(t, "default", status.Name, "unexpected bundle status name")
+				assert.True(t, status.Generated, "expected bundle to be generated")
+				if assert.NotNil(t, status.NotAfter, "missing expiry time") {
+					assert.True(t, status.NotAfter.Time.Equal(cert.NotAfter), "unexpected expiry time")
+				}
+				if assert.NotNil(t, status.RenewalTime, "missing renewal time") {
+					assert.True(t, status.RenewalTime.Time.Equal(cert.NotAfter.Add(-defaultRenewBefore)), "unexpected renewal time")
+				}
+			}
+		})
+	}
+}
+
+func TestRequestsForIssuerSecret(t *testing.T) {
+	scheme := scheme.GetScheme()
+	generated := testcd.FullBuilder(testNamespace, "generated", scheme).Build()
+	generated.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{{Name: "default", Generate: true}}
+	provided := testcd.FullBuilder(testNamespace, "provided", scheme).Build()
+	provided.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{{Name: "default"}}
+	r := &ReconcileCertificateBundle{
+		Client: testfake.NewFakeClientBuilder().WithRuntimeObjects(generated, provided).Build(),
+		config: &hivev1.CertificateGenerationConfig{
+			CA: &hivev1.CACertificateIssuer{SecretRef: corev1.LocalObjectReference{Name: caSecretName}},
+		},
+		logger: log.WithField("controller", ControllerName),
+	}
+	cases := []struct {
+		name           string
+		secret         *corev1.Secret
+		expectRequests []reconcile.Request
+	}{
+		{
+			name:   "CA secret",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: controllerutils.GetHiveNamespace(), Name: caSecretName}},
+			expectRequests: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "generated"}},
+			},
+		},
+		{
+			name:   "other secret",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: controllerutils.GetHiveNamespace(), Name: "other"}},
+		},
+		{
+			name:   "CA secret name in other namespace",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: caSecretName}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectRequests, r.requestsForIssuerSecret(context.Background(), tc.secret))
+		})
+	}
+}
+
+func TestCertificateDNSNames(t *testing.T) {
+	cases := []struct {
+		name     string
+		bundle   hivev1.CertificateBundleSpec
+		spec     func(*hivev1.ClusterDeploymentSpec)
+		expected []string
+	}{
+		{
+			name:     "unused bundle",
+			bundle:   hivev1.CertificateBundleSpec{Name: "bundle"},
+			expected: []string{"api.cluster.example.com", "*.apps.cluster.example.com"},
+		},
+		{
+			name:     "explicit names",
+			bundle:   hivev1.CertificateBundleSpec{Name: "bundle", DNSNames: []string{"a.example.com"}},
+			expected: []string{"a.example.com"},
+		},
+		{
+			name:   "control plane default",
+			bundle: hivev1.CertificateBundleSpec{Name: "bundle"},
+			spec: func(spec *hivev1.ClusterDeploymentSpec) {
+				spec.ControlPlaneConfig.ServingCertificates.Default = "bundle"
+			},
+			expected: []string{"api.cluster.example.com"},
+		},
+		{
+			name:   "control plane additional and ingress",
+			bundle: hivev1.CertificateBundleSpec{Name: "bundle"},
+			spec: func(spec *hivev1.ClusterDeploymentSpec) {
+				spec.ControlPlaneConfig.ServingCertificates.Additional = []hivev1.ControlPlaneAdditionalCertificate{
+					{Name: "bundle", Domain: "api.custom.example.com"},
+					{Name: "other", Domain: "other.example.com"},
+				}
+				spec.Ingress = []hivev1.ClusterIngress{
+					{Name: "default", Domain: "apps.custom.example.com", ServingCertificate: "bundle"},
+					{Name: "other", Domain: "apps.other.example.com", ServingCertificate: "other"},
+				}
+			},
+			expected: []string{"api.custom.example.com", "*.apps.custom.example.com"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{ClusterName: "cluster", BaseDomain: "example.com"},
+			}
+			if tc.spec != nil {
+				tc.spec(&cd.Spec)
+			}
+			assert.Equal(t, tc.expected, certificateDNSNames(cd, tc.bundle))
+		})
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	pkcs8DER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+
+	for name, data := range map[string][]byte{
+		"sec1":  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+		"pkcs8": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8DER}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, err := parsePrivateKey(data)
+			if assert.NoError(t, err) {
+				assert.True(t, ecKey.PublicKey.Equal(key.Public()), "unexpected public key")
+			}
+		})
+	}
+	_, err = parsePrivateKey([]byte("not a key"))
+	assert.Error(t, err, "expected error parsing invalid key")
+}
+
+func buildTestCA(t *testing.T) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, certificateKeyBits)
+	require.NoError(t, err, "unexpected error generating CA key")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err, "unexpected error creating CA certificate")
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err, "unexpected error parsing CA certificate")
+	return cert, key
+}
@@ -0,0 +1,189 @@
+package certificatebundle
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultCADuration = 90 * 24 * time.Hour
+
+	// clockSkewAllowance is how far in the past issued certificates become valid, so that they are accepted by
+	// clients whose clocks are slightly behind.
+	clockSkewAllowance = 5 * time.Minute
+)
+
+// issuer issues certificates for certificate bundles.
+type issuer interface {
+	// issue returns a certificate for the given DNS names. Issuers which cannot issue the certificate right away record
+	// their progress in status, and return a nil certificate and how long to wait before calling issue again.
+	issue(ctx context.Context, dnsNames []string, status *hivev1.CertificateBundleStatus) (*issuedCertificate, time.Duration, error)
+
+	// signed returns whether cert was signed by the issuer, so that certificates are reissued when the issuer changes.
+	signed(cert *x509.Certificate) bool
+}
+
+// issuedCertificate is a certificate chain, leaf first, and the private key of the leaf certificate.
+type issuedCertificate struct {
+	chain [][]byte
+	key   *rsa.PrivateKey
+}
+
+// ReadCertificateGenerationConfigFile reads the certificate generation configuration from the file named by the
+// environment. It returns nil if the environment variable is unset or the file does not exist.
+func ReadCertificateGenerationConfigFile() (*hivev1.CertificateGenerationConfig, error) {
+	fPath := os.Getenv(constants.CertificateGenerationConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	fileBytes, err := os.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the certificate generation config file")
+	}
+	config := &hivev1.CertificateGenerationConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the certificate generation config file")
+	}
+	return config, nil
+}
+
+// caIssuer issues certificates signed by a local certificate authority.
+type caIssuer struct {
+	cert     *x509.Certificate
+	key      crypto.Signer
+	duration time.Duration
+}
+
+var _ issuer = &caIssuer{}
+
+// newCAIssuer returns an issuer for the certificate authority in the secret referenced by the configuration.
+func newCAIssuer(c client.Client, config *hivev1.CACertificateIssuer) (*caIssuer, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(
+		context.TODO(),
+		client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: config.SecretRef.Name},
+		secret,
+	); err != nil {
+		return nil, errors.Wrap(err, "could not get certificate authority secret")
+	}
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse certificate authority certificate")
+	}
+	key, err := parsePrivateKey(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse certificate authority key")
+	}
+	duration := defaultCADuration
+	if config.Duration != nil {
+		duration = config.Duration.Duration
+	}
+	return &caIssuer{cert: cert, key: key, duration: duration}, nil
+}
+
+func (i *caIssuer) issue(_ context.Context, dnsNames []string, _ *hivev1.CertificateBundleStatus) (*issuedCertificate, time.Duration, error) {
+	key, err := rsa.GenerateKey(rand.Reader, certificateKeyBits)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not generate private key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not generate serial number")
+	}
+	now := time.Now()
+	notAfter := now.Add(i.duration)
+	if notAfter.After(i.cert.NotAfter) {
+		notAfter = i.cert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-clockSkewAllowance),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, i.cert, key.Public(), i.key)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not sign certificate")
+	}
+	return &issuedCertificate{chain: [][]byte{der, i.cert.Raw}, key: key}, 0, nil
+}
+
+func (i *caIssuer) signed(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(i.cert) == nil
+}
+
+// parseCertificate returns the first certificate in PEM encoded data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// parsePrivateKey returns the PEM encoded RSA or ECDSA private key in data, which may be in PKCS #1, PKCS #8 or SEC 1
+// form.
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New("unsupported private key format")
+	}
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	default:
+		return nil, errors.New("unsupported private key type")
+	}
+}
+
+// encodeCertificates returns a certificate chain in PEM encoding.
+func encodeCertificates(chain [][]byte) []byte {
+	var data []byte
+	for _, der := range chain {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	return data
+}
+
+// encodePrivateKey returns an RSA private key in PEM encoding.
+func encodePrivateKey(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
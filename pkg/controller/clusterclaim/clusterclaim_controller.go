@@ -13,6 +13,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/resource"
@@ -57,8 +59,9 @@ func Add(mgr manager.Manager) error {
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterClaim {
 	logger := log.WithField("controller", ControllerName)
 	return &ReconcileClusterClaim{
-		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
-		logger: logger,
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:        logger,
+		eventRecorder: mgr.GetEventRecorderFor(ControllerName.String()),
 	}
 }
 
@@ -166,7 +169,8 @@ var _ reconcile.Reconciler = &ReconcileClusterClaim{}
 // ReconcileClusterClaim reconciles a CLusterClaim object
 type ReconcileClusterClaim struct {
 	client.Client
-	logger log.FieldLogger
+	logger        log.FieldLogger
+	eventRecorder record.EventRecorder
 }
 
 // Reconcile reconciles a ClusterClaim.
@@ -240,13 +244,23 @@ func (r *ReconcileClusterClaim) Reconcile(ctx context.Context, request reconcile
 		}
 	}
 
+	if lifetime == nil {
+		if err := r.rejectRenewalWithoutLifetime(claim, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Delete ClusterClaim after its lifetime elapses
 	if lifetime != nil {
 		logger.WithField("lifetime", lifetime).Debug("checking whether lifetime of ClusterClaim has elapsed")
 		pendingCond := controllerutils.FindCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
 		if pendingCond.Status == corev1.ConditionFalse {
-			if timeSinceAssigned := time.Since(pendingCond.LastTransitionTime.Time); timeSinceAssigned >= lifetime.Duration {
-				logger.WithField("timeSinceAssigned", timeSinceAssigned).
+			if err := r.renewClaim(claim, poolLifetime, pendingCond.LastTransitionTime.Time, lifetime, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+			expiration := getClaimExpiration(claim, pendingCond.LastTransitionTime.Time, lifetime)
+			if timeLeft := time.Until(expiration); timeLeft <= 0 {
+				logger.WithField("expiration", expiration).
 					WithField("lifetime", lifetime).
 					Info("deleting ClusterClaim because its lifetime has elapsed")
 				if err := r.Delete(context.Background(), claim); err != nil {
@@ -255,9 +269,13 @@ func (r *ReconcileClusterClaim) Reconcile(ctx context.Context, request reconcile
 				}
 				return reconcile.Result{}, nil
 			}
+			requeueAfter, err := r.updateExpiration(claim, poolLifetime, expiration, logger)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
 			defer func() {
 				result, returnErr = controllerutils.EnsureRequeueAtLeastWithin(
-					lifetime.Duration-time.Since(pendingCond.LastTransitionTime.Time),
+					requeueAfter,
 					result,
 					returnErr,
 				)
@@ -326,6 +344,132 @@ func getClaimLifetime(poolLifetime *hivev1.ClusterPoolClaimLifetime, claimLifeti
 	return lifetime
 }
 
+// getClaimExpiration returns when the lifetime of a claim assigned a cluster at the given time elapses. The lifetime
+// is restarted by each renewal of the claim.
+func getClaimExpiration(claim *hivev1.ClusterClaim, assigned time.Time, lifetime *metav1.Duration) time.Time {
+	start := assigned
+	if n := len(claim.Status.Renewals); n > 0 {
+		start = claim.Status.Renewals[n-1].Time.Time
+	}
+	return start.Add(lifetime.Duration)
+}
+
+// renewClaim acts on a renewal requested by the ClaimRenewAnnotation. The annotation is removed before the renewal is
+// recorded, so that a failure part way through loses the request rather than consuming an additional renewal.
+func (r *ReconcileClusterClaim) renewClaim(claim *hivev1.ClusterClaim, poolLifetime *hivev1.ClusterPoolClaimLifetime, assigned time.Time, lifetime *metav1.Duration, logger log.FieldLogger) error {
+	if _, ok := claim.Annotations[constants.ClaimRenewAnnotation]; !ok {
+		return nil
+	}
+	delete(claim.Annotations, constants.ClaimRenewAnnotation)
+	if err := r.Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove renewal annotation from ClusterClaim")
+		return errors.Wrap(err, "could not remove renewal annotation from ClusterClaim")
+	}
+
+	var maxRenewals int32
+	if poolLifetime != nil && poolLifetime.MaxRenewals != nil {
+		maxRenewals = *poolLifetime.MaxRenewals
+	}
+	if renewals := int32(len(claim.Status.Renewals)); renewals >= maxRenewals {
+		logger.WithField("renewals", renewals).WithField("maxRenewals", maxRenewals).Info("rejecting renewal of ClusterClaim")
+		r.eventRecorder.Eventf(claim, corev1.EventTypeWarning, "RenewalRejected",
+			"Claim has been renewed %d times, and the pool allows %d renewals", renewals, maxRenewals)
+		return nil
+	}
+
+	now := metav1.Now()
+	renewal := hivev1.ClusterClaimRenewal{
+		Time:           now,
+		ExpirationTime: metav1.NewTime(now.Add(lifetime.Duration)),
+	}
+	claim.Status.Renewals = append(claim.Status.Renewals, renewal)
+	claim.Status.ExpirationTime = &renewal.ExpirationTime
+	if err := r.Status().Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not record renewal of ClusterClaim")
+		return errors.Wrap(err, "could not record renewal of ClusterClaim")
+	}
+	logger.WithField("expiration", renewal.ExpirationTime).Info("renewed ClusterClaim")
+	r.eventRecorder.Eventf(claim, corev1.EventTypeNormal, "Renewed",
+		"Claim renewed until %s", renewal.ExpirationTime.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// rejectRenewalWithoutLifetime removes the ClaimRenewAnnotation from a claim which has no lifetime to renew, so that
+// the request does not linger until a lifetime is set.
+func (r *ReconcileClusterClaim) rejectRenewalWithoutLifetime(claim *hivev1.ClusterClaim, logger log.FieldLogger) error {
+	if _, ok := claim.Annotations[constants.ClaimRenewAnnotation]; !ok {
+		return nil
+	}
+	delete(claim.Annotations, constants.ClaimRenewAnnotation)
+	if err := r.Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove renewal annotation from ClusterClaim")
+		return errors.Wrap(err, "could not remove renewal annotation from ClusterClaim")
+	}
+	logger.Info("rejecting renewal of ClusterClaim without a lifetime")
+	r.eventRecorder.Event(claim, corev1.EventTypeWarning, "RenewalRejected", "Claim has no lifetime to renew")
+	return nil
+}
+
+// updateExpiration records the expiration time of the claim in its status and, if the pool asks for it, warns when the
+// expiration is near. It returns how soon the claim must be reconciled again to warn of or act on its expiration.
+func (r *ReconcileClusterClaim) updateExpiration(claim *hivev1.ClusterClaim, poolLifetime *hivev1.ClusterPoolClaimLifetime, expiration time.Time, logger log.FieldLogger) (time.Duration, error) {
+	changed := false
+	if claim.Status.ExpirationTime == nil || !claim.Status.ExpirationTime.Time.Equal(expiration) {
+		claim.Status.ExpirationTime = &metav1.Time{Time: expiration}
+		changed = true
+	}
+
+	timeLeft := time.Until(expiration)
+	requeueAfter := timeLeft
+	warn := false
+	if poolLifetime != nil && poolLifetime.ExpiryWarning != nil {
+		if timeLeft <= poolLifetime.ExpiryWarning.Duration {
+			warn = true
+		} else {
+			requeueAfter = timeLeft - poolLifetime.ExpiryWarning.Duration
+		}
+	}
+	var conds []hivev1.ClusterClaimCondition
+	condChanged := false
+	switch {
+	case warn:
+		conds, condChanged = controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimExpiringCondition,
+			corev1.ConditionTrue,
+			"ExpiringSoon",
+			fmt.Sprintf("Claim will be deleted at %s", expiration.UTC().Format(time.RFC3339)),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	case controllerutils.FindCondition(claim.Status.Conditions, hivev1.ClusterClaimExpiringCondition) != nil:
+		conds, condChanged = controllerutils.SetClusterClaimConditionWithChangeCheck(
+			claim.Status.Conditions,
+			hivev1.ClusterClaimExpiringCondition,
+			corev1.ConditionFalse,
+			"NotExpiring",
+			"Claim will not be deleted within the expiry warning period",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if condChanged {
+		claim.Status.Conditions = conds
+		changed = true
+	}
+	if !changed {
+		return requeueAfter, nil
+	}
+	if err := r.Status().Update(context.Background(), claim); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterClaim expiration")
+		return 0, errors.Wrap(err, "could not update ClusterClaim expiration")
+	}
+	if warn && condChanged {
+		logger.WithField("expiration", expiration).Info("ClusterClaim will expire soon")
+		r.eventRecorder.Eventf(claim, corev1.EventTypeWarning, "ExpiringSoon",
+			"Claim will be deleted at %s", expiration.UTC().Format(time.RFC3339))
+	}
+	return requeueAfter, nil
+}
+
 // clusterPoolLifetimeForClaim returns the default and max lifetimes for the cluster pool the claim belongs to.
 func (r *ReconcileClusterClaim) clusterPoolLifetimeForClaim(claim *hivev1.ClusterClaim, logger log.FieldLogger) (*hivev1.ClusterPoolClaimLifetime, error) {
 	// Fetch the ClusterPool instance
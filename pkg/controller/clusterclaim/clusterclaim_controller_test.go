@@ -2,6 +2,7 @@ package clusterclaim
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,10 +15,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -106,6 +109,8 @@ func TestReconcileClusterClaim(t *testing.T) {
 		expectHibernating                      bool
 		expectDeleted                          bool
 		expectedRequeueAfter                   *time.Duration
+		expectedRenewals                       int
+		expectedEvents                         []string
 	}{
 		{
 			name:  "initialize conditions",
@@ -601,6 +606,286 @@ func TestReconcileClusterClaim(t *testing.T) {
 			expectRBAC:           true,
 			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(2 * time.Hour),
 		},
+		{
+			name: "renewal restarts lifetime",
+			claim: initializedClaimBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.ClaimRenewAnnotation, "true"),
+			).Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-50 * time.Minute)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaxClaimRenewals(1)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(1 * time.Hour),
+			expectedRenewals:     1,
+			expectedEvents:       []string{"Normal Renewed"},
+		},
+		{
+			name: "renewal rejected after maximum renewals",
+			claim: initializedClaimBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.ClaimRenewAnnotation, "true"),
+			).Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithRenewal(time.Now().Add(-30*time.Minute), 1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-80 * time.Minute)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaxClaimRenewals(1)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(30 * time.Minute),
+			expectedRenewals:     1,
+			expectedEvents:       []string{"Warning RenewalRejected"},
+		},
+		{
+			name: "renewal rejected when pool does not allow renewals",
+			claim: initializedClaimBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.ClaimRenewAnnotation, "true"),
+			).Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-30 * time.Minute)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithDefaultClaimLifetime(1 * time.Hour)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(30 * time.Minute),
+			expectedEvents:       []string{"Warning RenewalRejected"},
+		},
+		{
+			name: "renewal rejected when claim has no lifetime",
+			claim: initializedClaimBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.ClaimRenewAnnotation, "true"),
+			).Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-30 * time.Minute)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaxClaimRenewals(1)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedEvents:       []string{"Warning RenewalRejected"},
+		},
+		{
+			name: "renewed claim is not deleted after its original lifetime",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithRenewal(time.Now().Add(-30*time.Minute), 1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaxClaimRenewals(1)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(30 * time.Minute),
+			expectedRenewals:     1,
+		},
+		{
+			name: "claim with elapsed renewed lifetime is deleted",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithRenewal(time.Now().Add(-1*time.Hour), 1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaxClaimRenewals(1)),
+			},
+			expectCompletedClaim: true,
+			expectedRenewals:     1,
+		},
+		{
+			name: "claim is requeued for expiry warning",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-30 * time.Minute)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithClaimExpiryWarning(15 * time.Minute)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(15 * time.Minute),
+		},
+		{
+			name: "claim warns of expiry",
+			claim: initializedClaimBuilder.Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-50 * time.Minute)),
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithClaimExpiryWarning(15 * time.Minute)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(10 * time.Minute),
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:   hivev1.ClusterClaimExpiringCondition,
+					Status: corev1.ConditionTrue,
+					Reason: "ExpiringSoon",
+				},
+			},
+			expectedEvents: []string{"Warning ExpiringSoon"},
+		},
+		{
+			name: "renewal clears expiry warning",
+			claim: initializedClaimBuilder.GenericOptions(
+				testgeneric.WithAnnotation(constants.ClaimRenewAnnotation, "true"),
+			).Build(
+				testclaim.WithPool(testLeasePoolName),
+				testclaim.WithCluster(clusterName),
+				testclaim.WithLifetime(1*time.Hour),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:               hivev1.ClusterClaimPendingCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             "ClusterClaimed",
+					Message:            "Cluster claimed",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-50 * time.Minute)),
+				}),
+				testclaim.WithCondition(hivev1.ClusterClaimCondition{
+					Type:   hivev1.ClusterClaimExpiringCondition,
+					Status: corev1.ConditionTrue,
+					Reason: "ExpiringSoon",
+				}),
+			),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.ClusterPowerStateStartingMachines),
+			),
+			existing: []runtime.Object{
+				poolBuilder.Build(testcp.WithMaxClaimRenewals(1), testcp.WithClaimExpiryWarning(15*time.Minute)),
+				testRole(),
+				testRoleBinding(),
+			},
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedRequeueAfter: func(d time.Duration) *time.Duration { return &d }(45 * time.Minute),
+			expectedRenewals:     1,
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:   hivev1.ClusterClaimExpiringCondition,
+					Status: corev1.ConditionFalse,
+					Reason: "NotExpiring",
+				},
+			},
+			expectedEvents: []string{"Normal Renewed"},
+		},
 	}
 
 	for _, test := range tests {
@@ -614,9 +899,11 @@ func TestReconcileClusterClaim(t *testing.T) {
 			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.existing...).Build()
 			logger := log.New()
 			logger.SetLevel(log.DebugLevel)
+			recorder := record.NewFakeRecorder(10)
 			rcp := &ReconcileClusterClaim{
-				Client: c,
-				logger: logger,
+				Client:        c,
+				logger:        logger,
+				eventRecorder: recorder,
 			}
 
 			reconcileRequest := reconcile.Request{
@@ -704,6 +991,22 @@ func TestReconcileClusterClaim(t *testing.T) {
 				}
 			}
 
+			if !test.expectDeleted {
+				assert.NotContains(t, claim.Annotations, constants.ClaimRenewAnnotation, "expected renewal annotation to be removed")
+				assert.Len(t, claim.Status.Renewals, test.expectedRenewals, "unexpected number of renewals")
+			}
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Len(t, events, len(test.expectedEvents), "unexpected events: %v", events)
+			for i, expectedEvent := range test.expectedEvents {
+				if i < len(events) {
+					assert.True(t, strings.HasPrefix(events[i], expectedEvent), "unexpected event %q, expected %q", events[i], expectedEvent)
+				}
+			}
+
 			role := &rbacv1.Role{}
 			getRoleError := c.Get(context.Background(), client.ObjectKey{Namespace: clusterName, Name: hiveClaimOwnerRoleName}, role)
 			roleBinding := &rbacv1.RoleBinding{}
@@ -63,6 +63,7 @@ import (
 	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/hive/pkg/ibmclient"
 	"github.com/openshift/hive/pkg/imageset"
+	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/remoteclient"
 	"github.com/openshift/hive/pkg/util/contracts"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
@@ -121,6 +122,8 @@ const (
 	installAttemptsLimitReachedReason = "InstallAttemptsLimitReached"
 	installOnlyOnceSetReason          = "InstallOnlyOnceSet"
 	failureReasonNotListed            = "FailureReasonNotRetryable"
+	retryPolicyDoNotRetryReason       = "RetryPolicyDoNotRetry"
+	retryPolicyMaxAttemptsReason      = "RetryPolicyMaxAttemptsReached"
 	provisionNotStoppedReason         = "ProvisionNotStopped"
 
 	deleteAfterAnnotation    = "hive.openshift.io/delete-after" // contains a duration after which the cluster should be cleaned up.
@@ -192,6 +195,13 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		logger.WithError(err).Error("Unable to read Supported Contract Implementations file")
 	}
 
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err == nil {
+		r.managedDomains = managedDomains
+	} else {
+		logger.WithError(err).Error("Unable to read managed domains file")
+	}
+
 	return r
 }
 
@@ -351,6 +361,10 @@ type ReconcileClusterDeployment struct {
 	// supportedContractsConfig holds the list and configurations of supported external contracts,
 	// such as ClusterInstall.
 	supportedContractsConfig contracts.SupportedContractImplementationsList
+
+	// managedDomains holds the managed domains configured in HiveConfig. It is used to find the DNS
+	// provider settings for managed DNS zones that are not hosted by the cloud of the cluster.
+	managedDomains []hivev1.ManageDNSConfig
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -2042,6 +2056,8 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	case p.AWS != nil:
 	case p.GCP != nil:
 	case p.Azure != nil:
+	case p.IBMCloud != nil && r.ibmCloudManagedDomain(cd) != nil:
+	case r.rfc2136ManagedDomain(cd) != nil:
 	default:
 		cdLog.Error("cluster deployment platform does not support managed DNS")
 		if err := r.updateCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionTrue, dnsUnsupportedPlatformReason, "Managed DNS is not supported on specified platform", cdLog); err != nil {
@@ -2075,6 +2091,17 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 		return true, reconcile.Result{}, errors.New("Existing unowned DNS zone")
 	}
 
+	if dnsZone.Spec.RFC2136 != nil {
+		if records := r.rfc2136ClusterRecords(cd, logger); len(records) > 0 && !reflect.DeepEqual(records, dnsZone.Spec.RFC2136.Records) {
+			logger.Info("updating the records published in the DNSZone")
+			dnsZone.Spec.RFC2136.Records = records
+			if err := r.Update(context.TODO(), dnsZone); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update DNSZone records")
+				return false, reconcile.Result{}, err
+			}
+		}
+	}
+
 	availableCondition := controllerutils.FindCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
 	insufficientCredentialsCondition := controllerutils.FindCondition(dnsZone.Status.Conditions, hivev1.InsufficientCredentialsCondition)
 	authenticationFailureCondition := controllerutils.FindCondition(dnsZone.Status.Conditions, hivev1.AuthenticationFailureCondition)
@@ -2215,6 +2242,26 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 			ResourceGroupName:    cd.Spec.Platform.Azure.BaseDomainResourceGroupName,
 			CloudName:            cd.Spec.Platform.Azure.CloudName,
 		}
+	case cd.Spec.Platform.IBMCloud != nil:
+		ibmCloudConfig := r.ibmCloudManagedDomain(cd)
+		if ibmCloudConfig == nil {
+			logger.Error("no IBM Cloud managed domain configured for the base domain")
+			return errors.Errorf("no IBM Cloud managed domain configured for base domain %s", cd.Spec.BaseDomain)
+		}
+		dnsZone.Spec.IBMCloud = &hivev1.IBMCloudDNSZoneSpec{
+			CredentialsSecretRef: cd.Spec.Platform.IBMCloud.CredentialsSecretRef,
+			CISInstanceCRN:       ibmCloudConfig.CISInstanceCRN,
+		}
+	default:
+		if rfc2136Config := r.rfc2136ManagedDomain(cd); rfc2136Config != nil {
+			dnsZone.Spec.RFC2136 = &hivev1.RFC2136DNSZoneSpec{
+				Server:  rfc2136Config.Server,
+				Records: r.rfc2136ClusterRecords(cd, logger),
+			}
+			// The records of the zone are written into the zone of the managed domain, so there is no
+			// delegation to link.
+			dnsZone.Spec.LinkToParentDomain = false
+		}
 	}
 
 	logger.WithField("derivedObject", dnsZone.Name).Debug("Setting labels on derived object")
@@ -2234,6 +2281,67 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 	return nil
 }
 
+// ibmCloudManagedDomain returns the IBM Cloud settings of the managed domain containing the base domain of the
+// cluster deployment, or nil if that managed domain is not served by IBM Cloud Internet Services.
+func (r *ReconcileClusterDeployment) ibmCloudManagedDomain(cd *hivev1.ClusterDeployment) *hivev1.ManageDNSIBMCloudConfig {
+	md, _ := manageddns.FindManagedDomain(r.managedDomains, cd.Spec.BaseDomain)
+	if md == nil {
+		return nil
+	}
+	return md.IBMCloud
+}
+
+// rfc2136ManagedDomain returns the RFC2136 settings of the managed domain containing the base domain of the
+// cluster deployment, or nil if that managed domain is not served by an RFC2136 name server.
+func (r *ReconcileClusterDeployment) rfc2136ManagedDomain(cd *hivev1.ClusterDeployment) *hivev1.ManageDNSRFC2136Config {
+	md, _ := manageddns.FindManagedDomain(r.managedDomains, cd.Spec.BaseDomain)
+	if md == nil {
+		return nil
+	}
+	return md.RFC2136
+}
+
+// rfc2136ClusterRecords returns the API and ingress records of the cluster to publish in an RFC2136 managed domain,
+// from the VIPs in its install-config. The installers of the platforms using RFC2136 managed domains do not create
+// DNS records, so without them the cluster could not be reached. Adopted clusters have no install-config, and
+// therefore no records.
+func (r *ReconcileClusterDeployment) rfc2136ClusterRecords(cd *hivev1.ClusterDeployment, logger log.FieldLogger) []hivev1.RFC2136AddressRecord {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return nil
+	}
+	ic := r.getInstallConfig(cd, logger)
+	if ic == nil {
+		return nil
+	}
+	var apiVIPs, ingressVIPs []string
+	switch p := ic.Platform; {
+	case p.VSphere != nil:
+		apiVIPs, ingressVIPs = p.VSphere.APIVIPs, p.VSphere.IngressVIPs
+	case p.BareMetal != nil:
+		apiVIPs, ingressVIPs = p.BareMetal.APIVIPs, p.BareMetal.IngressVIPs
+	case p.Nutanix != nil:
+		apiVIPs, ingressVIPs = p.Nutanix.APIVIPs, p.Nutanix.IngressVIPs
+	case p.OpenStack != nil:
+		apiVIPs, ingressVIPs = p.OpenStack.APIVIPs, p.OpenStack.IngressVIPs
+		// The VIPs are on the nodes' network, which is reached from outside through the floating IPs, if any.
+		if p.OpenStack.APIFloatingIP != "" {
+			apiVIPs = []string{p.OpenStack.APIFloatingIP}
+		}
+		if p.OpenStack.IngressFloatingIP != "" {
+			ingressVIPs = []string{p.OpenStack.IngressFloatingIP}
+		}
+	}
+	clusterDomain := cd.Spec.ClusterName + "." + cd.Spec.BaseDomain
+	var records []hivev1.RFC2136AddressRecord
+	if len(apiVIPs) > 0 {
+		records = append(records, hivev1.RFC2136AddressRecord{Name: "api." + clusterDomain, Addresses: apiVIPs})
+	}
+	if len(ingressVIPs) > 0 {
+		records = append(records, hivev1.RFC2136AddressRecord{Name: "*.apps." + clusterDomain, Addresses: ingressVIPs})
+	}
+	return records
+}
+
 func selectorPodWatchHandler(ctx context.Context, pod *corev1.Pod) []reconcile.Request {
 	retval := []reconcile.Request{}
 
@@ -2561,6 +2669,19 @@ func calculateNextProvisionTime(failureTime time.Time, retries int) time.Time {
 	return failureTime.Add((1 << uint(retries)) * time.Minute)
 }
 
+// calculateRetryPolicyBackoff returns (2^retries) * the initial delay of the backoff, up to its max delay.
+func calculateRetryPolicyBackoff(backoff *hivev1.FailedProvisionRetryBackoff, retries int) time.Duration {
+	maxDelay := 24 * time.Hour
+	if backoff.MaxDelay != nil {
+		maxDelay = backoff.MaxDelay.Duration
+	}
+	delay := backoff.InitialDelay.Duration
+	for i := 0; i < retries && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
 // existingProvisions returns the list of ClusterProvisions associated with the specified
 // ClusterDeployment, sorted by age, oldest first.
 func (r *ReconcileClusterDeployment) existingProvisions(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*hivev1.ClusterProvision, error) {
@@ -43,6 +43,8 @@ import (
 	"golang.org/x/crypto/openpgp"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -157,6 +159,7 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		reconcilerSetup               func(*ReconcileClusterDeployment)
 		platformCredentialsValidation func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 		retryReasons                  *[]string
+		retryPolicies                 []hivev1.FailedProvisionRetryPolicy
 	}{
 		{
 			name: "Initialize conditions",
@@ -1801,6 +1804,39 @@ platform:
 				}
 			},
 		},
+		{
+			name: "Clear out provision counts its failure reason",
+			existing: []runtime.Object{
+				testInstallConfigSecretAWS(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Status.InstallRestarts = 2
+					cd.Status.InstallFailureCounts = []hivev1.InstallFailureCount{
+						{Reason: "aReason", Category: hivev1.InstallFailureCategoryQuota, Count: 1},
+						{Reason: "otherReason", Category: hivev1.InstallFailureCategoryUnknown, Count: 1},
+					}
+					return cd
+				}(),
+				testProvision(tcp.WithFailureTime(time.Now().Add(-10*time.Minute)), func(p *hivev1.ClusterProvision) {
+					p.Status.Conditions[0].Reason = "aReason"
+					p.Status.InstallFailures = []hivev1.InstallFailure{{Name: "aRegex", Reason: "aReason", Category: hivev1.InstallFailureCategoryQuota}}
+				}),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Nil(t, cd.Status.ProvisionRef, "expected empty provision ref")
+					assert.Equal(t, 3, cd.Status.InstallRestarts, "expected incremented install restart count")
+					assert.Equal(t, []hivev1.InstallFailureCount{
+						{Reason: "aReason", Category: hivev1.InstallFailureCategoryQuota, Count: 2},
+						{Reason: "otherReason", Category: hivev1.InstallFailureCategoryUnknown, Count: 1},
+					}, cd.Status.InstallFailureCounts, "unexpected install failure counts")
+				}
+			},
+		},
 		{
 			name: "Delete outstanding provision on delete",
 			existing: []runtime.Object{
@@ -3627,20 +3663,182 @@ platform:
 				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
 			},
 		},
+		{
+			name: "RetryPolicies: matching reason: backoff applied and reported",
+			existing: []runtime.Object{
+				testInstallConfigSecretAWS(),
+				testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision()),
+				testProvision(tcp.WithFailure("aReason", time.Now().Add(-2*time.Minute))),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			retryPolicies: []hivev1.FailedProvisionRetryPolicy{
+				{Reasons: []string{"otherReason"}, DoNotRetry: true},
+				{
+					Reasons: []string{"aReason"},
+					Backoff: &hivev1.FailedProvisionRetryBackoff{InitialDelay: metav1.Duration{Duration: time.Hour}},
+					Hint:    hivev1.FailedProvisionRetryHintSwitchRegionOrZone,
+				},
+			},
+			expectedRequeueAfter: 58 * time.Minute,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.NotNil(t, cd.Status.ProvisionRef, "expected provision ref to be kept")
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionFailedCondition); assert.NotNil(t, cond, "no ProvisionFailed condition") {
+						assert.Equal(t, "aReason", cond.Reason, "unexpected ProvisionFailed reason")
+						assert.Contains(t, cond.Message, "retry policy backoff of 1h0m0s applied", "expected backoff in ProvisionFailed message")
+						assert.Contains(t, cond.Message, "Consider installing in a different region or availability zone.", "expected hint in ProvisionFailed message")
+					}
+				}
+			},
+		},
+		{
+			name: "RetryPolicies: matching category: retry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("aReason"), tcp.WithInstallFailures(hivev1.InstallFailure{
+					Name:     "aRegex",
+					Reason:   "aReason",
+					Category: hivev1.InstallFailureCategoryCloudAPIOutage,
+				})),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			// The matching policy takes precedence over RetryReasons
+			retryReasons: &[]string{},
+			retryPolicies: []hivev1.FailedProvisionRetryPolicy{
+				{Categories: []hivev1.InstallFailureCategory{hivev1.InstallFailureCategoryCloudAPIOutage}, MaxAttempts: ptr.To(int32(5))},
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected ProvisionStopped to be False")
+					}
+				}
+				assert.Len(t, getProvisions(c), 2, "expected 2 ClusterProvisions to exist")
+			},
+		},
+		{
+			name: "RetryPolicies: max attempts reached: no retry",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment()))
+					cd.Status.InstallRestarts = 3
+					cd.Status.InstallFailureCounts = []hivev1.InstallFailureCount{
+						{Reason: "aReason", Category: hivev1.InstallFailureCategoryUnknown, Count: 2},
+						{Reason: "otherReason", Category: hivev1.InstallFailureCategoryUnknown, Count: 1},
+					}
+					return cd
+				}(),
+				testProvision(tcp.WithFailureReason("aReason")),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			retryPolicies: []hivev1.FailedProvisionRetryPolicy{
+				{Reasons: []string{"aReason"}, MaxAttempts: ptr.To(int32(2))},
+			},
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "expected ProvisionStopped to be True")
+						assert.Equal(t, "RetryPolicyMaxAttemptsReached", cond.Reason, "unexpected ProvisionStopped Reason")
+					}
+				}
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+		{
+			name: "RetryPolicies: attempts failing for other reasons not counted: retry",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					cd := testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment()))
+					cd.Status.InstallRestarts = 5
+					cd.Status.InstallFailureCounts = []hivev1.InstallFailureCount{
+						{Reason: "otherReason", Category: hivev1.InstallFailureCategoryUnknown, Count: 4},
+						{Reason: "aReason", Category: hivev1.InstallFailureCategoryUnknown, Count: 1},
+					}
+					return cd
+				}(),
+				testProvision(tcp.WithFailureReason("aReason")),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			retryPolicies: []hivev1.FailedProvisionRetryPolicy{
+				{Reasons: []string{"aReason"}, MaxAttempts: ptr.To(int32(2))},
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected ProvisionStopped to be False")
+					}
+				}
+				assert.Len(t, getProvisions(c), 2, "expected 2 ClusterProvisions to exist")
+			},
+		},
+		{
+			name: "RetryPolicies: do not retry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("aReason")),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			retryPolicies: []hivev1.FailedProvisionRetryPolicy{
+				{DoNotRetry: true},
+			},
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "expected ProvisionStopped to be True")
+						assert.Equal(t, "RetryPolicyDoNotRetry", cond.Reason, "unexpected ProvisionStopped Reason")
+					}
+				}
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+		{
+			name: "RetryPolicies: no matching policy: RetryReasons apply",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("aReason")),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			retryReasons: &[]string{"bReason"},
+			retryPolicies: []hivev1.FailedProvisionRetryPolicy{
+				{Categories: []hivev1.InstallFailureCategory{hivev1.InstallFailureCategoryQuota}},
+			},
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, "FailureReasonNotRetryable", cond.Reason, "unexpected ProvisionStopped Reason")
+					}
+				}
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			logger := log.WithField("controller", "clusterDeployment")
-			if test.retryReasons == nil {
+			if test.retryReasons == nil && test.retryPolicies == nil {
 				readFile = fakeReadFile("")
 			} else {
-				b, _ := json.Marshal(*test.retryReasons)
-				readFile = fakeReadFile(fmt.Sprintf(`
-				{
-					"retryReasons": %s
-				}
-				`, string(b)))
+				b, _ := json.Marshal(hivev1.FailedProvisionConfig{
+					RetryReasons:  test.retryReasons,
+					RetryPolicies: test.retryPolicies,
+				})
+				readFile = fakeReadFile(string(b))
 			}
 			scheme := scheme.GetScheme()
 			fakeClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.existing...).Build()
@@ -3818,6 +4016,47 @@ func TestCalculateNextProvisionTime(t *testing.T) {
 	}
 }
 
+func TestCalculateRetryPolicyBackoff(t *testing.T) {
+	cases := []struct {
+		name     string
+		backoff  hivev1.FailedProvisionRetryBackoff
+		retries  int
+		expected time.Duration
+	}{
+		{
+			name:     "first attempt",
+			backoff:  hivev1.FailedProvisionRetryBackoff{InitialDelay: metav1.Duration{Duration: 5 * time.Minute}},
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "third attempt",
+			backoff:  hivev1.FailedProvisionRetryBackoff{InitialDelay: metav1.Duration{Duration: 5 * time.Minute}},
+			retries:  2,
+			expected: 20 * time.Minute,
+		},
+		{
+			name: "capped by max delay",
+			backoff: hivev1.FailedProvisionRetryBackoff{
+				InitialDelay: metav1.Duration{Duration: 5 * time.Minute},
+				MaxDelay:     &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			retries:  3,
+			expected: 30 * time.Minute,
+		},
+		{
+			name:     "capped by default max delay",
+			backoff:  hivev1.FailedProvisionRetryBackoff{InitialDelay: metav1.Duration{Duration: time.Hour}},
+			retries:  999999,
+			expected: 24 * time.Hour,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, calculateRetryPolicyBackoff(&tc.backoff, tc.retries), "unexpected backoff")
+		})
+	}
+}
+
 func TestDeleteStaleProvisions(t *testing.T) {
 	cases := []struct {
 		name             string
@@ -5073,6 +5312,75 @@ spacing problem!
 	}
 }
 
+func Test_rfc2136ClusterRecords(t *testing.T) {
+	logger := log.WithField("controller", "clusterDeployment")
+	tests := []struct {
+		name            string
+		icData          string
+		noProvisioning  bool
+		expectedRecords []hivev1.RFC2136AddressRecord
+	}{
+		{
+			name: "vSphere VIPs",
+			icData: `
+platform:
+  vsphere:
+    apiVIPs:
+    - 192.168.1.10
+    ingressVIPs:
+    - 192.168.1.11
+`,
+			expectedRecords: []hivev1.RFC2136AddressRecord{
+				{Name: "api.bar.example.com", Addresses: []string{"192.168.1.10"}},
+				{Name: "*.apps.bar.example.com", Addresses: []string{"192.168.1.11"}},
+			},
+		},
+		{
+			name: "OpenStack floating IPs",
+			icData: `
+platform:
+  openstack:
+    apiVIPs:
+    - 10.0.0.5
+    ingressVIPs:
+    - 10.0.0.7
+    apiFloatingIP: 203.0.113.5
+`,
+			expectedRecords: []hivev1.RFC2136AddressRecord{
+				{Name: "api.bar.example.com", Addresses: []string{"203.0.113.5"}},
+				{Name: "*.apps.bar.example.com", Addresses: []string{"10.0.0.7"}},
+			},
+		},
+		{
+			name: "no VIPs",
+			icData: `
+platform:
+  none: {}
+`,
+		},
+		{
+			name:           "adopted cluster",
+			noProvisioning: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.BaseDomain = "example.com"
+			cd.Spec.Platform = hivev1.Platform{}
+			if test.noProvisioning {
+				cd.Spec.Provisioning = nil
+			}
+			fakeClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(cd, testInstallConfigSecret(test.icData)).Build()
+			r := &ReconcileClusterDeployment{
+				Client: fakeClient,
+				scheme: scheme.GetScheme(),
+			}
+			assert.Equal(t, test.expectedRecords, r.rfc2136ClusterRecords(cd, logger), "unexpected records")
+		})
+	}
+}
+
 func Test_discoverAzureResourceGroup(t *testing.T) {
 	logger := log.WithField("controller", "clusterDeployment")
 	azureCD := func(installed bool, cm *hivev1.ClusterMetadata) *hivev1.ClusterDeployment {
@@ -5597,3 +5905,133 @@ func (testReleaseVerifier) Verifiers() map[string]openpgp.EntityList {
 
 func (testReleaseVerifier) AddStore(_ store.Store) {
 }
+
+func TestGetInstallLogEnvVars(t *testing.T) {
+	t.Setenv(constants.FailedProvisionConfigFileEnvVar, "fake")
+	defer func() { readFile = os.ReadFile }()
+
+	tests := []struct {
+		name     string
+		config   string
+		expected []corev1.EnvVar
+	}{
+		{
+			name:     "not configured",
+			expected: []corev1.EnvVar{},
+		},
+		{
+			name:   "aws takes precedence",
+			config: `{"aws": {"credentialsSecretRef": {"name": "s3-creds"}, "region": "us-east-1", "bucket": "logs"}, "gcp": {"credentialsSecretRef": {"name": "gcs-creds"}, "bucket": "logs"}}`,
+			expected: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderAWS},
+				{Name: constants.InstallLogsCredentialsSecretRefEnvVar, Value: testName + "-s3-creds"},
+				{Name: constants.InstallLogsAWSRegionEnvVar, Value: "us-east-1"},
+				{Name: constants.InstallLogsAWSServiceEndpointEnvVar},
+				{Name: constants.InstallLogsAWSS3BucketEnvVar, Value: "logs"},
+			},
+		},
+		{
+			name:   "azure",
+			config: `{"azure": {"credentialsSecretRef": {"name": "blob-creds"}, "storageAccount": "account", "container": "logs"}, "logRetention": "168h"}`,
+			expected: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderAzure},
+				{Name: constants.InstallLogsCredentialsSecretRefEnvVar, Value: testName + "-blob-creds"},
+				{Name: constants.InstallLogsAzureStorageAccountEnvVar, Value: "account"},
+				{Name: constants.InstallLogsAzureContainerEnvVar, Value: "logs"},
+				{Name: constants.InstallLogsAzureCloudNameEnvVar},
+				{Name: constants.InstallLogsRetentionEnvVar, Value: "168h0m0s"},
+			},
+		},
+		{
+			name:   "gcp",
+			config: `{"gcp": {"credentialsSecretRef": {"name": "gcs-creds"}, "bucket": "logs"}}`,
+			expected: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderGCP},
+				{Name: constants.InstallLogsCredentialsSecretRefEnvVar, Value: testName + "-gcs-creds"},
+				{Name: constants.InstallLogsGCSBucketEnvVar, Value: "logs"},
+			},
+		},
+		{
+			name:   "in cluster defaults to secrets",
+			config: `{"inCluster": {}}`,
+			expected: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderInCluster},
+				{Name: constants.InstallLogsInClusterStorageTypeEnvVar, Value: string(hivev1.FailedProvisionLogStorageSecret)},
+			},
+		},
+		{
+			name:   "in cluster persistent volume claim",
+			config: `{"inCluster": {"storageType": "PersistentVolumeClaim"}}`,
+			expected: []corev1.EnvVar{
+				{Name: constants.InstallLogsUploadProviderEnvVar, Value: constants.InstallLogsUploadProviderInCluster},
+				{Name: constants.InstallLogsInClusterStorageTypeEnvVar, Value: string(hivev1.FailedProvisionLogStoragePersistentVolumeClaim)},
+				{Name: constants.InstallLogsPVCNameEnvVar, Value: testName + "-install-logs"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readFile = fakeReadFile(test.config)
+			envVars, err := getInstallLogEnvVars(testName)
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expected, envVars, "unexpected env vars")
+		})
+	}
+}
+
+func TestEnsureInstallLogsPVC(t *testing.T) {
+	t.Setenv(constants.FailedProvisionConfigFileEnvVar, "fake")
+	defer func() { readFile = os.ReadFile }()
+
+	tests := []struct {
+		name                     string
+		config                   string
+		expectPVC                bool
+		expectedSize             string
+		expectedStorageClassName *string
+	}{
+		{
+			name:   "not configured",
+			config: `{"inCluster": {"storageType": "Secret"}}`,
+		},
+		{
+			name:         "default size",
+			config:       `{"inCluster": {"storageType": "PersistentVolumeClaim"}}`,
+			expectPVC:    true,
+			expectedSize: "1Gi",
+		},
+		{
+			name:                     "configured size and storage class",
+			config:                   `{"inCluster": {"storageType": "PersistentVolumeClaim", "persistentVolumeClaim": {"size": "5Gi", "storageClassName": "fast"}}}`,
+			expectPVC:                true,
+			expectedSize:             "5Gi",
+			expectedStorageClassName: ptr.To("fast"),
+		},
+		{
+			name:   "object store takes precedence",
+			config: `{"gcp": {"credentialsSecretRef": {"name": "gcs-creds"}, "bucket": "logs"}, "inCluster": {"storageType": "PersistentVolumeClaim"}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readFile = fakeReadFile(test.config)
+			cd := testClusterDeployment()
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(cd).Build()
+			r := &ReconcileClusterDeployment{Client: c, scheme: scheme.GetScheme()}
+
+			require.NoError(t, r.ensureInstallLogsPVC(cd, log.WithField("test", test.name)), "unexpected error")
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-install-logs"}, pvc)
+			if !test.expectPVC {
+				assert.True(t, apierrors.IsNotFound(err), "expected no persistent volume claim")
+				return
+			}
+			require.NoError(t, err, "expected persistent volume claim")
+			assert.Equal(t, resource.MustParse(test.expectedSize), pvc.Spec.Resources.Requests[corev1.ResourceStorage], "unexpected size")
+			assert.Equal(t, test.expectedStorageClassName, pvc.Spec.StorageClassName, "unexpected storage class")
+			assert.Equal(t, constants.PVCTypeInstallLogs, pvc.Labels[constants.PVCTypeLabel], "unexpected pvc type label")
+			assert.True(t, metav1.IsControlledBy(pvc, cd), "expected persistent volume claim to be controlled by the cluster deployment")
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -13,10 +15,12 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -108,13 +112,13 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	if cd.Spec.InstallAttemptsLimit != nil && cd.Status.InstallRestarts >= int(*cd.Spec.InstallAttemptsLimit) {
 		return setProvisionStoppedTrue(installAttemptsLimitReachedReason, "Install attempts limit reached")
 	}
-	shouldRetry, err := r.shouldRetryBasedOnFailureReason(lastFailedProvision, logger)
+	shouldRetry, stopReason, stopMessage, err := r.shouldRetryBasedOnFailureReason(cd, lastFailedProvision, logger)
 	if err != nil {
 		logger.WithError(err).Error("failed to determine whether to retry based on provision failure reason")
 		return reconcile.Result{}, err
 	}
 	if !shouldRetry {
-		return setProvisionStoppedTrue(failureReasonNotListed, "Provision failure reason not retryable")
+		return setProvisionStoppedTrue(stopReason, stopMessage)
 	}
 
 	conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
@@ -209,6 +213,11 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		}
 	}
 
+	if err := r.ensureInstallLogsPVC(cd, logger); err != nil {
+		logger.WithError(err).Error("could not create install logs persistent volume claim")
+		return reconcile.Result{}, err
+	}
+
 	if err := install.CopyAWSServiceProviderSecret(r.Client, provision.Namespace, extraEnvVars, cd, r.scheme); err != nil {
 		logger.WithError(err).Error("could not copy AWS service provider secret")
 		return reconcile.Result{}, err
@@ -248,37 +257,129 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileClusterDeployment) shouldRetryBasedOnFailureReason(prov *hivev1.ClusterProvision, logger log.FieldLogger) (bool, error) {
+// shouldRetryBasedOnFailureReason returns whether to start a new provision after the failed provision and, if not, the
+// reason and message for stopping.
+func (r *ReconcileClusterDeployment) shouldRetryBasedOnFailureReason(cd *hivev1.ClusterDeployment, prov *hivev1.ClusterProvision, logger log.FieldLogger) (bool, string, string, error) {
 	// prov will be nil if there have been no failed provisions yet
 	if prov == nil {
 		logger.Debug("no failed provisions yet -- allowing retry")
-		return true, nil
+		return true, "", "", nil
 	}
 	// Load up FailedProvisionConfig
 	fpConfig, err := readProvisionFailedConfig()
 	if err != nil {
-		return false, err
+		return false, "", "", err
+	}
+	// A matching retry policy takes precedence over RetryReasons
+	if policy := retryPolicyForProvision(fpConfig, prov); policy != nil {
+		pLog := logger.WithField("reason", provisionFailedReason(prov))
+		switch {
+		case policy.DoNotRetry:
+			pLog.Debug("retry policy does not allow retry -- not retrying")
+			return false, retryPolicyDoNotRetryReason, "Provision failure reason not retryable according to retry policy", nil
+		case policy.MaxAttempts != nil && retryPolicyAttempts(cd, policy) >= int(*policy.MaxAttempts):
+			pLog.Debug("retry policy install attempts limit reached -- not retrying")
+			return false, retryPolicyMaxAttemptsReason, "Install attempts limit of retry policy reached", nil
+		}
+		pLog.Debug("retrying according to retry policy")
+		return true, "", "", nil
 	}
 	// If no retry reasons are specified, "always" retry
 	if fpConfig.RetryReasons == nil {
 		logger.Debug("no RetryReasons found in FailedProvisionConfig -- allowing retry")
-		return true, nil
+		return true, "", "", nil
 	}
 	// Does our failed provision's reason match?
 	cond := controllerutils.FindCondition(
 		prov.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
 	if cond == nil {
-		return false, errors.New("failed to find ClusterProvisionFailed Condition -- this should never happen!")
+		return false, "", "", errors.New("failed to find ClusterProvisionFailed Condition -- this should never happen!")
 	}
 	rLog := logger.WithField("reason", cond.Reason)
 	for _, reason := range *fpConfig.RetryReasons {
 		if cond.Reason == reason {
 			rLog.Debug("retrying due to matching retry reason")
-			return true, nil
+			return true, "", "", nil
 		}
 	}
 	rLog.Debug("reason not found in FailedProvisionConfig RetryReasons -- not retrying")
-	return false, nil
+	return false, failureReasonNotListed, "Provision failure reason not retryable", nil
+}
+
+// provisionFailedReason returns the reason of the ClusterProvisionFailed condition of the provision, or an empty
+// string if the condition is missing.
+func provisionFailedReason(prov *hivev1.ClusterProvision) string {
+	cond := controllerutils.FindCondition(prov.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
+	if cond == nil {
+		return ""
+	}
+	return cond.Reason
+}
+
+// provisionFailureCategory returns the category of the failure reason of the failed provision, as classified by the
+// install log regex which produced it.
+func provisionFailureCategory(prov *hivev1.ClusterProvision, reason string) hivev1.InstallFailureCategory {
+	for _, failure := range prov.Status.InstallFailures {
+		if failure.Reason == reason {
+			return failure.Category
+		}
+	}
+	return hivev1.InstallFailureCategoryUnknown
+}
+
+// retryPolicyMatches returns whether the retry policy applies to failures with the reason and category.
+func retryPolicyMatches(policy *hivev1.FailedProvisionRetryPolicy, reason string, category hivev1.InstallFailureCategory) bool {
+	return len(policy.Reasons) == 0 && len(policy.Categories) == 0 ||
+		slices.Contains(policy.Reasons, reason) ||
+		slices.Contains(policy.Categories, category)
+}
+
+// retryPolicyForProvision returns the first retry policy in the FailedProvisionConfig matching the failure reason, or
+// the category of that reason, of the failed provision. Returns nil if there is no such policy.
+func retryPolicyForProvision(fpConfig *hivev1.FailedProvisionConfig, prov *hivev1.ClusterProvision) *hivev1.FailedProvisionRetryPolicy {
+	reason := provisionFailedReason(prov)
+	if reason == "" {
+		return nil
+	}
+	category := provisionFailureCategory(prov, reason)
+	for i := range fpConfig.RetryPolicies {
+		if retryPolicyMatches(&fpConfig.RetryPolicies[i], reason, category) {
+			return &fpConfig.RetryPolicies[i]
+		}
+	}
+	return nil
+}
+
+// retryPolicyAttempts returns the number of failed install attempts of the ClusterDeployment matched by the retry
+// policy.
+func retryPolicyAttempts(cd *hivev1.ClusterDeployment, policy *hivev1.FailedProvisionRetryPolicy) int {
+	attempts := 0
+	for _, failures := range cd.Status.InstallFailureCounts {
+		if retryPolicyMatches(policy, failures.Reason, failures.Category) {
+			attempts += int(failures.Count)
+		}
+	}
+	return attempts
+}
+
+// countInstallFailure counts the failure reason of the failed provision in the InstallFailureCounts of the
+// ClusterDeployment. The caller is responsible for updating the status of the ClusterDeployment.
+func countInstallFailure(cd *hivev1.ClusterDeployment, prov *hivev1.ClusterProvision) {
+	reason := provisionFailedReason(prov)
+	if reason == "" {
+		return
+	}
+	for i := range cd.Status.InstallFailureCounts {
+		if cd.Status.InstallFailureCounts[i].Reason == reason {
+			cd.Status.InstallFailureCounts[i].Count++
+			return
+		}
+	}
+	cd.Status.InstallFailureCounts = append(cd.Status.InstallFailureCounts, hivev1.InstallFailureCount{
+		Reason:   reason,
+		Category: provisionFailureCategory(prov, reason),
+		Count:    1,
+	})
 }
 
 // setAWSHostedZoneRoleFromMetadata unmarshals `pmjson`, a representation of the installer ClusterMetadata type,
@@ -403,7 +504,7 @@ func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.Clust
 	switch err := r.Get(context.TODO(), types.NamespacedName{Name: cd.Status.ProvisionRef.Name, Namespace: cd.Namespace}, provision); {
 	case apierrors.IsNotFound(err):
 		logger.Warn("linked provision not found")
-		return r.clearOutCurrentProvision(cd, logger)
+		return r.clearOutCurrentProvision(cd, nil, logger)
 	case err != nil:
 		logger.WithError(err).Error("could not get provision")
 		return reconcile.Result{}, err
@@ -566,6 +667,27 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 		nextProvisionTime = calculateNextProvisionTime(failedCond.LastTransitionTime.Time, cd.Status.InstallRestarts)
 		reason = failedCond.Reason
 		message = failedCond.Message
+
+		fpConfig, err := readProvisionFailedConfig()
+		if err != nil {
+			cdLog.WithError(err).Error("failed to read failed provision config file")
+			return reconcile.Result{}, err
+		}
+		if policy := retryPolicyForProvision(fpConfig, provision); policy != nil {
+			switch {
+			case policy.DoNotRetry:
+				// No point in waiting, provisioning is stopped once the failed provision is cleared out.
+				nextProvisionTime = time.Now()
+			case policy.Backoff != nil:
+				backoff := calculateRetryPolicyBackoff(policy.Backoff, cd.Status.InstallRestarts)
+				nextProvisionTime = failedCond.LastTransitionTime.Time.Add(backoff)
+				message = fmt.Sprintf("%s (retry policy backoff of %s applied, next provision at %s)",
+					message, backoff, nextProvisionTime.UTC().Format(time.RFC3339))
+			}
+			if policy.Hint == hivev1.FailedProvisionRetryHintSwitchRegionOrZone {
+				message = fmt.Sprintf("%s. Consider installing in a different region or availability zone.", strings.TrimSuffix(message, "."))
+			}
+		}
 	} else {
 		cdLog.Warnf("failed provision does not have a %s condition", hivev1.ClusterProvisionFailedCondition)
 	}
@@ -592,7 +714,7 @@ func (r *ReconcileClusterDeployment) reconcileFailedProvision(cd *hivev1.Cluster
 	}
 
 	cdLog.Info("clearing current failed provision to make way for a new provision")
-	return r.clearOutCurrentProvision(cd, cdLog)
+	return r.clearOutCurrentProvision(cd, provision, cdLog)
 }
 
 func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision, cdLog log.FieldLogger) (reconcile.Result, error) {
@@ -681,9 +803,13 @@ func getClusterImageSetFromProvisioning(cd *hivev1.ClusterDeployment) string {
 	return ""
 }
 
-func (r *ReconcileClusterDeployment) clearOutCurrentProvision(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
+// clearOutCurrentProvision makes way for a new provision, counting the failure of the current one, if given.
+func (r *ReconcileClusterDeployment) clearOutCurrentProvision(cd *hivev1.ClusterDeployment, failedProvision *hivev1.ClusterProvision, cdLog log.FieldLogger) (reconcile.Result, error) {
 	cd.Status.ProvisionRef = nil
 	cd.Status.InstallRestarts = cd.Status.InstallRestarts + 1
+	if failedProvision != nil {
+		countInstallFailure(cd, failedProvision)
+	}
 	if err := r.Status().Update(context.TODO(), cd); err != nil {
 		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not clear out current provision")
 		return reconcile.Result{}, err
@@ -751,7 +877,9 @@ func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 	if err != nil || fpConfig == nil {
 		return extraEnvVars, err
 	}
-	if awsSpec := fpConfig.AWS; awsSpec != nil {
+	switch {
+	case fpConfig.AWS != nil:
+		awsSpec := fpConfig.AWS
 		// By default we will try to gather logs on failed installs:
 		extraEnvVars = []corev1.EnvVar{
 			{
@@ -775,11 +903,139 @@ func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 				Value: awsSpec.Bucket,
 			},
 		}
+	case fpConfig.Azure != nil:
+		azureSpec := fpConfig.Azure
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderAzure,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: secretPrefix + "-" + azureSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsAzureStorageAccountEnvVar,
+				Value: azureSpec.StorageAccount,
+			},
+			{
+				Name:  constants.InstallLogsAzureContainerEnvVar,
+				Value: azureSpec.Container,
+			},
+			{
+				Name:  constants.InstallLogsAzureCloudNameEnvVar,
+				Value: azureSpec.CloudName.Name(),
+			},
+		}
+	case fpConfig.GCP != nil:
+		gcpSpec := fpConfig.GCP
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderGCP,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: secretPrefix + "-" + gcpSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsGCSBucketEnvVar,
+				Value: gcpSpec.Bucket,
+			},
+		}
+	case fpConfig.InCluster != nil:
+		storageType := installLogsStorageType(fpConfig.InCluster)
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderInCluster,
+			},
+			{
+				Name:  constants.InstallLogsInClusterStorageTypeEnvVar,
+				Value: string(storageType),
+			},
+		}
+		if storageType == hivev1.FailedProvisionLogStoragePersistentVolumeClaim {
+			extraEnvVars = append(extraEnvVars, corev1.EnvVar{
+				Name:  constants.InstallLogsPVCNameEnvVar,
+				Value: installLogsPVCName(secretPrefix),
+			})
+		}
+	default:
+		return extraEnvVars, nil
+	}
+
+	if fpConfig.LogRetention != nil {
+		extraEnvVars = append(extraEnvVars, corev1.EnvVar{
+			Name:  constants.InstallLogsRetentionEnvVar,
+			Value: fpConfig.LogRetention.Duration.String(),
+		})
 	}
 
 	return extraEnvVars, nil
 }
 
+func installLogsStorageType(config *hivev1.FailedProvisionInClusterConfig) hivev1.FailedProvisionLogStorageType {
+	if config.StorageType == "" {
+		return hivev1.FailedProvisionLogStorageSecret
+	}
+	return config.StorageType
+}
+
+// installLogsPVCName returns the name of the PersistentVolumeClaim the logs of failed provisions of a
+// ClusterDeployment are stored in.
+func installLogsPVCName(cdName string) string {
+	return apihelpers.GetResourceName(cdName, "install-logs")
+}
+
+// ensureInstallLogsPVC creates the PersistentVolumeClaim logs of failed provisions are stored in if they are
+// configured to be stored in one.
+func (r *ReconcileClusterDeployment) ensureInstallLogsPVC(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	fpConfig, err := readProvisionFailedConfig()
+	if err != nil {
+		return err
+	}
+	if fpConfig.AWS != nil || fpConfig.Azure != nil || fpConfig.GCP != nil || fpConfig.InCluster == nil ||
+		installLogsStorageType(fpConfig.InCluster) != hivev1.FailedProvisionLogStoragePersistentVolumeClaim {
+		return nil
+	}
+
+	name := installLogsPVCName(cd.Name)
+	err = r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: name}, &corev1.PersistentVolumeClaim{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	size := resource.MustParse("1Gi")
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cd.Namespace,
+			Labels: map[string]string{
+				constants.ClusterDeploymentNameLabel: cd.Name,
+				constants.PVCTypeLabel:               constants.PVCTypeInstallLogs,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+	}
+	if pvcConfig := fpConfig.InCluster.PersistentVolumeClaim; pvcConfig != nil {
+		if pvcConfig.StorageClassName != "" {
+			pvc.Spec.StorageClassName = ptr.To(pvcConfig.StorageClassName)
+		}
+		if pvcConfig.Size != nil {
+			size = *pvcConfig.Size
+		}
+	}
+	pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: size}
+	if err := controllerutil.SetControllerReference(cd, pvc, r.scheme); err != nil {
+		return err
+	}
+	logger.WithField("pvc", name).Info("creating install logs persistent volume claim")
+	return r.Create(context.TODO(), pvc)
+}
+
 func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeployment, secretPrefix string) []corev1.EnvVar {
 	var extraEnvVars []corev1.EnvVar
 	spSecretName := controllerutils.AWSServiceProviderSecretName(secretPrefix)
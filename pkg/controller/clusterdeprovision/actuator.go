@@ -1,6 +1,10 @@
 package clusterdeprovision
 
 import (
+	"context"
+	"errors"
+	"net"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,3 +18,10 @@ type Actuator interface {
 	// TestCredentials returns nil if the credential check succeeds. Otherwise returns the error.
 	TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error
 }
+
+// isConnectionError returns true if the error from TestCredentials is a failure to reach the cloud, rather than a
+// rejection of the credentials.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
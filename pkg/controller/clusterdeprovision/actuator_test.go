@@ -0,0 +1,191 @@
+package clusterdeprovision
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/gcpclient"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+	"github.com/openshift/hive/pkg/ibmclient"
+	mockibm "github.com/openshift/hive/pkg/ibmclient/mock"
+	"github.com/openshift/hive/pkg/nutanixclient"
+	mocknutanix "github.com/openshift/hive/pkg/nutanixclient/mock"
+	"github.com/openshift/hive/pkg/openstackclient"
+	mockopenstack "github.com/openshift/hive/pkg/openstackclient/mock"
+	"github.com/openshift/hive/pkg/vsphereclient"
+	mockvsphere "github.com/openshift/hive/pkg/vsphereclient/mock"
+)
+
+func TestActuatorsTestCredentials(t *testing.T) {
+	authErr := errors.New("authentication failed")
+
+	tests := []struct {
+		name string
+		// actuator builds the actuator under test, with its client mocked. callErr is returned by the call which
+		// authenticates, or by the client function if nothing else does.
+		actuator  func(ctrl *gomock.Controller, callErr error) Actuator
+		platform  hivev1.ClusterDeprovisionPlatform
+		expectErr bool
+	}{
+		{
+			name:     "azure",
+			actuator: azureTestActuator,
+			platform: hivev1.ClusterDeprovisionPlatform{Azure: &hivev1.AzureClusterDeprovision{}},
+		},
+		{
+			name:      "azure failure",
+			actuator:  azureTestActuator,
+			platform:  hivev1.ClusterDeprovisionPlatform{Azure: &hivev1.AzureClusterDeprovision{}},
+			expectErr: true,
+		},
+		{
+			name:     "gcp",
+			actuator: gcpTestActuator,
+			platform: hivev1.ClusterDeprovisionPlatform{GCP: &hivev1.GCPClusterDeprovision{}},
+		},
+		{
+			name:      "gcp failure",
+			actuator:  gcpTestActuator,
+			platform:  hivev1.ClusterDeprovisionPlatform{GCP: &hivev1.GCPClusterDeprovision{}},
+			expectErr: true,
+		},
+		{
+			name:     "ibmcloud",
+			actuator: ibmCloudTestActuator,
+			platform: hivev1.ClusterDeprovisionPlatform{IBMCloud: &hivev1.IBMClusterDeprovision{}},
+		},
+		{
+			name:      "ibmcloud failure",
+			actuator:  ibmCloudTestActuator,
+			platform:  hivev1.ClusterDeprovisionPlatform{IBMCloud: &hivev1.IBMClusterDeprovision{}},
+			expectErr: true,
+		},
+		{
+			name:     "nutanix",
+			actuator: nutanixTestActuator,
+			platform: hivev1.ClusterDeprovisionPlatform{Nutanix: &hivev1.NutanixClusterDeprovision{}},
+		},
+		{
+			name:      "nutanix failure",
+			actuator:  nutanixTestActuator,
+			platform:  hivev1.ClusterDeprovisionPlatform{Nutanix: &hivev1.NutanixClusterDeprovision{}},
+			expectErr: true,
+		},
+		{
+			name:     "openstack",
+			actuator: openStackTestActuator,
+			platform: hivev1.ClusterDeprovisionPlatform{OpenStack: &hivev1.OpenStackClusterDeprovision{}},
+		},
+		{
+			name:      "openstack failure",
+			actuator:  openStackTestActuator,
+			platform:  hivev1.ClusterDeprovisionPlatform{OpenStack: &hivev1.OpenStackClusterDeprovision{}},
+			expectErr: true,
+		},
+		{
+			name:     "vsphere",
+			actuator: vSphereTestActuator,
+			platform: hivev1.ClusterDeprovisionPlatform{VSphere: &hivev1.VSphereClusterDeprovision{}},
+		},
+		{
+			name:      "vsphere failure",
+			actuator:  vSphereTestActuator,
+			platform:  hivev1.ClusterDeprovisionPlatform{VSphere: &hivev1.VSphereClusterDeprovision{}},
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			var callErr error
+			if test.expectErr {
+				callErr = authErr
+			}
+			actuator := test.actuator(ctrl, callErr)
+			cdp := testClusterDeprovision()
+			cdp.Spec.Platform = test.platform
+
+			if !assert.True(t, actuator.CanHandle(cdp), "actuator cannot handle its platform") {
+				return
+			}
+			err := actuator.TestCredentials(cdp, nil, log.WithField("test", test.name))
+			if test.expectErr {
+				assert.ErrorIs(t, err, authErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestActuatorsCanHandle(t *testing.T) {
+	cdp := testClusterDeprovision()
+	handled := 0
+	for _, a := range actuators {
+		if a.CanHandle(cdp) {
+			handled++
+		}
+	}
+	assert.Equal(t, 1, handled, "expected exactly one actuator to handle an AWS deprovision")
+}
+
+func azureTestActuator(ctrl *gomock.Controller, callErr error) Actuator {
+	azureClient := mockazure.NewMockClient(ctrl)
+	azureClient.EXPECT().ListAllVirtualMachines(gomock.Any(), "true").Return(compute.VirtualMachineListResultPage{}, callErr)
+	return &azureActuator{azureClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (azureclient.Client, error) {
+		return azureClient, nil
+	}}
+}
+
+func gcpTestActuator(ctrl *gomock.Controller, callErr error) Actuator {
+	gcpClient := mockgcp.NewMockClient(ctrl)
+	gcpClient.EXPECT().ListComputeZones(gcpclient.ListComputeZonesOptions{MaxResults: 1}).Return(nil, callErr)
+	return &gcpActuator{gcpClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (gcpclient.Client, error) {
+		return gcpClient, nil
+	}}
+}
+
+func ibmCloudTestActuator(ctrl *gomock.Controller, callErr error) Actuator {
+	ibmCloudClient := mockibm.NewMockAPI(ctrl)
+	ibmCloudClient.EXPECT().GetAuthenticatorAPIKeyDetails(gomock.Any()).Return(nil, callErr)
+	return &ibmCloudActuator{ibmCloudClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (ibmclient.API, error) {
+		return ibmCloudClient, nil
+	}}
+}
+
+func nutanixTestActuator(ctrl *gomock.Controller, callErr error) Actuator {
+	nutanixClient := mocknutanix.NewMockAPI(ctrl)
+	nutanixClient.EXPECT().GetCurrentLoggedInUser(gomock.Any()).Return(nil, callErr)
+	return &nutanixActuator{nutanixClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (nutanixclient.API, error) {
+		return nutanixClient, nil
+	}}
+}
+
+func openStackTestActuator(ctrl *gomock.Controller, callErr error) Actuator {
+	return &openStackActuator{openStackClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (openstackclient.API, error) {
+		if callErr != nil {
+			return nil, callErr
+		}
+		return mockopenstack.NewMockAPI(ctrl), nil
+	}}
+}
+
+func vSphereTestActuator(ctrl *gomock.Controller, callErr error) Actuator {
+	return &vSphereActuator{vSphereClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (vsphereclient.API, error) {
+		if callErr != nil {
+			return nil, callErr
+		}
+		vSphereClient := mockvsphere.NewMockAPI(ctrl)
+		vSphereClient.EXPECT().Logout()
+		return vSphereClient, nil
+	}}
+}
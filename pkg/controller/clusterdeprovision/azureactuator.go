@@ -0,0 +1,63 @@
+package clusterdeprovision
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+)
+
+func init() {
+	registerActuator(&azureActuator{azureClientFn: getAzureClient})
+}
+
+// Ensure azureActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &azureActuator{}
+
+// azureActuator tests the credentials of Azure deprovisions.
+type azureActuator struct {
+	// azureClientFn is the function to build an Azure client, here for testing
+	azureClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (azureclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *azureActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.Azure != nil
+}
+
+// TestCredentials ensures that the azure credentials are usable.
+func (a *azureActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	azureClient, err := a.azureClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+
+	// The client only authenticates once it makes a request. Fetching the first page of virtual machines is
+	// cheap and needs the same permissions as the uninstaller.
+	if _, err := azureClient.ListAllVirtualMachines(context.TODO(), "true"); err != nil {
+		return errors.Wrap(err, "failed to list virtual machines")
+	}
+
+	// Creds passed check.
+	return nil
+}
+
+func getAzureClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (azureclient.Client, error) {
+	if cd.Spec.Platform.Azure.CredentialsSecretRef == nil {
+		return nil, errors.New("no Azure credentials secret set")
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
+	}
+	var cloudName string
+	if cd.Spec.Platform.Azure.CloudName != nil {
+		cloudName = cd.Spec.Platform.Azure.CloudName.Name()
+	}
+	return azureclient.NewClientFromSecret(secret, cloudName)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -40,6 +41,14 @@ const (
 	jobHashAnnotation             = "hive.openshift.io/jobhash"
 	authenticationFailedReason    = "AuthenticationFailed"
 	authenticationSucceededReason = "AuthenticationSucceeded"
+	cloudUnreachableReason        = "CloudUnreachable"
+	cloudReachableReason          = "CloudReachable"
+	deprovisionStuckReason        = "ResourceBlocking"
+	deprovisionProgressingReason  = "DeprovisionProgressing"
+
+	// deprovisionStuckThreshold is how long the uninstall job may fail to delete the same resource before the
+	// deprovision is considered stuck.
+	deprovisionStuckThreshold = 30 * time.Minute
 )
 
 var (
@@ -232,23 +241,50 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, nil
 	}
 
+	// The credentials are only checked before the uninstall job is created. Once it exists, the job reports its own
+	// failures.
+	uninstallJobExists := true
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: install.GetUninstallJobName(instance.Name)}, &batchv1.Job{}); errors.IsNotFound(err) {
+		uninstallJobExists = false
+	} else if err != nil {
+		rLog.WithError(err).Error("error getting uninstall job")
+		return reconcile.Result{}, err
+	}
+
 	actuator := r.getActuator(instance)
 	if actuator == nil {
 		rLog.Debug("No actuator found for this provider")
-	} else {
+	} else if !uninstallJobExists {
 		// actuator found, ensure creds work.
 		err := actuator.TestCredentials(instance, r.Client, rLog)
 		if err != nil {
 			rLog.WithError(err).Warn("Credential check failed")
 
-			conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
-				instance.Status.Conditions,
-				hivev1.AuthenticationFailureClusterDeprovisionCondition,
-				corev1.ConditionTrue,
-				authenticationFailedReason,
-				"Credential check failed",
-				controllerutils.UpdateConditionIfReasonOrMessageChange,
-			)
+			var conditions []hivev1.ClusterDeprovisionCondition
+			var changed bool
+			if isConnectionError(err) {
+				// The credentials may be fine: leave the AuthenticationFailure condition as it was.
+				conditions, changed = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+					instance.Status.Conditions,
+					hivev1.CloudUnreachableClusterDeprovisionCondition,
+					corev1.ConditionTrue,
+					cloudUnreachableReason,
+					fmt.Sprintf("Could not reach the cloud to check credentials: %v", err),
+					controllerutils.UpdateConditionIfReasonOrMessageChange,
+				)
+			} else {
+				conditions, changed = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+					instance.Status.Conditions,
+					hivev1.AuthenticationFailureClusterDeprovisionCondition,
+					corev1.ConditionTrue,
+					authenticationFailedReason,
+					fmt.Sprintf("Credential check failed: %v", err),
+					controllerutils.UpdateConditionIfReasonOrMessageChange,
+				)
+				var reachedChanged bool
+				conditions, reachedChanged = setCloudReachable(conditions)
+				changed = changed || reachedChanged
+			}
 
 			if changed {
 				instance.Status.Conditions = conditions
@@ -269,6 +305,9 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 			"Credential check succeeded",
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
 		)
+		var reachedChanged bool
+		conditions, reachedChanged = setCloudReachable(conditions)
+		changed = changed || reachedChanged
 
 		if changed {
 			instance.Status.Conditions = conditions
@@ -359,6 +398,14 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 			"Deprovision has succeeded",
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
 		)
+		conditions, _ = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+			conditions,
+			hivev1.DeprovisionStuckClusterDeprovisionCondition,
+			corev1.ConditionFalse,
+			"DeprovisionCompleted",
+			"Deprovision has succeeded",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
 		instance.Status.Conditions = conditions
 
 		// jobDuration calculates the time elapsed since the uninstall job started for deprovision job
@@ -419,7 +466,42 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 	}
 
 	rLog.Infof("uninstall job not yet successful")
-	return reconcile.Result{}, nil
+	return r.setStuckCondition(instance, rLog)
+}
+
+// setStuckCondition sets the DeprovisionStuck condition from the progress reported by the uninstall job. If a resource
+// is blocking the deprovision but not yet for long enough, it requeues for when it will have been.
+func (r *ReconcileClusterDeprovision) setStuckCondition(instance *hivev1.ClusterDeprovision, logger log.FieldLogger) (reconcile.Result, error) {
+	status, reason, message := corev1.ConditionFalse, deprovisionProgressingReason, "No resource has been blocking the deprovision for too long"
+	var requeueAfter time.Duration
+	if progress := instance.Status.Progress; progress != nil && progress.BlockingResource != nil {
+		blocking := progress.BlockingResource
+		if blockedFor := time.Since(blocking.Since.Time); blockedFor >= deprovisionStuckThreshold {
+			status, reason = corev1.ConditionTrue, deprovisionStuckReason
+			message = fmt.Sprintf("Failing to delete %s since %s: %s", blocking.Name, blocking.Since.UTC().Format(time.RFC3339), blocking.Error)
+		} else {
+			requeueAfter = deprovisionStuckThreshold - blockedFor
+		}
+	}
+	conditions, changed := controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.DeprovisionStuckClusterDeprovisionCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		if status == corev1.ConditionTrue {
+			logger.WithField("resource", instance.Status.Progress.BlockingResource.Name).Warn("deprovision is stuck")
+		}
+		instance.Status.Conditions = conditions
+		if err := r.Status().Update(context.TODO(), instance); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating stuck condition")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
@@ -462,6 +544,18 @@ func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeprovision, secretPrefix st
 	return extraEnvVars
 }
 
+// setCloudReachable clears the CloudUnreachable condition once the credential check has reached the cloud.
+func setCloudReachable(conditions []hivev1.ClusterDeprovisionCondition) ([]hivev1.ClusterDeprovisionCondition, bool) {
+	return controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		conditions,
+		hivev1.CloudUnreachableClusterDeprovisionCondition,
+		corev1.ConditionFalse,
+		cloudReachableReason,
+		"Credential check reached the cloud",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
 func (r *ReconcileClusterDeprovision) setupAWSCredentialForAssumeRole(cd *hivev1.ClusterDeprovision) error {
 	if cd.Spec.Platform.AWS == nil ||
 		cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" ||
@@ -2,7 +2,10 @@ package clusterdeprovision
 
 import (
 	"context"
+	"net"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
@@ -25,6 +28,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/install"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
 	"github.com/openshift/hive/pkg/util/scheme"
 )
 
@@ -130,11 +134,38 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			existing: []runtime.Object{
 				testUninstallJob(),
 			},
-			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateNotCompleted(t, c)
 			},
 		},
+		{
+			name:        "stuck when resource blocks too long",
+			deprovision: testClusterDeprovisionBlockedFor(time.Hour),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.DeprovisionStuckClusterDeprovisionCondition,
+						Reason: deprovisionStuckReason,
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+		},
+		{
+			name:        "not stuck when resource blocks briefly",
+			deprovision: testClusterDeprovisionBlockedFor(time.Minute),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(),
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{})
+			},
+		},
 		{
 			name:        "completed when job is successful",
 			deprovision: testClusterDeprovision(),
@@ -145,7 +176,6 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					Status: corev1.ConditionTrue,
 				}),
 			},
-			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateCompleted(t, c)
 			},
@@ -160,9 +190,8 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					Status: corev1.ConditionTrue,
 				}),
 			},
-			mockGetCallerIdentity: true,
-			mockDeleteFailure:     true,
-			expectErr:             true,
+			mockDeleteFailure: true,
+			expectErr:         true,
 		},
 		{
 			name:        "job failed",
@@ -174,7 +203,6 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					Status: corev1.ConditionTrue,
 				}),
 			},
-			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
 					{
@@ -197,7 +225,6 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					Reason: "DeadlineExceeded",
 				}),
 			},
-			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
 					{
@@ -210,18 +237,13 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			},
 		},
 		{
-			name:        "credentials test fails",
-			deprovision: testClusterDeprovision(),
-			deployment:  testDeletedClusterDeployment(),
-			existing: []runtime.Object{
-				testUninstallJob(batchv1.JobCondition{
-					Type:   batchv1.JobComplete,
-					Status: corev1.ConditionTrue,
-				}),
-			},
+			name:                           "credentials test fails",
+			deprovision:                    testClusterDeprovision(),
+			deployment:                     testDeletedClusterDeployment(),
 			mockGetCallerIdentity:          true,
 			expectedGetCallerIdentityError: awsclient.NewAPIError("InvalidClientTokenId", ""),
 			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
 				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
 					{
 						Type:   hivev1.AuthenticationFailureClusterDeprovisionCondition,
@@ -232,6 +254,28 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:                  "credentials test cannot reach cloud",
+			deprovision:           testClusterDeprovision(),
+			deployment:            testDeletedClusterDeployment(),
+			mockGetCallerIdentity: true,
+			expectedGetCallerIdentityError: &url.Error{
+				Op:  "Post",
+				URL: "https://sts.us-east-1.amazonaws.com/",
+				Err: &net.DNSError{Err: "no such host", Name: "sts.us-east-1.amazonaws.com", IsTimeout: true},
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateNoJobExists(t, c)
+				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.CloudUnreachableClusterDeprovisionCondition,
+						Reason: "CloudUnreachable",
+						Status: corev1.ConditionTrue,
+					},
+				})
+			},
+			expectErr: true,
+		},
 		{
 			name:        "regenerate job when hash missing",
 			deprovision: testClusterDeprovision(),
@@ -243,7 +287,6 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					return job
 				}(),
 			},
-			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateNoJobExists(t, c)
 			},
@@ -259,7 +302,6 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					return job
 				}(),
 			},
-			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateNoJobExists(t, c)
 			},
@@ -372,9 +414,32 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 // By default, the start and completion timestamps are unset.
 // If one or more `conditions` are specified, we will set the time stamps in addition to adding the
 // specified conditions.
+// testClusterDeprovisionBlockedFor returns a ClusterDeprovision whose uninstall job has been failing to delete the same
+// resource for the given duration.
+func testClusterDeprovisionBlockedFor(blockedFor time.Duration) *hivev1.ClusterDeprovision {
+	cdp := testClusterDeprovision()
+	cdp.Status.Progress = &hivev1.ClusterDeprovisionProgress{
+		LastUpdateTime:         metav1.Now(),
+		DeletedResources:       12,
+		RemainingResourceTypes: []string{"ec2:vpc"},
+		BlockingResource: &hivev1.ClusterDeprovisionBlockingResource{
+			Name:  "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0123456789",
+			Type:  "ec2:vpc",
+			Error: "DependencyViolation: The vpc has dependencies and cannot be deleted.",
+			Since: metav1.NewTime(time.Now().Add(-blockedFor)),
+		},
+	}
+	return cdp
+}
+
 func testUninstallJob(conditions ...batchv1.JobCondition) *batchv1.Job {
-	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(testClusterDeprovision(),
-		"someserviceaccount", "", "", "", nil, controllerutils.SharedPodConfig{}, log.StandardLogger())
+	cdp := testClusterDeprovision()
+	// Generate the job as the controller does, so that its hash is current
+	uninstallJob, _ := install.GenerateUninstallerJobForDeprovision(cdp,
+		controllerutils.UninstallServiceAccountName, "", "", "", getAWSServiceProviderEnvVars(cdp, cdp.Name),
+		controllerutils.SharedPodConfig{}, log.StandardLogger())
+	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.ClusterDeprovisionNameLabel, cdp.Name)
+	uninstallJob.Labels = k8slabels.AddLabel(uninstallJob.Labels, constants.JobTypeLabel, constants.JobTypeDeprovision)
 	hash, err := controllerutils.CalculateJobSpecHash(uninstallJob)
 	if err != nil {
 		panic("should never get error calculating job spec hash")
@@ -0,0 +1,58 @@
+package clusterdeprovision
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func init() {
+	registerActuator(&gcpActuator{gcpClientFn: getGCPClient})
+}
+
+// Ensure gcpActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &gcpActuator{}
+
+// gcpActuator tests the credentials of GCP deprovisions.
+type gcpActuator struct {
+	// gcpClientFn is the function to build a GCP client, here for testing
+	gcpClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (gcpclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *gcpActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.GCP != nil
+}
+
+// TestCredentials ensures that the gcp credentials are usable.
+func (a *gcpActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	gcpClient, err := a.gcpClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+
+	// The client only authenticates once it makes a request, so list a single zone.
+	if _, err := gcpClient.ListComputeZones(gcpclient.ListComputeZonesOptions{MaxResults: 1}); err != nil {
+		return errors.Wrap(err, "failed to list compute zones")
+	}
+
+	// Creds passed check.
+	return nil
+}
+
+func getGCPClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (gcpclient.Client, error) {
+	if cd.Spec.Platform.GCP.CredentialsSecretRef == nil {
+		return nil, errors.New("no GCP credentials secret set")
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
+	}
+	return gcpclient.NewClientFromSecret(secret)
+}
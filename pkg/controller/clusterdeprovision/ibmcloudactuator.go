@@ -0,0 +1,54 @@
+package clusterdeprovision
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/ibmclient"
+)
+
+func init() {
+	registerActuator(&ibmCloudActuator{ibmCloudClientFn: getIBMCloudClient})
+}
+
+// Ensure ibmCloudActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &ibmCloudActuator{}
+
+// ibmCloudActuator tests the credentials of IBM Cloud deprovisions.
+type ibmCloudActuator struct {
+	// ibmCloudClientFn is the function to build an IBM Cloud client, here for testing
+	ibmCloudClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (ibmclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *ibmCloudActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.IBMCloud != nil
+}
+
+// TestCredentials ensures that the ibm cloud API key is usable.
+func (a *ibmCloudActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	ibmCloudClient, err := a.ibmCloudClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ibmCloudClient.GetAuthenticatorAPIKeyDetails(context.TODO()); err != nil {
+		return errors.Wrap(err, "failed to get API key details")
+	}
+
+	// Creds passed check.
+	return nil
+}
+
+func getIBMCloudClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (ibmclient.API, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.IBMCloud.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch IBM Cloud credentials secret")
+	}
+	return ibmclient.NewClientFromSecret(secret)
+}
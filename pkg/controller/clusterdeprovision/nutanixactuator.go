@@ -0,0 +1,63 @@
+package clusterdeprovision
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/nutanixclient"
+)
+
+func init() {
+	registerActuator(&nutanixActuator{nutanixClientFn: getNutanixClient})
+}
+
+// Ensure nutanixActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &nutanixActuator{}
+
+// nutanixActuator tests the credentials of Nutanix deprovisions.
+type nutanixActuator struct {
+	// nutanixClientFn is the function to build a Nutanix client, here for testing
+	nutanixClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (nutanixclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *nutanixActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.Nutanix != nil
+}
+
+// TestCredentials ensures that the nutanix credentials are usable.
+func (a *nutanixActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	nutanixClient, err := a.nutanixClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+
+	// The client only authenticates once it makes a request.
+	if _, err := nutanixClient.GetCurrentLoggedInUser(context.TODO()); err != nil {
+		return err
+	}
+
+	// Creds passed check.
+	return nil
+}
+
+func getNutanixClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (nutanixclient.API, error) {
+	credsSecret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Nutanix.CredentialsSecretRef.Name, Namespace: cd.Namespace}, credsSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch Nutanix credentials secret")
+	}
+	var certsSecret *corev1.Secret
+	if name := cd.Spec.Platform.Nutanix.CertificatesSecretRef.Name; name != "" {
+		certsSecret = &corev1.Secret{}
+		if err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: cd.Namespace}, certsSecret); err != nil {
+			return nil, errors.Wrap(err, "failed to fetch Nutanix certificates secret")
+		}
+	}
+	prismCentral := cd.Spec.Platform.Nutanix.PrismCentral
+	return nutanixclient.NewClientFromSecret(prismCentral.Address, prismCentral.Port, credsSecret, certsSecret)
+}
@@ -0,0 +1,59 @@
+package clusterdeprovision
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/openstackclient"
+)
+
+func init() {
+	registerActuator(&openStackActuator{openStackClientFn: getOpenStackClient})
+}
+
+// Ensure openStackActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &openStackActuator{}
+
+// openStackActuator tests the credentials of OpenStack deprovisions.
+type openStackActuator struct {
+	// openStackClientFn is the function to build an OpenStack client, here for testing
+	openStackClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (openstackclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *openStackActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.OpenStack != nil
+}
+
+// TestCredentials ensures that the openstack credentials are usable. The client authenticates with the cloud
+// when it is built, so there is nothing more to call.
+func (a *openStackActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	_, err := a.openStackClientFn(clusterDeprovision, c, logger)
+	return err
+}
+
+func getOpenStackClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (openstackclient.API, error) {
+	if cd.Spec.Platform.OpenStack.CredentialsSecretRef == nil {
+		return nil, errors.New("no OpenStack credentials secret set")
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch OpenStack credentials secret")
+	}
+	var trustBundle []byte
+	if cd.Spec.Platform.OpenStack.CertificatesSecretRef != nil {
+		buf := &bytes.Buffer{}
+		if err := controllerutils.TrustBundleFromSecretToWriter(c, cd.Namespace, cd.Spec.Platform.OpenStack.CertificatesSecretRef.Name, buf); err != nil {
+			return nil, errors.Wrap(err, "failed to load trust bundle from CertificatesSecretRef")
+		}
+		trustBundle = buf.Bytes()
+	}
+	return openstackclient.NewClientFromSecret(secret, cd.Spec.Platform.OpenStack.Cloud, trustBundle)
+}
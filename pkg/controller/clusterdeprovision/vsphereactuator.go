@@ -0,0 +1,59 @@
+package clusterdeprovision
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/vsphereclient"
+)
+
+func init() {
+	registerActuator(&vSphereActuator{vSphereClientFn: getVSphereClient})
+}
+
+// Ensure vSphereActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &vSphereActuator{}
+
+// vSphereActuator tests the credentials of vSphere deprovisions.
+type vSphereActuator struct {
+	// vSphereClientFn is the function to build a vSphere client, here for testing
+	vSphereClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (vsphereclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *vSphereActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.VSphere != nil
+}
+
+// TestCredentials ensures that the vsphere credentials are usable. The client logs in to the vCenter when it is
+// built, so it only needs to log out again.
+func (a *vSphereActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	vSphereClient, err := a.vSphereClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+	vSphereClient.Logout()
+
+	// Creds passed check.
+	return nil
+}
+
+func getVSphereClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (vsphereclient.API, error) {
+	credsSecret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.VSphere.CredentialsSecretRef.Name, Namespace: cd.Namespace}, credsSecret); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch vSphere credentials secret")
+	}
+	var certsSecret *corev1.Secret
+	if name := cd.Spec.Platform.VSphere.CertificatesSecretRef.Name; name != "" {
+		certsSecret = &corev1.Secret{}
+		if err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: cd.Namespace}, certsSecret); err != nil {
+			return nil, errors.Wrap(err, "failed to fetch vSphere certificates secret")
+		}
+	}
+	return vsphereclient.NewClientFromSecret(cd.Spec.Platform.VSphere.VCenter, credsSecret, certsSecret)
+}
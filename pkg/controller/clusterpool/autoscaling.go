@@ -0,0 +1,111 @@
+package clusterpool
+
+import (
+	"reflect"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	defaultAutoscalingDemandWindow      = time.Hour
+	defaultAutoscalingScaleDownCooldown = 15 * time.Minute
+)
+
+// poolSize returns the number of unclaimed clusters the pool should keep: the computed target size
+// if autoscaling is configured; otherwise Spec.Size.
+func poolSize(clp *hivev1.ClusterPool) int {
+	if clp.Spec.Autoscaling != nil && clp.Status.Autoscaling != nil {
+		return int(clp.Status.Autoscaling.TargetSize)
+	}
+	return int(clp.Spec.Size)
+}
+
+// setAutoscalingStatus computes the pool's target size from the demand observed in claims and
+// records it in clp.Status.Autoscaling. The demand is the number of claims created within the
+// demand window that have been assigned a cluster. Claims still waiting for a cluster are not
+// counted, as the pool already provisions a cluster for each of them on top of its size. Increases
+// to the target size are applied immediately; decreases wait until the scale-down cooldown has
+// elapsed since the target size last changed.
+// The caller is responsible for pushing the changes back to the server.
+// The first return indicates whether anything changed. The second indicates how long until the
+// computation may produce a different result in the absence of new claims, or zero if there's no
+// need to revisit it.
+func setAutoscalingStatus(clp *hivev1.ClusterPool, claims *claimCollection, now time.Time) (bool, time.Duration) {
+	as := clp.Spec.Autoscaling
+	if as == nil {
+		if clp.Status.Autoscaling == nil {
+			return false, 0
+		}
+		clp.Status.Autoscaling = nil
+		metricAutoscalingTargetSize.DeleteLabelValues(clp.Namespace, clp.Name)
+		return true, 0
+	}
+	demandWindow := defaultAutoscalingDemandWindow
+	if as.DemandWindow != nil {
+		demandWindow = as.DemandWindow.Duration
+	}
+	cooldown := defaultAutoscalingScaleDownCooldown
+	if as.ScaleDownCooldown != nil {
+		cooldown = as.ScaleDownCooldown.Duration
+	}
+
+	var requeueAfter time.Duration
+	// requeueAt shortens requeueAfter to d if d is sooner.
+	requeueAt := func(d time.Duration) {
+		if d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+			requeueAfter = d
+		}
+	}
+
+	recent := 0
+	for _, claim := range claims.byClaimName {
+		if claim.Spec.Namespace == "" {
+			continue
+		}
+		if age := now.Sub(claim.CreationTimestamp.Time); age < demandWindow {
+			recent++
+			// The demand drops when this claim ages out of the window.
+			requeueAt(demandWindow - age)
+		}
+	}
+	pending := len(claims.Unassigned())
+
+	desired := int32(recent) + as.TargetIdle
+	if desired < as.MinSize {
+		desired = as.MinSize
+	}
+	if desired > as.MaxSize {
+		desired = as.MaxSize
+	}
+
+	origStatus := clp.Status.Autoscaling.DeepCopy()
+	status := clp.Status.Autoscaling
+	if status == nil {
+		status = &hivev1.ClusterPoolAutoscalingStatus{TargetSize: desired, LastScaleTime: &metav1.Time{Time: now}}
+	}
+	switch {
+	case desired > status.TargetSize:
+		status.TargetSize = desired
+		status.LastScaleTime = &metav1.Time{Time: now}
+	case desired < status.TargetSize:
+		// Honor the cooldown unless the current target is out of bounds, e.g. because MaxSize was lowered.
+		if status.LastScaleTime != nil && status.TargetSize <= as.MaxSize {
+			if remaining := cooldown - now.Sub(status.LastScaleTime.Time); remaining > 0 {
+				requeueAt(remaining)
+				break
+			}
+		}
+		status.TargetSize = desired
+		status.LastScaleTime = &metav1.Time{Time: now}
+	}
+	status.RecentClaims = int32(recent)
+	status.PendingClaims = int32(pending)
+	clp.Status.Autoscaling = status
+
+	metricAutoscalingTargetSize.WithLabelValues(clp.Namespace, clp.Name).Set(float64(status.TargetSize))
+
+	return !reflect.DeepEqual(origStatus, status), requeueAfter
+}
@@ -8,11 +8,13 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/davegardnerisme/deephash"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -36,6 +38,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
 	yamlpatch "github.com/openshift/hive/pkg/util/yaml"
 )
 
@@ -84,11 +87,15 @@ func Add(mgr manager.Manager) error {
 // NewReconciler returns a new ReconcileClusterPool
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterPool {
 	logger := log.WithField("controller", ControllerName)
-	return &ReconcileClusterPool{
+	r := &ReconcileClusterPool{
 		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger:       logger,
 		expectations: controllerutils.NewExpectations(logger),
 	}
+	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
 }
 
 func indexClusterDeploymentsByClusterPool(o client.Object) []string {
@@ -185,6 +192,22 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 		return err
 	}
 
+	// Watch for changes to the jobs cleaning up recycled clusters
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &batchv1.Job{}, handler.TypedEnqueueRequestsFromMapFunc(
+			requestsForRecycleJob(r.Client, r.logger)),
+		)); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterQuotas, which may unblock or block claims
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hivev1.ClusterQuota{}, handler.TypedEnqueueRequestsFromMapFunc(
+			requestsForClusterQuota(r.Client, r.logger)),
+		)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -226,6 +249,26 @@ func requestsForCDCResources(c client.Client, logger log.FieldLogger) handler.Ty
 	}
 }
 
+func requestsForClusterQuota(c client.Client, logger log.FieldLogger) handler.TypedMapFunc[*hivev1.ClusterQuota, reconcile.Request] {
+	return func(ctx context.Context, _ *hivev1.ClusterQuota) []reconcile.Request {
+		cpList := &hivev1.ClusterPoolList{}
+		if err := c.List(context.Background(), cpList); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for ClusterQuota")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(cpList.Items))
+		for i, cpl := range cpList.Items {
+			requests[i] = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: cpl.Namespace,
+					Name:      cpl.Name,
+				},
+			}
+		}
+		return requests
+	}
+}
+
 func requestsForCDRBACResources(c client.Client, resourceName string, logger log.FieldLogger) handler.TypedMapFunc[*rbacv1.RoleBinding, reconcile.Request] {
 	return func(ctx context.Context, o *rbacv1.RoleBinding) []reconcile.Request {
 		if o.GetName() != resourceName {
@@ -277,6 +320,8 @@ type ReconcileClusterPool struct {
 	logger log.FieldLogger
 	// A TTLCache of ClusterDeployment creates each ClusterPool expects to see
 	expectations controllerutils.ExpectationsInterface
+
+	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 }
 
 // Reconcile reads the state of the ClusterPool, checks if we currently have enough ClusterDeployments waiting, and
@@ -302,10 +347,6 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	}
 	logger = controllerutils.AddLogFields(controllerutils.MetaObjectLogTagger{Object: clp}, logger)
 
-	if clp.Spec.RunningCount != clp.Spec.Size && poolAlwaysRunning(clp) {
-		return reconcile.Result{}, errors.New("Hibernation is not supported on Openstack and VSphere. Must set runningCount==size.")
-	}
-
 	// Initialize cluster pool conditions if not set
 	newConditions, changed := controllerutils.InitializeClusterPoolConditions(clp.Status.Conditions, clusterPoolConditions)
 	if changed {
@@ -361,7 +402,9 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	if setStatusCounts(clp, cds) {
+	countsChanged := setStatusCounts(clp, cds)
+	autoscalingChanged, requeueAfter := setAutoscalingStatus(clp, claims, time.Now())
+	if countsChanged || autoscalingChanged {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
 			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterPool status")
@@ -398,6 +441,16 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
+	// return clusters that were previously claimed to the pool, if it recycles them.
+	recycleRequeueAfter, err := r.recycleClusters(clp, poolVersion, cds, logger)
+	if err != nil {
+		logger.WithError(err).Error("error recycling clusters")
+		return reconcile.Result{}, err
+	}
+	if recycleRequeueAfter > 0 && (requeueAfter == 0 || recycleRequeueAfter < requeueAfter) {
+		requeueAfter = recycleRequeueAfter
+	}
+
 	// remove clusters that were previously claimed but now not required.
 	toRemoveClaimedCDs := cds.MarkedForDeletion()
 	toDel := minIntVarible(len(toRemoveClaimedCDs), availableCurrent)
@@ -412,9 +465,9 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	availableCurrent -= toDel
 
 	// drift will indicate how many clusters we need to add or delete to get back to steady state
-	// of the pool's Size. This needs to take into account the clusters we're creating to satisfy
-	// the immediate demand of pending claims.
-	switch drift := len(cds.Unassigned(true)) - int(clp.Spec.Size) - len(claims.Unassigned()); {
+	// of the pool's Size (or autoscaled target size). This needs to take into account the clusters we're creating to satisfy
+	// the immediate demand of pending claims. Clusters being recycled will rejoin the pool, so count them too.
+	switch drift := len(cds.Unassigned(true)) + len(cds.Recycling()) - poolSize(clp) - len(claims.Unassigned()); {
 	// activity quota exceeded, so no action
 	case availableCurrent <= 0:
 		logger.WithFields(log.Fields{
@@ -474,7 +527,9 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	// Revisit the autoscaled target size when claims age out of the demand window or a
+	// scale-down cooldown expires; and recycling clusters when their cleanup times out.
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // reconcileRunningClusters ensures the oldest unassigned clusters are set to running, and the
@@ -778,6 +833,8 @@ func (r *ReconcileClusterPool) createCluster(
 		builder.HibernateAfter = &clp.Spec.HibernateAfter.Duration
 	}
 
+	builder.HibernationSchedule = clp.Spec.HibernationSchedule
+
 	objs, err := builder.Build()
 	if err != nil {
 		return nil, errors.Wrap(err, "error building resources")
@@ -1093,12 +1150,6 @@ func poolReference(pool *hivev1.ClusterPool) hivev1.ClusterPoolReference {
 	}
 }
 
-// poolAlwaysRunning returns true if the Platrform, cloud provider, machines can only be in running state
-func poolAlwaysRunning(pool *hivev1.ClusterPool) bool {
-	p := pool.Spec.Platform
-	return p.OpenStack != nil || p.VSphere != nil
-}
-
 func (r *ReconcileClusterPool) getCredentialsSecret(pool *hivev1.ClusterPool, secretName string, logger log.FieldLogger) (*corev1.Secret, error) {
 	credsSecret := &corev1.Secret{}
 	if err := r.Client.Get(
@@ -1203,6 +1254,7 @@ func setStatusCounts(clp *hivev1.ClusterPool, cds *cdCollection) bool {
 	clp.Status.Size = int32(len(cds.Unassigned(true)))
 	clp.Status.Standby = int32(len(cds.Standby()))
 	clp.Status.Ready = int32(len(cds.Assignable()))
+	clp.Status.Recycling = int32(len(cds.Recycling()))
 	return !reflect.DeepEqual(origStatus, &clp.Status)
 }
 
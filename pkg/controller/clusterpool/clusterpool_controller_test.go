@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -56,6 +57,7 @@ func TestReconcileClusterPool(t *testing.T) {
 	inventoryAndCustomizationPoolVersion := "f1a16535dbc27559"
 	customizationPoolVersion := "564f99a732a771e9"
 	openstackPoolVersion := "0be50b7ba396d313"
+	openstackNoInventoryPoolVersion := "cd7cd5a7d52050bb"
 
 	poolBuilder := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).
 		GenericOptions(
@@ -150,8 +152,13 @@ func TestReconcileClusterPool(t *testing.T) {
 		// (The clusterpool controller always sets this condition's Status to True.)
 		// Not checked if nil.
 		expectedClaimPendingReasons      map[string]string
+		// Map, keyed by claim name, of expected Status.QueuePosition, where 0 means unset.
+		// Not checked if nil.
+		expectedClaimQueuePositions      map[string]int32
 		expectedInventoryAssignmentOrder []string
 		expectPoolVersionChanged         bool
+		// If nil, Status.Autoscaling is expected to be unset.
+		expectedAutoscalingTargetSize *int32
 	}{
 		{
 			name: "initialize conditions",
@@ -870,6 +877,22 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedSize:  3,
 			expectedObservedReady: 1,
 		},
+		{
+			name: "openstack pool hibernates unclaimed clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(2),
+					testcp.ForOpenstack(credsSecretName),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithPoolVersion(openstackNoInventoryPoolVersion)),
+				unclaimedCDBuilder("c2").Build(testcd.Running(), testcd.WithPoolVersion(openstackNoInventoryPoolVersion)),
+			},
+			// Both clusters are stopped, since RunningCount is zero.
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectedPoolVersion:   openstackNoInventoryPoolVersion,
+		},
 		{
 			name: "scale up with no more capacity",
 			existing: []runtime.Object{
@@ -1128,6 +1151,100 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady:   3,
 			expectedDeletedClusters: []string{"c2", "c3", "c6"},
 		},
+		{
+			name: "autoscaling sizes pool from claim demand",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(5), testcp.WithAutoscaling(1, 4, 1)),
+				testclaim.FullBuilder(testNamespace, "test", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			// The recent claim is still pending, so it is not counted as demand: target size is one
+			// idle. Plus one more to satisfy the pending claim.
+			expectedTotalClusters:         2,
+			expectedRunning:               1,
+			expectedUnassignedClaims:      1,
+			expectedAutoscalingTargetSize: ptr.To(int32(1)),
+		},
+		{
+			name: "autoscaling sizes pool from assigned claim demand",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(5), testcp.WithAutoscaling(1, 4, 1)),
+				testclaim.FullBuilder(testNamespace, "test", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish)).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithCluster("c1")),
+				cdBuilder("c1").Build(testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test")),
+			},
+			// One recent assigned claim plus one idle: target size is 2, plus the assigned cluster.
+			expectedTotalClusters:         3,
+			expectedAssignedClaims:        1,
+			expectedAssignedCDs:           1,
+			expectedAutoscalingTargetSize: ptr.To(int32(2)),
+		},
+		{
+			name: "autoscaling respects MaxSize",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(5), testcp.WithAutoscaling(0, 1, 3)),
+			},
+			expectedTotalClusters:         1,
+			expectedAutoscalingTargetSize: ptr.To(int32(1)),
+		},
+		{
+			name: "autoscaling ignores claims outside the demand window",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(5), testcp.WithAutoscaling(1, 4, 0)),
+				testclaim.FullBuilder(testNamespace, "test", scheme).
+					GenericOptions(testgeneric.WithCreationTimestamp(nowish.Add(-2*time.Hour))).
+					Build(testclaim.WithPool(testLeasePoolName), testclaim.WithCluster("c1")),
+				cdBuilder("c1").Build(testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test")),
+			},
+			expectedTotalClusters:         2,
+			expectedAssignedClaims:        1,
+			expectedAssignedCDs:           1,
+			expectedAutoscalingTargetSize: ptr.To(int32(1)),
+		},
+		{
+			name: "autoscaling scale down deferred during cooldown",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithAutoscaling(0, 4, 0),
+					testcp.WithAutoscalingStatus(2, nowish.Add(-time.Minute)),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:         2,
+			expectedObservedSize:          2,
+			expectedAutoscalingTargetSize: ptr.To(int32(2)),
+		},
+		{
+			name: "autoscaling scale down after cooldown",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithAutoscaling(0, 4, 0),
+					testcp.WithAutoscalingStatus(2, nowish.Add(-time.Hour)),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:         0,
+			expectedObservedSize:          2,
+			expectedDeletedClusters:       []string{"c1", "c2"},
+			expectedAutoscalingTargetSize: ptr.To(int32(0)),
+		},
+		{
+			name: "autoscaling status cleared when autoscaling removed",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithAutoscalingStatus(2, nowish.Add(-time.Minute)),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 1,
+			expectedObservedSize:  2,
+		},
 		{
 			name: "deleted pool: clusters deleted, pool held while clusters pending deletion",
 			existing: []runtime.Object{
@@ -1527,6 +1644,219 @@ func TestReconcileClusterPool(t *testing.T) {
 				"test-claim-3": "NoClusters",
 			},
 		},
+		{
+			name: "assign to claims by priority",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second*2))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-3", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			expectedTotalClusters:    5,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedAssignedCDs:      2,
+			expectedRunning:          3,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-1": "ClusterAssigned",
+				"test-claim-2": "NoClusters",
+				"test-claim-3": "ClusterAssigned",
+			},
+			expectedClaimQueuePositions: map[string]int32{
+				"test-claim-1": 0,
+				"test-claim-2": 1,
+				"test-claim-3": 0,
+			},
+		},
+		{
+			name: "waiting claims report their queue position",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithPriority(10),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     1,
+			expectedRunning:          2,
+			expectedUnassignedClaims: 2,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-1": "NoClusters",
+				"test-claim-2": "NoClusters",
+			},
+			expectedClaimQueuePositions: map[string]int32{
+				"test-claim-1": 2,
+				"test-claim-2": 1,
+			},
+		},
+		{
+			name: "fair share favors tenant holding fewest clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithClaimFairShare("team", nil)),
+				cdBuilder("held").Build(testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "held")),
+				testclaim.FullBuilder(testNamespace, "held", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("held"),
+					testclaim.Generic(testgeneric.WithLabel("team", "a")),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "test-claim-a", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithLabel("team", "a")),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-b", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithLabel("team", "b")),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			expectedTotalClusters:    4,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedAssignedClaims:   2,
+			expectedAssignedCDs:      2,
+			expectedRunning:          2,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-a": "NoClusters",
+				"test-claim-b": "ClusterAssigned",
+			},
+		},
+		{
+			name: "fair share limit blocks claims",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithClaimFairShare("team", ptr.To(int32(1)))),
+				cdBuilder("held").Build(testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "held")),
+				testclaim.FullBuilder(testNamespace, "held", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("held"),
+					testclaim.Generic(testgeneric.WithLabel("team", "a")),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "test-claim-a", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithLabel("team", "a")),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-b", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithLabel("team", "b")),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			// The blocked claim does not count as demand for new clusters.
+			expectedTotalClusters:    3,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedAssignedCDs:      2,
+			expectedRunning:          1,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-a": "TenantLimitReached",
+				"test-claim-b": "ClusterAssigned",
+			},
+		},
+		{
+			name: "claim quota blocks claims",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				&hivev1.ClusterQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+					Spec:       hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To(int32(2))},
+				},
+				cdBuilder("held").Build(testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "held")),
+				testclaim.FullBuilder(testNamespace, "held", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("held"),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "test-claim-a", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-b", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedAssignedCDs:      2,
+			expectedRunning:          1,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-a": "ClusterAssigned",
+				"test-claim-b": "QuotaExceeded",
+			},
+		},
+		{
+			name: "claim quota applies to each tenant",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithClaimFairShare("team", nil)),
+				&hivev1.ClusterQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+					Spec:       hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To(int32(1))},
+				},
+				cdBuilder("held").Build(testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "held")),
+				testclaim.FullBuilder(testNamespace, "held", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithCluster("held"),
+					testclaim.Generic(testgeneric.WithLabel("team", "team-a")),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "test-claim-a", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithLabel("team", "team-a")),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-b", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithLabel("team", "team-b")),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			// team-a is at its limit, so only team-b's claim is assigned, and one cluster is
+			// created to refill the pool.
+			expectedTotalClusters:    4,
+			expectedObservedSize:     2,
+			expectedObservedReady:    2,
+			expectedAssignedClaims:   2,
+			expectedAssignedCDs:      2,
+			expectedRunning:          1,
+			expectedUnassignedClaims: 1,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-a": "QuotaExceeded",
+				"test-claim-b": "ClusterAssigned",
+			},
+		},
 		{
 			name: "do not assign to claims for other pools",
 			existing: []runtime.Object{
@@ -2090,6 +2420,11 @@ func TestReconcileClusterPool(t *testing.T) {
 			}
 			assert.Equal(t, test.expectedObservedSize, pool.Status.Size, "unexpected observed size")
 			assert.Equal(t, test.expectedObservedReady, pool.Status.Ready, "unexpected observed ready count")
+			if test.expectedAutoscalingTargetSize == nil {
+				assert.Nil(t, pool.Status.Autoscaling, "unexpected autoscaling status")
+			} else if assert.NotNil(t, pool.Status.Autoscaling, "expected autoscaling status") {
+				assert.Equal(t, *test.expectedAutoscalingTargetSize, pool.Status.Autoscaling.TargetSize, "unexpected autoscaling target size")
+			}
 			currentPoolVersion := calculatePoolVersion(pool)
 			assert.Equal(
 				t, test.expectPoolVersionChanged, currentPoolVersion != expectedPoolVersion,
@@ -2241,6 +2576,11 @@ func TestReconcileClusterPool(t *testing.T) {
 						}
 					}
 				}
+				if test.expectedClaimQueuePositions != nil {
+					if position, ok := test.expectedClaimQueuePositions[claim.Name]; ok {
+						assert.Equal(t, position, ptr.Deref(claim.Status.QueuePosition, 0), "wrong queue position for claim %s", claim.Name)
+					}
+				}
 				if ns := claim.Spec.Namespace; ns == "" {
 					actualUnassignedClaims++
 				} else {
@@ -3263,3 +3603,191 @@ func Test_isBroken(t *testing.T) {
 		})
 	}
 }
+
+func Test_setAutoscalingStatus(t *testing.T) {
+	now := time.Now()
+	claimAged := func(name string, age time.Duration) *hivev1.ClusterClaim {
+		return testclaim.FullBuilder(testNamespace, name, scheme.GetScheme()).
+			GenericOptions(testgeneric.WithCreationTimestamp(now.Add(-age))).
+			Build(testclaim.WithPool(testLeasePoolName), testclaim.WithCluster(name))
+	}
+	pendingClaimAged := func(name string, age time.Duration) *hivev1.ClusterClaim {
+		return testclaim.FullBuilder(testNamespace, name, scheme.GetScheme()).
+			GenericOptions(testgeneric.WithCreationTimestamp(now.Add(-age))).
+			Build(testclaim.WithPool(testLeasePoolName))
+	}
+
+	tests := []struct {
+		name                  string
+		status                *hivev1.ClusterPoolAutoscalingStatus
+		claims                []*hivev1.ClusterClaim
+		expectedTargetSize    int32
+		expectedRecentClaims  int32
+		expectedPendingClaims int32
+		expectedRequeueAfter  time.Duration
+		expectedChanged       bool
+	}{
+		{
+			name:               "no demand",
+			expectedTargetSize: 1,
+			expectedChanged:    true,
+		},
+		{
+			name:                 "requeue when a claim leaves the demand window",
+			claims:               []*hivev1.ClusterClaim{claimAged("c1", 50*time.Minute), claimAged("c2", 20*time.Minute)},
+			expectedTargetSize:   3,
+			expectedRecentClaims: 2,
+			expectedRequeueAfter: 10 * time.Minute,
+			expectedChanged:      true,
+		},
+		{
+			name: "pending claims are not counted as demand",
+			claims: []*hivev1.ClusterClaim{
+				claimAged("c1", 20*time.Minute),
+				pendingClaimAged("c2", 10*time.Minute),
+				pendingClaimAged("c3", 5*time.Minute),
+			},
+			expectedTargetSize:    2,
+			expectedRecentClaims:  1,
+			expectedPendingClaims: 2,
+			expectedRequeueAfter:  40 * time.Minute,
+			expectedChanged:       true,
+		},
+		{
+			name: "requeue when the cooldown expires",
+			status: &hivev1.ClusterPoolAutoscalingStatus{
+				TargetSize:    4,
+				LastScaleTime: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			},
+			expectedTargetSize:   4,
+			expectedRequeueAfter: 5 * time.Minute,
+		},
+		{
+			name: "scale down immediately when above MaxSize",
+			status: &hivev1.ClusterPoolAutoscalingStatus{
+				TargetSize:    8,
+				LastScaleTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			expectedTargetSize: 1,
+			expectedChanged:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &hivev1.ClusterPool{
+				Spec: hivev1.ClusterPoolSpec{
+					Autoscaling: &hivev1.ClusterPoolAutoscaling{MinSize: 1, MaxSize: 5, TargetIdle: 1},
+				},
+				Status: hivev1.ClusterPoolStatus{Autoscaling: test.status},
+			}
+			claims := &claimCollection{byClaimName: map[string]*hivev1.ClusterClaim{}}
+			for _, claim := range test.claims {
+				claims.byClaimName[claim.Name] = claim
+				if claim.Spec.Namespace == "" {
+					claims.unassigned = append(claims.unassigned, claim)
+				}
+			}
+			changed, requeueAfter := setAutoscalingStatus(pool, claims, now)
+			assert.Equal(t, test.expectedChanged, changed, "unexpected changed")
+			if assert.NotNil(t, pool.Status.Autoscaling) {
+				assert.Equal(t, test.expectedTargetSize, pool.Status.Autoscaling.TargetSize, "unexpected target size")
+				assert.Equal(t, test.expectedRecentClaims, pool.Status.Autoscaling.RecentClaims, "unexpected recent claims")
+				assert.Equal(t, test.expectedPendingClaims, pool.Status.Autoscaling.PendingClaims, "unexpected pending claims")
+			}
+			assert.Equal(t, test.expectedRequeueAfter, requeueAfter, "unexpected requeueAfter")
+		})
+	}
+}
+
+func Test_queueClaims(t *testing.T) {
+	now := time.Now()
+	claim := func(name, team string, priority int32, age time.Duration) *hivev1.ClusterClaim {
+		return testclaim.FullBuilder(testNamespace, name, scheme.GetScheme()).Build(
+			testclaim.WithPool(testLeasePoolName),
+			testclaim.WithPriority(priority),
+			testclaim.Generic(testgeneric.WithLabel("team", team)),
+			testclaim.Generic(testgeneric.WithCreationTimestamp(now.Add(-age))),
+		)
+	}
+
+	tests := []struct {
+		name            string
+		fairShare       *hivev1.ClusterPoolClaimFairShare
+		assigned        []*hivev1.ClusterClaim
+		pending         []*hivev1.ClusterClaim
+		expectedQueued  []string
+		expectedBlocked []string
+	}{
+		{
+			name: "oldest first",
+			pending: []*hivev1.ClusterClaim{
+				claim("new", "a", 0, time.Minute),
+				claim("old", "a", 0, time.Hour),
+			},
+			expectedQueued: []string{"old", "new"},
+		},
+		{
+			name: "priority before age",
+			pending: []*hivev1.ClusterClaim{
+				claim("old", "a", 0, time.Hour),
+				claim("urgent", "a", 5, time.Minute),
+				claim("deferred", "a", -1, 2*time.Hour),
+			},
+			expectedQueued: []string{"urgent", "old", "deferred"},
+		},
+		{
+			name:      "fair share interleaves tenants",
+			fairShare: &hivev1.ClusterPoolClaimFairShare{TenantLabelKey: "team"},
+			assigned:  []*hivev1.ClusterClaim{claim("held", "a", 0, 3*time.Hour)},
+			pending: []*hivev1.ClusterClaim{
+				claim("a1", "a", 0, 2*time.Hour),
+				claim("a2", "a", 0, 90*time.Minute),
+				claim("b1", "b", 0, time.Hour),
+				claim("b2", "b", 0, 30*time.Minute),
+				claim("b3", "b", 0, 20*time.Minute),
+			},
+			expectedQueued: []string{"b1", "a1", "b2", "a2", "b3"},
+		},
+		{
+			name:      "fair share does not override priority",
+			fairShare: &hivev1.ClusterPoolClaimFairShare{TenantLabelKey: "team"},
+			assigned:  []*hivev1.ClusterClaim{claim("held", "a", 0, 3*time.Hour)},
+			pending: []*hivev1.ClusterClaim{
+				claim("a1", "a", 1, time.Hour),
+				claim("b1", "b", 0, 2*time.Hour),
+			},
+			expectedQueued: []string{"a1", "b1"},
+		},
+		{
+			name:      "fair share limit",
+			fairShare: &hivev1.ClusterPoolClaimFairShare{TenantLabelKey: "team", MaxClaimedPerTenant: ptr.To(int32(2))},
+			assigned:  []*hivev1.ClusterClaim{claim("held", "a", 0, 3*time.Hour)},
+			pending: []*hivev1.ClusterClaim{
+				claim("a1", "a", 0, 2*time.Hour),
+				claim("a2", "a", 0, 90*time.Minute),
+				claim("b1", "b", 0, time.Hour),
+			},
+			expectedQueued:  []string{"b1", "a1"},
+			expectedBlocked: []string{"a2"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &hivev1.ClusterPool{Spec: hivev1.ClusterPoolSpec{ClaimFairShare: test.fairShare}}
+			assigned := map[string]*hivev1.ClusterClaim{}
+			for _, claim := range test.assigned {
+				assigned[claim.Name] = claim
+			}
+			queued, blocked := queueClaims(pool, test.pending, assigned)
+			claimNames := func(claims []*hivev1.ClusterClaim) []string {
+				var names []string
+				for _, claim := range claims {
+					names = append(names, claim.Name)
+				}
+				return names
+			}
+			assert.Equal(t, test.expectedQueued, claimNames(queued), "unexpected queue order")
+			assert.Equal(t, test.expectedBlocked, claimNames(blocked), "unexpected blocked claims")
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,10 +16,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/clusterquota"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -27,6 +30,12 @@ type claimCollection struct {
 	// All claims for this pool
 	byClaimName map[string]*hivev1.ClusterClaim
 	unassigned  []*hivev1.ClusterClaim
+	// Unassigned claims which may not be assigned a cluster because their tenant is at the pool's
+	// ClaimFairShare limit. These are not included in unassigned.
+	blocked []*hivev1.ClusterClaim
+	// Unassigned claims which may not be assigned a cluster because their tenant holds as many claims
+	// for the pool as a ClusterQuota permits. These are not included in unassigned or blocked.
+	overQuota []*hivev1.ClusterClaim
 	// This contains only assigned claims
 	byCDName map[string]*hivev1.ClusterClaim
 }
@@ -70,33 +79,139 @@ func getAllClaimsForPool(c client.Client, pool *hivev1.ClusterPool, logger log.F
 			claimCol.byCDName[cdName] = ref
 		}
 	}
-	// Sort assignable claims by creationTimestamp for FIFO behavior.
-	sort.Slice(
-		claimCol.unassigned,
-		func(i, j int) bool {
-			return claimCol.unassigned[i].CreationTimestamp.Before(&claimCol.unassigned[j].CreationTimestamp)
-		},
-	)
+	claimCol.unassigned, claimCol.blocked = queueClaims(pool, claimCol.unassigned, claimCol.byCDName)
+
+	quotas, err := clusterquota.ForNamespace(c, pool.Namespace)
+	if err != nil {
+		logger.WithError(err).Error("error getting ClusterQuotas")
+		return nil, err
+	}
+	claimCol.unassigned, claimCol.overQuota = limitClaims(pool, clusterquota.MaxClaimsPerPool(quotas), claimCol.unassigned, claimCol.byCDName)
 
 	logger.WithFields(log.Fields{
 		"assignedCount":   len(claimCol.byCDName),
 		"unassignedCount": len(claimCol.unassigned),
+		"blockedCount":    len(claimCol.blocked),
+		"overQuotaCount":  len(claimCol.overQuota),
 	}).Debug("found claims for ClusterPool")
 
 	return &claimCol, nil
 }
 
+// queueClaims orders the pending claims in the order in which they should be assigned clusters:
+// highest priority first, then oldest first. If the pool has a ClaimFairShare policy, among claims
+// of equal priority we favor the tenant holding the fewest clusters, counting both the assigned
+// claims and the pending claims ahead in the queue. Claims which would put their tenant over the
+// policy's MaxClaimedPerTenant are returned separately as blocked.
+func queueClaims(pool *hivev1.ClusterPool, pending []*hivev1.ClusterClaim, assigned map[string]*hivev1.ClusterClaim) (queued, blocked []*hivev1.ClusterClaim) {
+	// Include secondary sort by name as a tie breaker in case creationTimestamps conflict (which
+	// they can, as they have a granularity of 1s).
+	sort.SliceStable(
+		pending,
+		func(i, j int) bool {
+			if pending[i].Spec.Priority != pending[j].Spec.Priority {
+				return pending[i].Spec.Priority > pending[j].Spec.Priority
+			}
+			if !pending[i].CreationTimestamp.Equal(&pending[j].CreationTimestamp) {
+				return pending[i].CreationTimestamp.Before(&pending[j].CreationTimestamp)
+			}
+			return pending[i].Name < pending[j].Name
+		},
+	)
+	fairShare := pool.Spec.ClaimFairShare
+	if fairShare == nil {
+		return pending, nil
+	}
+
+	held := map[string]int{}
+	for _, claim := range assigned {
+		held[controllerutils.ClaimTenant(pool, claim)]++
+	}
+	atLimit := func(tenant string) bool {
+		return fairShare.MaxClaimedPerTenant != nil && held[tenant] >= int(*fairShare.MaxClaimedPerTenant)
+	}
+	remaining := make([]*hivev1.ClusterClaim, len(pending))
+	copy(remaining, pending)
+	queued = make([]*hivev1.ClusterClaim, 0, len(pending))
+	for len(remaining) > 0 {
+		next := -1
+		for i, claim := range remaining {
+			tenant := controllerutils.ClaimTenant(pool, claim)
+			if atLimit(tenant) {
+				continue
+			}
+			if next == -1 {
+				next = i
+				continue
+			}
+			// remaining is sorted by priority, so we're done once we drop below that of the first candidate.
+			if claim.Spec.Priority < remaining[next].Spec.Priority {
+				break
+			}
+			if held[tenant] < held[controllerutils.ClaimTenant(pool, remaining[next])] {
+				next = i
+			}
+		}
+		if next == -1 {
+			// Everything left belongs to tenants at their limit.
+			blocked = remaining
+			break
+		}
+		claim := remaining[next]
+		queued = append(queued, claim)
+		held[controllerutils.ClaimTenant(pool, claim)]++
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	return queued, blocked
+}
+
+// limitClaims splits the queued claims into those which may be assigned clusters without any tenant (see
+// controllerutils.ClaimTenant) holding more than limit assigned claims against the pool, and the rest. A nil
+// limit imposes no limit.
+func limitClaims(pool *hivev1.ClusterPool, limit *int32, queued []*hivev1.ClusterClaim, assigned map[string]*hivev1.ClusterClaim) (allowed, overQuota []*hivev1.ClusterClaim) {
+	if limit == nil {
+		return queued, nil
+	}
+	held := map[string]int{}
+	for _, claim := range assigned {
+		held[controllerutils.ClaimTenant(pool, claim)]++
+	}
+	allowed = make([]*hivev1.ClusterClaim, 0, len(queued))
+	for _, claim := range queued {
+		tenant := controllerutils.ClaimTenant(pool, claim)
+		if held[tenant] >= int(*limit) {
+			overQuota = append(overQuota, claim)
+			continue
+		}
+		allowed = append(allowed, claim)
+		held[tenant]++
+	}
+	return allowed, overQuota
+}
+
 // ByName returns the named claim from the collection, or nil if no claim by that name exists.
 func (c *claimCollection) ByName(claimName string) *hivev1.ClusterClaim {
 	return c.byClaimName[claimName]
 }
 
-// Unassigned returns a list of claims that are not assigned to clusters yet. The list is sorted by
-// age, oldest first.
+// Unassigned returns a list of claims that are not assigned to clusters yet, excluding those that are
+// Blocked. The list is in the order in which the claims should be assigned (see queueClaims).
 func (c *claimCollection) Unassigned() []*hivev1.ClusterClaim {
 	return c.unassigned
 }
 
+// Blocked returns a list of claims that are not assigned to clusters yet, and may not be until their
+// tenant releases a cluster, per the pool's ClaimFairShare policy.
+func (c *claimCollection) Blocked() []*hivev1.ClusterClaim {
+	return c.blocked
+}
+
+// OverQuota returns a list of claims that are not assigned to clusters yet, and may not be until
+// assigned claims are released, per the ClusterQuotas applying to the pool's namespace.
+func (c *claimCollection) OverQuota() []*hivev1.ClusterClaim {
+	return c.overQuota
+}
+
 // Assign assigns the specified claim to the specified cluster, updating its spec and status on
 // the server. Errors updating the spec or status are bubbled up. Returns an error if the claim is
 // already assigned (to *any* CD). Does *not* validate that the CD isn't already assigned (to this
@@ -121,6 +236,7 @@ func (claims *claimCollection) Assign(c client.Client, claim *hivev1.ClusterClai
 				"Cluster assigned to ClusterClaim, awaiting claim",
 				controllerutils.UpdateConditionIfReasonOrMessageChange,
 			)
+			claimi.Status.QueuePosition = nil
 			if err := c.Status().Update(context.Background(), claimi); err != nil {
 				return err
 			}
@@ -163,7 +279,7 @@ func (claims *claimCollection) SyncClusterDeploymentAssignments(c client.Client,
 }
 
 // Untrack removes the named claims from the claimCollection, so they are no longer
-// - returned via ByName() or Unassigned()
+// - returned via ByName(), Unassigned(), Blocked() or OverQuota()
 // - available for Assign() or affected by SyncClusterDeploymentAssignments
 // Do this to broken claims.
 func (c *claimCollection) Untrack(claimNames ...string) {
@@ -180,6 +296,18 @@ func (c *claimCollection) Untrack(claimNames ...string) {
 				c.unassigned = c.unassigned[:len(c.unassigned)-1]
 			}
 		}
+		for i, claim := range c.blocked {
+			if claim.Name == claimName {
+				copy(c.blocked[i:], c.blocked[i+1:])
+				c.blocked = c.blocked[:len(c.blocked)-1]
+			}
+		}
+		for i, claim := range c.overQuota {
+			if claim.Name == claimName {
+				copy(c.overQuota[i:], c.overQuota[i+1:])
+				c.overQuota = c.overQuota[:len(c.overQuota)-1]
+			}
+		}
 		if cdName := found.Spec.Namespace; cdName != "" {
 			// TODO: Should just be able to
 			// 		delete(c.byCDName, cdName)
@@ -206,6 +334,9 @@ type cdCollection struct {
 	broken []*hivev1.ClusterDeployment
 	// Clusters with the ClusterClaimRemoveClusterAnnotation. Mutually exclusive with deleting.
 	markedForDeletion []*hivev1.ClusterDeployment
+	// Previously claimed clusters being cleaned up to be returned to the pool. Mutually exclusive with deleting and
+	// markedForDeletion.
+	recycling []*hivev1.ClusterDeployment
 	// Clusters with a missing or empty pool version annotation
 	unknownPoolVersion []*hivev1.ClusterDeployment
 	// Clusters whose pool version annotation doesn't match the pool's
@@ -333,6 +464,8 @@ func getAllClusterDeploymentsForPool(c client.Client, pool *hivev1.ClusterPool,
 		} else if controllerutils.IsClusterMarkedForRemoval(ref) {
 			// Do *not* double count "deleting" and "marked for deletion"
 			cdCol.markedForDeletion = append(cdCol.markedForDeletion, ref)
+		} else if isRecycling(ref) {
+			cdCol.recycling = append(cdCol.recycling, ref)
 		} else if claimName == "" {
 			if isBroken(&cd, pool, logger) {
 				cdCol.broken = append(cdCol.broken, ref)
@@ -400,6 +533,7 @@ func getAllClusterDeploymentsForPool(c client.Client, pool *hivev1.ClusterPool,
 		"standby":    len(cdCol.standby),
 		"claimed":    len(cdCol.byClaimName),
 		"deleting":   len(cdCol.deleting),
+		"recycling":  len(cdCol.recycling),
 		"installing": len(cdCol.installing),
 		"unclaimed":  len(cdCol.installing) + len(cdCol.assignable),
 		"stale":      len(cdCol.unknownPoolVersion) + len(cdCol.mismatchedPoolVersion),
@@ -414,6 +548,7 @@ func getAllClusterDeploymentsForPool(c client.Client, pool *hivev1.ClusterPool,
 	metricClusterDeploymentsStandby.WithLabelValues(pool.Namespace, pool.Name).Set(float64(len(cdCol.standby)))
 	metricClusterDeploymentsStale.WithLabelValues(pool.Namespace, pool.Name).Set(float64(len(cdCol.unknownPoolVersion) + len(cdCol.mismatchedPoolVersion)))
 	metricClusterDeploymentsBroken.WithLabelValues(pool.Namespace, pool.Name).Set(float64(len(cdCol.broken)))
+	metricClusterDeploymentsRecycling.WithLabelValues(pool.Namespace, pool.Name).Set(float64(len(cdCol.recycling)))
 
 	return &cdCol, nil
 }
@@ -466,6 +601,12 @@ func (cds *cdCollection) MarkedForDeletion() []*hivev1.ClusterDeployment {
 	return cds.markedForDeletion
 }
 
+// Recycling returns the list of previously claimed ClusterDeployments being cleaned up to be returned to the pool.
+// These are not available for claim assignment, but will be once cleaned up.
+func (cds *cdCollection) Recycling() []*hivev1.ClusterDeployment {
+	return cds.recycling
+}
+
 // Installing returns the list of ClusterDeployments in the process of being installed. These are
 // not available for claim assignment.
 func (cds *cdCollection) Installing() []*hivev1.ClusterDeployment {
@@ -622,6 +763,60 @@ func (cds *cdCollection) Delete(c client.Client, cdName string) error {
 	removeCDsFromSlice(&cds.unknownPoolVersion, cdName)
 	removeCDsFromSlice(&cds.mismatchedPoolVersion, cdName)
 	removeCDsFromSlice(&cds.markedForDeletion, cdName)
+	removeCDsFromSlice(&cds.recycling, cdName)
+	return nil
+}
+
+// StartRecycle unclaims the named ClusterDeployment, which must be MarkedForDeletion, and marks it as recycling,
+// updating it on the server and moving it from MarkedForDeletion() to Recycling().
+func (cds *cdCollection) StartRecycle(c client.Client, cdName string) error {
+	cd := cds.ByName(cdName)
+	if cd == nil {
+		return fmt.Errorf("no such ClusterDeployment %s to recycle; this is a bug", cdName)
+	}
+	recycles, _ := strconv.Atoi(cd.Annotations[constants.PoolClusterRecycleCountAnnotation])
+	delete(cd.Annotations, constants.RemovePoolClusterAnnotation)
+	delete(cd.Annotations, constants.RecycleCredentialsRotatedAnnotation)
+	cd.Annotations[constants.RecyclePoolClusterAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	cd.Annotations[constants.PoolClusterRecycleCountAnnotation] = strconv.Itoa(recycles + 1)
+	claimName := cd.Spec.ClusterPoolRef.ClaimName
+	cd.Spec.ClusterPoolRef.ClaimName = ""
+	cd.Spec.ClusterPoolRef.ClaimedTimestamp = nil
+	// The cleanup needs a running cluster
+	cd.Spec.PowerState = hivev1.ClusterPowerStateRunning
+	if err := c.Update(context.Background(), cd); err != nil {
+		return err
+	}
+	if assigned, ok := cds.byClaimName[claimName]; ok && assigned.Name == cdName {
+		delete(cds.byClaimName, claimName)
+	}
+	removeCDsFromSlice(&cds.markedForDeletion, cdName)
+	cds.recycling = append(cds.recycling, cd)
+	return nil
+}
+
+// FinishRecycle clears the recycling mark from the named ClusterDeployment, which must be Recycling, updating it on the
+// server. If the cleanup succeeded the CD moves to Standby(), and is subsequently treated like any other unclaimed
+// cluster. Otherwise it is marked for removal, moving to MarkedForDeletion().
+func (cds *cdCollection) FinishRecycle(c client.Client, cdName string, succeeded bool) error {
+	cd := cds.ByName(cdName)
+	if cd == nil {
+		return fmt.Errorf("no such ClusterDeployment %s to recycle; this is a bug", cdName)
+	}
+	delete(cd.Annotations, constants.RecyclePoolClusterAnnotation)
+	delete(cd.Annotations, constants.RecycleCredentialsRotatedAnnotation)
+	if !succeeded {
+		controllerutils.MarkClusterForRemoval(cd)
+	}
+	if err := c.Update(context.Background(), cd); err != nil {
+		return err
+	}
+	removeCDsFromSlice(&cds.recycling, cdName)
+	if succeeded {
+		cds.standby = append(cds.standby, cd)
+	} else {
+		cds.markedForDeletion = append(cds.markedForDeletion, cd)
+	}
 	return nil
 }
 
@@ -1248,7 +1443,8 @@ func ensureClaimAssignment(c client.Client, claim *hivev1.ClusterClaim, claims *
 
 // assignClustersToClaims iterates over unassigned claims and assignable ClusterDeployments, in order (see
 // claimCollection.Unassigned and cdCollection.Assignable), assigning them to each other, stopping when the
-// first of the two lists is exhausted.
+// first of the two lists is exhausted. Claims left waiting have their queue position recorded in their
+// status.
 func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCollection, logger log.FieldLogger) error {
 	// ensureClaimAssignment modifies claims.unassigned and cds.assignable, so make a copy of the lists.
 	// copy() limits itself to the size of the destination
@@ -1265,23 +1461,61 @@ func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCol
 		}
 	}
 	// If any unassigned claims remain, mark their status accordingly
-	for _, claim := range claims.Unassigned() {
+	unassigned := claims.Unassigned()
+	for i, claim := range unassigned {
 		logger := logger.WithField("claim", claim.Name)
 		logger.Debug("no clusters ready to assign to claim")
-		if conds, statusChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
-			claim.Status.Conditions,
-			hivev1.ClusterClaimPendingCondition,
-			corev1.ConditionTrue,
-			"NoClusters",
+		if err := setClaimPending(
+			c, claim, "NoClusters",
 			"No clusters in pool are ready to be claimed",
-			controllerutils.UpdateConditionIfReasonOrMessageChange,
-		); statusChanged {
-			claim.Status.Conditions = conds
-			if err := c.Status().Update(context.Background(), claim); err != nil {
-				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
-				errs = append(errs, err)
-			}
+			ptr.To(int32(i+1)),
+		); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+			errs = append(errs, err)
+		}
+	}
+	for _, claim := range claims.Blocked() {
+		logger := logger.WithField("claim", claim.Name)
+		logger.Debug("claim's tenant is at its limit of claimed clusters")
+		if err := setClaimPending(
+			c, claim, "TenantLimitReached",
+			"The tenant to which this claim belongs holds the maximum number of clusters permitted by the pool's claimFairShare",
+			nil,
+		); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+			errs = append(errs, err)
+		}
+	}
+	for _, claim := range claims.OverQuota() {
+		logger := logger.WithField("claim", claim.Name)
+		logger.Debug("claim's tenant is at its ClusterQuota limit of claims for the pool")
+		if err := setClaimPending(
+			c, claim, "QuotaExceeded",
+			"The tenant to which this claim belongs holds the maximum number of claims for this pool permitted by a ClusterQuota",
+			nil,
+		); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
+			errs = append(errs, err)
 		}
 	}
 	return utilerrors.NewAggregate(errs)
 }
+
+// setClaimPending sets the Pending condition and queue position of an unassigned claim, updating its status on the
+// server if either changed.
+func setClaimPending(c client.Client, claim *hivev1.ClusterClaim, reason, message string, queuePosition *int32) error {
+	conds, statusChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
+		claim.Status.Conditions,
+		hivev1.ClusterClaimPendingCondition,
+		corev1.ConditionTrue,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !statusChanged && ptr.Equal(claim.Status.QueuePosition, queuePosition) {
+		return nil
+	}
+	claim.Status.Conditions = conds
+	claim.Status.QueuePosition = queuePosition
+	return c.Status().Update(context.Background(), claim)
+}
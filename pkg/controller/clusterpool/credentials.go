@@ -0,0 +1,390 @@
+package clusterpool
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// The kubeadmin password hash on the cluster, as created by the installer
+	kubeadminSecretNamespace = "kube-system"
+	kubeadminSecretName      = "kubeadmin"
+	kubeadminSecretKey       = "kubeadmin"
+
+	// The CA trusted by the cluster for the client certificate of the admin kubeconfig, as created by the installer
+	adminClientCANamespace = "openshift-config"
+	adminClientCAName      = "admin-kubeconfig-client-ca"
+	adminClientCAKey       = "ca-bundle.crt"
+
+	// The key of the new admin client CA in the staged admin credentials secret
+	stagedCASecretKey = "ca.crt"
+
+	// Values of the RecycleCredentialsRotatedAnnotation: the admin secrets hold the rotated credentials, but the
+	// cluster may still trust the previous admin client CA; or the rotation is complete.
+	credentialsRotationSwitched = "switched"
+	credentialsRotationComplete = "true"
+
+	adminClientCertValidity = 10 * 365 * 24 * time.Hour
+	adminClientKeyBits      = 2048
+
+	// rotatedCredentialsCheckInterval is how often to check whether the cluster accepts rotated admin credentials.
+	rotatedCredentialsCheckInterval = 30 * time.Second
+
+	// kubeadmin passwords have the installer's format: four groups of five characters, separated by dashes
+	adminPasswordChars      = "23456789abcdefghijkmnopqrstuvwxyzABCDEFGHIJKLMNPQRSTUVWXYZ"
+	adminPasswordGroups     = 4
+	adminPasswordGroupChars = 5
+)
+
+// credentialsSwitched returns true if the admin secrets of a recycling ClusterDeployment hold its rotated
+// credentials. The cluster may still trust the previous admin client CA.
+func credentialsSwitched(cd *hivev1.ClusterDeployment) bool {
+	_, ok := cd.Annotations[constants.RecycleCredentialsRotatedAnnotation]
+	return ok
+}
+
+// credentialsRotated returns true if the rotation of the admin credentials of a recycling ClusterDeployment is
+// complete: the cluster accepts the rotated credentials and no longer trusts the previous admin client CA.
+func credentialsRotated(cd *hivev1.ClusterDeployment) bool {
+	return cd.Annotations[constants.RecycleCredentialsRotatedAnnotation] == credentialsRotationComplete
+}
+
+// stagedCredentialsSecretName returns the name of the secret holding the new admin credentials for the current
+// recycle of a ClusterDeployment.
+func stagedCredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, "recycle-credentials-"+cd.Annotations[constants.PoolClusterRecycleCountAnnotation])
+}
+
+// rotateAdminCredentials replaces the admin credentials of a recycling cluster, so that the previous claimant can no
+// longer use the kubeadmin password or admin kubeconfig they were given. The new credentials are saved on the hub
+// before anything changes on the cluster, so a failure at any step leaves credentials which Hive can use, and calling
+// it again resumes the rotation with the same credentials:
+//   - a new kubeadmin password, and a new CA signing a new admin client certificate, are saved in a staging secret;
+//   - using the current admin kubeconfig, the cluster's kubeadmin password is replaced, if the ClusterDeployment has
+//     one, and the new CA is added to the CAs trusted for the admin kubeconfig's client certificate;
+//   - the admin secrets of the ClusterDeployment are switched to the new credentials.
+//
+// The previous CA is still trusted afterwards; dropPreviousAdminClientCA removes it once the cluster accepts the new
+// admin kubeconfig.
+func (r *ReconcileClusterPool) rotateAdminCredentials(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	staged, err := r.stageAdminCredentials(cd, logger)
+	if err != nil {
+		return err
+	}
+
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		return errors.Wrap(err, "could not connect to cluster")
+	}
+	if cd.Spec.ClusterMetadata.AdminPasswordSecretRef != nil {
+		if err := updateKubeadminPassword(remoteClient, staged.Data[constants.PasswordSecretKey], logger); err != nil {
+			return err
+		}
+	}
+	if err := addAdminClientCA(remoteClient, staged.Data[stagedCASecretKey]); err != nil {
+		return err
+	}
+
+	if ref := cd.Spec.ClusterMetadata.AdminPasswordSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: ref.Name}, secret); err != nil {
+			return errors.Wrap(err, "could not get admin password secret")
+		}
+		if !bytes.Equal(secret.Data[constants.PasswordSecretKey], staged.Data[constants.PasswordSecretKey]) {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			secret.Data[constants.PasswordSecretKey] = staged.Data[constants.PasswordSecretKey]
+			if err := r.Update(context.Background(), secret); err != nil {
+				return errors.Wrap(err, "could not update admin password secret")
+			}
+		}
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, secret); err != nil {
+		return errors.Wrap(err, "could not get admin kubeconfig secret")
+	}
+	changed := false
+	for _, key := range []string{constants.KubeconfigSecretKey, constants.RawKubeconfigSecretKey} {
+		data, ok := secret.Data[key]
+		if !ok {
+			continue
+		}
+		updated, err := replaceClientCertificate(data, staged.Data[constants.TLSCrtSecretKey], staged.Data[constants.TLSKeySecretKey])
+		if err != nil {
+			return errors.Wrapf(err, "could not update %s in admin kubeconfig secret", key)
+		}
+		if !bytes.Equal(data, updated) {
+			secret.Data[key] = updated
+			changed = true
+		}
+	}
+	if changed {
+		if err := r.Update(context.Background(), secret); err != nil {
+			return errors.Wrap(err, "could not update admin kubeconfig secret")
+		}
+	}
+	logger.Info("rotated admin credentials")
+	return nil
+}
+
+// stageAdminCredentials returns the staging secret holding the new admin credentials for the current recycle of a
+// ClusterDeployment, generating them if they do not exist yet.
+func (r *ReconcileClusterPool) stageAdminCredentials(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: stagedCredentialsSecretName(cd)}, secret); {
+	case err == nil:
+		return secret, nil
+	case !apierrors.IsNotFound(err):
+		return nil, errors.Wrap(err, "could not get staged admin credentials")
+	}
+
+	password, err := generateAdminPassword()
+	if err != nil {
+		return nil, err
+	}
+	caPEM, certPEM, keyPEM, err := generateAdminClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cd.Namespace,
+			Name:      stagedCredentialsSecretName(cd),
+			Labels:    map[string]string{constants.ClusterDeploymentNameLabel: cd.Name},
+		},
+		Data: map[string][]byte{
+			constants.PasswordSecretKey: []byte(password),
+			stagedCASecretKey:           caPEM,
+			constants.TLSCrtSecretKey:   certPEM,
+			constants.TLSKeySecretKey:   keyPEM,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, secret, r.Scheme()); err != nil {
+		return nil, errors.Wrap(err, "could not set controller reference on staged admin credentials")
+	}
+	if err := r.Create(context.Background(), secret); err != nil {
+		return nil, errors.Wrap(err, "could not save staged admin credentials")
+	}
+	logger.WithField("secret", secret.Name).Info("staged new admin credentials")
+	return secret, nil
+}
+
+// adminCredentialsAccepted returns true if the cluster accepts the admin kubeconfig of the ClusterDeployment. The
+// cluster only trusts a new client CA once the kube-apiserver has reloaded it.
+func (r *ReconcileClusterPool) adminCredentialsAccepted(cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err == nil {
+		err = remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminClientCANamespace, Name: adminClientCAName}, &corev1.ConfigMap{})
+	}
+	if err != nil {
+		logger.WithError(err).Debug("cluster does not yet accept rotated admin credentials")
+		return false
+	}
+	return true
+}
+
+// dropPreviousAdminClientCA makes the new CA of the staged admin credentials the only one the cluster trusts for the
+// admin kubeconfig's client certificate. It must only be called once the cluster accepts the new admin kubeconfig.
+func (r *ReconcileClusterPool) dropPreviousAdminClientCA(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	staged := &corev1.Secret{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: stagedCredentialsSecretName(cd)}, staged); err != nil {
+		return errors.Wrap(err, "could not get staged admin credentials")
+	}
+	remoteClient, err := r.remoteClientBuilder(cd).Build()
+	if err != nil {
+		return errors.Wrap(err, "could not connect to cluster")
+	}
+	cm := &corev1.ConfigMap{}
+	if err := remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminClientCANamespace, Name: adminClientCAName}, cm); err != nil {
+		return errors.Wrap(err, "could not get admin client CA from cluster")
+	}
+	if cm.Data[adminClientCAKey] == string(staged.Data[stagedCASecretKey]) {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[adminClientCAKey] = string(staged.Data[stagedCASecretKey])
+	if err := remoteClient.Update(context.Background(), cm); err != nil {
+		return errors.Wrap(err, "could not update admin client CA on cluster")
+	}
+	logger.Info("removed previous admin client CA from cluster")
+	return nil
+}
+
+// deleteStagedAdminCredentials deletes the staging secret of the current recycle of a ClusterDeployment, if any.
+func (r *ReconcileClusterPool) deleteStagedAdminCredentials(cd *hivev1.ClusterDeployment) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: stagedCredentialsSecretName(cd)}}
+	if err := r.Delete(context.Background(), secret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete staged admin credentials")
+	}
+	return nil
+}
+
+func updateKubeadminPassword(remoteClient client.Client, password []byte, logger log.FieldLogger) error {
+	secret := &corev1.Secret{}
+	switch err := remoteClient.Get(context.Background(), client.ObjectKey{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName}, secret); {
+	case apierrors.IsNotFound(err):
+		// The kubeadmin user has been removed from the cluster, so there is no password to rotate
+		logger.Debug("kubeadmin secret not found on cluster")
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "could not get kubeadmin secret from cluster")
+	}
+	if bcrypt.CompareHashAndPassword(secret.Data[kubeadminSecretKey], password) == nil {
+		return nil
+	}
+	hash, err := bcrypt.GenerateFromPassword(password, bcrypt.DefaultCost)
+	if err != nil {
+		return errors.Wrap(err, "could not hash kubeadmin password")
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[kubeadminSecretKey] = hash
+	return errors.Wrap(remoteClient.Update(context.Background(), secret), "could not update kubeadmin secret on cluster")
+}
+
+// addAdminClientCA adds a CA to the bundle the cluster trusts for the admin kubeconfig's client certificate, keeping the
+// CAs already in it.
+func addAdminClientCA(remoteClient client.Client, caPEM []byte) error {
+	cm := &corev1.ConfigMap{}
+	switch err := remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminClientCANamespace, Name: adminClientCAName}, cm); {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: adminClientCANamespace, Name: adminClientCAName},
+			Data:       map[string]string{adminClientCAKey: string(caPEM)},
+		}
+		return errors.Wrap(remoteClient.Create(context.Background(), cm), "could not create admin client CA on cluster")
+	case err != nil:
+		return errors.Wrap(err, "could not get admin client CA from cluster")
+	}
+	bundle := cm.Data[adminClientCAKey]
+	if strings.Contains(bundle, string(caPEM)) {
+		return nil
+	}
+	if bundle != "" && !strings.HasSuffix(bundle, "\n") {
+		bundle += "\n"
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[adminClientCAKey] = bundle + string(caPEM)
+	return errors.Wrap(remoteClient.Update(context.Background(), cm), "could not update admin client CA on cluster")
+}
+
+func generateAdminPassword() (string, error) {
+	groups := make([]string, adminPasswordGroups)
+	for i := range groups {
+		group := make([]byte, adminPasswordGroupChars)
+		for j := range group {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(adminPasswordChars))))
+			if err != nil {
+				return "", errors.Wrap(err, "could not generate kubeadmin password")
+			}
+			group[j] = adminPasswordChars[n.Int64()]
+		}
+		groups[i] = string(group)
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+// generateAdminClientCertificate returns a new self-signed CA, and a client certificate and key for system:admin
+// signed by it, all PEM encoded.
+func generateAdminClientCertificate() (caPEM, certPEM, keyPEM []byte, err error) {
+	now := time.Now()
+	caKey, err := rsa.GenerateKey(rand.Reader, adminClientKeyBits)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not generate CA key")
+	}
+	caSerial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not generate serial number")
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          caSerial,
+		Subject:               pkix.Name{CommonName: "admin-kubeconfig-signer", OrganizationalUnit: []string{"openshift"}},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(adminClientCertValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not sign CA certificate")
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not parse CA certificate")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, adminClientKeyBits)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not generate client key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not generate serial number")
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "system:admin", Organization: []string{"system:masters"}},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(adminClientCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not sign client certificate")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		nil
+}
+
+// replaceClientCertificate replaces the client certificate and key of the current user of a kubeconfig.
+func replaceClientCertificate(kubeconfig, certPEM, keyPEM []byte) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, errors.New("kubeconfig has no current context")
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return nil, errors.New("kubeconfig has no user for the current context")
+	}
+	authInfo.ClientCertificate, authInfo.ClientKey = "", ""
+	authInfo.ClientCertificateData, authInfo.ClientKeyData = certPEM, keyPEM
+	return clientcmd.Write(*config)
+}
@@ -39,6 +39,14 @@ var (
 		Name: "hive_clusterpool_clusterdeployments_broken",
 		Help: "The number of ClusterDeployments we have deemed unrecoverable and unusable. Should tend toward zero as such clusters are gradually replaced.",
 	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricClusterDeploymentsRecycling = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_clusterdeployments_recycling",
+		Help: "The number of previously claimed ClusterDeployments being cleaned up to be returned to the pool. Contributes to Size and MaxSize.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricAutoscalingTargetSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_autoscaling_target_size",
+		Help: "The number of unclaimed ClusterDeployments an autoscaling pool is maintaining, as computed from recent ClusterClaim demand. Takes the place of the pool Size.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
 	// metricStaleClusterDeploymentsDeleted tracks the total number of CDs we delete because they
 	// became "stale". That is, the ClusterPool was modified in a substantive way such that these
 	// CDs no longer match its spec. Note that this only counts stale CDs we've *deleted* -- there
@@ -47,6 +55,12 @@ var (
 		Name: "hive_clusterpool_stale_clusterdeployments_deleted",
 		Help: "The number of ClusterDeployments deleted because they no longer match the spec of their ClusterPool.",
 	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	// metricClusterDeploymentsRecycled tracks the total number of previously claimed CDs we have
+	// successfully cleaned up and returned to the pool, rather than deleting them.
+	metricClusterDeploymentsRecycled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterpool_clusterdeployments_recycled",
+		Help: "The number of previously claimed ClusterDeployments returned to the pool after cleanup.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
 	// metricClaimDelaySeconds tracks how long it takes for a claim to be assigned, labeled by
 	// cluster pool.
 	metricClaimDelaySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -74,6 +88,9 @@ func init() {
 	metrics.Registry.MustRegister(metricClusterDeploymentsStandby)
 	metrics.Registry.MustRegister(metricClusterDeploymentsStale)
 	metrics.Registry.MustRegister(metricClusterDeploymentsBroken)
+	metrics.Registry.MustRegister(metricClusterDeploymentsRecycling)
+	metrics.Registry.MustRegister(metricAutoscalingTargetSize)
 	metrics.Registry.MustRegister(metricStaleClusterDeploymentsDeleted)
+	metrics.Registry.MustRegister(metricClusterDeploymentsRecycled)
 	metrics.Registry.MustRegister(metricClaimDelaySeconds)
 }
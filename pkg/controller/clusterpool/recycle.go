@@ -0,0 +1,304 @@
+package clusterpool
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultRecycleTimeout = time.Hour
+
+	recycleKubeconfigDir = "/etc/recycle/kubeconfig"
+)
+
+func isRecycling(cd *hivev1.ClusterDeployment) bool {
+	_, ok := cd.Annotations[constants.RecyclePoolClusterAnnotation]
+	return ok
+}
+
+func recycleTimeout(clp *hivev1.ClusterPool) time.Duration {
+	if clp.Spec.Recycle != nil && clp.Spec.Recycle.Timeout != nil {
+		return clp.Spec.Recycle.Timeout.Duration
+	}
+	return defaultRecycleTimeout
+}
+
+// recycleJobName returns the name of the cleanup job for the current recycle of a ClusterDeployment. The name is
+// distinct for each recycle so a finished job from an earlier recycle, which may still be deleting, is never mistaken
+// for the current one.
+func recycleJobName(cd *hivev1.ClusterDeployment) string {
+	return apihelpers.GetResourceName(cd.Name, "recycle-"+cd.Annotations[constants.PoolClusterRecycleCountAnnotation])
+}
+
+// shouldRecycle returns true if a ClusterDeployment marked for deletion should instead be returned to the pool: that is,
+// the pool recycles clusters, and the CD is an installed, previously claimed cluster, which is current with the pool
+// and has not yet been recycled the maximum number of times.
+func shouldRecycle(clp *hivev1.ClusterPool, poolVersion string, cd *hivev1.ClusterDeployment) bool {
+	recycle := clp.Spec.Recycle
+	if recycle == nil || !cd.Spec.Installed || cd.Spec.ClusterPoolRef.ClaimName == "" {
+		return false
+	}
+	if cd.Annotations[constants.ClusterDeploymentPoolSpecHashAnnotation] != poolVersion {
+		return false
+	}
+	if recycle.MaxRecycles != nil {
+		recycles, _ := strconv.Atoi(cd.Annotations[constants.PoolClusterRecycleCountAnnotation])
+		if recycles >= int(*recycle.MaxRecycles) {
+			return false
+		}
+	}
+	return true
+}
+
+// recycleClusters starts recycling the previously claimed clusters which are marked for deletion, if the pool
+// recycles clusters, and advances the cleanup of the clusters already being recycled. It returns how soon the pool
+// must be reconciled again to time out a cleanup.
+func (r *ReconcileClusterPool) recycleClusters(clp *hivev1.ClusterPool, poolVersion string, cds *cdCollection, logger log.FieldLogger) (time.Duration, error) {
+	toRecycle := []string{}
+	for _, cd := range cds.MarkedForDeletion() {
+		if shouldRecycle(clp, poolVersion, cd) {
+			toRecycle = append(toRecycle, cd.Name)
+		}
+	}
+	for _, cdName := range toRecycle {
+		logger.WithField("cluster", cdName).Info("recycling cluster deployment for previous claim")
+		if err := cds.StartRecycle(r.Client, cdName); err != nil {
+			logger.WithField("cluster", cdName).WithError(err).Error("error marking cluster deployment for recycling")
+			return 0, err
+		}
+	}
+
+	var requeueAfter time.Duration
+	recycling := make([]*hivev1.ClusterDeployment, len(cds.Recycling()))
+	copy(recycling, cds.Recycling())
+	for _, cd := range recycling {
+		remaining, err := r.recycleCluster(clp, cd, cds, logger.WithField("cluster", cd.Name))
+		if err != nil {
+			return 0, err
+		}
+		if remaining > 0 && (requeueAfter == 0 || remaining < requeueAfter) {
+			requeueAfter = remaining
+		}
+	}
+	return requeueAfter, nil
+}
+
+// recycleCluster advances the cleanup of a recycling ClusterDeployment: waiting for the cluster to be running, rotating
+// its admin credentials, waiting for the cluster to accept the new ones before it stops trusting the previous admin
+// client CA, running the cleanup job, and returning the cluster to the pool if the job succeeds and the cluster is
+// healthy. The cluster is marked for deletion instead if the
+// cleanup fails or times out, or the pool no longer recycles clusters. It returns how soon the cleanup must be checked
+// again: the time remaining before it times out, or less while waiting for the new credentials; or zero if the
+// cleanup is finished.
+func (r *ReconcileClusterPool) recycleCluster(clp *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, cds *cdCollection, logger log.FieldLogger) (time.Duration, error) {
+	finish := func(succeeded bool, reason string) (time.Duration, error) {
+		logger.WithField("succeeded", succeeded).Info(reason)
+		if err := r.deleteStagedAdminCredentials(cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error deleting staged admin credentials")
+			return 0, err
+		}
+		if err := cds.FinishRecycle(r.Client, cd.Name, succeeded); err != nil {
+			logger.WithError(err).Error("error updating recycled cluster deployment")
+			return 0, err
+		}
+		if succeeded {
+			metricClusterDeploymentsRecycled.WithLabelValues(clp.Namespace, clp.Name).Inc()
+		}
+		return 0, nil
+	}
+
+	if clp.Spec.Recycle == nil {
+		return finish(false, "pool no longer recycles clusters; deleting cluster deployment")
+	}
+	started, err := time.Parse(time.RFC3339, cd.Annotations[constants.RecyclePoolClusterAnnotation])
+	if err != nil {
+		return finish(false, "could not parse recycle start time; deleting cluster deployment")
+	}
+	remaining := recycleTimeout(clp) - time.Since(started)
+	if remaining <= 0 {
+		return finish(false, "cleanup timed out; deleting cluster deployment")
+	}
+
+	if cd.Status.PowerState != hivev1.ClusterPowerStateRunning {
+		logger.Debug("waiting for cluster to be running before cleanup")
+		return remaining, nil
+	}
+
+	if !credentialsRotated(cd) {
+		rotation := credentialsRotationComplete
+		switch {
+		case controllerutils.IsFakeCluster(cd):
+		case !credentialsSwitched(cd):
+			if err := r.rotateAdminCredentials(cd, logger); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "error rotating admin credentials")
+				return 0, errors.Wrap(err, "error rotating admin credentials")
+			}
+			rotation = credentialsRotationSwitched
+		case !r.adminCredentialsAccepted(cd, logger):
+			return min(remaining, rotatedCredentialsCheckInterval), nil
+		default:
+			if err := r.dropPreviousAdminClientCA(cd, logger); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "error removing previous admin client CA")
+				return 0, errors.Wrap(err, "error removing previous admin client CA")
+			}
+		}
+		cd.Annotations[constants.RecycleCredentialsRotatedAnnotation] = rotation
+		if err := r.Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error marking admin credentials rotated")
+			return 0, err
+		}
+		// The rotation, then the cleanup, continue on the next check
+		return min(remaining, rotatedCredentialsCheckInterval), nil
+	}
+
+	job := &batchv1.Job{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: recycleJobName(cd)}, job); {
+	case apierrors.IsNotFound(err):
+		if err := controllerutils.SetupClusterRecycleServiceAccount(r.Client, cd.Namespace, logger); err != nil {
+			logger.WithError(err).Error("error setting up service account for cleanup job")
+			return 0, err
+		}
+		job := generateRecycleJob(clp.Spec.Recycle, cd)
+		if err := controllerutil.SetControllerReference(cd, job, r.Scheme()); err != nil {
+			logger.WithError(err).Error("error setting controller reference on cleanup job")
+			return 0, err
+		}
+		logger.WithField("job", job.Name).Info("creating cleanup job")
+		if err := r.Create(context.Background(), job); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error creating cleanup job")
+			return 0, errors.Wrap(err, "error creating cleanup job")
+		}
+		return remaining, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cleanup job")
+		return 0, err
+	}
+
+	if !controllerutils.IsFinished(job) {
+		logger.WithField("job", job.Name).Debug("cleanup job is still running")
+		return remaining, nil
+	}
+	var result time.Duration
+	switch cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.UnreachableCondition); {
+	case controllerutils.IsFailed(job):
+		result, err = finish(false, "cleanup job failed; deleting cluster deployment")
+	case cond != nil && cond.Status == corev1.ConditionTrue:
+		result, err = finish(false, "cluster is unreachable after cleanup; deleting cluster deployment")
+	default:
+		result, err = finish(true, "cleanup succeeded; returning cluster deployment to the pool")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := r.Delete(context.Background(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error deleting cleanup job")
+		return 0, err
+	}
+	return result, nil
+}
+
+// generateRecycleJob returns the cleanup job for a recycling ClusterDeployment.
+func generateRecycleJob(recycle *hivev1.ClusterPoolRecycle, cd *hivev1.ClusterDeployment) *batchv1.Job {
+	env := []corev1.EnvVar{
+		{
+			Name:  "KUBECONFIG",
+			Value: filepath.Join(recycleKubeconfigDir, constants.KubeconfigSecretKey),
+		},
+		{
+			Name:  "CLUSTER_DEPLOYMENT_NAME",
+			Value: cd.Name,
+		},
+	}
+	env = append(env, recycle.Env...)
+
+	labels := map[string]string{
+		constants.JobTypeLabel:               constants.JobTypeRecycle,
+		constants.ClusterDeploymentNameLabel: cd.Name,
+	}
+	completions := int32(1)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      recycleJobName(cd),
+			Namespace: cd.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: &completions,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: controllerutils.RecycleServiceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:            "cleanup",
+							Image:           recycle.Image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         recycle.Command,
+							Args:            recycle.Args,
+							Env:             env,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "kubeconfig",
+									MountPath: recycleKubeconfigDir,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "kubeconfig",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// requestsForRecycleJob maps a cleanup job to the pool of the ClusterDeployment being recycled.
+func requestsForRecycleJob(c client.Client, logger log.FieldLogger) handler.TypedMapFunc[*batchv1.Job, reconcile.Request] {
+	return func(ctx context.Context, job *batchv1.Job) []reconcile.Request {
+		if job.Labels[constants.JobTypeLabel] != constants.JobTypeRecycle {
+			return nil
+		}
+		cdName := job.Labels[constants.ClusterDeploymentNameLabel]
+		cd := &hivev1.ClusterDeployment{}
+		if err := c.Get(context.Background(), client.ObjectKey{Namespace: job.Namespace, Name: cdName}, cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get ClusterDeployment for cleanup job")
+			return nil
+		}
+		cpKey := clusterPoolKey(cd)
+		if cpKey == nil {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: *cpKey}}
+	}
+}
@@ -0,0 +1,475 @@
+package clusterpool
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const recycleTestKubeconfig = `clusters:
+- cluster:
+    server: https://test-api-url:6443
+  name: cluster
+contexts:
+- context:
+    cluster: cluster
+    user: admin
+  name: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    client-certificate-data: b2xkLWNlcnQ=
+    client-key-data: b2xkLWtleQ==
+`
+
+func TestRecycleClusters(t *testing.T) {
+	scheme := scheme.GetScheme()
+	const cdName = "recycled"
+	recycle := &hivev1.ClusterPoolRecycle{Image: "example.com/cleanup:latest"}
+	poolBuilder := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).Options(
+		testcp.ForAWS(credsSecretName, "us-east-1"),
+		testcp.WithBaseDomain("test-domain"),
+		testcp.WithImageSet(imageSetName),
+	)
+	pool := poolBuilder.Build(testcp.WithRecycle(recycle))
+	poolVersion := calculatePoolVersion(pool)
+	cdBuilder := testcd.FullBuilder(cdName, cdName, scheme).Options(
+		testcd.Running(),
+		testcd.WithPoolVersion(poolVersion),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "kubeconfig"},
+			AdminPasswordSecretRef:   &corev1.LocalObjectReference{Name: "password"},
+		}),
+	)
+	markedCDBuilder := cdBuilder.Options(
+		testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "claim"),
+		testcd.WithAnnotation(constants.RemovePoolClusterAnnotation, "true"),
+	)
+	recyclingCDBuilder := func(started time.Time) testcd.Builder {
+		return cdBuilder.Options(
+			testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName),
+			testcd.WithAnnotation(constants.RecyclePoolClusterAnnotation, started.UTC().Format(time.RFC3339)),
+			testcd.WithAnnotation(constants.PoolClusterRecycleCountAnnotation, "1"),
+		)
+	}
+	switchedCDBuilder := func(started time.Time) testcd.Builder {
+		return recyclingCDBuilder(started).Options(
+			testcd.WithAnnotation(constants.RecycleCredentialsRotatedAnnotation, credentialsRotationSwitched),
+		)
+	}
+	rotatedCDBuilder := func(started time.Time) testcd.Builder {
+		return recyclingCDBuilder(started).Options(
+			testcd.WithAnnotation(constants.RecycleCredentialsRotatedAnnotation, credentialsRotationComplete),
+		)
+	}
+	caPEM, certPEM, keyPEM, err := generateAdminClientCertificate()
+	require.NoError(t, err, "could not generate admin client certificate")
+	stagedCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cdName, Name: stagedCredentialsSecretName(recyclingCDBuilder(time.Now()).Build())},
+		Data: map[string][]byte{
+			constants.PasswordSecretKey: []byte("staged-password"),
+			stagedCASecretKey:           caPEM,
+			constants.TLSCrtSecretKey:   certPEM,
+			constants.TLSKeySecretKey:   keyPEM,
+		},
+	}
+	recycleJob := func(conditionType batchv1.JobConditionType) *batchv1.Job {
+		job := generateRecycleJob(recycle, recyclingCDBuilder(time.Now()).Build())
+		if conditionType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		}
+		return job
+	}
+	unreachable := testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+		Type:   hivev1.UnreachableCondition,
+		Status: corev1.ConditionTrue,
+	})
+
+	cases := []struct {
+		name              string
+		pool              *hivev1.ClusterPool
+		cd                *hivev1.ClusterDeployment
+		existing          []runtime.Object
+		remoteUnreachable bool
+		expectRecycling   bool
+		expectRotation    bool
+		expectStaged      *corev1.Secret
+		expectCADropped   bool
+		expectMarked      bool
+		expectRecycles    int
+		expectJob         bool
+		expectRequeue     bool
+		expectStandby     bool
+		expectClaimName   string
+		expectPowerState  hivev1.ClusterPowerState
+		expectNoJobExists bool
+	}{
+		{
+			name:            "pool does not recycle",
+			pool:            poolBuilder.Build(),
+			cd:              markedCDBuilder.Build(),
+			expectMarked:    true,
+			expectClaimName: "claim",
+		},
+		{
+			name:            "claimed cluster starts recycling",
+			pool:            pool,
+			cd:              markedCDBuilder.Build(),
+			expectRecycling: true,
+			expectRotation:  true,
+			expectRecycles:  1,
+			expectRequeue:   true,
+		},
+		{
+			name:            "recycling cluster has credentials rotated",
+			pool:            pool,
+			cd:              recyclingCDBuilder(time.Now()).Build(),
+			expectRecycling: true,
+			expectRotation:  true,
+			expectRecycles:  1,
+			expectRequeue:   true,
+		},
+		{
+			name:            "rotation resumes with staged credentials",
+			pool:            pool,
+			cd:              recyclingCDBuilder(time.Now()).Build(),
+			existing:        []runtime.Object{stagedCredentials.DeepCopy()},
+			expectRecycling: true,
+			expectRotation:  true,
+			expectStaged:    stagedCredentials,
+			expectRecycles:  1,
+			expectRequeue:   true,
+		},
+		{
+			name:              "cleanup waits for rotated credentials",
+			pool:              pool,
+			cd:                switchedCDBuilder(time.Now()).Build(),
+			existing:          []runtime.Object{stagedCredentials.DeepCopy()},
+			remoteUnreachable: true,
+			expectRecycling:   true,
+			expectRecycles:    1,
+			expectRequeue:     true,
+			expectNoJobExists: true,
+		},
+		{
+			name:              "previous admin client CA is dropped once rotated credentials are accepted",
+			pool:              pool,
+			cd:                switchedCDBuilder(time.Now()).Build(),
+			existing:          []runtime.Object{stagedCredentials.DeepCopy()},
+			expectRecycling:   true,
+			expectCADropped:   true,
+			expectRecycles:    1,
+			expectRequeue:     true,
+			expectNoJobExists: true,
+		},
+		{
+			name:            "cleanup starts once rotation is complete",
+			pool:            pool,
+			cd:              rotatedCDBuilder(time.Now()).Build(),
+			expectRecycling: true,
+			expectRecycles:  1,
+			expectJob:       true,
+			expectRequeue:   true,
+		},
+		{
+			name:            "hibernating cluster is resumed before cleanup",
+			pool:            pool,
+			cd:              markedCDBuilder.Build(testcd.WithPowerState(hivev1.ClusterPowerStateHibernating), testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating)),
+			expectRecycling: true,
+			expectRecycles:  1,
+			expectRequeue:   true,
+			// The cleanup waits for the resume
+			expectPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name:            "unclaimed cluster is not recycled",
+			pool:            pool,
+			cd:              cdBuilder.Build(testcd.WithUnclaimedClusterPoolReference(testNamespace, testLeasePoolName), testcd.WithAnnotation(constants.RemovePoolClusterAnnotation, "true")),
+			expectMarked:    true,
+			expectClaimName: "",
+		},
+		{
+			name:            "stale cluster is not recycled",
+			pool:            pool,
+			cd:              markedCDBuilder.Build(testcd.WithPoolVersion("stale")),
+			expectMarked:    true,
+			expectClaimName: "claim",
+		},
+		{
+			name:            "cluster recycled the maximum number of times is not recycled",
+			pool:            poolBuilder.Build(testcp.WithRecycle(&hivev1.ClusterPoolRecycle{Image: recycle.Image, MaxRecycles: ptr.To[int32](1)})),
+			cd:              markedCDBuilder.Build(testcd.WithAnnotation(constants.PoolClusterRecycleCountAnnotation, "1")),
+			expectMarked:    true,
+			expectRecycles:  1,
+			expectClaimName: "claim",
+		},
+		{
+			name:            "cleanup still running",
+			pool:            pool,
+			cd:              rotatedCDBuilder(time.Now()).Build(),
+			existing:        []runtime.Object{recycleJob("")},
+			expectRecycling: true,
+			expectRecycles:  1,
+			expectJob:       true,
+			expectRequeue:   true,
+		},
+		{
+			name:              "successful cleanup returns cluster to pool",
+			pool:              pool,
+			cd:                rotatedCDBuilder(time.Now()).Build(),
+			existing:          []runtime.Object{recycleJob(batchv1.JobComplete), stagedCredentials.DeepCopy()},
+			expectRecycles:    1,
+			expectStandby:     true,
+			expectNoJobExists: true,
+		},
+		{
+			name:              "failed cleanup deletes cluster",
+			pool:              pool,
+			cd:                rotatedCDBuilder(time.Now()).Build(),
+			existing:          []runtime.Object{recycleJob(batchv1.JobFailed)},
+			expectMarked:      true,
+			expectRecycles:    1,
+			expectNoJobExists: true,
+		},
+		{
+			name:              "unreachable cluster is deleted after cleanup",
+			pool:              pool,
+			cd:                rotatedCDBuilder(time.Now()).Build(unreachable),
+			existing:          []runtime.Object{recycleJob(batchv1.JobComplete)},
+			expectMarked:      true,
+			expectRecycles:    1,
+			expectNoJobExists: true,
+		},
+		{
+			name:           "timed out cleanup deletes cluster",
+			pool:           pool,
+			cd:             rotatedCDBuilder(time.Now().Add(-2 * time.Hour)).Build(),
+			existing:       []runtime.Object{recycleJob("")},
+			expectMarked:   true,
+			expectRecycles: 1,
+		},
+		{
+			name:           "cluster is deleted when pool stops recycling",
+			pool:           poolBuilder.Build(),
+			cd:             rotatedCDBuilder(time.Now()).Build(),
+			expectMarked:   true,
+			expectRecycles: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeconfigSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: cdName, Name: "kubeconfig"},
+				Data: map[string][]byte{
+					constants.KubeconfigSecretKey:    []byte(recycleTestKubeconfig),
+					constants.RawKubeconfigSecretKey: []byte(recycleTestKubeconfig),
+				},
+			}
+			passwordSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: cdName, Name: "password"},
+				Data:       map[string][]byte{"username": []byte("kubeadmin"), "password": []byte("old-password")},
+			}
+			c := testfake.NewFakeClientBuilder().
+				WithIndex(&hivev1.ClusterDeployment{}, cdClusterPoolIndex, indexClusterDeploymentsByClusterPool).
+				WithRuntimeObjects(append(tc.existing, tc.pool, tc.cd, kubeconfigSecret, passwordSecret)...).
+				Build()
+			remoteClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName},
+					Data:       map[string][]byte{kubeadminSecretKey: []byte("old-hash")},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: adminClientCANamespace, Name: adminClientCAName},
+					Data:       map[string]string{adminClientCAKey: "old-ca"},
+				},
+			).Build()
+			mockCtrl := gomock.NewController(t)
+			logger := log.WithField("controller", "clusterpool")
+			r := &ReconcileClusterPool{
+				Client: c,
+				logger: logger,
+				remoteClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					builder := remoteclientmock.NewMockBuilder(mockCtrl)
+					if tc.remoteUnreachable {
+						builder.EXPECT().Build().Return(nil, errors.New("unauthorized"))
+					} else {
+						builder.EXPECT().Build().Return(remoteClient, nil)
+					}
+					return builder
+				},
+			}
+			cds, err := getAllClusterDeploymentsForPool(c, tc.pool, calculatePoolVersion(tc.pool), logger)
+			require.NoError(t, err, "unexpected error getting ClusterDeployments")
+
+			requeueAfter, err := r.recycleClusters(tc.pool, calculatePoolVersion(tc.pool), cds, logger)
+			require.NoError(t, err, "unexpected error recycling clusters")
+			if tc.expectRequeue {
+				assert.Positive(t, requeueAfter, "expected requeue for cleanup timeout")
+			} else {
+				assert.Zero(t, requeueAfter, "unexpected requeue")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: cdName, Name: cdName}, cd), "could not get ClusterDeployment")
+			assert.Equal(t, tc.expectRecycling, isRecycling(cd), "unexpected recycling annotation")
+			if !tc.expectRecycling {
+				assert.False(t, credentialsSwitched(cd), "expected credentials rotated annotation to be removed")
+				err := c.Get(context.Background(), client.ObjectKeyFromObject(stagedCredentials), &corev1.Secret{})
+				assert.True(t, apierrors.IsNotFound(err), "expected staged admin credentials to be deleted")
+			}
+			assert.Equal(t, tc.expectMarked, controllerutils.IsClusterMarkedForRemoval(cd), "unexpected removal annotation")
+			assert.Equal(t, tc.expectClaimName, cd.Spec.ClusterPoolRef.ClaimName, "unexpected claim name")
+			if tc.expectRecycles > 0 {
+				assert.Equal(t, strconv.Itoa(tc.expectRecycles), cd.Annotations[constants.PoolClusterRecycleCountAnnotation], "unexpected recycle count")
+			}
+			if tc.expectPowerState != "" {
+				assert.Equal(t, tc.expectPowerState, cd.Spec.PowerState, "unexpected power state")
+			}
+			assert.Equal(t, tc.expectRecycling, len(cds.Recycling()) == 1, "unexpected recycling clusters in collection")
+			assert.Equal(t, tc.expectStandby, len(cds.Standby()) == 1, "unexpected standby clusters in collection")
+			assert.Equal(t, tc.expectMarked, len(cds.MarkedForDeletion()) == 1, "unexpected clusters marked for deletion in collection")
+
+			if tc.expectCADropped {
+				assert.True(t, credentialsRotated(cd), "expected credentials rotation to be complete")
+				cm := &corev1.ConfigMap{}
+				require.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminClientCANamespace, Name: adminClientCAName}, cm))
+				assert.Equal(t, string(caPEM), cm.Data[adminClientCAKey], "expected only the new admin client CA to be trusted")
+			}
+			if tc.expectRotation {
+				assertCredentialsRotated(t, c, remoteClient, cdName, tc.expectStaged)
+			} else {
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: cdName, Name: "kubeconfig"}, secret))
+				assert.Equal(t, recycleTestKubeconfig, string(secret.Data[constants.KubeconfigSecretKey]), "unexpected admin kubeconfig change")
+			}
+
+			jobs := &batchv1.JobList{}
+			require.NoError(t, c.List(context.Background(), jobs, client.InNamespace(cdName)), "could not list jobs")
+			if tc.expectJob {
+				if assert.Len(t, jobs.Items, 1, "expected cleanup job") {
+					job := jobs.Items[0]
+					assert.Equal(t, recycleJobName(cd), job.Name, "unexpected job name")
+					assert.Equal(t, recycle.Image, job.Spec.Template.Spec.Containers[0].Image, "unexpected job image")
+					assert.Equal(t, controllerutils.RecycleServiceAccountName, job.Spec.Template.Spec.ServiceAccountName, "unexpected service account")
+				}
+				if len(tc.existing) == 0 {
+					sa := &corev1.ServiceAccount{}
+					assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: cdName, Name: controllerutils.RecycleServiceAccountName}, sa), "expected service account")
+				}
+			} else if tc.expectNoJobExists {
+				assert.Empty(t, jobs.Items, "expected cleanup job to be deleted")
+				err := c.Get(context.Background(), client.ObjectKey{Namespace: cdName, Name: recycleJobName(cd)}, &batchv1.Job{})
+				assert.True(t, apierrors.IsNotFound(err), "expected cleanup job to be deleted")
+			}
+		})
+	}
+}
+
+// assertCredentialsRotated asserts that the kubeadmin password and admin kubeconfig of a recycled cluster were
+// replaced by the staged credentials, and that the cluster was updated to accept them while still trusting the previous
+// admin client CA. If staged is given, the rotation must have used those credentials.
+func assertCredentialsRotated(t *testing.T, c, remoteClient client.Client, namespace string, staged *corev1.Secret) {
+	cd := &hivev1.ClusterDeployment{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: namespace}, cd))
+	assert.True(t, credentialsSwitched(cd), "expected credentials rotated annotation")
+	assert.False(t, credentialsRotated(cd), "expected the previous admin client CA to still be trusted")
+
+	stagedSecret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: stagedCredentialsSecretName(cd)}, stagedSecret), "expected staged admin credentials")
+	if staged != nil {
+		assert.Equal(t, staged.Data, stagedSecret.Data, "expected the staged admin credentials to be reused")
+	}
+
+	passwordSecret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "password"}, passwordSecret))
+	password := passwordSecret.Data["password"]
+	assert.Equal(t, stagedSecret.Data[constants.PasswordSecretKey], password, "expected the staged password")
+	if staged == nil {
+		assert.Regexp(t, "^[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}-[a-zA-Z0-9]{5}$", string(password), "unexpected password")
+	}
+	kubeadminSecret := &corev1.Secret{}
+	require.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName}, kubeadminSecret))
+	assert.NoError(t, bcrypt.CompareHashAndPassword(kubeadminSecret.Data[kubeadminSecretKey], password), "expected cluster to accept the new password")
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, remoteClient.Get(context.Background(), client.ObjectKey{Namespace: adminClientCANamespace, Name: adminClientCAName}, cm))
+	assert.Contains(t, cm.Data[adminClientCAKey], "old-ca", "expected the previous admin client CA to still be trusted")
+	assert.Contains(t, cm.Data[adminClientCAKey], string(stagedSecret.Data[stagedCASecretKey]), "expected the new admin client CA to be trusted")
+	block, _ := pem.Decode(stagedSecret.Data[stagedCASecretKey])
+	require.NotNil(t, block, "expected a new admin client CA")
+	ca, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err, "could not parse admin client CA")
+
+	kubeconfigSecret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "kubeconfig"}, kubeconfigSecret))
+	for _, key := range []string{constants.KubeconfigSecretKey, constants.RawKubeconfigSecretKey} {
+		config, err := clientcmd.Load(kubeconfigSecret.Data[key])
+		require.NoError(t, err, "could not load %s", key)
+		block, _ := pem.Decode(config.AuthInfos["admin"].ClientCertificateData)
+		require.NotNil(t, block, "expected a new client certificate in %s", key)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err, "could not parse client certificate in %s", key)
+		assert.NoError(t, cert.CheckSignatureFrom(ca), "expected client certificate in %s to be signed by the new CA", key)
+		assert.Equal(t, "system:admin", cert.Subject.CommonName, "unexpected client certificate user")
+		assert.Equal(t, []string{"system:masters"}, cert.Subject.Organization, "unexpected client certificate groups")
+	}
+}
+
+func TestGenerateRecycleJob(t *testing.T) {
+	recycle := &hivev1.ClusterPoolRecycle{
+		Image:   "example.com/cleanup:latest",
+		Command: []string{"/cleanup"},
+		Env:     []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+	}
+	cd := testcd.BasicBuilder().Build(
+		testcd.WithName("cd"),
+		testcd.WithNamespace("cd-ns"),
+		testcd.WithAnnotation(constants.PoolClusterRecycleCountAnnotation, "3"),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{
+			AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "kubeconfig"},
+			AdminPasswordSecretRef:   &corev1.LocalObjectReference{Name: "password"},
+		}),
+	)
+	job := generateRecycleJob(recycle, cd)
+	assert.Equal(t, "cd-recycle-3", job.Name, "unexpected job name")
+	assert.Equal(t, "cd-ns", job.Namespace, "unexpected job namespace")
+	assert.Equal(t, constants.JobTypeRecycle, job.Labels[constants.JobTypeLabel], "unexpected job type label")
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{"/cleanup"}, container.Command, "unexpected command")
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "KUBECONFIG", Value: "/etc/recycle/kubeconfig/kubeconfig"},
+		{Name: "CLUSTER_DEPLOYMENT_NAME", Value: "cd"},
+		{Name: "FOO", Value: "bar"},
+	}, container.Env, "unexpected environment")
+	assert.Equal(t, "kubeconfig", job.Spec.Template.Spec.Volumes[0].Secret.SecretName, "unexpected kubeconfig volume")
+}
@@ -391,14 +391,19 @@ func (r *ReconcileClusterProvision) reconcileSuccessfulJob(instance *hivev1.Clus
 
 func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("install job failed")
-	reason, message := r.parseInstallLog(instance.Spec.InstallLog, pLog)
+	reason, message, failures := r.parseInstallLog(instance.Spec.InstallLog, pLog)
 	if controllerutils.IsDeadlineExceeded(job) && reason == unknownReason {
 		reason, message = "AttemptDeadlineExceeded", "Install job failed due to deadline being exceeded for the attempt"
 	}
+	// Saved along with the ProvisionFailed condition.
+	instance.Status.InstallFailures = failures
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
 	if err == nil {
 		// Increment a counter metric for this cluster type and error reason:
 		metricInstallErrors.Observe(instance, map[string]string{"reason": reason}, 1)
+		for _, category := range installFailureCategories(failures) {
+			metricInstallFailureCategories.Observe(instance, map[string]string{"category": string(category)}, 1)
+		}
 		metricClusterProvisionsTotal.Observe(instance, map[string]string{"result": resultFailure}, 1)
 	}
 	return result, err
@@ -137,6 +137,27 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			expectedStage:      hivev1.ClusterProvisionStageFailed,
 			expectedFailReason: unknownReason,
 		},
+		{
+			name: "failed job with known failures",
+			existing: []runtime.Object{
+				testProvision(tcp.WithJob(installJobName), tcp.WithInstallLog(gcpSSDQuotaLog+"\n"+kubeAPIWaitTimeoutLog)),
+				testJob(failedJob()),
+				testPod("foo"),
+				buildRegexConfigMap(),
+			},
+			expectedStage:      hivev1.ClusterProvisionStageFailed,
+			expectedFailReason: "GCPQuotaSSDTotalGBExceeded",
+			validate: func(c client.Client, t *testing.T) {
+				failures := getProvision(c).Status.InstallFailures
+				if assert.Len(t, failures, 3, "unexpected number of install failures") {
+					assert.Equal(t, "GCPQuotaSSDTotalGBExceeded", failures[0].Reason, "unexpected first failure")
+					assert.Equal(t, hivev1.InstallFailureCategoryQuota, failures[0].Category, "unexpected first failure category")
+					assert.Equal(t, "KubeAPIWaitTimeout", failures[1].Reason, "unexpected second failure")
+					assert.Equal(t, hivev1.InstallFailureCategoryBootstrap, failures[1].Category, "unexpected second failure category")
+					assert.Equal(t, "FallbackQuotaExceeded", failures[2].Reason, "unexpected third failure")
+				}
+			},
+		},
 		{
 			name: "deadline exceeded job",
 			existing: []runtime.Object{
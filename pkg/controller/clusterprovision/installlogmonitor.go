@@ -3,14 +3,17 @@ package clusterprovision
 import (
 	"context"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
@@ -22,12 +25,16 @@ const (
 	logMissingMessage            = "Cluster install failed but installer log was not captured"
 	regexBadMessage              = "Cluster install failed but regex configmap to parse for known reasons could not be used"
 	unknownMessage               = "Cluster install failed but no known errors found in logs"
+
+	// maxExcerptLength is the most of the install log recorded for each failure found in it.
+	maxExcerptLength = 512
 )
 
-// parseInstallLog parses install log to monitor for known issues.
-func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string) {
+// parseInstallLog parses install log to monitor for known issues. It returns the reason and message of the first known
+// failure found, along with all of the known failures found.
+func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string, []hivev1.InstallFailure) {
 	if log == nil {
-		return unknownReason, logMissingMessage
+		return unknownReason, logMissingMessage, nil
 	}
 
 	// Load the regex configmap, if we don't have one, there's not much point proceeding here.
@@ -37,19 +44,19 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 		// Even if the error was a transient error in fetching the configmap, we should not block
 		// the continuation of deploying the cluster just so that we can potentially get a
 		// better failure message.
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, nil
 	}
 
 	regexesRaw, ok := regexCM.Data[regexDataEntryName]
 	if !ok {
 		pLog.Errorf("%s configmap does not have a %q data entry", regexConfigMapName, regexDataEntryName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, nil
 	}
 
 	regexes := []installLogRegex{}
 	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
 		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, nil
 	}
 
 	// Load additional regex configmap, continue anyway if configmap isn't present
@@ -78,9 +85,31 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 		pLog.WithField("line", l).Info("install log line")
 	}
 
-	// Scan log contents for known errors
-	combinedRegexes := append(regexes, additionalRegexes...)
-	for _, ilr := range combinedRegexes {
+	failures := classifyInstallLog(*log, append(regexes, additionalRegexes...), pLog)
+	if len(failures) == 0 {
+		return unknownReason, *log, nil
+	}
+	return failures[0].Reason, failures[0].Message, failures
+}
+
+// ClassifyInstallLog scans an install log for known failures. Each of regexesYAML holds install log regexes in the
+// format of the "regexes" data entry of the install-log-regexes ConfigMap; entries are matched in order.
+func ClassifyInstallLog(installLog string, pLog log.FieldLogger, regexesYAML ...string) ([]hivev1.InstallFailure, error) {
+	regexes := []installLogRegex{}
+	for _, raw := range regexesYAML {
+		entries := []installLogRegex{}
+		if err := yaml.Unmarshal([]byte(raw), &entries); err != nil {
+			return nil, errors.Wrap(err, "cannot unmarshal install log regexes")
+		}
+		regexes = append(regexes, entries...)
+	}
+	return classifyInstallLog(installLog, regexes, pLog), nil
+}
+
+// classifyInstallLog returns a failure for each regex entry with a search string matching the install log.
+func classifyInstallLog(installLog string, regexes []installLogRegex, pLog log.FieldLogger) []hivev1.InstallFailure {
+	var failures []hivev1.InstallFailure
+	for _, ilr := range regexes {
 		ilrLog := pLog.WithField("regexName", ilr.Name)
 		ilrLog.Debug("parsing regex entry")
 		for _, ss := range ilr.SearchRegexStrings {
@@ -90,15 +119,71 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 			ss = "(?i)" + ss
 			ssLog := ilrLog.WithField("searchString", ss)
 			ssLog.Debug("matching search string")
-			switch match, err := regexp.Match(ss, []byte(*log)); {
-			case err != nil:
+			re, err := regexp.Compile(ss)
+			if err != nil {
 				ssLog.WithError(err).Error("unable to compile regex")
-			case match:
-				pLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
-				return ilr.InstallFailingReason, ilr.InstallFailingMessage
+				continue
+			}
+			loc := re.FindStringIndex(installLog)
+			if loc == nil {
+				continue
 			}
+			category := ilr.Category
+			switch category {
+			case hivev1.InstallFailureCategoryQuota,
+				hivev1.InstallFailureCategoryCredentials,
+				hivev1.InstallFailureCategoryNetwork,
+				hivev1.InstallFailureCategoryCloudAPIOutage,
+				hivev1.InstallFailureCategoryBootstrap,
+				hivev1.InstallFailureCategoryUnknown:
+			case "":
+				category = hivev1.InstallFailureCategoryUnknown
+			default:
+				// The ClusterProvision status would fail validation with a category outside the API's enum
+				ilrLog.WithField("category", category).Warn("unknown install failure category, reporting Unknown")
+				category = hivev1.InstallFailureCategoryUnknown
+			}
+			pLog.WithField("reason", ilr.InstallFailingReason).WithField("category", category).Info("found known install failure string")
+			failures = append(failures, hivev1.InstallFailure{
+				Name:     ilr.Name,
+				Reason:   ilr.InstallFailingReason,
+				Category: category,
+				Message:  ilr.InstallFailingMessage,
+				Excerpt:  logExcerpt(installLog, loc[0], loc[1]),
+			})
+			// One failure per regex entry is enough, the remaining search strings are alternatives.
+			break
 		}
 	}
+	return failures
+}
+
+// logExcerpt returns the lines of the install log spanned by the match from start to end, truncated to
+// maxExcerptLength bytes.
+func logExcerpt(installLog string, start, end int) string {
+	start = strings.LastIndex(installLog[:start], "\n") + 1
+	if i := strings.Index(installLog[end:], "\n"); i >= 0 {
+		end += i
+	} else {
+		end = len(installLog)
+	}
+	excerpt := strings.TrimSpace(installLog[start:end])
+	if len(excerpt) > maxExcerptLength {
+		excerpt = strings.ToValidUTF8(excerpt[:maxExcerptLength], "") + "..."
+	}
+	return excerpt
+}
 
-	return unknownReason, *log
+// installFailureCategories returns the distinct categories of the failures, or Unknown when there are none.
+func installFailureCategories(failures []hivev1.InstallFailure) []hivev1.InstallFailureCategory {
+	if len(failures) == 0 {
+		return []hivev1.InstallFailureCategory{hivev1.InstallFailureCategoryUnknown}
+	}
+	var categories []hivev1.InstallFailureCategory
+	for _, f := range failures {
+		if !slices.Contains(categories, f.Category) {
+			categories = append(categories, f.Category)
+		}
+	}
+	return categories
 }
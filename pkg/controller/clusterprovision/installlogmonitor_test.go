@@ -2,10 +2,12 @@ package clusterprovision
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/utils/ptr"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	testfake "github.com/openshift/hive/pkg/test/fake"
 	"github.com/openshift/hive/pkg/util/scheme"
@@ -496,7 +499,7 @@ func TestParseInstallLog(t *testing.T) {
 				Client: fakeClient,
 				scheme: scheme.GetScheme(),
 			}
-			reason, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
+			reason, message, _ := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
 			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
 			if test.expectedMessage != nil {
 				assert.Equal(t, *test.expectedMessage, message)
@@ -521,3 +524,175 @@ func buildRegexConfigMap() runtime.Object {
 	}
 	return obj
 }
+
+func TestParseInstallLogFailures(t *testing.T) {
+	regexes := `
+- name: QuotaEntry
+  searchRegexStrings:
+  - "NatGatewayLimitExceeded"
+  installFailingReason: AWSNATGatewayLimitExceeded
+  installFailingMessage: AWS NAT gateway limit exceeded
+  category: Quota
+- name: NoMatchEntry
+  searchRegexStrings:
+  - "VpcLimitExceeded"
+  installFailingReason: AWSVPCLimitExceeded
+  installFailingMessage: AWS VPC limit exceeded
+  category: Quota
+- name: UncategorizedEntry
+  searchRegexStrings:
+  - "no match here"
+  - "maximum number of NAT Gateways"
+  installFailingReason: TooManyNATGateways
+  installFailingMessage: Too many NAT gateways
+`
+	additionalRegexes := `
+- name: BootstrapEntry
+  searchRegexStrings:
+  - "waiting for Kubernetes API: context deadline exceeded"
+  installFailingReason: KubeAPIWaitTimeout
+  installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+  category: Bootstrap
+`
+	installLog := natGatewayLimitExceeded + "\n" + kubeAPIWaitTimeoutLog
+	fakeClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: regexConfigMapName, Namespace: constants.DefaultHiveNamespace},
+			Data:       map[string]string{regexDataEntryName: regexes},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: additionalRegexConfigMapName, Namespace: constants.DefaultHiveNamespace},
+			Data:       map[string]string{regexDataEntryName: additionalRegexes},
+		},
+	).Build()
+	r := &ReconcileClusterProvision{
+		Client: fakeClient,
+		scheme: scheme.GetScheme(),
+	}
+
+	reason, message, failures := r.parseInstallLog(&installLog, log.WithFields(log.Fields{}))
+
+	assert.Equal(t, "AWSNATGatewayLimitExceeded", reason, "unexpected reason")
+	assert.Equal(t, "AWS NAT gateway limit exceeded", message, "unexpected message")
+	natExcerpt := "time=\"2021-01-06T03:35:44Z\" level=error msg=\"Error creating NAT Gateway: NatGatewayLimitExceeded: The maximum number of NAT Gateways has been reached.\""
+	expected := []hivev1.InstallFailure{
+		{
+			Name:     "QuotaEntry",
+			Reason:   "AWSNATGatewayLimitExceeded",
+			Category: hivev1.InstallFailureCategoryQuota,
+			Message:  "AWS NAT gateway limit exceeded",
+			Excerpt:  natExcerpt,
+		},
+		{
+			Name:     "UncategorizedEntry",
+			Reason:   "TooManyNATGateways",
+			Category: hivev1.InstallFailureCategoryUnknown,
+			Message:  "Too many NAT gateways",
+			Excerpt:  natExcerpt,
+		},
+		{
+			Name:     "BootstrapEntry",
+			Reason:   "KubeAPIWaitTimeout",
+			Category: hivev1.InstallFailureCategoryBootstrap,
+			Message:  "Timeout waiting for the Kubernetes API to begin responding",
+			Excerpt:  "time=\"2021-01-03T07:04:44Z\" level=fatal msg=\"waiting for Kubernetes API: context deadline exceeded\"",
+		},
+	}
+	assert.Equal(t, expected, failures, "unexpected failures")
+	assert.Equal(t,
+		[]hivev1.InstallFailureCategory{hivev1.InstallFailureCategoryQuota, hivev1.InstallFailureCategoryUnknown, hivev1.InstallFailureCategoryBootstrap},
+		installFailureCategories(failures),
+		"unexpected categories")
+}
+
+func TestClassifyInstallLog(t *testing.T) {
+	cm := buildRegexConfigMap().(*corev1.ConfigMap)
+	tests := []struct {
+		name             string
+		log              string
+		expectedReason   string
+		expectedCategory hivev1.InstallFailureCategory
+	}{
+		{
+			name:             "quota",
+			log:              gcpCPUQuotaLog,
+			expectedReason:   "GCPComputeQuotaExceeded",
+			expectedCategory: hivev1.InstallFailureCategoryQuota,
+		},
+		{
+			name:             "credentials",
+			log:              invalidCredentials,
+			expectedReason:   "InvalidCredentials",
+			expectedCategory: hivev1.InstallFailureCategoryCredentials,
+		},
+		{
+			name:             "network",
+			log:              proxyTimeoutLog,
+			expectedReason:   "ProxyTimeout",
+			expectedCategory: hivev1.InstallFailureCategoryNetwork,
+		},
+		{
+			name:             "cloud API outage",
+			log:              route53Timeout,
+			expectedReason:   "AWSRoute53Timeout",
+			expectedCategory: hivev1.InstallFailureCategoryCloudAPIOutage,
+		},
+		{
+			name:             "bootstrap",
+			log:              bootstrapFailed,
+			expectedReason:   "BootstrapFailed",
+			expectedCategory: hivev1.InstallFailureCategoryBootstrap,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failures, err := ClassifyInstallLog(test.log, log.WithFields(log.Fields{}), cm.Data[regexDataEntryName])
+			require.NoError(t, err, "unexpected error")
+			require.NotEmpty(t, failures, "expected failures")
+			assert.Equal(t, test.expectedReason, failures[0].Reason, "unexpected reason")
+			assert.Equal(t, test.expectedCategory, failures[0].Category, "unexpected category")
+			assert.NotEmpty(t, failures[0].Excerpt, "expected excerpt")
+		})
+	}
+}
+
+func TestClassifyInstallLogUnknownCategory(t *testing.T) {
+	regexes := []installLogRegex{
+		{
+			Name:                 "Typo",
+			SearchRegexStrings:   []string{"MissingQuota"},
+			InstallFailingReason: "QuotaExceeded",
+			Category:             "Qouta",
+		},
+		{
+			Name:                 "NoCategory",
+			SearchRegexStrings:   []string{"MissingQuota"},
+			InstallFailingReason: "QuotaExceeded",
+		},
+		{
+			Name:                 "Known",
+			SearchRegexStrings:   []string{"MissingQuota"},
+			InstallFailingReason: "QuotaExceeded",
+			Category:             hivev1.InstallFailureCategoryQuota,
+		},
+	}
+	failures := classifyInstallLog(gcpCPUQuotaLog, regexes, log.WithFields(log.Fields{}))
+	require.Len(t, failures, 3, "expected a failure for each regex")
+	assert.Equal(t, hivev1.InstallFailureCategoryUnknown, failures[0].Category, "expected unknown category to be reported as Unknown")
+	assert.Equal(t, hivev1.InstallFailureCategoryUnknown, failures[1].Category, "expected missing category to be reported as Unknown")
+	assert.Equal(t, hivev1.InstallFailureCategoryQuota, failures[2].Category, "unexpected category")
+}
+
+func TestClassifyInstallLogBadRegexes(t *testing.T) {
+	_, err := ClassifyInstallLog(noMatchLog, log.WithFields(log.Fields{}), "malformed")
+	assert.Error(t, err, "expected error")
+}
+
+func TestLogExcerpt(t *testing.T) {
+	installLog := "first\nsecond match line\nthird"
+	start := len("first\nsecond ")
+	assert.Equal(t, "second match line", logExcerpt(installLog, start, start+len("match")), "unexpected excerpt")
+
+	long := strings.Repeat("x", maxExcerptLength*2)
+	assert.Equal(t, long[:maxExcerptLength]+"...", logExcerpt(long, 10, 20), "unexpected truncated excerpt")
+}
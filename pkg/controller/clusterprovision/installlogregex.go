@@ -1,5 +1,9 @@
 package clusterprovision
 
+import (
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
 // installLogRegex is a struct that represents all the data we use to scan for certain
 // search strings in install logs. These structs are serialized as yaml and stored/read from
 // the install-log-regexes ConfigMap.
@@ -15,4 +19,8 @@ type installLogRegex struct {
 
 	// InstallFailingMessage is the user friendly sentence we report for this failure and conditions, metrics and logs.
 	InstallFailingMessage string `json:"installFailingMessage"`
+
+	// Category is the broad classification of this failure. Entries without one, or with one Hive does not know, are
+	// reported as Unknown.
+	Category hivev1.InstallFailureCategory `json:"category,omitempty"`
 }
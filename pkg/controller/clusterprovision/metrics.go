@@ -10,8 +10,9 @@ import (
 var (
 	// Declare the metrics which allow optional labels to be added.
 	// They are defined later once the hive config has been read.
-	metricClusterProvisionsTotal hivemetrics.CounterVecWithDynamicLabels
-	metricInstallErrors          hivemetrics.CounterVecWithDynamicLabels
+	metricClusterProvisionsTotal   hivemetrics.CounterVecWithDynamicLabels
+	metricInstallErrors            hivemetrics.CounterVecWithDynamicLabels
+	metricInstallFailureCategories hivemetrics.CounterVecWithDynamicLabels
 
 	metricInstallFailureSeconds hivemetrics.HistogramVecWithDynamicLabels
 	metricInstallSuccessSeconds hivemetrics.HistogramVecWithDynamicLabels
@@ -36,6 +37,14 @@ func registerMetrics(mConfig *metricsconfig.MetricsConfig) {
 		[]string{"reason"},
 		mapClusterTypeLabelToValue,
 	)
+	metricInstallFailureCategories = *hivemetrics.NewCounterVecWithDynamicLabels(
+		&prometheus.CounterOpts{
+			Name: "hive_install_failure_categories_total",
+			Help: "Counter incremented for each category of known failure found in the install log of a failed cluster provision.",
+		},
+		[]string{"category"},
+		mapClusterTypeLabelToValue,
+	)
 
 	metricInstallFailureSeconds = *hivemetrics.NewHistogramVecWithDynamicLabels(
 		&prometheus.HistogramOpts{
@@ -57,6 +66,7 @@ func registerMetrics(mConfig *metricsconfig.MetricsConfig) {
 	)
 
 	metricInstallErrors.Register()
+	metricInstallFailureCategories.Register()
 	metricClusterProvisionsTotal.Register()
 	metricInstallFailureSeconds.Register()
 	metricInstallSuccessSeconds.Register()
@@ -0,0 +1,255 @@
+package clusterquota
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterQuotaControllerName
+)
+
+// Add creates a new ClusterQuota Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterQuota {
+	return &ReconcileClusterQuota{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterQuota, concurrentReconciles int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) error {
+	// Create a new controller
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterQuotas
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterQuota{}, &handler.TypedEnqueueRequestForObject[*hivev1.ClusterQuota]{})); err != nil {
+		return err
+	}
+
+	// Any change to the objects counted by quotas, or to the namespace labels quotas select on, may change the
+	// usage of any quota.
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Namespace{}, handler.TypedEnqueueRequestsFromMapFunc(
+		requestsForAllQuotas[*corev1.Namespace](r.Client, r.logger)))); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}, handler.TypedEnqueueRequestsFromMapFunc(
+		requestsForAllQuotas[*hivev1.ClusterDeployment](r.Client, r.logger)))); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterClaim{}, handler.TypedEnqueueRequestsFromMapFunc(
+		requestsForAllQuotas[*hivev1.ClusterClaim](r.Client, r.logger)))); err != nil {
+		return err
+	}
+	// ClusterPools determine the tenant of each claim.
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterPool{}, handler.TypedEnqueueRequestsFromMapFunc(
+		requestsForAllQuotas[*hivev1.ClusterPool](r.Client, r.logger)))); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.MachinePool{}, handler.TypedEnqueueRequestsFromMapFunc(
+		requestsForAllQuotas[*hivev1.MachinePool](r.Client, r.logger)))); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func requestsForAllQuotas[T client.Object](c client.Client, logger log.FieldLogger) handler.TypedMapFunc[T, reconcile.Request] {
+	return func(ctx context.Context, _ T) []reconcile.Request {
+		quotaList := &hivev1.ClusterQuotaList{}
+		if err := c.List(context.Background(), quotaList); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterQuotas")
+			return nil
+		}
+		requests := make([]reconcile.Request, len(quotaList.Items))
+		for i, quota := range quotaList.Items {
+			requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: quota.Name}}
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterQuota{}
+
+// ReconcileClusterQuota reconciles a ClusterQuota object for the purpose of reporting the usage of the namespaces it
+// selects. The quota's limits are enforced by the hiveadmission webhooks and the clusterpool controller.
+type ReconcileClusterQuota struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile records the usage of each namespace selected by a ClusterQuota in its status.
+func (r *ReconcileClusterQuota) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterQuota", request.NamespacedName)
+	logger.Info("reconciling cluster quota")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	quota := &hivev1.ClusterQuota{}
+	switch err := r.Get(context.Background(), request.NamespacedName, quota); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster quota not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster quota")
+		return reconcile.Result{}, err
+	}
+
+	if quota.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	usages, err := r.computeUsage(quota)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not compute usage")
+		return reconcile.Result{}, err
+	}
+
+	var reasons []string
+	for i := range usages {
+		reasons = append(reasons, exceeded(quota, &usages[i])...)
+	}
+	status, reason, message := corev1.ConditionFalse, "WithinLimits", "All selected namespaces are within the limits of the quota"
+	if len(reasons) > 0 {
+		status, reason, message = corev1.ConditionTrue, "LimitsExceeded", strings.Join(reasons, "; ")
+	}
+	conds, condsChanged := controllerutils.SetClusterQuotaConditionWithChangeCheck(
+		quota.Status.Conditions,
+		hivev1.ClusterQuotaExceededCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !condsChanged && reflect.DeepEqual(usages, quota.Status.Namespaces) {
+		return reconcile.Result{}, nil
+	}
+	quota.Status.Namespaces = usages
+	quota.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), quota); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// computeUsage returns the usage of each namespace selected by the quota which holds any of the objects counted
+// against it, ordered by namespace name.
+func (r *ReconcileClusterQuota) computeUsage(quota *hivev1.ClusterQuota) ([]hivev1.ClusterQuotaNamespaceUsage, error) {
+	type namespaceObjects struct {
+		cds          []hivev1.ClusterDeployment
+		claims       []hivev1.ClusterClaim
+		clusterPools []hivev1.ClusterPool
+		pools        []hivev1.MachinePool
+	}
+	byNamespace := map[string]*namespaceObjects{}
+	objectsIn := func(namespace string) *namespaceObjects {
+		objs := byNamespace[namespace]
+		if objs == nil {
+			objs = &namespaceObjects{}
+			byNamespace[namespace] = objs
+		}
+		return objs
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList); err != nil {
+		return nil, err
+	}
+	for _, cd := range cdList.Items {
+		objs := objectsIn(cd.Namespace)
+		objs.cds = append(objs.cds, cd)
+	}
+	claimList := &hivev1.ClusterClaimList{}
+	if err := r.List(context.Background(), claimList); err != nil {
+		return nil, err
+	}
+	for _, claim := range claimList.Items {
+		objs := objectsIn(claim.Namespace)
+		objs.claims = append(objs.claims, claim)
+	}
+	clusterPoolList := &hivev1.ClusterPoolList{}
+	if err := r.List(context.Background(), clusterPoolList); err != nil {
+		return nil, err
+	}
+	for _, clusterPool := range clusterPoolList.Items {
+		// ClusterPools are not counted, so they are only needed where there are claims.
+		if objs := byNamespace[clusterPool.Namespace]; objs != nil && len(objs.claims) > 0 {
+			objs.clusterPools = append(objs.clusterPools, clusterPool)
+		}
+	}
+	poolList := &hivev1.MachinePoolList{}
+	if err := r.List(context.Background(), poolList); err != nil {
+		return nil, err
+	}
+	for _, pool := range poolList.Items {
+		objs := objectsIn(pool.Namespace)
+		objs.pools = append(objs.pools, pool)
+	}
+
+	nsList := &corev1.NamespaceList{}
+	if err := r.List(context.Background(), nsList); err != nil {
+		return nil, err
+	}
+	var usages []hivev1.ClusterQuotaNamespaceUsage
+	for _, ns := range nsList.Items {
+		objs := byNamespace[ns.Name]
+		if objs == nil {
+			continue
+		}
+		matches, err := selects(quota, ns.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+		usages = append(usages, namespaceUsage(quota, ns.Name, objs.cds, objs.claims, objs.clusterPools, objs.pools))
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Namespace < usages[j].Namespace })
+	return usages, nil
+}
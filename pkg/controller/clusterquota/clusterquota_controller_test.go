@@ -0,0 +1,113 @@
+package clusterquota
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+	testnamespace "github.com/openshift/hive/pkg/test/namespace"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestReconcileClusterQuota(t *testing.T) {
+	scheme := scheme.GetScheme()
+	tenantNS := testnamespace.FullBuilder(testNamespace, scheme).GenericOptions(testgeneric.WithLabel(tenantLabel, "true")).Build()
+	otherNS := testnamespace.FullBuilder("other", scheme).Build()
+	emptyNS := testnamespace.FullBuilder("empty", scheme).GenericOptions(testgeneric.WithLabel(tenantLabel, "true")).Build()
+	cdBuilder := testcd.FullBuilder(testNamespace, "cd", scheme)
+	claimBuilder := testclaim.FullBuilder(testNamespace, "claim", scheme).Options(testclaim.WithPool("pool"))
+	objects := []runtime.Object{
+		tenantNS, otherNS, emptyNS,
+		cdBuilder.Build(testcd.WithName("cd1")),
+		cdBuilder.Build(testcd.WithName("cd2")),
+		testcd.FullBuilder("other", "cd", scheme).Build(),
+		testcp.FullBuilder(testNamespace, "pool", scheme).Build(testcp.WithClaimFairShare("team", nil)),
+		claimBuilder.GenericOptions(testgeneric.WithName("claim1"), testgeneric.WithLabel("team", "team-a")).Build(),
+		claimBuilder.GenericOptions(testgeneric.WithName("claim2")).Build(testclaim.WithPool("pool2")),
+		claimBuilder.GenericOptions(testgeneric.WithName("claim3"), testgeneric.WithLabel("team", "team-b")).Build(),
+		testmp.FullBuilder(testNamespace, "worker", "cd1", scheme).Build(testmp.WithAWSInstanceType("m5.xlarge"), testmp.WithReplicas(3)),
+	}
+
+	cases := []struct {
+		name              string
+		quota             *hivev1.ClusterQuota
+		expectedUsage     []hivev1.ClusterQuotaNamespaceUsage
+		expectedExceeded  corev1.ConditionStatus
+		expectedCondition string
+	}{
+		{
+			name: "within limits",
+			quota: buildQuota("quota", hivev1.ClusterQuotaSpec{
+				NamespaceSelector:     tenantSelector(),
+				MaxClaimsPerPool:      ptr.To[int32](1),
+				MaxClusterDeployments: ptr.To[int32](2),
+				VCPULimits:            []hivev1.ClusterQuotaVCPULimit{awsLimit},
+			}),
+			expectedUsage: []hivev1.ClusterQuotaNamespaceUsage{{
+				Namespace:          testNamespace,
+				ClusterDeployments: 2,
+				Claims: []hivev1.ClusterQuotaPoolUsage{
+					{ClusterPoolName: "pool", Tenant: "team-a", Claims: 1},
+					{ClusterPoolName: "pool", Tenant: "team-b", Claims: 1},
+					{ClusterPoolName: "pool2", Claims: 1},
+				},
+				VCPUs: []hivev1.ClusterQuotaVCPUUsage{{Platform: "aws", VCPUs: 12}},
+			}},
+			expectedExceeded:  corev1.ConditionFalse,
+			expectedCondition: "WithinLimits",
+		},
+		{
+			name: "exceeded",
+			quota: buildQuota("quota", hivev1.ClusterQuotaSpec{
+				MaxClusterDeployments: ptr.To[int32](1),
+			}),
+			expectedUsage: []hivev1.ClusterQuotaNamespaceUsage{
+				{Namespace: "other", ClusterDeployments: 1},
+				{
+					Namespace:          testNamespace,
+					ClusterDeployments: 2,
+					Claims: []hivev1.ClusterQuotaPoolUsage{
+						{ClusterPoolName: "pool", Tenant: "team-a", Claims: 1},
+						{ClusterPoolName: "pool", Tenant: "team-b", Claims: 1},
+						{ClusterPoolName: "pool2", Claims: 1},
+					},
+				},
+			},
+			expectedExceeded:  corev1.ConditionTrue,
+			expectedCondition: "LimitsExceeded",
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(append(objects, test.quota)...).Build()
+			r := &ReconcileClusterQuota{Client: c, logger: log.WithField("controller", "clusterquota")}
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: test.quota.Name}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			quota := &hivev1.ClusterQuota{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: test.quota.Name}, quota), "could not get quota")
+			assert.Equal(t, test.expectedUsage, quota.Status.Namespaces, "unexpected usage")
+			cond := controllerutils.FindCondition(quota.Status.Conditions, hivev1.ClusterQuotaExceededCondition)
+			if assert.NotNil(t, cond, "missing Exceeded condition") {
+				assert.Equal(t, test.expectedExceeded, cond.Status, "unexpected Exceeded status")
+				assert.Equal(t, test.expectedCondition, cond.Reason, "unexpected Exceeded reason")
+			}
+		})
+	}
+}
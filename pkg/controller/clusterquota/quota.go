@@ -0,0 +1,432 @@
+package clusterquota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// ExceededError indicates that admitting an object would take a namespace over the limit of a ClusterQuota.
+type ExceededError struct {
+	// Quota is the name of the ClusterQuota.
+	Quota string
+	// Reason describes the limit that would be exceeded.
+	Reason string
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("exceeds ClusterQuota %s: %s", e.Quota, e.Reason)
+}
+
+// selects returns whether the quota applies to a namespace with the given labels.
+func selects(quota *hivev1.ClusterQuota, nsLabels labels.Set) (bool, error) {
+	if quota.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(quota.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector in ClusterQuota %s: %w", quota.Name, err)
+	}
+	return selector.Matches(nsLabels), nil
+}
+
+// ForNamespace returns the ClusterQuotas which apply to the named namespace.
+func ForNamespace(c client.Client, namespace string) ([]hivev1.ClusterQuota, error) {
+	quotaList := &hivev1.ClusterQuotaList{}
+	if err := c.List(context.Background(), quotaList); err != nil {
+		return nil, err
+	}
+	if len(quotaList.Items) == 0 {
+		return nil, nil
+	}
+	var nsLabels labels.Set
+	ns := &corev1.Namespace{}
+	switch err := c.Get(context.Background(), types.NamespacedName{Name: namespace}, ns); {
+	case apierrors.IsNotFound(err):
+		// The namespace may not be in the cache yet. Match it as if it had no labels.
+	case err != nil:
+		return nil, err
+	default:
+		nsLabels = ns.Labels
+	}
+	var quotas []hivev1.ClusterQuota
+	for i := range quotaList.Items {
+		matches, err := selects(&quotaList.Items[i], nsLabels)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			quotas = append(quotas, quotaList.Items[i])
+		}
+	}
+	return quotas, nil
+}
+
+// MaxClaimsPerPool returns the lowest MaxClaimsPerPool among the quotas, or nil if none of them sets one.
+func MaxClaimsPerPool(quotas []hivev1.ClusterQuota) *int32 {
+	var limit *int32
+	for i := range quotas {
+		if l := quotas[i].Spec.MaxClaimsPerPool; l != nil && (limit == nil || *l < *limit) {
+			limit = l
+		}
+	}
+	return limit
+}
+
+// MachinePoolPlatform returns the name of the platform of the MachinePool, as returned for ClusterDeployments
+// by GetClusterPlatform.
+func MachinePoolPlatform(pool *hivev1.MachinePool) string {
+	p := pool.Spec.Platform
+	switch {
+	case p.AWS != nil:
+		return constants.PlatformAWS
+	case p.Azure != nil:
+		return constants.PlatformAzure
+	case p.GCP != nil:
+		return constants.PlatformGCP
+	case p.IBMCloud != nil:
+		return constants.PlatformIBMCloud
+	case p.Nutanix != nil:
+		return constants.PlatformNutanix
+	case p.OpenStack != nil:
+		return constants.PlatformOpenStack
+	case p.VSphere != nil:
+		return constants.PlatformVSphere
+	}
+	return constants.PlatformUnknown
+}
+
+// machinePoolInstanceType returns the instance type (or flavor) of the MachinePool, or "" if its machines are sized
+// by CPU count.
+func machinePoolInstanceType(pool *hivev1.MachinePool) string {
+	p := pool.Spec.Platform
+	switch {
+	case p.AWS != nil:
+		return p.AWS.InstanceType
+	case p.Azure != nil:
+		return p.Azure.InstanceType
+	case p.GCP != nil:
+		return p.GCP.InstanceType
+	case p.IBMCloud != nil:
+		return p.IBMCloud.InstanceType
+	case p.OpenStack != nil:
+		return p.OpenStack.Flavor
+	}
+	return ""
+}
+
+// machinePoolMaxReplicas returns the largest number of machines the MachinePool may have.
+func machinePoolMaxReplicas(pool *hivev1.MachinePool) int32 {
+	switch {
+	case pool.Spec.Autoscaling != nil:
+		return pool.Spec.Autoscaling.MaxReplicas
+	case pool.Spec.Replicas != nil:
+		return int32(*pool.Spec.Replicas)
+	}
+	return constants.DefaultMachinePoolReplicas
+}
+
+// MachinePoolVCPUs returns the number of vCPUs the MachinePool consumes at its maximum size, as counted against
+// the limit, and whether that number is known. It is not known for pools whose machine size is given by an instance
+// type the limit doesn't list; those count as zero.
+func MachinePoolVCPUs(pool *hivev1.MachinePool, limit *hivev1.ClusterQuotaVCPULimit) (int32, bool) {
+	if MachinePoolPlatform(pool) != limit.Platform {
+		return 0, true
+	}
+	var perMachine int32
+	p := pool.Spec.Platform
+	switch {
+	case p.VSphere != nil:
+		perMachine = p.VSphere.NumCPUs
+	case p.Nutanix != nil:
+		perMachine = int32(p.Nutanix.NumCPUs)
+		if p.Nutanix.NumCoresPerSocket > 1 {
+			perMachine *= int32(p.Nutanix.NumCoresPerSocket)
+		}
+	default:
+		vcpus, ok := limit.InstanceTypeVCPUs[machinePoolInstanceType(pool)]
+		if !ok {
+			return 0, false
+		}
+		perMachine = vcpus
+	}
+	return perMachine * machinePoolMaxReplicas(pool), true
+}
+
+// machinePoolsVCPUs returns the number of vCPUs counted against the limit for the MachinePools other than the named
+// one. Pools being deleted are not counted.
+func machinePoolsVCPUs(pools []hivev1.MachinePool, limit *hivev1.ClusterQuotaVCPULimit, skip string) int32 {
+	var total int32
+	for i := range pools {
+		if pools[i].Name != skip && pools[i].DeletionTimestamp == nil {
+			vcpus, _ := MachinePoolVCPUs(&pools[i], limit)
+			total += vcpus
+		}
+	}
+	return total
+}
+
+// controlPlaneVCPUs returns the number of vCPUs counted against the limit for the control planes of the
+// ClusterDeployments other than the named one. ClusterDeployments being deleted are not counted.
+func controlPlaneVCPUs(cds []hivev1.ClusterDeployment, limit *hivev1.ClusterQuotaVCPULimit, skip string) int32 {
+	var total int32
+	for i := range cds {
+		if cds[i].Name != skip && cds[i].DeletionTimestamp == nil && controllerutils.GetClusterPlatform(&cds[i]) == limit.Platform {
+			total += limit.ControlPlaneVCPUs
+		}
+	}
+	return total
+}
+
+// namespaceUsage computes the usage counted against the quota from the given objects, all of which must be in the
+// named namespace. Claims are counted by ClusterPool and tenant, per the ClaimFairShare of the pool, if it is among
+// clusterPools. Objects being deleted are not counted.
+func namespaceUsage(quota *hivev1.ClusterQuota, namespace string, cds []hivev1.ClusterDeployment, claims []hivev1.ClusterClaim, clusterPools []hivev1.ClusterPool, pools []hivev1.MachinePool) hivev1.ClusterQuotaNamespaceUsage {
+	usage := hivev1.ClusterQuotaNamespaceUsage{Namespace: namespace}
+	for i := range cds {
+		if cds[i].DeletionTimestamp == nil {
+			usage.ClusterDeployments++
+		}
+	}
+	clusterPoolsByName := map[string]*hivev1.ClusterPool{}
+	for i := range clusterPools {
+		clusterPoolsByName[clusterPools[i].Name] = &clusterPools[i]
+	}
+	type poolTenant struct{ pool, tenant string }
+	claimsByPoolTenant := map[poolTenant]int32{}
+	for i := range claims {
+		if claims[i].DeletionTimestamp == nil {
+			poolName := claims[i].Spec.ClusterPoolName
+			tenant := controllerutils.ClaimTenant(clusterPoolsByName[poolName], &claims[i])
+			claimsByPoolTenant[poolTenant{pool: poolName, tenant: tenant}]++
+		}
+	}
+	for key, count := range claimsByPoolTenant {
+		usage.Claims = append(usage.Claims, hivev1.ClusterQuotaPoolUsage{ClusterPoolName: key.pool, Tenant: key.tenant, Claims: count})
+	}
+	sort.Slice(usage.Claims, func(i, j int) bool {
+		if usage.Claims[i].ClusterPoolName != usage.Claims[j].ClusterPoolName {
+			return usage.Claims[i].ClusterPoolName < usage.Claims[j].ClusterPoolName
+		}
+		return usage.Claims[i].Tenant < usage.Claims[j].Tenant
+	})
+	for i := range quota.Spec.VCPULimits {
+		limit := &quota.Spec.VCPULimits[i]
+		usage.VCPUs = append(usage.VCPUs, hivev1.ClusterQuotaVCPUUsage{
+			Platform: limit.Platform,
+			VCPUs:    machinePoolsVCPUs(pools, limit, "") + controlPlaneVCPUs(cds, limit, ""),
+		})
+	}
+	return usage
+}
+
+// exceeded returns a description of each limit of the quota exceeded by the usage.
+func exceeded(quota *hivev1.ClusterQuota, usage *hivev1.ClusterQuotaNamespaceUsage) []string {
+	var reasons []string
+	if l := quota.Spec.MaxClusterDeployments; l != nil && usage.ClusterDeployments > *l {
+		reasons = append(reasons, fmt.Sprintf("namespace %s has %d ClusterDeployments; the limit is %d", usage.Namespace, usage.ClusterDeployments, *l))
+	}
+	if l := quota.Spec.MaxClaimsPerPool; l != nil {
+		for _, claims := range usage.Claims {
+			if claims.Claims > *l {
+				reasons = append(reasons, fmt.Sprintf("%s has %d ClusterClaims for ClusterPool %s; the limit is %d", claimHolder(usage.Namespace, claims.Tenant), claims.Claims, claims.ClusterPoolName, *l))
+			}
+		}
+	}
+	for i, vcpus := range usage.VCPUs {
+		if l := quota.Spec.VCPULimits[i].MaxVCPUs; vcpus.VCPUs > l {
+			reasons = append(reasons, fmt.Sprintf("namespace %s has %d %s vCPUs; the limit is %d", usage.Namespace, vcpus.VCPUs, vcpus.Platform, l))
+		}
+	}
+	return reasons
+}
+
+// CheckClusterDeployment returns an ExceededError if creating the ClusterDeployment would take its namespace over
+// the MaxClusterDeployments of a ClusterQuota, or, counting its control plane, over a vCPU limit.
+func CheckClusterDeployment(c client.Client, cd *hivev1.ClusterDeployment) error {
+	quotas, err := ForNamespace(c, cd.Namespace)
+	if err != nil {
+		return err
+	}
+	var cdList *hivev1.ClusterDeploymentList
+	var poolList *hivev1.MachinePoolList
+	for i := range quotas {
+		if limit := quotas[i].Spec.MaxClusterDeployments; limit != nil {
+			if cdList == nil {
+				cdList = &hivev1.ClusterDeploymentList{}
+				if err := c.List(context.Background(), cdList, client.InNamespace(cd.Namespace)); err != nil {
+					return err
+				}
+			}
+			var count int32 = 1
+			for j := range cdList.Items {
+				if other := &cdList.Items[j]; other.Name != cd.Name && other.DeletionTimestamp == nil {
+					count++
+				}
+			}
+			if count > *limit {
+				return &ExceededError{
+					Quota:  quotas[i].Name,
+					Reason: fmt.Sprintf("namespace %s may hold at most %d ClusterDeployments", cd.Namespace, *limit),
+				}
+			}
+		}
+		for j := range quotas[i].Spec.VCPULimits {
+			limit := &quotas[i].Spec.VCPULimits[j]
+			if limit.ControlPlaneVCPUs == 0 || controllerutils.GetClusterPlatform(cd) != limit.Platform {
+				continue
+			}
+			if cdList == nil {
+				cdList = &hivev1.ClusterDeploymentList{}
+				if err := c.List(context.Background(), cdList, client.InNamespace(cd.Namespace)); err != nil {
+					return err
+				}
+			}
+			if poolList == nil {
+				poolList = &hivev1.MachinePoolList{}
+				if err := c.List(context.Background(), poolList, client.InNamespace(cd.Namespace)); err != nil {
+					return err
+				}
+			}
+			total := limit.ControlPlaneVCPUs + controlPlaneVCPUs(cdList.Items, limit, cd.Name) + machinePoolsVCPUs(poolList.Items, limit, "")
+			if total > limit.MaxVCPUs {
+				return &ExceededError{
+					Quota:  quotas[i].Name,
+					Reason: fmt.Sprintf("ClusterDeployments in namespace %s would use %d %s vCPUs; the limit is %d", cd.Namespace, total, limit.Platform, limit.MaxVCPUs),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// claimHolder describes the holder of ClusterClaims counted against a MaxClaimsPerPool limit.
+func claimHolder(namespace, tenant string) string {
+	if tenant == "" {
+		return fmt.Sprintf("namespace %s", namespace)
+	}
+	return fmt.Sprintf("tenant %s in namespace %s", tenant, namespace)
+}
+
+// CheckClusterClaim returns an ExceededError if creating the ClusterClaim would take its tenant over the
+// MaxClaimsPerPool of a ClusterQuota. The tenant is identified per the ClaimFairShare of the claim's ClusterPool
+// (see controllerutils.ClaimTenant).
+func CheckClusterClaim(c client.Client, claim *hivev1.ClusterClaim) error {
+	quotas, err := ForNamespace(c, claim.Namespace)
+	if err != nil {
+		return err
+	}
+	limit := MaxClaimsPerPool(quotas)
+	if limit == nil {
+		return nil
+	}
+	var clusterPool *hivev1.ClusterPool
+	cp := &hivev1.ClusterPool{}
+	switch err := c.Get(context.Background(), types.NamespacedName{Namespace: claim.Namespace, Name: claim.Spec.ClusterPoolName}, cp); {
+	case apierrors.IsNotFound(err):
+		// Without the pool, all of its claims are counted as belonging to the same tenant.
+	case err != nil:
+		return err
+	default:
+		clusterPool = cp
+	}
+	tenant := controllerutils.ClaimTenant(clusterPool, claim)
+	claimList := &hivev1.ClusterClaimList{}
+	if err := c.List(context.Background(), claimList, client.InNamespace(claim.Namespace)); err != nil {
+		return err
+	}
+	var count int32 = 1
+	for i := range claimList.Items {
+		other := &claimList.Items[i]
+		if other.Name != claim.Name && other.DeletionTimestamp == nil && other.Spec.ClusterPoolName == claim.Spec.ClusterPoolName &&
+			controllerutils.ClaimTenant(clusterPool, other) == tenant {
+			count++
+		}
+	}
+	if count <= *limit {
+		return nil
+	}
+	// Report the quota imposing the lowest limit.
+	for i := range quotas {
+		if l := quotas[i].Spec.MaxClaimsPerPool; l != nil && *l == *limit {
+			return &ExceededError{
+				Quota:  quotas[i].Name,
+				Reason: fmt.Sprintf("%s may hold at most %d ClusterClaims for ClusterPool %s", claimHolder(claim.Namespace, tenant), *limit, claim.Spec.ClusterPoolName),
+			}
+		}
+	}
+	return nil
+}
+
+// CheckMachinePool returns an ExceededError if creating (oldPool is nil) or updating the MachinePool would take
+// its namespace over a vCPU limit of a ClusterQuota, or if the limit doesn't list the vCPUs of its instance type.
+// Changes which do not increase the vCPUs counted for the pool are always allowed, so a namespace over its limit can
+// still be scaled down.
+func CheckMachinePool(c client.Client, oldPool, newPool *hivev1.MachinePool) error {
+	quotas, err := ForNamespace(c, newPool.Namespace)
+	if err != nil {
+		return err
+	}
+	var poolList *hivev1.MachinePoolList
+	var cdList *hivev1.ClusterDeploymentList
+	for i := range quotas {
+		for j := range quotas[i].Spec.VCPULimits {
+			limit := &quotas[i].Spec.VCPULimits[j]
+			vcpus, known := MachinePoolVCPUs(newPool, limit)
+			if oldPool != nil {
+				oldVCPUs, oldKnown := MachinePoolVCPUs(oldPool, limit)
+				if known && oldKnown && vcpus <= oldVCPUs {
+					continue
+				}
+				// A pool created before its instance type was limited may still be scaled down.
+				if !known && !oldKnown && machinePoolInstanceType(newPool) == machinePoolInstanceType(oldPool) &&
+					machinePoolMaxReplicas(newPool) <= machinePoolMaxReplicas(oldPool) {
+					continue
+				}
+			}
+			if !known {
+				return &ExceededError{
+					Quota:  quotas[i].Name,
+					Reason: fmt.Sprintf("the vCPUs of %s instance type %q used by MachinePool %s are not listed", limit.Platform, machinePoolInstanceType(newPool), newPool.Name),
+				}
+			}
+			if vcpus == 0 {
+				continue
+			}
+			if poolList == nil {
+				poolList = &hivev1.MachinePoolList{}
+				if err := c.List(context.Background(), poolList, client.InNamespace(newPool.Namespace)); err != nil {
+					return err
+				}
+			}
+			total := vcpus + machinePoolsVCPUs(poolList.Items, limit, newPool.Name)
+			if limit.ControlPlaneVCPUs > 0 {
+				if cdList == nil {
+					cdList = &hivev1.ClusterDeploymentList{}
+					if err := c.List(context.Background(), cdList, client.InNamespace(newPool.Namespace)); err != nil {
+						return err
+					}
+				}
+				total += controlPlaneVCPUs(cdList.Items, limit, "")
+			}
+			if total > limit.MaxVCPUs {
+				return &ExceededError{
+					Quota:  quotas[i].Name,
+					Reason: fmt.Sprintf("MachinePools in namespace %s would use %d %s vCPUs; the limit is %d", newPool.Namespace, total, limit.Platform, limit.MaxVCPUs),
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,442 @@
+package clusterquota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+	testnamespace "github.com/openshift/hive/pkg/test/namespace"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testNamespace = "tenant"
+	tenantLabel   = "tenant"
+)
+
+var awsLimit = hivev1.ClusterQuotaVCPULimit{
+	Platform:          "aws",
+	MaxVCPUs:          32,
+	InstanceTypeVCPUs: map[string]int32{"m5.xlarge": 4, "m5.2xlarge": 8},
+}
+
+// controlPlaneLimit counts 12 vCPUs for the control plane of each AWS cluster.
+var controlPlaneLimit = hivev1.ClusterQuotaVCPULimit{
+	Platform:          "aws",
+	MaxVCPUs:          32,
+	InstanceTypeVCPUs: map[string]int32{"m5.xlarge": 4},
+	ControlPlaneVCPUs: 12,
+}
+
+func buildQuota(name string, spec hivev1.ClusterQuotaSpec) *hivev1.ClusterQuota {
+	return &hivev1.ClusterQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+}
+
+func tenantSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: map[string]string{tenantLabel: "true"}}
+}
+
+func TestMachinePoolVCPUs(t *testing.T) {
+	scheme := scheme.GetScheme()
+	poolBuilder := testmp.FullBuilder(testNamespace, "worker", "cd", scheme)
+	cases := []struct {
+		name     string
+		pool     *hivev1.MachinePool
+		limit    hivev1.ClusterQuotaVCPULimit
+		expected int32
+		unknown  bool
+	}{
+		{
+			name:     "aws replicas",
+			pool:     poolBuilder.Build(testmp.WithAWSInstanceType("m5.xlarge"), testmp.WithReplicas(2)),
+			limit:    awsLimit,
+			expected: 8,
+		},
+		{
+			name:     "aws default replicas",
+			pool:     poolBuilder.Build(testmp.WithAWSInstanceType("m5.2xlarge")),
+			limit:    awsLimit,
+			expected: 24,
+		},
+		{
+			name:     "aws autoscaling counts max replicas",
+			pool:     poolBuilder.Build(testmp.WithAWSInstanceType("m5.xlarge"), testmp.WithAutoscaling(1, 5)),
+			limit:    awsLimit,
+			expected: 20,
+		},
+		{
+			name:    "aws unknown instance type",
+			pool:    poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(2)),
+			limit:   awsLimit,
+			unknown: true,
+		},
+		{
+			name:     "other platform",
+			pool:     poolBuilder.Build(testmp.WithAWSInstanceType("m5.xlarge"), testmp.WithReplicas(2)),
+			limit:    hivev1.ClusterQuotaVCPULimit{Platform: "vsphere", MaxVCPUs: 10},
+			expected: 0,
+		},
+		{
+			name: "vsphere",
+			pool: poolBuilder.Build(testmp.WithReplicas(3), func(mp *hivev1.MachinePool) {
+				mp.Spec.Platform.VSphere = &hivev1vsphere.MachinePool{NumCPUs: 4, NumCoresPerSocket: 2}
+			}),
+			limit:    hivev1.ClusterQuotaVCPULimit{Platform: "vsphere", MaxVCPUs: 10},
+			expected: 12,
+		},
+		{
+			name: "nutanix",
+			pool: poolBuilder.Build(testmp.WithReplicas(3), func(mp *hivev1.MachinePool) {
+				mp.Spec.Platform.Nutanix = &hivev1nutanix.MachinePool{NumCPUs: 2, NumCoresPerSocket: 2}
+			}),
+			limit:    hivev1.ClusterQuotaVCPULimit{Platform: "nutanix", MaxVCPUs: 10},
+			expected: 12,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			vcpus, known := MachinePoolVCPUs(test.pool, &test.limit)
+			assert.Equal(t, test.expected, vcpus, "unexpected vCPUs")
+			assert.Equal(t, !test.unknown, known, "unexpected known")
+		})
+	}
+}
+
+func TestForNamespace(t *testing.T) {
+	scheme := scheme.GetScheme()
+	tenantNS := testnamespace.FullBuilder(testNamespace, scheme).GenericOptions(testgeneric.WithLabel(tenantLabel, "true")).Build()
+	otherNS := testnamespace.FullBuilder("other", scheme).Build()
+	quotas := []runtime.Object{
+		buildQuota("all", hivev1.ClusterQuotaSpec{}),
+		buildQuota("tenants", hivev1.ClusterQuotaSpec{NamespaceSelector: tenantSelector()}),
+	}
+	cases := []struct {
+		name      string
+		namespace string
+		expected  []string
+	}{
+		{
+			name:      "selected namespace",
+			namespace: testNamespace,
+			expected:  []string{"all", "tenants"},
+		},
+		{
+			name:      "unselected namespace",
+			namespace: "other",
+			expected:  []string{"all"},
+		},
+		{
+			name:      "missing namespace",
+			namespace: "missing",
+			expected:  []string{"all"},
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(append(quotas, tenantNS, otherNS)...).Build()
+			actual, err := ForNamespace(c, test.namespace)
+			require.NoError(t, err, "unexpected error")
+			var names []string
+			for _, q := range actual {
+				names = append(names, q.Name)
+			}
+			assert.Equal(t, test.expected, names, "unexpected quotas")
+		})
+	}
+}
+
+func TestCheckClusterDeployment(t *testing.T) {
+	scheme := scheme.GetScheme()
+	tenantNS := testnamespace.FullBuilder(testNamespace, scheme).GenericOptions(testgeneric.WithLabel(tenantLabel, "true")).Build()
+	cdBuilder := testcd.FullBuilder(testNamespace, "cd", scheme)
+	cases := []struct {
+		name     string
+		existing []runtime.Object
+		// platform replaces the AWS platform of the ClusterDeployment being created, if set.
+		platform         *hivev1.Platform
+		expectedExceeded bool
+	}{
+		{
+			name: "no quotas",
+			existing: []runtime.Object{
+				cdBuilder.Build(testcd.WithName("cd1")),
+			},
+		},
+		{
+			name: "under limit",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{NamespaceSelector: tenantSelector(), MaxClusterDeployments: ptr.To[int32](2)}),
+				cdBuilder.Build(testcd.WithName("cd1")),
+			},
+		},
+		{
+			name: "at limit",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{NamespaceSelector: tenantSelector(), MaxClusterDeployments: ptr.To[int32](2)}),
+				cdBuilder.Build(testcd.WithName("cd1")),
+				cdBuilder.Build(testcd.WithName("cd2")),
+			},
+			expectedExceeded: true,
+		},
+		{
+			name: "deleting clusters not counted",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{NamespaceSelector: tenantSelector(), MaxClusterDeployments: ptr.To[int32](2)}),
+				cdBuilder.Build(testcd.WithName("cd1")),
+				cdBuilder.GenericOptions(testgeneric.Deleted(), testgeneric.WithFinalizer("test")).Build(testcd.WithName("cd2")),
+			},
+		},
+		{
+			name: "limit in other namespaces",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{
+					NamespaceSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"other": "true"}},
+					MaxClusterDeployments: ptr.To[int32](0),
+				}),
+			},
+		},
+		{
+			name: "control planes under vCPU limit",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{VCPULimits: []hivev1.ClusterQuotaVCPULimit{controlPlaneLimit}}),
+				cdBuilder.Build(testcd.WithName("cd1"), testcd.WithAWSPlatform(&hivev1aws.Platform{})),
+				testmp.FullBuilder(testNamespace, "worker", "cd1", scheme).Build(testmp.WithAWSInstanceType("m5.xlarge"), testmp.WithReplicas(2)),
+			},
+		},
+		{
+			name: "control planes over vCPU limit",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{VCPULimits: []hivev1.ClusterQuotaVCPULimit{controlPlaneLimit}}),
+				cdBuilder.Build(testcd.WithName("cd1"), testcd.WithAWSPlatform(&hivev1aws.Platform{})),
+				testmp.FullBuilder(testNamespace, "worker", "cd1", scheme).Build(testmp.WithAWSInstanceType("m5.xlarge"), testmp.WithReplicas(3)),
+			},
+			expectedExceeded: true,
+		},
+		{
+			name: "control planes on other platforms not counted",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{VCPULimits: []hivev1.ClusterQuotaVCPULimit{controlPlaneLimit}}),
+				cdBuilder.Build(testcd.WithName("cd1"), testcd.WithAWSPlatform(&hivev1aws.Platform{})),
+				cdBuilder.Build(testcd.WithName("cd2"), testcd.WithAWSPlatform(&hivev1aws.Platform{})),
+			},
+			platform: &hivev1.Platform{GCP: &hivev1gcp.Platform{}},
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(append(test.existing, tenantNS)...).Build()
+			cd := cdBuilder.Build(testcd.WithAWSPlatform(&hivev1aws.Platform{}))
+			if test.platform != nil {
+				cd.Spec.Platform = *test.platform
+			}
+			err := CheckClusterDeployment(c, cd)
+			assertExceeded(t, test.expectedExceeded, err)
+		})
+	}
+}
+
+func TestCheckClusterClaim(t *testing.T) {
+	scheme := scheme.GetScheme()
+	claimBuilder := testclaim.FullBuilder(testNamespace, "claim", scheme).Options(testclaim.WithPool("pool"))
+	cases := []struct {
+		name     string
+		existing []runtime.Object
+		// claimTeam is the value of the "team" label of the claim being created, if set.
+		claimTeam        string
+		expectedExceeded bool
+	}{
+		{
+			name: "under limit",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](2)}),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim1")).Build(),
+			},
+		},
+		{
+			name: "at limit",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](2)}),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim1")).Build(),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim2")).Build(),
+			},
+			expectedExceeded: true,
+		},
+		{
+			name: "lowest limit applies",
+			existing: []runtime.Object{
+				buildQuota("loose", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](5)}),
+				buildQuota("strict", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](1)}),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim1")).Build(),
+			},
+			expectedExceeded: true,
+		},
+		{
+			name: "tenants sharing a pool counted separately",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](1)}),
+				testcp.FullBuilder(testNamespace, "pool", scheme).Build(testcp.WithClaimFairShare("team", nil)),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim1"), testgeneric.WithLabel("team", "team-b")).Build(),
+			},
+			claimTeam: "team-a",
+		},
+		{
+			name: "tenant at limit",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](1)}),
+				testcp.FullBuilder(testNamespace, "pool", scheme).Build(testcp.WithClaimFairShare("team", nil)),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim1"), testgeneric.WithLabel("team", "team-a")).Build(),
+			},
+			claimTeam:        "team-a",
+			expectedExceeded: true,
+		},
+		{
+			name: "tenants not distinguished without fair share",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](1)}),
+				testcp.FullBuilder(testNamespace, "pool", scheme).Build(),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim1"), testgeneric.WithLabel("team", "team-b")).Build(),
+			},
+			claimTeam:        "team-a",
+			expectedExceeded: true,
+		},
+		{
+			name: "claims for other pools not counted",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To[int32](1)}),
+				claimBuilder.GenericOptions(testgeneric.WithName("claim1")).Build(testclaim.WithPool("other")),
+			},
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.existing...).Build()
+			claim := claimBuilder.Build()
+			if test.claimTeam != "" {
+				claim.Labels = map[string]string{"team": test.claimTeam}
+			}
+			err := CheckClusterClaim(c, claim)
+			assertExceeded(t, test.expectedExceeded, err)
+		})
+	}
+}
+
+func TestCheckMachinePool(t *testing.T) {
+	scheme := scheme.GetScheme()
+	quota := buildQuota("quota", hivev1.ClusterQuotaSpec{VCPULimits: []hivev1.ClusterQuotaVCPULimit{awsLimit}})
+	poolBuilder := testmp.FullBuilder(testNamespace, "worker", "cd", scheme).Options(testmp.WithAWSInstanceType("m5.xlarge"))
+	otherPool := testmp.FullBuilder(testNamespace, "worker", "other", scheme).Build(testmp.WithAWSInstanceType("m5.2xlarge"), testmp.WithReplicas(2))
+	cases := []struct {
+		name             string
+		existing         []runtime.Object
+		oldPool          *hivev1.MachinePool
+		newPool          *hivev1.MachinePool
+		expectedExceeded bool
+	}{
+		{
+			name:     "create under limit",
+			existing: []runtime.Object{quota, otherPool},
+			newPool:  poolBuilder.Build(testmp.WithReplicas(4)),
+		},
+		{
+			name:             "create over limit",
+			existing:         []runtime.Object{quota, otherPool},
+			newPool:          poolBuilder.Build(testmp.WithReplicas(5)),
+			expectedExceeded: true,
+		},
+		{
+			name:             "scale up over limit",
+			existing:         []runtime.Object{quota, otherPool, poolBuilder.Build(testmp.WithReplicas(4))},
+			oldPool:          poolBuilder.Build(testmp.WithReplicas(4)),
+			newPool:          poolBuilder.Build(testmp.WithAutoscaling(1, 6)),
+			expectedExceeded: true,
+		},
+		{
+			name:     "scale down while over limit",
+			existing: []runtime.Object{quota, otherPool, poolBuilder.Build(testmp.WithReplicas(6))},
+			oldPool:  poolBuilder.Build(testmp.WithReplicas(6)),
+			newPool:  poolBuilder.Build(testmp.WithReplicas(5)),
+		},
+		{
+			name:     "no quotas",
+			existing: []runtime.Object{otherPool},
+			newPool:  poolBuilder.Build(testmp.WithReplicas(100)),
+		},
+		{
+			name:             "create with unlisted instance type",
+			existing:         []runtime.Object{quota},
+			newPool:          poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(1)),
+			expectedExceeded: true,
+		},
+		{
+			name:             "scale up with unlisted instance type",
+			existing:         []runtime.Object{quota, poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(1))},
+			oldPool:          poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(1)),
+			newPool:          poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(2)),
+			expectedExceeded: true,
+		},
+		{
+			name:             "change to unlisted instance type",
+			existing:         []runtime.Object{quota, poolBuilder.Build(testmp.WithReplicas(1))},
+			oldPool:          poolBuilder.Build(testmp.WithReplicas(1)),
+			newPool:          poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(1)),
+			expectedExceeded: true,
+		},
+		{
+			name:     "scale down with unlisted instance type",
+			existing: []runtime.Object{quota, poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(3))},
+			oldPool:  poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(3)),
+			newPool:  poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(2)),
+		},
+		{
+			name: "unlisted instance type on other platform",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{VCPULimits: []hivev1.ClusterQuotaVCPULimit{{Platform: "gcp", MaxVCPUs: 10}}}),
+			},
+			newPool: poolBuilder.Build(testmp.WithAWSInstanceType("c5.large"), testmp.WithReplicas(1)),
+		},
+		{
+			name: "control planes counted",
+			existing: []runtime.Object{
+				buildQuota("quota", hivev1.ClusterQuotaSpec{VCPULimits: []hivev1.ClusterQuotaVCPULimit{controlPlaneLimit}}),
+				testcd.FullBuilder(testNamespace, "cd", scheme).Build(testcd.WithAWSPlatform(&hivev1aws.Platform{})),
+			},
+			newPool:          poolBuilder.Build(testmp.WithReplicas(6)),
+			expectedExceeded: true,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.existing...).Build()
+			err := CheckMachinePool(c, test.oldPool, test.newPool)
+			assertExceeded(t, test.expectedExceeded, err)
+		})
+	}
+}
+
+func assertExceeded(t *testing.T, expected bool, err error) {
+	if !expected {
+		assert.NoError(t, err, "unexpected error")
+		return
+	}
+	if assert.Error(t, err, "expected quota to be exceeded") {
+		assert.IsType(t, &ExceededError{}, err, "unexpected error type")
+	}
+}
@@ -2,10 +2,13 @@ package clusterrelocate
 
 import (
 	"context"
+	"errors"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
 
 type deleteBlockingClientWrapper struct {
@@ -23,3 +26,27 @@ func (c *deleteBlockingClientWrapper) Delete(ctx context.Context, obj client.Obj
 	a.SetDeletionTimestamp(&now)
 	return c.Update(ctx, obj)
 }
+
+type clusterDeploymentCreateFailingClientWrapper struct {
+	client.Client
+}
+
+var _ client.Client = (*clusterDeploymentCreateFailingClientWrapper)(nil)
+
+func (c *clusterDeploymentCreateFailingClientWrapper) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*hivev1.ClusterDeployment); ok {
+		return errors.New("failed to create clusterdeployment")
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// deleteFailingClientWrapper fails deletes, as for a cluster which has become unreachable.
+type deleteFailingClientWrapper struct {
+	client.Client
+}
+
+var _ client.Client = (*deleteFailingClientWrapper)(nil)
+
+func (c *deleteFailingClientWrapper) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return errors.New("connection refused")
+}
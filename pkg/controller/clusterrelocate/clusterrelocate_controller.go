@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -37,6 +38,9 @@ import (
 
 const (
 	ControllerName = hivev1.ClusterRelocateControllerName
+
+	// relocationSlotRequeueInterval is how long a cluster waits for a relocation slot before checking again.
+	relocationSlotRequeueInterval = 30 * time.Second
 )
 
 var (
@@ -298,13 +302,26 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 		return reconcile.Result{}, errors.Wrap(err, "could not create a client for the destination cluster")
 	}
 
+	if desiredRelocate.Spec.DryRun {
+		return reconcile.Result{}, r.planRelocation(cd, desiredRelocate.Name, destClient, logger)
+	}
+
 	switch proceed, completed, err := r.checkForExistingClusterDeployment(cd, destClient, logger); {
 	case err != nil:
 		return reconcile.Result{}, err
 	case completed:
 		return r.finishRelocateCompletion(cd, desiredRelocate.Name, logger)
 	case !proceed:
-		return reconcile.Result{}, nil
+		return reconcile.Result{}, r.setClusterStatus(desiredRelocate.Name, cd, hivev1.ClusterRelocateStateFailed,
+			"The ClusterDeployment in the destination cluster does not match the one being relocated", nil, logger)
+	}
+
+	switch claimed, err := r.claimRelocationSlot(desiredRelocate.Name, cd, logger); {
+	case err != nil:
+		return reconcile.Result{}, err
+	case !claimed:
+		logger.Info("waiting for other clusters to finish relocating")
+		return reconcile.Result{RequeueAfter: relocationSlotRequeueInterval}, nil
 	}
 
 	if err := r.setRelocateAnnotation(cd, desiredRelocate.Name, hivev1.RelocateOutgoing, logger); err != nil {
@@ -312,14 +329,29 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 	}
 
 	// Copy resources to destination cluster
-	if err := r.copy(cd, destClient, logger); err != nil {
+	c := r.newCopier(destClient, false)
+	if err := c.copy(cd, logger); err != nil {
+		message := err.Error()
+		// Remove what was copied so that the destination does not hold a partial copy of the cluster, and restore
+		// the source ClusterDeployment so that it is managed here again until the relocation is retried.
+		if rollbackErr := c.rollback(logger); rollbackErr != nil {
+			message = fmt.Sprintf("%s; rollback failed: %v", message, rollbackErr)
+		} else if clearErr := r.clearRelocateAnnotation(cd, logger); clearErr != nil {
+			message = fmt.Sprintf("%s; restoring the ClusterDeployment failed: %v", message, clearErr)
+		} else {
+			message = fmt.Sprintf("%s; rolled back", message)
+		}
 		r.setRelocationFailedCondition(
 			cd,
 			corev1.ConditionTrue,
 			"MoveFailed",
-			err.Error(),
+			message,
 			logger,
 		)
+		if statusErr := r.setClusterStatus(desiredRelocate.Name, cd, hivev1.ClusterRelocateStateFailed, message, nil, logger); statusErr != nil {
+			logger.WithError(statusErr).Warn("the failed move is not recorded in the ClusterRelocate status until the relocation is retried")
+		}
+		recordMetricForAbortedRelocate(desiredRelocate.Name, "move_failed")
 		// return the move error rather than the update error
 		return reconcile.Result{}, err
 	}
@@ -327,6 +359,28 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 	return r.finishRelocateCompletion(cd, desiredRelocate.Name, logger)
 }
 
+// planRelocation records in the status of the ClusterRelocate which objects relocating the ClusterDeployment would
+// copy to the destination cluster, without copying anything.
+func (r *ReconcileClusterRelocate) planRelocation(cd *hivev1.ClusterDeployment, relocateName string, destClient client.Client, logger log.FieldLogger) error {
+	c := r.newCopier(destClient, true)
+	if err := c.copy(cd, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not plan relocation")
+		return errors.Wrap(err, "could not plan relocation")
+	}
+	counts := map[hivev1.ClusterRelocateObjectAction]int{}
+	for _, obj := range c.plan {
+		counts[obj.Action]++
+	}
+	message := fmt.Sprintf("Dry run: %d to create, %d to replace, %d unchanged, %d conflicting",
+		counts[hivev1.ClusterRelocateObjectCreate],
+		counts[hivev1.ClusterRelocateObjectReplace],
+		counts[hivev1.ClusterRelocateObjectUnchanged],
+		counts[hivev1.ClusterRelocateObjectConflict],
+	)
+	logger.WithField("plan", message).Info("planned relocation")
+	return r.setClusterStatus(relocateName, cd, hivev1.ClusterRelocateStatePlanned, message, c.plan, logger)
+}
+
 // setRelocateAnnotation sets the relocate annotation on the ClusterDeployment as well as on the child DNSZone, if there
 // is one.
 func (r *ReconcileClusterRelocate) setRelocateAnnotation(cd *hivev1.ClusterDeployment, relocateName string, status hivev1.RelocateStatus, logger log.FieldLogger) error {
@@ -408,6 +462,10 @@ func (r *ReconcileClusterRelocate) finishRelocateCompletion(cd *hivev1.ClusterDe
 		return reconcile.Result{}, errors.Wrap(err, "could not delete relocated clusterdeployment")
 	}
 
+	if err := r.setClusterStatus(relocateName, cd, hivev1.ClusterRelocateStateCompleted, "", nil, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	metricSuccessfulClusterRelocations.WithLabelValues(relocateName).Inc()
 
 	return reconcile.Result{}, nil
@@ -428,20 +486,48 @@ func (r *ReconcileClusterRelocate) findMatchingRelocates(cd *hivev1.ClusterDeplo
 				Warn("cannot parse clusterdeployment selector")
 			continue
 		}
-		if labelSelector.Matches(labels.Set(cd.Labels)) {
-			matches = append(matches, &clusterRelocates.Items[i])
+		if !labelSelector.Matches(labels.Set(cd.Labels)) {
+			continue
 		}
+		if owner := metav1.GetControllerOf(&cr); owner != nil && owner.Kind == "HubFederation" {
+			// The ClusterRelocates of a HubFederation select clusters by a label which anyone who can edit a
+			// ClusterDeployment can set, so check that the federation would move the cluster.
+			if candidate, err := r.isHubFederationCandidate(owner.Name, cd); err != nil {
+				return nil, err
+			} else if !candidate {
+				logger.WithField("clusterRelocate", cr.Name).Warn("hub federation does not move the cluster deployment")
+				continue
+			}
+		}
+		matches = append(matches, &clusterRelocates.Items[i])
 	}
 	return matches, nil
 }
 
+// isHubFederationCandidate returns true if the named HubFederation's placement may move the ClusterDeployment.
+func (r *ReconcileClusterRelocate) isHubFederationCandidate(fedName string, cd *hivev1.ClusterDeployment) (bool, error) {
+	fed := &hivev1.HubFederation{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Name: fedName}, fed); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, errors.Wrap(err, "failed to get hubfederation")
+	}
+	candidate, err := controllerutils.IsHubFederationCandidate(fed, cd)
+	return candidate, errors.Wrap(err, "invalid hubfederation placement")
+}
+
 func (r *ReconcileClusterRelocate) stopRelocating(cd *hivev1.ClusterDeployment, currentRelocateName string, logger log.FieldLogger) error {
 	if currentRelocateName == "" {
 		return nil
 	}
 	logger.WithField("clusterRelocate", currentRelocateName).Info("stopping relocation")
 	// TODO: Attempt to clean up resources already relocated
-	return r.clearRelocateAnnotation(cd, logger)
+	if err := r.clearRelocateAnnotation(cd, logger); err != nil {
+		return err
+	}
+	// Free up the cluster's relocation slot
+	return r.removeClusterStatus(currentRelocateName, cd, logger)
 }
 
 // reconcileNoSingleMatch reconciles a ClusterDeployment that does not match with exactly one ClusterRelocate.
@@ -530,37 +616,45 @@ func (r *ReconcileClusterRelocate) checkForExistingClusterDeployment(cd *hivev1.
 	return
 }
 
-func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient client.Client, logger log.FieldLogger) error {
+// copier copies the objects of a ClusterDeployment to the destination cluster. In a dry run, nothing is copied and
+// what would be done with each object is recorded in plan instead.
+type copier struct {
+	*ReconcileClusterRelocate
+	destClient client.Client
+	dryRun     bool
+
+	// created are the objects created in the destination cluster, in order, so that they can be removed if the copy
+	// fails.
+	created []client.Object
+	// plan is what a dry run would do with each object.
+	plan []hivev1.ClusterRelocateObject
+}
+
+func (r *ReconcileClusterRelocate) newCopier(destClient client.Client, dryRun bool) *copier {
+	return &copier{ReconcileClusterRelocate: r, destClient: destClient, dryRun: dryRun}
+}
+
+func (c *copier) copy(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
 	// create namespace
-	switch err := destClient.Create(context.Background(), &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: cd.Namespace,
-		},
-	}); {
-	case apierrors.IsAlreadyExists(err):
-		logger.Info("namespace already exists in destination cluster")
-	case err != nil:
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to create namespace in destination cluster")
-		return errors.Wrap(err, "failed to create namespace in destination cluster")
-	default:
-		logger.Info("namespace created")
+	if err := c.copyNamespace(cd.Namespace, logger); err != nil {
+		return err
 	}
 
 	// copy dependent resources
 	for _, t := range typesToCopy() {
-		if err := r.copyResources(cd, destClient, t.(client.ObjectList), logger); err != nil {
+		if err := c.copyResources(cd, t.(client.ObjectList), logger); err != nil {
 			return errors.Wrapf(err, "failed to copy %T", t)
 		}
 	}
 
 	// copy dnszone
-	dnsZone, err := r.dnsZone(cd, logger)
+	dnsZone, err := c.dnsZone(cd, logger)
 	if err != nil {
 		return errors.Wrap(err, "could not get DNSZone")
 	}
 	if dnsZone != nil {
 		logger = logger.WithField("type", reflect.TypeOf(dnsZone)).WithField("resource", dnsZone.Name)
-		if err := r.copyResource(dnsZone, destClient, false, logger); err != nil {
+		if err := c.copyResource(dnsZone, false, logger); err != nil {
 			return errors.Wrap(err, "failed to copy dnszone")
 		}
 	}
@@ -568,7 +662,7 @@ func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient
 	// copy clusterdeployment
 	{
 		logger := logger.WithField("type", reflect.TypeOf(cd)).WithField("resource", cd.Name)
-		if err := r.copyResource(cd, destClient, true, logger); err != nil {
+		if err := c.copyResource(cd, true, logger); err != nil {
 			return errors.Wrap(err, "failed to copy clusterdeployment")
 		}
 	}
@@ -576,11 +670,42 @@ func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient
 	return nil
 }
 
+func (c *copier) copyNamespace(name string, logger log.FieldLogger) error {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	if c.dryRun {
+		action := hivev1.ClusterRelocateObjectCreate
+		switch err := c.destClient.Get(context.Background(), client.ObjectKeyFromObject(ns), &corev1.Namespace{}); {
+		case err == nil:
+			action = hivev1.ClusterRelocateObjectUnchanged
+		case !apierrors.IsNotFound(err):
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get namespace in destination cluster")
+			return errors.Wrap(err, "failed to get namespace in destination cluster")
+		}
+		c.plan = append(c.plan, hivev1.ClusterRelocateObject{Kind: "Namespace", Name: name, Action: action})
+		return nil
+	}
+	switch err := c.destClient.Create(context.Background(), ns); {
+	case apierrors.IsAlreadyExists(err):
+		logger.Info("namespace already exists in destination cluster")
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to create namespace in destination cluster")
+		return errors.Wrap(err, "failed to create namespace in destination cluster")
+	default:
+		logger.Info("namespace created")
+		c.created = append(c.created, ns)
+	}
+	return nil
+}
+
 // copyResources copies all of the resources of the given object type in the namespace of the ClusterDeployment to the
 // destination cluster
-func (r *ReconcileClusterRelocate) copyResources(cd *hivev1.ClusterDeployment, destClient client.Client, objectList client.ObjectList, logger log.FieldLogger) error {
+func (c *copier) copyResources(cd *hivev1.ClusterDeployment, objectList client.ObjectList, logger log.FieldLogger) error {
 	logger = logger.WithField("type", reflect.TypeOf(objectList))
-	if err := r.List(context.Background(), objectList, client.InNamespace(cd.Namespace)); err != nil {
+	if err := c.List(context.Background(), objectList, client.InNamespace(cd.Namespace)); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list resources")
 		return errors.Wrapf(err, "failed to list %T", objectList)
 	}
@@ -597,21 +722,24 @@ func (r *ReconcileClusterRelocate) copyResources(cd *hivev1.ClusterDeployment, d
 			return errors.Wrapf(err, "could not get object meta for %T", obj)
 		}
 		logger = logger.WithField("resource", objMeta.GetName())
-		switch ignore, err := r.ignoreResource(obj, logger); {
+		switch ignore, err := c.ignoreResource(obj, logger); {
 		case err != nil:
 			return errors.Wrap(err, "could not determine whether to ignore resource")
 		case ignore:
 			logger.Info("resource will not be copied since it is a resource that should be ignored")
 			continue
 		}
-		if err := r.copyResource(obj, destClient, false, logger); err != nil {
+		if err := c.copyResource(obj, false, logger); err != nil {
 			return errors.Wrapf(err, "could not copy %T resource %q", obj, objMeta.GetName())
 		}
 	}
 	return nil
 }
 
-func (r *ReconcileClusterRelocate) copyResource(obj runtime.Object, destClient client.Client, failIfExists bool, logger log.FieldLogger) error {
+func (c *copier) copyResource(obj runtime.Object, failIfExists bool, logger log.FieldLogger) error {
+	if c.dryRun {
+		return c.planResource(obj, logger)
+	}
 	obj, err := prepareForComparison(obj)
 	if err != nil {
 		logger.WithError(err).Error("could not clear fields from source object")
@@ -619,15 +747,16 @@ func (r *ReconcileClusterRelocate) copyResource(obj runtime.Object, destClient c
 	}
 	// Need to use a copy here so that `obj` is left unaltered if the resource already exists on the remote cluster.
 	objToCreate := obj.DeepCopyObject().(client.Object)
-	switch err := destClient.Create(context.Background(), objToCreate); {
+	switch err := c.destClient.Create(context.Background(), objToCreate); {
 	case err == nil:
 		logger.Info("resource created in destination cluster")
+		c.created = append(c.created, objToCreate)
 	case apierrors.IsAlreadyExists(err):
 		if failIfExists {
 			return errors.Wrap(err, "resource already exists in destination cluster")
 		}
 		logger.Info("resource already exists in destination cluster; replacing if there are changes")
-		if err := r.replaceResourceIfChanged(destClient, obj.(client.Object), logger); err != nil {
+		if err := c.replaceResourceIfChanged(obj.(client.Object), logger); err != nil {
 			return errors.Wrap(err, "failed to sync existing resource")
 		}
 	default:
@@ -637,26 +766,80 @@ func (r *ReconcileClusterRelocate) copyResource(obj runtime.Object, destClient c
 	return nil
 }
 
-func (r *ReconcileClusterRelocate) replaceResourceIfChanged(destClient client.Client, srcObj client.Object, logger log.FieldLogger) error {
-	// Get the object from the destination cluster
-	objKey := client.ObjectKeyFromObject(srcObj)
+// planResource records what copyResource would do with the resource.
+func (c *copier) planResource(obj runtime.Object, logger log.FieldLogger) error {
+	obj = obj.DeepCopyObject()
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return errors.Wrap(err, "could not get object meta")
+	}
+	planned := hivev1.ClusterRelocateObject{
+		Kind:   reflect.TypeOf(obj).Elem().Name(),
+		Name:   objMeta.GetName(),
+		Action: hivev1.ClusterRelocateObjectCreate,
+	}
+	switch t := obj.(type) {
+	case *hivev1.ClusterDeployment, *hivev1.DNSZone:
+		// These are only copied while relocating out, which a dry run does not do.
+		controllerutils.SetRelocateAnnotation(t.(hivev1.MetaRuntimeObject), "dry-run", hivev1.RelocateOutgoing)
+	}
+	srcObj, err := prepareForComparison(obj)
+	if err != nil {
+		logger.WithError(err).Error("could not clear fields from source object")
+		return errors.Wrap(err, "could not clear fields from source object")
+	}
 	destObj := reflect.New(reflect.TypeOf(srcObj).Elem()).Interface().(client.Object)
-	if err := destClient.Get(context.Background(), objKey, destObj); err != nil {
+	switch err := c.destClient.Get(context.Background(), client.ObjectKeyFromObject(objMeta.(client.Object)), destObj); {
+	case apierrors.IsNotFound(err):
+		c.plan = append(c.plan, planned)
+		return nil
+	case err != nil:
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get resource from destination cluster")
 		return errors.Wrap(err, "could not get resource from destination cluster")
 	}
+	if destCD, ok := destObj.(*hivev1.ClusterDeployment); ok {
+		// See checkForExistingClusterDeployment
+		planned.Action = hivev1.ClusterRelocateObjectConflict
+		if destCD.Spec.BaseDomain == srcObj.(*hivev1.ClusterDeployment).Spec.BaseDomain {
+			planned.Action = hivev1.ClusterRelocateObjectUnchanged
+		}
+		c.plan = append(c.plan, planned)
+		return nil
+	}
+	switch _, inSync, err := c.compareWithDestination(srcObj.(client.Object), logger); {
+	case err != nil:
+		// The copy would fail to sync the existing resource
+		logger.WithError(err).Info("resource in destination cluster conflicts with the one being relocated")
+		planned.Action = hivev1.ClusterRelocateObjectConflict
+	case inSync:
+		planned.Action = hivev1.ClusterRelocateObjectUnchanged
+	default:
+		planned.Action = hivev1.ClusterRelocateObjectReplace
+	}
+	c.plan = append(c.plan, planned)
+	return nil
+}
+
+// compareWithDestination gets the resource from the destination cluster and reports whether it is in sync with the
+// source resource, which must have been prepared for comparison.
+func (c *copier) compareWithDestination(srcObj client.Object, logger log.FieldLogger) (client.Object, bool, error) {
+	// Get the object from the destination cluster
+	objKey := client.ObjectKeyFromObject(srcObj)
+	destObj := reflect.New(reflect.TypeOf(srcObj).Elem()).Interface().(client.Object)
+	if err := c.destClient.Get(context.Background(), objKey, destObj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get resource from destination cluster")
+		}
+		return nil, false, errors.Wrap(err, "could not get resource from destination cluster")
+	}
 
 	// Prepare a copy of the object in the destination cluster for comparison with the object in the source cluster.
 	clearedDestObj, err := prepareForComparison(destObj)
 	if err != nil {
 		logger.WithError(err).Error("could not clear fields of destination resource")
-		return errors.Wrap(err, "could not clear fields of destination resource")
+		return nil, false, errors.Wrap(err, "could not clear fields of destination resource")
 	}
-	switch t := clearedDestObj.(type) {
-	case *hivev1.ClusterDeployment:
-		logger.Error("attempting to replace a ClusterDeployment")
-		return errors.New("resource already exists in destination cluster")
-	case *hivev1.MachinePool:
+	if t, ok := clearedDestObj.(*hivev1.MachinePool); ok {
 		// The remotemachineset controller in the destination cluster is going to remove its finalizer until the
 		// ClusterDeployment exists in the destination cluster. This will cause the source and destination MachinePools
 		// to have differences requiring a replacement if we do not set the finalizers equal first.
@@ -665,21 +848,36 @@ func (r *ReconcileClusterRelocate) replaceResourceIfChanged(destClient client.Cl
 
 	// Check if there are any meaningful changes between the objects in the source and destination clusters
 	if reflect.DeepEqual(srcObj, clearedDestObj) {
-		// Do nothing. Resource in destination cluster is in sync.
-		return nil
+		return destObj, true, nil
 	}
 	if logger.WithFields(nil).Logger.IsLevelEnabled(log.DebugLevel) {
 		logger.WithField("diff", diff.Diff(srcObj, clearedDestObj)).
 			Debug("resource in destination cluster is out of sync")
 	}
+	return destObj, false, nil
+}
+
+func (c *copier) replaceResourceIfChanged(srcObj client.Object, logger log.FieldLogger) error {
+	if _, ok := srcObj.(*hivev1.ClusterDeployment); ok {
+		logger.Error("attempting to replace a ClusterDeployment")
+		return errors.New("resource already exists in destination cluster")
+	}
+	destObj, inSync, err := c.compareWithDestination(srcObj, logger)
+	if err != nil {
+		return err
+	}
+	if inSync {
+		// Do nothing. Resource in destination cluster is in sync.
+		return nil
+	}
 
 	// Delete the object in the destination cluster and re-create it.
-	if err := destClient.Delete(context.Background(), destObj); err != nil {
+	if err := c.destClient.Delete(context.Background(), destObj); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete out-of-sync resource from destination cluster")
 		return errors.Wrap(err, "could not delete out-of-sync resource from destination cluster")
 	}
 	logger.Info("out-of-date resource deleted in destination cluster")
-	if err := destClient.Create(context.Background(), srcObj); err != nil {
+	if err := c.destClient.Create(context.Background(), srcObj); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create resource in destination cluster")
 		return err
 	}
@@ -687,6 +885,23 @@ func (r *ReconcileClusterRelocate) replaceResourceIfChanged(destClient client.Cl
 	return nil
 }
 
+// rollback deletes the objects created in the destination cluster, newest first. Objects which already existed in
+// the destination cluster are left alone: those replaced because they were out of sync keep the copy from the source
+// cluster, as their previous content is not kept.
+func (c *copier) rollback(logger log.FieldLogger) error {
+	for i := len(c.created) - 1; i >= 0; i-- {
+		obj := c.created[i]
+		logger := logger.WithField("type", reflect.TypeOf(obj)).WithField("resource", obj.GetName())
+		if err := c.destClient.Delete(context.Background(), obj); err != nil && !apierrors.IsNotFound(err) {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete resource from destination cluster")
+			return errors.Wrapf(err, "could not delete %T %q from destination cluster", obj, obj.GetName())
+		}
+		logger.Info("resource deleted from destination cluster")
+	}
+	c.created = nil
+	return nil
+}
+
 func prepareForComparison(obj runtime.Object) (runtime.Object, error) {
 	obj = obj.DeepCopyObject()
 
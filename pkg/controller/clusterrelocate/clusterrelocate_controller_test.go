@@ -16,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -84,6 +85,15 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 	)
 	jobBuilder := testjob.FullBuilder(namespace, "test-job", scheme)
 	namespaceBuilder := testnamespace.FullBuilder(namespace, scheme)
+	federation := &hivev1.HubFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-federation", UID: "test-federation-uid"},
+		Spec: hivev1.HubFederationSpec{
+			HubName: "test-hub",
+			Placement: &hivev1.HubFederationPlacement{
+				ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{labelKey: labelValue}},
+			},
+		},
+	}
 
 	cases := []struct {
 		name                string
@@ -811,6 +821,63 @@ func TestReconcileClusterRelocate_Reconcile_Movement(t *testing.T) {
 				crBuilder.Build(testcr.WithClusterDeploymentSelector("other-key", "other-value")),
 			},
 		},
+		{
+			name: "hub federation moves cluster",
+			cd: cdBuilder.Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.RelocationFailedCondition,
+				Status: corev1.ConditionUnknown,
+			})),
+			srcResources: []runtime.Object{
+				federation,
+				crBuilder.Build(testcr.Generic(testgeneric.WithControllerOwnerReference(federation))),
+			},
+			expectedResources: []client.Object{
+				namespaceBuilder.Build(),
+				cdBuilder.Build(
+					testcd.Installed(),
+					testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateIncoming)),
+				),
+			},
+		},
+		{
+			name: "hub federation does not move pool cluster",
+			cd: cdBuilder.Build(testcd.Installed(), testcd.WithClusterPoolReference(namespace, "test-pool", "test-claim"),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.RelocationFailedCondition,
+					Status: corev1.ConditionUnknown,
+				})),
+			srcResources: []runtime.Object{
+				federation,
+				crBuilder.Build(testcr.Generic(testgeneric.WithControllerOwnerReference(federation))),
+			},
+			unexpectedResources: []client.Object{
+				namespaceBuilder.Build(),
+				cdBuilder.Build(),
+			},
+		},
+		{
+			name: "hub federation does not move cluster outside placement",
+			cd: cdBuilder.Build(testcd.Installed(), testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.RelocationFailedCondition,
+				Status: corev1.ConditionUnknown,
+			})),
+			srcResources: []runtime.Object{
+				&hivev1.HubFederation{
+					ObjectMeta: federation.ObjectMeta,
+					Spec: hivev1.HubFederationSpec{
+						HubName: "test-hub",
+						Placement: &hivev1.HubFederationPlacement{
+							ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{"other-key": "other-value"}},
+						},
+					},
+				},
+				crBuilder.Build(testcr.Generic(testgeneric.WithControllerOwnerReference(federation))),
+			},
+			unexpectedResources: []client.Object{
+				namespaceBuilder.Build(),
+				cdBuilder.Build(),
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1255,6 +1322,232 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterRelocate_Reconcile_ClusterStatus(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := scheme.GetScheme()
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).GenericOptions(
+		testgeneric.WithLabel(labelKey, labelValue),
+	).Options(
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.RelocationFailedCondition,
+			Status: corev1.ConditionUnknown,
+		}),
+	)
+	crBuilder := testcr.FullBuilder(crName, scheme).Options(
+		testcr.WithKubeconfigSecret(kubeconfigNamespace, kubeconfigName),
+		testcr.WithClusterDeploymentSelector(labelKey, labelValue),
+	)
+	secretBuilder := testsecret.FullBuilder(namespace, "test-secret", scheme)
+	otherRelocating := hivev1.ClusterRelocateClusterStatus{
+		Namespace: namespace,
+		Name:      "other-cluster",
+		State:     hivev1.ClusterRelocateStateRelocating,
+	}
+
+	cases := []struct {
+		name           string
+		relocate       *hivev1.ClusterRelocate
+		srcResources   []runtime.Object
+		destResources  []runtime.Object
+		failCreatingCD bool
+		// failDeleting makes deletes in the destination fail, so that rollback fails.
+		failDeleting        bool
+		expectedError       bool
+		expectOutgoing      bool
+		expectRequeue       bool
+		expectDeleted       bool
+		expectedState       hivev1.ClusterRelocateClusterState
+		expectedObjects     []hivev1.ClusterRelocateObject
+		expectedDestSecrets []string
+	}{
+		{
+			name: "dry run",
+			relocate: crBuilder.Build(func(cr *hivev1.ClusterRelocate) {
+				cr.Spec.DryRun = true
+			}),
+			srcResources: []runtime.Object{
+				secretBuilder.Build(testsecret.WithName("new-secret")),
+				secretBuilder.Build(testsecret.WithName("existing-secret")),
+				secretBuilder.Build(testsecret.WithName("out-of-date-secret")),
+			},
+			destResources: []runtime.Object{
+				testnamespace.FullBuilder(namespace, scheme).Build(),
+				secretBuilder.Build(testsecret.WithName("existing-secret")),
+				secretBuilder.Build(
+					testsecret.WithName("out-of-date-secret"),
+					testsecret.WithDataKeyValue("other-key", []byte("other-data")),
+				),
+			},
+			expectedState: hivev1.ClusterRelocateStatePlanned,
+			expectedObjects: []hivev1.ClusterRelocateObject{
+				{Kind: "Namespace", Name: namespace, Action: hivev1.ClusterRelocateObjectUnchanged},
+				{Kind: "Secret", Name: "existing-secret", Action: hivev1.ClusterRelocateObjectUnchanged},
+				{Kind: "Secret", Name: "new-secret", Action: hivev1.ClusterRelocateObjectCreate},
+				{Kind: "Secret", Name: "out-of-date-secret", Action: hivev1.ClusterRelocateObjectReplace},
+				{Kind: "ClusterDeployment", Name: cdName, Action: hivev1.ClusterRelocateObjectCreate},
+			},
+			expectedDestSecrets: []string{"existing-secret", "out-of-date-secret"},
+		},
+		{
+			name: "dry run with conflicting clusterdeployment",
+			relocate: crBuilder.Build(func(cr *hivev1.ClusterRelocate) {
+				cr.Spec.DryRun = true
+			}),
+			destResources: []runtime.Object{
+				cdBuilder.Build(func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.BaseDomain = "other-domain"
+				}),
+			},
+			expectedState: hivev1.ClusterRelocateStatePlanned,
+			expectedObjects: []hivev1.ClusterRelocateObject{
+				{Kind: "Namespace", Name: namespace, Action: hivev1.ClusterRelocateObjectCreate},
+				{Kind: "ClusterDeployment", Name: cdName, Action: hivev1.ClusterRelocateObjectConflict},
+			},
+		},
+		{
+			name: "max concurrent reached",
+			relocate: crBuilder.Build(func(cr *hivev1.ClusterRelocate) {
+				cr.Spec.MaxConcurrent = ptr.To[int32](1)
+				cr.Status.Clusters = []hivev1.ClusterRelocateClusterStatus{otherRelocating}
+			}),
+			srcResources: []runtime.Object{
+				secretBuilder.Build(),
+			},
+			expectRequeue: true,
+			expectedState: hivev1.ClusterRelocateStatePending,
+		},
+		{
+			name: "max concurrent not reached",
+			relocate: crBuilder.Build(func(cr *hivev1.ClusterRelocate) {
+				cr.Spec.MaxConcurrent = ptr.To[int32](2)
+				cr.Status.Clusters = []hivev1.ClusterRelocateClusterStatus{otherRelocating}
+			}),
+			srcResources: []runtime.Object{
+				secretBuilder.Build(),
+			},
+			expectDeleted:       true,
+			expectedState:       hivev1.ClusterRelocateStateCompleted,
+			expectedDestSecrets: []string{"test-secret"},
+		},
+		{
+			name:     "rollback",
+			relocate: crBuilder.Build(),
+			srcResources: []runtime.Object{
+				secretBuilder.Build(testsecret.WithName("new-secret")),
+				secretBuilder.Build(testsecret.WithName("existing-secret")),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(testsecret.WithName("existing-secret")),
+			},
+			failCreatingCD:      true,
+			expectedError:       true,
+			expectedState:       hivev1.ClusterRelocateStateFailed,
+			expectedDestSecrets: []string{"existing-secret"},
+		},
+		{
+			name:     "rollback against unreachable destination",
+			relocate: crBuilder.Build(),
+			srcResources: []runtime.Object{
+				secretBuilder.Build(testsecret.WithName("new-secret")),
+				secretBuilder.Build(testsecret.WithName("existing-secret")),
+			},
+			destResources: []runtime.Object{
+				secretBuilder.Build(testsecret.WithName("existing-secret")),
+			},
+			failCreatingCD:      true,
+			failDeleting:        true,
+			expectedError:       true,
+			expectOutgoing:      true,
+			expectedState:       hivev1.ClusterRelocateStateFailed,
+			expectedDestSecrets: []string{"existing-secret", "new-secret"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeconfigSecret := testsecret.FullBuilder(kubeconfigNamespace, "test-kubeconfig", scheme).Build(
+				testsecret.WithDataKeyValue("kubeconfig", []byte("some-kubeconfig-data")),
+			)
+			srcResources := append(tc.srcResources, cdBuilder.Build(), tc.relocate, kubeconfigSecret)
+			srcClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(srcResources...).Build()
+			var destClient client.Client = testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.destResources...).Build()
+			if tc.failCreatingCD {
+				destClient = &clusterDeploymentCreateFailingClientWrapper{Client: destClient}
+			}
+			if tc.failDeleting {
+				destClient = &deleteFailingClientWrapper{Client: destClient}
+			}
+
+			mockCtrl := gomock.NewController(t)
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(destClient, nil).AnyTimes()
+
+			reconciler := &ReconcileClusterRelocate{
+				Client: srcClient,
+				logger: logger,
+				remoteClusterAPIClientBuilder: func(secret *corev1.Secret, cn hivev1.ControllerName) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      cdName,
+					Namespace: namespace,
+				},
+			})
+			if tc.expectedError {
+				require.Error(t, err, "expected error during reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error during reconcile")
+			}
+			assert.Equal(t, tc.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			relocate := &hivev1.ClusterRelocate{}
+			require.NoError(t, srcClient.Get(context.Background(), client.ObjectKey{Name: crName}, relocate), "unexpected error fetching clusterrelocate")
+			var clusterStatus *hivev1.ClusterRelocateClusterStatus
+			for i, cs := range relocate.Status.Clusters {
+				if cs.Name == cdName {
+					clusterStatus = &relocate.Status.Clusters[i]
+				}
+			}
+			if assert.NotNil(t, clusterStatus, "missing cluster status") {
+				assert.Equal(t, tc.expectedState, clusterStatus.State, "unexpected cluster state")
+				assert.ElementsMatch(t, tc.expectedObjects, clusterStatus.Objects, "unexpected planned objects")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = srcClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			if tc.expectDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected clusterdeployment to be deleted")
+			} else if assert.NoError(t, err, "unexpected error fetching clusterdeployment") {
+				if tc.expectOutgoing {
+					assert.Equal(t, fmt.Sprintf("%s/%s", crName, hivev1.RelocateOutgoing), cd.Annotations[constants.RelocateAnnotation], "unexpected relocate annotation on clusterdeployment")
+					cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.RelocationFailedCondition)
+					if assert.NotNil(t, cond, "missing RelocationFailed condition") {
+						assert.Contains(t, cond.Message, "rollback failed", "unexpected RelocationFailed message")
+					}
+				} else {
+					assert.NotContains(t, cd.Annotations, constants.RelocateAnnotation, "unexpected relocate annotation on clusterdeployment")
+				}
+			}
+
+			destSecrets := &corev1.SecretList{}
+			require.NoError(t, destClient.List(context.Background(), destSecrets, client.InNamespace(namespace)), "unexpected error listing destination secrets")
+			var destSecretNames []string
+			for _, secret := range destSecrets.Items {
+				destSecretNames = append(destSecretNames, secret.Name)
+			}
+			assert.ElementsMatch(t, tc.expectedDestSecrets, destSecretNames, "unexpected secrets in destination")
+			if tc.failCreatingCD && !tc.failDeleting {
+				assert.True(t, apierrors.IsNotFound(destClient.Get(context.Background(), client.ObjectKey{Name: namespace}, &corev1.Namespace{})),
+					"expected namespace to be removed from destination")
+			}
+		})
+	}
+}
+
 func withRelocateAnnotation(clusterRelocateName string, status hivev1.RelocateStatus) testgeneric.Option {
 	return testgeneric.WithAnnotation(
 		constants.RelocateAnnotation,
@@ -0,0 +1,131 @@
+package clusterrelocate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// updateClusterStatus applies mutate to the status of the ClusterDeployment in the ClusterRelocate, creating it if
+// needed, and updates the ClusterRelocate if the status changed.
+// The update is retried on conflicts, since the ClusterDeployments of a ClusterRelocate are reconciled concurrently.
+// A ClusterRelocate which does not exist, e.g. on the destination side of a relocation, is ignored.
+func (r *ReconcileClusterRelocate) updateClusterStatus(relocateName string, cd *hivev1.ClusterDeployment, logger log.FieldLogger, mutate func(*hivev1.ClusterRelocate, *hivev1.ClusterRelocateClusterStatus)) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		relocate := &hivev1.ClusterRelocate{}
+		if err := r.Get(context.Background(), client.ObjectKey{Name: relocateName}, relocate); err != nil {
+			return err
+		}
+		index := -1
+		for i, cs := range relocate.Status.Clusters {
+			if cs.Namespace == cd.Namespace && cs.Name == cd.Name {
+				index = i
+				break
+			}
+		}
+		clusterStatus := &hivev1.ClusterRelocateClusterStatus{Namespace: cd.Namespace, Name: cd.Name}
+		if index >= 0 {
+			clusterStatus = relocate.Status.Clusters[index].DeepCopy()
+		}
+		mutate(relocate, clusterStatus)
+		if index >= 0 {
+			if reflect.DeepEqual(clusterStatus, &relocate.Status.Clusters[index]) {
+				return nil
+			}
+			relocate.Status.Clusters[index] = *clusterStatus
+		} else {
+			relocate.Status.Clusters = append(relocate.Status.Clusters, *clusterStatus)
+		}
+		return r.Status().Update(context.Background(), relocate)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update clusterrelocate status")
+		return errors.Wrap(err, "could not update clusterrelocate status")
+	}
+	return nil
+}
+
+// setClusterStatus sets the state of the relocation of the ClusterDeployment in the status of the ClusterRelocate.
+func (r *ReconcileClusterRelocate) setClusterStatus(relocateName string, cd *hivev1.ClusterDeployment, state hivev1.ClusterRelocateClusterState, message string, objects []hivev1.ClusterRelocateObject, logger log.FieldLogger) error {
+	return r.updateClusterStatus(relocateName, cd, logger, func(_ *hivev1.ClusterRelocate, cs *hivev1.ClusterRelocateClusterStatus) {
+		setState(cs, state, message)
+		cs.Objects = objects
+	})
+}
+
+// claimRelocationSlot marks the ClusterDeployment as relocating in the status of the ClusterRelocate, unless
+// MaxConcurrent other ClusterDeployments are already relocating, in which case it is marked as pending and false is
+// returned. Since the status is updated with optimistic concurrency, two ClusterDeployments cannot claim the last
+// slot at once.
+func (r *ReconcileClusterRelocate) claimRelocationSlot(relocateName string, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
+	claimed := false
+	err := r.updateClusterStatus(relocateName, cd, logger, func(relocate *hivev1.ClusterRelocate, cs *hivev1.ClusterRelocateClusterStatus) {
+		claimed = true
+		if maxConcurrent := relocate.Spec.MaxConcurrent; maxConcurrent != nil {
+			relocating := 0
+			for _, other := range relocate.Status.Clusters {
+				if other.State == hivev1.ClusterRelocateStateRelocating && (other.Namespace != cd.Namespace || other.Name != cd.Name) {
+					relocating++
+				}
+			}
+			claimed = relocating < int(*maxConcurrent)
+		}
+		if claimed {
+			setState(cs, hivev1.ClusterRelocateStateRelocating, "")
+		} else {
+			setState(cs, hivev1.ClusterRelocateStatePending, fmt.Sprintf("Waiting for fewer than %d clusters to be relocating", *relocate.Spec.MaxConcurrent))
+		}
+		cs.Objects = nil
+	})
+	return claimed, err
+}
+
+// removeClusterStatus removes the ClusterDeployment from the status of the ClusterRelocate.
+func (r *ReconcileClusterRelocate) removeClusterStatus(relocateName string, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		relocate := &hivev1.ClusterRelocate{}
+		if err := r.Get(context.Background(), client.ObjectKey{Name: relocateName}, relocate); err != nil {
+			return err
+		}
+		clusters := relocate.Status.Clusters[:0]
+		for _, cs := range relocate.Status.Clusters {
+			if cs.Namespace != cd.Namespace || cs.Name != cd.Name {
+				clusters = append(clusters, cs)
+			}
+		}
+		if len(clusters) == len(relocate.Status.Clusters) {
+			return nil
+		}
+		relocate.Status.Clusters = clusters
+		return r.Status().Update(context.Background(), relocate)
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update clusterrelocate status")
+		return errors.Wrap(err, "could not update clusterrelocate status")
+	}
+	return nil
+}
+
+func setState(cs *hivev1.ClusterRelocateClusterStatus, state hivev1.ClusterRelocateClusterState, message string) {
+	if cs.State != state {
+		cs.LastTransitionTime = metav1.Now()
+	}
+	cs.State = state
+	cs.Message = message
+}
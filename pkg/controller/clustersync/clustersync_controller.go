@@ -50,9 +50,13 @@ const (
 	labelApply             = "apply"
 	labelCreateOrUpdate    = "createOrUpdate"
 	labelCreateOnly        = "createOnly"
+	labelServerSideApply   = "serverSideApply"
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
 	stsName                = hivev1.DeploymentNameClustersync
+
+	// defaultFieldManager is the field manager used for server-side apply when the syncset does not set one.
+	defaultFieldManager = "hive-" + string(ControllerName)
 )
 
 var (
@@ -391,6 +395,9 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	needToDoFullReapply := needToCreateLease || needToRenew
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
+	// Syncsets in waves after the earliest incomplete wave are held back until it is complete.
+	blockingWave, _ := getBlockingWave(syncSets, clusterSync.Status.SyncSets, selectorSyncSets, clusterSync.Status.SelectorSyncSets)
+
 	// Apply SyncSets
 	syncStatusesForSyncSets, syncSetsNeedRequeue := r.applySyncSets(
 		cd,
@@ -398,6 +405,7 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		syncSets,
 		clusterSync.Status.SyncSets,
 		needToDoFullReapply,
+		blockingWave,
 		false, // no need to report SelectorSyncSet metrics if we're reconciling non-selector SyncSets
 		resourceHelper,
 		logger,
@@ -411,12 +419,22 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		selectorSyncSets,
 		clusterSync.Status.SelectorSyncSets,
 		needToDoFullReapply,
+		blockingWave,
 		clusterSync.Status.FirstSuccessTime == nil, // only report SelectorSyncSet metrics if we haven't reached first success
 		resourceHelper,
 		logger,
 	)
 	clusterSync.Status.SelectorSyncSets = syncStatusesForSelectorSyncSets
 
+	// Fake clusters hold no resources to check.
+	if !controllerutils.IsFakeCluster(cd) {
+		updateReadiness(syncSets, syncStatusesForSyncSets, blockingWave, resourceHelper, logger)
+		updateReadiness(selectorSyncSets, syncStatusesForSelectorSyncSets, blockingWave, resourceHelper, logger)
+	}
+	newBlockingWave, blockingMessage := getBlockingWave(syncSets, syncStatusesForSyncSets, selectorSyncSets, syncStatusesForSelectorSyncSets)
+	clusterSync.Status.BlockingWave = newBlockingWave
+	clusterSync.Status.BlockingMessage = blockingMessage
+
 	setFailedCondition(clusterSync)
 
 	// Set clusterSync.Status.FirstSyncSetsSuccessTime
@@ -458,8 +476,15 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilRenew(lease)}
-	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
+	switch {
+	case syncSetsNeedRequeue || selectorSyncSetsNeedRequeue:
+		result.RequeueAfter = 0
+	case blockingWave != nil && (newBlockingWave == nil || *newBlockingWave != *blockingWave):
+		logger.WithField("wave", *blockingWave).Info("sync wave completed; applying later waves")
 		result.RequeueAfter = 0
+	case newBlockingWave != nil && result.RequeueAfter > waveRecheckInterval:
+		logger.WithField("wave", *newBlockingWave).Debug("sync wave is blocking later waves")
+		result.RequeueAfter = waveRecheckInterval
 	}
 	return result, nil
 }
@@ -470,6 +495,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 	syncSets []CommonSyncSet,
 	syncStatuses []hiveintv1alpha1.SyncStatus,
 	needToDoFullReapply bool,
+	blockingWave *int32,
 	reportSelectorSyncSetMetrics bool,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
@@ -521,6 +547,14 @@ func (r *ReconcileClusterSync) applySyncSets(
 		logger := logger.WithField(syncSetType, syncSet.AsMetaObject().GetName())
 		oldSyncStatus, indexOfOldStatus := getOldSyncStatus(syncSet, syncStatuses)
 
+		if isHeld(syncSet, blockingWave) {
+			logger.WithField("wave", syncSet.GetSpec().Wave).Debug("not applying syncset until earlier sync waves are complete")
+			if indexOfOldStatus >= 0 {
+				newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			}
+			continue
+		}
+
 		// Determine if the syncset needs to be applied
 		switch {
 		case needToDoFullReapply:
@@ -537,12 +571,19 @@ func (r *ReconcileClusterSync) applySyncSets(
 			continue
 		}
 
+		// Fake clusters hold no resources to compare.
+		checkDrift := shouldDetectDrift(syncSet, oldSyncStatus, indexOfOldStatus) && !controllerutils.IsFakeCluster(cd)
+
 		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, cd, resourceHelper, logger)
+		resourcesApplied, resourcesInSyncSet, drift, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, cd, checkDrift, resourceHelper, logger)
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
 			Name:               syncSet.AsMetaObject().GetName(),
 			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
 			Result:             hiveintv1alpha1.SuccessSyncSetResult,
+			Drift:              drift,
+		}
+		if len(drift) > 0 {
+			setDriftDetectionTimes(newSyncStatus.Drift, oldSyncStatus.Drift)
 		}
 		applyMode := syncSet.GetSpec().ResourceApplyMode
 		if applyMode == hivev1.SyncResourceApplyMode {
@@ -649,11 +690,13 @@ func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.S
 func (r *ReconcileClusterSync) applySyncSet(
 	syncSet CommonSyncSet,
 	cd *hivev1.ClusterDeployment,
+	checkDrift bool,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
 ) (
 	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
 	resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference,
+	drift []hiveintv1alpha1.SyncResourceDrift,
 	requeue bool,
 	returnErr error,
 ) {
@@ -674,10 +717,39 @@ func (r *ReconcileClusterSync) applySyncSet(
 	case hivev1.CreateOnlySyncSetApplyBehavior:
 		applyFn = resourceHelper.Create
 		applyFnMetricsLabel = labelCreateOnly
+	case hivev1.ServerSideApplySyncSetApplyBehavior:
+		fieldManager, force := defaultFieldManager, false
+		if ssa := syncSet.GetSpec().ServerSideApply; ssa != nil {
+			if ssa.FieldManager != "" {
+				fieldManager = ssa.FieldManager
+			}
+			force = ssa.ForceConflicts
+		}
+		applyFn = func(obj []byte) (resource.ApplyResult, error) {
+			return resourceHelper.ServerSideApply(obj, fieldManager, force)
+		}
+		applyFnMetricsLabel = labelServerSideApply
 	}
 
 	// Apply Resources
 	for i, resource := range resources {
+		if checkDrift {
+			logger := logger.WithField("resourceIndex", i)
+			resourceDrift, err := detectDrift(resource, referencesToResources[i], resourceHelper, logger)
+			if err != nil {
+				// Not being able to compare the resource should not stop it being applied.
+				logger.WithError(err).Warn("could not check resource for drift")
+			}
+			if resourceDrift != nil {
+				if syncSet.GetSpec().DriftPolicy == hivev1.BlockSyncSetDriftPolicy {
+					logger.Info("not reapplying resource which differs from the cluster")
+					drift = append(drift, *resourceDrift)
+					continue
+				}
+				resourceDrift.Reverted = syncSet.GetSpec().ApplyBehavior != hivev1.CreateOnlySyncSetApplyBehavior
+				drift = append(drift, *resourceDrift)
+			}
+		}
 		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
 		if returnErr != nil {
 			resourcesApplied = referencesToResources[:i]
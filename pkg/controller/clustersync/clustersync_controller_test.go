@@ -228,6 +228,13 @@ func areSyncStatusesEqual(t *testing.T, syncSetType string, expectedStatuses, ac
 			hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d to have LastTransitionTime of now", syncSetType, i)
 			expectedStatuses[i].LastTransitionTime = actual
 		}
+		for j, drift := range expectedStatus.Drift {
+			if drift.DetectionTime.IsZero() && j < len(actualStatuses[i].Drift) {
+				actual := actualStatuses[i].Drift[j].DetectionTime
+				hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d drift %d to have DetectionTime of now", syncSetType, i, j)
+				expectedStatuses[i].Drift[j].DetectionTime = actual
+			}
+		}
 		if expectedStatus.FirstSuccessTime != nil && expectedStatus.FirstSuccessTime.IsZero() {
 			if actualStatuses[i].FirstSuccessTime != nil {
 				actual := actualStatuses[i].FirstSuccessTime
@@ -601,6 +608,392 @@ func TestReconcileClusterSync_Reapply(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_DriftDetection(t *testing.T) {
+	liveConfigMap := func(data map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"namespace":       "dest-namespace",
+				"name":            "dest-name",
+				"resourceVersion": "123",
+			},
+			"data": data,
+		}}
+	}
+	notFound := apierrors.NewNotFound(corev1.Resource("configmaps"), "dest-name")
+	drifted := hiveintv1alpha1.SyncResourceDrift{
+		Resource: testConfigMapRef("dest-namespace", "dest-name"),
+		Fields:   []string{"data.key"},
+	}
+	reverted := drifted
+	reverted.Reverted = true
+	missing := hiveintv1alpha1.SyncResourceDrift{
+		Resource: testConfigMapRef("dest-namespace", "dest-name"),
+		Missing:  true,
+	}
+	blockedInThePast := drifted
+	blockedInThePast.DetectionTime = timeInThePast
+
+	cases := []struct {
+		name          string
+		driftPolicy   hivev1.SyncSetDriftPolicy
+		generation    int64
+		oldDrift      []hiveintv1alpha1.SyncResourceDrift
+		live          *unstructured.Unstructured
+		getErr        error
+		expectGet     bool
+		expectApply   bool
+		expectedDrift []hiveintv1alpha1.SyncResourceDrift
+	}{
+		{
+			name:        "drift ignored by default",
+			expectApply: true,
+		},
+		{
+			name:        "report without drift",
+			driftPolicy: hivev1.ReportSyncSetDriftPolicy,
+			live:        liveConfigMap(map[string]any{"key": "value"}),
+			expectGet:   true,
+			expectApply: true,
+		},
+		{
+			name:          "report drift",
+			driftPolicy:   hivev1.ReportSyncSetDriftPolicy,
+			live:          liveConfigMap(map[string]any{"key": "edited"}),
+			expectGet:     true,
+			expectApply:   true,
+			expectedDrift: []hiveintv1alpha1.SyncResourceDrift{reverted},
+		},
+		{
+			name:          "block drift",
+			driftPolicy:   hivev1.BlockSyncSetDriftPolicy,
+			live:          liveConfigMap(map[string]any{"key": "edited"}),
+			expectGet:     true,
+			expectedDrift: []hiveintv1alpha1.SyncResourceDrift{drifted},
+		},
+		{
+			name:          "block drift keeps detection time",
+			driftPolicy:   hivev1.BlockSyncSetDriftPolicy,
+			oldDrift:      []hiveintv1alpha1.SyncResourceDrift{blockedInThePast},
+			live:          liveConfigMap(map[string]any{"key": "edited"}),
+			expectGet:     true,
+			expectedDrift: []hiveintv1alpha1.SyncResourceDrift{blockedInThePast},
+		},
+		{
+			name:          "block deleted resource",
+			driftPolicy:   hivev1.BlockSyncSetDriftPolicy,
+			getErr:        notFound,
+			expectGet:     true,
+			expectedDrift: []hiveintv1alpha1.SyncResourceDrift{missing},
+		},
+		{
+			name:        "error getting resource",
+			driftPolicy: hivev1.BlockSyncSetDriftPolicy,
+			getErr:      errors.New("get failed"),
+			expectGet:   true,
+			expectApply: true,
+		},
+		{
+			name:        "changed syncset is not checked",
+			driftPolicy: hivev1.BlockSyncSetDriftPolicy,
+			generation:  2,
+			expectApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := scheme.GetScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			resourceToApply.Data = map[string]string{"key": "value"}
+			generation := tc.generation
+			if generation == 0 {
+				generation = 1
+			}
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(generation),
+				testsyncset.WithDriftPolicy(tc.driftPolicy),
+				testsyncset.WithResources(resourceToApply),
+			)
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(
+					testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+						withTransitionInThePast(),
+						withFirstSuccessTimeInThePast(),
+						withDrift(tc.oldDrift...),
+					)),
+				),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				buildSyncLease(time.Now().Add(-3 * time.Hour)),
+			}
+			rt := newReconcileTest(mockCtrl, existing...)
+			if tc.expectGet {
+				rt.mockResourceHelper.EXPECT().Get("v1", "ConfigMap", "dest-namespace", "dest-name").Return(tc.live, tc.getErr)
+			}
+			if tc.expectApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.ConfiguredApplyResult, nil)
+			}
+			expectedStatus := buildSyncStatus("test-syncset",
+				withObservedGeneration(generation),
+				withFirstSuccessTimeInThePast(),
+				withDrift(tc.expectedDrift...),
+			)
+			if reflect.DeepEqual(tc.oldDrift, tc.expectedDrift) && generation == 1 {
+				expectedStatus.LastTransitionTime = timeInThePast
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{expectedStatus}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_Waves(t *testing.T) {
+	deploymentRef := hiveintv1alpha1.SyncResourceReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  "operator-namespace",
+		Name:       "operator",
+	}
+	deployment := func(available string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"status": map[string]any{
+				"conditions": []any{
+					map[string]any{"type": "Progressing", "status": "True"},
+					map[string]any{"type": "Available", "status": available},
+				},
+			},
+		}}
+	}
+	cases := []struct {
+		name                   string
+		operatorStatus         *hiveintv1alpha1.SyncStatus
+		live                   *unstructured.Unstructured
+		getErr                 error
+		expectOperatorApply    bool
+		expectGet              bool
+		expectCRApply          bool
+		expectedUnready        []hiveintv1alpha1.SyncResourceReference
+		expectedBlockingWave   *int32
+		expectImmediateRequeue bool
+		// Zero means the requeue for the next full reapply.
+		expectedRequeueAfter time.Duration
+	}{
+		{
+			name:                 "later wave held until earlier wave is applied",
+			live:                 deployment("False"),
+			expectOperatorApply:  true,
+			expectGet:            true,
+			expectedUnready:      []hiveintv1alpha1.SyncResourceReference{deploymentRef},
+			expectedBlockingWave: ptr.To[int32](0),
+			expectedRequeueAfter: waveRecheckInterval,
+		},
+		{
+			name: "later wave held until earlier wave is ready",
+			operatorStatus: ptr.To(buildSyncStatus("operator",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withUnreadyResources(deploymentRef),
+			)),
+			getErr:               apierrors.NewNotFound(corev1.Resource("deployments"), "operator"),
+			expectGet:            true,
+			expectedUnready:      []hiveintv1alpha1.SyncResourceReference{deploymentRef},
+			expectedBlockingWave: ptr.To[int32](0),
+			expectedRequeueAfter: waveRecheckInterval,
+		},
+		{
+			name: "ready wave releases later wave",
+			operatorStatus: ptr.To(buildSyncStatus("operator",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+				withUnreadyResources(deploymentRef),
+			)),
+			live:                   deployment("True"),
+			expectGet:              true,
+			expectImmediateRequeue: true,
+		},
+		{
+			name: "later wave applied once earlier wave is complete",
+			operatorStatus: ptr.To(buildSyncStatus("operator",
+				withTransitionInThePast(),
+				withFirstSuccessTimeInThePast(),
+			)),
+			live:          deployment("True"),
+			expectGet:     true,
+			expectCRApply: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := scheme.GetScheme()
+			operatorResource := testConfigMap("operator-namespace", "operator-config")
+			crResource := testConfigMap("cr-namespace", "cr")
+			operatorSyncSet := testsyncset.FullBuilder(testNamespace, "operator", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(operatorResource),
+				testsyncset.WithReadinessChecks(hivev1.SyncResourceReadinessCheck{
+					APIVersion: deploymentRef.APIVersion,
+					Kind:       deploymentRef.Kind,
+					Namespace:  deploymentRef.Namespace,
+					Name:       deploymentRef.Name,
+				}),
+			)
+			crSelectorSyncSet := testselectorsyncset.FullBuilder("cr", scheme).Build(
+				testselectorsyncset.WithLabelSelector("test-label-key", "test-label-value"),
+				testselectorsyncset.WithGeneration(1),
+				testselectorsyncset.WithWave(1),
+				testselectorsyncset.WithResources(crResource),
+			)
+			var csOpts []testcs.Option
+			if tc.operatorStatus != nil {
+				csOpts = append(csOpts, testcs.WithSyncSetStatus(*tc.operatorStatus))
+			}
+			existing := []runtime.Object{
+				cdBuilder(scheme).Build(testcd.WithLabel("test-label-key", "test-label-value")),
+				clusterSyncBuilder(scheme).Build(csOpts...),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				operatorSyncSet,
+				crSelectorSyncSet,
+				buildSyncLease(time.Now()),
+			}
+			rt := newReconcileTest(mockCtrl, existing...)
+			if tc.expectOperatorApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(operatorResource)).Return(resource.CreatedApplyResult, nil)
+			}
+			if tc.expectGet {
+				rt.mockResourceHelper.EXPECT().Get("apps/v1", "Deployment", "operator-namespace", "operator").Return(tc.live, tc.getErr)
+			}
+			if tc.expectCRApply {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(crResource)).Return(resource.CreatedApplyResult, nil)
+			}
+
+			result, err := rt.r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testCDName}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			switch {
+			case tc.expectImmediateRequeue:
+				assert.Zero(t, result.RequeueAfter, "expected immediate requeue")
+			case tc.expectedRequeueAfter != 0:
+				assert.Equal(t, tc.expectedRequeueAfter, result.RequeueAfter, "unexpected requeue after")
+			default:
+				assert.Greater(t, result.RequeueAfter, waveRecheckInterval, "expected requeue for full reapply")
+			}
+
+			clusterSync := &hiveintv1alpha1.ClusterSync{}
+			require.NoError(t, rt.c.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync), "unexpected error getting ClusterSync")
+			if assert.Len(t, clusterSync.Status.SyncSets, 1, "expected status for operator syncset") {
+				assert.Equal(t, hiveintv1alpha1.SuccessSyncSetResult, clusterSync.Status.SyncSets[0].Result, "unexpected operator syncset result")
+				assert.Equal(t, tc.expectedUnready, clusterSync.Status.SyncSets[0].UnreadyResources, "unexpected unready resources")
+			}
+			if tc.expectCRApply {
+				assert.Len(t, clusterSync.Status.SelectorSyncSets, 1, "expected status for CR selectorsyncset")
+			} else {
+				assert.Empty(t, clusterSync.Status.SelectorSyncSets, "expected CR selectorsyncset to be held")
+			}
+			assert.Equal(t, tc.expectedBlockingWave, clusterSync.Status.BlockingWave, "unexpected blocking wave")
+			if tc.expectedBlockingWave != nil {
+				assert.Equal(t, "waiting for SyncSet operator", clusterSync.Status.BlockingMessage, "unexpected blocking message")
+			}
+		})
+	}
+}
+
+func TestDriftedFields(t *testing.T) {
+	cases := []struct {
+		name     string
+		desired  string
+		live     string
+		expected []string
+	}{
+		{
+			name:    "equal",
+			desired: `{"spec": {"replicas": 1, "selector": {"app": "foo"}}}`,
+			live:    `{"spec": {"replicas": 1, "selector": {"app": "foo"}}, "status": {"ready": 1}}`,
+		},
+		{
+			name:    "defaulted fields are ignored",
+			desired: `{"spec": {"containers": [{"name": "foo"}]}}`,
+			live:    `{"spec": {"containers": [{"name": "foo", "imagePullPolicy": "Always"}], "restartPolicy": "Always"}}`,
+		},
+		{
+			name:     "changed fields",
+			desired:  `{"metadata": {"labels": {"app": "foo"}}, "spec": {"replicas": 1, "paused": false}}`,
+			live:     `{"metadata": {"labels": {"app": "bar"}}, "spec": {"replicas": 3, "paused": false}}`,
+			expected: []string{"metadata.labels.app", "spec.replicas"},
+		},
+		{
+			name:     "removed field",
+			desired:  `{"data": {"a": "1", "b": "2"}}`,
+			live:     `{"data": {"a": "1"}}`,
+			expected: []string{"data.b"},
+		},
+		{
+			name:     "list length changed",
+			desired:  `{"spec": {"ports": [{"port": 80}, {"port": 443}]}}`,
+			live:     `{"spec": {"ports": [{"port": 80}]}}`,
+			expected: []string{"spec.ports"},
+		},
+		{
+			name:     "list element changed",
+			desired:  `{"spec": {"ports": [{"port": 80}, {"port": 443}]}}`,
+			live:     `{"spec": {"ports": [{"port": 80}, {"port": 8443}]}}`,
+			expected: []string{"spec.ports[1].port"},
+		},
+		{
+			name:    "null desired field is ignored",
+			desired: `{"metadata": {"creationTimestamp": null}}`,
+			live:    `{"metadata": {"creationTimestamp": "2020-01-02T03:04:05Z"}}`,
+		},
+		{
+			name:    "empty desired fields dropped by the server",
+			desired: `{"metadata": {"annotations": {}}, "spec": {"ports": []}}`,
+			live:    `{"metadata": {"name": "foo"}, "spec": {}}`,
+		},
+		{
+			name:    "normalized quantities",
+			desired: `{"spec": {"limits": {"memory": "1024Mi", "cpu": "1000m"}}}`,
+			live:    `{"spec": {"limits": {"memory": "1Gi", "cpu": "1"}}}`,
+		},
+		{
+			name:     "changed quantity",
+			desired:  `{"spec": {"limits": {"memory": "1Gi"}}}`,
+			live:     `{"spec": {"limits": {"memory": "2Gi"}}}`,
+			expected: []string{"spec.limits.memory"},
+		},
+		{
+			name:    "integer and float",
+			desired: `{"spec": {"weight": 1.0}}`,
+			live:    `{"spec": {"weight": 1}}`,
+		},
+		{
+			name:     "number and string",
+			desired:  `{"spec": {"weight": 1}}`,
+			live:     `{"spec": {"weight": "1"}}`,
+			expected: []string{"spec.weight"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			desired, live := &unstructured.Unstructured{}, &unstructured.Unstructured{}
+			require.NoError(t, json.Unmarshal([]byte(tc.desired), &desired.Object), "could not unmarshal desired")
+			require.NoError(t, json.Unmarshal([]byte(tc.live), &live.Object), "could not unmarshal live")
+			assert.Equal(t, tc.expected, driftedFields(desired.Object, live.Object), "unexpected drifted fields")
+		})
+	}
+}
+
 func TestReconcileClusterSync_NewSyncSetApplied(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	scheme := scheme.GetScheme()
@@ -1596,7 +1989,11 @@ func TestReconcileClusterSync_DeleteErrorDoesNotBlockOtherDeletes(t *testing.T)
 
 func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 	cases := []struct {
-		applyBehavior hivev1.SyncSetApplyBehavior
+		name                 string
+		applyBehavior        hivev1.SyncSetApplyBehavior
+		serverSideApply      *hivev1.SyncSetServerSideApply
+		expectedFieldManager string
+		expectedForce        bool
 	}{
 		{
 			applyBehavior: hivev1.ApplySyncSetApplyBehavior,
@@ -1607,9 +2004,24 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 		{
 			applyBehavior: hivev1.CreateOrUpdateSyncSetApplyBehavior,
 		},
+		{
+			applyBehavior:        hivev1.ServerSideApplySyncSetApplyBehavior,
+			expectedFieldManager: "hive-clustersync",
+		},
+		{
+			name:                 "ServerSideApply with options",
+			applyBehavior:        hivev1.ServerSideApplySyncSetApplyBehavior,
+			serverSideApply:      &hivev1.SyncSetServerSideApply{FieldManager: "my-manager", ForceConflicts: true},
+			expectedFieldManager: "my-manager",
+			expectedForce:        true,
+		},
 	}
 	for _, tc := range cases {
-		t.Run(string(tc.applyBehavior), func(t *testing.T) {
+		name := tc.name
+		if name == "" {
+			name = string(tc.applyBehavior)
+		}
+		t.Run(name, func(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			scheme := scheme.GetScheme()
 			resourceToApply := testConfigMap("resource-namespace", "resource-name")
@@ -1617,6 +2029,7 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 				testsyncset.ForClusterDeployments(testCDName),
 				testsyncset.WithGeneration(1),
 				testsyncset.WithApplyBehavior(tc.applyBehavior),
+				testsyncset.WithServerSideApply(tc.serverSideApply),
 				testsyncset.WithResources(resourceToApply),
 				testsyncset.WithSecrets(
 					testSecretMapping("test-secret", "secret-namespace", "secret-name"),
@@ -1654,6 +2067,9 @@ func TestReconcileClusterSync_ApplyBehavior(t *testing.T) {
 			case hivev1.CreateOrUpdateSyncSetApplyBehavior:
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
 				rt.mockResourceHelper.EXPECT().CreateOrUpdate(newApplyMatcher(secretToApply)).Return(resource.CreatedApplyResult, nil)
+			case hivev1.ServerSideApplySyncSetApplyBehavior:
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(resourceToApply), tc.expectedFieldManager, tc.expectedForce).Return(resource.CreatedApplyResult, nil)
+				rt.mockResourceHelper.EXPECT().ServerSideApply(newApplyMatcher(secretToApply), tc.expectedFieldManager, tc.expectedForce).Return(resource.CreatedApplyResult, nil)
 			}
 			rt.mockResourceHelper.EXPECT().Patch(
 				types.NamespacedName{Namespace: "patch-namespace", Name: "patch-name"},
@@ -2560,3 +2976,15 @@ func withFirstSuccessTime(firstSuccessTime metav1.Time) syncStatusOption {
 		syncStatus.FirstSuccessTime = &firstSuccessTime
 	}
 }
+
+func withDrift(drift ...hiveintv1alpha1.SyncResourceDrift) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.Drift = drift
+	}
+}
+
+func withUnreadyResources(resources ...hiveintv1alpha1.SyncResourceReference) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.UnreadyResources = resources
+	}
+}
@@ -0,0 +1,167 @@
+package clustersync
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// detectDrift compares a resource with its counterpart on the cluster. It returns nil if they do not differ.
+func detectDrift(
+	desired *unstructured.Unstructured,
+	reference hiveintv1alpha1.SyncResourceReference,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (*hiveintv1alpha1.SyncResourceDrift, error) {
+	live, err := resourceHelper.Get(reference.APIVersion, reference.Kind, reference.Namespace, reference.Name)
+	switch {
+	case apierrors.IsNotFound(err):
+		logger.Info("resource has been deleted from the cluster")
+		return &hiveintv1alpha1.SyncResourceDrift{Resource: reference, Missing: true}, nil
+	case err != nil:
+		return nil, err
+	}
+	fields := driftedFields(desired.Object, live.Object)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	logger.WithField("fields", fields).Info("resource differs from the cluster")
+	return &hiveintv1alpha1.SyncResourceDrift{Resource: reference, Fields: fields}, nil
+}
+
+// driftedFields returns the paths of the fields set in desired which have a different value in live. Fields which are
+// only set in live, such as defaulted fields and status, are not compared. Values are compared after normalization,
+// since the API server stores some of them in a different form than they were applied in.
+func driftedFields(desired, live map[string]any) []string {
+	var fields []string
+	compareFields("", desired, live, &fields)
+	return fields
+}
+
+func compareFields(path string, desired, live any, fields *[]string) {
+	switch d := desired.(type) {
+	case nil:
+		return
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			// Empty maps are dropped by the API server
+			if len(d) > 0 || live != nil {
+				*fields = append(*fields, path)
+			}
+			return
+		}
+		keys := make([]string, 0, len(d))
+		for k := range d {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			compareFields(fieldPath, d[k], l[k], fields)
+		}
+	case []any:
+		l, ok := live.([]any)
+		if !ok && len(d) == 0 && live == nil {
+			// Empty lists are dropped by the API server
+			return
+		}
+		if !ok || len(l) != len(d) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range d {
+			compareFields(fmt.Sprintf("%s[%d]", path, i), d[i], l[i], fields)
+		}
+	default:
+		if !equalValues(desired, live) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+// equalValues returns true if two scalar values are equal once normalized: numbers are compared by value, whether they
+// were decoded as integers or floats, and strings which are both quantities, such as "1Gi" and "1024Mi", are compared
+// as quantities, since the API server stores quantities in their canonical form.
+func equalValues(desired, live any) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	if d, ok := toFloat(desired); ok {
+		l, ok := toFloat(live)
+		return ok && d == l
+	}
+	if d, ok := desired.(string); ok {
+		l, ok := live.(string)
+		if !ok {
+			return false
+		}
+		dq, err := k8sresource.ParseQuantity(d)
+		if err != nil {
+			return false
+		}
+		lq, err := k8sresource.ParseQuantity(l)
+		return err == nil && dq.Cmp(lq) == 0
+	}
+	return false
+}
+
+// toFloat converts the numeric types found in unstructured objects to a float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// shouldDetectDrift returns true if the resources of a syncset should be compared with the cluster before they are
+// applied. This is only done when the syncset was last applied successfully and has not changed since, so that any
+// differences were made on the cluster.
+func shouldDetectDrift(syncSet CommonSyncSet, oldSyncStatus hiveintv1alpha1.SyncStatus, indexOfOldStatus int) bool {
+	switch syncSet.GetSpec().DriftPolicy {
+	case hivev1.ReportSyncSetDriftPolicy, hivev1.BlockSyncSetDriftPolicy:
+	default:
+		return false
+	}
+	return indexOfOldStatus >= 0 &&
+		oldSyncStatus.Result == hiveintv1alpha1.SuccessSyncSetResult &&
+		oldSyncStatus.ObservedGeneration == syncSet.AsMetaObject().GetGeneration()
+}
+
+// setDriftDetectionTimes sets the detection time of each drift, keeping the time from the previous status for
+// resources which were already found to differ and were left as they were.
+func setDriftDetectionTimes(drift, oldDrift []hiveintv1alpha1.SyncResourceDrift) {
+	now := metav1.Now()
+	for i := range drift {
+		drift[i].DetectionTime = now
+		for _, old := range oldDrift {
+			if old.Resource == drift[i].Resource && !old.Reverted {
+				drift[i].DetectionTime = old.DetectionTime
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package clustersync
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// waveRecheckInterval is how often a cluster is reconciled while a sync wave is holding back later waves, to check
+// whether its resources have become ready.
+const waveRecheckInterval = 30 * time.Second
+
+// isHeld returns true if a syncset is in a wave after the blocking wave, and so must not be applied yet.
+func isHeld(syncSet CommonSyncSet, blockingWave *int32) bool {
+	return blockingWave != nil && syncSet.GetSpec().Wave > *blockingWave
+}
+
+// isSyncSetComplete returns true if the current generation of a syncset has been applied successfully and its
+// resources are ready.
+func isSyncSetComplete(syncSet CommonSyncSet, syncStatuses []hiveintv1alpha1.SyncStatus) bool {
+	status, index := getOldSyncStatus(syncSet, syncStatuses)
+	return index >= 0 &&
+		status.Result == hiveintv1alpha1.SuccessSyncSetResult &&
+		status.ObservedGeneration == syncSet.AsMetaObject().GetGeneration() &&
+		len(status.UnreadyResources) == 0
+}
+
+// getBlockingWave returns the earliest sync wave with syncsets which are not complete, if there are syncsets in later
+// waves for it to hold back, along with a message naming its incomplete syncsets.
+func getBlockingWave(
+	syncSets []CommonSyncSet,
+	syncSetStatuses []hiveintv1alpha1.SyncStatus,
+	selectorSyncSets []CommonSyncSet,
+	selectorSyncSetStatuses []hiveintv1alpha1.SyncStatus,
+) (*int32, string) {
+	var blockingWave *int32
+	var lastWave int32
+	incomplete := map[int32][]string{}
+	check := func(syncSetType string, syncSets []CommonSyncSet, syncStatuses []hiveintv1alpha1.SyncStatus) {
+		for _, syncSet := range syncSets {
+			wave := syncSet.GetSpec().Wave
+			if wave > lastWave {
+				lastWave = wave
+			}
+			if isSyncSetComplete(syncSet, syncStatuses) {
+				continue
+			}
+			incomplete[wave] = append(incomplete[wave], fmt.Sprintf("%s %s", syncSetType, syncSet.AsMetaObject().GetName()))
+			if blockingWave == nil || wave < *blockingWave {
+				blockingWave = &wave
+			}
+		}
+	}
+	check("SyncSet", syncSets, syncSetStatuses)
+	check("SelectorSyncSet", selectorSyncSets, selectorSyncSetStatuses)
+	if blockingWave == nil || *blockingWave == lastWave {
+		return nil, ""
+	}
+	names := incomplete[*blockingWave]
+	sort.Strings(names)
+	return blockingWave, fmt.Sprintf("waiting for %s", strings.Join(names, ", "))
+}
+
+// updateReadiness runs the readiness checks of the syncsets which were not held back, recording the resources which
+// are not ready in their sync statuses. Syncsets which were not applied successfully are not checked.
+func updateReadiness(
+	syncSets []CommonSyncSet,
+	syncStatuses []hiveintv1alpha1.SyncStatus,
+	blockingWave *int32,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) {
+	for _, syncSet := range syncSets {
+		if len(syncSet.GetSpec().ReadinessChecks) == 0 || isHeld(syncSet, blockingWave) {
+			continue
+		}
+		_, index := getOldSyncStatus(syncSet, syncStatuses)
+		if index < 0 || syncStatuses[index].Result != hiveintv1alpha1.SuccessSyncSetResult {
+			continue
+		}
+		logger := logger.WithField("syncSet", syncSet.AsMetaObject().GetName())
+		var unready []hiveintv1alpha1.SyncResourceReference
+		for _, check := range syncSet.GetSpec().ReadinessChecks {
+			if !isResourceReady(check, resourceHelper, logger) {
+				unready = append(unready, hiveintv1alpha1.SyncResourceReference{
+					APIVersion: check.APIVersion,
+					Kind:       check.Kind,
+					Namespace:  check.Namespace,
+					Name:       check.Name,
+				})
+			}
+		}
+		if !reflect.DeepEqual(syncStatuses[index].UnreadyResources, unready) {
+			syncStatuses[index].UnreadyResources = unready
+			syncStatuses[index].LastTransitionTime = metav1.Now()
+		}
+	}
+}
+
+// isResourceReady returns true if the resource of a readiness check exists on the cluster and has a status condition
+// of the check's type with status "True".
+func isResourceReady(check hivev1.SyncResourceReadinessCheck, resourceHelper resource.Helper, logger log.FieldLogger) bool {
+	logger = logger.WithField("resourceNamespace", check.Namespace).
+		WithField("resourceName", check.Name).
+		WithField("resourceAPIVersion", check.APIVersion).
+		WithField("resourceKind", check.Kind)
+	obj, err := resourceHelper.Get(check.APIVersion, check.Kind, check.Namespace, check.Name)
+	switch {
+	case apierrors.IsNotFound(err):
+		logger.Debug("resource for readiness check does not exist")
+		return false
+	case err != nil:
+		logger.WithError(err).Warn("could not get resource for readiness check")
+		return false
+	}
+	conditionType := check.ConditionType
+	if conditionType == "" {
+		conditionType = defaultReadinessConditionType(check.Kind)
+	}
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok || cond["type"] != conditionType {
+			continue
+		}
+		ready := cond["status"] == "True"
+		logger.WithField("condition", conditionType).WithField("ready", ready).Debug("checked resource readiness")
+		return ready
+	}
+	logger.WithField("condition", conditionType).Debug("resource does not have readiness condition")
+	return false
+}
+
+func defaultReadinessConditionType(kind string) string {
+	switch kind {
+	case "CustomResourceDefinition":
+		return "Established"
+	case "Deployment":
+		return "Available"
+	default:
+		return "Ready"
+	}
+}
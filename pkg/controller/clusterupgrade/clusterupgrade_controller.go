@@ -0,0 +1,377 @@
+package clusterupgrade
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterUpgradeControllerName
+)
+
+// Add creates a new ClusterUpgrade Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterUpgrade {
+	return &ReconcileClusterUpgrade{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterUpgrade, concurrentReconciles int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) error {
+	// Create a new controller
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterUpgrades
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterUpgrade{}, &handler.TypedEnqueueRequestForObject[*hivev1.ClusterUpgrade]{})); err != nil {
+		return err
+	}
+
+	// The clusterversion controller reports the progress of each cluster's upgrade in its ClusterDeployment.
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}, handler.TypedEnqueueRequestsFromMapFunc(
+		r.requestsForClusterDeployment))); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForClusterDeployment returns the ClusterUpgrades which select or are upgrading the ClusterDeployment.
+func (r *ReconcileClusterUpgrade) requestsForClusterDeployment(ctx context.Context, cd *hivev1.ClusterDeployment) []reconcile.Request {
+	upgradeList := &hivev1.ClusterUpgradeList{}
+	if err := r.List(context.Background(), upgradeList); err != nil {
+		r.logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list ClusterUpgrades")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, upgrade := range upgradeList.Items {
+		selects := cd.Annotations[constants.ClusterUpgradeAnnotation] == upgrade.Name
+		if !selects {
+			selector, err := metav1.LabelSelectorAsSelector(&upgrade.Spec.ClusterDeploymentSelector)
+			if err != nil {
+				continue
+			}
+			selects = selector.Matches(labels.Set(cd.Labels))
+		}
+		if selects {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: upgrade.Name}})
+		}
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterUpgrade{}
+
+// ReconcileClusterUpgrade reconciles a ClusterUpgrade object by setting spec.upgrade on the ClusterDeployments it
+// selects, a few at a time. The upgrades themselves are requested from the clusters by the clusterversion controller.
+type ReconcileClusterUpgrade struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// clusterUpgrade is a ClusterDeployment selected by a ClusterUpgrade.
+type clusterUpgrade struct {
+	cd      *hivev1.ClusterDeployment
+	status  hivev1.ClusterUpgradeClusterStatus
+	started bool
+}
+
+// inFlight returns true if the cluster's upgrade has been started and has neither completed nor is failing. Failing
+// upgrades may never recover, so they do not hold back the rollout unless PauseOnFailure is set.
+func (c *clusterUpgrade) inFlight() bool {
+	return c.started && c.status.State != hivev1.ClusterUpgradeStateCompleted && !c.failing()
+}
+
+// failing returns true if the cluster's upgrade has been started and is failing or blocked.
+func (c *clusterUpgrade) failing() bool {
+	return c.started && (c.status.State == hivev1.ClusterUpgradeStateFailed || c.status.State == hivev1.ClusterUpgradeStateBlocked)
+}
+
+// Reconcile starts the upgrade of the next clusters selected by a ClusterUpgrade and records the progress of the
+// rollout in its status.
+func (r *ReconcileClusterUpgrade) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterUpgrade", request.NamespacedName)
+	logger.Info("reconciling cluster upgrade")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	upgrade := &hivev1.ClusterUpgrade{}
+	switch err := r.Get(context.Background(), request.NamespacedName, upgrade); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster upgrade not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting cluster upgrade")
+		return reconcile.Result{}, err
+	}
+
+	if upgrade.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	clusters, conflicts, err := r.selectClusters(upgrade, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not select clusters")
+		return reconcile.Result{}, err
+	}
+
+	var canaries, fleet []*clusterUpgrade
+	var failing []string
+	inFlight := 0
+	for _, c := range clusters {
+		if c.status.Canary {
+			canaries = append(canaries, c)
+		} else {
+			fleet = append(fleet, c)
+		}
+		if c.inFlight() {
+			inFlight++
+		}
+		if c.failing() {
+			failing = append(failing, fmt.Sprintf("%s/%s", c.status.Namespace, c.status.Name))
+		}
+	}
+
+	pausedStatus, pausedReason, pausedMessage := corev1.ConditionFalse, "NotPaused", "Upgrades are being started"
+	switch {
+	case upgrade.Spec.Paused:
+		pausedStatus, pausedReason, pausedMessage = corev1.ConditionTrue, "Paused", "The ClusterUpgrade is paused"
+	case upgrade.Spec.PauseOnFailure && len(failing) > 0:
+		pausedStatus, pausedReason, pausedMessage = corev1.ConditionTrue, "UpgradeFailing",
+			fmt.Sprintf("Upgrades are failing for: %s", strings.Join(failing, ", "))
+	}
+
+	// The rest of the fleet waits for all the canaries to complete.
+	phase, candidates := hivev1.ClusterUpgradePhaseCanary, canaries
+	if allCompleted(canaries) {
+		phase, candidates = hivev1.ClusterUpgradePhaseFleet, fleet
+	}
+	if pausedStatus == corev1.ConditionTrue {
+		phase = hivev1.ClusterUpgradePhasePaused
+	} else {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(
+			intstr.ValueOrDefault(upgrade.Spec.MaxUnavailable, intstr.FromInt32(1)), len(clusters), true)
+		if err != nil {
+			logger.WithError(err).Error("invalid maxUnavailable")
+			return reconcile.Result{}, err
+		}
+		maxUnavailable = max(maxUnavailable, 1)
+		for _, c := range candidates {
+			if inFlight >= maxUnavailable {
+				break
+			}
+			if c.started {
+				continue
+			}
+			if err := r.startUpgrade(upgrade, c, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+			inFlight++
+		}
+	}
+	if allCompleted(clusters) {
+		phase = hivev1.ClusterUpgradePhaseCompleted
+	}
+
+	status := hivev1.ClusterUpgradeStatus{
+		Phase:      phase,
+		Total:      int32(len(clusters)),
+		Conditions: upgrade.Status.DeepCopy().Conditions,
+	}
+	for _, c := range clusters {
+		switch {
+		case c.status.State == hivev1.ClusterUpgradeStateCompleted:
+			status.Completed++
+		case c.failing():
+			status.Failed++
+		case c.started:
+			status.Progressing++
+		}
+		status.Clusters = append(status.Clusters, c.status)
+	}
+
+	conflictStatus, conflictReason, conflictMessage := corev1.ConditionFalse, "NoConflicts", "No selected cluster is being upgraded by another ClusterUpgrade"
+	if len(conflicts) > 0 {
+		conflictStatus, conflictReason, conflictMessage = corev1.ConditionTrue, "UpgradedElsewhere",
+			fmt.Sprintf("Clusters being upgraded by another ClusterUpgrade: %s", strings.Join(conflicts, ", "))
+	}
+	status.Conditions, _ = controllerutils.SetClusterUpgradeConditionWithChangeCheck(
+		status.Conditions,
+		hivev1.ClusterUpgradePausedCondition,
+		pausedStatus,
+		pausedReason,
+		pausedMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	status.Conditions, _ = controllerutils.SetClusterUpgradeConditionWithChangeCheck(
+		status.Conditions,
+		hivev1.ClusterUpgradeConflictCondition,
+		conflictStatus,
+		conflictReason,
+		conflictMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+
+	if reflect.DeepEqual(status, upgrade.Status) {
+		return reconcile.Result{}, nil
+	}
+	upgrade.Status = status
+	if err := r.Status().Update(context.Background(), upgrade); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// selectClusters returns the installed ClusterDeployments selected by the upgrade, ordered by namespace and name,
+// and the names of those being upgraded by another ClusterUpgrade.
+func (r *ReconcileClusterUpgrade) selectClusters(upgrade *hivev1.ClusterUpgrade, logger log.FieldLogger) ([]*clusterUpgrade, []string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&upgrade.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	var canarySelector labels.Selector
+	if upgrade.Spec.Canary != nil && upgrade.Spec.Canary.Selector != nil {
+		if canarySelector, err = metav1.LabelSelectorAsSelector(upgrade.Spec.Canary.Selector); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// The image lets us tell whether a cluster's upgrade status is about this upgrade or an earlier one.
+	image := ""
+	imageSet := &hivev1.ClusterImageSet{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Name: upgrade.Spec.Target.ImageSetRef.Name}, imageSet); {
+	case err == nil:
+		image = imageSet.Spec.ReleaseImage
+	case !apierrors.IsNotFound(err):
+		return nil, nil, err
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(cdList.Items, func(i, j int) bool {
+		if cdList.Items[i].Namespace != cdList.Items[j].Namespace {
+			return cdList.Items[i].Namespace < cdList.Items[j].Namespace
+		}
+		return cdList.Items[i].Name < cdList.Items[j].Name
+	})
+
+	var clusters []*clusterUpgrade
+	var conflicts []string
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil {
+			continue
+		}
+		if owner := cd.Annotations[constants.ClusterUpgradeAnnotation]; owner != "" && owner != upgrade.Name &&
+			cd.Spec.Upgrade != nil && (cd.Status.Upgrade == nil || cd.Status.Upgrade.State != hivev1.ClusterUpgradeStateCompleted) {
+			logger.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name).WithField("owner", owner).
+				Debug("cluster is being upgraded by another cluster upgrade")
+			conflicts = append(conflicts, fmt.Sprintf("%s/%s (%s)", cd.Namespace, cd.Name, owner))
+			continue
+		}
+		c := &clusterUpgrade{
+			cd: cd,
+			status: hivev1.ClusterUpgradeClusterStatus{
+				Namespace: cd.Namespace,
+				Name:      cd.Name,
+				State:     hivev1.ClusterUpgradeStatePending,
+			},
+			started: cd.Annotations[constants.ClusterUpgradeAnnotation] == upgrade.Name &&
+				reflect.DeepEqual(cd.Spec.Upgrade, &upgrade.Spec.Target),
+		}
+		if c.started && cd.Status.Upgrade != nil && (image == "" || cd.Status.Upgrade.Image == "" || cd.Status.Upgrade.Image == image) {
+			c.status.State = cd.Status.Upgrade.State
+			c.status.Message = cd.Status.Upgrade.Message
+		}
+		if canarySelector != nil {
+			c.status.Canary = canarySelector.Matches(labels.Set(cd.Labels))
+		}
+		clusters = append(clusters, c)
+	}
+	if upgrade.Spec.Canary != nil && canarySelector == nil {
+		for i := 0; i < len(clusters) && i < int(upgrade.Spec.Canary.Count); i++ {
+			clusters[i].status.Canary = true
+		}
+	}
+	return clusters, conflicts, nil
+}
+
+// startUpgrade sets the upgrade's target on the cluster's ClusterDeployment, for the clusterversion controller to
+// request the upgrade from the cluster.
+func (r *ReconcileClusterUpgrade) startUpgrade(upgrade *hivev1.ClusterUpgrade, c *clusterUpgrade, logger log.FieldLogger) error {
+	logger = logger.WithField("clusterDeployment", c.status.Namespace+"/"+c.status.Name)
+	logger.WithField("canary", c.status.Canary).Info("starting cluster upgrade")
+	if c.cd.Annotations == nil {
+		c.cd.Annotations = map[string]string{}
+	}
+	c.cd.Annotations[constants.ClusterUpgradeAnnotation] = upgrade.Name
+	c.cd.Spec.Upgrade = upgrade.Spec.Target.DeepCopy()
+	if err := r.Update(context.Background(), c.cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not start cluster upgrade")
+		return err
+	}
+	c.started = true
+	c.status.State = hivev1.ClusterUpgradeStatePending
+	c.status.Message = ""
+	return nil
+}
+
+func allCompleted(clusters []*clusterUpgrade) bool {
+	for _, c := range clusters {
+		if c.status.State != hivev1.ClusterUpgradeStateCompleted {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,263 @@
+package clusterupgrade
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testUpgrade   = "upgrade"
+	testImageSet  = "openshift-v4.1.0"
+	testImage     = "quay.io/openshift-release-dev/ocp-release:4.1.0-x86_64"
+	fleetLabel    = "fleet"
+	canaryLabel   = "canary"
+)
+
+var testTarget = hivev1.ClusterUpgradeTarget{ImageSetRef: hivev1.ClusterImageSetReference{Name: testImageSet}}
+
+func TestReconcileClusterUpgrade(t *testing.T) {
+	cases := []struct {
+		name                string
+		spec                func(*hivev1.ClusterUpgradeSpec)
+		clusters            []*hivev1.ClusterDeployment
+		expectedStarted     []string
+		expectedPhase       hivev1.ClusterUpgradePhase
+		expectedCompleted   int32
+		expectedProgressing int32
+		expectedFailed      int32
+		expectedCanaries    []string
+		expectedPaused      corev1.ConditionStatus
+		expectedConflict    corev1.ConditionStatus
+	}{
+		{
+			name: "canaries by count",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.Canary = &hivev1.ClusterUpgradeCanary{Count: 1}
+				spec.MaxUnavailable = ptr.To(intstr.FromInt32(2))
+			},
+			clusters:            []*hivev1.ClusterDeployment{testCD("cd1"), testCD("cd2"), testCD("cd3")},
+			expectedStarted:     []string{"cd1"},
+			expectedPhase:       hivev1.ClusterUpgradePhaseCanary,
+			expectedProgressing: 1,
+			expectedCanaries:    []string{"cd1"},
+			expectedPaused:      corev1.ConditionFalse,
+			expectedConflict:    corev1.ConditionFalse,
+		},
+		{
+			name: "canaries by selector",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.Canary = &hivev1.ClusterUpgradeCanary{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{canaryLabel: "true"}}}
+			},
+			clusters:            []*hivev1.ClusterDeployment{testCD("cd1"), testCD("cd2", testcd.WithLabel(canaryLabel, "true"))},
+			expectedStarted:     []string{"cd2"},
+			expectedPhase:       hivev1.ClusterUpgradePhaseCanary,
+			expectedProgressing: 1,
+			expectedCanaries:    []string{"cd2"},
+			expectedPaused:      corev1.ConditionFalse,
+			expectedConflict:    corev1.ConditionFalse,
+		},
+		{
+			name: "fleet after canaries",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.Canary = &hivev1.ClusterUpgradeCanary{Count: 1}
+				spec.MaxUnavailable = ptr.To(intstr.FromString("50%"))
+			},
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", upgradeState(hivev1.ClusterUpgradeStateCompleted)),
+				testCD("cd2"), testCD("cd3"), testCD("cd4"),
+			},
+			expectedStarted:     []string{"cd1", "cd2", "cd3"},
+			expectedPhase:       hivev1.ClusterUpgradePhaseFleet,
+			expectedCompleted:   1,
+			expectedProgressing: 2,
+			expectedCanaries:    []string{"cd1"},
+			expectedPaused:      corev1.ConditionFalse,
+			expectedConflict:    corev1.ConditionFalse,
+		},
+		{
+			name: "max unavailable reached",
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", upgradeState(hivev1.ClusterUpgradeStateProgressing)),
+				testCD("cd2"),
+			},
+			expectedStarted:     []string{"cd1"},
+			expectedPhase:       hivev1.ClusterUpgradePhaseFleet,
+			expectedProgressing: 1,
+			expectedPaused:      corev1.ConditionFalse,
+			expectedConflict:    corev1.ConditionFalse,
+		},
+		{
+			name: "pause on failure",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.PauseOnFailure = true
+				spec.MaxUnavailable = ptr.To(intstr.FromInt32(2))
+			},
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", upgradeState(hivev1.ClusterUpgradeStateFailed)),
+				testCD("cd2"),
+			},
+			expectedStarted:  []string{"cd1"},
+			expectedPhase:    hivev1.ClusterUpgradePhasePaused,
+			expectedFailed:   1,
+			expectedPaused:   corev1.ConditionTrue,
+			expectedConflict: corev1.ConditionFalse,
+		},
+		{
+			name: "failure does not hold back rollout",
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", upgradeState(hivev1.ClusterUpgradeStateFailed)),
+				testCD("cd2", upgradeState(hivev1.ClusterUpgradeStateBlocked)),
+				testCD("cd3"),
+			},
+			expectedStarted:     []string{"cd1", "cd2", "cd3"},
+			expectedPhase:       hivev1.ClusterUpgradePhaseFleet,
+			expectedProgressing: 1,
+			expectedFailed:      2,
+			expectedPaused:      corev1.ConditionFalse,
+			expectedConflict:    corev1.ConditionFalse,
+		},
+		{
+			name: "paused",
+			spec: func(spec *hivev1.ClusterUpgradeSpec) {
+				spec.Paused = true
+			},
+			clusters:         []*hivev1.ClusterDeployment{testCD("cd1")},
+			expectedPhase:    hivev1.ClusterUpgradePhasePaused,
+			expectedPaused:   corev1.ConditionTrue,
+			expectedConflict: corev1.ConditionFalse,
+		},
+		{
+			name: "stale status from an earlier upgrade",
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", upgradeState(hivev1.ClusterUpgradeStateCompleted), func(cd *hivev1.ClusterDeployment) {
+					cd.Status.Upgrade.Image = "quay.io/openshift-release-dev/ocp-release:4.0.0-x86_64"
+				}),
+			},
+			expectedStarted:     []string{"cd1"},
+			expectedPhase:       hivev1.ClusterUpgradePhaseFleet,
+			expectedProgressing: 1,
+			expectedPaused:      corev1.ConditionFalse,
+			expectedConflict:    corev1.ConditionFalse,
+		},
+		{
+			name: "conflict with another upgrade",
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", upgradeState(hivev1.ClusterUpgradeStateProgressing), testcd.WithAnnotation(constants.ClusterUpgradeAnnotation, "other")),
+				testCD("cd2"),
+			},
+			expectedStarted:     []string{"cd2"},
+			expectedPhase:       hivev1.ClusterUpgradePhaseFleet,
+			expectedProgressing: 1,
+			expectedPaused:      corev1.ConditionFalse,
+			expectedConflict:    corev1.ConditionTrue,
+		},
+		{
+			name: "completed",
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", upgradeState(hivev1.ClusterUpgradeStateCompleted)),
+				testCD("not-selected", func(cd *hivev1.ClusterDeployment) { delete(cd.Labels, fleetLabel) }),
+				testCD("not-installed", func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+			},
+			expectedStarted:   []string{"cd1"},
+			expectedPhase:     hivev1.ClusterUpgradePhaseCompleted,
+			expectedCompleted: 1,
+			expectedPaused:    corev1.ConditionFalse,
+			expectedConflict:  corev1.ConditionFalse,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			upgrade := &hivev1.ClusterUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: testUpgrade},
+				Spec: hivev1.ClusterUpgradeSpec{
+					ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{fleetLabel: "true"}},
+					Target:                    testTarget,
+				},
+			}
+			if test.spec != nil {
+				test.spec(&upgrade.Spec)
+			}
+			imageSet := &hivev1.ClusterImageSet{
+				ObjectMeta: metav1.ObjectMeta{Name: testImageSet},
+				Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: testImage},
+			}
+			objects := []runtime.Object{upgrade, imageSet}
+			for _, cd := range test.clusters {
+				objects = append(objects, cd)
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(objects...).Build()
+			r := &ReconcileClusterUpgrade{Client: c, logger: log.WithField("controller", "clusterupgrade")}
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testUpgrade}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			var started []string
+			cdList := &hivev1.ClusterDeploymentList{}
+			require.NoError(t, c.List(context.Background(), cdList), "could not list clusterdeployments")
+			for _, cd := range cdList.Items {
+				if cd.Annotations[constants.ClusterUpgradeAnnotation] == testUpgrade && cd.Spec.Upgrade != nil {
+					assert.Equal(t, testTarget, *cd.Spec.Upgrade, "unexpected upgrade target")
+					started = append(started, cd.Name)
+				}
+			}
+			assert.ElementsMatch(t, test.expectedStarted, started, "unexpected started upgrades")
+
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: testUpgrade}, upgrade), "could not get cluster upgrade")
+			assert.Equal(t, test.expectedPhase, upgrade.Status.Phase, "unexpected phase")
+			assert.Equal(t, test.expectedCompleted, upgrade.Status.Completed, "unexpected completed count")
+			assert.Equal(t, test.expectedProgressing, upgrade.Status.Progressing, "unexpected progressing count")
+			assert.Equal(t, test.expectedFailed, upgrade.Status.Failed, "unexpected failed count")
+			var canaries []string
+			for _, cluster := range upgrade.Status.Clusters {
+				if cluster.Canary {
+					canaries = append(canaries, cluster.Name)
+				}
+			}
+			assert.Equal(t, test.expectedCanaries, canaries, "unexpected canaries")
+			paused := controllerutils.FindCondition(upgrade.Status.Conditions, hivev1.ClusterUpgradePausedCondition)
+			if assert.NotNil(t, paused, "missing Paused condition") {
+				assert.Equal(t, test.expectedPaused, paused.Status, "unexpected Paused status")
+			}
+			conflict := controllerutils.FindCondition(upgrade.Status.Conditions, hivev1.ClusterUpgradeConflictCondition)
+			if assert.NotNil(t, conflict, "missing Conflict condition") {
+				assert.Equal(t, test.expectedConflict, conflict.Status, "unexpected Conflict status")
+			}
+		})
+	}
+}
+
+func testCD(name string, opts ...testcd.Option) *hivev1.ClusterDeployment {
+	return testcd.FullBuilder(testNamespace, name, scheme.GetScheme()).Build(
+		append([]testcd.Option{testcd.Installed(), testcd.WithLabel(fleetLabel, "true")}, opts...)...,
+	)
+}
+
+// upgradeState marks the cluster's upgrade to the test target as started and in the given state.
+func upgradeState(state hivev1.ClusterUpgradeState) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.ClusterUpgradeAnnotation] = testUpgrade
+		cd.Spec.Upgrade = testTarget.DeepCopy()
+		cd.Status.Upgrade = &hivev1.ClusterUpgradeProgress{State: state, Image: testImage}
+	}
+}
@@ -109,7 +109,7 @@ type ReconcileClusterVersion struct {
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and syncs the remote ClusterVersion status
-// if the remote cluster is available.
+// if the remote cluster is available. It also requests and tracks the upgrade of the cluster in cd.Spec.Upgrade.
 func (r *ReconcileClusterVersion) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
 	cdLog.Info("reconciling cluster deployment")
@@ -178,10 +178,21 @@ func (r *ReconcileClusterVersion) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, err
 	}
 
+	// This Update()s the remote ClusterVersion and CD.Status iff necessary
+	upgrading, err := r.reconcileUpgrade(cd, remoteClient, clusterVersion, cdLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	var requeueAfter time.Duration
 	if r.pollInterval > 0 {
 		// Add a random fraction of a second jitter to reduce bunching
 		requeueAfter = r.pollInterval + time.Duration(rand.Float64()*float64(time.Second))
+	}
+	if upgrading && (requeueAfter == 0 || requeueAfter > upgradeRequeueInterval) {
+		requeueAfter = upgradeRequeueInterval
+	}
+	if requeueAfter > 0 {
 		cdLog.WithField("requeueAfter", requeueAfter).Debug("using requeue time")
 	}
 
@@ -0,0 +1,171 @@
+package clusterversion
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// clusterVersionFailingCondition is the ClusterVersion condition the CVO sets when it cannot apply the desired
+	// release.
+	clusterVersionFailingCondition openshiftapiv1.ClusterStatusConditionType = "Failing"
+
+	// upgradeRequeueInterval is how often we check on an upgrade which has not completed yet.
+	upgradeRequeueInterval = time.Minute
+)
+
+// reconcileUpgrade requests the upgrade in cd.Spec.Upgrade from the remote cluster and reports its progress in
+// cd.Status.Upgrade. It returns true if the upgrade has not completed yet and should be checked on again.
+func (r *ReconcileClusterVersion) reconcileUpgrade(cd *hivev1.ClusterDeployment, remoteClient client.Client, clusterVersion *openshiftapiv1.ClusterVersion, cdLog log.FieldLogger) (bool, error) {
+	if cd.Spec.Upgrade == nil {
+		// If the upgrade was removed, clear the progress so it doesn't go stale
+		if cd.Status.Upgrade == nil {
+			return false, nil
+		}
+		cdLog.Debug("clearing upgrade status")
+		cd.Status.Upgrade = nil
+		return false, r.updateUpgradeStatus(cd, cdLog)
+	}
+	target := cd.Spec.Upgrade
+	uLog := cdLog.WithField("imageSet", target.ImageSetRef.Name)
+
+	progress := &hivev1.ClusterUpgradeProgress{}
+	if cd.Status.Upgrade != nil {
+		progress = cd.Status.Upgrade.DeepCopy()
+	}
+
+	imageSet := &hivev1.ClusterImageSet{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Name: target.ImageSetRef.Name}, imageSet); err != nil {
+		if !apierrors.IsNotFound(err) {
+			uLog.WithError(err).Error("error getting cluster image set")
+			return false, err
+		}
+		uLog.Info("cluster image set for upgrade does not exist")
+		progress.State = hivev1.ClusterUpgradeStateBlocked
+		progress.Message = fmt.Sprintf("ClusterImageSet %s does not exist", target.ImageSetRef.Name)
+		return true, r.setUpgradeProgress(cd, progress, uLog)
+	}
+	image := imageSet.Spec.ReleaseImage
+	uLog = uLog.WithField("image", image)
+	if progress.Image != image {
+		// New target: start over
+		progress = &hivev1.ClusterUpgradeProgress{Image: image}
+	}
+	if progress.State == hivev1.ClusterUpgradeStateCompleted {
+		// Once completed, the cluster is left alone: it may since have been upgraded further, and requesting the
+		// target again would downgrade it.
+		uLog.Debug("upgrade already completed")
+		return false, nil
+	}
+
+	// The first history entry is the release the cluster is running or upgrading to.
+	if history := clusterVersion.Status.History; len(history) > 0 && history[0].Image == image {
+		progress.Version = history[0].Version
+		if history[0].State == openshiftapiv1.CompletedUpdate {
+			uLog.Debug("upgrade completed")
+			progress.State = hivev1.ClusterUpgradeStateCompleted
+			progress.Message = ""
+			if progress.CompletedTime == nil {
+				progress.CompletedTime = history[0].CompletionTime
+			}
+			if progress.CompletedTime == nil {
+				now := metav1.Now()
+				progress.CompletedTime = &now
+			}
+			return false, r.setUpgradeProgress(cd, progress, uLog)
+		}
+	}
+
+	if desired := clusterVersion.Spec.DesiredUpdate; desired != nil && desired.Image == image {
+		// Already requested: report how the CVO is getting along with it.
+		progress.State = hivev1.ClusterUpgradeStateProgressing
+		progress.Message = ""
+		for _, cond := range clusterVersion.Status.Conditions {
+			if cond.Type == clusterVersionFailingCondition && cond.Status == openshiftapiv1.ConditionTrue {
+				progress.State = hivev1.ClusterUpgradeStateFailed
+				progress.Message = cond.Message
+			}
+		}
+		if progress.StartedTime == nil {
+			now := metav1.Now()
+			progress.StartedTime = &now
+		}
+		return true, r.setUpgradeProgress(cd, progress, uLog)
+	}
+
+	if target.Channel != "" && clusterVersion.Spec.Channel != target.Channel {
+		// The available updates are only recomputed by the CVO once the channel has changed, so we request the
+		// upgrade itself on a later reconcile.
+		uLog.WithField("channel", target.Channel).Info("switching cluster channel for upgrade")
+		clusterVersion.Spec.Channel = target.Channel
+		if err := remoteClient.Update(context.TODO(), clusterVersion); err != nil {
+			uLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating remote clusterversion channel")
+			return false, err
+		}
+		progress.State = hivev1.ClusterUpgradeStatePending
+		progress.Message = fmt.Sprintf("Switched to channel %s, waiting for the available updates", target.Channel)
+		return true, r.setUpgradeProgress(cd, progress, uLog)
+	}
+
+	desiredUpdate := &openshiftapiv1.Update{Image: image}
+	for _, release := range clusterVersion.Status.AvailableUpdates {
+		if release.Image == image {
+			desiredUpdate.Version = release.Version
+			break
+		}
+	}
+	if desiredUpdate.Version == "" {
+		if !target.Force {
+			uLog.Info("release is not an available update of the cluster")
+			progress.State = hivev1.ClusterUpgradeStateBlocked
+			progress.Message = fmt.Sprintf("Release %s is not an available update in channel %q", image, clusterVersion.Spec.Channel)
+			return true, r.setUpgradeProgress(cd, progress, uLog)
+		}
+		desiredUpdate.Force = true
+	}
+
+	uLog.WithField("force", desiredUpdate.Force).Info("requesting upgrade of remote cluster")
+	clusterVersion.Spec.DesiredUpdate = desiredUpdate
+	if err := remoteClient.Update(context.TODO(), clusterVersion); err != nil {
+		uLog.WithError(err).Log(controllerutils.LogLevel(err), "error requesting upgrade of remote cluster")
+		return false, err
+	}
+	now := metav1.Now()
+	progress.State = hivev1.ClusterUpgradeStateProgressing
+	progress.Version = desiredUpdate.Version
+	progress.StartedTime = &now
+	progress.CompletedTime = nil
+	progress.Message = ""
+	return true, r.setUpgradeProgress(cd, progress, uLog)
+}
+
+// setUpgradeProgress sets cd.Status.Upgrade to progress and Update()s CD.Status iff it changed.
+func (r *ReconcileClusterVersion) setUpgradeProgress(cd *hivev1.ClusterDeployment, progress *hivev1.ClusterUpgradeProgress, cdLog log.FieldLogger) error {
+	if reflect.DeepEqual(cd.Status.Upgrade, progress) {
+		cdLog.Debug("upgrade status has not changed, nothing to update")
+		return nil
+	}
+	cdLog.WithField("state", progress.State).Debug("updating upgrade status")
+	cd.Status.Upgrade = progress
+	return r.updateUpgradeStatus(cd, cdLog)
+}
+
+func (r *ReconcileClusterVersion) updateUpgradeStatus(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment's upgrade status")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package clusterversion
+
+import (
+	"cmp"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testUpgradeImageSet = "openshift-v4.1.0"
+	testUpgradeImage    = "quay.io/openshift-release-dev/ocp-release:4.1.0-x86_64"
+	testUpgradeVersion  = "4.1.0"
+)
+
+func TestReconcileUpgrade(t *testing.T) {
+	startedTime := metav1.NewTime(time.Unix(100, 0))
+	tests := []struct {
+		name                  string
+		cdOpts                []cdOpt
+		noImageSet            bool
+		clusterVersion        *configv1.ClusterVersion
+		expectedProgress      *hivev1.ClusterUpgradeProgress
+		expectedDesiredUpdate *configv1.Update
+		expectedChannel       string
+		expectedRequeue       bool
+	}{
+		{
+			name:           "no upgrade",
+			clusterVersion: testUpgradeClusterVersion(),
+		},
+		{
+			name: "upgrade removed",
+			cdOpts: []cdOpt{
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Status.Upgrade = &hivev1.ClusterUpgradeProgress{State: hivev1.ClusterUpgradeStateCompleted}
+				},
+			},
+			clusterVersion: testUpgradeClusterVersion(),
+		},
+		{
+			name:           "missing image set",
+			cdOpts:         []cdOpt{withUpgrade(hivev1.ClusterUpgradeTarget{})},
+			noImageSet:     true,
+			clusterVersion: testUpgradeClusterVersion(),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State:   hivev1.ClusterUpgradeStateBlocked,
+				Message: "ClusterImageSet openshift-v4.1.0 does not exist",
+			},
+			expectedRequeue: true,
+		},
+		{
+			name:           "not an available update",
+			cdOpts:         []cdOpt{withUpgrade(hivev1.ClusterUpgradeTarget{})},
+			clusterVersion: testUpgradeClusterVersion(),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State:   hivev1.ClusterUpgradeStateBlocked,
+				Image:   testUpgradeImage,
+				Message: `Release quay.io/openshift-release-dev/ocp-release:4.1.0-x86_64 is not an available update in channel "stable-4.0"`,
+			},
+			expectedRequeue: true,
+		},
+		{
+			name:           "forced upgrade",
+			cdOpts:         []cdOpt{withUpgrade(hivev1.ClusterUpgradeTarget{Force: true})},
+			clusterVersion: testUpgradeClusterVersion(),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State: hivev1.ClusterUpgradeStateProgressing,
+				Image: testUpgradeImage,
+			},
+			expectedDesiredUpdate: &configv1.Update{Image: testUpgradeImage, Force: true},
+			expectedRequeue:       true,
+		},
+		{
+			name:           "available update",
+			cdOpts:         []cdOpt{withUpgrade(hivev1.ClusterUpgradeTarget{})},
+			clusterVersion: testUpgradeClusterVersion(withAvailableUpdate()),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State:   hivev1.ClusterUpgradeStateProgressing,
+				Image:   testUpgradeImage,
+				Version: testUpgradeVersion,
+			},
+			expectedDesiredUpdate: &configv1.Update{Image: testUpgradeImage, Version: testUpgradeVersion},
+			expectedRequeue:       true,
+		},
+		{
+			name:           "channel switch",
+			cdOpts:         []cdOpt{withUpgrade(hivev1.ClusterUpgradeTarget{Channel: "fast-4.1"})},
+			clusterVersion: testUpgradeClusterVersion(withAvailableUpdate()),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State:   hivev1.ClusterUpgradeStatePending,
+				Image:   testUpgradeImage,
+				Message: "Switched to channel fast-4.1, waiting for the available updates",
+			},
+			expectedChannel: "fast-4.1",
+			expectedRequeue: true,
+		},
+		{
+			name: "upgrade failing",
+			cdOpts: []cdOpt{
+				withUpgrade(hivev1.ClusterUpgradeTarget{}),
+				withUpgradeProgress(&hivev1.ClusterUpgradeProgress{
+					State:       hivev1.ClusterUpgradeStateProgressing,
+					Image:       testUpgradeImage,
+					Version:     testUpgradeVersion,
+					StartedTime: &startedTime,
+				}),
+			},
+			clusterVersion: testUpgradeClusterVersion(
+				withDesiredUpdate(),
+				func(cv *configv1.ClusterVersion) {
+					cv.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+						Type:    clusterVersionFailingCondition,
+						Status:  configv1.ConditionTrue,
+						Message: "Cluster operator etcd is degraded",
+					}}
+				},
+			),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State:       hivev1.ClusterUpgradeStateFailed,
+				Image:       testUpgradeImage,
+				Version:     testUpgradeVersion,
+				StartedTime: &startedTime,
+				Message:     "Cluster operator etcd is degraded",
+			},
+			expectedDesiredUpdate: &configv1.Update{Image: testUpgradeImage, Version: testUpgradeVersion},
+			expectedRequeue:       true,
+		},
+		{
+			name: "upgrade completed",
+			cdOpts: []cdOpt{
+				withUpgrade(hivev1.ClusterUpgradeTarget{}),
+				withUpgradeProgress(&hivev1.ClusterUpgradeProgress{
+					State:       hivev1.ClusterUpgradeStateProgressing,
+					Image:       testUpgradeImage,
+					Version:     testUpgradeVersion,
+					StartedTime: &startedTime,
+				}),
+			},
+			clusterVersion: testUpgradeClusterVersion(
+				withDesiredUpdate(),
+				func(cv *configv1.ClusterVersion) {
+					cv.Status.History = append([]configv1.UpdateHistory{{
+						State:          configv1.CompletedUpdate,
+						Version:        testUpgradeVersion,
+						Image:          testUpgradeImage,
+						CompletionTime: &startedTime,
+					}}, cv.Status.History...)
+				},
+			),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State:         hivev1.ClusterUpgradeStateCompleted,
+				Image:         testUpgradeImage,
+				Version:       testUpgradeVersion,
+				StartedTime:   &startedTime,
+				CompletedTime: &startedTime,
+			},
+			expectedDesiredUpdate: &configv1.Update{Image: testUpgradeImage, Version: testUpgradeVersion},
+		},
+		{
+			name: "upgraded further after completion",
+			cdOpts: []cdOpt{
+				withUpgrade(hivev1.ClusterUpgradeTarget{}),
+				withUpgradeProgress(&hivev1.ClusterUpgradeProgress{
+					State:         hivev1.ClusterUpgradeStateCompleted,
+					Image:         testUpgradeImage,
+					Version:       testUpgradeVersion,
+					StartedTime:   &startedTime,
+					CompletedTime: &startedTime,
+				}),
+			},
+			clusterVersion: testUpgradeClusterVersion(
+				withAvailableUpdate(),
+				func(cv *configv1.ClusterVersion) {
+					cv.Spec.DesiredUpdate = &configv1.Update{Image: "quay.io/openshift-release-dev/ocp-release:4.1.1-x86_64", Version: "4.1.1"}
+					cv.Status.History = append([]configv1.UpdateHistory{{
+						State:   configv1.PartialUpdate,
+						Version: "4.1.1",
+						Image:   "quay.io/openshift-release-dev/ocp-release:4.1.1-x86_64",
+					}}, cv.Status.History...)
+				},
+			),
+			expectedProgress: &hivev1.ClusterUpgradeProgress{
+				State:         hivev1.ClusterUpgradeStateCompleted,
+				Image:         testUpgradeImage,
+				Version:       testUpgradeVersion,
+				StartedTime:   &startedTime,
+				CompletedTime: &startedTime,
+			},
+			expectedDesiredUpdate: &configv1.Update{Image: "quay.io/openshift-release-dev/ocp-release:4.1.1-x86_64", Version: "4.1.1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := []runtime.Object{testClusterDeployment(test.cdOpts...), testKubeconfigSecret()}
+			if !test.noImageSet {
+				existing = append(existing, &hivev1.ClusterImageSet{
+					ObjectMeta: metav1.ObjectMeta{Name: testUpgradeImageSet},
+					Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: testUpgradeImage},
+				})
+			}
+			fakeClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(existing...).Build()
+			remoteClient := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.clusterVersion).Build()
+			mockCtrl := gomock.NewController(t)
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
+			rcd := &ReconcileClusterVersion{
+				Client:                        fakeClient,
+				scheme:                        scheme.GetScheme(),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+			}
+
+			namespacedName := types.NamespacedName{Name: testName, Namespace: testNamespace}
+			result, err := rcd.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName})
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), namespacedName, cd), "unexpected error getting clusterdeployment")
+			if test.expectedProgress == nil {
+				assert.Nil(t, cd.Status.Upgrade, "expected no upgrade status")
+			} else if assert.NotNil(t, cd.Status.Upgrade, "expected upgrade status") {
+				progress := cd.Status.Upgrade.DeepCopy()
+				if test.expectedProgress.StartedTime == nil && progress.StartedTime != nil {
+					// Set from the current time
+					progress.StartedTime = nil
+				}
+				assert.Equal(t, test.expectedProgress, progress, "unexpected upgrade status")
+			}
+
+			cv := &configv1.ClusterVersion{}
+			require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Name: clusterVersionObjectName}, cv), "unexpected error getting clusterversion")
+			assert.Equal(t, test.expectedDesiredUpdate, cv.Spec.DesiredUpdate, "unexpected desired update")
+			assert.Equal(t, cmp.Or(test.expectedChannel, "stable-4.0"), cv.Spec.Channel, "unexpected channel")
+		})
+	}
+}
+
+func withUpgrade(target hivev1.ClusterUpgradeTarget) cdOpt {
+	return func(cd *hivev1.ClusterDeployment) {
+		target.ImageSetRef.Name = testUpgradeImageSet
+		cd.Spec.Upgrade = &target
+	}
+}
+
+func withUpgradeProgress(progress *hivev1.ClusterUpgradeProgress) cdOpt {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Upgrade = progress
+	}
+}
+
+type cvOpt func(*configv1.ClusterVersion)
+
+func withAvailableUpdate() cvOpt {
+	return func(cv *configv1.ClusterVersion) {
+		cv.Status.AvailableUpdates = []configv1.Release{{Version: testUpgradeVersion, Image: testUpgradeImage}}
+	}
+}
+
+func withDesiredUpdate() cvOpt {
+	return func(cv *configv1.ClusterVersion) {
+		cv.Spec.DesiredUpdate = &configv1.Update{Image: testUpgradeImage, Version: testUpgradeVersion}
+	}
+}
+
+func testUpgradeClusterVersion(opts ...cvOpt) *configv1.ClusterVersion {
+	cv := &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: remoteClusterVersionObjectName},
+		Spec:       configv1.ClusterVersionSpec{Channel: "stable-4.0"},
+		Status:     *testRemoteClusterVersionStatus(),
+	}
+	for _, opt := range opts {
+		opt(cv)
+	}
+	return cv
+}
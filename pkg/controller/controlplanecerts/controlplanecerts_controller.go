@@ -50,16 +50,23 @@ const (
 	certsFoundReason     = "ControlPlaneCertificatesFound"
 	certsFoundMessage    = "Control plane certificates are present"
 
+	// servingCertificateComponent is the component label of the serving certificate metrics of the control plane.
+	servingCertificateComponent = "control-plane"
+
 	kubeAPIServerPatchTemplate = `[ {"op": "replace", "path": "/spec/forceRedeploymentReason", "value": %q } ]`
 )
 
 var (
 	secretCheckInterval = 2 * time.Minute
 
+	// certificateCheckInterval is how often the expiry of control plane certificates is checked.
+	certificateCheckInterval = time.Hour
+
 	// clusterDeploymentControlPlaneCertsConditions are the cluster deployment conditions controlled by
 	// Control Plane Certs controller
 	clusterDeploymentControlPlaneCertsConditions = []hivev1.ClusterDeploymentConditionType{
 		hivev1.ControlPlaneCertificateNotFoundCondition,
+		hivev1.ControlPlaneCertificateUnhealthyCondition,
 	}
 )
 
@@ -143,6 +150,7 @@ func (r *ReconcileControlPlaneCerts) Reconcile(ctx context.Context, request reco
 	err := r.Get(context.TODO(), request.NamespacedName, cd)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			controllerutils.ReportServingCertificateMetrics(request.Namespace, request.Name, servingCertificateComponent, nil, time.Now())
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -175,6 +183,7 @@ func (r *ReconcileControlPlaneCerts) Reconcile(ctx context.Context, request reco
 
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
+		controllerutils.ReportServingCertificateMetrics(cd.Namespace, cd.Name, servingCertificateComponent, nil, time.Now())
 		return reconcile.Result{}, nil
 	}
 
@@ -214,6 +223,23 @@ func (r *ReconcileControlPlaneCerts) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{RequeueAfter: secretCheckInterval}, nil
 	}
 
+	checks, err := r.checkControlPlaneCertificates(cd, secrets)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to check control plane certificates")
+		return reconcile.Result{}, err
+	}
+	now := time.Now()
+	controllerutils.ReportServingCertificateMetrics(cd.Namespace, cd.Name, servingCertificateComponent, checks, now)
+	invalid, err := r.setCertsUnhealthyCondition(cd, checks, now)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update cluster deployment certificates unhealthy condition")
+		return reconcile.Result{}, err
+	}
+	if invalid {
+		cdLog.Warn("not syncing control plane certificates as a certificate chain is invalid")
+		return reconcile.Result{RequeueAfter: secretCheckInterval}, nil
+	}
+
 	if len(secrets) == 0 && existingSyncSet == nil {
 		cdLog.Debug("no control plane certs needed, and no syncset exists, nothing to do")
 		return reconcile.Result{}, nil
@@ -230,9 +256,52 @@ func (r *ReconcileControlPlaneCerts) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{}, err
 	}
 
+	if len(checks) > 0 {
+		// Requeue to keep the expiry of the certificates up to date.
+		return reconcile.Result{RequeueAfter: certificateCheckInterval}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
+// checkControlPlaneCertificates checks the secret of each certificate bundle used by the control plane against the
+// domains the bundle serves.
+func (r *ReconcileControlPlaneCerts) checkControlPlaneCertificates(cd *hivev1.ClusterDeployment, secrets []*corev1.Secret) ([]controllerutils.ServingCertificateCheck, error) {
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+	domains := map[string][]string{}
+	if name := cd.Spec.ControlPlaneConfig.ServingCertificates.Default; name != "" {
+		apidomain, err := r.defaultControlPlaneDomain(cd)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get control plane domain")
+		}
+		domains[name] = append(domains[name], apidomain)
+	}
+	for _, additional := range cd.Spec.ControlPlaneConfig.ServingCertificates.Additional {
+		domains[additional.Name] = append(domains[additional.Name], additional.Domain)
+	}
+	names := make([]string, 0, len(domains))
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checks := []controllerutils.ServingCertificateCheck{}
+	for _, name := range names {
+		bundle := certificateBundle(cd, name)
+		if bundle == nil {
+			return nil, fmt.Errorf("no certificate bundle was found for %s", name)
+		}
+		for _, secret := range secrets {
+			if secret.Name == bundle.CertificateSecretRef.Name {
+				checks = append(checks, controllerutils.CheckServingCertificate(name, secret, domains[name]))
+				break
+			}
+		}
+	}
+	return checks, nil
+}
+
 func (r *ReconcileControlPlaneCerts) getControlPlaneSecrets(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*corev1.Secret, bool, error) {
 	secretsNeeded, err := getControlPlaneSecretNames(cd, cdLog)
 	if err != nil {
@@ -426,6 +495,25 @@ func (r *ReconcileControlPlaneCerts) setCertsNotFoundCondition(cd *hivev1.Cluste
 	return true, r.Status().Update(context.TODO(), cd)
 }
 
+// setCertsUnhealthyCondition reports problems with the control plane certificates in the
+// ControlPlaneCertificateUnhealthy condition. It returns whether any certificate chain is invalid.
+func (r *ReconcileControlPlaneCerts) setCertsUnhealthyCondition(cd *hivev1.ClusterDeployment, checks []controllerutils.ServingCertificateCheck, now time.Time) (bool, error) {
+	status, reason, message, invalid := controllerutils.ServingCertificateCondition(checks, now)
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ControlPlaneCertificateUnhealthyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return invalid, nil
+	}
+	cd.Status.Conditions = conds
+	return invalid, r.Status().Update(context.TODO(), cd)
+}
+
 // defaultControlPlaneDomain will attempt to return the domain/hostname for the secondary API URL
 // for the cluster based on the contents of the clusterDeployment's adminKubeConfig secret.
 func (r *ReconcileControlPlaneCerts) defaultControlPlaneDomain(cd *hivev1.ClusterDeployment) (string, error) {
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,7 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 		expectedPatch          string
 		expectedSecrets        []string
 		expectedNotFoundStatus corev1.ConditionStatus
+		expectedUnhealthy      string
 	}{
 		{
 			name: "initialize conditions",
@@ -85,8 +87,37 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 					withNotFoundCondition(corev1.ConditionFalse).obj(),
 				fakeCertSecret("default-secret"),
 			},
-			expectedPatch:   `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
-			expectedSecrets: []string{"default-secret"},
+			expectedPatch:     `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "test-api-url" ], "servingCertificate": { "name": "fake-cluster-default-secret" } } ] } ]`,
+			expectedSecrets:   []string{"default-secret"},
+			expectedUnhealthy: controllerutils.ServingCertificatesValidReason,
+		},
+		{
+			name: "invalid certificate chain",
+			existing: []runtime.Object{
+				fakeClusterDeployment().
+					defaultCert("default-cert", "default-secret").
+					withNotFoundCondition(corev1.ConditionFalse).obj(),
+				testsecret.Build(
+					testsecret.WithName("default-secret"),
+					testsecret.WithNamespace(fakeNamespace),
+					testsecret.WithDataKeyValue(constants.TLSCrtSecretKey, []byte("blah")),
+					testsecret.WithDataKeyValue(constants.TLSKeySecretKey, []byte("blah")),
+				),
+			},
+			expectNoSyncSet:   true,
+			expectedUnhealthy: controllerutils.ServingCertificateChainInvalidReason,
+		},
+		{
+			name: "certificate name mismatch",
+			existing: []runtime.Object{
+				fakeClusterDeployment().
+					namedCert("cert1", "other.com", "secret1").
+					withNotFoundCondition(corev1.ConditionFalse).obj(),
+				fakeCertSecret("secret1"),
+			},
+			expectedPatch:     `[ { "op": "add", "path": "/spec/servingCerts", "value": {} }, { "op": "add", "path": "/spec/servingCerts/namedCertificates", "value": [  ] }, { "op": "replace", "path": "/spec/servingCerts/namedCertificates", "value": [  { "names": [ "other.com" ], "servingCertificate": { "name": "fake-cluster-secret1" } } ] } ]`,
+			expectedSecrets:   []string{"secret1"},
+			expectedUnhealthy: controllerutils.ServingCertificateNameMismatchReason,
 		},
 		{
 			name: "additional certs only",
@@ -221,6 +252,13 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 				assert.Equal(t, corev1.ConditionFalse, notFoundCondition.Status)
 			}
 
+			unhealthyCondition := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ControlPlaneCertificateUnhealthyCondition)
+			if test.expectedUnhealthy != "" {
+				if assert.NotNil(t, unhealthyCondition, "expected an Unhealthy condition") {
+					assert.Equal(t, test.expectedUnhealthy, unhealthyCondition.Reason, "unexpected Unhealthy reason")
+				}
+			}
+
 		})
 	}
 }
@@ -337,10 +375,16 @@ func fakeClusterDeployment() *fakeClusterDeploymentWrapper {
 		},
 		Status: hivev1.ClusterDeploymentStatus{
 			APIURL: fakeAPIURL,
-			Conditions: []hivev1.ClusterDeploymentCondition{{
-				Type:   hivev1.UnreachableCondition,
-				Status: corev1.ConditionFalse,
-			}},
+			Conditions: []hivev1.ClusterDeploymentCondition{
+				{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionFalse,
+				},
+				{
+					Type:   hivev1.ControlPlaneCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				},
+			},
 		},
 	}
 	return &fakeClusterDeploymentWrapper{cd: cd}
@@ -388,15 +432,11 @@ func (f *fakeClusterDeploymentWrapper) withNotFoundCondition(status corev1.Condi
 }
 
 func fakeCertSecret(name string) *corev1.Secret {
-	s := &corev1.Secret{}
-	s.Name = name
-	s.Namespace = fakeNamespace
-	s.Data = map[string][]byte{
-		constants.TLSKeySecretKey: []byte("blah"),
-		constants.TLSCrtSecretKey: []byte("blah"),
-	}
-	s.Type = corev1.SecretTypeTLS
-	return s
+	return testsecret.Build(
+		testsecret.WithName(name),
+		testsecret.WithNamespace(fakeNamespace),
+		testsecret.WithTLSCertificate(time.Now().Add(365*24*time.Hour), fakeAPIURLDomain, "foo.com", "bar.com"),
+	)
 }
 
 func fakeSyncSet() *hivev1.SyncSet {
@@ -116,10 +116,28 @@ func newReconciler(mgr manager.Manager, kubeClient client.Client) (*ReconcileDNS
 		return reconciler, nil, nil
 	}
 
+	// Dangling delegations are only looked for when delegation audits are enabled.
+	var lameDelegationChecker lameDelegationChecker
+	if auditConfig, err := manageddns.ReadDelegationAuditConfigFile(); err != nil {
+		logger.WithError(err).Error("could not read the DNS delegation audit config, dangling delegations will not be reported")
+	} else if auditConfig != nil {
+		if auditor, err := manageddns.NewDelegationAuditor(auditConfig.Resolvers); err != nil {
+			logger.WithError(err).Error("could not create the DNS delegation auditor, dangling delegations will not be reported")
+		} else {
+			lameDelegationChecker = auditor
+		}
+	}
+
 	nameServerChangeNotifier := make(chan event.GenericEvent, 1024)
 
 	for _, md := range managedDomains {
-		nameServerQuery := createNameServerQuery(kubeClient, logger, md)
+		if md.RFC2136 != nil {
+			// Records for zones in RFC2136 managed domains are written into the managed domain's zone, so there is
+			// no delegation to maintain.
+			logger.WithField("domains", md.Domains).Info("skipping name server scraping for RFC2136 managed domains")
+			continue
+		}
+		nameServerQuery := NewNameServerQuery(kubeClient, logger, md)
 		if nameServerQuery == nil {
 			logger.WithField("domains", md.Domains).Warn("no platform found for managed DNS")
 			continue
@@ -129,6 +147,9 @@ func newReconciler(mgr manager.Manager, kubeClient client.Client) (*ReconcileDNS
 			nameServerChangeNotifier <- event.GenericEvent{Object: obj}
 		}
 		nameServerScraper := newNameServerScraper(logger, nameServerQuery, md.Domains, registerNameServerChange)
+		if nameServerScraper != nil {
+			nameServerScraper.lameDelegationChecker = lameDelegationChecker
+		}
 		if err := mgr.Add(nameServerScraper); err != nil {
 			logger.WithError(err).WithField("domains", md.Domains).Warn("unable to add name server scraper for root domains")
 			continue
@@ -300,7 +321,9 @@ func (r *ReconcileDNSEndpoint) Reconcile(ctx context.Context, request reconcile.
 	return reconcile.Result{}, nil
 }
 
-func createNameServerQuery(c client.Client, logger log.FieldLogger, managedDomain hivev1.ManageDNSConfig) nameserver.Query {
+// NewNameServerQuery returns the name server query for the cloud of the managed domain, or nil if the cloud is not
+// supported. The credentials of the managed domain are read from the hive namespace.
+func NewNameServerQuery(c client.Client, logger log.FieldLogger, managedDomain hivev1.ManageDNSConfig) nameserver.Query {
 	if managedDomain.AWS != nil {
 		secretName := managedDomain.AWS.CredentialsSecretRef.Name
 		logger.Infof("using aws creds for managed domains stored in %q secret", secretName)
@@ -320,6 +343,11 @@ func createNameServerQuery(c client.Client, logger log.FieldLogger, managedDomai
 		logger.Infof("using azure creds for managed domain stored in %q secret", secretName)
 		return nameserver.NewAzureQuery(c, secretName, managedDomain.Azure.ResourceGroupName, managedDomain.Azure.CloudName.Name())
 	}
+	if managedDomain.IBMCloud != nil {
+		secretName := managedDomain.IBMCloud.CredentialsSecretRef.Name
+		logger.Infof("using ibmcloud creds for managed domain stored in %q secret", secretName)
+		return nameserver.NewIBMCloudQuery(c, secretName, managedDomain.IBMCloud.CISInstanceCRN)
+	}
 	logger.Error("unsupported cloud for managing DNS")
 	return nil
 }
@@ -0,0 +1,182 @@
+package nameserver
+
+import (
+	"context"
+
+	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ibmclient"
+)
+
+const ibmCloudNSRecordTTL = 60
+
+// NewIBMCloudQuery creates a new name server query for IBM Cloud Internet Services.
+func NewIBMCloudQuery(c client.Client, credsSecretName, cisInstanceCRN string) Query {
+	return &ibmCloudQuery{
+		getIBMClient: func() (ibmclient.API, error) {
+			credsSecret := &corev1.Secret{}
+			if err := c.Get(
+				context.Background(),
+				client.ObjectKey{Namespace: controllerutils.GetHiveNamespace(), Name: credsSecretName},
+				credsSecret,
+			); err != nil {
+				return nil, errors.Wrap(err, "could not get the creds secret")
+			}
+			ibmClient, err := ibmclient.NewClientFromSecret(credsSecret)
+			return ibmClient, errors.Wrap(err, "error creating IBM Cloud client")
+		},
+		cisInstanceCRN: cisInstanceCRN,
+	}
+}
+
+type ibmCloudQuery struct {
+	getIBMClient   func() (ibmclient.API, error)
+	cisInstanceCRN string
+}
+
+var _ Query = (*ibmCloudQuery)(nil)
+
+// Get implements Query.Get.
+func (q *ibmCloudQuery) Get(rootDomain string) (map[string]sets.Set[string], error) {
+	ibmClient, err := q.getIBMClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get IBM Cloud client")
+	}
+	zoneID, err := q.getZoneID(ibmClient, rootDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	records, err := ibmClient.ListCISDNSRecords(ctx, q.cisInstanceCRN, zoneID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying name servers")
+	}
+
+	nameServers := map[string]sets.Set[string]{}
+	for _, record := range records {
+		if record.Type == nil || *record.Type != dnsrecordsv1.CreateDnsRecordOptions_Type_Ns ||
+			record.Name == nil || record.Content == nil {
+			continue
+		}
+		domain := controllerutils.Undotted(*record.Name)
+		if nameServers[domain] == nil {
+			nameServers[domain] = sets.Set[string]{}
+		}
+		nameServers[domain].Insert(controllerutils.Undotted(*record.Content))
+	}
+	return nameServers, nil
+}
+
+// CreateOrUpdate implements Query.CreateOrUpdate.
+func (q *ibmCloudQuery) CreateOrUpdate(rootDomain string, domain string, values sets.Set[string]) error {
+	ibmClient, err := q.getIBMClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get IBM Cloud client")
+	}
+	zoneID, err := q.getZoneID(ibmClient, rootDomain)
+	if err != nil {
+		return err
+	}
+	records, err := q.getNameServerRecords(ibmClient, zoneID, domain)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	// CIS holds one record per name server, so only the differences need to be applied.
+	existing := sets.Set[string]{}
+	for _, record := range records {
+		value := controllerutils.Undotted(*record.Content)
+		if values.Has(value) {
+			existing.Insert(value)
+			continue
+		}
+		if err := ibmClient.DeleteCISDNSRecord(ctx, q.cisInstanceCRN, zoneID, *record.ID); err != nil {
+			return errors.Wrap(err, "error deleting stale name server")
+		}
+	}
+	for _, value := range sets.List(values.Difference(existing)) {
+		if _, err := ibmClient.CreateCISDNSRecord(
+			ctx,
+			q.cisInstanceCRN,
+			zoneID,
+			dnsrecordsv1.CreateDnsRecordOptions_Type_Ns,
+			domain,
+			value,
+			ibmCloudNSRecordTTL,
+		); err != nil {
+			return errors.Wrap(err, "error creating the name server")
+		}
+	}
+	return nil
+}
+
+// Delete implements Query.Delete.
+func (q *ibmCloudQuery) Delete(rootDomain string, domain string, values sets.Set[string]) error {
+	ibmClient, err := q.getIBMClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to get IBM Cloud client")
+	}
+	zoneID, err := q.getZoneID(ibmClient, rootDomain)
+	if err != nil {
+		return err
+	}
+	records, err := q.getNameServerRecords(ibmClient, zoneID, domain)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+	for _, record := range records {
+		if err := ibmClient.DeleteCISDNSRecord(ctx, q.cisInstanceCRN, zoneID, *record.ID); err != nil {
+			return errors.Wrap(err, "error deleting the name servers")
+		}
+	}
+	return nil
+}
+
+// getZoneID gets the ID of the CIS zone for the specified root domain.
+func (q *ibmCloudQuery) getZoneID(ibmClient ibmclient.API, rootDomain string) (string, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	zone, err := ibmClient.GetCISZoneByName(ctx, q.cisInstanceCRN, rootDomain)
+	if err != nil {
+		return "", errors.Wrap(err, "error getting the CIS zone")
+	}
+	if zone == nil || zone.ID == nil {
+		return "", errors.Errorf("no CIS zone found for %s", rootDomain)
+	}
+	return *zone.ID, nil
+}
+
+// getNameServerRecords gets the NS records for the specified domain in the specified CIS zone.
+func (q *ibmCloudQuery) getNameServerRecords(ibmClient ibmclient.API, zoneID string, domain string) ([]dnsrecordsv1.DnsrecordDetails, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	records, err := ibmClient.GetDNSRecordsByName(ctx, q.cisInstanceCRN, zoneID, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying name servers")
+	}
+	var nsRecords []dnsrecordsv1.DnsrecordDetails
+	for _, record := range records {
+		if record.ID == nil || record.Content == nil ||
+			record.Type == nil || *record.Type != dnsrecordsv1.CreateDnsRecordOptions_Type_Ns {
+			continue
+		}
+		nsRecords = append(nsRecords, record)
+	}
+	return nsRecords, nil
+}
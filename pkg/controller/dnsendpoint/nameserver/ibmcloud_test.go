@@ -0,0 +1,195 @@
+package nameserver
+
+import (
+	"testing"
+
+	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/hive/pkg/ibmclient"
+	"github.com/openshift/hive/pkg/ibmclient/mock"
+)
+
+const (
+	testCISInstanceCRN = "test-crn"
+	testCISZoneID      = "test-zone-id"
+)
+
+func TestIBMCloudGet(t *testing.T) {
+	cases := []struct {
+		name                string
+		records             []dnsrecordsv1.DnsrecordDetails
+		expectedNameServers map[string]sets.Set[string]
+	}{
+		{
+			name:    "single name server",
+			records: []dnsrecordsv1.DnsrecordDetails{ibmCloudRecord("1", "test-subdomain.test-domain", "NS", "test-ns")},
+			expectedNameServers: map[string]sets.Set[string]{
+				"test-subdomain.test-domain": sets.New("test-ns"),
+			},
+		},
+		{
+			name: "no records",
+		},
+		{
+			name:    "no name server records",
+			records: []dnsrecordsv1.DnsrecordDetails{ibmCloudRecord("1", "test-subdomain.test-domain", "A", "1.2.3.4")},
+		},
+		{
+			name: "multiple name servers",
+			records: []dnsrecordsv1.DnsrecordDetails{
+				ibmCloudRecord("1", "test-subdomain.test-domain", "NS", "test-ns-1"),
+				ibmCloudRecord("2", "test-subdomain.test-domain", "NS", "test-ns-2"),
+				ibmCloudRecord("3", "test-subdomain.test-domain", "NS", "test-ns-3"),
+			},
+			expectedNameServers: map[string]sets.Set[string]{
+				"test-subdomain.test-domain": sets.New("test-ns-1", "test-ns-2", "test-ns-3"),
+			},
+		},
+		{
+			name: "name servers for multiple domains",
+			records: []dnsrecordsv1.DnsrecordDetails{
+				ibmCloudRecord("1", "test-subdomain-1.test-domain", "NS", "test-ns-1"),
+				ibmCloudRecord("2", "test-subdomain-2.test-domain", "NS", "test-ns-2"),
+				ibmCloudRecord("3", "test-subdomain-3.test-domain", "NS", "test-ns-3"),
+			},
+			expectedNameServers: map[string]sets.Set[string]{
+				"test-subdomain-1.test-domain": sets.New("test-ns-1"),
+				"test-subdomain-2.test-domain": sets.New("test-ns-2"),
+				"test-subdomain-3.test-domain": sets.New("test-ns-3"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockIBMClient := mock.NewMockAPI(mockCtrl)
+			query := newTestIBMCloudQuery(mockIBMClient)
+
+			mockGetCISZone(mockIBMClient)
+			mockIBMClient.EXPECT().ListCISDNSRecords(gomock.Any(), testCISInstanceCRN, testCISZoneID).Return(tc.records, nil)
+
+			actualNameservers, err := query.Get("test-domain")
+			assert.NoError(t, err, "expected no error from querying")
+			if len(tc.expectedNameServers) == 0 {
+				assert.Empty(t, actualNameservers, "expected no name servers")
+			} else {
+				assert.Equal(t, tc.expectedNameServers, actualNameservers, "unexpected name servers")
+			}
+		})
+	}
+}
+
+func TestIBMCloudCreateOrUpdate(t *testing.T) {
+	cases := []struct {
+		name            string
+		existing        []dnsrecordsv1.DnsrecordDetails
+		values          sets.Set[string]
+		expectedCreated []string
+		expectedDeleted []string
+	}{
+		{
+			name:            "no existing name servers",
+			values:          sets.New("test-ns-1", "test-ns-2"),
+			expectedCreated: []string{"test-ns-1", "test-ns-2"},
+		},
+		{
+			name: "name servers already set",
+			existing: []dnsrecordsv1.DnsrecordDetails{
+				ibmCloudRecord("1", "test-subdomain.test-domain", "NS", "test-ns-1"),
+				ibmCloudRecord("2", "test-subdomain.test-domain", "NS", "test-ns-2"),
+			},
+			values: sets.New("test-ns-1", "test-ns-2"),
+		},
+		{
+			name: "stale name servers replaced",
+			existing: []dnsrecordsv1.DnsrecordDetails{
+				ibmCloudRecord("1", "test-subdomain.test-domain", "NS", "test-ns-1"),
+				ibmCloudRecord("2", "test-subdomain.test-domain", "NS", "old-ns"),
+				ibmCloudRecord("3", "test-subdomain.test-domain", "TXT", "test-txt"),
+			},
+			values:          sets.New("test-ns-1", "test-ns-2"),
+			expectedCreated: []string{"test-ns-2"},
+			expectedDeleted: []string{"2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockIBMClient := mock.NewMockAPI(mockCtrl)
+			query := newTestIBMCloudQuery(mockIBMClient)
+
+			mockGetCISZone(mockIBMClient)
+			mockIBMClient.EXPECT().GetDNSRecordsByName(gomock.Any(), testCISInstanceCRN, testCISZoneID, "test-subdomain.test-domain").
+				Return(tc.existing, nil)
+			for _, id := range tc.expectedDeleted {
+				mockIBMClient.EXPECT().DeleteCISDNSRecord(gomock.Any(), testCISInstanceCRN, testCISZoneID, id).Return(nil)
+			}
+			for _, value := range tc.expectedCreated {
+				mockIBMClient.EXPECT().CreateCISDNSRecord(gomock.Any(), testCISInstanceCRN, testCISZoneID, "NS",
+					"test-subdomain.test-domain", value, int64(ibmCloudNSRecordTTL)).Return(nil, nil)
+			}
+
+			err := query.CreateOrUpdate("test-domain", "test-subdomain.test-domain", tc.values)
+			assert.NoError(t, err, "expected no error from create or update")
+		})
+	}
+}
+
+func TestIBMCloudDelete(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockIBMClient := mock.NewMockAPI(mockCtrl)
+	query := newTestIBMCloudQuery(mockIBMClient)
+
+	mockGetCISZone(mockIBMClient)
+	mockIBMClient.EXPECT().GetDNSRecordsByName(gomock.Any(), testCISInstanceCRN, testCISZoneID, "test-subdomain.test-domain").
+		Return([]dnsrecordsv1.DnsrecordDetails{
+			ibmCloudRecord("1", "test-subdomain.test-domain", "NS", "test-ns-1"),
+			ibmCloudRecord("2", "test-subdomain.test-domain", "TXT", "test-txt"),
+		}, nil)
+	mockIBMClient.EXPECT().DeleteCISDNSRecord(gomock.Any(), testCISInstanceCRN, testCISZoneID, "1").Return(nil)
+
+	err := query.Delete("test-domain", "test-subdomain.test-domain", sets.New("test-ns-1"))
+	assert.NoError(t, err, "expected no error from delete")
+}
+
+func TestIBMCloudMissingZone(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockIBMClient := mock.NewMockAPI(mockCtrl)
+	query := newTestIBMCloudQuery(mockIBMClient)
+
+	mockIBMClient.EXPECT().GetCISZoneByName(gomock.Any(), testCISInstanceCRN, "test-domain").Return(nil, nil)
+
+	_, err := query.Get("test-domain")
+	assert.Error(t, err, "expected error when zone is missing")
+}
+
+func newTestIBMCloudQuery(ibmClient ibmclient.API) *ibmCloudQuery {
+	return &ibmCloudQuery{
+		getIBMClient: func() (ibmclient.API, error) {
+			return ibmClient, nil
+		},
+		cisInstanceCRN: testCISInstanceCRN,
+	}
+}
+
+func mockGetCISZone(ibmClient *mock.MockAPI) {
+	ibmClient.EXPECT().GetCISZoneByName(gomock.Any(), testCISInstanceCRN, "test-domain").
+		Return(&zonesv1.ZoneDetails{ID: ptr.To(testCISZoneID), Name: ptr.To("test-domain")}, nil)
+}
+
+func ibmCloudRecord(id, name, rType, content string) dnsrecordsv1.DnsrecordDetails {
+	return dnsrecordsv1.DnsrecordDetails{
+		ID:      ptr.To(id),
+		Name:    ptr.To(name),
+		Type:    ptr.To(rType),
+		Content: ptr.To(content),
+	}
+}
@@ -29,6 +29,10 @@ var (
 		Name: "hive_managed_dns_subdomains_scraped",
 		Help: "The number of subdomains of a root managed domain handled by the name server scraper.",
 	}, []string{"managed_domain"})
+	metricDanglingDelegations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_managed_dns_dangling_delegations",
+		Help: "The number of subdomains of a root managed domain delegated to name servers that do not serve them, without a DNSZone in this Hive.",
+	}, []string{"managed_domain"})
 	metricScrapeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "hive_managed_dns_scrape_seconds",
 		Help:    "How long it took to scrape a root domain.",
@@ -39,6 +43,7 @@ var (
 func init() {
 	metrics.Registry.MustRegister(metricSubdomainsScraped)
 	metrics.Registry.MustRegister(metricScrapeDuration)
+	metrics.Registry.MustRegister(metricDanglingDelegations)
 }
 
 type endpointState struct {
@@ -119,6 +124,14 @@ type nameServerScraper struct {
 	rootDomainsMap  rootDomainsMap
 	nameServerQuery nameserver.Query
 	notifyChange    func(client.Object)
+	// lameDelegationChecker, if set, is used after each scrape to find delegations in the root domain's hosted zone
+	// to name servers that no longer serve the subdomain, such as those left behind by deleted DNSZones.
+	lameDelegationChecker lameDelegationChecker
+}
+
+// lameDelegationChecker checks whether a delegation is dangling.
+type lameDelegationChecker interface {
+	IsLameDelegation(zone string, nameServers []string) (bool, error)
 }
 
 func newNameServerScraper(logger log.FieldLogger, nameServerQuery nameserver.Query, rootDomains []string, notifyChange func(client.Object)) *nameServerScraper {
@@ -249,6 +262,8 @@ func (s *nameServerScraper) scrape(rootDomain string) error {
 		return errors.Wrap(err, "error querying name servers")
 	}
 	changedDNSZones := []client.Object{}
+	// Subdomains delegated in the root hosted zone without a DNSZone in this controller's cache
+	unclaimedSubdomains := map[string]sets.Set[string]{}
 	func() {
 		s.mux.Lock()
 		defer s.mux.Unlock()
@@ -281,6 +296,9 @@ func (s *nameServerScraper) scrape(rootDomain string) error {
 			}
 			oldEndpoints.nsValues = nameServers
 			oldNSMap[subdomain] = oldEndpoints
+			if subdomain != rootDomain && oldEndpoints.dnsZone == nil {
+				unclaimedSubdomains[subdomain] = nameServers
+			}
 		}
 		// Now go through the cache looking for entries that were seeded by the dnsendpoint
 		// controller, but for which no entries were found in the root hosted zone. Those need to
@@ -298,11 +316,35 @@ func (s *nameServerScraper) scrape(rootDomain string) error {
 			Info("notify dnsendpoint controller of name server change")
 		s.notifyChange(changedDNSZone)
 	}
+	s.reportDanglingDelegations(rootDomain, unclaimedSubdomains)
 	metricScrapeDuration.With(prometheus.Labels{"managed_domain": rootDomain}).Observe(time.Since(start).Seconds())
 	metricSubdomainsScraped.With(prometheus.Labels{"managed_domain": rootDomain}).Set((float64)(len(currentNameServerMap)))
 	return nil
 }
 
+// reportDanglingDelegations logs and counts the given delegations in the root hosted zone whose name servers do not
+// serve the subdomain. These are not deleted, as they may belong to another Hive managing the same root domain.
+func (s *nameServerScraper) reportDanglingDelegations(rootDomain string, delegations map[string]sets.Set[string]) {
+	if s.lameDelegationChecker == nil {
+		return
+	}
+	logger := s.logger.WithField("rootDomain", rootDomain)
+	dangling := 0
+	for subdomain, nameServers := range delegations {
+		lame, err := s.lameDelegationChecker.IsLameDelegation(subdomain, sets.List(nameServers))
+		if err != nil {
+			logger.WithError(err).Warn("could not check for dangling delegations")
+			return
+		}
+		if lame {
+			dangling++
+			logger.WithField("subdomain", subdomain).WithField("nameServers", sets.List(nameServers)).
+				Warn("found dangling delegation in root domain without a DNSZone")
+		}
+	}
+	metricDanglingDelegations.With(prometheus.Labels{"managed_domain": rootDomain}).Set(float64(dangling))
+}
+
 func (s *nameServerScraper) rootDomainNameServers(domain string) (string, endpointsBySubdomain) {
 	for root, rdInfo := range s.rootDomainsMap {
 		if strings.HasSuffix(domain, root) {
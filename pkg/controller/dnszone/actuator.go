@@ -25,4 +25,28 @@ type Actuator interface {
 
 	// SetConditionsForError sets conditions on the dnszone given a specific error
 	SetConditionsForError(err error) bool
+
+	// SetTXTRecord creates or replaces the TXT record with the given name in the zone.
+	SetTXTRecord(name string, values []string) error
+
+	// DeleteTXTRecord removes the TXT record with the given name from the zone, if it exists.
+	DeleteTXTRecord(name string) error
+}
+
+// txtRecordTTL is the TTL of the TXT records set by actuators. TXT records are only set for short-lived uses such as
+// ACME challenges, so they are kept short.
+const txtRecordTTL = 60
+
+// zoneAvailabilityChecker is implemented by actuators whose zones cannot be checked for availability by looking up the
+// SOA record of the zone, such as when the records of the zone are served from the zone of a parent domain.
+type zoneAvailabilityChecker interface {
+	// IsZoneAvailable returns whether the records of the zone are being served.
+	IsZoneAvailable() (bool, error)
+}
+
+// recordsPublisher is implemented by actuators which publish records listed in the DNSZone, rather than leaving all the
+// records of the zone to the installer.
+type recordsPublisher interface {
+	// PublishRecords creates or replaces the records listed in the DNSZone.
+	PublishRecords() error
 }
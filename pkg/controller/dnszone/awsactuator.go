@@ -535,6 +535,68 @@ func (a *AWSActuator) GetNameServers() ([]string, error) {
 	return result, nil
 }
 
+// SetTXTRecord creates or replaces a TXT record in the route53 hosted zone.
+func (a *AWSActuator) SetTXTRecord(name string, values []string) error {
+	if a.hostedZone == nil {
+		return errors.New("hostedZone is unpopulated")
+	}
+	records := make([]route53types.ResourceRecord, len(values))
+	for i, value := range values {
+		records[i] = route53types.ResourceRecord{Value: aws.String(fmt.Sprintf("%q", value))}
+	}
+	a.logger.WithField("id", aws.ToString(a.hostedZone.Id)).WithField("name", name).Info("Setting TXT record")
+	_, err := a.awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: a.hostedZone.Id,
+		ChangeBatch: &route53types.ChangeBatch{
+			Changes: []route53types.Change{{
+				Action: route53types.ChangeActionUpsert,
+				ResourceRecordSet: &route53types.ResourceRecordSet{
+					Name:            aws.String(controllerutils.Dotted(name)),
+					Type:            route53types.RRTypeTxt,
+					TTL:             aws.Int64(txtRecordTTL),
+					ResourceRecords: records,
+				},
+			}},
+		},
+	})
+	return err
+}
+
+// DeleteTXTRecord removes a TXT record from the route53 hosted zone.
+func (a *AWSActuator) DeleteTXTRecord(name string) error {
+	if a.hostedZone == nil {
+		return errors.New("hostedZone is unpopulated")
+	}
+	logger := a.logger.WithField("id", aws.ToString(a.hostedZone.Id)).WithField("name", name)
+	resp, err := a.awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    a.hostedZone.Id,
+		StartRecordName: aws.String(controllerutils.Dotted(name)),
+		StartRecordType: route53types.RRTypeTxt,
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error listing recordsets for zone")
+		return err
+	}
+	if len(resp.ResourceRecordSets) == 0 ||
+		aws.ToString(resp.ResourceRecordSets[0].Name) != controllerutils.Dotted(name) ||
+		resp.ResourceRecordSets[0].Type != route53types.RRTypeTxt {
+		logger.Debug("TXT record does not exist")
+		return nil
+	}
+	logger.Info("Deleting TXT record")
+	_, err = a.awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: a.hostedZone.Id,
+		ChangeBatch: &route53types.ChangeBatch{
+			Changes: []route53types.Change{{
+				Action:            route53types.ChangeActionDelete,
+				ResourceRecordSet: &resp.ResourceRecordSets[0],
+			}},
+		},
+	})
+	return err
+}
+
 // Exists determines if the route53 hosted zone corresponding to the DNSZone exists
 func (a *AWSActuator) Exists() (bool, error) {
 	return a.hostedZone != nil, nil
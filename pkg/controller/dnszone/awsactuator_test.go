@@ -228,3 +228,79 @@ func mockGetResourcePages(expect *mock.MockClientMockRecorder) {
 		f(getResourcesOutput, true)
 	})
 }
+
+func TestAWSActuatorTXTRecords(t *testing.T) {
+	const recordName = "_acme-challenge.api.blah.example.com"
+	cases := []struct {
+		name         string
+		delete       bool
+		setupAWSMock func(*mock.MockClientMockRecorder)
+	}{
+		{
+			name: "set record",
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				expect.ChangeResourceRecordSets(gomock.Any()).
+					Do(func(input *route53.ChangeResourceRecordSetsInput) {
+						change := input.ChangeBatch.Changes[0]
+						assert.Equal(t, route53types.ChangeActionUpsert, change.Action, "unexpected change action")
+						assert.Equal(t, recordName+".", aws.ToString(change.ResourceRecordSet.Name), "unexpected record name")
+						assert.Equal(t, route53types.RRTypeTxt, change.ResourceRecordSet.Type, "unexpected record type")
+						assert.Equal(t, `"token"`, aws.ToString(change.ResourceRecordSet.ResourceRecords[0].Value), "unexpected record value")
+					}).
+					Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Times(1)
+			},
+		},
+		{
+			name:   "delete existing record",
+			delete: true,
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				expect.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []route53types.ResourceRecordSet{{
+						Name:            aws.String(recordName + "."),
+						Type:            route53types.RRTypeTxt,
+						ResourceRecords: []route53types.ResourceRecord{{Value: aws.String(`"token"`)}},
+					}},
+				}, nil).Times(1)
+				expect.ChangeResourceRecordSets(gomock.Any()).
+					Do(func(input *route53.ChangeResourceRecordSetsInput) {
+						assert.Equal(t, route53types.ChangeActionDelete, input.ChangeBatch.Changes[0].Action, "unexpected change action")
+					}).
+					Return(&route53.ChangeResourceRecordSetsOutput{}, nil).Times(1)
+			},
+		},
+		{
+			name:   "delete missing record",
+			delete: true,
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				expect.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []route53types.ResourceRecordSet{{
+						Name: aws.String("other.blah.example.com."),
+						Type: route53types.RRTypeTxt,
+					}},
+				}, nil).Times(1)
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t)
+			tc.setupAWSMock(mocks.mockAWSClient.EXPECT())
+			actuator := &AWSActuator{
+				logger:    log.WithField("controller", ControllerName),
+				awsClient: mocks.mockAWSClient,
+				dnsZone:   validDNSZone(),
+				hostedZone: &route53types.HostedZone{
+					Id:   aws.String("/hostedzone/1234"),
+					Name: aws.String("blah.example.com."),
+				},
+			}
+			var err error
+			if tc.delete {
+				err = actuator.DeleteTXTRecord(recordName)
+			} else {
+				err = actuator.SetTXTRecord(recordName, []string{"token"})
+			}
+			assert.NoError(t, err, "unexpected error")
+		})
+	}
+}
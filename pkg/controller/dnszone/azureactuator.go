@@ -139,6 +139,57 @@ func DeleteAzureRecordSets(azureClient azureclient.Client, dnsZone *hivev1.DNSZo
 	return nil
 }
 
+// SetTXTRecord implements the SetTXTRecord call of the actuator interface
+func (a *AzureActuator) SetTXTRecord(name string, values []string) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+	records := make([]dns.TxtRecord, len(values))
+	for i := range values {
+		records[i] = dns.TxtRecord{Value: &[]string{values[i]}}
+	}
+	ttl := int64(txtRecordTTL)
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Setting TXT record")
+	_, err := a.azureClient.CreateOrUpdateRecordSet(
+		context.TODO(),
+		a.dnsZone.Spec.Azure.ResourceGroupName,
+		a.dnsZone.Spec.Zone,
+		a.relativeRecordName(name),
+		dns.TXT,
+		dns.RecordSet{
+			RecordSetProperties: &dns.RecordSetProperties{
+				TTL:        &ttl,
+				TxtRecords: &records,
+			},
+		},
+	)
+	return err
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the actuator interface
+func (a *AzureActuator) DeleteTXTRecord(name string) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Deleting TXT record")
+	return a.azureClient.DeleteRecordSet(
+		context.TODO(),
+		a.dnsZone.Spec.Azure.ResourceGroupName,
+		a.dnsZone.Spec.Zone,
+		a.relativeRecordName(name),
+		dns.TXT,
+	)
+}
+
+// relativeRecordName returns the name of a record relative to the zone, as Azure DNS expects.
+func (a *AzureActuator) relativeRecordName(name string) string {
+	name = controllerutils.Undotted(name)
+	if name == a.dnsZone.Spec.Zone {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+a.dnsZone.Spec.Zone)
+}
+
 // Exists implements the Exists call of the actuator interface
 func (a *AzureActuator) Exists() (bool, error) {
 	return a.managedZone != nil, nil
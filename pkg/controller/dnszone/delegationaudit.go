@@ -0,0 +1,133 @@
+package dnszone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/manageddns"
+)
+
+const (
+	delegationVerifiedReason = "DelegationVerified"
+	delegationMismatchReason = "DelegationMismatch"
+	delegationAuditFailed    = "AuditFailed"
+)
+
+var (
+	metricDelegationAudits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_dnszone_delegation_audits_total",
+		Help: "Counter incremented every time the delegation of a dnszone is audited, by result (verified, mismatch or failed).",
+	},
+		[]string{"result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricDelegationAudits)
+}
+
+// delegationAuditor audits the delegation of a zone from its parent zone.
+type delegationAuditor interface {
+	AuditDelegation(zone string, expectedNameServers []string) (*manageddns.DelegationReport, error)
+}
+
+// configureDelegationAudit enables delegation audits on the reconciler if HiveConfig configures them.
+func (r *ReconcileDNSZone) configureDelegationAudit() {
+	config, err := manageddns.ReadDelegationAuditConfigFile()
+	if err != nil {
+		r.logger.WithError(err).Error("could not read the DNS delegation audit config, delegations will not be audited")
+		return
+	}
+	if config == nil {
+		return
+	}
+	auditor, err := manageddns.NewDelegationAuditor(config.Resolvers)
+	if err != nil {
+		r.logger.WithError(err).Error("could not create the DNS delegation auditor, delegations will not be audited")
+		return
+	}
+	r.delegationAuditor = auditor
+	r.delegationAuditInterval = manageddns.DelegationAuditInterval(config)
+}
+
+// reconcileDelegationAudit audits the delegation of an available zone linked to its parent domain once every audit
+// interval, recording the result in the DelegationVerified condition.
+func (r *ReconcileDNSZone) reconcileDelegationAudit(dnsZone *hivev1.DNSZone, logger log.FieldLogger) (reconcile.Result, error) {
+	if r.delegationAuditor == nil || !dnsZone.Spec.LinkToParentDomain || dnsZone.DeletionTimestamp != nil ||
+		len(dnsZone.Status.NameServers) == 0 {
+		return reconcile.Result{}, nil
+	}
+	availableCondition := controllerutils.FindCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+	if availableCondition == nil || availableCondition.Status != corev1.ConditionTrue {
+		return reconcile.Result{}, nil
+	}
+	if cond := controllerutils.FindCondition(dnsZone.Status.Conditions, hivev1.DelegationVerifiedCondition); cond != nil {
+		if since := time.Since(cond.LastProbeTime.Time); since < r.delegationAuditInterval {
+			return reconcile.Result{RequeueAfter: r.delegationAuditInterval - since}, nil
+		}
+	}
+
+	logger.Info("auditing zone delegation")
+	var status corev1.ConditionStatus
+	var reason, message string
+	report, err := r.delegationAuditor.AuditDelegation(dnsZone.Spec.Zone, dnsZone.Status.NameServers)
+	switch {
+	case err != nil:
+		logger.WithError(err).Warn("could not audit zone delegation")
+		metricDelegationAudits.WithLabelValues("failed").Inc()
+		status, reason, message = corev1.ConditionUnknown, delegationAuditFailed, controllerutils.ErrorScrub(err)
+	case report.Verified():
+		logger.WithField("parentZone", report.ParentZone).Info("zone delegation verified")
+		metricDelegationAudits.WithLabelValues("verified").Inc()
+		status, reason = corev1.ConditionTrue, delegationVerifiedReason
+		message = fmt.Sprintf("Delegation from parent zone %s matches the zone name servers", report.ParentZone)
+	default:
+		logger.WithField("parentZone", report.ParentZone).WithField("problems", report.Problems).Warn("zone delegation mismatch")
+		metricDelegationAudits.WithLabelValues("mismatch").Inc()
+		status, reason, message = corev1.ConditionFalse, delegationMismatchReason, strings.Join(report.Problems, "; ")
+	}
+
+	dnsZone.Status.Conditions = setDelegationVerifiedCondition(dnsZone.Status.Conditions, status, reason, message)
+	if err := r.Status().Update(context.TODO(), dnsZone); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update dnszone status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: r.delegationAuditInterval}, nil
+}
+
+// setDelegationVerifiedCondition records an audit in the DelegationVerified condition. Unlike other DNSZone
+// conditions, it is added whatever its status, and its probe time is updated on every audit.
+func setDelegationVerifiedCondition(conditions []hivev1.DNSZoneCondition, status corev1.ConditionStatus, reason, message string) []hivev1.DNSZoneCondition {
+	if controllerutils.FindCondition(conditions, hivev1.DelegationVerifiedCondition) == nil {
+		now := metav1.Now()
+		return append(conditions, hivev1.DNSZoneCondition{
+			Type:               hivev1.DelegationVerifiedCondition,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		})
+	}
+	conditions, _ = controllerutils.SetDNSZoneConditionWithChangeCheck(
+		conditions,
+		hivev1.DelegationVerifiedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionAlways,
+	)
+	return conditions
+}
@@ -0,0 +1,145 @@
+package dnszone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/manageddns"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+type fakeDelegationAuditor struct {
+	report *manageddns.DelegationReport
+	err    error
+	audits int
+}
+
+func (a *fakeDelegationAuditor) AuditDelegation(zone string, expectedNameServers []string) (*manageddns.DelegationReport, error) {
+	a.audits++
+	return a.report, a.err
+}
+
+func availableDNSZoneWithLinkToParent() *hivev1.DNSZone {
+	zone := validDNSZoneWithLinkToParent()
+	zone.Status.NameServers = []string{"ns1.example.com", "ns2.example.com"}
+	zone.Status.Conditions = []hivev1.DNSZoneCondition{{
+		Type:   hivev1.ZoneAvailableDNSZoneCondition,
+		Status: corev1.ConditionTrue,
+	}}
+	return zone
+}
+
+func TestReconcileDelegationAudit(t *testing.T) {
+	const interval = time.Hour
+	withDelegationCondition := func(probed time.Time) *hivev1.DNSZone {
+		zone := availableDNSZoneWithLinkToParent()
+		zone.Status.Conditions = append(zone.Status.Conditions, hivev1.DNSZoneCondition{
+			Type:          hivev1.DelegationVerifiedCondition,
+			Status:        corev1.ConditionTrue,
+			Reason:        delegationVerifiedReason,
+			LastProbeTime: metav1.NewTime(probed),
+		})
+		return zone
+	}
+
+	cases := []struct {
+		name            string
+		dnsZone         *hivev1.DNSZone
+		report          *manageddns.DelegationReport
+		auditErr        error
+		expectAudit     bool
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:    "zone not linked to parent",
+			dnsZone: validDNSZone(),
+		},
+		{
+			name: "zone not available",
+			dnsZone: func() *hivev1.DNSZone {
+				zone := availableDNSZoneWithLinkToParent()
+				zone.Status.Conditions = nil
+				return zone
+			}(),
+		},
+		{
+			name:    "audited recently",
+			dnsZone: withDelegationCondition(time.Now().Add(-10 * time.Minute)),
+		},
+		{
+			name:            "delegation verified",
+			dnsZone:         availableDNSZoneWithLinkToParent(),
+			report:          &manageddns.DelegationReport{Zone: "blah.example.com", ParentZone: "example.com"},
+			expectAudit:     true,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  delegationVerifiedReason,
+			expectedMessage: "Delegation from parent zone example.com matches the zone name servers",
+		},
+		{
+			name:    "delegation mismatch after interval",
+			dnsZone: withDelegationCondition(time.Now().Add(-2 * interval)),
+			report: &manageddns.DelegationReport{
+				Zone:       "blah.example.com",
+				ParentZone: "example.com",
+				Problems:   []string{"first problem", "second problem"},
+			},
+			expectAudit:     true,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  delegationMismatchReason,
+			expectedMessage: "first problem; second problem",
+		},
+		{
+			name:            "audit failed",
+			dnsZone:         availableDNSZoneWithLinkToParent(),
+			auditErr:        errors.New("no resolver answered"),
+			expectAudit:     true,
+			expectedStatus:  corev1.ConditionUnknown,
+			expectedReason:  delegationAuditFailed,
+			expectedMessage: "no resolver answered",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditor := &fakeDelegationAuditor{report: tc.report, err: tc.auditErr}
+			r := &ReconcileDNSZone{
+				Client:                  testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.dnsZone).Build(),
+				logger:                  log.WithField("controller", ControllerName),
+				delegationAuditor:       auditor,
+				delegationAuditInterval: interval,
+			}
+
+			result, err := r.reconcileDelegationAudit(tc.dnsZone, r.logger)
+			require.NoError(t, err, "unexpected error")
+			if !tc.expectAudit {
+				assert.Zero(t, auditor.audits, "expected no audit")
+				return
+			}
+			assert.Equal(t, 1, auditor.audits, "expected a single audit")
+			assert.Equal(t, interval, result.RequeueAfter, "unexpected requeue")
+
+			zone := &hivev1.DNSZone{}
+			require.NoError(t, r.Get(context.TODO(), types.NamespacedName{Namespace: tc.dnsZone.Namespace, Name: tc.dnsZone.Name}, zone))
+			cond := controllerutils.FindCondition(zone.Status.Conditions, hivev1.DelegationVerifiedCondition)
+			if assert.NotNil(t, cond, "expected DelegationVerified condition") {
+				assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectedReason, cond.Reason, "unexpected condition reason")
+				assert.Equal(t, tc.expectedMessage, cond.Message, "unexpected condition message")
+				assert.WithinDuration(t, time.Now(), cond.LastProbeTime.Time, time.Minute, "expected probe time to be updated")
+			}
+		})
+	}
+}
@@ -2,10 +2,7 @@ package dnszone
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/miekg/dns"
@@ -19,6 +16,7 @@ import (
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/manageddns"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,8 +38,6 @@ const (
 	zoneResyncDuration              = 2 * time.Hour
 	domainAvailabilityCheckInterval = 30 * time.Second
 	dnsClientTimeout                = 30 * time.Second
-	resolverConfigFile              = "/etc/resolv.conf"
-	zoneCheckDNSServersEnvVar       = "ZONE_CHECK_DNS_SERVERS"
 	accessDeniedReason              = "AccessDenied"
 	accessGrantedReason             = "AccessGranted"
 	authenticationFailedReason      = "AuthenticationFailed"
@@ -79,11 +75,13 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileDNSZone {
-	return &ReconcileDNSZone{
+	r := &ReconcileDNSZone{
 		Client:    controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger:    log.WithField("controller", ControllerName),
 		soaLookup: lookupSOARecord,
 	}
+	r.configureDelegationAudit()
+	return r
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -126,6 +124,12 @@ type ReconcileDNSZone struct {
 
 	// soaLookup is a function that looks up a zone's SOA record
 	soaLookup func(string, log.FieldLogger) (bool, error)
+
+	// delegationAuditor audits the delegation of zones linked to their parent domain. Nil if audits are disabled.
+	delegationAuditor delegationAuditor
+
+	// delegationAuditInterval is how often the delegation of each zone is audited.
+	delegationAuditInterval time.Duration
 }
 
 // Reconcile reads that state of the cluster for a DNSZone object and makes changes based on the state read
@@ -181,7 +185,7 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 			"lastSyncedGeneration": desiredState.Status.LastSyncGeneration,
 		}).Debug("Sync not needed")
 
-		return reconcile.Result{}, nil
+		return r.reconcileDelegationAudit(desiredState, dnsLog)
 	}
 
 	actuator, actErr := r.getActuator(desiredState, dnsLog)
@@ -322,13 +326,25 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 		}
 	}
 
+	if publisher, ok := actuator.(recordsPublisher); ok {
+		if err := publisher.PublishRecords(); err != nil {
+			logger.WithError(err).Error("Failed to publish records in hosted zone")
+			return reconcile.Result{}, err
+		}
+	}
+
 	nameServers, err := actuator.GetNameServers()
 	if err != nil {
 		logger.WithError(err).Error("Failed to get hosted zone name servers")
 		return reconcile.Result{}, err
 	}
 
-	isZoneSOAAvailable, err := r.soaLookup(dnsZone.Spec.Zone, logger)
+	var isZoneSOAAvailable bool
+	if checker, ok := actuator.(zoneAvailabilityChecker); ok {
+		isZoneSOAAvailable, err = checker.IsZoneAvailable()
+	} else {
+		isZoneSOAAvailable, err = r.soaLookup(dnsZone.Spec.Zone, logger)
+	}
 	if err != nil {
 		logger.WithError(err).Error("error looking up SOA record for zone")
 	}
@@ -388,6 +404,12 @@ func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 }
 
 func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.FieldLogger) (Actuator, error) {
+	return NewActuator(r.Client, dnsZone, dnsLog)
+}
+
+// NewActuator returns the actuator for the DNS provider of a DNSZone, using the credentials referenced by the
+// DNSZone. Refresh must be called on the actuator before it is used.
+func NewActuator(c client.Client, dnsZone *hivev1.DNSZone, dnsLog log.FieldLogger) (Actuator, error) {
 	if dnsZone.Spec.AWS != nil {
 		credentials := awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
@@ -403,12 +425,12 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 			},
 		}
 
-		return NewAWSActuator(dnsLog, r.Client, credentials, dnsZone, awsclient.New)
+		return NewAWSActuator(dnsLog, c, credentials, dnsZone, awsclient.New)
 	}
 
 	if dnsZone.Spec.GCP != nil {
 		secret := &corev1.Secret{}
-		err := r.Get(context.TODO(),
+		err := c.Get(context.TODO(),
 			types.NamespacedName{
 				Name:      dnsZone.Spec.GCP.CredentialsSecretRef.Name,
 				Namespace: dnsZone.Namespace,
@@ -423,7 +445,7 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 
 	if dnsZone.Spec.Azure != nil {
 		secret := &corev1.Secret{}
-		err := r.Get(context.TODO(),
+		err := c.Get(context.TODO(),
 			types.NamespacedName{
 				Name:      dnsZone.Spec.Azure.CredentialsSecretRef.Name,
 				Namespace: dnsZone.Namespace,
@@ -436,6 +458,46 @@ func (r *ReconcileDNSZone) getActuator(dnsZone *hivev1.DNSZone, dnsLog log.Field
 		return NewAzureActuator(dnsLog, secret, dnsZone, azureclient.NewClientFromSecret)
 	}
 
+	if dnsZone.Spec.IBMCloud != nil {
+		secret := &corev1.Secret{}
+		err := c.Get(context.TODO(),
+			types.NamespacedName{
+				Name:      dnsZone.Spec.IBMCloud.CredentialsSecretRef.Name,
+				Namespace: dnsZone.Namespace,
+			},
+			secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIBMCloudActuator(dnsLog, secret, dnsZone, newIBMClientFromSecret)
+	}
+
+	if dnsZone.Spec.RFC2136 != nil {
+		// The TSIG key is only available for name servers of managed domains, and lives in the hive namespace.
+		managedDomains, err := manageddns.ReadManagedDomainsFile()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read managed domains file")
+		}
+		md, _ := manageddns.FindManagedDomain(managedDomains, dnsZone.Spec.Zone)
+		if md == nil || md.RFC2136 == nil || md.RFC2136.Server != dnsZone.Spec.RFC2136.Server {
+			return nil, errors.Errorf("no RFC2136 managed domain with server %s configured for zone %s",
+				dnsZone.Spec.RFC2136.Server, dnsZone.Spec.Zone)
+		}
+		secret := &corev1.Secret{}
+		err = c.Get(context.TODO(),
+			types.NamespacedName{
+				Name:      md.RFC2136.TSIGSecretRef.Name,
+				Namespace: controllerutils.GetHiveNamespace(),
+			},
+			secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewRFC2136Actuator(dnsLog, md.RFC2136, secret, dnsZone)
+	}
+
 	return nil, errors.New("unable to determine which actuator to use")
 }
 
@@ -480,24 +542,11 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 }
 
 func lookupSOARecord(zone string, logger log.FieldLogger) (bool, error) {
-	// TODO: determine if there's a better way to obtain resolver endpoints
-	clientConfig, _ := dns.ClientConfigFromFile(resolverConfigFile)
 	client := dns.Client{Timeout: dnsClientTimeout}
 
-	dnsServers := []string{}
-	serversFromEnv := os.Getenv(zoneCheckDNSServersEnvVar)
-	if len(serversFromEnv) > 0 {
-		dnsServers = strings.Split(serversFromEnv, ",")
-		// Add port to servers with unspecified port
-		for i := range dnsServers {
-			if !strings.Contains(dnsServers[i], ":") {
-				dnsServers[i] = dnsServers[i] + ":53"
-			}
-		}
-	} else {
-		for _, s := range clientConfig.Servers {
-			dnsServers = append(dnsServers, fmt.Sprintf("%s:%s", s, clientConfig.Port))
-		}
+	dnsServers, err := manageddns.DefaultResolvers()
+	if err != nil {
+		return false, err
 	}
 	logger.WithField("servers", dnsServers).Info("looking up domain SOA record")
 
@@ -2,6 +2,7 @@ package dnszone
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -158,6 +159,62 @@ func DeleteGCPRecordSets(gcpClient gcpclient.Client, dnsZone *hivev1.DNSZone, lo
 	return nil
 }
 
+// SetTXTRecord implements the SetTXTRecord call of the actuator interface
+func (a *GCPActuator) SetTXTRecord(name string, values []string) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+	existing, err := a.getTXTRecord(name)
+	if err != nil {
+		return err
+	}
+	rrdatas := make([]string, len(values))
+	for i, value := range values {
+		rrdatas[i] = strconv.Quote(value)
+	}
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Setting TXT record")
+	return a.gcpClient.UpdateResourceRecordSet(
+		a.managedZone.Name,
+		&dns.ResourceRecordSet{
+			Name:    controllerutils.Dotted(name),
+			Type:    "TXT",
+			Ttl:     txtRecordTTL,
+			Rrdatas: rrdatas,
+		},
+		existing,
+	)
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the actuator interface
+func (a *GCPActuator) DeleteTXTRecord(name string) error {
+	if a.managedZone == nil {
+		return errors.New("managedZone is unpopulated")
+	}
+	existing, err := a.getTXTRecord(name)
+	if err != nil || existing == nil {
+		return err
+	}
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Deleting TXT record")
+	return a.gcpClient.DeleteResourceRecordSet(a.managedZone.Name, existing)
+}
+
+func (a *GCPActuator) getTXTRecord(name string) (*dns.ResourceRecordSet, error) {
+	resp, err := a.gcpClient.ListResourceRecordSets(a.managedZone.Name, gcpclient.ListResourceRecordSetsOptions{
+		Name: controllerutils.Dotted(name),
+		Type: "TXT",
+	})
+	if err != nil {
+		a.logger.WithError(err).Error("Error listing recordsets for zone")
+		return nil, err
+	}
+	for _, recordSet := range resp.Rrsets {
+		if recordSet.Name == controllerutils.Dotted(name) && recordSet.Type == "TXT" {
+			return recordSet, nil
+		}
+	}
+	return nil, nil
+}
+
 // Exists implements the Exists call of the actuator interface
 func (a *GCPActuator) Exists() (bool, error) {
 	return a.managedZone != nil, nil
@@ -0,0 +1,266 @@
+package dnszone
+
+import (
+	"context"
+
+	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ibmclient"
+)
+
+// IBMCloudActuator attempts to make the current state reflect the given desired state.
+type IBMCloudActuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// ibmClient is a utility for making it easy for controllers to interface with IBM Cloud
+	ibmClient ibmclient.API
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// zone is the IBM Cloud Internet Services zone object.
+	zone *zonesv1.ZoneDetails
+}
+
+type ibmClientBuilderType func(secret *corev1.Secret) (ibmclient.API, error)
+
+// NewIBMCloudActuator creates a new IBMCloudActuator object. A new IBMCloudActuator is expected to be created for each controller sync.
+func NewIBMCloudActuator(
+	logger log.FieldLogger,
+	secret *corev1.Secret,
+	dnsZone *hivev1.DNSZone,
+	ibmClientBuilder ibmClientBuilderType,
+) (*IBMCloudActuator, error) {
+	ibmClient, err := ibmClientBuilder(secret)
+	if err != nil {
+		logger.WithError(err).Error("Error creating IBMClient")
+		return nil, err
+	}
+
+	ibmCloudActuator := &IBMCloudActuator{
+		logger:    logger,
+		ibmClient: ibmClient,
+		dnsZone:   dnsZone,
+	}
+
+	return ibmCloudActuator, nil
+}
+
+// newIBMClientFromSecret adapts ibmclient.NewClientFromSecret to return the API interface.
+func newIBMClientFromSecret(secret *corev1.Secret) (ibmclient.API, error) {
+	return ibmclient.NewClientFromSecret(secret)
+}
+
+// Ensure IBMCloudActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &IBMCloudActuator{}
+
+// Create implements the Create call of the actuator interface
+func (a *IBMCloudActuator) Create() error {
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Info("Creating CIS zone")
+
+	zone, err := a.ibmClient.CreateCISZone(context.TODO(), a.dnsZone.Spec.IBMCloud.CISInstanceCRN, a.dnsZone.Spec.Zone)
+	if err != nil {
+		logger.WithError(err).Error("Error creating CIS zone")
+		return err
+	}
+
+	logger.Debug("CIS zone successfully created")
+	a.zone = zone
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to modify DNSZone status")
+		return err
+	}
+	return nil
+}
+
+// Delete implements the Delete call of the actuator interface
+func (a *IBMCloudActuator) Delete() error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("zoneID", *a.zone.ID)
+
+	logger.Info("Deleting DNS records in CIS zone")
+	if err := DeleteIBMCloudDNSRecords(a.ibmClient, a.dnsZone, *a.zone.ID, logger); err != nil {
+		return err
+	}
+
+	logger.Info("Deleting CIS zone")
+	err := a.ibmClient.DeleteCISZone(context.TODO(), a.dnsZone.Spec.IBMCloud.CISInstanceCRN, *a.zone.ID)
+	if err != nil {
+		logger.WithError(err).Error("Cannot delete CIS zone")
+	}
+	return err
+}
+
+// DeleteIBMCloudDNSRecords will remove all non-essential records from the CIS zone of the DNSZone provided.
+func DeleteIBMCloudDNSRecords(ibmClient ibmclient.API, dnsZone *hivev1.DNSZone, zoneID string, logger log.FieldLogger) error {
+	crn := dnsZone.Spec.IBMCloud.CISInstanceCRN
+	records, err := ibmClient.ListCISDNSRecords(context.TODO(), crn, zoneID)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record.ID == nil || record.Name == nil || record.Type == nil {
+			logger.Warn("found DNS record with missing id, name or type")
+			continue
+		}
+		// Ignore the records that are created with the zone
+		if n, t := controllerutils.Undotted(*record.Name), *record.Type; n == dnsZone.Spec.Zone && (t == "NS" || t == "SOA") {
+			continue
+		}
+		logger.WithField("name", *record.Name).WithField("type", *record.Type).Info("deleting DNS record")
+		if err := ibmClient.DeleteCISDNSRecord(context.TODO(), crn, zoneID, *record.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetTXTRecord implements the SetTXTRecord call of the actuator interface
+func (a *IBMCloudActuator) SetTXTRecord(name string, values []string) error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+	if err := a.DeleteTXTRecord(name); err != nil {
+		return err
+	}
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Setting TXT record")
+	for _, value := range values {
+		if _, err := a.ibmClient.CreateCISDNSRecord(
+			context.TODO(),
+			a.dnsZone.Spec.IBMCloud.CISInstanceCRN,
+			*a.zone.ID,
+			dnsrecordsv1.CreateDnsRecordOptions_Type_Txt,
+			controllerutils.Undotted(name),
+			value,
+			txtRecordTTL,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the actuator interface
+func (a *IBMCloudActuator) DeleteTXTRecord(name string) error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+	crn := a.dnsZone.Spec.IBMCloud.CISInstanceCRN
+	records, err := a.ibmClient.GetDNSRecordsByName(context.TODO(), crn, *a.zone.ID, controllerutils.Undotted(name))
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record.ID == nil || record.Type == nil || *record.Type != dnsrecordsv1.CreateDnsRecordOptions_Type_Txt {
+			continue
+		}
+		a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Deleting TXT record")
+		if err := a.ibmClient.DeleteCISDNSRecord(context.TODO(), crn, *a.zone.ID, *record.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *IBMCloudActuator) Exists() (bool, error) {
+	return a.zone != nil, nil
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *IBMCloudActuator) UpdateMetadata() error {
+	// Nothing to do here since CIS zones don't support tags.
+	return nil
+}
+
+// modifyStatus updates the DnsZone's status with IBM Cloud specific information.
+func (a *IBMCloudActuator) modifyStatus() error {
+	if a.zone == nil {
+		return errors.New("zone is unpopulated")
+	}
+
+	a.dnsZone.Status.IBMCloud = &hivev1.IBMCloudDNSZoneStatus{
+		ZoneID: a.zone.ID,
+	}
+
+	return nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *IBMCloudActuator) GetNameServers() ([]string, error) {
+	if a.zone == nil {
+		return nil, errors.New("zone is unpopulated")
+	}
+
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	result := a.zone.NameServers
+	logger.WithField("nameservers", result).Debug("found CIS zone name servers")
+	return result, nil
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *IBMCloudActuator) Refresh() error {
+	// Fetch the zone
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone)
+	logger.Debug("Fetching CIS zone by zone name")
+	zone, err := a.ibmClient.GetCISZoneByName(context.TODO(), a.dnsZone.Spec.IBMCloud.CISInstanceCRN, a.dnsZone.Spec.Zone)
+	if err != nil {
+		logger.WithError(err).Error("Cannot get CIS zone")
+		return err
+	}
+	if zone == nil {
+		logger.Debug("Zone not found, clearing out the cached object")
+		a.zone = nil
+		return nil
+	}
+
+	logger.Debug("Found CIS zone")
+	a.zone = zone
+	if err := a.modifyStatus(); err != nil {
+		logger.WithError(err).Error("failed to modify DNSZone status")
+		return err
+	}
+	return nil
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *IBMCloudActuator) SetConditionsForError(err error) bool {
+	// other conditions not implemented for IBM Cloud yet, so set generic condition
+	var cloudErrorsConds []hivev1.DNSZoneCondition
+	var cloudErrorsCondsChanged bool
+	if err == nil {
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionFalse,
+			dnsNoErrorReason,
+			"No cloud errors occurred",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	} else {
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionTrue,
+			dnsCloudErrorReason,
+			controllerutils.ErrorScrub(err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if cloudErrorsCondsChanged {
+		a.dnsZone.Status.Conditions = cloudErrorsConds
+	}
+	return cloudErrorsCondsChanged
+}
@@ -0,0 +1,145 @@
+package dnszone
+
+import (
+	"testing"
+
+	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/ibmclient"
+	"github.com/openshift/hive/pkg/ibmclient/mock"
+)
+
+const testCISInstanceCRN = "crn:v1:bluemix:public:internet-svcs:global:a/1234:5678::"
+
+func validIBMCloudDNSZone() *hivev1.DNSZone {
+	zone := validDNSZone()
+	zone.Spec.AWS = nil
+	zone.Spec.IBMCloud = &hivev1.IBMCloudDNSZoneSpec{
+		CredentialsSecretRef: corev1.LocalObjectReference{Name: "somesecret"},
+		CISInstanceCRN:       testCISInstanceCRN,
+	}
+	zone.Status.AWS = nil
+	return zone
+}
+
+func testCISZone() *zonesv1.ZoneDetails {
+	return &zonesv1.ZoneDetails{
+		ID:          ptr.To("zone-id"),
+		Name:        ptr.To("blah.example.com"),
+		NameServers: []string{"ns1.example.com", "ns2.example.com"},
+	}
+}
+
+func testCISRecord(id, name, rType string) dnsrecordsv1.DnsrecordDetails {
+	return dnsrecordsv1.DnsrecordDetails{ID: ptr.To(id), Name: ptr.To(name), Type: ptr.To(rType)}
+}
+
+func TestIBMCloudActuatorLifecycle(t *testing.T) {
+	cases := []struct {
+		name            string
+		existingZone    *zonesv1.ZoneDetails
+		setupMock       func(*mock.MockAPIMockRecorder)
+		delete          bool
+		expectExists    bool
+		expectedZoneID  string
+		expectedServers []string
+	}{
+		{
+			name: "create missing zone",
+			setupMock: func(expect *mock.MockAPIMockRecorder) {
+				expect.CreateCISZone(gomock.Any(), testCISInstanceCRN, "blah.example.com").Return(testCISZone(), nil).Times(1)
+			},
+			expectExists:    true,
+			expectedZoneID:  "zone-id",
+			expectedServers: []string{"ns1.example.com", "ns2.example.com"},
+		},
+		{
+			name:            "existing zone",
+			existingZone:    testCISZone(),
+			expectExists:    true,
+			expectedZoneID:  "zone-id",
+			expectedServers: []string{"ns1.example.com", "ns2.example.com"},
+		},
+		{
+			name:         "delete zone keeps apex records until zone deletion",
+			existingZone: testCISZone(),
+			delete:       true,
+			setupMock: func(expect *mock.MockAPIMockRecorder) {
+				expect.ListCISDNSRecords(gomock.Any(), testCISInstanceCRN, "zone-id").Return([]dnsrecordsv1.DnsrecordDetails{
+					testCISRecord("1", "blah.example.com", "NS"),
+					testCISRecord("2", "blah.example.com", "SOA"),
+					testCISRecord("3", "api.blah.example.com", "A"),
+				}, nil).Times(1)
+				expect.DeleteCISDNSRecord(gomock.Any(), testCISInstanceCRN, "zone-id", "3").Return(nil).Times(1)
+				expect.DeleteCISZone(gomock.Any(), testCISInstanceCRN, "zone-id").Return(nil).Times(1)
+			},
+			expectExists:   true,
+			expectedZoneID: "zone-id",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockIBMClient := mock.NewMockAPI(mockCtrl)
+			mockIBMClient.EXPECT().GetCISZoneByName(gomock.Any(), testCISInstanceCRN, "blah.example.com").Return(tc.existingZone, nil).Times(1)
+			if tc.setupMock != nil {
+				tc.setupMock(mockIBMClient.EXPECT())
+			}
+			dnsZone := validIBMCloudDNSZone()
+			actuator, err := NewIBMCloudActuator(log.WithField("controller", ControllerName), nil, dnsZone,
+				func(*corev1.Secret) (ibmclient.API, error) { return mockIBMClient, nil })
+			require.NoError(t, err, "unexpected error creating actuator")
+
+			require.NoError(t, actuator.Refresh(), "unexpected error refreshing")
+			exists, _ := actuator.Exists()
+			switch {
+			case tc.delete:
+				require.NoError(t, actuator.Delete(), "unexpected error deleting")
+			case !exists:
+				require.NoError(t, actuator.Create(), "unexpected error creating")
+			}
+
+			exists, _ = actuator.Exists()
+			assert.Equal(t, tc.expectExists, exists, "unexpected zone existence")
+			if assert.NotNil(t, dnsZone.Status.IBMCloud, "expected IBM Cloud status") {
+				assert.Equal(t, tc.expectedZoneID, ptr.Deref(dnsZone.Status.IBMCloud.ZoneID, ""), "unexpected zone ID")
+			}
+			if tc.expectedServers != nil {
+				servers, err := actuator.GetNameServers()
+				require.NoError(t, err, "unexpected error getting name servers")
+				assert.Equal(t, tc.expectedServers, servers, "unexpected name servers")
+			}
+		})
+	}
+}
+
+func TestIBMCloudActuatorTXTRecords(t *testing.T) {
+	const recordName = "_acme-challenge.api.blah.example.com"
+	mockCtrl := gomock.NewController(t)
+	mockIBMClient := mock.NewMockAPI(mockCtrl)
+	expect := mockIBMClient.EXPECT()
+	expect.GetDNSRecordsByName(gomock.Any(), testCISInstanceCRN, "zone-id", recordName).Return([]dnsrecordsv1.DnsrecordDetails{
+		testCISRecord("1", recordName, "TXT"),
+		testCISRecord("2", recordName, "CNAME"),
+	}, nil).Times(1)
+	expect.DeleteCISDNSRecord(gomock.Any(), testCISInstanceCRN, "zone-id", "1").Return(nil).Times(1)
+	expect.CreateCISDNSRecord(gomock.Any(), testCISInstanceCRN, "zone-id", "TXT", recordName, "token", int64(txtRecordTTL)).
+		Return(nil, nil).Times(1)
+
+	actuator := &IBMCloudActuator{
+		logger:    log.WithField("controller", ControllerName),
+		ibmClient: mockIBMClient,
+		dnsZone:   validIBMCloudDNSZone(),
+		zone:      testCISZone(),
+	}
+	assert.NoError(t, actuator.SetTXTRecord(recordName+".", []string{"token"}), "unexpected error")
+}
@@ -0,0 +1,383 @@
+package dnszone
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultTSIGAlgorithm = "hmac-sha256"
+	tsigFudge            = 300
+
+	// addressRecordTTL is the TTL of the address records published from the DNSZone.
+	addressRecordTTL = 300
+)
+
+// RFC2136Actuator attempts to make the current state reflect the given desired state, using RFC2136 dynamic updates.
+// RFC2136 does not allow creating zones, so the records of the DNSZone are written into the zone on the name server
+// that contains the DNSZone's zone, usually the zone of the managed domain.
+type RFC2136Actuator struct {
+	// logger is the logger used for this controller
+	logger log.FieldLogger
+
+	// client sends queries and updates to the name server
+	client rfc2136Client
+
+	// server is the address of the name server
+	server string
+
+	// dnsZone is the DNSZone that represents the desired state.
+	dnsZone *hivev1.DNSZone
+
+	// authZone is the zone on the name server containing the records of the DNSZone. It is empty when the name
+	// server is not authoritative for the DNSZone's zone.
+	authZone string
+}
+
+// rfc2136Client sends messages to a name server accepting RFC2136 dynamic updates.
+type rfc2136Client interface {
+	// Exchange sends a query or update to the name server and returns the response.
+	Exchange(m *dns.Msg) (*dns.Msg, error)
+
+	// Transfer requests a transfer of the given zone from the name server and returns its records.
+	Transfer(zone string) ([]dns.RR, error)
+}
+
+// NewRFC2136Actuator creates a new RFC2136Actuator object. A new RFC2136Actuator is expected to be created for each controller sync.
+func NewRFC2136Actuator(
+	logger log.FieldLogger,
+	config *hivev1.ManageDNSRFC2136Config,
+	tsigSecret *corev1.Secret,
+	dnsZone *hivev1.DNSZone,
+) (*RFC2136Actuator, error) {
+	secret, ok := tsigSecret.Data[constants.RFC2136TSIGSecretKey]
+	if !ok {
+		return nil, errors.Errorf("TSIG secret does not contain %q data", constants.RFC2136TSIGSecretKey)
+	}
+	algorithm := config.TSIGAlgorithm
+	if algorithm == "" {
+		algorithm = defaultTSIGAlgorithm
+	}
+	server := rfc2136ServerAddress(config.Server)
+
+	return &RFC2136Actuator{
+		logger: logger,
+		client: &tsigClient{
+			server:    server,
+			keyName:   dns.Fqdn(strings.ToLower(config.TSIGKeyName)),
+			algorithm: dns.Fqdn(algorithm),
+			secret:    string(secret),
+		},
+		server:  server,
+		dnsZone: dnsZone,
+	}, nil
+}
+
+// rfc2136ServerAddress adds the default DNS port to a name server address without one.
+func rfc2136ServerAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		return net.JoinHostPort(server, "53")
+	}
+	return server
+}
+
+// Ensure RFC2136Actuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &RFC2136Actuator{}
+
+// Create implements the Create call of the actuator interface
+func (a *RFC2136Actuator) Create() error {
+	return errors.Errorf("name server %s is not authoritative for a zone containing %s and zones cannot be created with RFC2136",
+		a.server, a.dnsZone.Spec.Zone)
+}
+
+// Delete implements the Delete call of the actuator interface
+func (a *RFC2136Actuator) Delete() error {
+	if a.authZone == "" {
+		return errors.New("authoritative zone is unpopulated")
+	}
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("authZone", a.authZone)
+
+	records, err := a.client.Transfer(a.authZone)
+	if err != nil {
+		logger.WithError(err).Error("Cannot transfer zone")
+		return err
+	}
+
+	zone := dns.Fqdn(a.dnsZone.Spec.Zone)
+	seen := sets.New[string]()
+	var recordSetsToDelete []dns.RR
+	for _, rr := range records {
+		h := rr.Header()
+		if !dns.IsSubDomain(zone, h.Name) {
+			continue
+		}
+		// Ignore the records that belong to the zone on the name server itself
+		if h.Name == a.authZone && (h.Rrtype == dns.TypeNS || h.Rrtype == dns.TypeSOA) {
+			continue
+		}
+		key := h.Name + "/" + dns.TypeToString[h.Rrtype]
+		if seen.Has(key) {
+			continue
+		}
+		seen.Insert(key)
+		logger.WithField("name", h.Name).WithField("type", dns.TypeToString[h.Rrtype]).Info("recordset set for deletion")
+		recordSetsToDelete = append(recordSetsToDelete, rr)
+	}
+	if len(recordSetsToDelete) == 0 {
+		return nil
+	}
+
+	logger.WithField("count", len(recordSetsToDelete)).Info("deleting recordsets")
+	m := &dns.Msg{}
+	m.SetUpdate(a.authZone)
+	m.RemoveRRset(recordSetsToDelete)
+	return a.update(m)
+}
+
+// SetTXTRecord implements the SetTXTRecord call of the actuator interface
+func (a *RFC2136Actuator) SetTXTRecord(name string, values []string) error {
+	if a.authZone == "" {
+		return errors.New("authoritative zone is unpopulated")
+	}
+	fqdn := dns.Fqdn(name)
+	records := make([]dns.RR, len(values))
+	for i, value := range values {
+		records[i] = &dns.TXT{
+			Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: txtRecordTTL},
+			Txt: []string{value},
+		}
+	}
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Setting TXT record")
+	m := &dns.Msg{}
+	m.SetUpdate(a.authZone)
+	m.RemoveRRset([]dns.RR{&dns.TXT{Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET}}})
+	m.Insert(records)
+	return a.update(m)
+}
+
+// DeleteTXTRecord implements the DeleteTXTRecord call of the actuator interface
+func (a *RFC2136Actuator) DeleteTXTRecord(name string) error {
+	if a.authZone == "" {
+		return errors.New("authoritative zone is unpopulated")
+	}
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", name).Info("Deleting TXT record")
+	m := &dns.Msg{}
+	m.SetUpdate(a.authZone)
+	m.RemoveRRset([]dns.RR{&dns.TXT{Hdr: dns.RR_Header{Name: dns.Fqdn(name), Rrtype: dns.TypeTXT, Class: dns.ClassINET}}})
+	return a.update(m)
+}
+
+// Ensure RFC2136Actuator implements the recordsPublisher interface. This will fail at compile time when false.
+var _ recordsPublisher = &RFC2136Actuator{}
+
+// PublishRecords implements recordsPublisher. It replaces the A and AAAA records of each name listed in the DNSZone
+// with its addresses, in a single update.
+func (a *RFC2136Actuator) PublishRecords() error {
+	if len(a.dnsZone.Spec.RFC2136.Records) == 0 {
+		return nil
+	}
+	if a.authZone == "" {
+		return errors.New("authoritative zone is unpopulated")
+	}
+	zone := dns.Fqdn(a.dnsZone.Spec.Zone)
+	m := &dns.Msg{}
+	m.SetUpdate(a.authZone)
+	for _, record := range a.dnsZone.Spec.RFC2136.Records {
+		fqdn := dns.Fqdn(record.Name)
+		if !dns.IsSubDomain(zone, fqdn) {
+			return errors.Errorf("record %s is not in zone %s", record.Name, a.dnsZone.Spec.Zone)
+		}
+		var records []dns.RR
+		for _, address := range record.Addresses {
+			ip := net.ParseIP(address)
+			switch {
+			case ip == nil:
+				return errors.Errorf("invalid address %q for record %s", address, record.Name)
+			case ip.To4() != nil:
+				records = append(records, &dns.A{
+					Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: addressRecordTTL},
+					A:   ip.To4(),
+				})
+			default:
+				records = append(records, &dns.AAAA{
+					Hdr:  dns.RR_Header{Name: fqdn, Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: addressRecordTTL},
+					AAAA: ip,
+				})
+			}
+		}
+		a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("name", record.Name).WithField("addresses", record.Addresses).
+			Debug("Publishing address records")
+		m.RemoveRRset([]dns.RR{
+			&dns.A{Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeA, Class: dns.ClassINET}},
+			&dns.AAAA{Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeAAAA, Class: dns.ClassINET}},
+		})
+		m.Insert(records)
+	}
+	return a.update(m)
+}
+
+// update sends a dynamic update to the name server.
+func (a *RFC2136Actuator) update(m *dns.Msg) error {
+	resp, err := a.client.Exchange(m)
+	if err != nil {
+		return errors.Wrap(err, "error sending update to name server")
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return errors.Errorf("name server rejected the update: %s", dns.RcodeToString[resp.Rcode])
+	}
+	return nil
+}
+
+// Exists implements the Exists call of the actuator interface
+func (a *RFC2136Actuator) Exists() (bool, error) {
+	return a.authZone != "", nil
+}
+
+// UpdateMetadata implements the UpdateMetadata call of the actuator interface
+func (a *RFC2136Actuator) UpdateMetadata() error {
+	// Nothing to do here since zones have no metadata in RFC2136.
+	return nil
+}
+
+// GetNameServers implements the GetNameServers call of the actuator interface
+func (a *RFC2136Actuator) GetNameServers() ([]string, error) {
+	if a.authZone == "" {
+		return nil, errors.New("authoritative zone is unpopulated")
+	}
+
+	m := &dns.Msg{}
+	m.SetQuestion(a.authZone, dns.TypeNS)
+	m.RecursionDesired = false
+	resp, err := a.client.Exchange(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying name server")
+	}
+	var result []string
+	for _, rr := range resp.Answer {
+		if ns, ok := rr.(*dns.NS); ok {
+			result = append(result, controllerutils.Undotted(ns.Ns))
+		}
+	}
+	a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("nameservers", result).Debug("found zone name servers")
+	return result, nil
+}
+
+// IsZoneAvailable implements zoneAvailabilityChecker. The zone is available whenever the name server is
+// authoritative for it, as the records may be served from the zone of a parent domain.
+func (a *RFC2136Actuator) IsZoneAvailable() (bool, error) {
+	return a.authZone != "", nil
+}
+
+// Refresh implements the Refresh call of the actuator interface
+func (a *RFC2136Actuator) Refresh() error {
+	zone := dns.Fqdn(a.dnsZone.Spec.Zone)
+	logger := a.logger.WithField("zone", a.dnsZone.Spec.Zone).WithField("server", a.server)
+	logger.Debug("Looking up the authoritative zone on the name server")
+
+	m := &dns.Msg{}
+	m.SetQuestion(zone, dns.TypeSOA)
+	m.RecursionDesired = false
+	resp, err := a.client.Exchange(m)
+	if err != nil {
+		logger.WithError(err).Error("Cannot query name server")
+		return err
+	}
+
+	a.authZone = ""
+	if !resp.Authoritative || (resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError) {
+		logger.WithField("rcode", dns.RcodeToString[resp.Rcode]).Debug("Name server is not authoritative for the zone")
+		return nil
+	}
+	// The SOA record is in the answer when the zone exists on the name server, and in the authority section
+	// when the records are served from the zone of a parent domain.
+	for _, rr := range append(resp.Answer, resp.Ns...) {
+		if soa, ok := rr.(*dns.SOA); ok && dns.IsSubDomain(soa.Hdr.Name, zone) {
+			a.authZone = soa.Hdr.Name
+			break
+		}
+	}
+	logger.WithField("authZone", a.authZone).Debug("Found authoritative zone")
+	return nil
+}
+
+// SetConditionsForError sets conditions on the dnszone given a specific error. Returns true if conditions changed.
+func (a *RFC2136Actuator) SetConditionsForError(err error) bool {
+	// other conditions not implemented for RFC2136 yet, so set generic condition
+	var cloudErrorsConds []hivev1.DNSZoneCondition
+	var cloudErrorsCondsChanged bool
+	if err == nil {
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionFalse,
+			dnsNoErrorReason,
+			"No cloud errors occurred",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	} else {
+		cloudErrorsConds, cloudErrorsCondsChanged = controllerutils.SetDNSZoneConditionWithChangeCheck(
+			a.dnsZone.Status.Conditions,
+			hivev1.GenericDNSErrorsCondition,
+			corev1.ConditionTrue,
+			dnsCloudErrorReason,
+			controllerutils.ErrorScrub(err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+	}
+	if cloudErrorsCondsChanged {
+		a.dnsZone.Status.Conditions = cloudErrorsConds
+	}
+	return cloudErrorsCondsChanged
+}
+
+// tsigClient is an rfc2136Client that signs messages with a TSIG key.
+type tsigClient struct {
+	server    string
+	keyName   string
+	algorithm string
+	secret    string
+}
+
+// Exchange implements rfc2136Client.Exchange
+func (c *tsigClient) Exchange(m *dns.Msg) (*dns.Msg, error) {
+	m.SetTsig(c.keyName, c.algorithm, tsigFudge, time.Now().Unix())
+	client := &dns.Client{
+		Net:        "tcp",
+		Timeout:    dnsClientTimeout,
+		TsigSecret: map[string]string{c.keyName: c.secret},
+	}
+	resp, _, err := client.Exchange(m, c.server)
+	return resp, err
+}
+
+// Transfer implements rfc2136Client.Transfer
+func (c *tsigClient) Transfer(zone string) ([]dns.RR, error) {
+	m := &dns.Msg{}
+	m.SetAxfr(zone)
+	m.SetTsig(c.keyName, c.algorithm, tsigFudge, time.Now().Unix())
+	t := &dns.Transfer{TsigSecret: map[string]string{c.keyName: c.secret}}
+	envelopes, err := t.In(m, c.server)
+	if err != nil {
+		return nil, err
+	}
+	var records []dns.RR
+	for env := range envelopes {
+		if env.Error != nil {
+			return nil, env.Error
+		}
+		records = append(records, env.RR...)
+	}
+	return records, nil
+}
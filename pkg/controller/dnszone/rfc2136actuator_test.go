@@ -0,0 +1,232 @@
+package dnszone
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// fakeRFC2136Client serves a single zone and records the updates sent to it.
+type fakeRFC2136Client struct {
+	zone    string
+	records []dns.RR
+	updates []*dns.Msg
+}
+
+func (c *fakeRFC2136Client) Exchange(m *dns.Msg) (*dns.Msg, error) {
+	resp := &dns.Msg{}
+	resp.SetReply(m)
+	if m.Opcode == dns.OpcodeUpdate {
+		c.updates = append(c.updates, m)
+		return resp, nil
+	}
+	q := m.Question[0]
+	if !dns.IsSubDomain(c.zone, q.Name) {
+		resp.Rcode = dns.RcodeRefused
+		return resp, nil
+	}
+	resp.Authoritative = true
+	soa := &dns.SOA{Hdr: dns.RR_Header{Name: c.zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET}, Ns: "ns1." + c.zone}
+	switch {
+	case q.Name == c.zone && q.Qtype == dns.TypeSOA:
+		resp.Answer = []dns.RR{soa}
+	case q.Name == c.zone && q.Qtype == dns.TypeNS:
+		resp.Answer = []dns.RR{&dns.NS{Hdr: dns.RR_Header{Name: c.zone, Rrtype: dns.TypeNS, Class: dns.ClassINET}, Ns: "ns1." + c.zone}}
+	default:
+		resp.Ns = []dns.RR{soa}
+	}
+	return resp, nil
+}
+
+func (c *fakeRFC2136Client) Transfer(zone string) ([]dns.RR, error) {
+	return c.records, nil
+}
+
+func validRFC2136DNSZone() *hivev1.DNSZone {
+	zone := validDNSZone()
+	zone.Spec.AWS = nil
+	zone.Spec.RFC2136 = &hivev1.RFC2136DNSZoneSpec{Server: "ns1.example.com"}
+	zone.Status.AWS = nil
+	return zone
+}
+
+func newTestRFC2136Actuator(client rfc2136Client) *RFC2136Actuator {
+	return &RFC2136Actuator{
+		logger:  log.WithField("controller", ControllerName),
+		client:  client,
+		server:  "ns1.example.com:53",
+		dnsZone: validRFC2136DNSZone(),
+	}
+}
+
+func TestNewRFC2136Actuator(t *testing.T) {
+	config := &hivev1.ManageDNSRFC2136Config{
+		Server:      "ns1.example.com",
+		TSIGKeyName: "Hive-Key",
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{constants.RFC2136TSIGSecretKey: []byte("c2VjcmV0")}}
+
+	actuator, err := NewRFC2136Actuator(log.WithField("controller", ControllerName), config, secret, validRFC2136DNSZone())
+	require.NoError(t, err, "unexpected error creating actuator")
+	assert.Equal(t, "ns1.example.com:53", actuator.server, "unexpected server address")
+	assert.Equal(t, &tsigClient{
+		server:    "ns1.example.com:53",
+		keyName:   "hive-key.",
+		algorithm: "hmac-sha256.",
+		secret:    "c2VjcmV0",
+	}, actuator.client, "unexpected client")
+
+	_, err = NewRFC2136Actuator(log.WithField("controller", ControllerName), config, &corev1.Secret{}, validRFC2136DNSZone())
+	assert.Error(t, err, "expected error for secret without TSIG secret")
+}
+
+func TestRFC2136ActuatorRefresh(t *testing.T) {
+	cases := []struct {
+		name             string
+		serverZone       string
+		expectedAuthZone string
+		expectedServers  []string
+	}{
+		{
+			name:             "records served from parent zone",
+			serverZone:       "example.com.",
+			expectedAuthZone: "example.com.",
+			expectedServers:  []string{"ns1.example.com"},
+		},
+		{
+			name:             "zone exists on server",
+			serverZone:       "blah.example.com.",
+			expectedAuthZone: "blah.example.com.",
+			expectedServers:  []string{"ns1.blah.example.com"},
+		},
+		{
+			name:       "server not authoritative",
+			serverZone: "other.com.",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actuator := newTestRFC2136Actuator(&fakeRFC2136Client{zone: tc.serverZone})
+			require.NoError(t, actuator.Refresh(), "unexpected error refreshing")
+			assert.Equal(t, tc.expectedAuthZone, actuator.authZone, "unexpected authoritative zone")
+
+			exists, _ := actuator.Exists()
+			available, _ := actuator.IsZoneAvailable()
+			assert.Equal(t, tc.expectedAuthZone != "", exists, "unexpected zone existence")
+			assert.Equal(t, exists, available, "unexpected zone availability")
+			if !exists {
+				assert.Error(t, actuator.Create(), "expected error creating zone")
+				return
+			}
+			servers, err := actuator.GetNameServers()
+			require.NoError(t, err, "unexpected error getting name servers")
+			assert.Equal(t, tc.expectedServers, servers, "unexpected name servers")
+		})
+	}
+}
+
+func TestRFC2136ActuatorDelete(t *testing.T) {
+	header := func(name string, rrtype uint16) dns.RR_Header {
+		return dns.RR_Header{Name: name, Rrtype: rrtype, Class: dns.ClassINET, Ttl: 300}
+	}
+	client := &fakeRFC2136Client{
+		zone: "example.com.",
+		records: []dns.RR{
+			&dns.SOA{Hdr: header("example.com.", dns.TypeSOA)},
+			&dns.NS{Hdr: header("example.com.", dns.TypeNS), Ns: "ns1.example.com."},
+			&dns.A{Hdr: header("ns1.example.com.", dns.TypeA)},
+			&dns.A{Hdr: header("api.blah.example.com.", dns.TypeA)},
+			&dns.A{Hdr: header("api.blah.example.com.", dns.TypeA)},
+			&dns.CNAME{Hdr: header("*.apps.blah.example.com.", dns.TypeCNAME)},
+		},
+	}
+	actuator := newTestRFC2136Actuator(client)
+	require.NoError(t, actuator.Refresh(), "unexpected error refreshing")
+	require.NoError(t, actuator.Delete(), "unexpected error deleting")
+
+	require.Len(t, client.updates, 1, "expected a single update")
+	update := client.updates[0]
+	assert.Equal(t, "example.com.", update.Question[0].Name, "unexpected update zone")
+	var removed []string
+	for _, rr := range update.Ns {
+		assert.Equal(t, uint16(dns.ClassANY), rr.Header().Class, "expected recordset removal")
+		removed = append(removed, rr.Header().Name+"/"+dns.TypeToString[rr.Header().Rrtype])
+	}
+	assert.Equal(t, []string{"api.blah.example.com./A", "*.apps.blah.example.com./CNAME"}, removed, "unexpected removed recordsets")
+}
+
+func TestRFC2136ActuatorTXTRecords(t *testing.T) {
+	const recordName = "_acme-challenge.api.blah.example.com"
+	client := &fakeRFC2136Client{zone: "example.com."}
+	actuator := newTestRFC2136Actuator(client)
+	require.NoError(t, actuator.Refresh(), "unexpected error refreshing")
+
+	require.NoError(t, actuator.SetTXTRecord(recordName, []string{"token"}), "unexpected error setting record")
+	require.NoError(t, actuator.DeleteTXTRecord(recordName), "unexpected error deleting record")
+
+	require.Len(t, client.updates, 2, "expected two updates")
+	set := client.updates[0].Ns
+	require.Len(t, set, 2, "expected removal and insertion")
+	assert.Equal(t, uint16(dns.ClassANY), set[0].Header().Class, "expected recordset removal first")
+	if txt, ok := set[1].(*dns.TXT); assert.True(t, ok, "expected TXT record") {
+		assert.Equal(t, recordName+".", txt.Hdr.Name, "unexpected record name")
+		assert.Equal(t, []string{"token"}, txt.Txt, "unexpected record value")
+		assert.Equal(t, uint32(txtRecordTTL), txt.Hdr.Ttl, "unexpected record TTL")
+	}
+	deleted := client.updates[1].Ns
+	require.Len(t, deleted, 1, "expected removal")
+	assert.Equal(t, uint16(dns.ClassANY), deleted[0].Header().Class, "expected recordset removal")
+}
+
+func TestRFC2136ActuatorPublishRecords(t *testing.T) {
+	client := &fakeRFC2136Client{zone: "example.com."}
+	actuator := newTestRFC2136Actuator(client)
+	require.NoError(t, actuator.Refresh(), "unexpected error refreshing")
+
+	require.NoError(t, actuator.PublishRecords(), "unexpected error publishing no records")
+	assert.Empty(t, client.updates, "expected no update without records")
+
+	actuator.dnsZone.Spec.RFC2136.Records = []hivev1.RFC2136AddressRecord{
+		{Name: "api.blah.example.com", Addresses: []string{"192.168.1.10", "fd00::10"}},
+		{Name: "*.apps.blah.example.com", Addresses: []string{"192.168.1.11"}},
+	}
+	require.NoError(t, actuator.PublishRecords(), "unexpected error publishing records")
+	require.Len(t, client.updates, 1, "expected a single update")
+	var changes []string
+	for _, rr := range client.updates[0].Ns {
+		change := rr.Header().Name + "/" + dns.TypeToString[rr.Header().Rrtype]
+		switch rr := rr.(type) {
+		case *dns.A:
+			if rr.Hdr.Class == dns.ClassINET {
+				change += "=" + rr.A.String()
+			}
+		case *dns.AAAA:
+			if rr.Hdr.Class == dns.ClassINET {
+				change += "=" + rr.AAAA.String()
+			}
+		}
+		changes = append(changes, change)
+	}
+	assert.Equal(t, []string{
+		"api.blah.example.com./A",
+		"api.blah.example.com./AAAA",
+		"api.blah.example.com./A=192.168.1.10",
+		"api.blah.example.com./AAAA=fd00::10",
+		"*.apps.blah.example.com./A",
+		"*.apps.blah.example.com./AAAA",
+		"*.apps.blah.example.com./A=192.168.1.11",
+	}, changes, "unexpected changes")
+
+	actuator.dnsZone.Spec.RFC2136.Records = []hivev1.RFC2136AddressRecord{{Name: "api.other.com", Addresses: []string{"192.168.1.10"}}}
+	assert.Error(t, actuator.PublishRecords(), "expected error for record outside the zone")
+	actuator.dnsZone.Spec.RFC2136.Records = []hivev1.RFC2136AddressRecord{{Name: "api.blah.example.com", Addresses: []string{"not-an-address"}}}
+	assert.Error(t, actuator.PublishRecords(), "expected error for invalid address")
+}
@@ -249,6 +249,29 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, r.updateClusterDeploymentStatus(cd, cdLog)
 	}
 
+	// Enforce HibernationSchedule, if set. Like HibernateAfter, pool clusters wait until they're claimed for the
+	// schedule to have effect.
+	if cd.Spec.HibernationSchedule != nil && !isUnclaimedPoolCluster(cd) {
+		requeueAfter, updated, err := r.applyHibernationSchedule(cd, cdLog)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if updated {
+			// The PowerState change will trigger another reconcile
+			return reconcile.Result{}, nil
+		}
+		if requeueAfter > 0 {
+			// Make sure we wake up in time for the next scheduled transition, whatever else happens below.
+			defer func() {
+				requeueNow := result.Requeue && result.RequeueAfter <= 0
+				if returnErr == nil && !requeueNow && (result.RequeueAfter <= 0 || requeueAfter < result.RequeueAfter) {
+					cdLog.Debugf("cluster will reconcile due to hibernation schedule in: %v", requeueAfter)
+					result.RequeueAfter = requeueAfter
+				}
+			}()
+		}
+	}
+
 	shouldHibernate := cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating
 	// set readyToHibernate if hibernate after is ready to kick in hibernation
 	var readyToHibernate bool
@@ -261,13 +284,7 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		// - The last time the cluster resumed (status.conditions[Hibernating].lastTransitionTime if not hibernating (but see TODO))
 		// BUT pool clusters wait until they're claimed for HibernateAfter to have effect.
 		poolRef := cd.Spec.ClusterPoolRef
-		isUnclaimedPoolCluster := poolRef != nil && poolRef.PoolName != "" &&
-			// Upgrade note: If we hit this code path on a CD that was claimed before upgrading to
-			// where we introduced ClaimedTimestamp, then that CD was Hibernating when it was claimed
-			// (because that's the same time we introduced ClusterPool.RunningCount) so it's safe to
-			// just use installed/last-resumed as the baseline for hibernateAfter.
-			(poolRef.ClaimName == "" || poolRef.ClaimedTimestamp == nil)
-		if !isUnclaimedPoolCluster {
+		if !isUnclaimedPoolCluster(cd) {
 			hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 			hibLog := cdLog.WithField("hibernateAfter", hibernateAfterDur)
 
@@ -394,6 +411,83 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 	return r.checkClusterRunning(cd, syncSetsApplied, cdLog, readyCondition)
 }
 
+// isUnclaimedPoolCluster returns true if the ClusterDeployment belongs to a ClusterPool and has not yet been claimed.
+func isUnclaimedPoolCluster(cd *hivev1.ClusterDeployment) bool {
+	poolRef := cd.Spec.ClusterPoolRef
+	return poolRef != nil && poolRef.PoolName != "" &&
+		// Upgrade note: If we hit this code path on a CD that was claimed before upgrading to
+		// where we introduced ClaimedTimestamp, then that CD was Hibernating when it was claimed
+		// (because that's the same time we introduced ClusterPool.RunningCount) so it's safe to
+		// just use installed/last-resumed as the baseline for hibernateAfter.
+		(poolRef.ClaimName == "" || poolRef.ClaimedTimestamp == nil)
+}
+
+// applyHibernationSchedule enforces the ClusterDeployment's HibernationSchedule by updating Spec.PowerState, and
+// records the schedule's next transition in Status.HibernationSchedule. Outside of running windows (and during
+// Exceptions) the scheduled power state is enforced continuously; within a running window the cluster is resumed
+// once, at the start of the window, so that HibernateAfter and manual hibernation keep working.
+// It returns the time until the next scheduled transition, and whether Spec.PowerState was updated.
+func (r *hibernationReconciler) applyHibernationSchedule(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (time.Duration, bool, error) {
+	schedule, err := parseHibernationSchedule(cd.Spec.HibernationSchedule)
+	if err != nil {
+		// This should have been caught by the webhook. There's no point requeueing until the spec changes.
+		logger.WithError(err).Error("invalid hibernation schedule")
+		return 0, false, nil
+	}
+	now := time.Now()
+	state := schedule.evaluate(now)
+	schedLog := logger.WithField("scheduledPowerState", state.powerState)
+
+	newStatus := &hivev1.HibernationScheduleStatus{}
+	if cd.Status.HibernationSchedule != nil {
+		newStatus = cd.Status.HibernationSchedule.DeepCopy()
+	}
+
+	desiredPowerState := cd.Spec.PowerState
+	switch {
+	case state.powerState == hivev1.ClusterPowerStateHibernating || state.inException:
+		desiredPowerState = state.powerState
+	case newStatus.LastScheduledResume == nil || newStatus.LastScheduledResume.Time.Before(state.windowStart):
+		schedLog.WithField("windowStart", state.windowStart).Info("running window has started")
+		desiredPowerState = hivev1.ClusterPowerStateRunning
+		newStatus.LastScheduledResume = &metav1.Time{Time: state.windowStart}
+	}
+
+	var requeueAfter time.Duration
+	if state.nextTransition.IsZero() {
+		newStatus.NextTransitionTime = nil
+		newStatus.NextPowerState = ""
+	} else {
+		newStatus.NextTransitionTime = &metav1.Time{Time: state.nextTransition}
+		newStatus.NextPowerState = state.nextPowerState
+		requeueAfter = state.nextTransition.Sub(now)
+	}
+
+	// An empty PowerState means Running.
+	specUpdated := false
+	if (desiredPowerState == hivev1.ClusterPowerStateHibernating) != (cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating) {
+		schedLog.WithField("powerState", desiredPowerState).Info("updating power state per hibernation schedule")
+		cd.Spec.PowerState = desiredPowerState
+		if err := r.Update(context.TODO(), cd); err != nil {
+			schedLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating power state per hibernation schedule")
+			return 0, false, err
+		}
+		specUpdated = true
+	}
+
+	oldStatus := cd.Status.HibernationSchedule
+	if oldStatus == nil ||
+		!oldStatus.LastScheduledResume.Equal(newStatus.LastScheduledResume) ||
+		!oldStatus.NextTransitionTime.Equal(newStatus.NextTransitionTime) ||
+		oldStatus.NextPowerState != newStatus.NextPowerState {
+		cd.Status.HibernationSchedule = newStatus
+		if err := r.updateClusterDeploymentStatus(cd, logger); err != nil {
+			return 0, specUpdated, err
+		}
+	}
+	return requeueAfter, specUpdated, nil
+}
+
 func (r *hibernationReconciler) startMachines(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
 	actuator := r.getActuator(cd)
 	if actuator == nil {
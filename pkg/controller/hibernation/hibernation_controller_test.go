@@ -1272,6 +1272,148 @@ func TestHibernateAfter(t *testing.T) {
 	}
 }
 
+func TestHibernationSchedule(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := scheme.GetScheme()
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+	)
+	o := clusterDeploymentOptions{}
+	csBuilder := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(time.Now().Add(-10 * time.Hour)),
+	)
+
+	now := time.Now().UTC()
+	clock := func(offset time.Duration) string {
+		return now.Add(offset).Format(scheduleTimeFormat)
+	}
+	windowStart := now.Add(-time.Hour).Truncate(time.Minute)
+	inWindow := &hivev1.HibernationSchedule{
+		RunningWindows: []hivev1.HibernationScheduleWindow{{Start: clock(-time.Hour), End: clock(time.Hour)}},
+	}
+	outsideWindow := &hivev1.HibernationSchedule{
+		RunningWindows: []hivev1.HibernationScheduleWindow{{Start: clock(time.Hour), End: clock(2 * time.Hour)}},
+	}
+	runningException := outsideWindow.DeepCopy()
+	runningException.Exceptions = []hivev1.HibernationScheduleException{{
+		Start:      metav1.NewTime(now.Add(-time.Hour)),
+		End:        metav1.NewTime(now.Add(30 * time.Minute)),
+		PowerState: hivev1.ClusterPowerStateRunning,
+	}}
+	resumed := func(cd *hivev1.ClusterDeployment) {
+		cd.Status.HibernationSchedule = &hivev1.HibernationScheduleStatus{
+			LastScheduledResume: &metav1.Time{Time: windowStart},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setupActuator func(actuator *mock.MockHibernationActuator)
+		cd            *hivev1.ClusterDeployment
+
+		expectRequeueAfter       time.Duration
+		expectedPowerState       hivev1.ClusterPowerState
+		expectLastScheduleResume *time.Time
+		expectNextPowerState     hivev1.ClusterPowerState
+	}{
+		{
+			name:                     "running window started",
+			cd:                       cdBuilder.Options(o.shouldHibernate, o.hibernating, testcd.WithHibernationSchedule(inWindow)).Build(),
+			expectedPowerState:       hivev1.ClusterPowerStateRunning,
+			expectLastScheduleResume: &windowStart,
+			expectNextPowerState:     hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "manually hibernated during running window",
+			cd:   cdBuilder.Options(o.shouldHibernate, o.hibernating, resumed, testcd.WithHibernationSchedule(inWindow)).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil, nil)
+			},
+			expectRequeueAfter:       time.Hour,
+			expectedPowerState:       hivev1.ClusterPowerStateHibernating,
+			expectLastScheduleResume: &windowStart,
+			expectNextPowerState:     hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name:                 "outside running window",
+			cd:                   cdBuilder.Options(o.shouldRun, testcd.WithHibernationSchedule(outsideWindow)).Build(),
+			expectedPowerState:   hivev1.ClusterPowerStateHibernating,
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name:                 "running exception",
+			cd:                   cdBuilder.Options(o.shouldHibernate, o.hibernating, testcd.WithHibernationSchedule(runningException)).Build(),
+			expectedPowerState:   hivev1.ClusterPowerStateRunning,
+			expectNextPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "unclaimed pool cluster",
+			cd: cdBuilder.Options(o.shouldHibernate, o.hibernating, testcd.WithHibernationSchedule(inWindow),
+				testcd.WithClusterPoolReference(namespace, "pool", "")).Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil, nil)
+			},
+			expectedPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			if test.setupActuator != nil {
+				test.setupActuator(mockActuator)
+			}
+			actuators = []HibernationActuator{mockActuator}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(test.cd, csBuilder.Build()).Build()
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return remoteclientmock.NewMockBuilder(ctrl)
+				},
+				csrUtil: mock.NewMockcsrHelper(ctrl),
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			assert.NoError(t, err, "expected no error from reconcile")
+
+			// Need to do fuzzy requeue after matching. Schedule times are truncated to the minute.
+			if test.expectRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter)
+			} else {
+				assert.GreaterOrEqual(t, result.RequeueAfter.Seconds(), (test.expectRequeueAfter - 70*time.Second).Seconds(), "requeue after too small")
+				assert.LessOrEqual(t, result.RequeueAfter.Seconds(), (test.expectRequeueAfter + 10*time.Second).Seconds(), "request after too large")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			assert.Equal(t, test.expectedPowerState, cd.Spec.PowerState, "unexpected PowerState")
+			if test.expectNextPowerState == "" {
+				assert.Nil(t, cd.Status.HibernationSchedule, "unexpected hibernation schedule status")
+				return
+			}
+			if assert.NotNil(t, cd.Status.HibernationSchedule, "expected hibernation schedule status") {
+				assert.Equal(t, test.expectNextPowerState, cd.Status.HibernationSchedule.NextPowerState, "unexpected NextPowerState")
+				assert.NotNil(t, cd.Status.HibernationSchedule.NextTransitionTime, "expected NextTransitionTime")
+				if test.expectLastScheduleResume == nil {
+					assert.Nil(t, cd.Status.HibernationSchedule.LastScheduledResume, "unexpected LastScheduledResume")
+				} else if assert.NotNil(t, cd.Status.HibernationSchedule.LastScheduledResume, "expected LastScheduledResume") {
+					assert.True(t, test.expectLastScheduleResume.Equal(cd.Status.HibernationSchedule.LastScheduledResume.Time), "unexpected LastScheduledResume")
+				}
+			}
+		})
+	}
+}
+
 func hibernatingCondition(status corev1.ConditionStatus, reason string, lastTransitionAgo time.Duration) hivev1.ClusterDeploymentCondition {
 	return hivev1.ClusterDeploymentCondition{
 		Type:               hivev1.ClusterHibernatingCondition,
@@ -0,0 +1,238 @@
+package hibernation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// scheduleTimeFormat is the layout of the Start and End times of a HibernationScheduleWindow
+	scheduleTimeFormat = constants.HibernationScheduleTimeFormat
+
+	// scheduleHorizon is how far ahead we look for the next scheduled transition. Windows repeat
+	// weekly, so if the power state doesn't change within a week (plus a day for windows that
+	// span midnight) it never will, barring Exceptions.
+	scheduleHorizon = 8 * 24 * time.Hour
+)
+
+// scheduleWindow is a parsed HibernationScheduleWindow.
+type scheduleWindow struct {
+	// days is the set of weekdays on which the window starts. A nil set means every day.
+	days map[time.Weekday]bool
+	// start and end are offsets from midnight, in minutes. end may exceed a day if the window
+	// closes the following day.
+	start, end int
+}
+
+// hibernationSchedule is a parsed HibernationSchedule, evaluated in its time zone.
+type hibernationSchedule struct {
+	loc        *time.Location
+	windows    []scheduleWindow
+	exceptions []hivev1.HibernationScheduleException
+}
+
+// scheduleState is the result of evaluating a hibernationSchedule at a point in time.
+type scheduleState struct {
+	// powerState is the power state the schedule requires at the evaluated time.
+	powerState hivev1.ClusterPowerState
+	// inException is true if powerState is dictated by an Exception rather than by the RunningWindows.
+	inException bool
+	// windowStart is the start of the running window containing the evaluated time. It is zero if the
+	// evaluated time is not within a running window.
+	windowStart time.Time
+	// nextTransition is the next time the required power state changes, and nextPowerState is the power
+	// state it changes to. nextTransition is zero if no change is scheduled within scheduleHorizon.
+	nextTransition time.Time
+	nextPowerState hivev1.ClusterPowerState
+}
+
+var weekdays = map[hivev1.HibernationScheduleDay]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// parseHibernationSchedule validates a HibernationSchedule and converts it into a form that can be evaluated.
+func parseHibernationSchedule(schedule *hivev1.HibernationSchedule) (*hibernationSchedule, error) {
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", schedule.TimeZone, err)
+	}
+	if len(schedule.RunningWindows) == 0 {
+		return nil, fmt.Errorf("at least one running window is required")
+	}
+	s := &hibernationSchedule{
+		loc:        loc,
+		exceptions: schedule.Exceptions,
+	}
+	for i, w := range schedule.RunningWindows {
+		start, err := parseScheduleTime(w.Start)
+		if err != nil {
+			return nil, fmt.Errorf("running window %d: invalid start: %w", i, err)
+		}
+		end, err := parseScheduleTime(w.End)
+		if err != nil {
+			return nil, fmt.Errorf("running window %d: invalid end: %w", i, err)
+		}
+		if end <= start {
+			// The window closes the following day
+			end += 24 * 60
+		}
+		sw := scheduleWindow{start: start, end: end}
+		if len(w.Days) > 0 {
+			sw.days = map[time.Weekday]bool{}
+			for _, d := range w.Days {
+				wd, ok := weekdays[d]
+				if !ok {
+					return nil, fmt.Errorf("running window %d: invalid day %q", i, d)
+				}
+				sw.days[wd] = true
+			}
+		}
+		s.windows = append(s.windows, sw)
+	}
+	for i, e := range schedule.Exceptions {
+		if !e.End.After(e.Start.Time) {
+			return nil, fmt.Errorf("exception %d: end must be after start", i)
+		}
+		if e.PowerState != hivev1.ClusterPowerStateRunning && e.PowerState != hivev1.ClusterPowerStateHibernating {
+			return nil, fmt.Errorf("exception %d: invalid power state %q", i, e.PowerState)
+		}
+	}
+	return s, nil
+}
+
+// parseScheduleTime parses an "HH:MM" time of day into minutes since midnight.
+func parseScheduleTime(s string) (int, error) {
+	t, err := time.Parse(scheduleTimeFormat, s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// instance returns the start and end of the window's occurrence on the given day, and whether it occurs on that day.
+func (w scheduleWindow) instance(day time.Time) (time.Time, time.Time, bool) {
+	if w.days != nil && !w.days[day.Weekday()] {
+		return time.Time{}, time.Time{}, false
+	}
+	// Let time.Date normalize the minutes (rather than adding durations to midnight) so that wall clock
+	// times are honored across DST changes.
+	y, m, d := day.Date()
+	return time.Date(y, m, d, 0, w.start, 0, 0, day.Location()), time.Date(y, m, d, 0, w.end, 0, 0, day.Location()), true
+}
+
+// days returns midnight of each day in the schedule's time zone from the day before t through the day after t+d.
+func (s *hibernationSchedule) days(t time.Time, d time.Duration) []time.Time {
+	t = t.In(s.loc)
+	var days []time.Time
+	for day := time.Date(t.Year(), t.Month(), t.Day()-1, 0, 0, 0, 0, s.loc); !day.After(t.Add(d)); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// windowStartAt returns the start of the running window containing t, if any. If more than one window contains
+// t, the earliest start is returned.
+func (s *hibernationSchedule) windowStartAt(t time.Time) (time.Time, bool) {
+	var earliest time.Time
+	for _, day := range s.days(t, 0) {
+		for _, w := range s.windows {
+			start, end, ok := w.instance(day)
+			if !ok || t.Before(start) || !t.Before(end) {
+				continue
+			}
+			if earliest.IsZero() || start.Before(earliest) {
+				earliest = start
+			}
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// exceptionAt returns the Exception in effect at t, if any.
+func (s *hibernationSchedule) exceptionAt(t time.Time) *hivev1.HibernationScheduleException {
+	for i, e := range s.exceptions {
+		if !t.Before(e.Start.Time) && t.Before(e.End.Time) {
+			return &s.exceptions[i]
+		}
+	}
+	return nil
+}
+
+// powerStateAt returns the power state required by the schedule at t.
+func (s *hibernationSchedule) powerStateAt(t time.Time) hivev1.ClusterPowerState {
+	if e := s.exceptionAt(t); e != nil {
+		return e.PowerState
+	}
+	if _, ok := s.windowStartAt(t); ok {
+		return hivev1.ClusterPowerStateRunning
+	}
+	return hivev1.ClusterPowerStateHibernating
+}
+
+// boundaries returns every time after t, up to scheduleHorizon (or scheduleHorizon beyond the end of an
+// Exception), at which the required power state might change.
+func (s *hibernationSchedule) boundaries(t time.Time) []time.Time {
+	var candidates []time.Time
+	add := func(c time.Time) {
+		if c.After(t) {
+			candidates = append(candidates, c)
+		}
+	}
+	addWindows := func(from time.Time) {
+		horizon := from.Add(scheduleHorizon)
+		for _, day := range s.days(from, scheduleHorizon) {
+			for _, w := range s.windows {
+				if start, end, ok := w.instance(day); ok {
+					if !start.After(horizon) {
+						add(start)
+					}
+					if !end.After(horizon) {
+						add(end)
+					}
+				}
+			}
+		}
+	}
+	addWindows(t)
+	for _, e := range s.exceptions {
+		add(e.Start.Time)
+		add(e.End.Time)
+		if e.End.After(t) {
+			addWindows(e.End.Time)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+	return candidates
+}
+
+// evaluate determines the power state required by the schedule at now, and when it next changes.
+func (s *hibernationSchedule) evaluate(now time.Time) *scheduleState {
+	state := &scheduleState{
+		powerState: s.powerStateAt(now),
+	}
+	if s.exceptionAt(now) != nil {
+		state.inException = true
+	} else if start, ok := s.windowStartAt(now); ok {
+		state.windowStart = start
+	}
+	for _, b := range s.boundaries(now) {
+		if ps := s.powerStateAt(b); ps != state.powerState {
+			state.nextTransition = b
+			state.nextPowerState = ps
+			break
+		}
+	}
+	return state
+}
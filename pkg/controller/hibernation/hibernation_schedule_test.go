@@ -0,0 +1,244 @@
+package hibernation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestEvaluateHibernationSchedule(t *testing.T) {
+	workdays := []hivev1.HibernationScheduleDay{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}
+	officeHours := func(tz string) *hivev1.HibernationSchedule {
+		return &hivev1.HibernationSchedule{
+			TimeZone: tz,
+			RunningWindows: []hivev1.HibernationScheduleWindow{
+				{Days: workdays, Start: "08:00", End: "18:00"},
+			},
+		}
+	}
+	utc := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return ts
+	}
+
+	tests := []struct {
+		name                 string
+		schedule             *hivev1.HibernationSchedule
+		now                  time.Time
+		expectPowerState     hivev1.ClusterPowerState
+		expectInException    bool
+		expectWindowStart    time.Time
+		expectNext           time.Time
+		expectNextPowerState hivev1.ClusterPowerState
+	}{
+		{
+			name:                 "within running window",
+			schedule:             officeHours(""),
+			now:                  utc("2026-10-14T10:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateRunning,
+			expectWindowStart:    utc("2026-10-14T08:00:00Z"),
+			expectNext:           utc("2026-10-14T18:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name:                 "after running window",
+			schedule:             officeHours("UTC"),
+			now:                  utc("2026-10-14T19:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectNext:           utc("2026-10-15T08:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name:                 "window end is exclusive",
+			schedule:             officeHours("UTC"),
+			now:                  utc("2026-10-14T18:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectNext:           utc("2026-10-15T08:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name:                 "weekend",
+			schedule:             officeHours("UTC"),
+			now:                  utc("2026-10-17T12:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectNext:           utc("2026-10-19T08:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "window spanning midnight",
+			schedule: &hivev1.HibernationSchedule{
+				TimeZone: "America/New_York",
+				RunningWindows: []hivev1.HibernationScheduleWindow{
+					{Start: "22:00", End: "02:00"},
+				},
+			},
+			// 01:00 EDT
+			now:                  utc("2026-10-14T05:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateRunning,
+			expectWindowStart:    utc("2026-10-14T02:00:00Z"),
+			expectNext:           utc("2026-10-14T06:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "daylight saving time ends",
+			schedule: &hivev1.HibernationSchedule{
+				TimeZone: "America/New_York",
+				RunningWindows: []hivev1.HibernationScheduleWindow{
+					{Start: "08:00", End: "18:00"},
+				},
+			},
+			// 19:00 EDT on Saturday; the next window opens at 08:00 EST on Sunday
+			now:                  utc("2026-10-31T23:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectNext:           utc("2026-11-01T13:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "always running",
+			schedule: &hivev1.HibernationSchedule{
+				RunningWindows: []hivev1.HibernationScheduleWindow{
+					{Start: "00:00", End: "00:00"},
+				},
+			},
+			now:               utc("2026-10-14T10:00:00Z"),
+			expectPowerState:  hivev1.ClusterPowerStateRunning,
+			expectWindowStart: utc("2026-10-14T00:00:00Z"),
+		},
+		{
+			name: "hibernating exception within running window",
+			schedule: func() *hivev1.HibernationSchedule {
+				s := officeHours("UTC")
+				s.Exceptions = []hivev1.HibernationScheduleException{{
+					Start:      metav1.NewTime(utc("2026-10-14T00:00:00Z")),
+					End:        metav1.NewTime(utc("2026-10-16T00:00:00Z")),
+					PowerState: hivev1.ClusterPowerStateHibernating,
+				}}
+				return s
+			}(),
+			now:                  utc("2026-10-14T10:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectInException:    true,
+			expectNext:           utc("2026-10-16T08:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateRunning,
+		},
+		{
+			name: "running exception on the weekend",
+			schedule: func() *hivev1.HibernationSchedule {
+				s := officeHours("UTC")
+				s.Exceptions = []hivev1.HibernationScheduleException{{
+					Start:      metav1.NewTime(utc("2026-10-17T10:00:00Z")),
+					End:        metav1.NewTime(utc("2026-10-17T14:00:00Z")),
+					PowerState: hivev1.ClusterPowerStateRunning,
+				}}
+				return s
+			}(),
+			now:                  utc("2026-10-17T12:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateRunning,
+			expectInException:    true,
+			expectNext:           utc("2026-10-17T14:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "upcoming exception",
+			schedule: func() *hivev1.HibernationSchedule {
+				s := officeHours("UTC")
+				s.Exceptions = []hivev1.HibernationScheduleException{{
+					Start:      metav1.NewTime(utc("2026-10-14T12:00:00Z")),
+					End:        metav1.NewTime(utc("2026-10-14T13:00:00Z")),
+					PowerState: hivev1.ClusterPowerStateHibernating,
+				}}
+				return s
+			}(),
+			now:                  utc("2026-10-14T10:00:00Z"),
+			expectPowerState:     hivev1.ClusterPowerStateRunning,
+			expectWindowStart:    utc("2026-10-14T08:00:00Z"),
+			expectNext:           utc("2026-10-14T12:00:00Z"),
+			expectNextPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := parseHibernationSchedule(test.schedule)
+			require.NoError(t, err, "unexpected error parsing schedule")
+			state := schedule.evaluate(test.now)
+			assert.Equal(t, test.expectPowerState, state.powerState, "unexpected power state")
+			assert.Equal(t, test.expectInException, state.inException, "unexpected inException")
+			assert.True(t, test.expectWindowStart.Equal(state.windowStart), "unexpected window start %v", state.windowStart)
+			assert.True(t, test.expectNext.Equal(state.nextTransition), "unexpected next transition %v", state.nextTransition)
+			assert.Equal(t, test.expectNextPowerState, state.nextPowerState, "unexpected next power state")
+		})
+	}
+}
+
+func TestParseHibernationSchedule(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		schedule    *hivev1.HibernationSchedule
+		expectError bool
+	}{
+		{
+			name: "valid",
+			schedule: &hivev1.HibernationSchedule{
+				TimeZone:       "Europe/Prague",
+				RunningWindows: []hivev1.HibernationScheduleWindow{{Days: []hivev1.HibernationScheduleDay{"Monday"}, Start: "07:30", End: "19:45"}},
+			},
+		},
+		{
+			name: "invalid time zone",
+			schedule: &hivev1.HibernationSchedule{
+				TimeZone:       "Mars/Olympus_Mons",
+				RunningWindows: []hivev1.HibernationScheduleWindow{{Start: "08:00", End: "18:00"}},
+			},
+			expectError: true,
+		},
+		{
+			name:        "no running windows",
+			schedule:    &hivev1.HibernationSchedule{},
+			expectError: true,
+		},
+		{
+			name: "invalid start",
+			schedule: &hivev1.HibernationSchedule{
+				RunningWindows: []hivev1.HibernationScheduleWindow{{Start: "8am", End: "18:00"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid day",
+			schedule: &hivev1.HibernationSchedule{
+				RunningWindows: []hivev1.HibernationScheduleWindow{{Days: []hivev1.HibernationScheduleDay{"Funday"}, Start: "08:00", End: "18:00"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "exception ends before it starts",
+			schedule: &hivev1.HibernationSchedule{
+				RunningWindows: []hivev1.HibernationScheduleWindow{{Start: "08:00", End: "18:00"}},
+				Exceptions: []hivev1.HibernationScheduleException{{
+					Start:      metav1.NewTime(now),
+					End:        metav1.NewTime(now.Add(-time.Hour)),
+					PowerState: hivev1.ClusterPowerStateHibernating,
+				}},
+			},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseHibernationSchedule(test.schedule)
+			if test.expectError {
+				assert.Error(t, err, "expected error parsing schedule")
+			} else {
+				assert.NoError(t, err, "unexpected error parsing schedule")
+			}
+		})
+	}
+}
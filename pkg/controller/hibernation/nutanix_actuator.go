@@ -0,0 +1,174 @@
+package hibernation
+
+import (
+	"context"
+
+	nutanixclientv3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	installernutanix "github.com/openshift/installer/pkg/types/nutanix"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/nutanixclient"
+)
+
+func init() {
+	RegisterActuator(&nutanixActuator{nutanixClientFn: getNutanixClient})
+}
+
+type nutanixActuator struct {
+	// nutanixClientFn is the function to build a Nutanix client, here for testing
+	nutanixClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (nutanixclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *nutanixActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.Nutanix != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *nutanixActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "nutanix")
+	nutanixClient, err := a.nutanixClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+
+	vms, err := getNutanixClusterVMs(cd, nutanixClient, func(powerState string) bool { return powerState == nutanixclient.PowerStateOn }, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to stop")
+		return nil
+	}
+	logger.WithField("vms", nutanixVMNames(vms)).Info("Stopping cluster VMs")
+	err = nutanixClient.PowerOffVMs(context.TODO(), vms)
+	if err != nil {
+		logger.WithError(err).Error("failed to stop Nutanix VMs")
+		return err
+	}
+	return nil
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *nutanixActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "nutanix")
+	nutanixClient, err := a.nutanixClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+
+	vms, err := getNutanixClusterVMs(cd, nutanixClient, func(powerState string) bool { return powerState == nutanixclient.PowerStateOff }, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to start")
+		return nil
+	}
+	logger.WithField("vms", nutanixVMNames(vms)).Info("Starting cluster VMs")
+	err = nutanixClient.PowerOnVMs(context.TODO(), vms)
+	if err != nil {
+		logger.WithError(err).Error("failed to start Nutanix VMs")
+		return err
+	}
+	return nil
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *nutanixActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "nutanix")
+	logger.Infof("checking whether machines are running")
+	nutanixClient, err := a.nutanixClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	vms, err := getNutanixClusterVMs(cd, nutanixClient, func(powerState string) bool { return powerState != nutanixclient.PowerStateOn }, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, nutanixVMNames(vms), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *nutanixActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "nutanix")
+	logger.Infof("checking whether machines are stopped")
+	nutanixClient, err := a.nutanixClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	vms, err := getNutanixClusterVMs(cd, nutanixClient, func(powerState string) bool { return powerState != nutanixclient.PowerStateOff }, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, nutanixVMNames(vms), nil
+}
+
+func getNutanixClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (nutanixclient.API, error) {
+	credsSecret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Nutanix.CredentialsSecretRef.Name, Namespace: cd.Namespace}, credsSecret)
+	if err != nil {
+		logger.WithError(err).Error("failed to fetch Nutanix credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch Nutanix credentials secret")
+	}
+	var certsSecret *corev1.Secret
+	if name := cd.Spec.Platform.Nutanix.CertificatesSecretRef.Name; name != "" {
+		certsSecret = &corev1.Secret{}
+		err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: cd.Namespace}, certsSecret)
+		if err != nil {
+			logger.WithError(err).Error("failed to fetch Nutanix certificates secret")
+			return nil, errors.Wrap(err, "failed to fetch Nutanix certificates secret")
+		}
+	}
+	prismCentral := cd.Spec.Platform.Nutanix.PrismCentral
+	return nutanixclient.NewClientFromSecret(prismCentral.Address, prismCentral.Port, credsSecret, certsSecret)
+}
+
+func nutanixVMNames(vms []*nutanixclientv3.VMIntentResource) []string {
+	names := make([]string, len(vms))
+	for i, vm := range vms {
+		if vm.Spec != nil {
+			names[i] = ptr.Deref(vm.Spec.Name, "")
+		}
+	}
+	return names
+}
+
+func nutanixVMPowerState(vm *nutanixclientv3.VMIntentResource) string {
+	if vm.Status == nil || vm.Status.Resources == nil {
+		return ""
+	}
+	return ptr.Deref(vm.Status.Resources.PowerState, "")
+}
+
+// getNutanixClusterVMs returns the VMs owned by the cluster whose power state satisfies the given predicate.
+func getNutanixClusterVMs(cd *hivev1.ClusterDeployment, c nutanixclient.API, matchPowerState func(string) bool, logger log.FieldLogger) ([]*nutanixclientv3.VMIntentResource, error) {
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	logger = logger.WithField("infraID", infraID)
+	logger.Debug("listing cluster VMs")
+
+	vms, err := c.ListVMs(context.TODO(), installernutanix.CategoryKey(infraID), installernutanix.CategoryValueOwned)
+	if err != nil {
+		logger.WithError(err).Error("failed to list VMs")
+		return nil, err
+	}
+	var result []*nutanixclientv3.VMIntentResource
+	for _, vm := range vms {
+		if matchPowerState(nutanixVMPowerState(vm)) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).Debug("result of listing VMs")
+	return result, nil
+}
@@ -0,0 +1,264 @@
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	nutanixclientv3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	"github.com/openshift/hive/pkg/nutanixclient"
+	mocknutanixclient "github.com/openshift/hive/pkg/nutanixclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestNutanixCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.Nutanix = &hivev1nutanix.Platform{}
+	}).Build()
+	actuator := nutanixActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestNutanixStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		vms         map[string]int
+		setupClient func(*testing.T, *mocknutanixclient.MockAPI)
+		expectErr   bool
+	}{
+		{
+			name:     "stop no running VMs",
+			testFunc: "StopMachines",
+			vms:      map[string]int{"OFF": 3},
+		},
+		{
+			name:     "stop running VMs",
+			testFunc: "StopMachines",
+			vms:      map[string]int{"OFF": 2, "ON": 3},
+			setupClient: func(t *testing.T, c *mocknutanixclient.MockAPI) {
+				c.EXPECT().PowerOffVMs(gomock.Any(), gomock.Any()).Times(1).Do(
+					func(ctx context.Context, vms []*nutanixclientv3.VMIntentResource) {
+						assert.Equal(t, 3, len(vms), "unexpected number of VMs provided to PowerOffVMs")
+						for _, vm := range vms {
+							assert.Equal(t, "ON", nutanixVMPowerState(vm))
+						}
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:     "start no stopped VMs",
+			testFunc: "StartMachines",
+			vms:      map[string]int{"ON": 3},
+		},
+		{
+			name:     "start stopped VMs",
+			testFunc: "StartMachines",
+			vms:      map[string]int{"OFF": 2, "ON": 4},
+			setupClient: func(t *testing.T, c *mocknutanixclient.MockAPI) {
+				c.EXPECT().PowerOnVMs(gomock.Any(), gomock.Any()).Times(1).Do(
+					func(ctx context.Context, vms []*nutanixclientv3.VMIntentResource) {
+						assert.Equal(t, 2, len(vms), "unexpected number of VMs provided to PowerOnVMs")
+						for _, vm := range vms {
+							assert.Equal(t, "OFF", nutanixVMPowerState(vm))
+						}
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:     "failure to stop VMs",
+			testFunc: "StopMachines",
+			vms:      map[string]int{"ON": 2},
+			setupClient: func(t *testing.T, c *mocknutanixclient.MockAPI) {
+				c.EXPECT().PowerOffVMs(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("cannot power off"))
+			},
+			expectErr: true,
+		},
+		{
+			name:     "unable to list VMs",
+			testFunc: "StartMachines",
+			setupClient: func(t *testing.T, c *mocknutanixclient.MockAPI) {
+				c.EXPECT().ListVMs(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("cannot list VMs"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			nutanixClient := mocknutanixclient.NewMockAPI(ctrl)
+			if test.vms != nil {
+				setupNutanixClientVMs(nutanixClient, test.vms)
+			}
+			if test.setupClient != nil {
+				test.setupClient(t, nutanixClient)
+			}
+			actuator := testNutanixActuator(nutanixClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testNutanixClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testNutanixClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			if test.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestNutanixMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name              string
+		testFunc          string
+		expectedRemaining []string
+		expectedResult    bool
+		vms               map[string]int
+	}{
+		{
+			name:           "Stopped - All VMs off",
+			testFunc:       "MachinesStopped",
+			expectedResult: true,
+			vms:            map[string]int{"OFF": 3},
+		},
+		{
+			name:              "Stopped - Some VMs on",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"ON-0"},
+			vms:               map[string]int{"OFF": 2, "ON": 1},
+		},
+		{
+			name:           "Running - All VMs on",
+			testFunc:       "MachinesRunning",
+			expectedResult: true,
+			vms:            map[string]int{"ON": 3},
+		},
+		{
+			name:              "Running - Some VMs off",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"OFF-0", "OFF-1"},
+			vms:               map[string]int{"ON": 3, "OFF": 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			nutanixClient := mocknutanixclient.NewMockAPI(ctrl)
+			setupNutanixClientVMs(nutanixClient, test.vms)
+			actuator := testNutanixActuator(nutanixClient)
+			var err error
+			var result bool
+			var remaining []string
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, remaining, err = actuator.MachinesStopped(testNutanixClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, remaining, err = actuator.MachinesRunning(testNutanixClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			if len(test.expectedRemaining) > 0 {
+				sort.Strings(test.expectedRemaining)
+				sort.Strings(remaining)
+				assert.Equal(t, test.expectedRemaining, remaining)
+			}
+		})
+	}
+}
+
+func TestGetNutanixClientSecrets(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []client.Object
+		expectedError string
+	}{
+		{
+			name:          "missing credentials secret",
+			expectedError: "failed to fetch Nutanix credentials secret",
+		},
+		{
+			name: "missing username and password",
+			existing: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "nutanix-creds"},
+			}},
+			expectedError: "creds secret does not contain",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithObjects(test.existing...).Build()
+			_, err := getNutanixClient(testNutanixClusterDeployment(), c, log.New())
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.expectedError)
+			}
+		})
+	}
+}
+
+func testNutanixActuator(nutanixClient nutanixclient.API) *nutanixActuator {
+	return &nutanixActuator{
+		nutanixClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (nutanixclient.API, error) {
+			return nutanixClient, nil
+		},
+	}
+}
+
+func setupNutanixClientVMs(nutanixClient *mocknutanixclient.MockAPI, powerStates map[string]int) {
+	vms := []*nutanixclientv3.VMIntentResource{}
+	for powerState, count := range powerStates {
+		for i := 0; i < count; i++ {
+			vms = append(vms, &nutanixclientv3.VMIntentResource{
+				Metadata: &nutanixclientv3.Metadata{UUID: ptr.To(fmt.Sprintf("%s-%d", powerState, i))},
+				Spec:     &nutanixclientv3.VM{Name: ptr.To(fmt.Sprintf("%s-%d", powerState, i))},
+				Status: &nutanixclientv3.VMDefStatus{
+					Resources: &nutanixclientv3.VMResourcesDefStatus{PowerState: ptr.To(powerState)},
+				},
+			})
+		}
+	}
+	nutanixClient.EXPECT().ListVMs(gomock.Any(), "kubernetes-io-cluster-testnutanixcluster-foobarbaz", "owned").Times(1).Return(vms, nil)
+}
+
+func testNutanixClusterDeployment() *hivev1.ClusterDeployment {
+	scheme := scheme.GetScheme()
+	cdBuilder := testcd.FullBuilder("testns", "testnutanixcluster", scheme)
+	return cdBuilder.Build(
+		testcd.WithNutanixPlatform(&hivev1nutanix.Platform{
+			PrismCentral:         hivev1nutanix.PrismEndpoint{Address: "prism.example.com", Port: 9440},
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "nutanix-creds"},
+		}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "testnutanixcluster-foobarbaz"}),
+	)
+}
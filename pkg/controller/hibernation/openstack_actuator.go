@@ -0,0 +1,171 @@
+package hibernation
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/openstackclient"
+)
+
+const (
+	// openStackClusterIDMetadataKey is the server metadata key the installer sets to the cluster's infraID.
+	openStackClusterIDMetadataKey = "openshiftClusterID"
+
+	openStackStatusActive  = "ACTIVE"
+	openStackStatusShutoff = "SHUTOFF"
+)
+
+func init() {
+	RegisterActuator(&openStackActuator{openStackClientFn: getOpenStackClient})
+}
+
+type openStackActuator struct {
+	// openStackClientFn is the function to build an OpenStack client, here for testing
+	openStackClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *openStackActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.OpenStack != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *openStackActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "openstack")
+	openStackClient, err := a.openStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+
+	toStop, err := getOpenStackClusterServers(cd, openStackClient, func(status string) bool { return status == openStackStatusActive }, logger)
+	if err != nil {
+		return err
+	}
+	if len(toStop) == 0 {
+		logger.Info("No servers were found to stop")
+		return nil
+	}
+	logger.WithField("servers", openStackServerNames(toStop)).Info("Stopping cluster servers")
+	err = openStackClient.StopServers(context.TODO(), toStop)
+	if err != nil {
+		logger.WithError(err).Error("failed to stop OpenStack servers")
+		return err
+	}
+	return nil
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *openStackActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "openstack")
+	openStackClient, err := a.openStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+
+	toStart, err := getOpenStackClusterServers(cd, openStackClient, func(status string) bool { return status == openStackStatusShutoff }, logger)
+	if err != nil {
+		return err
+	}
+	if len(toStart) == 0 {
+		logger.Info("No servers were found to start")
+		return nil
+	}
+	logger.WithField("servers", openStackServerNames(toStart)).Info("Starting cluster servers")
+	err = openStackClient.StartServers(context.TODO(), toStart)
+	if err != nil {
+		logger.WithError(err).Error("failed to start OpenStack servers")
+		return err
+	}
+	return nil
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *openStackActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "openstack")
+	logger.Infof("checking whether machines are running")
+	openStackClient, err := a.openStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	notRunning, err := getOpenStackClusterServers(cd, openStackClient, func(status string) bool { return status != openStackStatusActive }, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(notRunning) == 0, openStackServerNames(notRunning), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *openStackActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "openstack")
+	logger.Infof("checking whether machines are stopped")
+	openStackClient, err := a.openStackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	notStopped, err := getOpenStackClusterServers(cd, openStackClient, func(status string) bool { return status != openStackStatusShutoff }, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(notStopped) == 0, openStackServerNames(notStopped), nil
+}
+
+func getOpenStackClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (openstackclient.API, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Error("failed to fetch OpenStack credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch OpenStack credentials secret")
+	}
+	var trustBundle []byte
+	if cd.Spec.Platform.OpenStack.CertificatesSecretRef != nil {
+		buf := &bytes.Buffer{}
+		if err := controllerutils.TrustBundleFromSecretToWriter(c, cd.Namespace, cd.Spec.Platform.OpenStack.CertificatesSecretRef.Name, buf); err != nil {
+			logger.WithError(err).Error("failed to load trust bundle from CertificatesSecretRef")
+			return nil, errors.Wrap(err, "failed to load trust bundle from CertificatesSecretRef")
+		}
+		trustBundle = buf.Bytes()
+	}
+	return openstackclient.NewClientFromSecret(secret, cd.Spec.Platform.OpenStack.Cloud, trustBundle)
+}
+
+func openStackServerNames(toName []servers.Server) []string {
+	names := make([]string, len(toName))
+	for i, s := range toName {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// getOpenStackClusterServers returns the servers belonging to the cluster whose status satisfies the given predicate.
+func getOpenStackClusterServers(cd *hivev1.ClusterDeployment, c openstackclient.API, matchStatus func(string) bool, logger log.FieldLogger) ([]servers.Server, error) {
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	logger = logger.WithField("infraID", infraID)
+	logger.Debug("listing cluster servers")
+
+	clusterServers, err := c.ListServers(context.TODO(), map[string]string{openStackClusterIDMetadataKey: infraID})
+	if err != nil {
+		logger.WithError(err).Error("failed to list servers")
+		return nil, err
+	}
+	var result []servers.Server
+	for _, s := range clusterServers {
+		if matchStatus(s.Status) {
+			result = append(result, s)
+		}
+	}
+	logger.WithField("count", len(result)).Debug("result of listing servers")
+	return result, nil
+}
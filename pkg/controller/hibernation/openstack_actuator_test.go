@@ -0,0 +1,263 @@
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/openstackclient"
+	mockopenstackclient "github.com/openshift/hive/pkg/openstackclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestOpenStackCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.OpenStack = &hivev1openstack.Platform{}
+	}).Build()
+	actuator := openStackActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestOpenStackStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		servers     map[string]int
+		setupClient func(*testing.T, *mockopenstackclient.MockAPI)
+		expectErr   bool
+	}{
+		{
+			name:     "stop no active servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"SHUTOFF": 3, "ERROR": 1},
+		},
+		{
+			name:     "stop active servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"SHUTOFF": 2, "ACTIVE": 3, "BUILD": 1},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockAPI) {
+				c.EXPECT().StopServers(gomock.Any(), gomock.Any()).Times(1).Do(
+					func(ctx context.Context, toStop []servers.Server) {
+						assert.Equal(t, 3, len(toStop), "unexpected number of servers provided to StopServers")
+						for _, s := range toStop {
+							assert.Equal(t, "ACTIVE", s.Status)
+						}
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:     "start no stopped servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"ACTIVE": 3},
+		},
+		{
+			name:     "start stopped servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"SHUTOFF": 2, "ACTIVE": 4},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockAPI) {
+				c.EXPECT().StartServers(gomock.Any(), gomock.Any()).Times(1).Do(
+					func(ctx context.Context, toStart []servers.Server) {
+						assert.Equal(t, 2, len(toStart), "unexpected number of servers provided to StartServers")
+						for _, s := range toStart {
+							assert.Equal(t, "SHUTOFF", s.Status)
+						}
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:     "failure to start servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"SHUTOFF": 2},
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockAPI) {
+				c.EXPECT().StartServers(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("cannot start servers"))
+			},
+			expectErr: true,
+		},
+		{
+			name:     "unable to list servers",
+			testFunc: "StopMachines",
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockAPI) {
+				c.EXPECT().ListServers(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("cannot list servers"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openStackClient := mockopenstackclient.NewMockAPI(ctrl)
+			if test.servers != nil {
+				setupOpenStackClientServers(openStackClient, test.servers)
+			}
+			if test.setupClient != nil {
+				test.setupClient(t, openStackClient)
+			}
+			actuator := testOpenStackActuator(openStackClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testOpenStackClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testOpenStackClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			if test.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenStackMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name              string
+		testFunc          string
+		expectedRemaining []string
+		expectedResult    bool
+		servers           map[string]int
+	}{
+		{
+			name:           "Stopped - All servers shut off",
+			testFunc:       "MachinesStopped",
+			expectedResult: true,
+			servers:        map[string]int{"SHUTOFF": 3},
+		},
+		{
+			name:              "Stopped - Some servers active",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"ACTIVE-0", "ACTIVE-1"},
+			servers:           map[string]int{"SHUTOFF": 2, "ACTIVE": 2},
+		},
+		{
+			name:           "Running - All servers active",
+			testFunc:       "MachinesRunning",
+			expectedResult: true,
+			servers:        map[string]int{"ACTIVE": 3},
+		},
+		{
+			name:              "Running - Some servers shut off or rebooting",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"SHUTOFF-0", "REBOOT-0"},
+			servers:           map[string]int{"ACTIVE": 3, "SHUTOFF": 1, "REBOOT": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openStackClient := mockopenstackclient.NewMockAPI(ctrl)
+			setupOpenStackClientServers(openStackClient, test.servers)
+			actuator := testOpenStackActuator(openStackClient)
+			var err error
+			var result bool
+			var remaining []string
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, remaining, err = actuator.MachinesStopped(testOpenStackClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, remaining, err = actuator.MachinesRunning(testOpenStackClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			if len(test.expectedRemaining) > 0 {
+				sort.Strings(test.expectedRemaining)
+				sort.Strings(remaining)
+				assert.Equal(t, test.expectedRemaining, remaining)
+			}
+		})
+	}
+}
+
+func TestGetOpenStackClientSecrets(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []client.Object
+		expectedError string
+	}{
+		{
+			name:          "missing credentials secret",
+			expectedError: "failed to fetch OpenStack credentials secret",
+		},
+		{
+			name: "missing clouds.yaml",
+			existing: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "openstack-creds"},
+			}},
+			expectedError: "creds secret does not contain \"" + constants.OpenStackCredentialsName + "\" data",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithObjects(test.existing...).Build()
+			_, err := getOpenStackClient(testOpenStackClusterDeployment(), c, log.New())
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.expectedError)
+			}
+		})
+	}
+}
+
+func testOpenStackActuator(openStackClient openstackclient.API) *openStackActuator {
+	return &openStackActuator{
+		openStackClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.API, error) {
+			return openStackClient, nil
+		},
+	}
+}
+
+func setupOpenStackClientServers(openStackClient *mockopenstackclient.MockAPI, statuses map[string]int) {
+	clusterServers := []servers.Server{}
+	for status, count := range statuses {
+		for i := 0; i < count; i++ {
+			clusterServers = append(clusterServers, servers.Server{
+				ID:     fmt.Sprintf("%s-%d", status, i),
+				Name:   fmt.Sprintf("%s-%d", status, i),
+				Status: status,
+			})
+		}
+	}
+	openStackClient.EXPECT().ListServers(gomock.Any(), map[string]string{"openshiftClusterID": "testopenstackcluster-foobarbaz"}).
+		Times(1).Return(clusterServers, nil)
+}
+
+func testOpenStackClusterDeployment() *hivev1.ClusterDeployment {
+	scheme := scheme.GetScheme()
+	cdBuilder := testcd.FullBuilder("testns", "testopenstackcluster", scheme)
+	return cdBuilder.Build(
+		testcd.WithOpenStackPlatform(&hivev1openstack.Platform{
+			Cloud:                "openstack",
+			CredentialsSecretRef: corev1.LocalObjectReference{Name: "openstack-creds"},
+		}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "testopenstackcluster-foobarbaz"}),
+	)
+}
@@ -0,0 +1,176 @@
+package hibernation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/vsphereclient"
+)
+
+var (
+	vSpherePoweredOn     = sets.New(types.VirtualMachinePowerStatePoweredOn)
+	vSphereNotPoweredOn  = sets.New(types.VirtualMachinePowerStatePoweredOff, types.VirtualMachinePowerStateSuspended)
+	vSphereNotPoweredOff = sets.New(types.VirtualMachinePowerStatePoweredOn, types.VirtualMachinePowerStateSuspended)
+)
+
+func init() {
+	RegisterActuator(&vSphereActuator{vSphereClientFn: getVSphereClient})
+}
+
+type vSphereActuator struct {
+	// vSphereClientFn is the function to build a vSphere client, here for testing
+	vSphereClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *vSphereActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.VSphere != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *vSphereActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vsphere")
+	vSphereClient, err := a.vSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vSphereClient.Logout()
+
+	vms, err := getVSphereClusterVirtualMachines(cd, vSphereClient, vSpherePoweredOn, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No virtual machines were found to stop")
+		return nil
+	}
+	logger.WithField("virtualMachines", vSphereVirtualMachineNames(vms)).Info("Stopping cluster virtual machines")
+	err = vSphereClient.PowerOffVirtualMachines(context.TODO(), vms)
+	if err != nil {
+		logger.WithError(err).Error("failed to stop vSphere virtual machines")
+		return err
+	}
+	return nil
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *vSphereActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vsphere")
+	vSphereClient, err := a.vSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vSphereClient.Logout()
+
+	vms, err := getVSphereClusterVirtualMachines(cd, vSphereClient, vSphereNotPoweredOn, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No virtual machines were found to start")
+		return nil
+	}
+	logger.WithField("virtualMachines", vSphereVirtualMachineNames(vms)).Info("Starting cluster virtual machines")
+	err = vSphereClient.PowerOnVirtualMachines(context.TODO(), vms)
+	if err != nil {
+		logger.WithError(err).Error("failed to start vSphere virtual machines")
+		return err
+	}
+	return nil
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *vSphereActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "vsphere")
+	logger.Infof("checking whether machines are running")
+	vSphereClient, err := a.vSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer vSphereClient.Logout()
+
+	vms, err := getVSphereClusterVirtualMachines(cd, vSphereClient, vSphereNotPoweredOn, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, vSphereVirtualMachineNames(vms), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *vSphereActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "vsphere")
+	logger.Infof("checking whether machines are stopped")
+	vSphereClient, err := a.vSphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer vSphereClient.Logout()
+
+	vms, err := getVSphereClusterVirtualMachines(cd, vSphereClient, vSphereNotPoweredOff, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, vSphereVirtualMachineNames(vms), nil
+}
+
+func getVSphereClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (vsphereclient.API, error) {
+	credsSecret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.VSphere.CredentialsSecretRef.Name, Namespace: cd.Namespace}, credsSecret)
+	if err != nil {
+		logger.WithError(err).Error("failed to fetch vSphere credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch vSphere credentials secret")
+	}
+	var certsSecret *corev1.Secret
+	if name := cd.Spec.Platform.VSphere.CertificatesSecretRef.Name; name != "" {
+		certsSecret = &corev1.Secret{}
+		err := c.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: cd.Namespace}, certsSecret)
+		if err != nil {
+			logger.WithError(err).Error("failed to fetch vSphere certificates secret")
+			return nil, errors.Wrap(err, "failed to fetch vSphere certificates secret")
+		}
+	}
+	return vsphereclient.NewClientFromSecret(cd.Spec.Platform.VSphere.VCenter, credsSecret, certsSecret)
+}
+
+func vSphereVirtualMachineNames(vms []mo.VirtualMachine) []string {
+	names := make([]string, len(vms))
+	for i, vm := range vms {
+		names[i] = vm.Name
+	}
+	return names
+}
+
+// getVSphereClusterVirtualMachines returns the virtual machines tagged with the cluster's infraID
+// whose power state is one of the given states.
+func getVSphereClusterVirtualMachines(cd *hivev1.ClusterDeployment, c vsphereclient.API, states sets.Set[types.VirtualMachinePowerState], logger log.FieldLogger) ([]mo.VirtualMachine, error) {
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	logger = logger.WithField("infraID", infraID)
+	logger.Debug("listing cluster virtual machines")
+
+	vms, err := c.ListVirtualMachines(context.TODO(), infraID)
+	if err != nil {
+		logger.WithError(err).Error("failed to list virtual machines")
+		return nil, err
+	}
+	var result []mo.VirtualMachine
+	for _, vm := range vms {
+		if states.Has(vm.Summary.Runtime.PowerState) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing virtual machines")
+	return result, nil
+}
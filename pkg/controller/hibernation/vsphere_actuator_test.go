@@ -0,0 +1,264 @@
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+	"github.com/openshift/hive/pkg/vsphereclient"
+	mockvsphereclient "github.com/openshift/hive/pkg/vsphereclient/mock"
+)
+
+func TestVSphereCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{}
+	}).Build()
+	actuator := vSphereActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestVSphereStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    string
+		vms         map[types.VirtualMachinePowerState]int
+		setupClient func(*testing.T, *mockvsphereclient.MockAPI)
+		expectErr   bool
+	}{
+		{
+			name:     "stop no running virtual machines",
+			testFunc: "StopMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 3, "suspended": 1},
+		},
+		{
+			name:     "stop running virtual machines",
+			testFunc: "StopMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 2, "poweredOn": 3},
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockAPI) {
+				c.EXPECT().PowerOffVirtualMachines(gomock.Any(), gomock.Any()).Times(1).Do(
+					func(ctx context.Context, vms []mo.VirtualMachine) {
+						assert.Equal(t, 3, len(vms), "unexpected number of virtual machines provided to PowerOffVirtualMachines")
+						for _, vm := range vms {
+							assert.Equal(t, types.VirtualMachinePowerStatePoweredOn, vm.Summary.Runtime.PowerState)
+						}
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:     "start no stopped virtual machines",
+			testFunc: "StartMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOn": 3},
+		},
+		{
+			name:     "start stopped and suspended virtual machines",
+			testFunc: "StartMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOff": 2, "suspended": 1, "poweredOn": 4},
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockAPI) {
+				c.EXPECT().PowerOnVirtualMachines(gomock.Any(), gomock.Any()).Times(1).Do(
+					func(ctx context.Context, vms []mo.VirtualMachine) {
+						assert.Equal(t, 3, len(vms), "unexpected number of virtual machines provided to PowerOnVirtualMachines")
+						for _, vm := range vms {
+							assert.NotEqual(t, types.VirtualMachinePowerStatePoweredOn, vm.Summary.Runtime.PowerState)
+						}
+					},
+				).Return(nil)
+			},
+		},
+		{
+			name:     "failure to stop virtual machines",
+			testFunc: "StopMachines",
+			vms:      map[types.VirtualMachinePowerState]int{"poweredOn": 3},
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockAPI) {
+				c.EXPECT().PowerOffVirtualMachines(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("cannot power off"))
+			},
+			expectErr: true,
+		},
+		{
+			name:     "unable to list virtual machines",
+			testFunc: "StartMachines",
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockAPI) {
+				c.EXPECT().ListVirtualMachines(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("cannot list virtual machines"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vSphereClient := mockvsphereclient.NewMockAPI(ctrl)
+			vSphereClient.EXPECT().Logout().Times(1)
+			if test.vms != nil {
+				setupVSphereClientVirtualMachines(vSphereClient, test.vms)
+			}
+			if test.setupClient != nil {
+				test.setupClient(t, vSphereClient)
+			}
+			actuator := testVSphereActuator(vSphereClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testVSphereClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testVSphereClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			if test.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestVSphereMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name              string
+		testFunc          string
+		expectedRemaining []string
+		expectedResult    bool
+		vms               map[types.VirtualMachinePowerState]int
+	}{
+		{
+			name:           "Stopped - All virtual machines powered off",
+			testFunc:       "MachinesStopped",
+			expectedResult: true,
+			vms:            map[types.VirtualMachinePowerState]int{"poweredOff": 3},
+		},
+		{
+			name:              "Stopped - Some virtual machines running or suspended",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"poweredOn-0", "suspended-0"},
+			vms:               map[types.VirtualMachinePowerState]int{"poweredOff": 2, "poweredOn": 1, "suspended": 1},
+		},
+		{
+			name:           "Running - All virtual machines running",
+			testFunc:       "MachinesRunning",
+			expectedResult: true,
+			vms:            map[types.VirtualMachinePowerState]int{"poweredOn": 3},
+		},
+		{
+			name:              "Running - Some virtual machines powered off",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"poweredOff-0", "poweredOff-1"},
+			vms:               map[types.VirtualMachinePowerState]int{"poweredOn": 3, "poweredOff": 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vSphereClient := mockvsphereclient.NewMockAPI(ctrl)
+			vSphereClient.EXPECT().Logout().Times(1)
+			setupVSphereClientVirtualMachines(vSphereClient, test.vms)
+			actuator := testVSphereActuator(vSphereClient)
+			var err error
+			var result bool
+			var remaining []string
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, remaining, err = actuator.MachinesStopped(testVSphereClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, remaining, err = actuator.MachinesRunning(testVSphereClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			if len(test.expectedRemaining) > 0 {
+				sort.Strings(test.expectedRemaining)
+				sort.Strings(remaining)
+				assert.Equal(t, test.expectedRemaining, remaining)
+			}
+		})
+	}
+}
+
+func TestGetVSphereClientSecrets(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []client.Object
+		expectedError string
+	}{
+		{
+			name:          "missing credentials secret",
+			expectedError: "failed to fetch vSphere credentials secret",
+		},
+		{
+			name: "missing certificates secret",
+			existing: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "vsphere-creds"},
+			}},
+			expectedError: "failed to fetch vSphere certificates secret",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := testfake.NewFakeClientBuilder().WithObjects(test.existing...).Build()
+			_, err := getVSphereClient(testVSphereClusterDeployment(), c, log.New())
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.expectedError)
+			}
+		})
+	}
+}
+
+func testVSphereActuator(vSphereClient vsphereclient.API) *vSphereActuator {
+	return &vSphereActuator{
+		vSphereClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.API, error) {
+			return vSphereClient, nil
+		},
+	}
+}
+
+func setupVSphereClientVirtualMachines(vSphereClient *mockvsphereclient.MockAPI, powerStates map[types.VirtualMachinePowerState]int) {
+	vms := []mo.VirtualMachine{}
+	for powerState, count := range powerStates {
+		for i := 0; i < count; i++ {
+			vm := mo.VirtualMachine{}
+			vm.Name = fmt.Sprintf("%s-%d", powerState, i)
+			vm.Summary.Runtime.PowerState = powerState
+			vms = append(vms, vm)
+		}
+	}
+	vSphereClient.EXPECT().ListVirtualMachines(gomock.Any(), "testvspherecluster-foobarbaz").Times(1).Return(vms, nil)
+}
+
+func testVSphereClusterDeployment() *hivev1.ClusterDeployment {
+	scheme := scheme.GetScheme()
+	cdBuilder := testcd.FullBuilder("testns", "testvspherecluster", scheme)
+	return cdBuilder.Build(
+		testcd.WithVSpherePlatform(&hivev1vsphere.Platform{
+			VCenter:               "vcenter.example.com",
+			CredentialsSecretRef:  corev1.LocalObjectReference{Name: "vsphere-creds"},
+			CertificatesSecretRef: corev1.LocalObjectReference{Name: "vsphere-certs"},
+		}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "testvspherecluster-foobarbaz"}),
+	)
+}
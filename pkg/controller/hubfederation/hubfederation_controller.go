@@ -0,0 +1,493 @@
+package hubfederation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	ControllerName = hivev1.HubFederationControllerName
+
+	// resyncInterval is how often the peers are queried, since changes on the peers are not watched.
+	resyncInterval = 5 * time.Minute
+)
+
+// Add creates a new HubFederation Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileHubFederation {
+	r := &ReconcileHubFederation{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+	r.remoteClientBuilder = func(secret *corev1.Secret) remoteclient.Builder {
+		return remoteclient.NewBuilderFromKubeconfig(r.Client, secret, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileHubFederation, concurrentReconciles int, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) error {
+	// Create a new controller
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to HubFederations
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.HubFederation{}, &handler.TypedEnqueueRequestForObject[*hivev1.HubFederation]{})); err != nil {
+		return err
+	}
+
+	// Clusters coming and going change the load of this hub.
+	if err := c.Watch(source.Kind(mgr.GetCache(), &hivev1.ClusterDeployment{}, handler.TypedEnqueueRequestsFromMapFunc(
+		r.requestsForClusterDeployment))); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// requestsForClusterDeployment returns all the HubFederations, since any of them may move the ClusterDeployment.
+func (r *ReconcileHubFederation) requestsForClusterDeployment(ctx context.Context, cd *hivev1.ClusterDeployment) []reconcile.Request {
+	fedList := &hivev1.HubFederationList{}
+	if err := r.List(context.Background(), fedList); err != nil {
+		r.logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list HubFederations")
+		return nil
+	}
+	requests := make([]reconcile.Request, len(fedList.Items))
+	for i, fed := range fedList.Items {
+		requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: fed.Name}}
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileHubFederation{}
+
+// ReconcileHubFederation reconciles a HubFederation object by reporting on the hubs of the federation and moving
+// clusters off this hub when it is over capacity or draining. The clusters are moved by ClusterRelocates, one for
+// each peer, selecting the ClusterDeployments labelled with the peer's name.
+type ReconcileHubFederation struct {
+	client.Client
+	logger log.FieldLogger
+
+	// remoteClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for a peer hub.
+	remoteClientBuilder func(secret *corev1.Secret) remoteclient.Builder
+}
+
+// hub is a hub of the federation.
+type hub struct {
+	status hivev1.HubFederationHubStatus
+	peer   *hivev1.HubFederationPeer
+	// incoming is the number of clusters this hub is moving to the hub which have not arrived yet.
+	incoming int
+}
+
+// spare returns the number of clusters which can still be moved to the hub.
+func (h *hub) spare() int {
+	if !h.status.Reachable || !h.status.Registered || h.status.Draining {
+		return 0
+	}
+	if h.status.Capacity == nil {
+		return math.MaxInt
+	}
+	return max(int(*h.status.Capacity)-int(h.status.ClusterDeployments)-h.incoming, 0)
+}
+
+// Reconcile queries the peers of a HubFederation, moves clusters to them if needed and records the state of the
+// federation in its status.
+func (r *ReconcileHubFederation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "hubFederation", request.NamespacedName)
+	logger.Info("reconciling hub federation")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	fed := &hivev1.HubFederation{}
+	switch err := r.Get(context.Background(), request.NamespacedName, fed); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("hub federation not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error getting hub federation")
+		return reconcile.Result{}, err
+	}
+
+	if fed.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if fed.Spec.Placement != nil {
+		if _, err := metav1.LabelSelectorAsSelector(&fed.Spec.Placement.ClusterDeploymentSelector); err != nil {
+			logger.WithError(err).Error("invalid placement cluster deployment selector")
+			return reconcile.Result{}, err
+		}
+	}
+
+	local := &hub{status: hivev1.HubFederationHubStatus{
+		Name:       fed.Spec.HubName,
+		Local:      true,
+		Reachable:  true,
+		Registered: true,
+		Capacity:   fed.Spec.Capacity,
+		Draining:   fed.Spec.Draining,
+	}}
+	peers := map[string]*hub{}
+	hubs := []*hub{local}
+	for i := range fed.Spec.Peers {
+		peer := &fed.Spec.Peers[i]
+		h := &hub{status: r.peerStatus(fed, peer, logger), peer: peer}
+		peers[peer.Name] = h
+		hubs = append(hubs, h)
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), cdList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list cluster deployments")
+		return reconcile.Result{}, err
+	}
+	sort.Slice(cdList.Items, func(i, j int) bool {
+		if cdList.Items[i].Namespace != cdList.Items[j].Namespace {
+			return cdList.Items[i].Namespace < cdList.Items[j].Namespace
+		}
+		return cdList.Items[i].Name < cdList.Items[j].Name
+	})
+
+	moving := 0
+	var candidates []*hivev1.ClusterDeployment
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		_, relocateStatus, err := controllerutils.IsRelocating(cd)
+		if err != nil {
+			logger.WithError(err).WithField("clusterDeployment", cd.Namespace+"/"+cd.Name).Warn("could not determine relocate status")
+		}
+		if cd.DeletionTimestamp != nil || relocateStatus == hivev1.RelocateComplete {
+			// Gone, or about to be
+			continue
+		}
+		local.status.ClusterDeployments++
+		// The placement's selector was validated above
+		candidate, _ := controllerutils.IsHubFederationCandidate(fed, cd)
+		destination, labelled := cd.Labels[constants.HubFederationDestinationLabel]
+		switch {
+		case labelled && relocateStatus == "" && destination == fed.Spec.HubName:
+			// Moved here from a peer
+			if err := r.setDestination(cd, "", logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		case labelled && relocateStatus == "" && peers[destination] == nil:
+			// The peer has been removed from the federation before the cluster moved there.
+			if err := r.setDestination(cd, "", logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		case labelled && relocateStatus == "" && !candidate:
+			// The label was not set by the federation, which does not move this cluster. The ClusterRelocate does not
+			// move it either.
+			if err := r.setDestination(cd, "", logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		case labelled && destination != fed.Spec.HubName:
+			moving++
+			if peer := peers[destination]; peer != nil {
+				peer.incoming++
+			}
+		case candidate && relocateStatus == "":
+			candidates = append(candidates, cd)
+		}
+	}
+
+	// How many more clusters need to move off this hub
+	excess := 0
+	switch {
+	case fed.Spec.Placement == nil:
+	case fed.Spec.Draining:
+		excess = len(candidates)
+	case fed.Spec.Capacity != nil:
+		excess = int(local.status.ClusterDeployments) - int(*fed.Spec.Capacity) - moving
+	}
+	excess = min(max(excess, 0), len(candidates))
+
+	// Each cluster goes to the peer with the most spare capacity.
+	unplaced := excess
+	for _, cd := range candidates[:excess] {
+		var destination *hub
+		for _, h := range hubs[1:] {
+			if h.spare() > 0 && (destination == nil || h.spare() > destination.spare()) {
+				destination = h
+			}
+		}
+		if destination == nil {
+			break
+		}
+		if err := r.setDestination(cd, destination.status.Name, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		destination.incoming++
+		moving++
+		unplaced--
+	}
+
+	for _, h := range hubs[1:] {
+		if h.incoming > 0 {
+			if err := r.ensureClusterRelocate(fed, h.peer, logger); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+	if err := r.deleteStaleClusterRelocates(fed, peers, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	status := hivev1.HubFederationStatus{
+		Moving:     int32(moving),
+		Conditions: fed.Status.DeepCopy().Conditions,
+	}
+	for _, h := range hubs {
+		status.Hubs = append(status.Hubs, h.status)
+		status.ClusterDeployments += h.status.ClusterDeployments
+	}
+
+	rebalancingStatus, rebalancingReason, rebalancingMessage := corev1.ConditionFalse, "Balanced", "No clusters are being moved off this hub"
+	if moving > 0 {
+		rebalancingStatus, rebalancingReason = corev1.ConditionTrue, "OverCapacity"
+		if fed.Spec.Draining {
+			rebalancingReason = "Draining"
+		}
+		rebalancingMessage = fmt.Sprintf("Moving %d clusters off this hub", moving)
+	}
+	capacityStatus, capacityReason, capacityMessage := corev1.ConditionFalse, "SufficientCapacity", "The peers can take the clusters moving off this hub"
+	if unplaced > 0 {
+		capacityStatus, capacityReason, capacityMessage = corev1.ConditionTrue, "NoPeerCapacity",
+			fmt.Sprintf("No peer has the capacity for %d more clusters", unplaced)
+	}
+	status.Conditions, _ = controllerutils.SetHubFederationConditionWithChangeCheck(
+		status.Conditions,
+		hivev1.HubFederationRebalancingCondition,
+		rebalancingStatus,
+		rebalancingReason,
+		rebalancingMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	status.Conditions, _ = controllerutils.SetHubFederationConditionWithChangeCheck(
+		status.Conditions,
+		hivev1.HubFederationInsufficientCapacityCondition,
+		capacityStatus,
+		capacityReason,
+		capacityMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+
+	if !reflect.DeepEqual(status, fed.Status) {
+		fed.Status = status
+		if err := r.Status().Update(context.Background(), fed); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: resyncInterval}, nil
+}
+
+// peerStatus queries the peer for its HubFederation and ClusterDeployments.
+func (r *ReconcileHubFederation) peerStatus(fed *hivev1.HubFederation, peer *hivev1.HubFederationPeer, logger log.FieldLogger) hivev1.HubFederationHubStatus {
+	logger = logger.WithField("peer", peer.Name)
+	status := hivev1.HubFederationHubStatus{Name: peer.Name}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(context.Background(), types.NamespacedName{
+		Namespace: peer.KubeconfigSecretRef.Namespace,
+		Name:      peer.KubeconfigSecretRef.Name,
+	}, secret); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get kubeconfig secret for peer")
+		status.Message = fmt.Sprintf("Could not get kubeconfig secret: %v", err)
+		return status
+	}
+	peerClient, err := r.remoteClientBuilder(secret).Build()
+	if err != nil {
+		logger.WithError(err).Info("could not connect to peer")
+		status.Message = fmt.Sprintf("Could not connect: %v", err)
+		return status
+	}
+
+	fedList := &hivev1.HubFederationList{}
+	if err := peerClient.List(context.Background(), fedList); err != nil {
+		logger.WithError(err).Info("could not list hub federations on peer")
+		status.Message = fmt.Sprintf("Could not list HubFederations: %v", err)
+		return status
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := peerClient.List(context.Background(), cdList); err != nil {
+		logger.WithError(err).Info("could not list cluster deployments on peer")
+		status.Message = fmt.Sprintf("Could not list ClusterDeployments: %v", err)
+		return status
+	}
+	status.Reachable = true
+	for _, cd := range cdList.Items {
+		if cd.DeletionTimestamp == nil {
+			status.ClusterDeployments++
+		}
+	}
+
+	switch {
+	case len(fedList.Items) == 0:
+		status.Message = "The peer has no HubFederation"
+	case fedList.Items[0].Spec.HubName != peer.Name:
+		status.Message = fmt.Sprintf("The peer's HubFederation is for hub %s", fedList.Items[0].Spec.HubName)
+	default:
+		peerSpec := fedList.Items[0].Spec
+		status.Capacity = peerSpec.Capacity
+		status.Draining = peerSpec.Draining
+		status.Message = fmt.Sprintf("The peer's HubFederation does not list hub %s as a peer", fed.Spec.HubName)
+		for _, p := range peerSpec.Peers {
+			if p.Name == fed.Spec.HubName {
+				status.Registered = true
+				status.Message = ""
+				break
+			}
+		}
+	}
+	return status
+}
+
+// setDestination labels the ClusterDeployment with the hub it should move to, or removes the label if destination
+// is empty.
+func (r *ReconcileHubFederation) setDestination(cd *hivev1.ClusterDeployment, destination string, logger log.FieldLogger) error {
+	logger = logger.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name)
+	if destination == "" {
+		logger.Info("clearing federation destination")
+		delete(cd.Labels, constants.HubFederationDestinationLabel)
+	} else {
+		logger.WithField("destination", destination).Info("moving cluster to peer hub")
+		if cd.Labels == nil {
+			cd.Labels = map[string]string{}
+		}
+		cd.Labels[constants.HubFederationDestinationLabel] = destination
+	}
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster deployment")
+		return err
+	}
+	return nil
+}
+
+// clusterRelocateName returns the name of the ClusterRelocate moving clusters to the peer.
+func clusterRelocateName(fed *hivev1.HubFederation, peerName string) string {
+	return fmt.Sprintf("%s-%s", fed.Name, peerName)
+}
+
+// ensureClusterRelocate creates or updates the ClusterRelocate moving the clusters labelled for the peer. Without a
+// placement, the ClusterRelocate only finishes the moves already under way, and keeps the limit it had.
+func (r *ReconcileHubFederation) ensureClusterRelocate(fed *hivev1.HubFederation, peer *hivev1.HubFederationPeer, logger log.FieldLogger) error {
+	spec := hivev1.ClusterRelocateSpec{
+		KubeconfigSecretRef: peer.KubeconfigSecretRef,
+		ClusterDeploymentSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{constants.HubFederationDestinationLabel: peer.Name},
+		},
+	}
+	if fed.Spec.Placement != nil {
+		spec.MaxConcurrent = fed.Spec.Placement.MaxConcurrent
+	}
+	relocate := &hivev1.ClusterRelocate{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Name: clusterRelocateName(fed, peer.Name)}, relocate); {
+	case apierrors.IsNotFound(err):
+		relocate = &hivev1.ClusterRelocate{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterRelocateName(fed, peer.Name)},
+			Spec:       spec,
+		}
+		if err := controllerutil.SetControllerReference(fed, relocate, scheme.GetScheme()); err != nil {
+			logger.WithError(err).Error("could not set owner of cluster relocate")
+			return err
+		}
+		logger.WithField("clusterRelocate", relocate.Name).Info("creating cluster relocate")
+		if err := r.Create(context.Background(), relocate); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not create cluster relocate")
+			return err
+		}
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster relocate")
+		return err
+	}
+	if fed.Spec.Placement == nil {
+		spec.MaxConcurrent = relocate.Spec.MaxConcurrent
+	}
+	if reflect.DeepEqual(relocate.Spec, spec) {
+		return nil
+	}
+	logger.WithField("clusterRelocate", relocate.Name).Info("updating cluster relocate")
+	relocate.Spec = spec
+	if err := r.Update(context.Background(), relocate); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update cluster relocate")
+		return err
+	}
+	return nil
+}
+
+// deleteStaleClusterRelocates deletes the ClusterRelocates owned by the HubFederation for hubs which are no longer
+// peers.
+func (r *ReconcileHubFederation) deleteStaleClusterRelocates(fed *hivev1.HubFederation, peers map[string]*hub, logger log.FieldLogger) error {
+	relocateList := &hivev1.ClusterRelocateList{}
+	if err := r.List(context.Background(), relocateList); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list cluster relocates")
+		return err
+	}
+	for i := range relocateList.Items {
+		relocate := &relocateList.Items[i]
+		if !metav1.IsControlledBy(relocate, fed) {
+			continue
+		}
+		if _, ok := peers[relocate.Spec.ClusterDeploymentSelector.MatchLabels[constants.HubFederationDestinationLabel]]; ok {
+			continue
+		}
+		logger.WithField("clusterRelocate", relocate.Name).Info("deleting cluster relocate for removed peer")
+		if err := r.Delete(context.Background(), relocate); err != nil && !apierrors.IsNotFound(err) {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete cluster relocate")
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,401 @@
+package hubfederation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testFederation = "federation"
+	testNamespace  = "test-namespace"
+	localHub       = "east"
+	peerA          = "west"
+	peerB          = "north"
+	movableLabel   = "movable"
+)
+
+func TestReconcileHubFederation(t *testing.T) {
+	cases := []struct {
+		name     string
+		spec     func(*hivev1.HubFederationSpec)
+		clusters []*hivev1.ClusterDeployment
+		peers    map[string]*peerHub
+		// relocateMaxConcurrent, if set, is the MaxConcurrent of an existing ClusterRelocate for peerA.
+		relocateMaxConcurrent *int32
+		expectedDestinations  map[string]string
+		expectedRelocates     []string
+		// expectedMaxConcurrent is the MaxConcurrent expected of every ClusterRelocate.
+		expectedMaxConcurrent *int32
+		expectedHubs          []hivev1.HubFederationHubStatus
+		expectedMoving        int32
+		expectedRebalancing   corev1.ConditionStatus
+		expectedInsufficient  corev1.ConditionStatus
+	}{
+		{
+			name:     "report only",
+			clusters: []*hivev1.ClusterDeployment{testCD("cd1"), testCD("cd2")},
+			peers:    map[string]*peerHub{peerA: {clusters: 3, registered: true}},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, ClusterDeployments: 2},
+				{Name: peerA, Reachable: true, Registered: true, ClusterDeployments: 3},
+			},
+			expectedRebalancing:  corev1.ConditionFalse,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+		{
+			name: "over capacity",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Capacity = ptr.To[int32](2)
+			},
+			clusters: []*hivev1.ClusterDeployment{testCD("cd1"), testCD("cd2"), testCD("cd3")},
+			peers: map[string]*peerHub{
+				peerA: {clusters: 3, capacity: ptr.To[int32](5), registered: true},
+				peerB: {clusters: 1, capacity: ptr.To[int32](5), registered: true},
+			},
+			expectedDestinations: map[string]string{"cd1": peerB},
+			expectedRelocates:    []string{peerB},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, Capacity: ptr.To[int32](2), ClusterDeployments: 3},
+				{Name: peerA, Reachable: true, Registered: true, Capacity: ptr.To[int32](5), ClusterDeployments: 3},
+				{Name: peerB, Reachable: true, Registered: true, Capacity: ptr.To[int32](5), ClusterDeployments: 1},
+			},
+			expectedMoving:       1,
+			expectedRebalancing:  corev1.ConditionTrue,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+		{
+			name: "over capacity, already moving",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Capacity = ptr.To[int32](2)
+			},
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", testcd.WithLabel(constants.HubFederationDestinationLabel, peerA)),
+				testCD("cd2"), testCD("cd3"),
+			},
+			peers:                map[string]*peerHub{peerA: {registered: true}},
+			expectedDestinations: map[string]string{"cd1": peerA},
+			expectedRelocates:    []string{peerA},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, Capacity: ptr.To[int32](2), ClusterDeployments: 3},
+				{Name: peerA, Reachable: true, Registered: true},
+			},
+			expectedMoving:       1,
+			expectedRebalancing:  corev1.ConditionTrue,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+		{
+			name: "draining",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Draining = true
+			},
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1"),
+				testCD("cd2"),
+				testCD("not-selected", func(cd *hivev1.ClusterDeployment) { delete(cd.Labels, movableLabel) }),
+				testCD("pooled", func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{Namespace: testNamespace, PoolName: "pool"}
+				}),
+				testCD("not-installed", func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+			},
+			peers:                map[string]*peerHub{peerA: {registered: true}},
+			expectedDestinations: map[string]string{"cd1": peerA, "cd2": peerA},
+			expectedRelocates:    []string{peerA},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, Draining: true, ClusterDeployments: 5},
+				{Name: peerA, Reachable: true, Registered: true},
+			},
+			expectedMoving:       2,
+			expectedRebalancing:  corev1.ConditionTrue,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+		{
+			name: "peer has not registered this hub",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Draining = true
+			},
+			clusters: []*hivev1.ClusterDeployment{testCD("cd1")},
+			peers:    map[string]*peerHub{peerA: {}},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, Draining: true, ClusterDeployments: 1},
+				{Name: peerA, Reachable: true, Message: "The peer's HubFederation does not list hub east as a peer"},
+			},
+			expectedRebalancing:  corev1.ConditionFalse,
+			expectedInsufficient: corev1.ConditionTrue,
+		},
+		{
+			name: "peers full or draining",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Capacity = ptr.To[int32](0)
+			},
+			clusters: []*hivev1.ClusterDeployment{testCD("cd1")},
+			peers: map[string]*peerHub{
+				peerA: {clusters: 2, capacity: ptr.To[int32](2), registered: true},
+				peerB: {draining: true, registered: true},
+			},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, Capacity: ptr.To[int32](0), ClusterDeployments: 1},
+				{Name: peerA, Reachable: true, Registered: true, Capacity: ptr.To[int32](2), ClusterDeployments: 2},
+				{Name: peerB, Reachable: true, Registered: true, Draining: true},
+			},
+			expectedRebalancing:  corev1.ConditionFalse,
+			expectedInsufficient: corev1.ConditionTrue,
+		},
+		{
+			name: "unreachable peer",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Draining = true
+			},
+			clusters: []*hivev1.ClusterDeployment{testCD("cd1")},
+			peers:    map[string]*peerHub{peerA: {unreachable: true}},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, Draining: true, ClusterDeployments: 1},
+				{Name: peerA, Message: "Could not connect: connection refused"},
+			},
+			expectedRebalancing:  corev1.ConditionFalse,
+			expectedInsufficient: corev1.ConditionTrue,
+		},
+		{
+			name: "destination set on clusters placement does not move",
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("not-selected", testcd.WithLabel(constants.HubFederationDestinationLabel, peerA),
+					func(cd *hivev1.ClusterDeployment) { delete(cd.Labels, movableLabel) }),
+				testCD("pooled", testcd.WithLabel(constants.HubFederationDestinationLabel, peerA),
+					func(cd *hivev1.ClusterDeployment) {
+						cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{Namespace: testNamespace, PoolName: "pool", ClaimName: "claim"}
+					}),
+			},
+			peers: map[string]*peerHub{peerA: {registered: true}},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, ClusterDeployments: 2},
+				{Name: peerA, Reachable: true, Registered: true},
+			},
+			expectedRebalancing:  corev1.ConditionFalse,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+		{
+			name: "placement removed while moving",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Placement = nil
+			},
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", testcd.WithLabel(constants.HubFederationDestinationLabel, peerA),
+					testcd.WithAnnotation(constants.RelocateAnnotation, fmt.Sprintf("%s-%s/%s", testFederation, peerA, hivev1.RelocateOutgoing))),
+				testCD("cd2", testcd.WithLabel(constants.HubFederationDestinationLabel, peerA)),
+			},
+			peers:                 map[string]*peerHub{peerA: {registered: true}},
+			relocateMaxConcurrent: ptr.To[int32](2),
+			expectedDestinations:  map[string]string{"cd1": peerA},
+			expectedRelocates:     []string{peerA},
+			expectedMaxConcurrent: ptr.To[int32](2),
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, ClusterDeployments: 2},
+				{Name: peerA, Reachable: true, Registered: true},
+			},
+			expectedMoving:       1,
+			expectedRebalancing:  corev1.ConditionTrue,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+		{
+			name: "placement removed while moving, no cluster relocate",
+			spec: func(spec *hivev1.HubFederationSpec) {
+				spec.Placement = nil
+			},
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", testcd.WithLabel(constants.HubFederationDestinationLabel, peerA),
+					testcd.WithAnnotation(constants.RelocateAnnotation, fmt.Sprintf("%s-%s/%s", testFederation, peerA, hivev1.RelocateOutgoing))),
+			},
+			peers:                map[string]*peerHub{peerA: {registered: true}},
+			expectedDestinations: map[string]string{"cd1": peerA},
+			expectedRelocates:    []string{peerA},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, ClusterDeployments: 1},
+				{Name: peerA, Reachable: true, Registered: true},
+			},
+			expectedMoving:       1,
+			expectedRebalancing:  corev1.ConditionTrue,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+		{
+			name: "arrived from a peer",
+			clusters: []*hivev1.ClusterDeployment{
+				testCD("cd1", testcd.WithLabel(constants.HubFederationDestinationLabel, localHub)),
+				testCD("cd2", testcd.WithLabel(constants.HubFederationDestinationLabel, "removed")),
+			},
+			peers: map[string]*peerHub{peerA: {registered: true}},
+			expectedHubs: []hivev1.HubFederationHubStatus{
+				{Name: localHub, Local: true, Reachable: true, Registered: true, ClusterDeployments: 2},
+				{Name: peerA, Reachable: true, Registered: true},
+			},
+			expectedRebalancing:  corev1.ConditionFalse,
+			expectedInsufficient: corev1.ConditionFalse,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			fed := &hivev1.HubFederation{
+				ObjectMeta: metav1.ObjectMeta{Name: testFederation, UID: "federation-uid"},
+				Spec: hivev1.HubFederationSpec{
+					HubName: localHub,
+					Placement: &hivev1.HubFederationPlacement{
+						ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{movableLabel: "true"}},
+					},
+				},
+			}
+			objects := []runtime.Object{}
+			peerClients := map[string]client.Client{}
+			for _, name := range []string{peerA, peerB} {
+				peer, ok := test.peers[name]
+				if !ok {
+					continue
+				}
+				fed.Spec.Peers = append(fed.Spec.Peers, hivev1.HubFederationPeer{
+					Name:                name,
+					KubeconfigSecretRef: hivev1.KubeconfigSecretReference{Namespace: constants.DefaultHiveNamespace, Name: name + "-kubeconfig"},
+				})
+				objects = append(objects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: name + "-kubeconfig"},
+					Data:       map[string][]byte{constants.KubeconfigSecretKey: []byte(name)},
+				})
+				if !peer.unreachable {
+					peerClients[name] = peer.client(name)
+				}
+			}
+			if test.spec != nil {
+				test.spec(&fed.Spec)
+			}
+			objects = append(objects, fed)
+			if test.relocateMaxConcurrent != nil {
+				relocate := &hivev1.ClusterRelocate{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", testFederation, peerA)},
+					Spec: hivev1.ClusterRelocateSpec{
+						KubeconfigSecretRef: hivev1.KubeconfigSecretReference{Namespace: constants.DefaultHiveNamespace, Name: peerA + "-kubeconfig"},
+						ClusterDeploymentSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{constants.HubFederationDestinationLabel: peerA},
+						},
+						MaxConcurrent: test.relocateMaxConcurrent,
+					},
+				}
+				require.NoError(t, controllerutil.SetControllerReference(fed, relocate, scheme.GetScheme()), "could not set owner")
+				objects = append(objects, relocate)
+			}
+			for _, cd := range test.clusters {
+				objects = append(objects, cd)
+			}
+			c := testfake.NewFakeClientBuilder().WithRuntimeObjects(objects...).Build()
+			mockCtrl := gomock.NewController(t)
+			r := &ReconcileHubFederation{
+				Client: c,
+				logger: log.WithField("controller", "hubfederation"),
+				remoteClientBuilder: func(secret *corev1.Secret) remoteclient.Builder {
+					builder := remoteclientmock.NewMockBuilder(mockCtrl)
+					if peerClient, ok := peerClients[string(secret.Data[constants.KubeconfigSecretKey])]; ok {
+						builder.EXPECT().Build().Return(peerClient, nil)
+					} else {
+						builder.EXPECT().Build().Return(nil, errors.New("connection refused"))
+					}
+					return builder
+				},
+			}
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: testFederation}})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, resyncInterval, result.RequeueAfter, "unexpected requeue")
+
+			destinations := map[string]string{}
+			cdList := &hivev1.ClusterDeploymentList{}
+			require.NoError(t, c.List(context.Background(), cdList), "could not list clusterdeployments")
+			for _, cd := range cdList.Items {
+				if destination, ok := cd.Labels[constants.HubFederationDestinationLabel]; ok {
+					destinations[cd.Name] = destination
+				}
+			}
+			if test.expectedDestinations == nil {
+				test.expectedDestinations = map[string]string{}
+			}
+			assert.Equal(t, test.expectedDestinations, destinations, "unexpected destinations")
+
+			var relocates []string
+			relocateList := &hivev1.ClusterRelocateList{}
+			require.NoError(t, c.List(context.Background(), relocateList), "could not list clusterrelocates")
+			for _, relocate := range relocateList.Items {
+				peer := relocate.Spec.ClusterDeploymentSelector.MatchLabels[constants.HubFederationDestinationLabel]
+				assert.Equal(t, fmt.Sprintf("%s-%s", testFederation, peer), relocate.Name, "unexpected clusterrelocate name")
+				assert.Equal(t, peer+"-kubeconfig", relocate.Spec.KubeconfigSecretRef.Name, "unexpected kubeconfig secret")
+				assert.True(t, metav1.IsControlledBy(&relocate, fed), "clusterrelocate not owned by the federation")
+				assert.Equal(t, test.expectedMaxConcurrent, relocate.Spec.MaxConcurrent, "unexpected maxConcurrent")
+				relocates = append(relocates, peer)
+			}
+			assert.ElementsMatch(t, test.expectedRelocates, relocates, "unexpected clusterrelocates")
+
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: testFederation}, fed), "could not get hub federation")
+			assert.Equal(t, test.expectedHubs, fed.Status.Hubs, "unexpected hubs")
+			assert.Equal(t, test.expectedMoving, fed.Status.Moving, "unexpected moving count")
+			rebalancing := controllerutils.FindCondition(fed.Status.Conditions, hivev1.HubFederationRebalancingCondition)
+			if assert.NotNil(t, rebalancing, "missing Rebalancing condition") {
+				assert.Equal(t, test.expectedRebalancing, rebalancing.Status, "unexpected Rebalancing status")
+			}
+			insufficient := controllerutils.FindCondition(fed.Status.Conditions, hivev1.HubFederationInsufficientCapacityCondition)
+			if assert.NotNil(t, insufficient, "missing InsufficientCapacity condition") {
+				assert.Equal(t, test.expectedInsufficient, insufficient.Status, "unexpected InsufficientCapacity status")
+			}
+		})
+	}
+}
+
+// peerHub describes a peer of the hub under test.
+type peerHub struct {
+	clusters    int
+	capacity    *int32
+	draining    bool
+	registered  bool
+	unreachable bool
+}
+
+// client returns a client for the peer, with its HubFederation and ClusterDeployments.
+func (p *peerHub) client(name string) client.Client {
+	fed := &hivev1.HubFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: testFederation},
+		Spec: hivev1.HubFederationSpec{
+			HubName:  name,
+			Capacity: p.capacity,
+			Draining: p.draining,
+		},
+	}
+	if p.registered {
+		fed.Spec.Peers = []hivev1.HubFederationPeer{{Name: localHub}}
+	}
+	objects := []runtime.Object{fed}
+	for i := 0; i < p.clusters; i++ {
+		objects = append(objects, testcd.FullBuilder(testNamespace, fmt.Sprintf("%s-%d", name, i), scheme.GetScheme()).Build())
+	}
+	return testfake.NewFakeClientBuilder().WithRuntimeObjects(objects...).Build()
+}
+
+func testCD(name string, opts ...testcd.Option) *hivev1.ClusterDeployment {
+	return testcd.FullBuilder(testNamespace, name, scheme.GetScheme()).Build(
+		append([]testcd.Option{testcd.Installed(), testcd.WithLabel(movableLabel, "true")}, opts...)...,
+	)
+}
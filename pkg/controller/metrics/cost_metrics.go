@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/costmodel"
+)
+
+var (
+	metricClusterDeploymentEstimatedHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployment_estimated_hourly_cost",
+		Help: "Estimated hourly cost of an installed cluster, according to the hive-cost-model configmap.",
+	}, []string{"cluster_deployment", "namespace", "cluster_type", "platform", "power_state", "cluster_pool_namespace", "cluster_pool_name"})
+	metricClusterPoolEstimatedHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_pool_estimated_hourly_cost",
+		Help: "Estimated hourly cost of the unclaimed clusters of a cluster pool, according to the hive-cost-model configmap.",
+	}, []string{"cluster_pool_namespace", "cluster_pool_name"})
+	metricNamespaceEstimatedHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_namespace_estimated_hourly_cost",
+		Help: "Estimated hourly cost of the installed clusters in a namespace, according to the hive-cost-model configmap.",
+	}, []string{"namespace"})
+)
+
+// calculateCostMetrics estimates the cost of every installed ClusterDeployment, and of the ClusterPools and namespaces
+// they belong to. If the estimates cannot be calculated, the metrics of the previous pass are kept.
+func (mc *Calculator) calculateCostMetrics(mcLog log.FieldLogger) {
+	model, err := costmodel.Load(context.Background(), mc.Client, "")
+	if err != nil {
+		mcLog.WithError(err).Error("error loading cost model")
+		return
+	}
+	if model == nil {
+		// Cost estimation is not configured
+		metricClusterDeploymentEstimatedHourlyCost.Reset()
+		metricClusterPoolEstimatedHourlyCost.Reset()
+		metricNamespaceEstimatedHourlyCost.Reset()
+		return
+	}
+	mcLog.Debug("calculating estimated cost metrics across all ClusterDeployments")
+	estimates, err := model.EstimateAll(context.Background(), mc.Client, "", mcLog)
+	if err != nil {
+		mcLog.WithError(err).Error("error estimating cluster costs")
+		return
+	}
+
+	// Reset metrics on each pass to prevent reporting clusters which no longer exist
+	metricClusterDeploymentEstimatedHourlyCost.Reset()
+	metricClusterPoolEstimatedHourlyCost.Reset()
+	metricNamespaceEstimatedHourlyCost.Reset()
+	poolCosts := map[types.NamespacedName]float64{}
+	namespaceCosts := map[string]float64{}
+	for _, e := range estimates {
+		poolNamespace, poolName := "", ""
+		if e.PoolRef != nil {
+			poolNamespace, poolName = e.PoolRef.Namespace, e.PoolRef.PoolName
+			// Claimed clusters are paid for by their claim rather than the pool.
+			if e.PoolRef.ClaimName == "" {
+				poolCosts[types.NamespacedName{Namespace: poolNamespace, Name: poolName}] += e.Estimate.HourlyCost
+			}
+		}
+		namespaceCosts[e.Namespace] += e.Estimate.HourlyCost
+		if len(e.Estimate.UnpricedInstanceTypes) > 0 {
+			mcLog.WithField("clusterDeployment", e.Namespace+"/"+e.Name).
+				WithField("instanceTypes", e.Estimate.UnpricedInstanceTypes).
+				Debug("instance types missing from cost model")
+		}
+		clusterType := e.ClusterType
+		if clusterType == "" {
+			clusterType = constants.MetricLabelDefaultValue
+		}
+		metricClusterDeploymentEstimatedHourlyCost.WithLabelValues(
+			e.Name,
+			e.Namespace,
+			clusterType,
+			e.Estimate.Platform,
+			GetPowerStateValue(e.Estimate.PowerState),
+			poolNamespace,
+			poolName,
+		).Set(e.Estimate.HourlyCost)
+	}
+	for pool, cost := range poolCosts {
+		metricClusterPoolEstimatedHourlyCost.WithLabelValues(pool.Namespace, pool.Name).Set(cost)
+	}
+	for namespace, cost := range namespaceCosts {
+		metricNamespaceEstimatedHourlyCost.WithLabelValues(namespace).Set(cost)
+	}
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/costmodel"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+func TestCostMetrics(t *testing.T) {
+	scheme := scheme.GetScheme()
+
+	costModel := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: costmodel.ConfigMapName},
+		Data: map[string]string{
+			"aws": "instanceTypes:\n  m6a.xlarge: 1\ncontrolPlaneInstanceType: m6a.xlarge\nhibernatingClusterHourlyCost: 1\n",
+		},
+	}
+	cdBuilder := func(namespace, name string) testcd.Builder {
+		return testcd.FullBuilder(namespace, name, scheme).Options(
+			testcd.Installed(),
+			testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+		)
+	}
+
+	cases := []struct {
+		name     string
+		existing []runtime.Object
+		expected []string
+	}{
+		{
+			name: "no cost model",
+			existing: []runtime.Object{
+				cdBuilder("ns-1", "cd-1").Build(),
+			},
+		},
+		{
+			name: "clusters and pools",
+			existing: []runtime.Object{
+				costModel,
+				cdBuilder("ns-1", "cd-1").Build(),
+				cdBuilder("ns-1", "cd-2").Build(testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating)),
+				cdBuilder("ns-2", "cd-3").Build(testcd.WithUnclaimedClusterPoolReference("pools", "pool")),
+				cdBuilder("ns-3", "cd-4").Build(testcd.WithClusterPoolReference("pools", "pool", "claim")),
+				testcd.FullBuilder("ns-1", "cd-5", scheme).Build(),
+			},
+			expected: []string{
+				"cluster_deployment = cd-1 cluster_pool_name =  cluster_pool_namespace =  cluster_type = unspecified namespace = ns-1 platform = aws power_state = unspecified 3",
+				"cluster_deployment = cd-2 cluster_pool_name =  cluster_pool_namespace =  cluster_type = unspecified namespace = ns-1 platform = aws power_state = Hibernating 1",
+				"cluster_deployment = cd-3 cluster_pool_name = pool cluster_pool_namespace = pools cluster_type = unspecified namespace = ns-2 platform = aws power_state = unspecified 3",
+				"cluster_deployment = cd-4 cluster_pool_name = pool cluster_pool_namespace = pools cluster_type = unspecified namespace = ns-3 platform = aws power_state = unspecified 3",
+				"cluster_pool_name = pool cluster_pool_namespace = pools 3",
+				"namespace = ns-1 4",
+				"namespace = ns-2 3",
+				"namespace = ns-3 3",
+			},
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			mc := &Calculator{Client: testfake.NewFakeClientBuilder().WithRuntimeObjects(test.existing...).Build()}
+			mc.calculateCostMetrics(log.WithField("test", t.Name()))
+			assert.Equal(t, test.expected, collectCostMetrics(t))
+		})
+	}
+}
+
+func TestCostMetricsKeptOnError(t *testing.T) {
+	cd := testcd.FullBuilder("ns-1", "cd-1", scheme.GetScheme()).Build(
+		testcd.Installed(),
+		testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+	)
+	costModel := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: costmodel.ConfigMapName},
+		Data: map[string]string{
+			"aws": "instanceTypes:\n  m6a.xlarge: 1\ncontrolPlaneInstanceType: m6a.xlarge\n",
+		},
+	}
+	mc := &Calculator{Client: testfake.NewFakeClientBuilder().WithRuntimeObjects(cd, costModel).Build()}
+	mc.calculateCostMetrics(log.WithField("test", t.Name()))
+	expected := collectCostMetrics(t)
+	require.NotEmpty(t, expected, "expected cost metrics")
+
+	invalidCostModel := costModel.DeepCopy()
+	invalidCostModel.Data = map[string]string{"unknown": ""}
+	mc = &Calculator{Client: testfake.NewFakeClientBuilder().WithRuntimeObjects(cd, invalidCostModel).Build()}
+	mc.calculateCostMetrics(log.WithField("test", t.Name()))
+	assert.Equal(t, expected, collectCostMetrics(t), "expected previous cost metrics to be kept")
+}
+
+// collectCostMetrics returns the samples of the cost metrics, sorted.
+func collectCostMetrics(t *testing.T) []string {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		metricClusterDeploymentEstimatedHourlyCost.Collect(ch)
+		metricClusterPoolEstimatedHourlyCost.Collect(ch)
+		metricNamespaceEstimatedHourlyCost.Collect(ch)
+		close(ch)
+	}()
+	var got []string
+	for sample := range ch {
+		var d dto.Metric
+		require.NoError(t, sample.Write(&d))
+		got = append(got, metricPrettyWithValue(&d))
+	}
+	sort.Strings(got)
+	return got
+}
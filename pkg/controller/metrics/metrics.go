@@ -81,6 +81,10 @@ var (
 		Name: "hive_syncsets_unapplied_total",
 		Help: "Total number of SyncSetsInstances referencing non-selector SyncSets that have not successfully applied all resources/patches/secrets.",
 	})
+	metricSyncSetResourcesDrifted = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_syncset_resources_drifted",
+		Help: "Number of synced resources which differ from their SyncSet or SelectorSyncSet and were not reverted, labeled by type of syncset.",
+	}, []string{"type"})
 
 	// TODO: convert all metrics with both namespace and name as labels to namespaced_name (logged as $namespace/$name)
 
@@ -185,6 +189,10 @@ func init() {
 	metrics.Registry.MustRegister(metricSelectorSyncSetClustersUnappliedTotal)
 	metrics.Registry.MustRegister(metricSyncSetsTotal)
 	metrics.Registry.MustRegister(metricSyncSetsUnappliedTotal)
+	metrics.Registry.MustRegister(metricSyncSetResourcesDrifted)
+	metrics.Registry.MustRegister(metricClusterDeploymentEstimatedHourlyCost)
+	metrics.Registry.MustRegister(metricClusterPoolEstimatedHourlyCost)
+	metrics.Registry.MustRegister(metricNamespaceEstimatedHourlyCost)
 	metrics.Registry.MustRegister(metricControllerReconcileTime)
 	metrics.Registry.MustRegister(metricClusterDeploymentSyncsetPaused)
 }
@@ -371,6 +379,7 @@ func (mc *Calculator) Start(ctx context.Context) error {
 		}
 
 		mc.calculateSyncSetMetrics(mcLog)
+		mc.calculateCostMetrics(mcLog)
 	}, mc.Interval)
 
 	return nil
@@ -426,6 +435,8 @@ func (mc *Calculator) calculateSyncSetMetrics(mcLog log.FieldLogger) {
 
 	ssInstancesTotal := 0
 	ssInstancesUnappliedTotal := 0
+	sssResourcesDrifted := 0
+	ssResourcesDrifted := 0
 	for _, cs := range clusterSyncList.Items {
 
 		for _, sss := range cs.Status.SelectorSyncSets {
@@ -433,12 +444,14 @@ func (mc *Calculator) calculateSyncSetMetrics(mcLog log.FieldLogger) {
 			if sss.Result != hiveintv1alpha1.SuccessSyncSetResult {
 				sssInstancesUnappliedTotal[sss.Name]++
 			}
+			sssResourcesDrifted += countDriftedResources(sss.Drift)
 		}
 		for _, ss := range cs.Status.SyncSets {
 			ssInstancesTotal++
 			if ss.Result != hiveintv1alpha1.SuccessSyncSetResult {
 				ssInstancesUnappliedTotal++
 			}
+			ssResourcesDrifted += countDriftedResources(ss.Drift)
 		}
 	}
 	for k, v := range sssInstancesTotal {
@@ -458,6 +471,20 @@ func (mc *Calculator) calculateSyncSetMetrics(mcLog log.FieldLogger) {
 	}
 	metricSyncSetsTotal.Set(float64(ssInstancesTotal))
 	metricSyncSetsUnappliedTotal.Set(float64(ssInstancesUnappliedTotal))
+	metricSyncSetResourcesDrifted.WithLabelValues("selectorsyncset").Set(float64(sssResourcesDrifted))
+	metricSyncSetResourcesDrifted.WithLabelValues("syncset").Set(float64(ssResourcesDrifted))
+}
+
+// countDriftedResources returns the number of resources which still differ from their syncset: drift which was
+// reverted when the syncset was applied is not counted.
+func countDriftedResources(drift []hiveintv1alpha1.SyncResourceDrift) int {
+	count := 0
+	for _, d := range drift {
+		if !d.Reverted {
+			count++
+		}
+	}
+	return count
 }
 
 func processJobs(jobs []batchv1.Job) (runningTotal, succeededTotal, failedTotal map[string]int) {
@@ -4,11 +4,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testfake "github.com/openshift/hive/pkg/test/fake"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -218,3 +222,30 @@ func testClusterDeploymentWithConditions(name, clusterType string, created metav
 	return cd
 
 }
+
+func TestSyncSetResourcesDrifted(t *testing.T) {
+	drift := func(name string, reverted bool) hiveintv1alpha1.SyncResourceDrift {
+		return hiveintv1alpha1.SyncResourceDrift{
+			Resource: hiveintv1alpha1.SyncResourceReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: name},
+			Reverted: reverted,
+		}
+	}
+	clusterSync := func(name string, syncSetDrift, selectorSyncSetDrift []hiveintv1alpha1.SyncResourceDrift) *hiveintv1alpha1.ClusterSync {
+		return &hiveintv1alpha1.ClusterSync{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name},
+			Status: hiveintv1alpha1.ClusterSyncStatus{
+				SyncSets:         []hiveintv1alpha1.SyncStatus{{Name: "ss", Result: hiveintv1alpha1.SuccessSyncSetResult, Drift: syncSetDrift}},
+				SelectorSyncSets: []hiveintv1alpha1.SyncStatus{{Name: "sss", Result: hiveintv1alpha1.SuccessSyncSetResult, Drift: selectorSyncSetDrift}},
+			},
+		}
+	}
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(
+		clusterSync("cluster1", []hiveintv1alpha1.SyncResourceDrift{drift("a", false), drift("b", true)}, nil),
+		clusterSync("cluster2", []hiveintv1alpha1.SyncResourceDrift{drift("a", false)}, []hiveintv1alpha1.SyncResourceDrift{drift("c", true)}),
+	).Build()
+	mc := &Calculator{Client: c}
+	mc.calculateSyncSetMetrics(log.WithField("test", "TestSyncSetResourcesDrifted"))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(metricSyncSetResourcesDrifted.WithLabelValues("syncset")), "unexpected drifted syncset resources")
+	assert.Equal(t, float64(0), testutil.ToFloat64(metricSyncSetResourcesDrifted.WithLabelValues("selectorsyncset")), "unexpected drifted selectorsyncset resources")
+}
@@ -0,0 +1,108 @@
+package azureactuator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	"github.com/openshift/hive/pkg/azureclient"
+)
+
+var (
+	requeueLater = reconcile.Result{RequeueAfter: 1 * time.Minute}
+)
+
+type azureClientFn func(secret *corev1.Secret, cloudName string) (azureclient.Client, error)
+
+func newAzureClient(client client.Client, clientFn azureClientFn, secretName string, secretNamespace string, cloudName hivev1azure.CloudEnvironment) (azureclient.Client, error) {
+	if clientFn == nil {
+		clientFn = azureclient.NewClientFromSecret
+	}
+
+	secret := &corev1.Secret{}
+	err := client.Get(context.TODO(),
+		types.NamespacedName{
+			Name:      secretName,
+			Namespace: secretNamespace,
+		},
+		secret)
+	if err != nil {
+		return nil, err
+	}
+	return clientFn(secret, cloudName.Name())
+}
+
+func initPrivateLinkStatus(cd *hivev1.ClusterDeployment) {
+	if cd.Status.Platform == nil {
+		cd.Status.Platform = &hivev1.PlatformStatus{}
+	}
+	if cd.Status.Platform.Azure == nil {
+		cd.Status.Platform.Azure = &hivev1azure.PlatformStatus{}
+	}
+	if cd.Status.Platform.Azure.PrivateLink == nil {
+		cd.Status.Platform.Azure.PrivateLink = &hivev1azure.PrivateLinkStatus{}
+	}
+}
+
+func updatePrivateLinkStatus(client *client.Client, cd *hivev1.ClusterDeployment) error {
+	var retryBackoff = wait.Backoff{
+		Steps:    5,
+		Duration: 1 * time.Second,
+		Factor:   1.0,
+		Jitter:   0.1,
+	}
+	return retry.RetryOnConflict(retryBackoff, func() error {
+		curr := &hivev1.ClusterDeployment{}
+		err := (*client).Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, curr)
+		if err != nil {
+			return err
+		}
+
+		initPrivateLinkStatus(curr)
+		curr.Status.Platform.Azure.PrivateLink = cd.Status.Platform.Azure.PrivateLink
+		return (*client).Status().Update(context.TODO(), curr)
+	})
+}
+
+// getPrivateLinkStatus returns the private link status of the cluster deployment, or an empty
+// status when it has not been initialized yet.
+func getPrivateLinkStatus(cd *hivev1.ClusterDeployment) hivev1azure.PrivateLinkStatus {
+	if cd.Status.Platform == nil ||
+		cd.Status.Platform.Azure == nil ||
+		cd.Status.Platform.Azure.PrivateLink == nil {
+		return hivev1azure.PrivateLinkStatus{}
+	}
+	return *cd.Status.Platform.Azure.PrivateLink
+}
+
+// clusterResourceGroup returns the resource group containing the cluster resources.
+func clusterResourceGroup(metadata *hivev1.ClusterMetadata) string {
+	if metadata.Platform != nil &&
+		metadata.Platform.Azure != nil &&
+		metadata.Platform.Azure.ResourceGroupName != nil &&
+		*metadata.Platform.Azure.ResourceGroupName != "" {
+		return *metadata.Platform.Azure.ResourceGroupName
+	}
+	// This is the default set by the installer
+	return metadata.InfraID + "-rg"
+}
+
+// apiDomain returns the domain of the cluster API.
+func apiDomain(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("api.%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain)
+}
+
+// virtualNetworkID returns the resource ID of a VNet in the given subscription.
+func virtualNetworkID(subscriptionID string, vnet hivev1.AzurePrivateLinkVNet) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s",
+		subscriptionID, vnet.ResourceGroup, vnet.Name)
+}
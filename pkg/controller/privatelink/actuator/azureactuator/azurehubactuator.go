@@ -0,0 +1,357 @@
+package azureactuator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/controller/privatelink/actuator"
+	"github.com/openshift/hive/pkg/controller/privatelink/conditions"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// TTL of the api record in the private DNS zone.
+	apiRecordTTL = 10
+)
+
+// Ensure AzureHubActuator implements the Actuator interface. This will fail at compile time when false.
+var _ actuator.Actuator = &AzureHubActuator{}
+
+type AzureHubActuator struct {
+	client *client.Client
+	config *hivev1.AzurePrivateLinkConfig
+
+	privateLinkEnabled bool
+
+	azureClientHub azureclient.Client
+}
+
+// NewAzureHubActuator creates a new Azure Hub Actuator
+func NewAzureHubActuator(
+	client *client.Client,
+	config *hivev1.AzurePrivateLinkConfig,
+	privateLinkEnabled bool,
+	azureClientFn azureClientFn,
+	logger log.FieldLogger) (*AzureHubActuator, error) {
+
+	actuator := &AzureHubActuator{
+		client: client,
+		config: config,
+
+		privateLinkEnabled: privateLinkEnabled,
+	}
+
+	if config == nil {
+		return nil, errors.New("unable to create Azure actuator: config is empty")
+	}
+
+	hubClient, err := newAzureClient(*client, azureClientFn, actuator.config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace(), actuator.config.CloudName)
+	if err != nil {
+		return nil, err
+	}
+	actuator.azureClientHub = hubClient
+
+	return actuator, nil
+}
+
+// Cleanup is the actuator interface for cleaning up the cloud resources.
+func (a *AzureHubActuator) Cleanup(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+	if err := a.cleanupPrivateDNSZone(cd, logger); err != nil {
+		return errors.Wrap(err, "error cleaning up Private DNS Zone")
+	}
+
+	return nil
+}
+
+// CleanupRequired is the actuator interface for determining if cleanup is required.
+func (a *AzureHubActuator) CleanupRequired(cd *hivev1.ClusterDeployment) bool {
+	// There is nothing to do when PrivateLink is undefined. This either means it was never enabled, or it was already cleaned up.
+	if cd.Status.Platform == nil ||
+		cd.Status.Platform.Azure == nil ||
+		cd.Status.Platform.Azure.PrivateLink == nil {
+		return false
+	}
+	// There is nothing to do when deleting a ClusterDeployment with PreserveOnDelete unless privatelink has been disabled.
+	// NOTE: If a ClusterDeployment is deleted after a failed install with PreserveOnDelete set, the PrivateLink
+	// resources are not cleaned up. This is by design as the rest of the cloud resources are also not cleaned up.
+	if cd.DeletionTimestamp != nil &&
+		cd.Spec.PreserveOnDelete &&
+		a.privateLinkEnabled {
+		return false
+	}
+
+	return cd.Status.Platform.Azure.PrivateLink.PrivateDNSZone != ""
+}
+
+// Reconcile is the actuator interface for reconciling the cloud resources.
+func (a *AzureHubActuator) Reconcile(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, dnsRecord *actuator.DnsRecord, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.Debug("reconciling hub resources")
+
+	endpointID := getPrivateLinkStatus(cd).PrivateEndpoint
+	if endpointID == "" {
+		return reconcile.Result{}, errors.New("the Private Endpoint has not been created for the cluster")
+	}
+	endpointVNetID, err := a.endpointVirtualNetworkID(endpointID)
+	if err != nil {
+		if err := conditions.SetErrConditionWithRetry(*a.client, cd, "PrivateEndpointVNetNotFound", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to find the VNet of the Private Endpoint")
+	}
+	zoneResourceGroup, err := a.zoneResourceGroup(endpointID)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	zoneName := apiDomain(cd)
+
+	logger.Debug("reconciling Private DNS Zone")
+	zoneModified, err := a.ensurePrivateDNSZone(cd, zoneResourceGroup, zoneName)
+	if err != nil {
+		if err := conditions.SetErrConditionWithRetry(*a.client, cd, "PrivateDNSZoneReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile the Private DNS Zone")
+	}
+	if zoneModified {
+		err := conditions.SetReadyConditionWithRetry(*a.client, cd, corev1.ConditionFalse,
+			"ReconciledPrivateDNSZone",
+			"reconciled the Private DNS Zone for the Private Endpoint of the cluster",
+			logger)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to update condition on cluster deployment")
+		}
+	}
+
+	logger.Debug("reconciling Private DNS Zone Records")
+	if err := a.reconcilePrivateDNSZoneRecords(zoneResourceGroup, zoneName, dnsRecord); err != nil {
+		if err := conditions.SetErrConditionWithRetry(*a.client, cd, "PrivateDNSZoneRecordsReconcileFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile the Private DNS Zone Records")
+	}
+
+	logger.Debug("reconciling Private DNS Zone VNet Links")
+	linksModified, err := a.reconcileVirtualNetworkLinks(zoneResourceGroup, zoneName, endpointVNetID, logger)
+	if err != nil {
+		if err := conditions.SetErrConditionWithRetry(*a.client, cd, "LinkingVNetsToPrivateDNSZoneFailed", err, logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile the Private DNS Zone VNet Links")
+	}
+	if linksModified {
+		err := conditions.SetReadyConditionWithRetry(*a.client, cd, corev1.ConditionFalse,
+			"ReconciledPrivateDNSZoneVNetLinks",
+			"reconciled the links of all the required VNets to the Private DNS Zone for the Private Endpoint",
+			logger)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to update condition on cluster deployment")
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// ShouldSync is the actuator interface to determine if there are changes that need to be made.
+func (a *AzureHubActuator) ShouldSync(cd *hivev1.ClusterDeployment) bool {
+	return cd.Status.Platform == nil ||
+		cd.Status.Platform.Azure == nil ||
+		cd.Status.Platform.Azure.PrivateLink == nil ||
+		cd.Status.Platform.Azure.PrivateLink.PrivateDNSZone == ""
+}
+
+// endpointVirtualNetworkID returns the resource ID of the VNet containing the private endpoint.
+func (a *AzureHubActuator) endpointVirtualNetworkID(endpointID string) (string, error) {
+	id, err := arm.ParseResourceID(endpointID)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing the Private Endpoint ID")
+	}
+	endpoint, err := a.azureClientHub.GetPrivateEndpoint(context.TODO(), id.ResourceGroupName, id.Name)
+	if err != nil {
+		return "", err
+	}
+	if endpoint.Properties == nil || endpoint.Properties.Subnet == nil {
+		return "", errors.New("the Private Endpoint has no subnet")
+	}
+	subnetID, err := arm.ParseResourceID(ptr.Deref(endpoint.Properties.Subnet.ID, ""))
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing the Private Endpoint subnet ID")
+	}
+	return subnetID.Parent.String(), nil
+}
+
+// zoneResourceGroup returns the resource group of the private DNS zone. It defaults to the
+// resource group of the private endpoint.
+func (a *AzureHubActuator) zoneResourceGroup(endpointID string) (string, error) {
+	if a.config.DNSZoneResourceGroup != "" {
+		return a.config.DNSZoneResourceGroup, nil
+	}
+	id, err := arm.ParseResourceID(endpointID)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing the Private Endpoint ID")
+	}
+	return id.ResourceGroupName, nil
+}
+
+// ensurePrivateDNSZone creates the private DNS zone for the cluster api if it does not already exist.
+func (a *AzureHubActuator) ensurePrivateDNSZone(cd *hivev1.ClusterDeployment, resourceGroup string, zoneName string) (bool, error) {
+	modified := false
+
+	zone, err := a.azureClientHub.GetPrivateZone(context.TODO(), resourceGroup, zoneName)
+	if azureclient.IsNotFound(err) {
+		newZone, err := a.azureClientHub.CreateOrUpdatePrivateZone(context.TODO(), resourceGroup, zoneName)
+		if err != nil {
+			return false, errors.Wrap(err, "error creating the Private DNS Zone")
+		}
+		modified = true
+		zone = newZone
+	} else if err != nil {
+		return false, err
+	}
+
+	initPrivateLinkStatus(cd)
+	if cd.Status.Platform.Azure.PrivateLink.PrivateDNSZone != ptr.Deref(zone.ID, "") {
+		cd.Status.Platform.Azure.PrivateLink.PrivateDNSZone = ptr.Deref(zone.ID, "")
+		if err := updatePrivateLinkStatus(a.client, cd); err != nil {
+			return false, errors.Wrap(err, "error updating clusterdeployment status with PrivateDNSZone")
+		}
+		modified = true
+	}
+
+	return modified, nil
+}
+
+// reconcilePrivateDNSZoneRecords points the apex of the private DNS zone at the private endpoint.
+func (a *AzureHubActuator) reconcilePrivateDNSZoneRecords(resourceGroup string, zoneName string, dnsRecord *actuator.DnsRecord) error {
+	if len(dnsRecord.IpAddress) == 0 {
+		return errors.New("no IP addresses were provided for the Private DNS Zone records")
+	}
+
+	records := make([]privatedns.ARecord, 0, len(dnsRecord.IpAddress))
+	for _, ip := range dnsRecord.IpAddress {
+		records = append(records, privatedns.ARecord{Ipv4Address: ptr.To(ip)})
+	}
+
+	_, err := a.azureClientHub.CreateOrUpdatePrivateRecordSet(context.TODO(), resourceGroup, zoneName, "@", privatedns.A, privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			TTL:      ptr.To[int64](apiRecordTTL),
+			ARecords: &records,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error updating the Private DNS Zone records")
+	}
+	return nil
+}
+
+// reconcileVirtualNetworkLinks links the private DNS zone to the VNet of the private endpoint and to the
+// associated VNets from the config, and removes links to any other VNet.
+func (a *AzureHubActuator) reconcileVirtualNetworkLinks(resourceGroup string, zoneName string, endpointVNetID string, logger log.FieldLogger) (bool, error) {
+	modified := false
+
+	desired := map[string]string{strings.ToLower(endpointVNetID): endpointVNetID}
+	for _, vnet := range a.config.AssociatedVNets {
+		id := virtualNetworkID(a.azureClientHub.SubscriptionID(), vnet)
+		desired[strings.ToLower(id)] = id
+	}
+
+	links, err := a.azureClientHub.ListVirtualNetworkLinks(context.TODO(), resourceGroup, zoneName)
+	if err != nil {
+		return false, errors.Wrap(err, "error listing the Private DNS Zone VNet links")
+	}
+
+	linked := map[string]bool{}
+	for _, link := range links {
+		var vnetID string
+		if link.VirtualNetworkLinkProperties != nil && link.VirtualNetworkLinkProperties.VirtualNetwork != nil {
+			vnetID = strings.ToLower(ptr.Deref(link.VirtualNetworkLinkProperties.VirtualNetwork.ID, ""))
+		}
+		if _, ok := desired[vnetID]; ok {
+			linked[vnetID] = true
+			continue
+		}
+		logger.WithField("link", ptr.Deref(link.Name, "")).Debug("removing VNet link from the Private DNS Zone")
+		if err := a.azureClientHub.DeleteVirtualNetworkLink(context.TODO(), resourceGroup, zoneName, ptr.Deref(link.Name, "")); err != nil && !azureclient.IsNotFound(err) {
+			return false, errors.Wrap(err, "error deleting the Private DNS Zone VNet link")
+		}
+		modified = true
+	}
+
+	for key, vnetID := range desired {
+		if linked[key] {
+			continue
+		}
+		linkName, err := virtualNetworkLinkName(vnetID)
+		if err != nil {
+			return false, err
+		}
+		logger.WithField("vnet", vnetID).Debug("linking VNet to the Private DNS Zone")
+		if _, err := a.azureClientHub.CreateOrUpdateVirtualNetworkLink(context.TODO(), resourceGroup, zoneName, linkName, vnetID); err != nil {
+			return false, errors.Wrap(err, "error linking the VNet to the Private DNS Zone")
+		}
+		modified = true
+	}
+
+	return modified, nil
+}
+
+// virtualNetworkLinkName returns the name of the link between the private DNS zone and the VNet.
+func virtualNetworkLinkName(vnetID string) (string, error) {
+	id, err := arm.ParseResourceID(vnetID)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing the VNet ID")
+	}
+	return fmt.Sprintf("%s-%s", id.ResourceGroupName, id.Name), nil
+}
+
+// cleanupPrivateDNSZone deletes the private DNS zone along with its VNet links.
+func (a *AzureHubActuator) cleanupPrivateDNSZone(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	zoneID := getPrivateLinkStatus(cd).PrivateDNSZone
+	if zoneID == "" {
+		logger.Debug("no Private DNS Zone recorded for the cluster, skipping cleanup")
+		return nil
+	}
+	logger.Debug("cleaning up Private DNS Zone")
+
+	id, err := arm.ParseResourceID(zoneID)
+	if err != nil {
+		return errors.Wrap(err, "error parsing the Private DNS Zone ID")
+	}
+
+	// The zone cannot be deleted while VNets are linked to it.
+	links, err := a.azureClientHub.ListVirtualNetworkLinks(context.TODO(), id.ResourceGroupName, id.Name)
+	if err != nil && !azureclient.IsNotFound(err) {
+		return errors.Wrap(err, "error listing the Private DNS Zone VNet links")
+	}
+	for _, link := range links {
+		err := a.azureClientHub.DeleteVirtualNetworkLink(context.TODO(), id.ResourceGroupName, id.Name, ptr.Deref(link.Name, ""))
+		if err != nil && !azureclient.IsNotFound(err) {
+			return errors.Wrap(err, "error deleting the Private DNS Zone VNet link")
+		}
+	}
+
+	err = a.azureClientHub.DeletePrivateZone(context.TODO(), id.ResourceGroupName, id.Name)
+	if err != nil && !azureclient.IsNotFound(err) {
+		return errors.Wrap(err, "error deleting the Private DNS Zone")
+	}
+
+	initPrivateLinkStatus(cd)
+	cd.Status.Platform.Azure.PrivateLink.PrivateDNSZone = ""
+	if err := updatePrivateLinkStatus(a.client, cd); err != nil {
+		return errors.Wrap(err, "error updating clusterdeployment after cleanup of PrivateDNSZone")
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package azureactuator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	mockclient "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/controller/privatelink/actuator"
+	testassert "github.com/openshift/hive/pkg/test/assert"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testlogger "github.com/openshift/hive/pkg/test/logger"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testClusterName = "test-cluster"
+	testBaseDomain  = "example.com"
+	testAPIDomain   = "api." + testClusterName + "." + testBaseDomain
+)
+
+func newTestAzureHubActuator(t *testing.T, config *hivev1.AzurePrivateLinkConfig, cd *hivev1.ClusterDeployment, existing []runtime.Object, configureAzureClient func(*mockclient.MockClient)) (*AzureHubActuator, error) {
+	fakeClient := client.Client(testfake.NewFakeClientBuilder().
+		WithRuntimeObjects(cd).
+		WithRuntimeObjects(existing...).
+		Build())
+
+	mockedAzureClient := mockclient.NewMockClient(gomock.NewController(t))
+	if configureAzureClient != nil {
+		configureAzureClient(mockedAzureClient)
+	}
+
+	return &AzureHubActuator{
+		client:         &fakeClient,
+		config:         config,
+		azureClientHub: mockedAzureClient,
+	}, nil
+}
+
+func Test_HubReconcile(t *testing.T) {
+	cdBuilder := testcd.FullBuilder(testNameSpace, testClusterDeployment, scheme.GetScheme()).Options(
+		testcd.WithAzurePlatform(&hivev1azure.Platform{Region: testRegion}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: testInfraID}),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterName = testClusterName
+			cd.Spec.BaseDomain = testBaseDomain
+		},
+	)
+	staleVNetID := "/subscriptions/" + testHubSubscription + "/resourceGroups/other-rg/providers/Microsoft.Network/virtualNetworks/stale-vnet"
+	associatedVNetID := "/subscriptions/" + testHubSubscription + "/resourceGroups/dns-rg/providers/Microsoft.Network/virtualNetworks/dns-vnet"
+
+	cases := []struct {
+		name   string
+		cd     *hivev1.ClusterDeployment
+		config *hivev1.AzurePrivateLinkConfig
+		record *actuator.DnsRecord
+
+		azureClientConfig func(*mockclient.MockClient)
+
+		expectConditions []hivev1.ClusterDeploymentCondition
+		expectError      string
+		expectStatus     *hivev1azure.PrivateLinkStatus
+	}{{ // There should be an error when the private endpoint has not been created
+		name:        "no private endpoint",
+		cd:          cdBuilder.Build(),
+		config:      mockConfig,
+		record:      &actuator.DnsRecord{IpAddress: []string{testEndpointIPAddress}},
+		expectError: "the Private Endpoint has not been created for the cluster",
+	}, { // There should be an error and failed condition on failure to create the zone
+		name: "CreateOrUpdatePrivateZone failure",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+			}}),
+		),
+		config: mockConfig,
+		record: &actuator.DnsRecord{IpAddress: []string{testEndpointIPAddress}},
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(mockEndpoint(connectionStatusApproved), nil)
+			m.EXPECT().GetPrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{}, mockNotFoundErr)
+			m.EXPECT().CreateOrUpdatePrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{}, mockForbiddenErr)
+		},
+		expectConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.PrivateLinkFailedClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PrivateDNSZoneReconcileFailed",
+			Message: "error creating the Private DNS Zone: not authorized",
+		}},
+		expectError: "failed to reconcile the Private DNS Zone: error creating the Private DNS Zone: not authorized",
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateEndpoint: mockEndpointID,
+		},
+	}, { // Should create the zone and record, link the VNets and remove stale links
+		name: "zone created",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+			}}),
+		),
+		config: &hivev1.AzurePrivateLinkConfig{
+			EndpointVNetInventory: mockConfig.EndpointVNetInventory,
+			AssociatedVNets: []hivev1.AzurePrivateLinkVNet{{
+				ResourceGroup: "dns-rg",
+				Name:          "dns-vnet",
+			}},
+		},
+		record: &actuator.DnsRecord{IpAddress: []string{testEndpointIPAddress}},
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(mockEndpoint(connectionStatusApproved), nil)
+			m.EXPECT().GetPrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{}, mockNotFoundErr)
+			m.EXPECT().CreateOrUpdatePrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(privatedns.PrivateZone{ID: ptr.To(mockPrivateZone)}, nil)
+			m.EXPECT().CreateOrUpdatePrivateRecordSet(gomock.Any(), testHubResourceGroup, testAPIDomain, "@", privatedns.A, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _, _ string, _ privatedns.RecordType, recordSet privatedns.RecordSet) (privatedns.RecordSet, error) {
+					assert.Equal(t, []privatedns.ARecord{{Ipv4Address: ptr.To(testEndpointIPAddress)}}, *recordSet.ARecords)
+					return recordSet, nil
+				})
+			m.EXPECT().SubscriptionID().Return(testHubSubscription)
+			m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), testHubResourceGroup, testAPIDomain).Return([]privatedns.VirtualNetworkLink{{
+				Name: ptr.To("other-rg-stale-vnet"),
+				VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+					VirtualNetwork: &privatedns.SubResource{ID: ptr.To(staleVNetID)},
+				},
+			}}, nil)
+			m.EXPECT().DeleteVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, "other-rg-stale-vnet").Return(nil)
+			m.EXPECT().CreateOrUpdateVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testHubResourceGroup+"-"+testHubVNet, mockHubVNetID).Return(privatedns.VirtualNetworkLink{}, nil)
+			m.EXPECT().CreateOrUpdateVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, "dns-rg-dns-vnet", associatedVNetID).Return(privatedns.VirtualNetworkLink{}, nil)
+		},
+		expectConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:   hivev1.PrivateLinkReadyClusterDeploymentCondition,
+			Status: corev1.ConditionFalse,
+			Reason: "ReconciledPrivateDNSZoneVNetLinks",
+		}},
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateEndpoint: mockEndpointID,
+			PrivateDNSZone:  mockPrivateZone,
+		},
+	}, { // Should use the configured resource group for the zone and leave existing links alone
+		name: "zone exists in configured resource group",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+				PrivateDNSZone:  mockPrivateZone,
+			}}),
+		),
+		config: &hivev1.AzurePrivateLinkConfig{
+			EndpointVNetInventory: mockConfig.EndpointVNetInventory,
+			DNSZoneResourceGroup:  "dns-rg",
+		},
+		record: &actuator.DnsRecord{IpAddress: []string{testEndpointIPAddress}},
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(mockEndpoint(connectionStatusApproved), nil)
+			m.EXPECT().GetPrivateZone(gomock.Any(), "dns-rg", testAPIDomain).Return(privatedns.PrivateZone{ID: ptr.To(mockPrivateZone)}, nil)
+			m.EXPECT().CreateOrUpdatePrivateRecordSet(gomock.Any(), "dns-rg", testAPIDomain, "@", privatedns.A, gomock.Any()).Return(privatedns.RecordSet{}, nil)
+			m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), "dns-rg", testAPIDomain).Return([]privatedns.VirtualNetworkLink{{
+				Name: ptr.To(testHubResourceGroup + "-" + testHubVNet),
+				VirtualNetworkLinkProperties: &privatedns.VirtualNetworkLinkProperties{
+					VirtualNetwork: &privatedns.SubResource{ID: ptr.To(mockHubVNetID)},
+				},
+			}}, nil)
+		},
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateEndpoint: mockEndpointID,
+			PrivateDNSZone:  mockPrivateZone,
+		},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := testlogger.NewLoggerWithHook()
+			azureHubActuator, err := newTestAzureHubActuator(t, test.config, test.cd, []runtime.Object{}, test.azureClientConfig)
+			require.NoError(t, err)
+
+			_, err = azureHubActuator.Reconcile(test.cd, test.cd.Spec.ClusterMetadata, test.record, logger)
+			if test.expectError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectError)
+			}
+
+			if test.expectStatus != nil {
+				assert.Equal(t, test.expectStatus, test.cd.Status.Platform.Azure.PrivateLink)
+			}
+
+			curr := &hivev1.ClusterDeployment{}
+			fakeClient := *azureHubActuator.client
+			errGet := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: test.cd.Namespace, Name: test.cd.Name}, curr)
+			assert.NoError(t, errGet)
+			testassert.AssertConditions(t, curr, test.expectConditions)
+		})
+	}
+}
+
+func Test_HubCleanup(t *testing.T) {
+	cdBuilder := testcd.FullBuilder(testNameSpace, testClusterDeployment, scheme.GetScheme())
+
+	cases := []struct {
+		name string
+		cd   *hivev1.ClusterDeployment
+
+		azureClientConfig func(*mockclient.MockClient)
+
+		expectError  string
+		expectStatus *hivev1azure.PrivateLinkStatus
+	}{{ // Nothing should be done when no zone is recorded
+		name: "no zone",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+			}}),
+		),
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateEndpoint: mockEndpointID,
+		},
+	}, { // The links should be removed before deleting the zone
+		name: "zone deleted",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+				PrivateDNSZone:  mockPrivateZone,
+			}}),
+		),
+		azureClientConfig: func(m *mockclient.MockClient) {
+			gomock.InOrder(
+				m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), testHubResourceGroup, testAPIDomain).Return([]privatedns.VirtualNetworkLink{{
+					Name: ptr.To(testHubResourceGroup + "-" + testHubVNet),
+				}}, nil),
+				m.EXPECT().DeleteVirtualNetworkLink(gomock.Any(), testHubResourceGroup, testAPIDomain, testHubResourceGroup+"-"+testHubVNet).Return(nil),
+				m.EXPECT().DeletePrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(nil),
+			)
+		},
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateEndpoint: mockEndpointID,
+		},
+	}, { // There should be an error on failure to delete the zone
+		name: "DeletePrivateZone failure",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateDNSZone: mockPrivateZone,
+			}}),
+		),
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().ListVirtualNetworkLinks(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(nil, mockNotFoundErr)
+			m.EXPECT().DeletePrivateZone(gomock.Any(), testHubResourceGroup, testAPIDomain).Return(mockForbiddenErr)
+		},
+		expectError: "error cleaning up Private DNS Zone: error deleting the Private DNS Zone: not authorized",
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateDNSZone: mockPrivateZone,
+		},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := testlogger.NewLoggerWithHook()
+			azureHubActuator, err := newTestAzureHubActuator(t, mockConfig, test.cd, []runtime.Object{}, test.azureClientConfig)
+			require.NoError(t, err)
+
+			err = azureHubActuator.Cleanup(test.cd, &hivev1.ClusterMetadata{InfraID: testInfraID}, logger)
+			if test.expectError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectError)
+			}
+			assert.Equal(t, test.expectStatus, test.cd.Status.Platform.Azure.PrivateLink)
+		})
+	}
+}
+
+func Test_HubShouldSync(t *testing.T) {
+	azureHubActuator := &AzureHubActuator{}
+	cdBuilder := testcd.FullBuilder(testNameSpace, testClusterDeployment, scheme.GetScheme())
+
+	assert.True(t, azureHubActuator.ShouldSync(cdBuilder.Build()))
+	assert.True(t, azureHubActuator.ShouldSync(cdBuilder.Build(
+		testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+			PrivateEndpoint: mockEndpointID,
+		}}),
+	)))
+	assert.False(t, azureHubActuator.ShouldSync(cdBuilder.Build(
+		testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+			PrivateDNSZone: mockPrivateZone,
+		}}),
+	)))
+}
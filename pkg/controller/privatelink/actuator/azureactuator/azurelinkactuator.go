@@ -0,0 +1,517 @@
+package azureactuator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/controller/privatelink/actuator"
+	"github.com/openshift/hive/pkg/controller/privatelink/conditions"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// Name of the internal api load balancer frontend created by the installer.
+	apiFrontendIPConfigurationName = "internal-lb-ip-v4"
+
+	// Status of a private endpoint connection that has been approved.
+	connectionStatusApproved = "Approved"
+)
+
+// Ensure AzureLinkActuator implements the Actuator interface. This will fail at compile time when false.
+var _ actuator.Actuator = &AzureLinkActuator{}
+
+type AzureLinkActuator struct {
+	client *client.Client
+	config *hivev1.AzurePrivateLinkConfig
+
+	privateLinkEnabled bool
+
+	azureClientHub   azureclient.Client
+	azureClientSpoke azureclient.Client
+}
+
+func NewAzureLinkActuator(
+	client *client.Client,
+	config *hivev1.AzurePrivateLinkConfig,
+	cd *hivev1.ClusterDeployment,
+	privateLinkEnabled bool,
+	azureClientFn azureClientFn,
+	logger log.FieldLogger) (*AzureLinkActuator, error) {
+
+	actuator := &AzureLinkActuator{
+		client: client,
+		config: config,
+
+		privateLinkEnabled: privateLinkEnabled,
+	}
+
+	if config == nil {
+		return nil, errors.New("unable to create Azure actuator: config is empty")
+	}
+
+	if cd == nil || cd.Spec.Platform.Azure == nil {
+		return nil, errors.New("unable to create Azure actuator: cluster deployment spec does not contain Azure platform")
+	}
+
+	hubClient, err := newAzureClient(*client, azureClientFn, actuator.config.CredentialsSecretRef.Name, controllerutils.GetHiveNamespace(), actuator.config.CloudName)
+	if err != nil {
+		return nil, err
+	}
+	actuator.azureClientHub = hubClient
+
+	spokeClient, err := newAzureClient(*client, azureClientFn, cd.Spec.Platform.Azure.CredentialsSecretRef.Name, cd.Namespace, cd.Spec.Platform.Azure.CloudName)
+	if err != nil {
+		return nil, err
+	}
+	actuator.azureClientSpoke = spokeClient
+
+	return actuator, nil
+}
+
+// Cleanup is the actuator interface for cleaning up the cloud resources.
+func (a *AzureLinkActuator) Cleanup(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+	if err := a.cleanupPrivateEndpoint(cd, metadata, logger); err != nil {
+		return errors.Wrap(err, "error cleaning up private endpoint")
+	}
+
+	if err := a.cleanupPrivateLinkService(cd, metadata, logger); err != nil {
+		return errors.Wrap(err, "error cleaning up private link service")
+	}
+
+	return nil
+}
+
+// CleanupRequired is the actuator interface for determining if cleanup is required.
+func (a *AzureLinkActuator) CleanupRequired(cd *hivev1.ClusterDeployment) bool {
+	// There is nothing to do when PrivateLink is undefined.
+	// This either means it was never enabled, or it was already cleaned up.
+	if cd.Status.Platform == nil ||
+		cd.Status.Platform.Azure == nil ||
+		cd.Status.Platform.Azure.PrivateLink == nil {
+		return false
+	}
+
+	// There is nothing to do when deleting a ClusterDeployment with PreserveOnDelete unless privatelink has been disabled.
+	// If a ClusterDeployment is deleted after a failed install with PreserveOnDelete set, the PrivateLink
+	// resources are not cleaned up. This is by design as the rest of the cloud resources are also not cleaned up.
+	if cd.DeletionTimestamp != nil &&
+		cd.Spec.PreserveOnDelete &&
+		a.privateLinkEnabled {
+		return false
+	}
+
+	return cd.Status.Platform.Azure.PrivateLink.PrivateEndpoint != "" ||
+		cd.Status.Platform.Azure.PrivateLink.PrivateLinkService != ""
+}
+
+// Reconcile is the actuator interface for reconciling the cloud resources.
+func (a *AzureLinkActuator) Reconcile(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, dnsRecord *actuator.DnsRecord, logger log.FieldLogger) (reconcile.Result, error) {
+	logger.Debug("reconciling link resources")
+
+	loadBalancer, err := a.azureClientSpoke.GetLoadBalancer(context.TODO(), clusterResourceGroup(metadata), metadata.InfraID+"-internal")
+	if azureclient.IsNotFound(err) {
+		logger.Debug("waiting for cluster api load balancer to be provisioned, will retry soon.")
+		return requeueLater, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to find the cluster api Load Balancer")
+	}
+	frontendIPConfiguration, err := findAPIFrontendIPConfiguration(loadBalancer)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	logger.Debug("reconciling Private Link Service")
+	serviceModified, service, err := a.ensurePrivateLinkService(cd, metadata, frontendIPConfiguration)
+	if err != nil {
+		if err := conditions.SetErrConditionWithRetry(*a.client, cd, "PrivateLinkServiceReconcileFailed", errors.New(controllerutils.ErrorScrub(err)), logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile the Private Link Service")
+	}
+	if serviceModified {
+		err := conditions.SetReadyConditionWithRetry(*a.client, cd, corev1.ConditionFalse,
+			"ReconciledPrivateLinkService",
+			"reconciled the Private Link Service",
+			logger)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to update condition on cluster deployment")
+		}
+	}
+
+	logger.Debug("reconciling Private Endpoint")
+	endpointModified, endpoint, err := a.ensurePrivateEndpoint(cd, metadata, service)
+	if err != nil {
+		if err := conditions.SetErrConditionWithRetry(*a.client, cd, "PrivateEndpointReconcileFailed", errors.New(controllerutils.ErrorScrub(err)), logger); err != nil {
+			logger.WithError(err).Error("failed to update condition on cluster deployment")
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to reconcile the Private Endpoint")
+	}
+	if endpointModified {
+		err := conditions.SetReadyConditionWithRetry(*a.client, cd, corev1.ConditionFalse,
+			"ReconciledPrivateEndpoint",
+			"reconciled the Private Endpoint",
+			logger)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to update condition on cluster deployment")
+		}
+	}
+
+	if !privateEndpointApproved(endpoint) {
+		logger.Debug("waiting for the Private Endpoint connection to be approved, will retry soon.")
+		return requeueLater, nil
+	}
+
+	ipAddress, err := a.privateEndpointIPAddress(endpoint)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get the Private Endpoint IP address")
+	}
+
+	// Set the DNS IP Addresses for the hub actuator.
+	dnsRecord.IpAddress = []string{ipAddress}
+
+	return reconcile.Result{}, nil
+}
+
+// ShouldSync is the actuator interface to determine if there are changes that need to be made.
+func (a *AzureLinkActuator) ShouldSync(cd *hivev1.ClusterDeployment) bool {
+	return cd.Status.Platform == nil ||
+		cd.Status.Platform.Azure == nil ||
+		cd.Status.Platform.Azure.PrivateLink == nil ||
+		cd.Status.Platform.Azure.PrivateLink.PrivateEndpoint == "" ||
+		cd.Status.Platform.Azure.PrivateLink.PrivateLinkService == ""
+}
+
+// findAPIFrontendIPConfiguration returns the frontend of the internal load balancer that serves the cluster api.
+func findAPIFrontendIPConfiguration(loadBalancer *armnetwork.LoadBalancer) (*armnetwork.FrontendIPConfiguration, error) {
+	if loadBalancer.Properties == nil || len(loadBalancer.Properties.FrontendIPConfigurations) == 0 {
+		return nil, errors.New("the cluster api Load Balancer has no frontend IP configurations")
+	}
+	for _, frontend := range loadBalancer.Properties.FrontendIPConfigurations {
+		if ptr.Deref(frontend.Name, "") == apiFrontendIPConfigurationName {
+			return frontend, nil
+		}
+	}
+	return loadBalancer.Properties.FrontendIPConfigurations[0], nil
+}
+
+// ensurePrivateLinkService creates the private link service if it does not already exist.
+func (a *AzureLinkActuator) ensurePrivateLinkService(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, frontendIPConfiguration *armnetwork.FrontendIPConfiguration) (bool, *armnetwork.PrivateLinkService, error) {
+	modified := false
+
+	resourceGroup := clusterResourceGroup(metadata)
+	serviceName := metadata.InfraID + "-pls"
+	service, err := a.azureClientSpoke.GetPrivateLinkService(context.TODO(), resourceGroup, serviceName)
+	if azureclient.IsNotFound(err) {
+		newService, err := a.createPrivateLinkService(cd, metadata, serviceName, frontendIPConfiguration)
+		if err != nil {
+			return false, nil, err
+		}
+		modified = true
+		service = newService
+	} else if err != nil {
+		return false, nil, err
+	}
+
+	initPrivateLinkStatus(cd)
+	if cd.Status.Platform.Azure.PrivateLink.PrivateLinkService != ptr.Deref(service.ID, "") {
+		cd.Status.Platform.Azure.PrivateLink.PrivateLinkService = ptr.Deref(service.ID, "")
+		if err := updatePrivateLinkStatus(a.client, cd); err != nil {
+			return false, nil, errors.Wrap(err, "error updating clusterdeployment status with PrivateLinkService")
+		}
+		modified = true
+	}
+
+	return modified, service, nil
+}
+
+// createPrivateLinkService creates the private link service in the control plane subnet of the cluster.
+// The service is only visible to, and automatically approves connections from, the hub subscription.
+func (a *AzureLinkActuator) createPrivateLinkService(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, serviceName string, frontendIPConfiguration *armnetwork.FrontendIPConfiguration) (*armnetwork.PrivateLinkService, error) {
+	resourceGroup := clusterResourceGroup(metadata)
+	vnetName := metadata.InfraID + "-vnet"
+	subnetName := metadata.InfraID + "-master-subnet"
+
+	subnet, err := a.azureClientSpoke.GetSubnet(context.TODO(), resourceGroup, vnetName, subnetName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the cluster control plane Subnet")
+	}
+
+	// Private link service network policies must be disabled on the subnet that hosts the service.
+	if subnet.Properties == nil {
+		subnet.Properties = &armnetwork.SubnetPropertiesFormat{}
+	}
+	if subnet.Properties.PrivateLinkServiceNetworkPolicies == nil ||
+		*subnet.Properties.PrivateLinkServiceNetworkPolicies != armnetwork.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled {
+		subnet.Properties.PrivateLinkServiceNetworkPolicies = ptr.To(armnetwork.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled)
+		subnet, err = a.azureClientSpoke.CreateOrUpdateSubnet(context.TODO(), resourceGroup, vnetName, subnetName, *subnet)
+		if err != nil {
+			return nil, errors.Wrap(err, "error disabling private link service network policies on the cluster control plane Subnet")
+		}
+	}
+
+	hubSubscription := a.azureClientHub.SubscriptionID()
+	service, err := a.azureClientSpoke.CreateOrUpdatePrivateLinkService(context.TODO(), resourceGroup, serviceName, armnetwork.PrivateLinkService{
+		Location: ptr.To(cd.Spec.Platform.Azure.Region),
+		Properties: &armnetwork.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{{
+				ID: frontendIPConfiguration.ID,
+			}},
+			IPConfigurations: []*armnetwork.PrivateLinkServiceIPConfiguration{{
+				Name: ptr.To(serviceName + "-nat"),
+				Properties: &armnetwork.PrivateLinkServiceIPConfigurationProperties{
+					Primary:                   ptr.To(true),
+					PrivateIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodDynamic),
+					Subnet:                    &armnetwork.Subnet{ID: subnet.ID},
+				},
+			}},
+			Visibility: &armnetwork.PrivateLinkServicePropertiesVisibility{
+				Subscriptions: []*string{ptr.To(hubSubscription)},
+			},
+			AutoApproval: &armnetwork.PrivateLinkServicePropertiesAutoApproval{
+				Subscriptions: []*string{ptr.To(hubSubscription)},
+			},
+		},
+	})
+	if err != nil || service == nil {
+		return nil, errors.Wrap(err, "error creating the Private Link Service")
+	}
+
+	return service, nil
+}
+
+// cleanupPrivateLinkService deletes the private link service.
+func (a *AzureLinkActuator) cleanupPrivateLinkService(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+	logger.Debug("cleaning up Private Link Service")
+
+	err := a.azureClientSpoke.DeletePrivateLinkService(context.TODO(), clusterResourceGroup(metadata), metadata.InfraID+"-pls")
+	if err != nil && !azureclient.IsNotFound(err) {
+		return errors.Wrap(err, "error deleting the Private Link Service")
+	}
+
+	initPrivateLinkStatus(cd)
+	if cd.Status.Platform.Azure.PrivateLink.PrivateLinkService != "" {
+		cd.Status.Platform.Azure.PrivateLink.PrivateLinkService = ""
+		if err := updatePrivateLinkStatus(a.client, cd); err != nil {
+			return errors.Wrap(err, "error updating clusterdeployment after cleanup of PrivateLinkService")
+		}
+	}
+
+	return nil
+}
+
+// ensurePrivateEndpoint creates the private endpoint if it does not already exist. The subnet of the endpoint is only
+// chosen when creating it: an existing endpoint stays in its subnet even if the inventory has changed since.
+func (a *AzureLinkActuator) ensurePrivateEndpoint(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, service *armnetwork.PrivateLinkService) (bool, *armnetwork.PrivateEndpoint, error) {
+	modified := false
+
+	endpointName := metadata.InfraID + "-pe"
+	var endpoint *armnetwork.PrivateEndpoint
+	if existing := getPrivateLinkStatus(cd).PrivateEndpoint; existing != "" {
+		id, err := arm.ParseResourceID(existing)
+		if err != nil {
+			return false, nil, errors.Wrap(err, "error parsing the Private Endpoint ID")
+		}
+		endpoint, err = a.azureClientHub.GetPrivateEndpoint(context.TODO(), id.ResourceGroupName, endpointName)
+		if err != nil && !azureclient.IsNotFound(err) {
+			return false, nil, err
+		}
+	}
+	if endpoint == nil {
+		subnet, resourceGroup, err := chooseSubnetForEndpoint(a.azureClientHub, *a.config, cd.Spec.Platform.Azure.Region)
+		if err != nil {
+			return false, nil, errors.Wrap(err, "error choosing a Subnet for the Private Endpoint")
+		}
+		endpoint, err = a.azureClientHub.GetPrivateEndpoint(context.TODO(), resourceGroup, endpointName)
+		if azureclient.IsNotFound(err) {
+			endpoint, err = a.createPrivateEndpoint(cd, resourceGroup, endpointName, subnet, service)
+			if err != nil {
+				return false, nil, err
+			}
+			modified = true
+		} else if err != nil {
+			return false, nil, err
+		}
+	}
+
+	initPrivateLinkStatus(cd)
+	if cd.Status.Platform.Azure.PrivateLink.PrivateEndpoint != ptr.Deref(endpoint.ID, "") {
+		cd.Status.Platform.Azure.PrivateLink.PrivateEndpoint = ptr.Deref(endpoint.ID, "")
+		if err := updatePrivateLinkStatus(a.client, cd); err != nil {
+			return false, nil, errors.Wrap(err, "error updating clusterdeployment status with PrivateEndpoint")
+		}
+		modified = true
+	}
+
+	return modified, endpoint, nil
+}
+
+// createPrivateEndpoint creates the private endpoint.
+func (a *AzureLinkActuator) createPrivateEndpoint(cd *hivev1.ClusterDeployment, resourceGroup string, endpointName string, subnet *armnetwork.Subnet, service *armnetwork.PrivateLinkService) (*armnetwork.PrivateEndpoint, error) {
+	endpoint, err := a.azureClientHub.CreateOrUpdatePrivateEndpoint(context.TODO(), resourceGroup, endpointName, armnetwork.PrivateEndpoint{
+		Location: ptr.To(cd.Spec.Platform.Azure.Region),
+		Properties: &armnetwork.PrivateEndpointProperties{
+			Subnet: &armnetwork.Subnet{ID: subnet.ID},
+			PrivateLinkServiceConnections: []*armnetwork.PrivateLinkServiceConnection{{
+				Name: service.Name,
+				Properties: &armnetwork.PrivateLinkServiceConnectionProperties{
+					PrivateLinkServiceID: service.ID,
+				},
+			}},
+		},
+	})
+	if err != nil || endpoint == nil {
+		return nil, errors.Wrap(err, "error creating the Private Endpoint")
+	}
+
+	return endpoint, nil
+}
+
+// cleanupPrivateEndpoint deletes the private endpoint.
+func (a *AzureLinkActuator) cleanupPrivateEndpoint(cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, logger log.FieldLogger) error {
+	logger.Debug("cleaning up Private Endpoint")
+
+	// The endpoint may have been created in the resource group of any VNet in the inventory when
+	// its ID has not been recorded.
+	resourceGroups := sets.New[string]()
+	if existing := getPrivateLinkStatus(cd).PrivateEndpoint; existing != "" {
+		id, err := arm.ParseResourceID(existing)
+		if err != nil {
+			return errors.Wrap(err, "error parsing the Private Endpoint ID")
+		}
+		resourceGroups.Insert(id.ResourceGroupName)
+	} else {
+		for _, inventory := range a.config.EndpointVNetInventory {
+			resourceGroups.Insert(inventory.VNet.ResourceGroup)
+		}
+	}
+
+	for _, resourceGroup := range sets.List(resourceGroups) {
+		err := a.azureClientHub.DeletePrivateEndpoint(context.TODO(), resourceGroup, metadata.InfraID+"-pe")
+		if err != nil && !azureclient.IsNotFound(err) {
+			return errors.Wrap(err, "error deleting the Private Endpoint")
+		}
+	}
+
+	initPrivateLinkStatus(cd)
+	if cd.Status.Platform.Azure.PrivateLink.PrivateEndpoint != "" {
+		cd.Status.Platform.Azure.PrivateLink.PrivateEndpoint = ""
+		if err := updatePrivateLinkStatus(a.client, cd); err != nil {
+			return errors.Wrap(err, "error updating clusterdeployment after cleanup of PrivateEndpoint")
+		}
+	}
+
+	return nil
+}
+
+// privateEndpointApproved returns true when the connection of the private endpoint to the private
+// link service has been approved.
+func privateEndpointApproved(endpoint *armnetwork.PrivateEndpoint) bool {
+	if endpoint.Properties == nil {
+		return false
+	}
+	for _, connection := range endpoint.Properties.PrivateLinkServiceConnections {
+		if connection.Properties != nil &&
+			connection.Properties.PrivateLinkServiceConnectionState != nil &&
+			ptr.Deref(connection.Properties.PrivateLinkServiceConnectionState.Status, "") == connectionStatusApproved {
+			return true
+		}
+	}
+	return false
+}
+
+// privateEndpointIPAddress returns the private IP address of the network interface of the private endpoint.
+func (a *AzureLinkActuator) privateEndpointIPAddress(endpoint *armnetwork.PrivateEndpoint) (string, error) {
+	if endpoint.Properties == nil || len(endpoint.Properties.NetworkInterfaces) == 0 {
+		return "", errors.New("the Private Endpoint has no network interfaces")
+	}
+	id, err := arm.ParseResourceID(ptr.Deref(endpoint.Properties.NetworkInterfaces[0].ID, ""))
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing the Private Endpoint network interface ID")
+	}
+	nic, err := a.azureClientHub.GetNetworkInterface(context.TODO(), id.ResourceGroupName, id.Name)
+	if err != nil {
+		return "", err
+	}
+	if nic.Properties != nil {
+		for _, ipConfiguration := range nic.Properties.IPConfigurations {
+			if ipConfiguration.Properties != nil && ptr.Deref(ipConfiguration.Properties.PrivateIPAddress, "") != "" {
+				return ptr.Deref(ipConfiguration.Properties.PrivateIPAddress, ""), nil
+			}
+		}
+	}
+	return "", errors.New("the Private Endpoint network interface has no private IP address")
+}
+
+// chooseSubnetForEndpoint returns the subnet, and the resource group of its VNet, that should be used
+// for the private endpoint of a cluster in the given region.
+func chooseSubnetForEndpoint(azureClient azureclient.Client, config hivev1.AzurePrivateLinkConfig, region string) (*armnetwork.Subnet, string, error) {
+	type candidate struct {
+		subnet        *armnetwork.Subnet
+		resourceGroup string
+	}
+
+	// Filter out the subnets not in cluster region.
+	var candidates []candidate
+	for _, inventory := range config.EndpointVNetInventory {
+		for _, cand := range inventory.Subnets {
+			if !strings.EqualFold(cand.Region, region) {
+				continue
+			}
+			subnet, err := azureClient.GetSubnet(context.TODO(), inventory.VNet.ResourceGroup, inventory.VNet.Name, cand.Subnet)
+			if azureclient.IsNotFound(err) {
+				// Ignoring subnets that are not found
+				continue
+			} else if err != nil {
+				return nil, "", err
+			}
+			candidates = append(candidates, candidate{subnet: subnet, resourceGroup: inventory.VNet.ResourceGroup})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, "", fmt.Errorf("no supported subnet in inventory for region %s", region)
+	}
+
+	// Determine how many endpoints are in each subnet
+	endpointsPerSubnet := map[string]int{}
+	listed := sets.New[string]()
+	for _, cand := range candidates {
+		if listed.Has(cand.resourceGroup) {
+			continue
+		}
+		listed.Insert(cand.resourceGroup)
+		endpoints, err := azureClient.ListPrivateEndpoints(context.TODO(), cand.resourceGroup)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, endpoint := range endpoints {
+			if endpoint.Properties != nil && endpoint.Properties.Subnet != nil {
+				endpointsPerSubnet[strings.ToLower(ptr.Deref(endpoint.Properties.Subnet.ID, ""))]++
+			}
+		}
+	}
+
+	// "Spread" strategy: sort the candidates by the number of endpoints already in the subnet, ascending,
+	// and return the first (emptiest) one.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return endpointsPerSubnet[strings.ToLower(ptr.Deref(candidates[i].subnet.ID, ""))] <
+			endpointsPerSubnet[strings.ToLower(ptr.Deref(candidates[j].subnet.ID, ""))]
+	})
+
+	return candidates[0].subnet, candidates[0].resourceGroup, nil
+}
@@ -0,0 +1,538 @@
+package azureactuator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	mockclient "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/controller/privatelink/actuator"
+	testassert "github.com/openshift/hive/pkg/test/assert"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	"github.com/openshift/hive/pkg/test/generic"
+	testlogger "github.com/openshift/hive/pkg/test/logger"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+const (
+	testNameSpace         = "testnamespace"
+	testClusterDeployment = "testclusterdeployment"
+	testInfraID           = "testinfraid"
+	testRegion            = "eastus"
+	testHubSubscription   = "00000000-0000-0000-0000-000000000001"
+	testSpokeSubscription = "00000000-0000-0000-0000-000000000002"
+	testHubResourceGroup  = "hub-rg"
+	testHubVNet           = "hub-vnet"
+	testHubSubnet         = "hub-subnet"
+	testEndpointIPAddress = "10.0.0.4"
+)
+
+var (
+	mockHubSubnetID  = "/subscriptions/" + testHubSubscription + "/resourceGroups/" + testHubResourceGroup + "/providers/Microsoft.Network/virtualNetworks/" + testHubVNet + "/subnets/" + testHubSubnet
+	mockHubVNetID    = "/subscriptions/" + testHubSubscription + "/resourceGroups/" + testHubResourceGroup + "/providers/Microsoft.Network/virtualNetworks/" + testHubVNet
+	mockEndpointID   = "/subscriptions/" + testHubSubscription + "/resourceGroups/" + testHubResourceGroup + "/providers/Microsoft.Network/privateEndpoints/" + testInfraID + "-pe"
+	mockInterfaceID  = "/subscriptions/" + testHubSubscription + "/resourceGroups/" + testHubResourceGroup + "/providers/Microsoft.Network/networkInterfaces/" + testInfraID + "-pe-nic"
+	mockServiceID    = "/subscriptions/" + testSpokeSubscription + "/resourceGroups/" + testInfraID + "-rg/providers/Microsoft.Network/privateLinkServices/" + testInfraID + "-pls"
+	mockFrontendID   = "/subscriptions/" + testSpokeSubscription + "/resourceGroups/" + testInfraID + "-rg/providers/Microsoft.Network/loadBalancers/" + testInfraID + "-internal/frontendIPConfigurations/internal-lb-ip-v4"
+	mockSpokeSubnet  = "/subscriptions/" + testSpokeSubscription + "/resourceGroups/" + testInfraID + "-rg/providers/Microsoft.Network/virtualNetworks/" + testInfraID + "-vnet/subnets/" + testInfraID + "-master-subnet"
+	mockPrivateZone  = "/subscriptions/" + testHubSubscription + "/resourceGroups/" + testHubResourceGroup + "/providers/Microsoft.Network/privateDnsZones/api.test-cluster.example.com"
+	mockNotFoundErr  = &azcore.ResponseError{StatusCode: http.StatusNotFound, ErrorCode: "NotFound"}
+	mockForbiddenErr = errors.New("not authorized")
+
+	mockConfig = &hivev1.AzurePrivateLinkConfig{
+		EndpointVNetInventory: []hivev1.AzurePrivateLinkInventory{{
+			VNet: hivev1.AzurePrivateLinkVNet{
+				ResourceGroup: testHubResourceGroup,
+				Name:          testHubVNet,
+			},
+			Subnets: []hivev1.AzurePrivateLinkSubnet{{
+				Subnet: testHubSubnet,
+				Region: testRegion,
+			}},
+		}},
+	}
+
+	mockHubSubnet = &armnetwork.Subnet{ID: ptr.To(mockHubSubnetID)}
+
+	mockLoadBalancer = &armnetwork.LoadBalancer{
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{{
+				ID:   ptr.To(mockFrontendID),
+				Name: ptr.To(apiFrontendIPConfigurationName),
+			}},
+		},
+	}
+
+	mockService = &armnetwork.PrivateLinkService{
+		ID:   ptr.To(mockServiceID),
+		Name: ptr.To(testInfraID + "-pls"),
+	}
+)
+
+func mockEndpoint(status string) *armnetwork.PrivateEndpoint {
+	return &armnetwork.PrivateEndpoint{
+		ID: ptr.To(mockEndpointID),
+		Properties: &armnetwork.PrivateEndpointProperties{
+			Subnet:            &armnetwork.Subnet{ID: ptr.To(mockHubSubnetID)},
+			NetworkInterfaces: []*armnetwork.Interface{{ID: ptr.To(mockInterfaceID)}},
+			PrivateLinkServiceConnections: []*armnetwork.PrivateLinkServiceConnection{{
+				Properties: &armnetwork.PrivateLinkServiceConnectionProperties{
+					PrivateLinkServiceID: ptr.To(mockServiceID),
+					PrivateLinkServiceConnectionState: &armnetwork.PrivateLinkServiceConnectionState{
+						Status: ptr.To(status),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func newTestAzureLinkActuator(t *testing.T, config *hivev1.AzurePrivateLinkConfig, cd *hivev1.ClusterDeployment, existing []runtime.Object, configureAzureClient func(*mockclient.MockClient)) (*AzureLinkActuator, error) {
+	fakeClient := client.Client(testfake.NewFakeClientBuilder().
+		WithRuntimeObjects(cd).
+		WithRuntimeObjects(existing...).
+		Build())
+
+	mockedAzureClient := mockclient.NewMockClient(gomock.NewController(t))
+	if configureAzureClient != nil {
+		configureAzureClient(mockedAzureClient)
+	}
+
+	return &AzureLinkActuator{
+		client:           &fakeClient,
+		config:           config,
+		azureClientHub:   mockedAzureClient,
+		azureClientSpoke: mockedAzureClient,
+	}, nil
+}
+
+func Test_Cleanup(t *testing.T) {
+	cdBuilder := testcd.FullBuilder(testNameSpace, testClusterDeployment, scheme.GetScheme())
+
+	cases := []struct {
+		name   string
+		cd     *hivev1.ClusterDeployment
+		config *hivev1.AzurePrivateLinkConfig
+
+		azureClientConfig func(*mockclient.MockClient)
+
+		expectError  string
+		expectStatus *hivev1azure.PrivateLinkStatus
+	}{{ // There should be an error on failure to delete the private endpoint
+		name: "DeletePrivateEndpoint failure",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+			}}),
+		),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().DeletePrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(mockForbiddenErr)
+		},
+		expectError: "error cleaning up private endpoint: error deleting the Private Endpoint: not authorized",
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateEndpoint: mockEndpointID,
+		},
+	}, { // Resources that are already gone should be ignored
+		name: "resources not found",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint:    mockEndpointID,
+				PrivateLinkService: mockServiceID,
+			}}),
+		),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().DeletePrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(mockNotFoundErr)
+			m.EXPECT().DeletePrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls").Return(mockNotFoundErr)
+		},
+		expectStatus: &hivev1azure.PrivateLinkStatus{},
+	}, { // The inventory resource groups should be searched when the endpoint was never recorded
+		name: "endpoint not recorded",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateLinkService: mockServiceID,
+			}}),
+		),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().DeletePrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(nil)
+			m.EXPECT().DeletePrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls").Return(nil)
+		},
+		expectStatus: &hivev1azure.PrivateLinkStatus{},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := testlogger.NewLoggerWithHook()
+			azureLinkActuator, err := newTestAzureLinkActuator(t, test.config, test.cd, []runtime.Object{}, test.azureClientConfig)
+			require.NoError(t, err)
+
+			err = azureLinkActuator.Cleanup(test.cd, &hivev1.ClusterMetadata{InfraID: testInfraID}, logger)
+			if test.expectError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectError)
+			}
+			assert.Equal(t, test.expectStatus, test.cd.Status.Platform.Azure.PrivateLink)
+		})
+	}
+}
+
+func Test_CleanupRequired(t *testing.T) {
+	cdBuilder := testcd.FullBuilder(testNameSpace, testClusterDeployment, scheme.GetScheme())
+
+	cases := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		privateLinkEnabled bool
+
+		expect bool
+	}{{ // There is nothing to cleanup when status.platform is nil
+		name: "status.platform is nil",
+		cd:   cdBuilder.Build(),
+	}, { // There is nothing to cleanup when status.platform.azure.privateLink is nil
+		name: "status.platform.azure.privateLink is nil",
+		cd:   cdBuilder.Build(testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{})),
+	}, { // There is nothing to cleanup when deleting with PreserveOnDelete while private link is enabled
+		name: "PreserveOnDelete",
+		cd: cdBuilder.GenericOptions(generic.Deleted()).Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+			}}),
+			testcd.PreserveOnDelete(),
+		),
+		privateLinkEnabled: true,
+	}, { // Cleanup is required when the private endpoint is recorded
+		name: "private endpoint",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateEndpoint: mockEndpointID,
+			}}),
+		),
+		expect: true,
+	}, { // Cleanup is required when the private link service is recorded
+		name: "private link service",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateLinkService: mockServiceID,
+			}}),
+		),
+		expect: true,
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			azureLinkActuator := &AzureLinkActuator{privateLinkEnabled: test.privateLinkEnabled}
+			assert.Equal(t, test.expect, azureLinkActuator.CleanupRequired(test.cd))
+		})
+	}
+}
+
+func Test_Reconcile(t *testing.T) {
+	cdBuilder := testcd.FullBuilder(testNameSpace, testClusterDeployment, scheme.GetScheme()).Options(
+		testcd.WithAzurePlatform(&hivev1azure.Platform{Region: testRegion}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: testInfraID}),
+	)
+
+	cases := []struct {
+		name   string
+		cd     *hivev1.ClusterDeployment
+		config *hivev1.AzurePrivateLinkConfig
+
+		azureClientConfig func(*mockclient.MockClient)
+
+		expect           reconcile.Result
+		expectConditions []hivev1.ClusterDeploymentCondition
+		expectError      string
+		expectRecord     *actuator.DnsRecord
+		expectStatus     *hivev1azure.PrivateLinkStatus
+	}{{ // There should be an error and failed condition when there are no subnets in the cluster region for the endpoint
+		name: "no subnet in region",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatform(&hivev1azure.Platform{Region: "westus"}),
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateLinkService: mockServiceID,
+			}}),
+		),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), testInfraID+"-rg", testInfraID+"-internal").Return(mockLoadBalancer, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls").Return(mockService, nil)
+		},
+		expectConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.PrivateLinkFailedClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PrivateEndpointReconcileFailed",
+			Message: "error choosing a Subnet for the Private Endpoint: no supported subnet in inventory for region westus",
+		}},
+		expectError: "failed to reconcile the Private Endpoint: error choosing a Subnet for the Private Endpoint: " +
+			"no supported subnet in inventory for region westus",
+	}, { // Should return requeue later when the api load balancer is not found
+		name:   "load balancer not found",
+		cd:     cdBuilder.Build(),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), testInfraID+"-rg", testInfraID+"-internal").Return(nil, mockNotFoundErr)
+		},
+		expect: requeueLater,
+	}, { // There should be an error and failed condition on failure to create the private link service
+		name:   "CreateOrUpdatePrivateLinkService failure",
+		cd:     cdBuilder.Build(),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), testInfraID+"-rg", testInfraID+"-internal").Return(mockLoadBalancer, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls").Return(nil, mockNotFoundErr)
+			m.EXPECT().GetSubnet(gomock.Any(), testInfraID+"-rg", testInfraID+"-vnet", testInfraID+"-master-subnet").Return(&armnetwork.Subnet{
+				ID: ptr.To(mockSpokeSubnet),
+				Properties: &armnetwork.SubnetPropertiesFormat{
+					PrivateLinkServiceNetworkPolicies: ptr.To(armnetwork.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled),
+				},
+			}, nil)
+			m.EXPECT().SubscriptionID().Return(testHubSubscription)
+			m.EXPECT().CreateOrUpdatePrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls", gomock.Any()).Return(nil, mockForbiddenErr)
+		},
+		expectConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:    hivev1.PrivateLinkFailedClusterDeploymentCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PrivateLinkServiceReconcileFailed",
+			Message: "error creating the Private Link Service: not authorized",
+		}},
+		expectError: "failed to reconcile the Private Link Service: error creating the Private Link Service: not authorized",
+	}, { // Should create the private link service and endpoint, and requeue until the connection is approved
+		name:   "endpoint pending approval",
+		cd:     cdBuilder.Build(),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetSubnet(gomock.Any(), testHubResourceGroup, testHubVNet, testHubSubnet).Return(mockHubSubnet, nil)
+			m.EXPECT().ListPrivateEndpoints(gomock.Any(), testHubResourceGroup).Return(nil, nil)
+			m.EXPECT().GetLoadBalancer(gomock.Any(), testInfraID+"-rg", testInfraID+"-internal").Return(mockLoadBalancer, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls").Return(nil, mockNotFoundErr)
+			m.EXPECT().GetSubnet(gomock.Any(), testInfraID+"-rg", testInfraID+"-vnet", testInfraID+"-master-subnet").Return(&armnetwork.Subnet{
+				ID: ptr.To(mockSpokeSubnet),
+			}, nil)
+			m.EXPECT().CreateOrUpdateSubnet(gomock.Any(), testInfraID+"-rg", testInfraID+"-vnet", testInfraID+"-master-subnet", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _, _ string, subnet armnetwork.Subnet) (*armnetwork.Subnet, error) {
+					assert.Equal(t, armnetwork.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled, *subnet.Properties.PrivateLinkServiceNetworkPolicies)
+					return &subnet, nil
+				})
+			m.EXPECT().SubscriptionID().Return(testHubSubscription)
+			m.EXPECT().CreateOrUpdatePrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, service armnetwork.PrivateLinkService) (*armnetwork.PrivateLinkService, error) {
+					assert.Equal(t, mockFrontendID, *service.Properties.LoadBalancerFrontendIPConfigurations[0].ID)
+					assert.Equal(t, testHubSubscription, *service.Properties.AutoApproval.Subscriptions[0])
+					return mockService, nil
+				})
+			m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(nil, mockNotFoundErr)
+			m.EXPECT().CreateOrUpdatePrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe", gomock.Any()).Return(mockEndpoint("Pending"), nil)
+		},
+		expect: requeueLater,
+		expectConditions: []hivev1.ClusterDeploymentCondition{{
+			Type:   hivev1.PrivateLinkReadyClusterDeploymentCondition,
+			Status: corev1.ConditionFalse,
+			Reason: "ReconciledPrivateEndpoint",
+		}},
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateLinkService: mockServiceID,
+			PrivateEndpoint:    mockEndpointID,
+		},
+	}, { // Should return the endpoint IP address once the connection is approved
+		name: "endpoint approved",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateLinkService: mockServiceID,
+				PrivateEndpoint:    mockEndpointID,
+			}}),
+		),
+		config: mockConfig,
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), testInfraID+"-rg", testInfraID+"-internal").Return(mockLoadBalancer, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls").Return(mockService, nil)
+			m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(mockEndpoint(connectionStatusApproved), nil)
+			m.EXPECT().GetNetworkInterface(gomock.Any(), testHubResourceGroup, testInfraID+"-pe-nic").Return(&armnetwork.Interface{
+				Properties: &armnetwork.InterfacePropertiesFormat{
+					IPConfigurations: []*armnetwork.InterfaceIPConfiguration{{
+						Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+							PrivateIPAddress: ptr.To(testEndpointIPAddress),
+						},
+					}},
+				},
+			}, nil)
+		},
+		expectRecord: &actuator.DnsRecord{IpAddress: []string{testEndpointIPAddress}},
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateLinkService: mockServiceID,
+			PrivateEndpoint:    mockEndpointID,
+		},
+	}, { // Should keep using the existing endpoint when its subnet is no longer in the inventory
+		name: "inventory changed after endpoint created",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateLinkService: mockServiceID,
+				PrivateEndpoint:    mockEndpointID,
+			}}),
+		),
+		config: &hivev1.AzurePrivateLinkConfig{
+			EndpointVNetInventory: []hivev1.AzurePrivateLinkInventory{{
+				VNet: hivev1.AzurePrivateLinkVNet{
+					ResourceGroup: "other-rg",
+					Name:          "other-vnet",
+				},
+				Subnets: []hivev1.AzurePrivateLinkSubnet{{
+					Subnet: "other-subnet",
+					Region: testRegion,
+				}},
+			}},
+		},
+		azureClientConfig: func(m *mockclient.MockClient) {
+			m.EXPECT().GetLoadBalancer(gomock.Any(), testInfraID+"-rg", testInfraID+"-internal").Return(mockLoadBalancer, nil)
+			m.EXPECT().GetPrivateLinkService(gomock.Any(), testInfraID+"-rg", testInfraID+"-pls").Return(mockService, nil)
+			m.EXPECT().GetPrivateEndpoint(gomock.Any(), testHubResourceGroup, testInfraID+"-pe").Return(mockEndpoint(connectionStatusApproved), nil)
+			m.EXPECT().GetNetworkInterface(gomock.Any(), testHubResourceGroup, testInfraID+"-pe-nic").Return(&armnetwork.Interface{
+				Properties: &armnetwork.InterfacePropertiesFormat{
+					IPConfigurations: []*armnetwork.InterfaceIPConfiguration{{
+						Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+							PrivateIPAddress: ptr.To(testEndpointIPAddress),
+						},
+					}},
+				},
+			}, nil)
+		},
+		expectRecord: &actuator.DnsRecord{IpAddress: []string{testEndpointIPAddress}},
+		expectStatus: &hivev1azure.PrivateLinkStatus{
+			PrivateLinkService: mockServiceID,
+			PrivateEndpoint:    mockEndpointID,
+		},
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			logger, _ := testlogger.NewLoggerWithHook()
+			azureLinkActuator, err := newTestAzureLinkActuator(t, test.config, test.cd, []runtime.Object{}, test.azureClientConfig)
+			require.NoError(t, err)
+
+			dnsRecord := &actuator.DnsRecord{}
+			result, err := azureLinkActuator.Reconcile(test.cd, test.cd.Spec.ClusterMetadata, dnsRecord, logger)
+
+			if test.expectError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectError)
+			}
+
+			assert.Equal(t, test.expect, result)
+
+			if test.expectRecord == nil {
+				test.expectRecord = &actuator.DnsRecord{}
+			}
+			assert.Equal(t, test.expectRecord, dnsRecord)
+
+			if test.expectStatus != nil {
+				assert.Equal(t, test.expectStatus, test.cd.Status.Platform.Azure.PrivateLink)
+			}
+
+			curr := &hivev1.ClusterDeployment{}
+			fakeClient := *azureLinkActuator.client
+			errGet := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: test.cd.Namespace, Name: test.cd.Name}, curr)
+			assert.NoError(t, errGet)
+			testassert.AssertConditions(t, curr, test.expectConditions)
+		})
+	}
+}
+
+func Test_ShouldSync(t *testing.T) {
+	azureLinkActuator := &AzureLinkActuator{}
+	cdBuilder := testcd.FullBuilder(testNameSpace, testClusterDeployment, scheme.GetScheme())
+
+	cases := []struct {
+		name string
+		cd   *hivev1.ClusterDeployment
+
+		expect bool
+	}{{ // Sync is required when status.platform is nil
+		name:   "status.platform is nil",
+		cd:     cdBuilder.Build(),
+		expect: true,
+	}, { // Sync is required when status.platform.azure.privateLink is nil
+		name:   "status.platform.azure.privateLink is nil",
+		cd:     cdBuilder.Build(testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{})),
+		expect: true,
+	}, { // Sync is required when the private endpoint is empty
+		name: "PrivateEndpoint is empty",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateLinkService: mockServiceID,
+			}}),
+		),
+		expect: true,
+	}, { // Sync is not required when all resources are recorded
+		name: "no changes required",
+		cd: cdBuilder.Build(
+			testcd.WithAzurePlatformStatus(&hivev1azure.PlatformStatus{PrivateLink: &hivev1azure.PrivateLinkStatus{
+				PrivateLinkService: mockServiceID,
+				PrivateEndpoint:    mockEndpointID,
+			}}),
+		),
+	}}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, azureLinkActuator.ShouldSync(test.cd))
+		})
+	}
+}
+
+func Test_chooseSubnetForEndpoint(t *testing.T) {
+	otherSubnetID := mockHubVNetID + "/subnets/other-subnet"
+	config := hivev1.AzurePrivateLinkConfig{
+		EndpointVNetInventory: []hivev1.AzurePrivateLinkInventory{{
+			VNet: hivev1.AzurePrivateLinkVNet{ResourceGroup: testHubResourceGroup, Name: testHubVNet},
+			Subnets: []hivev1.AzurePrivateLinkSubnet{
+				{Subnet: testHubSubnet, Region: testRegion},
+				{Subnet: "other-subnet", Region: testRegion},
+				{Subnet: "missing-subnet", Region: testRegion},
+				{Subnet: "west-subnet", Region: "westus"},
+			},
+		}},
+	}
+
+	mockedAzureClient := mockclient.NewMockClient(gomock.NewController(t))
+	mockedAzureClient.EXPECT().GetSubnet(gomock.Any(), testHubResourceGroup, testHubVNet, testHubSubnet).Return(mockHubSubnet, nil)
+	mockedAzureClient.EXPECT().GetSubnet(gomock.Any(), testHubResourceGroup, testHubVNet, "other-subnet").Return(&armnetwork.Subnet{ID: ptr.To(otherSubnetID)}, nil)
+	mockedAzureClient.EXPECT().GetSubnet(gomock.Any(), testHubResourceGroup, testHubVNet, "missing-subnet").Return(nil, mockNotFoundErr)
+	mockedAzureClient.EXPECT().ListPrivateEndpoints(gomock.Any(), testHubResourceGroup).Return([]*armnetwork.PrivateEndpoint{
+		mockEndpoint(connectionStatusApproved),
+	}, nil)
+
+	subnet, resourceGroup, err := chooseSubnetForEndpoint(mockedAzureClient, config, testRegion)
+	require.NoError(t, err)
+	// The subnet that already contains an endpoint should not be chosen.
+	assert.Equal(t, otherSubnetID, *subnet.ID)
+	assert.Equal(t, testHubResourceGroup, resourceGroup)
+}
+
+func Test_clusterResourceGroup(t *testing.T) {
+	assert.Equal(t, testInfraID+"-rg", clusterResourceGroup(&hivev1.ClusterMetadata{InfraID: testInfraID}))
+	assert.Equal(t, "custom-rg", clusterResourceGroup(&hivev1.ClusterMetadata{
+		InfraID: testInfraID,
+		Platform: &hivev1.ClusterPlatformMetadata{
+			Azure: &hivev1azure.Metadata{ResourceGroupName: ptr.To("custom-rg")},
+		},
+	}))
+}
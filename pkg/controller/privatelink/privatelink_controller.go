@@ -29,6 +29,7 @@ import (
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	"github.com/openshift/hive/pkg/controller/privatelink/actuator"
 	"github.com/openshift/hive/pkg/controller/privatelink/actuator/awsactuator"
+	"github.com/openshift/hive/pkg/controller/privatelink/actuator/azureactuator"
 	"github.com/openshift/hive/pkg/controller/privatelink/actuator/gcpactuator"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -130,6 +131,11 @@ func (r *PrivateLinkReconciler) Reconcile(ctx context.Context, request reconcile
 	var privateLinkEnabled bool
 	var spokePlatformType configv1.PlatformType
 	switch {
+	case cd.Spec.Platform.Azure != nil:
+		spokePlatformType = configv1.AzurePlatformType
+		if cd.Spec.Platform.Azure.PrivateLink != nil {
+			privateLinkEnabled = cd.Spec.Platform.Azure.PrivateLink.Enabled
+		}
 	case cd.Spec.Platform.GCP != nil:
 		spokePlatformType = configv1.GCPPlatformType
 		if cd.Spec.Platform.GCP.PrivateServiceConnect != nil {
@@ -166,8 +172,12 @@ func (r *PrivateLinkReconciler) Reconcile(ctx context.Context, request reconcile
 
 	// TODO: Determine and use hub platform type
 	// Ideally we would get the hub platform type from the cluster infrastructure. However,
-	// the hive service account does not have access. For now, we hard-code aws platform.
+	// the hive service account does not have access. For now, we hard-code aws platform, except
+	// for azure spokes whose private endpoint can only be resolved by an azure private dns zone.
 	var hubPlatformType = configv1.AWSPlatformType
+	if spokePlatformType == configv1.AzurePlatformType {
+		hubPlatformType = configv1.AzurePlatformType
+	}
 
 	privateLink.hubActuator, err = CreateActuator(r.Client, hubPlatformType, actuator.ActuatorTypeHub, nil, r.controllerconfig, privateLinkEnabled, logger)
 	if err != nil {
@@ -197,6 +207,17 @@ func CreateActuator(
 		if actuatorType == actuator.ActuatorTypeHub {
 			return awsactuator.NewAWSHubActuator(&client, awsConfig, privateLinkEnabled, nil, logger)
 		}
+	case configv1.AzurePlatformType:
+		var azureConfig *hivev1.AzurePrivateLinkConfig
+		if config != nil {
+			azureConfig = config.Azure
+		}
+		switch actuatorType {
+		case actuator.ActuatorTypeHub:
+			return azureactuator.NewAzureHubActuator(&client, azureConfig, privateLinkEnabled, nil, logger)
+		case actuator.ActuatorTypeLink:
+			return azureactuator.NewAzureLinkActuator(&client, azureConfig, cd, privateLinkEnabled, nil, logger)
+		}
 	case configv1.GCPPlatformType:
 		var gcpConfig *hivev1.GCPPrivateServiceConnectConfig
 		if config != nil {
@@ -56,12 +56,19 @@ const (
 	// requeueAfter2 is just a static 2 minute delay for when to requeue
 	// for the case when a necessary secret is missing
 	requeueAfter2 = time.Minute * 2
+
+	// certificateCheckInterval is how often the expiry of ingress certificates is checked
+	certificateCheckInterval = time.Hour
+
+	// servingCertificateComponent is the component label of the serving certificate metrics of ingress
+	servingCertificateComponent = "ingress"
 )
 
 // clusterDeploymentRemoteIngressConditions are the cluster deployment conditions controlled by
 // Remote Ingress controller
 var clusterDeploymentRemoteIngressConditions = []hivev1.ClusterDeploymentConditionType{
 	hivev1.IngressCertificateNotFoundCondition,
+	hivev1.IngressCertificateUnhealthyCondition,
 }
 
 // kubeCLIApplier knows how to ApplyRuntimeObject.
@@ -155,6 +162,7 @@ func (r *ReconcileRemoteClusterIngress) Reconcile(ctx context.Context, request r
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Object not found (must have been deleted), return
+			controllerutils.ReportServingCertificateMetrics(request.Namespace, request.Name, servingCertificateComponent, nil, time.Now())
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request
@@ -190,6 +198,7 @@ func (r *ReconcileRemoteClusterIngress) Reconcile(ctx context.Context, request r
 
 	// If the clusterdeployment is deleted, do not reconcile.
 	if cd.DeletionTimestamp != nil {
+		controllerutils.ReportServingCertificateMetrics(cd.Namespace, cd.Name, servingCertificateComponent, nil, time.Now())
 		return reconcile.Result{}, nil
 	}
 
@@ -227,14 +236,61 @@ func (r *ReconcileRemoteClusterIngress) Reconcile(ctx context.Context, request r
 
 	rContext.certBundleSecrets = certBundleSecrets
 
+	checks := checkIngressCertificates(rContext)
+	now := time.Now()
+	controllerutils.ReportServingCertificateMetrics(cd.Namespace, cd.Name, servingCertificateComponent, checks, now)
+	invalid, err := r.setIngressCertificateUnhealthyCondition(rContext, checks, now)
+	if err != nil {
+		rContext.logger.WithError(err).Error("error setting clusterDeployment condition")
+		return reconcile.Result{}, err
+	}
+	if invalid {
+		rContext.logger.Warn("not syncing clusterIngress as a certificate chain is invalid")
+		return reconcile.Result{RequeueAfter: requeueAfter2}, nil
+	}
+
 	if err := r.syncClusterIngress(rContext); err != nil {
 		cdLog.Errorf("error syncing clusterIngress syncset: %v", err)
 		return reconcile.Result{}, err
 	}
 
+	if len(checks) > 0 {
+		// requeue to keep the expiry of the certificates up to date
+		return reconcile.Result{RequeueAfter: certificateCheckInterval}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
+// checkIngressCertificates checks the secret of each certificate bundle used by an ingress against the wildcard
+// domains of the ingresses using it.
+func checkIngressCertificates(rContext *reconcileContext) []controllerutils.ServingCertificateCheck {
+	domains := map[string][]string{}
+	for _, ingress := range rContext.clusterDeployment.Spec.Ingress {
+		if ingress.ServingCertificate != "" {
+			domains[ingress.ServingCertificate] = append(domains[ingress.ServingCertificate], "*."+ingress.Domain)
+		}
+	}
+	names := sets.StringKeySet(domains).List()
+
+	checks := []controllerutils.ServingCertificateCheck{}
+	for _, name := range names {
+		for _, cb := range rContext.clusterDeployment.Spec.CertificateBundles {
+			if cb.Name != name {
+				continue
+			}
+			// getIngressSecrets has ensured that the secrets of all bundles in use were found
+			for _, secret := range rContext.certBundleSecrets {
+				if secret.Name == cb.CertificateSecretRef.Name {
+					checks = append(checks, controllerutils.CheckServingCertificate(name, secret, domains[name]))
+					break
+				}
+			}
+			break
+		}
+	}
+	return checks
+}
+
 // GenerateRemoteIngressSyncSetName generates the name of the SyncSet that holds the cluster ingress information to sync.
 func GenerateRemoteIngressSyncSetName(clusterDeploymentName string) string {
 	return apihelpers.GetResourceName(clusterDeploymentName, constants.ClusterIngressSuffix)
@@ -259,7 +315,7 @@ func rawExtensionsFromClusterDeployment(rContext *reconcileContext) ([]runtime.R
 	rawList := []runtime.RawExtension{}
 
 	for _, ingress := range rContext.clusterDeployment.Spec.Ingress {
-		ingressObj := createIngressController(rContext.clusterDeployment, ingress)
+		ingressObj := createIngressController(rContext.clusterDeployment, ingress, rContext.certBundleSecrets)
 
 		// scrub the status field
 		unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ingressObj)
@@ -354,7 +410,7 @@ func (r *ReconcileRemoteClusterIngress) syncSyncSet(rContext *reconcileContext,
 
 // createIngressController will return an ingressController based on a clusterDeployment's
 // spec.Ingress object
-func createIngressController(cd *hivev1.ClusterDeployment, ingress hivev1.ClusterIngress) *ingresscontroller.IngressController {
+func createIngressController(cd *hivev1.ClusterDeployment, ingress hivev1.ClusterIngress, certBundleSecrets []*corev1.Secret) *ingresscontroller.IngressController {
 	newIngress := ingresscontroller.IngressController{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "IngressController",
@@ -380,6 +436,16 @@ func createIngressController(cd *hivev1.ClusterDeployment, ingress hivev1.Cluste
 				newIngress.Spec.DefaultCertificate = &corev1.LocalObjectReference{
 					Name: remoteSecretNameForCertificateBundleSecret(cb.CertificateSecretRef.Name, cd),
 				}
+				// Changing the annotation with the secret contents makes the syncset apply the secret
+				// again, so that replaced and renewed certificates reach the cluster.
+				for _, secret := range certBundleSecrets {
+					if secret.Name == cb.CertificateSecretRef.Name {
+						newIngress.Annotations = map[string]string{
+							constants.ServingCertificateHashAnnotation: secretHash(secret),
+						}
+						break
+					}
+				}
 				break
 			}
 		}
@@ -473,6 +539,25 @@ func (r *ReconcileRemoteClusterIngress) setIngressCertificateNotFoundCondition(r
 	return nil
 }
 
+// setIngressCertificateUnhealthyCondition reports problems with the ingress certificates in the
+// IngressCertificateUnhealthy condition. Returns whether any certificate chain is invalid, and any error
+// encountered while setting the condition.
+func (r *ReconcileRemoteClusterIngress) setIngressCertificateUnhealthyCondition(rContext *reconcileContext, checks []controllerutils.ServingCertificateCheck, now time.Time) (bool, error) {
+	cd := rContext.clusterDeployment
+	status, reason, msg, invalid := controllerutils.ServingCertificateCondition(checks, now)
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(cd.Status.Conditions,
+		hivev1.IngressCertificateUnhealthyCondition, status, reason, msg, controllerutils.UpdateConditionIfReasonOrMessageChange)
+	if !changed {
+		return invalid, nil
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		rContext.logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating clusterDeployment condition")
+		return invalid, err
+	}
+	return invalid, nil
+}
+
 // remoteSecretNameForCertificateBundleSecret just stitches together a secret name consisting of
 // the original certificateBundle's secret name pre-pended with the clusterDeployment.Name
 func remoteSecretNameForCertificateBundleSecret(secretName string, cd *hivev1.ClusterDeployment) string {
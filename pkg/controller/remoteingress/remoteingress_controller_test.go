@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/hive/pkg/resource"
 	testassert "github.com/openshift/hive/pkg/test/assert"
 	testfake "github.com/openshift/hive/pkg/test/fake"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
 	"github.com/openshift/hive/pkg/util/scheme"
 )
 
@@ -78,6 +79,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 				return objects
 			}(),
@@ -98,6 +103,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 				return objects
 			}(),
@@ -121,6 +130,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 				ss := syncSetFromClusterDeployment(testClusterDeployment())
 				objects = append(objects, ss)
@@ -158,6 +171,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 
 				return objects
@@ -178,6 +195,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 				return objects
 			}(),
@@ -202,6 +223,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 				return objects
 			}(),
@@ -226,6 +251,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 
 				// put the expected secrets to satisfy the list of certificateBundles
@@ -263,6 +292,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 
 				// secrets for the clusterDeployment
@@ -306,6 +339,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 
 				// secrets for the clusterDeployment
@@ -339,6 +376,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 
 				// add the secrets for the certbundles
@@ -375,6 +416,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 				return objects
 			}(),
@@ -395,6 +440,10 @@ func TestRemoteClusterIngressReconcile(t *testing.T) {
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 					Status: corev1.ConditionUnknown,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				cd.Spec.Ingress[0].TuningOptions = &operatorv1.IngressControllerTuningOptions{
 					ReloadInterval: metav1.Duration{Duration: time.Minute},
 				}
@@ -450,6 +499,7 @@ func TestRemoteClusterIngressReconcileConditions(t *testing.T) {
 		name                      string
 		localObjects              []runtime.Object
 		expectedClusterConditions []hivev1.ClusterDeploymentCondition
+		expectNoSyncSet           bool
 	}{
 		{
 			name: "Test initialize conditions",
@@ -462,6 +512,11 @@ func TestRemoteClusterIngressReconcileConditions(t *testing.T) {
 					Status: corev1.ConditionUnknown,
 					Reason: hivev1.InitializedConditionReason,
 				},
+				{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+					Reason: hivev1.InitializedConditionReason,
+				},
 			},
 		}, {
 			name: "Test no issue no condition",
@@ -485,6 +540,10 @@ func TestRemoteClusterIngressReconcileConditions(t *testing.T) {
 					Status: corev1.ConditionUnknown,
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{}
 
 				return []runtime.Object{cd}
@@ -505,6 +564,10 @@ func TestRemoteClusterIngressReconcileConditions(t *testing.T) {
 					Status: corev1.ConditionUnknown,
 					Type:   hivev1.IngressCertificateNotFoundCondition,
 				})
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				return []runtime.Object{cd}
 			}(),
 			expectedClusterConditions: []hivev1.ClusterDeploymentCondition{
@@ -515,6 +578,53 @@ func TestRemoteClusterIngressReconcileConditions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Test invalid certificate chain",
+			localObjects: func() []runtime.Object {
+				cd := testClusterDeploymentWithManualCertificate()
+				cd.Status.Conditions = utils.SetClusterDeploymentCondition(cd.Status.Conditions,
+					hivev1.IngressCertificateNotFoundCondition, corev1.ConditionFalse, ingressCertificateFoundReason, "",
+					utils.UpdateConditionNever)
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
+				secret := testSecretForCertificateBundle(cd.Spec.CertificateBundles[0])
+				secret.Data[constants.TLSKeySecretKey] = []byte("SOME_FAKE_CERTIFICATE_KEY_DATA")
+				return []runtime.Object{cd, &secret}
+			}(),
+			expectedClusterConditions: []hivev1.ClusterDeploymentCondition{
+				{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionTrue,
+					Reason: utils.ServingCertificateChainInvalidReason,
+				},
+			},
+			expectNoSyncSet: true,
+		},
+		{
+			name: "Test certificate expiring soon",
+			localObjects: func() []runtime.Object {
+				cd := testClusterDeploymentWithManualCertificate()
+				cd.Status.Conditions = utils.SetClusterDeploymentCondition(cd.Status.Conditions,
+					hivev1.IngressCertificateNotFoundCondition, corev1.ConditionFalse, ingressCertificateFoundReason, "",
+					utils.UpdateConditionNever)
+				cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
+				secret := testSecretForCertificateBundle(cd.Spec.CertificateBundles[0])
+				testsecret.WithTLSCertificate(time.Now().Add(7*24*time.Hour), "*."+testIngressDomain)(&secret)
+				return []runtime.Object{cd, &secret}
+			}(),
+			expectedClusterConditions: []hivev1.ClusterDeploymentCondition{
+				{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionTrue,
+					Reason: utils.ServingCertificateExpiringSoonReason,
+				},
+			},
+		},
 		{
 			name: "Test clear previous condition",
 			localObjects: func() []runtime.Object {
@@ -523,7 +633,10 @@ func TestRemoteClusterIngressReconcileConditions(t *testing.T) {
 				conditions := utils.SetClusterDeploymentCondition(cd.Status.Conditions,
 					hivev1.IngressCertificateNotFoundCondition, corev1.ConditionTrue, ingressCertificateNotFoundReason, "TEST MISSING SECRET MESSAGE",
 					utils.UpdateConditionIfReasonOrMessageChange)
-				cd.Status.Conditions = conditions
+				cd.Status.Conditions = append(conditions, hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.IngressCertificateUnhealthyCondition,
+					Status: corev1.ConditionUnknown,
+				})
 				objects = append(objects, cd)
 
 				secret := testSecretForCertificateBundle(cd.Spec.CertificateBundles[0])
@@ -571,6 +684,9 @@ func TestRemoteClusterIngressReconcileConditions(t *testing.T) {
 				assert.NoError(t, fakeClient.Get(context.TODO(), searchKey, &cd), "error fetching resulting clusterDeployment")
 				testassert.AssertConditions(t, &cd, test.expectedClusterConditions)
 			}
+			if test.expectNoSyncSet {
+				assert.Nil(t, helper.createdSyncSet.syncset, "unexpected syncset apply")
+			}
 		})
 	}
 
@@ -707,11 +823,8 @@ func testSecretForCertificateBundle(cb hivev1.CertificateBundleSpec) corev1.Secr
 		TypeMeta: metav1.TypeMeta{
 			Kind: "Secret",
 		},
-		Data: map[string][]byte{
-			constants.TLSCrtSecretKey: []byte("SOME_FAKE_CERTIFICATE_DATA"),
-			constants.TLSKeySecretKey: []byte("SOME_FAKE_CERTIFICATE_KEY_DATA"),
-		},
 	}
+	testsecret.WithTLSCertificate(time.Now().Add(365*24*time.Hour), "*."+testIngressDomain, "*.moreingress.example.com")(&secret)
 	return secret
 }
 
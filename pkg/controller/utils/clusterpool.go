@@ -36,3 +36,13 @@ func MarkClusterForRemoval(cd *hivev1.ClusterDeployment) {
 	}
 	cd.Annotations[constants.RemovePoolClusterAnnotation] = "true"
 }
+
+// ClaimTenant returns the tenant to which the claim belongs according to the pool's ClaimFairShare
+// policy: the value of the claim's TenantLabelKey label. All claims belong to the same (unnamed)
+// tenant if the pool is nil or has no such policy.
+func ClaimTenant(pool *hivev1.ClusterPool, claim *hivev1.ClusterClaim) string {
+	if pool == nil || pool.Spec.ClaimFairShare == nil {
+		return ""
+	}
+	return claim.Labels[pool.Spec.ClaimFairShare.TenantLabelKey]
+}
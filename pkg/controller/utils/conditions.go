@@ -346,6 +346,144 @@ func SetClusterPoolConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// SetClusterQuotaConditionWithChangeCheck sets a condition on a ClusterQuota resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetClusterQuotaConditionWithChangeCheck(
+	conditions []hivev1.ClusterQuotaCondition,
+	conditionType hivev1.ClusterQuotaConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.ClusterQuotaCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.ClusterQuotaCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
+// SetClusterUpgradeConditionWithChangeCheck sets a condition on a ClusterUpgrade resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetClusterUpgradeConditionWithChangeCheck(
+	conditions []hivev1.ClusterUpgradeCondition,
+	conditionType hivev1.ClusterUpgradeConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.ClusterUpgradeCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.ClusterUpgradeCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
+// SetHubFederationConditionWithChangeCheck sets a condition on a HubFederation resource's status.
+// It returns the conditions as well a boolean indicating whether there was a change made
+// to the conditions.
+func SetHubFederationConditionWithChangeCheck(
+	conditions []hivev1.HubFederationCondition,
+	conditionType hivev1.HubFederationConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) ([]hivev1.HubFederationCondition, bool) {
+	changed := false
+	now := metav1.Now()
+	existingCondition := FindCondition(conditions, conditionType)
+	if existingCondition == nil {
+		conditions = append(
+			conditions,
+			hivev1.HubFederationCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+		changed = true
+	} else {
+		if shouldUpdateCondition(
+			existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+			status, reason, message,
+			updateConditionCheck,
+		) {
+			if existingCondition.Status != status {
+				existingCondition.LastTransitionTime = now
+			}
+			existingCondition.Status = status
+			existingCondition.Reason = reason
+			existingCondition.Message = message
+			existingCondition.LastProbeTime = now
+			changed = true
+		}
+	}
+	return conditions, changed
+}
+
 // SetClusterProvisionCondition sets a condition on a ClusterProvision resource's status
 func SetClusterProvisionCondition(
 	conditions []hivev1.ClusterProvisionCondition,
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// IsHubFederationCandidate returns true if the placement of the HubFederation may move the ClusterDeployment to a peer
+// hub: that is, the cluster is installed, does not belong to a ClusterPool, and matches the placement's selector.
+func IsHubFederationCandidate(fed *hivev1.HubFederation, cd *hivev1.ClusterDeployment) (bool, error) {
+	if fed.Spec.Placement == nil || !cd.Spec.Installed || cd.Spec.ClusterPoolRef != nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&fed.Spec.Placement.ClusterDeploymentSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(cd.Labels)), nil
+}
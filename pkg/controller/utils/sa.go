@@ -27,6 +27,11 @@ const (
 	UninstallServiceAccountName = "cluster-uninstaller"
 	uninstallRoleName           = "cluster-uninstaller"
 	uninstallRoleBindingName    = "cluster-uninstaller"
+
+	// RecycleServiceAccountName will be a service account that runs the cleanup of a cluster being returned to its
+	// pool. It has no permissions on the hub: the cleanup image is user-supplied, and only needs the cluster's admin
+	// kubeconfig, which is mounted in the job.
+	RecycleServiceAccountName = "cluster-recycler"
 )
 
 var (
@@ -69,6 +74,16 @@ var (
 			Resources: []string{"secrets", "configmaps"},
 			Verbs:     []string{"create", "delete", "get", "list", "update"},
 		},
+		{
+			APIGroups: []string{"hive.openshift.io"},
+			Resources: []string{"clusterdeprovisions"},
+			Verbs:     []string{"get"},
+		},
+		{
+			APIGroups: []string{"hive.openshift.io"},
+			Resources: []string{"clusterdeprovisions/status"},
+			Verbs:     []string{"update"},
+		},
 	}
 )
 
@@ -110,6 +125,16 @@ func SetupClusterUninstallServiceAccount(c client.Client, namespace string, logg
 	return nil
 }
 
+// SetupClusterRecycleServiceAccount ensures a service account exists which can run the cleanup of a cluster being
+// returned to its pool. The service account is not bound to any role.
+func SetupClusterRecycleServiceAccount(c client.Client, namespace string, logger log.FieldLogger) error {
+	// create new serviceaccount if it doesn't already exist
+	if err := setupServiceAccount(c, RecycleServiceAccountName, namespace, logger); err != nil {
+		return errors.Wrap(err, "failed to setup service account")
+	}
+	return nil
+}
+
 func setupRoleBinding(c client.Client, name, namespace string, role, serviceaccount string, logger log.FieldLogger) error {
 	switch err := c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, &rbacv1.RoleBinding{}); {
 	case apierrors.IsNotFound(err):
@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ServingCertificateExpiryWarning is how long before expiry a serving certificate is reported as expiring soon.
+	ServingCertificateExpiryWarning = 30 * 24 * time.Hour
+
+	ServingCertificatesValidReason       = "ServingCertificatesValid"
+	ServingCertificateChainInvalidReason = "ServingCertificateChainInvalid"
+	ServingCertificateExpiredReason      = "ServingCertificateExpired"
+	ServingCertificateNameMismatchReason = "ServingCertificateNameMismatch"
+	ServingCertificateExpiringSoonReason = "ServingCertificateExpiringSoon"
+	servingCertificatesValidMessage      = "Serving certificates are valid"
+)
+
+var (
+	servingCertificateLabels = []string{"cluster_deployment", "namespace", "certificate_bundle", "component"}
+
+	// metricServingCertificateExpiryDays tracks the days left until the serving certificates of clusters expire. It
+	// is negative once a certificate has expired.
+	metricServingCertificateExpiryDays = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployment_serving_certificate_expiry_days",
+		Help: "Days until the earliest expiring certificate in the chain of a serving certificate bundle expires.",
+	}, servingCertificateLabels)
+	// metricServingCertificateNameMismatch is 1 for serving certificates which do not cover all of the domains they
+	// are used for.
+	metricServingCertificateNameMismatch = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployment_serving_certificate_name_mismatch",
+		Help: "Whether a serving certificate bundle is missing subject alternative names for the domains it is used for.",
+	}, servingCertificateLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricServingCertificateExpiryDays)
+	metrics.Registry.MustRegister(metricServingCertificateNameMismatch)
+}
+
+// ServingCertificateCheck is the result of checking the secret of a certificate bundle used as a serving certificate.
+type ServingCertificateCheck struct {
+	// Bundle is the name of the certificate bundle.
+	Bundle string
+	// Err is set if the certificate chain or key in the secret is invalid, in which case the other fields are unset.
+	Err error
+	// NotAfter is the earliest expiry of the certificates in the chain.
+	NotAfter time.Time
+	// MissingNames are the names the certificate is used for but does not cover.
+	MissingNames []string
+}
+
+// CheckServingCertificate parses the certificate chain and key in the secret of a certificate bundle, and checks that
+// the chain is well formed and that the leaf certificate covers the given names. Names may be wildcards.
+func CheckServingCertificate(bundle string, secret *corev1.Secret, names []string) ServingCertificateCheck {
+	check := ServingCertificateCheck{Bundle: bundle}
+	chain, err := parseServingCertificateChain(secret)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	check.NotAfter = chain[0].NotAfter
+	for _, cert := range chain[1:] {
+		if cert.NotAfter.Before(check.NotAfter) {
+			check.NotAfter = cert.NotAfter
+		}
+	}
+	for _, name := range names {
+		if !certificateCoversName(chain[0], name) {
+			check.MissingNames = append(check.MissingNames, name)
+		}
+	}
+	return check
+}
+
+func parseServingCertificateChain(secret *corev1.Secret) ([]*x509.Certificate, error) {
+	certPEM := secret.Data[corev1.TLSCertKey]
+	keyPEM := secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, fmt.Errorf("secret %s must contain %s and %s", secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	// X509KeyPair checks that the key is valid and belongs to the leaf certificate.
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, errors.Wrapf(err, "secret %s does not contain a valid certificate and key", secret.Name)
+	}
+	var chain []*x509.Certificate
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse certificate %d in secret %s", len(chain), secret.Name)
+		}
+		chain = append(chain, cert)
+	}
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return nil, errors.Wrapf(err, "certificate %d in secret %s is not signed by the certificate following it", i, secret.Name)
+		}
+	}
+	return chain, nil
+}
+
+// certificateCoversName returns whether the subject alternative names of cert include name, either exactly or, for a
+// name which is not itself a wildcard, through a wildcard.
+func certificateCoversName(cert *x509.Certificate, name string) bool {
+	name = strings.ToLower(Undotted(name))
+	for _, san := range cert.DNSNames {
+		san = strings.ToLower(Undotted(san))
+		if san == name {
+			return true
+		}
+		if strings.HasPrefix(san, "*.") && !strings.HasPrefix(name, "*.") {
+			if i := strings.Index(name, "."); i > 0 && name[i+1:] == san[2:] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ServingCertificateCondition returns the status, reason and message of a condition reporting problems found by the
+// checks of serving certificates, and whether any certificate chain is invalid. Invalid chains take precedence over
+// expired certificates, which take precedence over name mismatches and then certificates expiring soon.
+func ServingCertificateCondition(checks []ServingCertificateCheck, now time.Time) (corev1.ConditionStatus, string, string, bool) {
+	var invalid, expired, mismatched, expiring []string
+	for _, check := range checks {
+		switch {
+		case check.Err != nil:
+			invalid = append(invalid, fmt.Sprintf("certificate bundle %s: %v", check.Bundle, check.Err))
+			continue
+		case !check.NotAfter.After(now):
+			expired = append(expired, fmt.Sprintf("certificate bundle %s expired at %s", check.Bundle, check.NotAfter.UTC().Format(time.RFC3339)))
+		case check.NotAfter.Sub(now) < ServingCertificateExpiryWarning:
+			expiring = append(expiring, fmt.Sprintf("certificate bundle %s expires at %s", check.Bundle, check.NotAfter.UTC().Format(time.RFC3339)))
+		}
+		if len(check.MissingNames) > 0 {
+			mismatched = append(mismatched, fmt.Sprintf("certificate bundle %s does not cover %s", check.Bundle, strings.Join(check.MissingNames, ", ")))
+		}
+	}
+	var reason string
+	switch {
+	case len(invalid) > 0:
+		reason = ServingCertificateChainInvalidReason
+	case len(expired) > 0:
+		reason = ServingCertificateExpiredReason
+	case len(mismatched) > 0:
+		reason = ServingCertificateNameMismatchReason
+	case len(expiring) > 0:
+		reason = ServingCertificateExpiringSoonReason
+	default:
+		return corev1.ConditionFalse, ServingCertificatesValidReason, servingCertificatesValidMessage, false
+	}
+	problems := append(append(append(invalid, expired...), mismatched...), expiring...)
+	return corev1.ConditionTrue, reason, strings.Join(problems, "; "), len(invalid) > 0
+}
+
+// ReportServingCertificateMetrics sets the serving certificate metrics of a component of a cluster from the given
+// checks, clearing those of certificate bundles which are no longer checked. Passing no checks clears all of the
+// metrics of the component.
+func ReportServingCertificateMetrics(namespace, clusterDeployment, component string, checks []ServingCertificateCheck, now time.Time) {
+	labels := prometheus.Labels{
+		"cluster_deployment": clusterDeployment,
+		"namespace":          namespace,
+		"component":          component,
+	}
+	metricServingCertificateExpiryDays.DeletePartialMatch(labels)
+	metricServingCertificateNameMismatch.DeletePartialMatch(labels)
+	for _, check := range checks {
+		if check.Err != nil {
+			continue
+		}
+		days := check.NotAfter.Sub(now).Hours() / 24
+		metricServingCertificateExpiryDays.WithLabelValues(clusterDeployment, namespace, check.Bundle, component).Set(math.Floor(days*100) / 100)
+		mismatch := 0.0
+		if len(check.MissingNames) > 0 {
+			mismatch = 1
+		}
+		metricServingCertificateNameMismatch.WithLabelValues(clusterDeployment, namespace, check.Bundle, component).Set(mismatch)
+	}
+}
@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+func TestCheckServingCertificate(t *testing.T) {
+	notAfter := time.Now().Add(90 * 24 * time.Hour).Truncate(time.Second)
+	valid := testsecret.Build(
+		testsecret.WithName("valid"),
+		testsecret.WithTLSCertificate(notAfter, "api.example.com", "*.apps.example.com"),
+	)
+	other := testsecret.Build(testsecret.WithTLSCertificate(notAfter, "api.example.com"))
+	mismatchedKey := valid.DeepCopy()
+	mismatchedKey.Data[corev1.TLSPrivateKeyKey] = other.Data[corev1.TLSPrivateKeyKey]
+	brokenChain := valid.DeepCopy()
+	brokenChain.Data[corev1.TLSCertKey] = append(brokenChain.Data[corev1.TLSCertKey], other.Data[corev1.TLSCertKey]...)
+
+	cases := []struct {
+		name           string
+		secret         *corev1.Secret
+		names          []string
+		expectErr      bool
+		expectMissing  []string
+		expectNotAfter time.Time
+	}{
+		{
+			name:           "valid",
+			secret:         valid,
+			names:          []string{"api.example.com", "*.apps.example.com", "console.apps.example.com"},
+			expectNotAfter: notAfter,
+		},
+		{
+			name:           "missing names",
+			secret:         valid,
+			names:          []string{"api.example.com", "*.example.com", "a.b.apps.example.com", "api.other.com"},
+			expectMissing:  []string{"*.example.com", "a.b.apps.example.com", "api.other.com"},
+			expectNotAfter: notAfter,
+		},
+		{
+			name:      "missing data",
+			secret:    testsecret.Build(testsecret.WithName("empty")),
+			expectErr: true,
+		},
+		{
+			name: "unparseable data",
+			secret: testsecret.Build(
+				testsecret.WithDataKeyValue(corev1.TLSCertKey, []byte("blah")),
+				testsecret.WithDataKeyValue(corev1.TLSPrivateKeyKey, []byte("blah")),
+			),
+			expectErr: true,
+		},
+		{
+			name:      "key does not match certificate",
+			secret:    mismatchedKey,
+			expectErr: true,
+		},
+		{
+			name:      "chain not signed in order",
+			secret:    brokenChain,
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			check := CheckServingCertificate("bundle", tc.secret, tc.names)
+			assert.Equal(t, "bundle", check.Bundle, "unexpected bundle")
+			if tc.expectErr {
+				assert.Error(t, check.Err, "expected error")
+				return
+			}
+			require.NoError(t, check.Err, "unexpected error")
+			assert.Equal(t, tc.expectMissing, check.MissingNames, "unexpected missing names")
+			assert.True(t, tc.expectNotAfter.Equal(check.NotAfter), "unexpected expiry %s", check.NotAfter)
+		})
+	}
+}
+
+func TestCheckServingCertificateChain(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(10 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		DNSNames:     []string{"api.example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(90 * 24 * time.Hour),
+	}, ca, key.Public(), caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	secret := testsecret.Build(
+		testsecret.WithDataKeyValue(corev1.TLSCertKey, append(
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}),
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})...)),
+		testsecret.WithDataKeyValue(corev1.TLSPrivateKeyKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	)
+	check := CheckServingCertificate("bundle", secret, []string{"api.example.com"})
+	require.NoError(t, check.Err, "unexpected error")
+	assert.Empty(t, check.MissingNames, "unexpected missing names")
+	assert.True(t, ca.NotAfter.Equal(check.NotAfter), "expected the expiry of the CA, got %s", check.NotAfter)
+}
+
+func TestServingCertificateCondition(t *testing.T) {
+	now := time.Now()
+	valid := ServingCertificateCheck{Bundle: "valid", NotAfter: now.Add(90 * 24 * time.Hour)}
+	invalid := ServingCertificateCheck{Bundle: "invalid", Err: assert.AnError}
+	expired := ServingCertificateCheck{Bundle: "expired", NotAfter: now.Add(-time.Hour)}
+	expiring := ServingCertificateCheck{Bundle: "expiring", NotAfter: now.Add(24 * time.Hour)}
+	mismatched := ServingCertificateCheck{Bundle: "mismatched", NotAfter: now.Add(90 * 24 * time.Hour), MissingNames: []string{"a.example.com"}}
+
+	cases := []struct {
+		name          string
+		checks        []ServingCertificateCheck
+		expectStatus  corev1.ConditionStatus
+		expectReason  string
+		expectInvalid bool
+	}{
+		{
+			name:         "no checks",
+			expectStatus: corev1.ConditionFalse,
+			expectReason: ServingCertificatesValidReason,
+		},
+		{
+			name:         "valid",
+			checks:       []ServingCertificateCheck{valid},
+			expectStatus: corev1.ConditionFalse,
+			expectReason: ServingCertificatesValidReason,
+		},
+		{
+			name:         "expiring",
+			checks:       []ServingCertificateCheck{valid, expiring},
+			expectStatus: corev1.ConditionTrue,
+			expectReason: ServingCertificateExpiringSoonReason,
+		},
+		{
+			name:         "mismatch over expiring",
+			checks:       []ServingCertificateCheck{expiring, mismatched},
+			expectStatus: corev1.ConditionTrue,
+			expectReason: ServingCertificateNameMismatchReason,
+		},
+		{
+			name:         "expired over mismatch",
+			checks:       []ServingCertificateCheck{mismatched, expired},
+			expectStatus: corev1.ConditionTrue,
+			expectReason: ServingCertificateExpiredReason,
+		},
+		{
+			name:          "invalid over everything",
+			checks:        []ServingCertificateCheck{valid, expired, mismatched, expiring, invalid},
+			expectStatus:  corev1.ConditionTrue,
+			expectReason:  ServingCertificateChainInvalidReason,
+			expectInvalid: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, reason, message, invalid := ServingCertificateCondition(tc.checks, now)
+			assert.Equal(t, tc.expectStatus, status, "unexpected status")
+			assert.Equal(t, tc.expectReason, reason, "unexpected reason")
+			assert.Equal(t, tc.expectInvalid, invalid, "unexpected invalid")
+			for _, check := range tc.checks {
+				if check.Bundle != valid.Bundle {
+					assert.Contains(t, message, check.Bundle, "expected message to mention bundle")
+				}
+			}
+		})
+	}
+}
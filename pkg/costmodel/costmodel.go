@@ -0,0 +1,311 @@
+package costmodel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the hive namespace holding the price tables. It has one data
+	// entry per platform (aws, azure, gcp, ibmcloud, openstack...) containing a PriceTable in YAML.
+	ConfigMapName = "hive-cost-model"
+
+	// ControlPlaneRole is the role of the control plane machines in an Estimate.
+	ControlPlaneRole = "master"
+
+	// defaultControlPlaneReplicas is the number of control plane machines when the install config does not say.
+	defaultControlPlaneReplicas = 3
+
+	installConfigSecretKey = "install-config.yaml"
+)
+
+// PriceTable is the price table for a platform.
+type PriceTable struct {
+	// InstanceTypes is the hourly price of each instance type.
+	InstanceTypes map[string]float64 `json:"instanceTypes"`
+	// ControlPlaneInstanceType is the instance type of the control plane machines when the install config does not
+	// set one.
+	ControlPlaneInstanceType string `json:"controlPlaneInstanceType,omitempty"`
+	// ComputeInstanceType is the instance type of compute machines when neither the MachinePool nor the install
+	// config sets one.
+	ComputeInstanceType string `json:"computeInstanceType,omitempty"`
+	// ClusterHourlyCost is a fixed hourly cost added for every running cluster, covering load balancers, storage,
+	// NAT gateways and so on.
+	ClusterHourlyCost float64 `json:"clusterHourlyCost,omitempty"`
+	// HibernatingClusterHourlyCost is the hourly cost of a hibernating cluster, whose machines are stopped.
+	HibernatingClusterHourlyCost float64 `json:"hibernatingClusterHourlyCost,omitempty"`
+}
+
+// Model holds the price tables of every platform.
+type Model struct {
+	// Platforms is the price table of each platform, keyed by the platform names in the constants package.
+	Platforms map[string]*PriceTable
+}
+
+// Machines is the estimated cost of a set of identical machines in a cluster.
+type Machines struct {
+	// Role is ControlPlaneRole for the control plane, or the name of the compute pool.
+	Role         string  `json:"role"`
+	InstanceType string  `json:"instanceType"`
+	Replicas     int64   `json:"replicas"`
+	HourlyCost   float64 `json:"hourlyCost"`
+}
+
+// Estimate is the estimated cost of a cluster.
+type Estimate struct {
+	Platform   string                   `json:"platform"`
+	PowerState hivev1.ClusterPowerState `json:"powerState,omitempty"`
+	// Machines are the machines of a running cluster.
+	Machines []Machines `json:"machines,omitempty"`
+	// HourlyCost is the total estimated hourly cost of the cluster.
+	HourlyCost float64 `json:"hourlyCost"`
+	// UnpricedInstanceTypes are the instance types missing from the price table, which are not part of the cost.
+	UnpricedInstanceTypes []string `json:"unpricedInstanceTypes,omitempty"`
+}
+
+// ClusterEstimate is the estimated cost of a ClusterDeployment.
+type ClusterEstimate struct {
+	Namespace   string                       `json:"namespace"`
+	Name        string                       `json:"name"`
+	ClusterType string                       `json:"clusterType,omitempty"`
+	PoolRef     *hivev1.ClusterPoolReference `json:"clusterPoolRef,omitempty"`
+	Estimate    *Estimate                    `json:"estimate"`
+}
+
+// Load reads the cost model from its ConfigMap in the given namespace, defaulting to the hive namespace. It returns
+// nil if there is no such ConfigMap.
+func Load(ctx context.Context, c client.Reader, namespace string) (*Model, error) {
+	if namespace == "" {
+		namespace = controllerutils.GetHiveNamespace()
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ConfigMapName}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error reading the %s configmap", ConfigMapName)
+	}
+	return Parse(cm.Data)
+}
+
+// Parse parses the data of the cost model ConfigMap.
+func Parse(data map[string]string) (*Model, error) {
+	model := &Model{Platforms: map[string]*PriceTable{}}
+	for platform, raw := range data {
+		if !slices.Contains(knownPlatforms, platform) {
+			return nil, fmt.Errorf("unsupported platform %q in the %s configmap", platform, ConfigMapName)
+		}
+		table := &PriceTable{}
+		if err := yaml.Unmarshal([]byte(raw), table); err != nil {
+			return nil, errors.Wrapf(err, "error parsing the %s price table", platform)
+		}
+		model.Platforms[platform] = table
+	}
+	return model, nil
+}
+
+// EstimateClusterDeployment estimates the hourly cost of an installed ClusterDeployment from its MachinePools, or the
+// compute pools of its install config if it has none, and the control plane of its install config. The install config
+// may be nil. It returns nil if the cluster is not installed or there is no price table for its platform.
+func (m *Model) EstimateClusterDeployment(cd *hivev1.ClusterDeployment, machinePools []hivev1.MachinePool, installConfig *installertypes.InstallConfig) *Estimate {
+	if !cd.Spec.Installed {
+		return nil
+	}
+	platform := controllerutils.GetClusterPlatform(cd)
+	table, ok := m.Platforms[platform]
+	if !ok {
+		return nil
+	}
+	estimate := &Estimate{Platform: platform, PowerState: cd.Status.PowerState}
+	if cd.Status.PowerState == hivev1.ClusterPowerStateHibernating {
+		estimate.HourlyCost = table.HibernatingClusterHourlyCost
+		return estimate
+	}
+
+	unpriced := map[string]bool{}
+	add := func(role, instanceType string, replicas int64) {
+		machines := Machines{Role: role, InstanceType: instanceType, Replicas: replicas}
+		if price, ok := table.InstanceTypes[instanceType]; ok {
+			machines.HourlyCost = price * float64(replicas)
+		} else if replicas > 0 {
+			unpriced[instanceType] = true
+		}
+		estimate.Machines = append(estimate.Machines, machines)
+		estimate.HourlyCost += machines.HourlyCost
+	}
+
+	controlPlaneReplicas := int64(defaultControlPlaneReplicas)
+	controlPlaneInstanceType := table.ControlPlaneInstanceType
+	if installConfig != nil && installConfig.ControlPlane != nil {
+		if installConfig.ControlPlane.Replicas != nil {
+			controlPlaneReplicas = *installConfig.ControlPlane.Replicas
+		}
+		if instanceType := installConfigInstanceType(installConfig.ControlPlane.Platform); instanceType != "" {
+			controlPlaneInstanceType = instanceType
+		}
+	}
+	add(ControlPlaneRole, controlPlaneInstanceType, controlPlaneReplicas)
+
+	if len(machinePools) > 0 {
+		for _, pool := range machinePools {
+			instanceType := machinePoolInstanceType(pool.Spec.Platform)
+			if instanceType == "" {
+				instanceType = table.ComputeInstanceType
+			}
+			add(pool.Spec.Name, instanceType, machinePoolReplicas(&pool))
+		}
+	} else if installConfig != nil {
+		for _, pool := range installConfig.Compute {
+			instanceType := installConfigInstanceType(pool.Platform)
+			if instanceType == "" {
+				instanceType = table.ComputeInstanceType
+			}
+			replicas := int64(constants.DefaultMachinePoolReplicas)
+			if pool.Replicas != nil {
+				replicas = *pool.Replicas
+			}
+			add(pool.Name, instanceType, replicas)
+		}
+	}
+
+	estimate.HourlyCost += table.ClusterHourlyCost
+	for instanceType := range unpriced {
+		estimate.UnpricedInstanceTypes = append(estimate.UnpricedInstanceTypes, instanceType)
+	}
+	sort.Strings(estimate.UnpricedInstanceTypes)
+	return estimate
+}
+
+// EstimateAll estimates the cost of every installed ClusterDeployment, optionally limited to a namespace, with a
+// price table for its platform. ClusterDeployments whose install config cannot be read are logged and skipped.
+func (m *Model) EstimateAll(ctx context.Context, c client.Reader, namespace string, logger log.FieldLogger) ([]ClusterEstimate, error) {
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(ctx, cds, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "error listing cluster deployments")
+	}
+	machinePools := &hivev1.MachinePoolList{}
+	if err := c.List(ctx, machinePools, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "error listing machine pools")
+	}
+	poolsByCluster := map[types.NamespacedName][]hivev1.MachinePool{}
+	for _, pool := range machinePools.Items {
+		key := types.NamespacedName{Namespace: pool.Namespace, Name: pool.Spec.ClusterDeploymentRef.Name}
+		poolsByCluster[key] = append(poolsByCluster[key], pool)
+	}
+
+	var estimates []ClusterEstimate
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		if _, ok := m.Platforms[controllerutils.GetClusterPlatform(cd)]; !ok || !cd.Spec.Installed {
+			continue
+		}
+		installConfig, err := readInstallConfig(ctx, c, cd)
+		if err != nil {
+			logger.WithError(err).WithField("clusterDeployment", cd.Namespace+"/"+cd.Name).Warn("skipping cost estimate")
+			continue
+		}
+		estimate := m.EstimateClusterDeployment(cd, poolsByCluster[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}], installConfig)
+		if estimate == nil {
+			continue
+		}
+		estimates = append(estimates, ClusterEstimate{
+			Namespace:   cd.Namespace,
+			Name:        cd.Name,
+			ClusterType: cd.Labels[hivev1.HiveClusterTypeLabel],
+			PoolRef:     cd.Spec.ClusterPoolRef,
+			Estimate:    estimate,
+		})
+	}
+	return estimates, nil
+}
+
+// readInstallConfig reads the install config of a ClusterDeployment, returning nil if it has none.
+func readInstallConfig(ctx context.Context, c client.Reader, cd *hivev1.ClusterDeployment) (*installertypes.InstallConfig, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.InstallConfigSecretRef == nil {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.Provisioning.InstallConfigSecretRef.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error reading the install config of %s/%s", cd.Namespace, cd.Name)
+	}
+	installConfig := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(secret.Data[installConfigSecretKey], installConfig); err != nil {
+		return nil, errors.Wrapf(err, "error parsing the install config of %s/%s", cd.Namespace, cd.Name)
+	}
+	return installConfig, nil
+}
+
+// machinePoolReplicas returns the number of machines of a MachinePool, using the current replicas of autoscaled pools,
+// and the installer's default for pools which set neither replicas nor autoscaling.
+func machinePoolReplicas(pool *hivev1.MachinePool) int64 {
+	switch {
+	case pool.Spec.Autoscaling != nil && pool.Status.Replicas > 0:
+		return int64(pool.Status.Replicas)
+	case pool.Spec.Autoscaling != nil:
+		return int64(pool.Spec.Autoscaling.MinReplicas)
+	case pool.Spec.Replicas != nil:
+		return *pool.Spec.Replicas
+	}
+	return constants.DefaultMachinePoolReplicas
+}
+
+func machinePoolInstanceType(platform hivev1.MachinePoolPlatform) string {
+	switch {
+	case platform.AWS != nil:
+		return platform.AWS.InstanceType
+	case platform.Azure != nil:
+		return platform.Azure.InstanceType
+	case platform.GCP != nil:
+		return platform.GCP.InstanceType
+	case platform.IBMCloud != nil:
+		return platform.IBMCloud.InstanceType
+	case platform.OpenStack != nil:
+		return platform.OpenStack.Flavor
+	}
+	return ""
+}
+
+func installConfigInstanceType(platform installertypes.MachinePoolPlatform) string {
+	switch {
+	case platform.AWS != nil:
+		return platform.AWS.InstanceType
+	case platform.Azure != nil:
+		return platform.Azure.InstanceType
+	case platform.GCP != nil:
+		return platform.GCP.InstanceType
+	case platform.IBMCloud != nil:
+		return platform.IBMCloud.InstanceType
+	case platform.OpenStack != nil:
+		return platform.OpenStack.FlavorName
+	}
+	return ""
+}
+
+// knownPlatforms are the platforms with instance types that the cost model can price.
+var knownPlatforms = []string{
+	constants.PlatformAWS,
+	constants.PlatformAzure,
+	constants.PlatformGCP,
+	constants.PlatformIBMCloud,
+	constants.PlatformOpenStack,
+}
@@ -0,0 +1,256 @@
+package costmodel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+	"github.com/openshift/hive/pkg/util/scheme"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesaws "github.com/openshift/installer/pkg/types/aws"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-cluster"
+)
+
+func testModel() *Model {
+	return &Model{Platforms: map[string]*PriceTable{
+		"aws": {
+			InstanceTypes: map[string]float64{
+				"m6a.xlarge":  0.5,
+				"m6a.2xlarge": 1,
+			},
+			ControlPlaneInstanceType:     "m6a.xlarge",
+			ComputeInstanceType:          "m6a.xlarge",
+			ClusterHourlyCost:            0.25,
+			HibernatingClusterHourlyCost: 0.1,
+		},
+	}}
+}
+
+func testClusterDeployment(opts ...testcd.Option) *hivev1.ClusterDeployment {
+	return testcd.FullBuilder(testNamespace, testName, scheme.GetScheme()).Build(
+		append([]testcd.Option{
+			testcd.Installed(),
+			testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+		}, opts...)...,
+	)
+}
+
+func testInstallConfig() *installertypes.InstallConfig {
+	return &installertypes.InstallConfig{
+		ControlPlane: &installertypes.MachinePool{
+			Name:     "master",
+			Replicas: ptr.To[int64](3),
+			Platform: installertypes.MachinePoolPlatform{
+				AWS: &installertypesaws.MachinePool{InstanceType: "m6a.2xlarge"},
+			},
+		},
+		Compute: []installertypes.MachinePool{{
+			Name:     "worker",
+			Replicas: ptr.To[int64](2),
+		}},
+	}
+}
+
+func TestEstimateClusterDeployment(t *testing.T) {
+	mpBuilder := testmp.FullBuilder(testNamespace, "worker", testName, scheme.GetScheme())
+	cases := []struct {
+		name             string
+		cd               *hivev1.ClusterDeployment
+		machinePools     []hivev1.MachinePool
+		installConfig    *installertypes.InstallConfig
+		expectNil        bool
+		expectedMachines []Machines
+		expectedCost     float64
+		expectedUnpriced []string
+	}{
+		{
+			name:      "not installed",
+			cd:        testcd.FullBuilder(testNamespace, testName, scheme.GetScheme()).Build(testcd.WithAWSPlatform(&hivev1aws.Platform{})),
+			expectNil: true,
+		},
+		{
+			name: "no price table for platform",
+			cd: testcd.FullBuilder(testNamespace, testName, scheme.GetScheme()).Build(
+				testcd.Installed(),
+				testcd.WithEmptyPlatformStatus(),
+			),
+			expectNil: true,
+		},
+		{
+			name:         "hibernating",
+			cd:           testClusterDeployment(testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating)),
+			expectedCost: 0.1,
+		},
+		{
+			name:          "compute from install config",
+			cd:            testClusterDeployment(),
+			installConfig: testInstallConfig(),
+			expectedMachines: []Machines{
+				{Role: ControlPlaneRole, InstanceType: "m6a.2xlarge", Replicas: 3, HourlyCost: 3},
+				{Role: "worker", InstanceType: "m6a.xlarge", Replicas: 2, HourlyCost: 1},
+			},
+			expectedCost: 4.25,
+		},
+		{
+			name: "compute from machine pools",
+			cd:   testClusterDeployment(),
+			machinePools: []hivev1.MachinePool{
+				*mpBuilder.Build(testmp.WithReplicas(4), testmp.WithAWSInstanceType("m6a.2xlarge")),
+				*mpBuilder.Build(testmp.WithAutoscaling(1, 5), testmp.WithAWSInstanceType("m6a.xlarge")),
+			},
+			installConfig: testInstallConfig(),
+			expectedMachines: []Machines{
+				{Role: ControlPlaneRole, InstanceType: "m6a.2xlarge", Replicas: 3, HourlyCost: 3},
+				{Role: "worker", InstanceType: "m6a.2xlarge", Replicas: 4, HourlyCost: 4},
+				{Role: "worker", InstanceType: "m6a.xlarge", Replicas: 1, HourlyCost: 0.5},
+			},
+			expectedCost: 7.75,
+		},
+		{
+			name: "no install config",
+			cd:   testClusterDeployment(),
+			expectedMachines: []Machines{
+				{Role: ControlPlaneRole, InstanceType: "m6a.xlarge", Replicas: 3, HourlyCost: 1.5},
+			},
+			expectedCost: 1.75,
+		},
+		{
+			name: "machine pool without replicas",
+			cd:   testClusterDeployment(),
+			machinePools: []hivev1.MachinePool{
+				*mpBuilder.Build(testmp.WithAWSInstanceType("m6a.xlarge")),
+			},
+			expectedMachines: []Machines{
+				{Role: ControlPlaneRole, InstanceType: "m6a.xlarge", Replicas: 3, HourlyCost: 1.5},
+				{Role: "worker", InstanceType: "m6a.xlarge", Replicas: 3, HourlyCost: 1.5},
+			},
+			expectedCost: 3.25,
+		},
+		{
+			name: "unpriced instance type",
+			cd:   testClusterDeployment(),
+			machinePools: []hivev1.MachinePool{
+				*mpBuilder.Build(testmp.WithReplicas(3), testmp.WithAWSInstanceType("r5.metal")),
+			},
+			expectedMachines: []Machines{
+				{Role: ControlPlaneRole, InstanceType: "m6a.xlarge", Replicas: 3, HourlyCost: 1.5},
+				{Role: "worker", InstanceType: "r5.metal", Replicas: 3},
+			},
+			expectedCost:     1.75,
+			expectedUnpriced: []string{"r5.metal"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			estimate := testModel().EstimateClusterDeployment(tc.cd, tc.machinePools, tc.installConfig)
+			if tc.expectNil {
+				assert.Nil(t, estimate, "expected no estimate")
+				return
+			}
+			require.NotNil(t, estimate, "expected an estimate")
+			assert.Equal(t, "aws", estimate.Platform, "unexpected platform")
+			assert.Equal(t, tc.expectedMachines, estimate.Machines, "unexpected machines")
+			assert.InDelta(t, tc.expectedCost, estimate.HourlyCost, 0.0001, "unexpected hourly cost")
+			assert.Equal(t, tc.expectedUnpriced, estimate.UnpricedInstanceTypes, "unexpected unpriced instance types")
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hive", Name: ConfigMapName},
+		Data: map[string]string{
+			"aws": "instanceTypes:\n  m6a.xlarge: 0.1728\ncontrolPlaneInstanceType: m6a.xlarge\nclusterHourlyCost: 0.05\n",
+		},
+	}
+	c := testfake.NewFakeClientBuilder().WithRuntimeObjects(cm).Build()
+
+	model, err := Load(context.TODO(), c, "hive")
+	require.NoError(t, err, "unexpected error loading cost model")
+	assert.Equal(t, &PriceTable{
+		InstanceTypes:            map[string]float64{"m6a.xlarge": 0.1728},
+		ControlPlaneInstanceType: "m6a.xlarge",
+		ClusterHourlyCost:        0.05,
+	}, model.Platforms["aws"], "unexpected price table")
+
+	model, err = Load(context.TODO(), c, "other")
+	require.NoError(t, err, "unexpected error for missing configmap")
+	assert.Nil(t, model, "expected no model without configmap")
+
+	_, err = Parse(map[string]string{"aws-typo": "instanceTypes: {}"})
+	assert.Error(t, err, "expected error for unknown platform")
+}
+
+func TestEstimateAll(t *testing.T) {
+	installConfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "install-config"},
+		Data: map[string][]byte{
+			"install-config.yaml": []byte(`
+controlPlane:
+  name: master
+  replicas: 3
+  platform:
+    aws:
+      type: m6a.2xlarge
+compute:
+- name: worker
+  replicas: 2
+`),
+		},
+	}
+	cd := testClusterDeployment(testcd.WithUnclaimedClusterPoolReference("pool-namespace", "pool"))
+	cd.Spec.Provisioning = &hivev1.Provisioning{InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "install-config"}}
+	other := testcd.FullBuilder(testNamespace, "not-installed", scheme.GetScheme()).Build(testcd.WithAWSPlatform(&hivev1aws.Platform{}))
+	unreadable := testcd.FullBuilder(testNamespace, "unreadable", scheme.GetScheme()).Build(
+		testcd.Installed(),
+		testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+	)
+	unreadable.Spec.Provisioning = &hivev1.Provisioning{InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "unreadable-install-config"}}
+	malformed := testcd.FullBuilder(testNamespace, "malformed", scheme.GetScheme()).Build(
+		testcd.Installed(),
+		testcd.WithAWSPlatform(&hivev1aws.Platform{Region: "us-east-1"}),
+	)
+	malformed.Spec.Provisioning = &hivev1.Provisioning{InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "malformed-install-config"}}
+	malformedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "malformed-install-config"},
+		Data:       map[string][]byte{"install-config.yaml": []byte("compute: [")},
+	}
+	c := testfake.NewFakeClientBuilder().
+		WithRuntimeObjects(cd, other, unreadable, installConfigSecret, malformed, malformedSecret).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if key.Name == "unreadable-install-config" {
+					return errors.New("forbidden")
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+
+	estimates, err := testModel().EstimateAll(context.TODO(), c, "", log.WithField("test", t.Name()))
+	require.NoError(t, err, "unexpected error estimating costs")
+	require.Len(t, estimates, 1, "expected a single estimate")
+	assert.Equal(t, testName, estimates[0].Name, "unexpected cluster")
+	assert.Equal(t, "pool", estimates[0].PoolRef.PoolName, "unexpected pool")
+	assert.InDelta(t, 4.25, estimates[0].Estimate.HourlyCost, 0.0001, "unexpected hourly cost")
+}
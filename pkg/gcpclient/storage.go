@@ -0,0 +1,98 @@
+package gcpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// StorageClient is a client for the objects of a single Google Cloud Storage bucket.
+type StorageClient interface {
+	// Put uploads the contents of body as an object with the given name, replacing any existing object.
+	Put(ctx context.Context, name string, body io.Reader, size int64) error
+
+	// List returns the names of the objects with the given prefix, mapped to when they were last modified.
+	List(ctx context.Context, prefix string) (map[string]time.Time, error)
+
+	// Delete deletes the named object. Deleting an object which does not exist is not an error.
+	Delete(ctx context.Context, name string) error
+
+	// URL returns the gs:// URL of the object with the given name, or of the bucket if the name is empty.
+	URL(name string) string
+}
+
+type storageClient struct {
+	bucketName string
+	bucket     *storage.BucketHandle
+}
+
+// NewStorageClientFromSecret creates a client for the objects of a GCS bucket. The GCP creds are read from the
+// specified secret.
+func NewStorageClientFromSecret(secret *corev1.Secret, bucket string) (StorageClient, error) {
+	ctx := context.TODO()
+
+	authJSON, err := authJSONFromSecretSource(secret)()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := google.CredentialsFromJSON(ctx, authJSON, storage.ScopeReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	client, err := storage.NewClient(ctx,
+		option.WithCredentials(creds),
+		option.WithUserAgent("openshift.io hive/v1"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &storageClient{
+		bucketName: bucket,
+		bucket:     client.Bucket(bucket),
+	}, nil
+}
+
+func (c *storageClient) URL(name string) string {
+	return fmt.Sprintf("gs://%s/%s", c.bucketName, name)
+}
+
+func (c *storageClient) Put(ctx context.Context, name string, body io.Reader, _ int64) error {
+	w := c.bucket.Object(name).NewWriter(ctx)
+	if _, err := io.Copy(w, body); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (c *storageClient) List(ctx context.Context, prefix string) (map[string]time.Time, error) {
+	objects := map[string]time.Time{}
+	it := c.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects[attrs.Name] = attrs.Updated
+	}
+}
+
+func (c *storageClient) Delete(ctx context.Context, name string) error {
+	err := c.bucket.Object(name).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+package ibmclient
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/pkg/errors"
+)
+
+// cisListPageSize is the number of zones or DNS records requested per page when listing from CIS.
+const cisListPageSize = 100
+
+func (c *Client) zonesService(crnstr string) (*zonesv1.ZonesV1, error) {
+	authenticator, err := NewIamAuthenticator(c.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	return zonesv1.NewZonesV1(&zonesv1.ZonesV1Options{
+		Authenticator: authenticator,
+		Crn:           core.StringPtr(crnstr),
+	})
+}
+
+func (c *Client) dnsRecordsService(crnstr string, zoneID string) (*dnsrecordsv1.DnsRecordsV1, error) {
+	authenticator, err := NewIamAuthenticator(c.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	return dnsrecordsv1.NewDnsRecordsV1(&dnsrecordsv1.DnsRecordsV1Options{
+		Authenticator:  authenticator,
+		Crn:            core.StringPtr(crnstr),
+		ZoneIdentifier: core.StringPtr(zoneID),
+	})
+}
+
+// GetCISZoneByName gets the zone with the given domain name from a Cloud Internet Services instance
+// by its CRN. Unlike GetDNSZones, zones that are not yet active are included. Returns nil if there is
+// no such zone.
+func (c *Client) GetCISZoneByName(ctx context.Context, crnstr string, name string) (*zonesv1.ZoneDetails, error) {
+	zonesService, err := c.zonesService(crnstr)
+	if err != nil {
+		return nil, err
+	}
+
+	options := zonesService.NewListZonesOptions()
+	options.PerPage = core.Int64Ptr(cisListPageSize)
+	for page := int64(1); ; page++ {
+		options.Page = core.Int64Ptr(page)
+		resp, _, err := zonesService.ListZonesWithContext(ctx, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list DNS zones")
+		}
+		for i, zone := range resp.Result {
+			if zone.Name != nil && *zone.Name == name {
+				return &resp.Result[i], nil
+			}
+		}
+		if len(resp.Result) < cisListPageSize {
+			return nil, nil
+		}
+	}
+}
+
+// CreateCISZone creates a zone with the given domain name in a Cloud Internet Services instance.
+func (c *Client) CreateCISZone(ctx context.Context, crnstr string, name string) (*zonesv1.ZoneDetails, error) {
+	zonesService, err := c.zonesService(crnstr)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, _, err := zonesService.CreateZoneWithContext(ctx, &zonesv1.CreateZoneOptions{
+		Name: core.StringPtr(name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create DNS zone")
+	}
+	return resp.Result, nil
+}
+
+// DeleteCISZone deletes a zone from a Cloud Internet Services instance.
+func (c *Client) DeleteCISZone(ctx context.Context, crnstr string, zoneID string) error {
+	zonesService, err := c.zonesService(crnstr)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = zonesService.DeleteZoneWithContext(ctx, zonesService.NewDeleteZoneOptions(zoneID))
+	return errors.Wrap(err, "could not delete DNS zone")
+}
+
+// ListCISDNSRecords lists all of the DNS records in a zone of a Cloud Internet Services instance.
+func (c *Client) ListCISDNSRecords(ctx context.Context, crnstr string, zoneID string) ([]dnsrecordsv1.DnsrecordDetails, error) {
+	dnsService, err := c.dnsRecordsService(crnstr, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []dnsrecordsv1.DnsrecordDetails
+	for page := int64(1); ; page++ {
+		resp, _, err := dnsService.ListAllDnsRecordsWithContext(ctx, &dnsrecordsv1.ListAllDnsRecordsOptions{
+			Page:    core.Int64Ptr(page),
+			PerPage: core.Int64Ptr(cisListPageSize),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list DNS records")
+		}
+		records = append(records, resp.Result...)
+		if len(resp.Result) < cisListPageSize {
+			return records, nil
+		}
+	}
+}
+
+// CreateCISDNSRecord creates a DNS record in a zone of a Cloud Internet Services instance.
+func (c *Client) CreateCISDNSRecord(ctx context.Context, crnstr string, zoneID string, recordType string, name string, content string, ttl int64) (*dnsrecordsv1.DnsrecordDetails, error) {
+	dnsService, err := c.dnsRecordsService(crnstr, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, _, err := dnsService.CreateDnsRecordWithContext(ctx, &dnsrecordsv1.CreateDnsRecordOptions{
+		Type:    core.StringPtr(recordType),
+		Name:    core.StringPtr(name),
+		Content: core.StringPtr(content),
+		TTL:     core.Int64Ptr(ttl),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create DNS record")
+	}
+	return resp.Result, nil
+}
+
+// DeleteCISDNSRecord deletes a DNS record from a zone of a Cloud Internet Services instance.
+func (c *Client) DeleteCISDNSRecord(ctx context.Context, crnstr string, zoneID string, recordID string) error {
+	dnsService, err := c.dnsRecordsService(crnstr, zoneID)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = dnsService.DeleteDnsRecordWithContext(ctx, dnsService.NewDeleteDnsRecordOptions(recordID))
+	return errors.Wrap(err, "could not delete DNS record")
+}
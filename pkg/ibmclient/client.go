@@ -24,7 +24,12 @@ import (
 
 // API represents the calls made to the API.
 type API interface {
+	CreateCISDNSRecord(ctx context.Context, crnstr string, zoneID string, recordType string, name string, content string, ttl int64) (*dnsrecordsv1.DnsrecordDetails, error)
+	CreateCISZone(ctx context.Context, crnstr string, name string) (*zonesv1.ZoneDetails, error)
+	DeleteCISDNSRecord(ctx context.Context, crnstr string, zoneID string, recordID string) error
+	DeleteCISZone(ctx context.Context, crnstr string, zoneID string) error
 	GetAuthenticatorAPIKeyDetails(ctx context.Context) (*iamidentityv1.APIKey, error)
+	GetCISZoneByName(ctx context.Context, crnstr string, name string) (*zonesv1.ZoneDetails, error)
 	GetCISInstance(ctx context.Context, crnstr string) (*resourcecontrollerv2.ResourceInstance, error)
 	GetDedicatedHostByName(ctx context.Context, name string, region string) (*vpcv1.DedicatedHost, error)
 	GetDedicatedHostProfiles(ctx context.Context, region string) ([]vpcv1.DedicatedHostProfile, error)
@@ -39,6 +44,7 @@ type API interface {
 	GetVPC(ctx context.Context, vpcID string) (*vpcv1.VPC, error)
 	GetVPCZonesForRegion(ctx context.Context, region string) ([]string, error)
 	GetVPCInstances(ctx context.Context, infraID, region string) ([]vpcv1.Instance, error)
+	ListCISDNSRecords(ctx context.Context, crnstr string, zoneID string) ([]dnsrecordsv1.DnsrecordDetails, error)
 	StartInstances(ctx context.Context, instances []vpcv1.Instance, region string) error
 	StopInstances(ctx context.Context, instances []vpcv1.Instance, region string) error
 }
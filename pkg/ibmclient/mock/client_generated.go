@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	dnsrecordsv1 "github.com/IBM/networking-go-sdk/dnsrecordsv1"
+	zonesv1 "github.com/IBM/networking-go-sdk/zonesv1"
 	iamidentityv1 "github.com/IBM/platform-services-go-sdk/iamidentityv1"
 	resourcecontrollerv2 "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 	resourcemanagerv2 "github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
@@ -40,6 +41,64 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// CreateCISDNSRecord mocks base method.
+func (m *MockAPI) CreateCISDNSRecord(ctx context.Context, crnstr, zoneID, recordType, name, content string, ttl int64) (*dnsrecordsv1.DnsrecordDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCISDNSRecord", ctx, crnstr, zoneID, recordType, name, content, ttl)
+	ret0, _ := ret[0].(*dnsrecordsv1.DnsrecordDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCISDNSRecord indicates an expected call of CreateCISDNSRecord.
+func (mr *MockAPIMockRecorder) CreateCISDNSRecord(ctx, crnstr, zoneID, recordType, name, content, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCISDNSRecord", reflect.TypeOf((*MockAPI)(nil).CreateCISDNSRecord), ctx, crnstr, zoneID, recordType, name, content, ttl)
+}
+
+// CreateCISZone mocks base method.
+func (m *MockAPI) CreateCISZone(ctx context.Context, crnstr, name string) (*zonesv1.ZoneDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCISZone", ctx, crnstr, name)
+	ret0, _ := ret[0].(*zonesv1.ZoneDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCISZone indicates an expected call of CreateCISZone.
+func (mr *MockAPIMockRecorder) CreateCISZone(ctx, crnstr, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCISZone", reflect.TypeOf((*MockAPI)(nil).CreateCISZone), ctx, crnstr, name)
+}
+
+// DeleteCISDNSRecord mocks base method.
+func (m *MockAPI) DeleteCISDNSRecord(ctx context.Context, crnstr, zoneID, recordID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCISDNSRecord", ctx, crnstr, zoneID, recordID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCISDNSRecord indicates an expected call of DeleteCISDNSRecord.
+func (mr *MockAPIMockRecorder) DeleteCISDNSRecord(ctx, crnstr, zoneID, recordID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCISDNSRecord", reflect.TypeOf((*MockAPI)(nil).DeleteCISDNSRecord), ctx, crnstr, zoneID, recordID)
+}
+
+// DeleteCISZone mocks base method.
+func (m *MockAPI) DeleteCISZone(ctx context.Context, crnstr, zoneID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCISZone", ctx, crnstr, zoneID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCISZone indicates an expected call of DeleteCISZone.
+func (mr *MockAPIMockRecorder) DeleteCISZone(ctx, crnstr, zoneID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCISZone", reflect.TypeOf((*MockAPI)(nil).DeleteCISZone), ctx, crnstr, zoneID)
+}
+
 // GetAuthenticatorAPIKeyDetails mocks base method.
 func (m *MockAPI) GetAuthenticatorAPIKeyDetails(ctx context.Context) (*iamidentityv1.APIKey, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +129,21 @@ func (mr *MockAPIMockRecorder) GetCISInstance(ctx, crnstr interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCISInstance", reflect.TypeOf((*MockAPI)(nil).GetCISInstance), ctx, crnstr)
 }
 
+// GetCISZoneByName mocks base method.
+func (m *MockAPI) GetCISZoneByName(ctx context.Context, crnstr, name string) (*zonesv1.ZoneDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCISZoneByName", ctx, crnstr, name)
+	ret0, _ := ret[0].(*zonesv1.ZoneDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCISZoneByName indicates an expected call of GetCISZoneByName.
+func (mr *MockAPIMockRecorder) GetCISZoneByName(ctx, crnstr, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCISZoneByName", reflect.TypeOf((*MockAPI)(nil).GetCISZoneByName), ctx, crnstr, name)
+}
+
 // GetDNSRecordsByName mocks base method.
 func (m *MockAPI) GetDNSRecordsByName(ctx context.Context, crnstr, zoneID, recordName string) ([]dnsrecordsv1.DnsrecordDetails, error) {
 	m.ctrl.T.Helper()
@@ -265,6 +339,21 @@ func (mr *MockAPIMockRecorder) GetVSIProfiles(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVSIProfiles", reflect.TypeOf((*MockAPI)(nil).GetVSIProfiles), ctx)
 }
 
+// ListCISDNSRecords mocks base method.
+func (m *MockAPI) ListCISDNSRecords(ctx context.Context, crnstr, zoneID string) ([]dnsrecordsv1.DnsrecordDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCISDNSRecords", ctx, crnstr, zoneID)
+	ret0, _ := ret[0].([]dnsrecordsv1.DnsrecordDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCISDNSRecords indicates an expected call of ListCISDNSRecords.
+func (mr *MockAPIMockRecorder) ListCISDNSRecords(ctx, crnstr, zoneID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCISDNSRecords", reflect.TypeOf((*MockAPI)(nil).ListCISDNSRecords), ctx, crnstr, zoneID)
+}
+
 // StartInstances mocks base method.
 func (m *MockAPI) StartInstances(ctx context.Context, instances []vpcv1.Instance, region string) error {
 	m.ctrl.T.Helper()
@@ -334,6 +334,25 @@ func InstallerPodSpec(
 		})
 	}
 
+	// Mount the PersistentVolumeClaim logs of failed provisions are stored in, if configured:
+	for _, envVar := range extraEnvVars {
+		if envVar.Name != constants.InstallLogsPVCNameEnvVar {
+			continue
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: "install-logs",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: envVar.Value,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "install-logs",
+			MountPath: constants.InstallLogsPVCMountPath,
+		})
+	}
+
 	// Signal to fake an installation:
 	if controllerutils.IsFakeCluster(cd) {
 		env = append(env, corev1.EnvVar{
@@ -543,6 +562,7 @@ func GenerateUninstallerJobForDeprovision(
 		args = []string{
 			"deprovision",
 			"--metadata-json-secret-name", req.Spec.MetadataJSONSecretRef.Name,
+			"--clusterdeprovision-name", req.Name,
 		}
 	}
 
@@ -564,6 +584,10 @@ func GenerateUninstallerJobForDeprovision(
 	default:
 		return nil, errors.New("deprovision requests currently not supported for platform")
 	}
+	if legacy {
+		// Legacy deprovisions report their progress to the ClusterDeprovision as well.
+		job.Spec.Template.Spec.Containers[0].Args = append(job.Spec.Template.Spec.Containers[0].Args, "--clusterdeprovision-name", req.Name)
+	}
 
 	for idx := range job.Spec.Template.Spec.Containers {
 		job.Spec.Template.Spec.Containers[idx].Env = append(job.Spec.Template.Spec.Containers[idx].Env, extraEnvVars...)
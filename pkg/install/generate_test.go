@@ -4,10 +4,12 @@ import (
 	"testing"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	hiveassert "github.com/openshift/hive/pkg/test/assert"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +42,8 @@ func TestGenerateDeprovision(t *testing.T) {
 	hiveassert.AssertAllContainersHaveEnvVar(t, &job.Spec.Template.Spec, "HTTP_PROXY", testHttpProxy)
 	hiveassert.AssertAllContainersHaveEnvVar(t, &job.Spec.Template.Spec, "HTTPS_PROXY", testHttpsProxy)
 	hiveassert.AssertAllContainersHaveEnvVar(t, &job.Spec.Template.Spec, "NO_PROXY", testNoProxy)
+	args := job.Spec.Template.Spec.Containers[0].Args
+	assert.Equal(t, []string{"--clusterdeprovision-name", dr.Name}, args[len(args)-2:], "expected legacy deprovision to report progress")
 }
 
 func testClusterDeprovision() *hivev1.ClusterDeprovision {
@@ -108,6 +112,40 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Provision Pod Install Logs Volume",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			extraEnvVars: []corev1.EnvVar{
+				{
+					Name:  constants.InstallLogsPVCNameEnvVar,
+					Value: "logs-claim",
+				},
+			},
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				require.NoError(t, actualError)
+				assert.Contains(t, actualPodSpec.Volumes, corev1.Volume{
+					Name: "install-logs",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "logs-claim"},
+					},
+				}, "expected install logs volume")
+				assert.Contains(t, actualPodSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name:      "install-logs",
+					MountPath: constants.InstallLogsPVCMountPath,
+				}, "expected install logs volume to be mounted")
+			},
+		},
 	}
 
 	for _, test := range tests {
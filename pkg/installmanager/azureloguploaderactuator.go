@@ -0,0 +1,65 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+
+	"github.com/pkg/errors"
+)
+
+// Ensure azureLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &azureLogUploaderActuator{}
+
+// azureLogUploaderActuator uploads logs to a container in Azure Blob Storage.
+type azureLogUploaderActuator struct {
+	// blobClientFn is the function to build an Azure blob client, here for lazy loading the client.
+	blobClientFn func(secret *corev1.Secret, cloudName, storageAccount, container string) (azureclient.BlobClient, error)
+}
+
+// IsConfigured returns true if this log upload provider is configured
+func (a *azureLogUploaderActuator) IsConfigured() bool {
+	return isLogUploadProvider(constants.InstallLogsUploadProviderAzure)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *azureLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) (string, error) {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return "", errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	storageAccount, foundStorageAccountEnvVar := os.LookupEnv(constants.InstallLogsAzureStorageAccountEnvVar)
+	if !foundStorageAccountEnvVar {
+		return "", errors.New("couldn't find storage account in environment variable. Skipping upload")
+	}
+
+	container, foundContainerEnvVar := os.LookupEnv(constants.InstallLogsAzureContainerEnvVar)
+	if !foundContainerEnvVar {
+		return "", errors.New("couldn't find container in environment variable. Skipping upload")
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: clusterprovision.Namespace, Name: secretName}, secret); err != nil {
+		return "", errors.Wrap(err, "failed to get install logs credentials secret")
+	}
+
+	blobClient, err := a.blobClientFn(secret, os.Getenv(constants.InstallLogsAzureCloudNameEnvVar), storageAccount, container)
+	if err != nil {
+		log.WithError(err).Error("failed to get Azure blob client")
+		return "", err
+	}
+
+	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+
+	return uploadLogsToObjectStore(blobClient, folder, clusterprovision, log, filenames...)
+}
@@ -3,6 +3,7 @@ package installmanager
 import (
 	"context"
 	"fmt"
+	"os"
 
 	log "github.com/sirupsen/logrus"
 
@@ -12,11 +13,13 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
 	dns "github.com/openshift/hive/pkg/controller/dnszone"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	azurecreds "github.com/openshift/hive/pkg/creds/azure"
 	gcpcreds "github.com/openshift/hive/pkg/creds/gcp"
 	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/ibmclient"
 )
 
 // cleanupDNSZone will handle any needed DNS cleanup for ClusterDeployments with
@@ -40,6 +43,8 @@ func cleanupDNSZone(dynClient client.Client, cd *hivev1.ClusterDeployment, logge
 		return cleanupAzureDNSZone(dnsZone, logger)
 	case cd.Spec.Platform.GCP != nil:
 		return cleanupGCPDNSZone(dnsZone, logger)
+	case cd.Spec.Platform.IBMCloud != nil:
+		return cleanupIBMCloudDNSZone(dnsZone, logger)
 	default:
 		log.Debug("No DNS cleanup for platform type")
 		return nil
@@ -130,3 +135,34 @@ func cleanupGCPDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
 	logger.Info("DNSZone cleaned")
 	return nil
 }
+
+// cleanupIBMCloudDNSZone will return a DNS zone to the minimum set of DNS records
+func cleanupIBMCloudDNSZone(dnsZone *hivev1.DNSZone, logger log.FieldLogger) error {
+	if dnsZone.Spec.IBMCloud == nil || dnsZone.Status.IBMCloud == nil {
+		return fmt.Errorf("found non-IBM Cloud DNSZone for IBM Cloud ClusterDeployment")
+	}
+	if dnsZone.Status.IBMCloud.ZoneID == nil {
+		// Shouldn't happen as we block installs until DNS is ready
+		return fmt.Errorf("DNSZone %s has no ZoneID set", dnsZone.Name)
+	}
+
+	logger = logger.WithField("dnsZoneID", *dnsZone.Status.IBMCloud.ZoneID)
+	logger.Info("cleaning up DNSZone")
+
+	ibmCloudAPIKey := os.Getenv(constants.IBMCloudAPIKeyEnvVar)
+	if ibmCloudAPIKey == "" {
+		return fmt.Errorf("no %s env var set, cannot proceed", constants.IBMCloudAPIKeyEnvVar)
+	}
+	ibmClient, err := ibmclient.NewClient(ibmCloudAPIKey)
+	if err != nil {
+		logger.WithError(err).Error("failed to create IBM Cloud client")
+		return err
+	}
+
+	if err := dns.DeleteIBMCloudDNSRecords(ibmClient, dnsZone, *dnsZone.Status.IBMCloud.ZoneID, logger); err != nil {
+		logger.WithError(err).Error("failed to clean up DNS zone")
+		return err
+	}
+	logger.Info("DNSZone cleaned")
+	return nil
+}
@@ -0,0 +1,60 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/gcpclient"
+
+	"github.com/pkg/errors"
+)
+
+// Ensure gcsLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &gcsLogUploaderActuator{}
+
+// gcsLogUploaderActuator uploads logs to a Google Cloud Storage bucket.
+type gcsLogUploaderActuator struct {
+	// storageClientFn is the function to build a GCS client, here for lazy loading the client.
+	storageClientFn func(secret *corev1.Secret, bucket string) (gcpclient.StorageClient, error)
+}
+
+// IsConfigured returns true if this log upload provider is configured
+func (a *gcsLogUploaderActuator) IsConfigured() bool {
+	return isLogUploadProvider(constants.InstallLogsUploadProviderGCP)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *gcsLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) (string, error) {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return "", errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	bucket, foundBucketEnvVar := os.LookupEnv(constants.InstallLogsGCSBucketEnvVar)
+	if !foundBucketEnvVar {
+		return "", errors.New("couldn't find bucket in environment variable. Skipping upload")
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: clusterprovision.Namespace, Name: secretName}, secret); err != nil {
+		return "", errors.Wrap(err, "failed to get install logs credentials secret")
+	}
+
+	storageClient, err := a.storageClientFn(secret, bucket)
+	if err != nil {
+		log.WithError(err).Error("failed to get GCS client")
+		return "", err
+	}
+
+	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+
+	return uploadLogsToObjectStore(storageClient, folder, clusterprovision, log, filenames...)
+}
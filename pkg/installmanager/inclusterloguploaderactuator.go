@@ -0,0 +1,271 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+
+	"github.com/pkg/errors"
+)
+
+// installLogChunkSize is the most log data stored in a single Secret or ConfigMap, leaving room for metadata within
+// the 1MiB limit on the size of objects.
+const installLogChunkSize = 768 * 1024
+
+// Ensure inClusterLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &inClusterLogUploaderActuator{}
+
+// inClusterLogUploaderActuator stores logs on the hub cluster, either in Secrets or ConfigMaps owned by the
+// ClusterProvision, or in a PersistentVolumeClaim mounted in the installer pod.
+type inClusterLogUploaderActuator struct {
+	// pvcMountPath is where the PersistentVolumeClaim logs are written to is mounted.
+	pvcMountPath string
+}
+
+// IsConfigured returns true if this log upload provider is configured
+func (a *inClusterLogUploaderActuator) IsConfigured() bool {
+	return isLogUploadProvider(constants.InstallLogsUploadProviderInCluster)
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *inClusterLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) (string, error) {
+	storageType := hivev1.FailedProvisionLogStorageType(os.Getenv(constants.InstallLogsInClusterStorageTypeEnvVar))
+	switch storageType {
+	case hivev1.FailedProvisionLogStoragePersistentVolumeClaim:
+		claimName, found := os.LookupEnv(constants.InstallLogsPVCNameEnvVar)
+		if !found {
+			return "", errors.New("couldn't find persistent volume claim name in environment variable. Skipping upload")
+		}
+		return a.uploadLogsToVolume(claimName, clusterprovision, log, filenames...)
+	case "", hivev1.FailedProvisionLogStorageSecret, hivev1.FailedProvisionLogStorageConfigMap:
+		if storageType == "" {
+			storageType = hivev1.FailedProvisionLogStorageSecret
+		}
+		return uploadLogsToObjects(storageType, clusterprovision, c, log, filenames...)
+	default:
+		return "", fmt.Errorf("unsupported install logs storage type %q. Skipping upload", storageType)
+	}
+}
+
+// uploadLogsToVolume copies log files to <provision name>-<file name> in the mounted PersistentVolumeClaim, first
+// deleting the files in it older than the configured log retention. The claim is specific to the ClusterDeployment.
+func (a *inClusterLogUploaderActuator) uploadLogsToVolume(claimName string, clusterprovision *hivev1.ClusterProvision, log log.FieldLogger, filenames ...string) (string, error) {
+	claimURL := fmt.Sprintf("/api/v1/namespaces/%s/persistentvolumeclaims/%s", clusterprovision.Namespace, claimName)
+	log.Infof("Copying log(s) to persistent volume claim %v", claimName)
+
+	retvalErrs := []error{}
+
+	retention, err := logRetention()
+	if err != nil {
+		retvalErrs = append(retvalErrs, err)
+	} else if retention > 0 {
+		if err := pruneVolume(a.pvcMountPath, time.Now().Add(-retention), log); err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrap(err, "Failed pruning old logs"))
+		}
+	}
+
+	for _, filename := range filenames {
+		dest := filepath.Join(a.pvcMountPath, fmt.Sprintf("%v-%v", clusterprovision.Name, filepath.Base(filename)))
+		if err := copyFile(filename, dest); err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed copying log file: %v", filename))
+		}
+	}
+
+	return claimURL, utilerrors.NewAggregate(retvalErrs)
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// pruneVolume deletes the files in dir last modified before cutoff.
+func pruneVolume(dir string, cutoff time.Time, log log.FieldLogger) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	errs := []error{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		log.WithField("file", entry.Name()).Info("deleting log past retention")
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// uploadLogsToObjects stores log files in Secrets or ConfigMaps owned by the ClusterProvision, splitting files into
+// chunks of at most installLogChunkSize bytes, one chunk per object. Objects holding logs of earlier provisions of the
+// ClusterDeployment older than the configured log retention are deleted first.
+func uploadLogsToObjects(storageType hivev1.FailedProvisionLogStorageType, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) (string, error) {
+	resource := "secrets"
+	if storageType == hivev1.FailedProvisionLogStorageConfigMap {
+		resource = "configmaps"
+	}
+	selector := fmt.Sprintf("%s=%s", constants.ClusterProvisionNameLabel, clusterprovision.Name)
+	objectsURL := fmt.Sprintf("/api/v1/namespaces/%s/%s?labelSelector=%s", clusterprovision.Namespace, resource, url.QueryEscape(selector))
+	log.Infof("Storing log(s) in %v", resource)
+
+	retvalErrs := []error{}
+
+	retention, err := logRetention()
+	if err != nil {
+		retvalErrs = append(retvalErrs, err)
+	} else if retention > 0 {
+		if err := pruneLogObjects(storageType, clusterprovision, c, time.Now().Add(-retention), log); err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrap(err, "Failed pruning old logs"))
+		}
+	}
+
+	index := 0
+	for _, filename := range filenames {
+		contents, err := os.ReadFile(filename)
+		if err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed reading log file: %v", filename))
+			continue
+		}
+		key := filepath.Base(filename)
+		for chunk := 0; chunk == 0 || len(contents) > 0; chunk++ {
+			size := min(len(contents), installLogChunkSize)
+			obj := newLogObject(storageType, clusterprovision, helpers.GetResourceName(clusterprovision.Name, fmt.Sprintf("logs-%d", index)), key, chunk, contents[:size])
+			contents = contents[size:]
+			index++
+			if err := createOrUpdateLogObject(c, obj); err != nil {
+				retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed storing chunk %d of log file: %v", chunk, filename))
+			}
+		}
+	}
+
+	return objectsURL, utilerrors.NewAggregate(retvalErrs)
+}
+
+func newLogObject(storageType hivev1.FailedProvisionLogStorageType, clusterprovision *hivev1.ClusterProvision, name, key string, chunk int, data []byte) client.Object {
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: clusterprovision.Namespace,
+		Labels: map[string]string{
+			constants.ClusterDeploymentNameLabel: clusterprovision.Spec.ClusterDeploymentRef.Name,
+			constants.ClusterProvisionNameLabel:  clusterprovision.Name,
+			constants.InstallLogsLabel:           "true",
+		},
+		Annotations: map[string]string{
+			constants.InstallLogsFileAnnotation:  key,
+			constants.InstallLogsChunkAnnotation: strconv.Itoa(chunk),
+		},
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion:         hivev1.SchemeGroupVersion.String(),
+			Kind:               "ClusterProvision",
+			Name:               clusterprovision.Name,
+			UID:                clusterprovision.UID,
+			BlockOwnerDeletion: ptr.To(true),
+		}},
+	}
+	if storageType == hivev1.FailedProvisionLogStorageConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: objectMeta,
+			BinaryData: map[string][]byte{key: data},
+		}
+	}
+	return &corev1.Secret{
+		ObjectMeta: objectMeta,
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{key: data},
+	}
+}
+
+// createOrUpdateLogObject creates the object, replacing an existing one left behind by an earlier attempt to store
+// the logs of the same provision.
+func createOrUpdateLogObject(c client.Client, obj client.Object) error {
+	err := c.Create(context.TODO(), obj)
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	existing := obj.DeepCopyObject().(client.Object)
+	if err := c.Get(context.TODO(), client.ObjectKeyFromObject(obj), existing); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(context.TODO(), obj)
+}
+
+// pruneLogObjects deletes the Secrets or ConfigMaps holding logs of earlier provisions of the ClusterDeployment created
+// before cutoff.
+func pruneLogObjects(storageType hivev1.FailedProvisionLogStorageType, clusterprovision *hivev1.ClusterProvision, c client.Client, cutoff time.Time, log log.FieldLogger) error {
+	listOpts := []client.ListOption{
+		client.InNamespace(clusterprovision.Namespace),
+		client.MatchingLabels{
+			constants.ClusterDeploymentNameLabel: clusterprovision.Spec.ClusterDeploymentRef.Name,
+			constants.InstallLogsLabel:           "true",
+		},
+	}
+	var objs []client.Object
+	if storageType == hivev1.FailedProvisionLogStorageConfigMap {
+		list := &corev1.ConfigMapList{}
+		if err := c.List(context.TODO(), list, listOpts...); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	} else {
+		list := &corev1.SecretList{}
+		if err := c.List(context.TODO(), list, listOpts...); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	}
+	errs := []error{}
+	for _, obj := range objs {
+		if obj.GetLabels()[constants.ClusterProvisionNameLabel] == clusterprovision.Name || !obj.GetCreationTimestamp().Time.Before(cutoff) {
+			continue
+		}
+		log.WithField("object", obj.GetName()).Info("deleting log past retention")
+		if err := c.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,181 @@
+package installmanager
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestInClusterUploadLogs(t *testing.T) {
+	logDir := t.TempDir()
+	smallLog := filepath.Join(logDir, "small.log")
+	require.NoError(t, os.WriteFile(smallLog, []byte("small"), 0600))
+	largeContents := bytes.Repeat([]byte("x"), installLogChunkSize+10)
+	largeLog := filepath.Join(logDir, "large.log")
+	require.NoError(t, os.WriteFile(largeLog, largeContents, 0600))
+
+	oldLogSecret := func(name, provision string, created time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         testNamespace,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					constants.ClusterDeploymentNameLabel: testDeploymentName,
+					constants.ClusterProvisionNameLabel:  provision,
+					constants.InstallLogsLabel:           "true",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		storageType    string
+		retention      string
+		existing       []runtime.Object
+		files          []string
+		expectError    bool
+		expectedURL    string
+		expectedChunks map[string][]byte
+		expectDeleted  []string
+		expectKept     []string
+	}{
+		{
+			name:        "secrets by default",
+			files:       []string{smallLog, largeLog},
+			expectedURL: "/api/v1/namespaces/test-namespace/secrets?labelSelector=hive.openshift.io%2Fcluster-provision-name%3D" + testProvisionName,
+			expectedChunks: map[string][]byte{
+				testProvisionName + "-logs-0": []byte("small"),
+				testProvisionName + "-logs-1": largeContents[:installLogChunkSize],
+				testProvisionName + "-logs-2": largeContents[installLogChunkSize:],
+			},
+		},
+		{
+			name:        "configmaps",
+			storageType: string(hivev1.FailedProvisionLogStorageConfigMap),
+			files:       []string{smallLog},
+			expectedURL: "/api/v1/namespaces/test-namespace/configmaps?labelSelector=hive.openshift.io%2Fcluster-provision-name%3D" + testProvisionName,
+			expectedChunks: map[string][]byte{
+				testProvisionName + "-logs-0": []byte("small"),
+			},
+		},
+		{
+			name:        "prune secrets past retention",
+			storageType: string(hivev1.FailedProvisionLogStorageSecret),
+			retention:   "24h",
+			existing: []runtime.Object{
+				oldLogSecret("old-logs-0", "old", time.Now().Add(-48*time.Hour)),
+				oldLogSecret("recent-logs-0", "recent", time.Now().Add(-time.Hour)),
+			},
+			files:       []string{smallLog},
+			expectedURL: "/api/v1/namespaces/test-namespace/secrets?labelSelector=hive.openshift.io%2Fcluster-provision-name%3D" + testProvisionName,
+			expectedChunks: map[string][]byte{
+				testProvisionName + "-logs-0": []byte("small"),
+			},
+			expectDeleted: []string{"old-logs-0"},
+			expectKept:    []string{"recent-logs-0"},
+		},
+		{
+			name:        "unsupported storage type",
+			storageType: "Tape",
+			files:       []string{smallLog},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderInCluster)
+			t.Setenv(constants.InstallLogsInClusterStorageTypeEnvVar, test.storageType)
+			t.Setenv(constants.InstallLogsRetentionEnvVar, test.retention)
+			mocks := setupDefaultMocks(t, test.existing...)
+			actuator := &inClusterLogUploaderActuator{}
+			provision := testClusterProvision()
+
+			require.True(t, actuator.IsConfigured(), "expected actuator to be configured")
+			logsURL, err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), test.files...)
+
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedURL, logsURL, "unexpected logs URL")
+			for name, expected := range test.expectedChunks {
+				var obj client.Object
+				var data map[string][]byte
+				if test.storageType == string(hivev1.FailedProvisionLogStorageConfigMap) {
+					cm := &corev1.ConfigMap{}
+					require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: name}, cm), "expected configmap %s", name)
+					obj, data = cm, cm.BinaryData
+				} else {
+					secret := &corev1.Secret{}
+					require.NoError(t, mocks.fakeKubeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: name}, secret), "expected secret %s", name)
+					obj, data = secret, secret.Data
+				}
+				key := obj.GetAnnotations()[constants.InstallLogsFileAnnotation]
+				assert.Equal(t, expected, data[key], "unexpected contents of %s", name)
+				assert.Equal(t, testProvisionName, obj.GetLabels()[constants.ClusterProvisionNameLabel], "unexpected provision label")
+				assert.Equal(t, testDeploymentName, obj.GetLabels()[constants.ClusterDeploymentNameLabel], "unexpected deployment label")
+				if assert.Len(t, obj.GetOwnerReferences(), 1, "expected owner reference") {
+					assert.Equal(t, testProvisionName, obj.GetOwnerReferences()[0].Name, "unexpected owner")
+				}
+			}
+			for _, name := range test.expectDeleted {
+				err := mocks.fakeKubeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: name}, &corev1.Secret{})
+				assert.Error(t, err, "expected %s to be deleted", name)
+			}
+			for _, name := range test.expectKept {
+				err := mocks.fakeKubeClient.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: name}, &corev1.Secret{})
+				assert.NoError(t, err, "expected %s to be kept", name)
+			}
+		})
+	}
+}
+
+func TestInClusterUploadLogsToVolume(t *testing.T) {
+	logDir := t.TempDir()
+	logFile := filepath.Join(logDir, "log-bundle.tar.gz")
+	require.NoError(t, os.WriteFile(logFile, []byte("logs"), 0600))
+
+	mountPath := t.TempDir()
+	oldLog := filepath.Join(mountPath, "old-log-bundle.tar.gz")
+	require.NoError(t, os.WriteFile(oldLog, []byte("old logs"), 0600))
+	oldTime := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(oldLog, oldTime, oldTime))
+	recentLog := filepath.Join(mountPath, "recent-log-bundle.tar.gz")
+	require.NoError(t, os.WriteFile(recentLog, []byte("recent logs"), 0600))
+
+	t.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderInCluster)
+	t.Setenv(constants.InstallLogsInClusterStorageTypeEnvVar, string(hivev1.FailedProvisionLogStoragePersistentVolumeClaim))
+	t.Setenv(constants.InstallLogsPVCNameEnvVar, "logs-claim")
+	t.Setenv(constants.InstallLogsRetentionEnvVar, "24h")
+	mocks := setupDefaultMocks(t)
+	actuator := &inClusterLogUploaderActuator{pvcMountPath: mountPath}
+
+	logsURL, err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), logFile)
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, "/api/v1/namespaces/test-namespace/persistentvolumeclaims/logs-claim", logsURL, "unexpected logs URL")
+
+	contents, err := os.ReadFile(filepath.Join(mountPath, testProvisionName+"-log-bundle.tar.gz"))
+	require.NoError(t, err, "expected log file to be copied")
+	assert.Equal(t, []byte("logs"), contents, "unexpected log file contents")
+	_, err = os.Stat(oldLog)
+	assert.True(t, os.IsNotExist(err), "expected old log to be pruned")
+	_, err = os.Stat(recentLog)
+	assert.NoError(t, err, "expected recent log to be kept")
+}
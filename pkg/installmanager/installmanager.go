@@ -61,6 +61,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	contributils "github.com/openshift/hive/contrib/pkg/utils"
 	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/azureclient"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/machinepool"
 	"github.com/openshift/hive/pkg/controller/utils"
@@ -151,14 +152,26 @@ completing install, and monitors for artifacts that need to be uploaded back to
 The following environment variables, if present, configure the Install Manager to upload logs for
 failed provisions:
 
-HIVE_INSTALL_LOGS_UPLOAD_PROVIDER: The cloud provider hosting the object store. At this time only
-	"aws" is supported.
+HIVE_INSTALL_LOGS_UPLOAD_PROVIDER: Where the logs are stored. One of "aws", "azure" and "gcp" for
+	the object store of that cloud provider, or "incluster" to store them on the hub cluster.
 HIVE_INSTALL_LOGS_CREDENTIALS_SECRET: The name of a secret in the current namespace containing
 	credentials sufficient to write data to the specified bucket. For example, for AWS, the secret
 	data could contain base64-encoded values for "aws_access_key_id" and "aws_secret_access_key".
+HIVE_INSTALL_LOGS_RETENTION: If set, logs of the cluster older than this duration are deleted
+	when new logs are stored.
 HIVE_INSTALL_LOGS_AWS_REGION: The region containing the specified bucket.
 HIVE_INSTALL_LOGS_AWS_S3_BUCKET: The name of the S3 bucket to which to upload the logs. The bucket
 	must exist and be writable using the specified credentials.
+HIVE_INSTALL_LOGS_AZURE_STORAGE_ACCOUNT: The Azure storage account to which to upload the logs.
+HIVE_INSTALL_LOGS_AZURE_CONTAINER: The blob container in the storage account to which to upload
+	the logs. The container must exist and be writable using the specified credentials.
+HIVE_INSTALL_LOGS_AZURE_CLOUD_NAME: The Azure cloud environment. Defaults to "AzurePublicCloud".
+HIVE_INSTALL_LOGS_GCS_BUCKET: The name of the GCS bucket to which to upload the logs. The bucket
+	must exist and be writable using the specified credentials.
+HIVE_INSTALL_LOGS_STORAGE_TYPE: For "incluster", one of "Secret" (the default), "ConfigMap" or
+	"PersistentVolumeClaim".
+HIVE_INSTALL_LOGS_PVC_NAME: For the "PersistentVolumeClaim" storage type, the name of the claim,
+	which must be mounted at /install-logs.
 SSH_PRIV_KEY_PATH: File system path of a file containing the SSH private key corresponding to the
 	public key in the install config.`,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -574,6 +587,9 @@ func getActuator() LogUploaderActuator {
 	// As we add more LogUploaderActuators, add them here
 	actuators := []LogUploaderActuator{
 		&s3LogUploaderActuator{awsClientFn: getAWSClient},
+		&azureLogUploaderActuator{blobClientFn: azureclient.NewBlobClientFromSecret},
+		&gcsLogUploaderActuator{storageClientFn: gcpclient.NewStorageClientFromSecret},
+		&inClusterLogUploaderActuator{pvcMountPath: constants.InstallLogsPVCMountPath},
 	}
 
 	for _, a := range actuators {
@@ -1364,10 +1380,27 @@ func (m *InstallManager) gatherLogs(cd *hivev1.ClusterDeployment, sshPrivKeyPath
 		filepaths = append(filepaths, filepath.Join(m.LogsDir, file.Name()))
 	}
 
-	uploadErr := m.actuator.UploadLogs(cd.Spec.ClusterName, m.ClusterProvision, m.DynamicClient, m.log, filepaths...)
+	logsURL, uploadErr := m.actuator.UploadLogs(cd.Spec.ClusterName, m.ClusterProvision, m.DynamicClient, m.log, filepaths...)
 	if uploadErr != nil {
 		m.log.WithError(uploadErr).Error("error uploading logs")
 	}
+	if logsURL == "" {
+		return
+	}
+	if err := m.setInstallLogsURL(logsURL); err != nil {
+		m.log.WithError(err).Warn("error recording install logs location on clusterprovision")
+	}
+}
+
+// setInstallLogsURL records where the logs of the failed provision were stored on the status of the ClusterProvision.
+func (m *InstallManager) setInstallLogsURL(logsURL string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := m.loadClusterProvision(); err != nil {
+			return err
+		}
+		m.ClusterProvision.Status.InstallLogsURL = logsURL
+		return m.DynamicClient.Status().Update(context.Background(), m.ClusterProvision)
+	})
 }
 
 func (m *InstallManager) gatherClusterLogs(cd *hivev1.ClusterDeployment) error {
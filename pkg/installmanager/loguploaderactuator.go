@@ -1,8 +1,17 @@
 package installmanager
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -11,6 +20,105 @@ type LogUploaderActuator interface {
 	// IsConfigured returns true if the actuator can handle a particular case
 	IsConfigured() bool
 
-	// UploadLogs uploads installer logs to the provider's storage mechanism.
-	UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error
+	// UploadLogs uploads installer logs to the provider's storage mechanism. It returns the location the logs were
+	// stored at, which is recorded on the ClusterProvision, even if some of the logs failed to upload.
+	UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) (string, error)
+}
+
+// logObjectStore is an object store that logs are uploaded to.
+type logObjectStore interface {
+	// Put uploads size bytes read from body as an object with the given name.
+	Put(ctx context.Context, name string, body io.Reader, size int64) error
+	// List returns the names of the objects with the given prefix, mapped to when they were last modified.
+	List(ctx context.Context, prefix string) (map[string]time.Time, error)
+	// Delete deletes the named object.
+	Delete(ctx context.Context, name string) error
+	// URL returns the URL of the named object.
+	URL(name string) string
+}
+
+// isLogUploadProvider returns true if the install logs upload provider environment variable is set to provider.
+func isLogUploadProvider(provider string) bool {
+	configured, found := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !found {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+	return configured == provider
+}
+
+// logRetention returns how long uploaded logs are kept, or 0 if they are kept indefinitely.
+func logRetention() (time.Duration, error) {
+	retention, found := os.LookupEnv(constants.InstallLogsRetentionEnvVar)
+	if !found || retention == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(retention)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid log retention %q", retention)
+	}
+	return d, nil
+}
+
+// uploadLogsToObjectStore uploads log files to <folder>/<provision name>-<file name> in the store, first deleting the
+// objects in the folder older than the configured log retention. It returns the URL of the folder.
+func uploadLogsToObjectStore(store logObjectStore, folder string, clusterprovision *hivev1.ClusterProvision, log log.FieldLogger, filenames ...string) (string, error) {
+	ctx := context.TODO()
+	folderURL := store.URL(folder + "/")
+	log.Infof("Uploading log(s) to %v", folderURL)
+
+	retvalErrs := []error{}
+
+	retention, err := logRetention()
+	if err != nil {
+		retvalErrs = append(retvalErrs, err)
+	} else if retention > 0 {
+		if err := pruneObjectStore(ctx, store, folder+"/", time.Now().Add(-retention), log); err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrap(err, "Failed pruning old logs"))
+		}
+	}
+
+	for _, filename := range filenames {
+		if err := func() error {
+			file, err := os.Open(filename)
+			if err != nil {
+				return errors.Wrapf(err, "Failed opening log file: %v", filename)
+			}
+			defer file.Close()
+
+			stat, err := file.Stat()
+			if err != nil {
+				return errors.Wrapf(err, "Failed stat on log file: %v", filename)
+			}
+
+			logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+			if err := store.Put(ctx, logkey, file, stat.Size()); err != nil {
+				return errors.Wrapf(err, "Failed uploading log file: %v", filename)
+			}
+			return nil
+		}(); err != nil {
+			retvalErrs = append(retvalErrs, err)
+		}
+	}
+
+	return folderURL, utilerrors.NewAggregate(retvalErrs)
+}
+
+// pruneObjectStore deletes the objects with the given prefix last modified before cutoff.
+func pruneObjectStore(ctx context.Context, store logObjectStore, prefix string, cutoff time.Time, log log.FieldLogger) error {
+	objects, err := store.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	errs := []error{}
+	for name, lastModified := range objects {
+		if !lastModified.Before(cutoff) {
+			continue
+		}
+		log.WithField("object", name).Info("deleting log past retention")
+		if err := store.Delete(ctx, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
 }
@@ -0,0 +1,213 @@
+package installmanager
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openshift/hive/pkg/azureclient"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/gcpclient"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+// fakeLogStore is an in-memory logObjectStore.
+type fakeLogStore struct {
+	objects map[string][]byte
+	times   map[string]time.Time
+}
+
+func newFakeLogStore() *fakeLogStore {
+	return &fakeLogStore{objects: map[string][]byte{}, times: map[string]time.Time{}}
+}
+
+func (s *fakeLogStore) Put(_ context.Context, name string, body io.Reader, _ int64) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.objects[name] = data
+	s.times[name] = time.Now()
+	return nil
+}
+
+func (s *fakeLogStore) List(_ context.Context, prefix string) (map[string]time.Time, error) {
+	objects := map[string]time.Time{}
+	for name, t := range s.times {
+		if strings.HasPrefix(name, prefix) {
+			objects[name] = t
+		}
+	}
+	return objects, nil
+}
+
+func (s *fakeLogStore) Delete(_ context.Context, name string) error {
+	delete(s.objects, name)
+	delete(s.times, name)
+	return nil
+}
+
+func (s *fakeLogStore) URL(name string) string {
+	return "fake://bucket/" + name
+}
+
+func TestUploadLogsToObjectStores(t *testing.T) {
+	logDir := t.TempDir()
+	logFile := filepath.Join(logDir, "log-bundle.tar.gz")
+	require.NoError(t, os.WriteFile(logFile, []byte("logs"), 0600))
+	folder := "notarealcluster-" + testNamespace
+
+	tests := []struct {
+		name          string
+		provider      string
+		envVars       map[string]string
+		existing      []runtime.Object
+		oldObject     bool
+		expectError   bool
+		expectPruned  bool
+		expectedURL   string
+		buildActuator func(store *fakeLogStore) LogUploaderActuator
+	}{
+		{
+			name:     "azure",
+			provider: constants.InstallLogsUploadProviderAzure,
+			envVars: map[string]string{
+				constants.InstallLogsCredentialsSecretRefEnvVar: "logs-creds",
+				constants.InstallLogsAzureStorageAccountEnvVar:  "account",
+				constants.InstallLogsAzureContainerEnvVar:       "container",
+			},
+			existing:    []runtime.Object{testsecret.Build(testsecret.WithName("logs-creds"), testsecret.WithNamespace(testNamespace))},
+			expectedURL: "fake://bucket/" + folder + "/",
+			buildActuator: func(store *fakeLogStore) LogUploaderActuator {
+				return &azureLogUploaderActuator{blobClientFn: func(*corev1.Secret, string, string, string) (azureclient.BlobClient, error) {
+					return store, nil
+				}}
+			},
+		},
+		{
+			name:     "azure missing container",
+			provider: constants.InstallLogsUploadProviderAzure,
+			envVars: map[string]string{
+				constants.InstallLogsCredentialsSecretRefEnvVar: "logs-creds",
+				constants.InstallLogsAzureStorageAccountEnvVar:  "account",
+			},
+			existing:    []runtime.Object{testsecret.Build(testsecret.WithName("logs-creds"), testsecret.WithNamespace(testNamespace))},
+			expectError: true,
+			buildActuator: func(store *fakeLogStore) LogUploaderActuator {
+				return &azureLogUploaderActuator{blobClientFn: func(*corev1.Secret, string, string, string) (azureclient.BlobClient, error) {
+					return store, nil
+				}}
+			},
+		},
+		{
+			name:     "gcp",
+			provider: constants.InstallLogsUploadProviderGCP,
+			envVars: map[string]string{
+				constants.InstallLogsCredentialsSecretRefEnvVar: "logs-creds",
+				constants.InstallLogsGCSBucketEnvVar:            "bucket",
+			},
+			existing:    []runtime.Object{testsecret.Build(testsecret.WithName("logs-creds"), testsecret.WithNamespace(testNamespace))},
+			expectedURL: "fake://bucket/" + folder + "/",
+			buildActuator: func(store *fakeLogStore) LogUploaderActuator {
+				return &gcsLogUploaderActuator{storageClientFn: func(*corev1.Secret, string) (gcpclient.StorageClient, error) {
+					return store, nil
+				}}
+			},
+		},
+		{
+			name:     "gcp missing secret",
+			provider: constants.InstallLogsUploadProviderGCP,
+			envVars: map[string]string{
+				constants.InstallLogsCredentialsSecretRefEnvVar: "logs-creds",
+				constants.InstallLogsGCSBucketEnvVar:            "bucket",
+			},
+			expectError: true,
+			buildActuator: func(store *fakeLogStore) LogUploaderActuator {
+				return &gcsLogUploaderActuator{storageClientFn: func(*corev1.Secret, string) (gcpclient.StorageClient, error) {
+					return store, nil
+				}}
+			},
+		},
+		{
+			name:     "gcp prune past retention",
+			provider: constants.InstallLogsUploadProviderGCP,
+			envVars: map[string]string{
+				constants.InstallLogsCredentialsSecretRefEnvVar: "logs-creds",
+				constants.InstallLogsGCSBucketEnvVar:            "bucket",
+				constants.InstallLogsRetentionEnvVar:            "24h",
+			},
+			existing:     []runtime.Object{testsecret.Build(testsecret.WithName("logs-creds"), testsecret.WithNamespace(testNamespace))},
+			oldObject:    true,
+			expectPruned: true,
+			expectedURL:  "fake://bucket/" + folder + "/",
+			buildActuator: func(store *fakeLogStore) LogUploaderActuator {
+				return &gcsLogUploaderActuator{storageClientFn: func(*corev1.Secret, string) (gcpclient.StorageClient, error) {
+					return store, nil
+				}}
+			},
+		},
+		{
+			name:     "gcp keep without retention",
+			provider: constants.InstallLogsUploadProviderGCP,
+			envVars: map[string]string{
+				constants.InstallLogsCredentialsSecretRefEnvVar: "logs-creds",
+				constants.InstallLogsGCSBucketEnvVar:            "bucket",
+			},
+			existing:    []runtime.Object{testsecret.Build(testsecret.WithName("logs-creds"), testsecret.WithNamespace(testNamespace))},
+			oldObject:   true,
+			expectedURL: "fake://bucket/" + folder + "/",
+			buildActuator: func(store *fakeLogStore) LogUploaderActuator {
+				return &gcsLogUploaderActuator{storageClientFn: func(*corev1.Secret, string) (gcpclient.StorageClient, error) {
+					return store, nil
+				}}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(constants.InstallLogsUploadProviderEnvVar, test.provider)
+			for name, value := range test.envVars {
+				t.Setenv(name, value)
+			}
+			mocks := setupDefaultMocks(t, test.existing...)
+			store := newFakeLogStore()
+			oldKey := folder + "/old-provision-log-bundle.tar.gz"
+			if test.oldObject {
+				store.objects[oldKey] = []byte("old logs")
+				store.times[oldKey] = time.Now().Add(-48 * time.Hour)
+			}
+			actuator := test.buildActuator(store)
+
+			assert.True(t, actuator.IsConfigured(), "expected actuator to be configured")
+			logsURL, err := actuator.UploadLogs("notarealcluster", testClusterProvision(), mocks.fakeKubeClient, log.New(), logFile)
+
+			if test.expectError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectedURL, logsURL, "unexpected logs URL")
+			assert.Equal(t, []byte("logs"), store.objects[folder+"/"+testProvisionName+"-log-bundle.tar.gz"], "expected log file to be uploaded")
+			_, oldFound := store.objects[oldKey]
+			assert.Equal(t, test.oldObject && !test.expectPruned, oldFound, "unexpected presence of old log")
+		})
+	}
+}
+
+func TestIsLogUploadProvider(t *testing.T) {
+	t.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderAzure)
+	assert.True(t, (&azureLogUploaderActuator{}).IsConfigured(), "expected azure actuator to be configured")
+	assert.False(t, (&gcsLogUploaderActuator{}).IsConfigured(), "expected gcs actuator not to be configured")
+	assert.False(t, (&s3LogUploaderActuator{}).IsConfigured(), "expected s3 actuator not to be configured")
+	assert.False(t, (&inClusterLogUploaderActuator{}).IsConfigured(), "expected in-cluster actuator not to be configured")
+}
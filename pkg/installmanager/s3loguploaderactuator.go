@@ -1,8 +1,11 @@
 package installmanager
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -14,7 +17,6 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 
 	"github.com/pkg/errors"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 // Ensure s3LogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
@@ -26,73 +28,84 @@ type s3LogUploaderActuator struct {
 	awsClientFn func(client.Client, string, string, string, log.FieldLogger) (awsclient.Client, error)
 }
 
-// IsConfigured returns true if the actuator can handle a particular ClusterDeprovision
+// IsConfigured returns true if this log upload provider is configured
 func (a *s3LogUploaderActuator) IsConfigured() bool {
-	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
-	if !foundProviderEnvVar {
-		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
-		return false
-	}
-
-	return provider == constants.InstallLogsUploadProviderAWS
+	return isLogUploadProvider(constants.InstallLogsUploadProviderAWS)
 }
 
 // UploadLogs uploads installer logs to the provider's storage mechanism.
-func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) (string, error) {
 	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
 	if !foundSecretName {
-		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+		return "", errors.New("couldn't find secret name in environment variable. Skipping upload")
 	}
 
 	region, foundRegionEnvVar := os.LookupEnv(constants.InstallLogsAWSRegionEnvVar)
 	if !foundRegionEnvVar {
-		return errors.New("couldn't find region in environment variable. Skipping upload")
+		return "", errors.New("couldn't find region in environment variable. Skipping upload")
 	}
 
 	bucket, foundBucketEnvVar := os.LookupEnv(constants.InstallLogsAWSS3BucketEnvVar)
 	if !foundBucketEnvVar {
-		return errors.New("couldn't find bucket in environment variable. Skipping upload")
+		return "", errors.New("couldn't find bucket in environment variable. Skipping upload")
 	}
 
 	awsc, err := a.awsClientFn(c, secretName, clusterprovision.Namespace, region, log)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	retvalErrs := []error{}
-
 	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
 
-	log.Infof("Uploading log(s) to S3: s3://%v/%v/", bucket, folder)
-
-	for _, filename := range filenames {
-		file, err := os.Open(filename)
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed opening log file: %v", filename))
-			continue
-		}
-		defer file.Close()
-
-		stat, err := file.Stat()
-		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed stat on log file: %v", filename))
-			continue
-		}
+	return uploadLogsToObjectStore(&s3LogStore{awsClient: awsc, bucket: bucket}, folder, clusterprovision, log, filenames...)
+}
 
-		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+// s3LogStore stores logs in an S3 bucket.
+type s3LogStore struct {
+	awsClient awsclient.Client
+	bucket    string
+}
 
-		_, err = awsc.Upload(&s3.PutObjectInput{
-			Bucket: aws.String(bucket),
-			Key:    aws.String(logkey),
-			Body:   file,
-		})
+func (s *s3LogStore) Put(_ context.Context, name string, body io.Reader, _ int64) error {
+	_, err := s.awsClient.Upload(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(name),
+		Body:   body,
+	})
+	return err
+}
 
+func (s *s3LogStore) List(_ context.Context, prefix string) (map[string]time.Time, error) {
+	objects := map[string]time.Time{}
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}
+	for {
+		output, err := s.awsClient.ListObjectsV2(input)
 		if err != nil {
-			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed uploading log file: %v", filename))
+			return nil, err
+		}
+		for _, object := range output.Contents {
+			objects[aws.ToString(object.Key)] = aws.ToTime(object.LastModified)
 		}
+		if !aws.ToBool(output.IsTruncated) {
+			return objects, nil
+		}
+		input.ContinuationToken = output.NextContinuationToken
 	}
+}
+
+func (s *s3LogStore) Delete(_ context.Context, name string) error {
+	_, err := s.awsClient.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(name),
+	})
+	return err
+}
 
-	return utilerrors.NewAggregate(retvalErrs)
+func (s *s3LogStore) URL(name string) string {
+	return fmt.Sprintf("s3://%v/%v", s.bucket, name)
 }
 
 func getAWSClient(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
@@ -3,6 +3,11 @@ package installmanager
 import (
 	"os"
 	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 
 	"github.com/golang/mock/gomock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,6 +32,8 @@ func TestUploadLogs(t *testing.T) {
 		putObjectError          error
 		setupPutObjectMock      bool
 		setupEnvVars            bool
+		retention               string
+		setupPruneMocks         bool
 		expectedUploadLogsError bool
 	}{
 		{
@@ -40,6 +47,14 @@ func TestUploadLogs(t *testing.T) {
 			setupPutObjectMock: true,
 			setupEnvVars:       true,
 		},
+		{
+			name:               "prune objects past retention",
+			existing:           []runtime.Object{},
+			setupPutObjectMock: true,
+			setupEnvVars:       true,
+			retention:          "24h",
+			setupPruneMocks:    true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -55,6 +70,22 @@ func TestUploadLogs(t *testing.T) {
 				os.Setenv(constants.InstallLogsAWSRegionEnvVar, "region1")
 				os.Setenv(constants.InstallLogsAWSS3BucketEnvVar, "bucket1")
 			}
+			if test.retention != "" {
+				os.Setenv(constants.InstallLogsRetentionEnvVar, test.retention)
+			}
+			if test.setupPruneMocks {
+				mocks.mockAWSClient.EXPECT().
+					ListObjectsV2(gomock.Any()).
+					Return(&s3.ListObjectsV2Output{
+						Contents: []s3types.Object{
+							{Key: aws.String("notarealcluster-test-namespace/old-issue"), LastModified: aws.Time(time.Now().Add(-48 * time.Hour))},
+							{Key: aws.String("notarealcluster-test-namespace/recent-issue"), LastModified: aws.Time(time.Now().Add(-time.Hour))},
+						},
+					}, nil)
+				mocks.mockAWSClient.EXPECT().
+					DeleteObject(&s3.DeleteObjectInput{Bucket: aws.String("bucket1"), Key: aws.String("notarealcluster-test-namespace/old-issue")}).
+					Return(&s3.DeleteObjectOutput{}, nil)
+			}
 			if test.setupPutObjectMock {
 				mocks.mockAWSClient.EXPECT().
 					Upload(gomock.Any()).
@@ -67,13 +98,14 @@ func TestUploadLogs(t *testing.T) {
 			provision := testClusterProvision()
 
 			// Act
-			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
+			logsURL, err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
 
 			// Assert
 			if test.expectedUploadLogsError {
 				assert.Error(t, err, "Function didn't error as expected")
 			} else {
 				assert.NoError(t, err, "Function errored unexpectedly")
+				assert.Equal(t, "s3://bucket1/notarealcluster-"+provision.Namespace+"/", logsURL, "unexpected logs URL")
 			}
 
 			if test.setupEnvVars {
@@ -82,6 +114,7 @@ func TestUploadLogs(t *testing.T) {
 				os.Unsetenv(constants.InstallLogsAWSRegionEnvVar)
 				os.Unsetenv(constants.InstallLogsAWSS3BucketEnvVar)
 			}
+			os.Unsetenv(constants.InstallLogsRetentionEnvVar)
 		})
 	}
 }
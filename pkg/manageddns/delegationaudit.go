@@ -0,0 +1,407 @@
+package manageddns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// DefaultDelegationAuditInterval is how often delegations are audited when the configuration does not say.
+	DefaultDelegationAuditInterval = 2 * time.Hour
+
+	// ZoneCheckDNSServersEnvVar is a comma separated list of the resolvers used to check zones, overriding the
+	// resolvers of the system.
+	ZoneCheckDNSServersEnvVar = "ZONE_CHECK_DNS_SERVERS"
+
+	resolverConfigFile = "/etc/resolv.conf"
+	auditQueryTimeout  = 10 * time.Second
+)
+
+// ReadDelegationAuditConfigFile reads the DNS delegation audit configuration from the file named by the environment.
+// It returns nil if the environment variable is unset, the file does not exist or audits are not configured.
+func ReadDelegationAuditConfigFile() (*hivev1.DNSDelegationAuditConfig, error) {
+	fPath := os.Getenv(constants.DNSDelegationAuditConfigFileEnvVar)
+	if len(fPath) == 0 {
+		return nil, nil
+	}
+
+	fileBytes, err := os.ReadFile(fPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the DNS delegation audit config file")
+	}
+	config := &hivev1.DNSDelegationAuditConfig{}
+	if err := json.Unmarshal(fileBytes, &config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the DNS delegation audit config file")
+	}
+	return config, nil
+}
+
+// DelegationAuditInterval returns how often delegations are audited with the given configuration.
+func DelegationAuditInterval(config *hivev1.DNSDelegationAuditConfig) time.Duration {
+	if config != nil && config.Interval != nil && config.Interval.Duration > 0 {
+		return config.Interval.Duration
+	}
+	return DefaultDelegationAuditInterval
+}
+
+// DefaultResolvers returns the resolvers listed in the ZoneCheckDNSServersEnvVar environment variable or, if unset,
+// the resolvers of the system. Every resolver includes a port.
+func DefaultResolvers() ([]string, error) {
+	if serversFromEnv := os.Getenv(ZoneCheckDNSServersEnvVar); len(serversFromEnv) > 0 {
+		return withDefaultPort(strings.Split(serversFromEnv, ",")), nil
+	}
+	clientConfig, err := dns.ClientConfigFromFile(resolverConfigFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the resolver configuration")
+	}
+	servers := make([]string, len(clientConfig.Servers))
+	for i, s := range clientConfig.Servers {
+		servers[i] = net.JoinHostPort(s, clientConfig.Port)
+	}
+	return servers, nil
+}
+
+// withDefaultPort adds the DNS port to servers with unspecified port.
+func withDefaultPort(servers []string) []string {
+	result := make([]string, len(servers))
+	for i, s := range servers {
+		s = strings.TrimSpace(s)
+		if _, _, err := net.SplitHostPort(s); err != nil {
+			s = net.JoinHostPort(s, "53")
+		}
+		result[i] = s
+	}
+	return result
+}
+
+// DelegationReport is the result of auditing the delegation of a zone.
+type DelegationReport struct {
+	// Zone is the audited zone.
+	Zone string `json:"zone"`
+	// ParentZone is the zone containing the delegation of the audited zone.
+	ParentZone string `json:"parentZone"`
+	// DelegatedNameServers are the name servers the parent zone delegates the zone to.
+	DelegatedNameServers []string `json:"delegatedNameServers,omitempty"`
+	// ApexNameServers are the NS records served at the apex of the zone by the delegated name servers.
+	ApexNameServers []string `json:"apexNameServers,omitempty"`
+	// SOASerials are the serials of the SOA record of the zone, keyed by the delegated name server serving them.
+	SOASerials map[string]uint32 `json:"soaSerials,omitempty"`
+	// Problems describe every inconsistency found by the audit.
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Verified returns whether the audit found no problems with the delegation.
+func (r *DelegationReport) Verified() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *DelegationReport) addProblem(format string, args ...any) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+// DelegationAuditor audits the delegation of zones by querying the authoritative name servers of the zones and their
+// parents directly. Recursive resolvers are only used to find those name servers and their addresses.
+type DelegationAuditor struct {
+	resolvers []string
+	exchange  func(m *dns.Msg, server string) (*dns.Msg, error)
+}
+
+// NewDelegationAuditor returns a DelegationAuditor using the given resolvers, or the default resolvers if none are
+// given.
+func NewDelegationAuditor(resolvers []string) (*DelegationAuditor, error) {
+	if len(resolvers) == 0 {
+		var err error
+		if resolvers, err = DefaultResolvers(); err != nil {
+			return nil, err
+		}
+	}
+	if len(resolvers) == 0 {
+		return nil, errors.New("no resolvers configured")
+	}
+	return &DelegationAuditor{
+		resolvers: withDefaultPort(resolvers),
+		exchange:  exchange,
+	}, nil
+}
+
+// exchange sends a query over UDP, retrying over TCP if the response was truncated.
+func exchange(m *dns.Msg, server string) (*dns.Msg, error) {
+	c := &dns.Client{Timeout: auditQueryTimeout}
+	resp, _, err := c.Exchange(m, server)
+	if err == nil && resp.Truncated {
+		c.Net = "tcp"
+		resp, _, err = c.Exchange(m, server)
+	}
+	return resp, err
+}
+
+// AuditDelegation checks that the parent zone delegates the zone to the expected name servers, and that every
+// delegated name server serves the zone authoritatively with the same SOA serial and NS records matching the
+// delegation. An error is returned if the audit could not be carried out, such as when the resolvers fail.
+func (a *DelegationAuditor) AuditDelegation(zone string, expectedNameServers []string) (*DelegationReport, error) {
+	fqdn := dns.Fqdn(strings.ToLower(zone))
+	report := &DelegationReport{
+		Zone:       undotted(fqdn),
+		SOASerials: map[string]uint32{},
+	}
+
+	parent, err := a.parentZone(fqdn)
+	if err != nil {
+		return nil, err
+	}
+	report.ParentZone = undotted(parent)
+	parentServers, err := a.nameServers(parent)
+	if err != nil {
+		return nil, err
+	}
+	if len(parentServers) == 0 {
+		return nil, errors.Errorf("no name servers found for parent zone %s", report.ParentZone)
+	}
+
+	// Every name server of the parent zone must return the same delegation.
+	var delegated sets.Set[string]
+	for _, server := range parentServers {
+		m := &dns.Msg{}
+		m.SetQuestion(fqdn, dns.TypeNS)
+		m.RecursionDesired = false
+		resp, err := a.queryServer(server, m)
+		if err != nil {
+			if isResolverError(err) {
+				return nil, err
+			}
+			report.addProblem("parent name server %s did not answer: %v", server, err)
+			continue
+		}
+		ns := nsRecords(resp, fqdn)
+		if delegated == nil {
+			delegated = ns
+		} else if !ns.Equal(delegated) {
+			report.addProblem("parent name server %s delegates to [%s] instead of [%s]",
+				server, strings.Join(sets.List(ns), ", "), strings.Join(sets.List(delegated), ", "))
+		}
+	}
+	report.DelegatedNameServers = sets.List(delegated)
+
+	expected := sets.New[string]()
+	for _, ns := range expectedNameServers {
+		expected.Insert(undotted(strings.ToLower(ns)))
+	}
+	switch {
+	case len(delegated) == 0:
+		report.addProblem("parent zone %s has no delegation for the zone", report.ParentZone)
+	case !delegated.Equal(expected):
+		report.addProblem("parent zone %s delegates to [%s] instead of [%s]",
+			report.ParentZone, strings.Join(sets.List(delegated), ", "), strings.Join(sets.List(expected), ", "))
+	}
+
+	// Every delegated name server must serve the zone authoritatively and consistently.
+	apexChecked := false
+	for _, server := range sets.List(delegated) {
+		serial, apexNS, err := a.checkAuthoritative(fqdn, server)
+		if err != nil {
+			if isResolverError(err) {
+				return nil, err
+			}
+			report.addProblem("name server %s: %v", server, err)
+			continue
+		}
+		report.SOASerials[server] = serial
+		if !apexChecked {
+			apexChecked = true
+			report.ApexNameServers = sets.List(apexNS)
+			if !apexNS.Equal(delegated) {
+				report.addProblem("NS records at the zone apex [%s] do not match the delegation [%s]",
+					strings.Join(report.ApexNameServers, ", "), strings.Join(report.DelegatedNameServers, ", "))
+			}
+		}
+	}
+	serials := sets.New[uint32]()
+	for _, serial := range report.SOASerials {
+		serials.Insert(serial)
+	}
+	if len(serials) > 1 {
+		servers := make([]string, 0, len(report.SOASerials))
+		for server, serial := range report.SOASerials {
+			servers = append(servers, fmt.Sprintf("%s=%d", server, serial))
+		}
+		sort.Strings(servers)
+		report.addProblem("name servers serve different SOA serials: %s", strings.Join(servers, ", "))
+	}
+
+	return report, nil
+}
+
+// IsLameDelegation returns whether none of the given name servers serve the zone authoritatively, meaning a
+// delegation to them is dangling. An error is returned if the resolvers fail.
+func (a *DelegationAuditor) IsLameDelegation(zone string, nameServers []string) (bool, error) {
+	fqdn := dns.Fqdn(strings.ToLower(zone))
+	for _, server := range nameServers {
+		_, _, err := a.checkAuthoritative(fqdn, undotted(strings.ToLower(server)))
+		if err == nil {
+			return false, nil
+		}
+		if isResolverError(err) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// checkAuthoritative checks that the name server answers authoritatively for the zone, returning the serial of the
+// SOA record and the NS records at the apex of the zone.
+func (a *DelegationAuditor) checkAuthoritative(fqdn, server string) (uint32, sets.Set[string], error) {
+	m := &dns.Msg{}
+	m.SetQuestion(fqdn, dns.TypeSOA)
+	m.RecursionDesired = false
+	resp, err := a.queryServer(server, m)
+	if err != nil {
+		return 0, nil, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return 0, nil, errors.Errorf("answered %s", dns.RcodeToString[resp.Rcode])
+	}
+	if !resp.Authoritative {
+		return 0, nil, errors.New("is not authoritative for the zone")
+	}
+	var soa *dns.SOA
+	for _, rr := range resp.Answer {
+		if s, ok := rr.(*dns.SOA); ok && strings.EqualFold(s.Hdr.Name, fqdn) {
+			soa = s
+			break
+		}
+	}
+	if soa == nil {
+		return 0, nil, errors.New("did not return the SOA record of the zone")
+	}
+
+	m = &dns.Msg{}
+	m.SetQuestion(fqdn, dns.TypeNS)
+	m.RecursionDesired = false
+	resp, err = a.queryServer(server, m)
+	if err != nil {
+		return 0, nil, err
+	}
+	return soa.Serial, nsRecords(resp, fqdn), nil
+}
+
+// parentZone returns the zone containing the delegation of the zone, as found by the resolvers.
+func (a *DelegationAuditor) parentZone(fqdn string) (string, error) {
+	labels := dns.SplitDomainName(fqdn)
+	if len(labels) < 2 {
+		return "", errors.Errorf("zone %s has no parent domain", fqdn)
+	}
+	parent := dns.Fqdn(strings.Join(labels[1:], "."))
+	m := &dns.Msg{}
+	m.SetQuestion(parent, dns.TypeSOA)
+	resp, err := a.resolve(m)
+	if err != nil {
+		return "", err
+	}
+	// The SOA record is in the answer when the name is the apex of a zone, and in the authority section otherwise.
+	for _, rr := range append(resp.Answer, resp.Ns...) {
+		if soa, ok := rr.(*dns.SOA); ok && dns.IsSubDomain(soa.Hdr.Name, parent) {
+			return strings.ToLower(soa.Hdr.Name), nil
+		}
+	}
+	return "", errors.Errorf("could not find the zone of parent domain %s", undotted(parent))
+}
+
+// nameServers returns the name servers of the zone, as found by the resolvers.
+func (a *DelegationAuditor) nameServers(fqdn string) ([]string, error) {
+	m := &dns.Msg{}
+	m.SetQuestion(fqdn, dns.TypeNS)
+	resp, err := a.resolve(m)
+	if err != nil {
+		return nil, err
+	}
+	return sets.List(nsRecords(resp, fqdn)), nil
+}
+
+// queryServer sends the query to the name server, looking up its address with the resolvers.
+func (a *DelegationAuditor) queryServer(server string, m *dns.Msg) (*dns.Msg, error) {
+	address, err := a.address(server)
+	if err != nil {
+		return nil, err
+	}
+	return a.exchange(m, net.JoinHostPort(address, "53"))
+}
+
+// address returns an address of the name server, as found by the resolvers.
+func (a *DelegationAuditor) address(server string) (string, error) {
+	if ip := net.ParseIP(server); ip != nil {
+		return server, nil
+	}
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		m := &dns.Msg{}
+		m.SetQuestion(dns.Fqdn(server), qtype)
+		resp, err := a.resolve(m)
+		if err != nil {
+			return "", err
+		}
+		for _, rr := range resp.Answer {
+			switch r := rr.(type) {
+			case *dns.A:
+				return r.A.String(), nil
+			case *dns.AAAA:
+				return r.AAAA.String(), nil
+			}
+		}
+	}
+	return "", errors.Errorf("could not find an address for %s", server)
+}
+
+// resolverError is returned when none of the resolvers answer a query.
+type resolverError struct {
+	error
+}
+
+func isResolverError(err error) bool {
+	_, ok := err.(resolverError)
+	return ok
+}
+
+// resolve sends the query to the resolvers in turn until one of them answers.
+func (a *DelegationAuditor) resolve(m *dns.Msg) (*dns.Msg, error) {
+	m.RecursionDesired = true
+	var lastErr error
+	for _, resolver := range a.resolvers {
+		resp, err := a.exchange(m, resolver)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, resolverError{errors.Wrap(lastErr, "no resolver answered")}
+}
+
+// nsRecords returns the undotted, lower case targets of the NS records of the zone in the answer or authority
+// section of the response.
+func nsRecords(resp *dns.Msg, fqdn string) sets.Set[string] {
+	result := sets.New[string]()
+	for _, rr := range append(resp.Answer, resp.Ns...) {
+		if ns, ok := rr.(*dns.NS); ok && strings.EqualFold(ns.Hdr.Name, fqdn) {
+			result.Insert(undotted(strings.ToLower(ns.Ns)))
+		}
+	}
+	return result
+}
+
+func undotted(domain string) string {
+	return strings.TrimSuffix(domain, ".")
+}
@@ -0,0 +1,231 @@
+package manageddns
+
+import (
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResolver = "10.0.0.1:53"
+
+// fakeZone is a zone served by a fake name server.
+type fakeZone struct {
+	serial      uint32
+	nameServers []string
+	// delegations are the NS records of the subdomains delegated by the zone
+	delegations map[string][]string
+}
+
+// fakeDNS answers queries for a resolver and a set of authoritative name servers.
+type fakeDNS struct {
+	// addresses are the addresses of the name servers
+	addresses map[string]string
+	// zones are the zones served by each name server address
+	zones map[string]map[string]*fakeZone
+}
+
+func (f *fakeDNS) exchange(m *dns.Msg, server string) (*dns.Msg, error) {
+	q := m.Question[0]
+	resp := &dns.Msg{}
+	resp.SetReply(m)
+	if server == testResolver {
+		return f.resolve(resp, q), nil
+	}
+	host, _, _ := net.SplitHostPort(server)
+	zones, ok := f.zones[host]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	for name, zone := range zones {
+		if delegation, ok := zone.delegations[q.Name]; ok {
+			resp.Ns = nsRRs(q.Name, delegation)
+			return resp, nil
+		}
+		if q.Name != name {
+			continue
+		}
+		resp.Authoritative = true
+		switch q.Qtype {
+		case dns.TypeSOA:
+			resp.Answer = []dns.RR{soaRR(name, zone.serial)}
+		case dns.TypeNS:
+			resp.Answer = nsRRs(name, zone.nameServers)
+		}
+		return resp, nil
+	}
+	resp.Rcode = dns.RcodeRefused
+	return resp, nil
+}
+
+// resolve answers like a recursive resolver, using the first name server serving a zone.
+func (f *fakeDNS) resolve(resp *dns.Msg, q dns.Question) *dns.Msg {
+	switch q.Qtype {
+	case dns.TypeA:
+		if address, ok := f.addresses[q.Name]; ok {
+			resp.Answer = []dns.RR{&dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET}, A: net.ParseIP(address)}}
+		}
+		return resp
+	case dns.TypeAAAA:
+		return resp
+	}
+	for _, zones := range f.zones {
+		for name, zone := range zones {
+			if !dns.IsSubDomain(name, q.Name) {
+				continue
+			}
+			if _, delegated := zone.delegations[q.Name]; delegated {
+				continue
+			}
+			switch {
+			case q.Name == name && q.Qtype == dns.TypeSOA:
+				resp.Answer = []dns.RR{soaRR(name, zone.serial)}
+			case q.Name == name && q.Qtype == dns.TypeNS:
+				resp.Answer = nsRRs(name, zone.nameServers)
+			default:
+				resp.Ns = []dns.RR{soaRR(name, zone.serial)}
+			}
+			return resp
+		}
+	}
+	resp.Rcode = dns.RcodeNameError
+	return resp
+}
+
+func soaRR(name string, serial uint32) dns.RR {
+	return &dns.SOA{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeSOA, Class: dns.ClassINET}, Serial: serial}
+}
+
+func nsRRs(name string, nameServers []string) []dns.RR {
+	var rrs []dns.RR
+	for _, ns := range nameServers {
+		rrs = append(rrs, &dns.NS{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeNS, Class: dns.ClassINET}, Ns: dns.Fqdn(ns)})
+	}
+	return rrs
+}
+
+// newFakeDNS returns a parent zone example.com delegating cluster.example.com to two name servers.
+func newFakeDNS() *fakeDNS {
+	parent := &fakeZone{
+		serial:      1,
+		nameServers: []string{"ns1.example.com"},
+		delegations: map[string][]string{
+			"cluster.example.com.": {"ns-a.cloud.net", "ns-b.cloud.net"},
+		},
+	}
+	child := func() *fakeZone {
+		return &fakeZone{serial: 7, nameServers: []string{"ns-a.cloud.net", "ns-b.cloud.net"}}
+	}
+	return &fakeDNS{
+		addresses: map[string]string{
+			"ns1.example.com.": "10.1.0.1",
+			"ns-a.cloud.net.":  "10.2.0.1",
+			"ns-b.cloud.net.":  "10.2.0.2",
+		},
+		zones: map[string]map[string]*fakeZone{
+			"10.1.0.1": {"example.com.": parent},
+			"10.2.0.1": {"cluster.example.com.": child()},
+			"10.2.0.2": {"cluster.example.com.": child()},
+		},
+	}
+}
+
+func TestAuditDelegation(t *testing.T) {
+	cases := []struct {
+		name             string
+		modify           func(*fakeDNS)
+		expected         []string
+		expectVerified   bool
+		expectedProblems []string
+	}{
+		{
+			name:           "verified",
+			expected:       []string{"ns-a.cloud.net.", "NS-B.cloud.net"},
+			expectVerified: true,
+		},
+		{
+			name:     "delegation does not match status",
+			expected: []string{"ns-a.cloud.net", "ns-c.cloud.net"},
+			expectedProblems: []string{
+				"parent zone example.com delegates to [ns-a.cloud.net, ns-b.cloud.net] instead of [ns-a.cloud.net, ns-c.cloud.net]",
+			},
+		},
+		{
+			name:     "missing delegation",
+			expected: []string{"ns-a.cloud.net", "ns-b.cloud.net"},
+			modify: func(f *fakeDNS) {
+				delete(f.zones["10.1.0.1"]["example.com."].delegations, "cluster.example.com.")
+			},
+			expectedProblems: []string{"parent zone example.com has no delegation for the zone"},
+		},
+		{
+			name:     "lame name server",
+			expected: []string{"ns-a.cloud.net", "ns-b.cloud.net"},
+			modify: func(f *fakeDNS) {
+				delete(f.zones, "10.2.0.2")
+			},
+			expectedProblems: []string{"name server ns-b.cloud.net: connection refused"},
+		},
+		{
+			name:     "inconsistent serials and apex records",
+			expected: []string{"ns-a.cloud.net", "ns-b.cloud.net"},
+			modify: func(f *fakeDNS) {
+				zone := f.zones["10.2.0.1"]["cluster.example.com."]
+				zone.serial = 6
+				zone.nameServers = []string{"ns-a.cloud.net"}
+			},
+			expectedProblems: []string{
+				"NS records at the zone apex [ns-a.cloud.net] do not match the delegation [ns-a.cloud.net, ns-b.cloud.net]",
+				"name servers serve different SOA serials: ns-a.cloud.net=6, ns-b.cloud.net=7",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeDNS()
+			if tc.modify != nil {
+				tc.modify(fake)
+			}
+			auditor := &DelegationAuditor{resolvers: []string{testResolver}, exchange: fake.exchange}
+
+			report, err := auditor.AuditDelegation("cluster.example.com", tc.expected)
+			require.NoError(t, err, "unexpected error auditing delegation")
+			assert.Equal(t, "example.com", report.ParentZone, "unexpected parent zone")
+			assert.Equal(t, tc.expectVerified, report.Verified(), "unexpected verification result")
+			assert.Equal(t, tc.expectedProblems, report.Problems, "unexpected problems")
+		})
+	}
+}
+
+func TestAuditDelegationResolverFailure(t *testing.T) {
+	auditor := &DelegationAuditor{
+		resolvers: []string{testResolver},
+		exchange: func(*dns.Msg, string) (*dns.Msg, error) {
+			return nil, errors.New("timeout")
+		},
+	}
+	_, err := auditor.AuditDelegation("cluster.example.com", []string{"ns-a.cloud.net"})
+	assert.Error(t, err, "expected error when resolvers fail")
+}
+
+func TestIsLameDelegation(t *testing.T) {
+	fake := newFakeDNS()
+	auditor := &DelegationAuditor{resolvers: []string{testResolver}, exchange: fake.exchange}
+
+	lame, err := auditor.IsLameDelegation("cluster.example.com", []string{"ns-a.cloud.net", "ns-b.cloud.net"})
+	require.NoError(t, err, "unexpected error")
+	assert.False(t, lame, "expected served delegation not to be lame")
+
+	lame, err = auditor.IsLameDelegation("deleted.example.com", []string{"ns-a.cloud.net", "ns-b.cloud.net"})
+	require.NoError(t, err, "unexpected error")
+	assert.True(t, lame, "expected delegation to name servers not serving the zone to be lame")
+}
+
+func TestNewDelegationAuditorAddsPorts(t *testing.T) {
+	auditor, err := NewDelegationAuditor([]string{"10.0.0.1", "10.0.0.2:5353", "fd00::1"})
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, []string{"10.0.0.1:53", "10.0.0.2:5353", "[fd00::1]:53"}, auditor.resolvers, "unexpected resolvers")
+}
@@ -3,6 +3,7 @@ package manageddns
 import (
 	"encoding/json"
 	"os"
+	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -28,3 +29,21 @@ func ReadManagedDomainsFile() ([]hivev1.ManageDNSConfig, error) {
 
 	return domains, nil
 }
+
+// FindManagedDomain returns the managed domain configuration for the given domain, along with the
+// managed domain that the given domain is a subdomain of. If the domain is a subdomain of more than
+// one managed domain, the most specific one is returned. Returns nil if the domain is not a subdomain
+// of any managed domain.
+func FindManagedDomain(managedDomains []hivev1.ManageDNSConfig, domain string) (*hivev1.ManageDNSConfig, string) {
+	var found *hivev1.ManageDNSConfig
+	var foundDomain string
+	for i, md := range managedDomains {
+		for _, d := range md.Domains {
+			if strings.HasSuffix(domain, "."+d) && len(d) > len(foundDomain) {
+				found = &managedDomains[i]
+				foundDomain = d
+			}
+		}
+	}
+	return found, foundDomain
+}
@@ -0,0 +1,159 @@
+package nutanixclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	nutanixclient "github.com/nutanix-cloud-native/prism-go-client"
+	nutanixclientv3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+const (
+	// PowerStateOn is the power state of a running VM.
+	PowerStateOn = "ON"
+	// PowerStateOff is the power state of a stopped VM.
+	PowerStateOff = "OFF"
+
+	// powerOffMechanism asks the guest OS to shut down rather than cutting the power.
+	powerOffMechanism = "ACPI"
+)
+
+// API represents the calls made to the Nutanix Prism Central API.
+type API interface {
+	// GetCurrentLoggedInUser returns the user the client is authenticated as.
+	GetCurrentLoggedInUser(ctx context.Context) (*nutanixclientv3.UserIntentResponse, error)
+	// ListVMs returns the VMs with the given category value.
+	ListVMs(ctx context.Context, categoryKey, categoryValue string) ([]*nutanixclientv3.VMIntentResource, error)
+	// PowerOnVMs powers on the given VMs.
+	PowerOnVMs(ctx context.Context, vms []*nutanixclientv3.VMIntentResource) error
+	// PowerOffVMs shuts down the given VMs.
+	PowerOffVMs(ctx context.Context, vms []*nutanixclientv3.VMIntentResource) error
+}
+
+// Client makes calls to the Nutanix Prism Central API.
+type Client struct {
+	v3 nutanixclientv3.Service
+}
+
+// NewClient creates a client for the Prism Central at the given address and port. certBundle is an
+// optional PEM encoded bundle of certificates to trust when connecting to Prism Central.
+func NewClient(address string, port int32, username, password string, certBundle []byte) (*Client, error) {
+	portStr := strconv.Itoa(int(port))
+	creds := nutanixclient.Credentials{
+		URL:      fmt.Sprintf("%s:%s", address, portStr),
+		Username: username,
+		Password: password,
+		Port:     portStr,
+		Endpoint: address,
+	}
+	var opts []nutanixclientv3.ClientOption
+	if len(certBundle) > 0 {
+		opts = append(opts, nutanixclientv3.WithPEMEncodedCertBundle(certBundle))
+	}
+	c, err := nutanixclientv3.NewV3Client(creds, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Nutanix client")
+	}
+	return &Client{v3: c.V3}, nil
+}
+
+// NewClientFromSecret creates a client for the given Prism Central using the username and password in the
+// credentials secret and, if provided, the CA certificates in the certificates secret.
+func NewClientFromSecret(address string, port int32, credsSecret, certsSecret *corev1.Secret) (*Client, error) {
+	username := string(credsSecret.Data[constants.UsernameSecretKey])
+	password := string(credsSecret.Data[constants.PasswordSecretKey])
+	if username == "" || password == "" {
+		return nil, errors.Errorf("creds secret does not contain %q and %q data", constants.UsernameSecretKey, constants.PasswordSecretKey)
+	}
+	var certBundle []byte
+	if certsSecret != nil {
+		for _, cert := range certsSecret.Data {
+			certBundle = append(certBundle, cert...)
+			certBundle = append(certBundle, '\n')
+		}
+	}
+	return NewClient(address, port, username, password, certBundle)
+}
+
+// GetCurrentLoggedInUser returns the user the client is authenticated as.
+func (c *Client) GetCurrentLoggedInUser(ctx context.Context) (*nutanixclientv3.UserIntentResponse, error) {
+	user, err := c.v3.GetCurrentLoggedInUser(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current user")
+	}
+	return user, nil
+}
+
+// ListVMs returns the VMs with the given category value.
+func (c *Client) ListVMs(ctx context.Context, categoryKey, categoryValue string) ([]*nutanixclientv3.VMIntentResource, error) {
+	allVMs, err := c.v3.ListAllVM(ctx, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list VMs")
+	}
+	var result []*nutanixclientv3.VMIntentResource
+	for _, vm := range allVMs.Entities {
+		if vm.Metadata != nil && vm.Metadata.Categories[categoryKey] == categoryValue {
+			result = append(result, vm)
+		}
+	}
+	return result, nil
+}
+
+// PowerOnVMs powers on the given VMs.
+func (c *Client) PowerOnVMs(ctx context.Context, vms []*nutanixclientv3.VMIntentResource) error {
+	var errs []error
+	for _, vm := range vms {
+		if err := c.setPowerState(ctx, vm, PowerStateOn); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// PowerOffVMs shuts down the given VMs.
+func (c *Client) PowerOffVMs(ctx context.Context, vms []*nutanixclientv3.VMIntentResource) error {
+	var errs []error
+	for _, vm := range vms {
+		if err := c.setPowerState(ctx, vm, PowerStateOff); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *Client) setPowerState(ctx context.Context, vm *nutanixclientv3.VMIntentResource, powerState string) error {
+	uuid := ptr.Deref(vm.Metadata.UUID, "")
+	// Updates must carry the current spec_version, so fetch the latest intent rather than reusing the listed one.
+	current, err := c.v3.GetVM(ctx, uuid)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get VM %s", uuid)
+	}
+	if current.Spec == nil || current.Spec.Resources == nil {
+		return errors.Errorf("VM %s has no resources in its spec", uuid)
+	}
+	current.Spec.Resources.PowerState = ptr.To(powerState)
+	if powerState == PowerStateOff {
+		current.Spec.Resources.PowerStateMechanism = &nutanixclientv3.VMPowerStateMechanism{
+			Mechanism: ptr.To(powerOffMechanism),
+		}
+	}
+	_, err = c.v3.UpdateVM(ctx, uuid, &nutanixclientv3.VMIntentInput{
+		APIVersion: current.APIVersion,
+		Metadata:   current.Metadata,
+		Spec:       current.Spec,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to set power state of VM %s to %s", uuid, powerState)
+	}
+	return nil
+}
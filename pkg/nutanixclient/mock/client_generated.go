@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentLoggedInUser mocks base method.
+func (m *MockAPI) GetCurrentLoggedInUser(ctx context.Context) (*v3.UserIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentLoggedInUser", ctx)
+	ret0, _ := ret[0].(*v3.UserIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentLoggedInUser indicates an expected call of GetCurrentLoggedInUser.
+func (mr *MockAPIMockRecorder) GetCurrentLoggedInUser(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentLoggedInUser", reflect.TypeOf((*MockAPI)(nil).GetCurrentLoggedInUser), ctx)
+}
+
+// ListVMs mocks base method.
+func (m *MockAPI) ListVMs(ctx context.Context, categoryKey, categoryValue string) ([]*v3.VMIntentResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVMs", ctx, categoryKey, categoryValue)
+	ret0, _ := ret[0].([]*v3.VMIntentResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVMs indicates an expected call of ListVMs.
+func (mr *MockAPIMockRecorder) ListVMs(ctx, categoryKey, categoryValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVMs", reflect.TypeOf((*MockAPI)(nil).ListVMs), ctx, categoryKey, categoryValue)
+}
+
+// PowerOffVMs mocks base method.
+func (m *MockAPI) PowerOffVMs(ctx context.Context, vms []*v3.VMIntentResource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOffVMs", ctx, vms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOffVMs indicates an expected call of PowerOffVMs.
+func (mr *MockAPIMockRecorder) PowerOffVMs(ctx, vms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOffVMs", reflect.TypeOf((*MockAPI)(nil).PowerOffVMs), ctx, vms)
+}
+
+// PowerOnVMs mocks base method.
+func (m *MockAPI) PowerOnVMs(ctx context.Context, vms []*v3.VMIntentResource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOnVMs", ctx, vms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOnVMs indicates an expected call of PowerOnVMs.
+func (mr *MockAPIMockRecorder) PowerOnVMs(ctx, vms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOnVMs", reflect.TypeOf((*MockAPI)(nil).PowerOnVMs), ctx, vms)
+}
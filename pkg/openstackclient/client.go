@@ -0,0 +1,132 @@
+package openstackclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/gophercloud/utils/v2/openstack/clientconfig"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// API represents the calls made to the OpenStack API.
+type API interface {
+	// ListServers returns the servers whose metadata contains all of the given key/value pairs.
+	ListServers(ctx context.Context, metadata map[string]string) ([]servers.Server, error)
+	// StartServers starts the given servers.
+	StartServers(ctx context.Context, toStart []servers.Server) error
+	// StopServers stops the given servers.
+	StopServers(ctx context.Context, toStop []servers.Server) error
+}
+
+// Client makes calls to the OpenStack API.
+type Client struct {
+	computeClient *gophercloud.ServiceClient
+}
+
+// NewClientFromSecret creates a client for the given cloud using the clouds.yaml in the credentials secret.
+// trustBundle is an optional PEM encoded bundle of certificates to trust when connecting to the cloud.
+func NewClientFromSecret(secret *corev1.Secret, cloud string, trustBundle []byte) (*Client, error) {
+	cloudsYAML, ok := secret.Data[constants.OpenStackCredentialsName]
+	if !ok {
+		return nil, errors.New("creds secret does not contain \"" + constants.OpenStackCredentialsName + "\" data")
+	}
+	var clouds clientconfig.Clouds
+	if err := yaml.Unmarshal(cloudsYAML, &clouds); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal clouds.yaml stored in secret")
+	}
+	if len(trustBundle) > 0 {
+		conf, ok := clouds.Clouds[cloud]
+		if !ok {
+			return nil, errors.Errorf("no cloud %s found", cloud)
+		}
+		conf.CACertFile = string(trustBundle)
+		clouds.Clouds[cloud] = conf
+	}
+
+	computeClient, err := clientconfig.NewServiceClient(context.TODO(), "compute", &clientconfig.ClientOpts{
+		Cloud:    cloud,
+		YAMLOpts: &yamlOpts{clouds: clouds.Clouds},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OpenStack compute client")
+	}
+	return &Client{computeClient: computeClient}, nil
+}
+
+// yamlOpts provides the clouds.yaml loaded from a secret to the OpenStack client config.
+type yamlOpts struct {
+	clouds map[string]clientconfig.Cloud
+}
+
+func (o *yamlOpts) LoadCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return o.clouds, nil
+}
+
+func (o *yamlOpts) LoadSecureCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	// secure.yaml is optional so just pretend it doesn't exist
+	return nil, nil
+}
+
+func (o *yamlOpts) LoadPublicCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return nil, fmt.Errorf("LoadPublicCloudsYAML() not implemented")
+}
+
+// ListServers returns the servers whose metadata contains all of the given key/value pairs.
+func (c *Client) ListServers(ctx context.Context, metadata map[string]string) ([]servers.Server, error) {
+	allPages, err := servers.List(c.computeClient, servers.ListOpts{}).AllPages(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list servers")
+	}
+	allServers, err := servers.ExtractServers(allPages)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract servers")
+	}
+	var result []servers.Server
+	for _, s := range allServers {
+		if hasMetadata(s, metadata) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func hasMetadata(s servers.Server, metadata map[string]string) bool {
+	for k, v := range metadata {
+		if s.Metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// StartServers starts the given servers.
+func (c *Client) StartServers(ctx context.Context, toStart []servers.Server) error {
+	var errs []error
+	for _, s := range toStart {
+		if err := servers.Start(ctx, c.computeClient, s.ID).ExtractErr(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to start server %s", s.Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// StopServers stops the given servers.
+func (c *Client) StopServers(ctx context.Context, toStop []servers.Server) error {
+	var errs []error
+	for _, s := range toStop {
+		if err := servers.Stop(ctx, c.computeClient, s.ID).ExtractErr(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to stop server %s", s.Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
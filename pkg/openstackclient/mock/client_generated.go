@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	servers "github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+)
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// ListServers mocks base method.
+func (m *MockAPI) ListServers(ctx context.Context, metadata map[string]string) ([]servers.Server, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServers", ctx, metadata)
+	ret0, _ := ret[0].([]servers.Server)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServers indicates an expected call of ListServers.
+func (mr *MockAPIMockRecorder) ListServers(ctx, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServers", reflect.TypeOf((*MockAPI)(nil).ListServers), ctx, metadata)
+}
+
+// StartServers mocks base method.
+func (m *MockAPI) StartServers(ctx context.Context, toStart []servers.Server) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartServers", ctx, toStart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartServers indicates an expected call of StartServers.
+func (mr *MockAPIMockRecorder) StartServers(ctx, toStart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartServers", reflect.TypeOf((*MockAPI)(nil).StartServers), ctx, toStart)
+}
+
+// StopServers mocks base method.
+func (m *MockAPI) StopServers(ctx context.Context, toStop []servers.Server) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopServers", ctx, toStop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopServers indicates an expected call of StopServers.
+func (mr *MockAPIMockRecorder) StopServers(ctx, toStop interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopServers", reflect.TypeOf((*MockAPI)(nil).StopServers), ctx, toStop)
+}
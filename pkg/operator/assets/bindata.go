@@ -3,6 +3,7 @@
 // config/sharded_controllers/service.yaml
 // config/sharded_controllers/statefulset.yaml
 // config/hiveadmission/apiservice.yaml
+// config/hiveadmission/clusterclaim-webhook.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
@@ -10,6 +11,7 @@
 // config/hiveadmission/dnszones-webhook.yaml
 // config/hiveadmission/hiveadmission_rbac_role.yaml
 // config/hiveadmission/hiveadmission_rbac_role_binding.yaml
+// config/hiveadmission/hubfederation-webhook.yaml
 // config/hiveadmission/machinepool-webhook.yaml
 // config/hiveadmission/sa-token-secret.yaml
 // config/hiveadmission/selectorsyncset-webhook.yaml
@@ -268,6 +270,50 @@ func configHiveadmissionApiserviceYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterclaimWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusterclaimvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterclaimvalidators.admission.hive.openshift.io
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterclaimvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterclaims
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterclaimWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterclaimWebhookYaml, nil
+}
+
+func configHiveadmissionClusterclaimWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterclaimWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterclaim-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterdeploymentWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1
 kind: ValidatingWebhookConfiguration
@@ -446,6 +492,10 @@ spec:
         - configMapRef:
             name: hive-feature-gates
         env:
+        - name: HIVE_NS
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.namespace
         - name: TMPDIR
           value: /tmp
         volumeMounts:
@@ -552,6 +602,19 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterquotas
+  - clusterdeployments
+  - clusterclaims
+  - clusterpools
+  - machinepools
+  - hubfederations
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - admissionregistration.k8s.io
   resources:
@@ -630,6 +693,49 @@ func configHiveadmissionHiveadmission_rbac_role_bindingYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionHubfederationWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: hubfederationvalidators.admission.hive.openshift.io
+webhooks:
+- name: hubfederationvalidators.admission.hive.openshift.io
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/hubfederationvalidators
+  rules:
+  - operations:
+    - CREATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - hubfederations
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionHubfederationWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionHubfederationWebhookYaml, nil
+}
+
+func configHiveadmissionHubfederationWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionHubfederationWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/hubfederation-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionMachinepoolWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1
 kind: ValidatingWebhookConfiguration
@@ -1184,6 +1290,9 @@ rules:
   - syncsetinstances
   - clusterdeprovisions
   - clusterdeploymentcustomizations
+  - clusterquotas
+  - clusterupgrades
+  - hubfederations
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
@@ -1199,6 +1308,9 @@ rules:
   - selectorsyncsets
   - selectorsyncidentityproviders
   - clusterdeploymentcustomizations
+  - clusterquotas
+  - clusterupgrades
+  - hubfederations
   verbs:
   - get
   - list
@@ -1569,6 +1681,9 @@ rules:
   - syncsetinstances
   - clusterdeprovisions
   - clusterdeploymentcustomizations
+  - clusterquotas
+  - clusterupgrades
+  - hubfederations
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
@@ -1663,108 +1778,129 @@ data:
       - "Error: .*InsufficientInstanceCapacity.* Our system will be working on provisioning additional capacity"
       installFailingReason: AWSInsufficientCapacity
       installFailingMessage: AWS currently does not have sufficient capacity to provision the requested EC2 instances in the specified Availability Zone. Please try again later or in a different Availability Zone.
+      category: Quota
     - name: AWSEC2QuotaExceeded
       searchRegexStrings:
       - "failed to generate asset.*Platform Quota Check.*MissingQuota.*ec2"
       installFailingReason: AWSEC2QuotaExceeded
       installFailingMessage: AWS EC2 Quota Exceeded
+      category: Quota
     - name: AWSNATGatewayLimitExceeded
       searchRegexStrings:
       - "NatGatewayLimitExceeded"
       installFailingReason: AWSNATGatewayLimitExceeded
       installFailingMessage: AWS NAT gateway limit exceeded
+      category: Quota
     - name: AWSVPCLimitExceeded
       searchRegexStrings:
       - "VpcLimitExceeded"
       installFailingReason: AWSVPCLimitExceeded
       installFailingMessage: AWS VPC limit exceeded
+      category: Quota
     - name: S3BucketsLimitExceeded
       searchRegexStrings:
        - "TooManyBuckets"
       installFailingReason: S3BucketsLimitExceeded
       installFailingMessage: S3 Buckets Limit Exceeded
+      category: Quota
     - name: LoadBalancerLimitExceeded
       searchRegexStrings:
       - "TooManyLoadBalancers: Exceeded quota of account"
       installFailingReason: LoadBalancerLimitExceeded
       installFailingMessage: AWS Load Balancer Limit Exceeded
+      category: Quota
     - name: EIPAddressLimitExceeded
       searchRegexStrings:
       - "EIP: AddressLimitExceeded"
       installFailingReason: EIPAddressLimitExceeded
       installFailingMessage: EIP Address limit exceeded
+      category: Quota
     - name: AWSSubnetInsufficientIPSpace
       searchRegexStrings:
       - "InvalidSubnet: Not enough IP space available in"
       installFailingReason: AWSSubnetInsufficientIPSpace
       installFailingMessage: Insufficient IP space available in subnet
+      category: Network
     - name: AWSIAMRoleTagLimitExceeded
       searchRegexStrings:
       - "could not tag \".*\" instance role: LimitExceeded: The number of tags has reached the maximum limit"
       installFailingReason: AWSIAMRoleTagLimitExceeded
       installFailingMessage: AWS IAM Role exceeds the maximum number of tags allowed (50)
+      category: Quota
     - name: AWSSubnetTagLimitExceeded
       searchRegexStrings:
       - "could not add tags to subnets: TagLimitExceeded"
       installFailingReason: AWSSubnetTagLimitExceeded
       installFailingMessage: AWS Subnet exceeds the maximum number of tags allowed (50)
+      category: Quota
     - name: MissingPublicSubnetForZone
       searchRegexStrings:
       - "No public subnet provided for zone"
       installFailingReason: MissingPublicSubnetForZone
       installFailingMessage: No public subnet provided for at least one zone
+      category: Network
     - name: PrivateSubnetInMultipleZones
       searchRegexStrings:
       - "private subnet .* is also in zone"
       installFailingReason: PrivateSubnetInMultipleZones
       installFailingMessage: Same private subnet used in multiple zones
+      category: Network
     - name: InvalidInstallConfigSubnet
       searchRegexStrings:
       - "CIDR range start.*is outside of the specified machine networks"
       installFailingReason: InvalidInstallConfigSubnet
       installFailingMessage: Invalid subnet in install config. Subnet's CIDR range start is outside of the specified machine networks
+      category: Network
     # https://bugzilla.redhat.com/show_bug.cgi?id=1844320
     - name: AWSUnableToFindMatchingRouteTable
       searchRegexStrings:
       - "Error: Unable to find matching route for Route Table"
       installFailingReason: AWSUnableToFindMatchingRouteTable
       installFailingMessage: Unable to find matching route for route table
+      category: Network
     - name: DNSAlreadyExists
       searchRegexStrings:
       - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
       installFailingReason: DNSAlreadyExists
       installFailingMessage: DNS record already exists
+      category: Network
     - name: PendingVerification
       searchRegexStrings:
       - "PendingVerification: Your request for accessing resources in this region is being validated"
       installFailingReason: PendingVerification
       installFailingMessage: Account pending verification for region
+      category: Credentials
     - name: NoMatchingRoute53Zone
       searchRegexStrings:
       - "data.aws_route53_zone.public: no matching Route53Zone found"
       installFailingReason: NoMatchingRoute53Zone
       installFailingMessage: No matching Route53Zone found
+      category: Network
     - name: TooManyRoute53Zones
       searchRegexStrings:
       - "error creating Route53 Hosted Zone: TooManyHostedZones: Limits Exceeded"
       installFailingReason: TooManyRoute53Zones
       installFailingMessage: Route53 hosted zone limit exceeded
+      category: Quota
     - name: MultipleRoute53ZonesFound
       searchRegexStrings:
         - "Error: multiple Route53Zone found"
       installFailingReason: MultipleRoute53ZonesFound
       installFailingMessage: Multiple Route53 zones found
+      category: Network
     - name: DefaultEbsKmsKeyInsufficientPermissions
       searchRegexStrings:
         - "Client.InternalError: Client error on launch"
         - "Client.InvalidKMSKey.InvalidState: The KMS key provided is in an incorrect state"
       installFailingReason: DefaultEbsKmsKeyInsufficientPermissions
       installFailingMessage: Default KMS key for EBS encryption has insufficient permissions to launch EC2 instances
+      category: Credentials
     - name: SimulatorThrottling
       searchRegexStrings:
       - "validate AWS credentials: checking install permissions: error simulating policy: Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded while simulating policy
+      category: CloudAPIOutage
     - name: S3AccessControlListNotSupported
       searchRegexStrings:
       - "error creating S3 bucket ACL for.*AccessControlListNotSupported: The bucket does not allow ACLs"
@@ -1775,6 +1911,7 @@ data:
       - "Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded
+      category: CloudAPIOutage
     # This issue is caused by AWS throttling the CreateHostedZone request. The terraform provider is not properly
     # handling the throttling response and gets stuck in a state where it does not retry the request. Eventually,
     # the terraform provider times out claiming that it is waiting for the hosted zone to be INSYNC.
@@ -1783,11 +1920,13 @@ data:
       - "error waiting for Route53 Hosted Zone .* creation: timeout while waiting for state to become 'INSYNC'"
       installFailingReason: AWSRoute53Timeout
       installFailingMessage: AWS Route53 timeout while waiting for INSYNC. This is usually caused by Route53 rate limiting.
+      category: CloudAPIOutage
     - name: InvalidCredentials
       searchRegexStrings:
       - "InvalidClientTokenId: The security token included in the request is invalid."
       installFailingReason: InvalidCredentials
       installFailingMessage: Credentials are invalid
+      category: Credentials
     - name: InvalidAWSTags
       searchRegexStrings:
       - "platform\\.aws\\.userTags.*: Invalid value:.*value contains invalid characters"
@@ -1803,6 +1942,7 @@ data:
       - "The subnet ID .* does not exist"
       installFailingReason: AWSSubnetDoesNotExist
       installFailingMessage: AWS Subnet Does Not Exist
+      category: Network
     # iam:CreateServiceLinkedRole is a super powerful permission that we don't give to STS clusters. We require it's done as a one-time prereq.
     # This is the error we see when the prereq step was missed.
     - name: NATGatewayFailed
@@ -1810,33 +1950,39 @@ data:
       - "Error waiting for NAT Gateway (.*) to become available"
       installFailingReason: NATGatewayFailed
       installFailingMessage: Error waiting for NAT Gateway to become available.
+      category: Network
     - name: AWSAccessDeniedSLR
       searchRegexStrings:
       - "Error creating network Load Balancer: AccessDenied.*iam:CreateServiceLinkedRole"
       installFailingReason: AWSAccessDeniedSLR
       installFailingMessage: Missing prerequisite service role for load balancer
+      category: Credentials
     - name: AWSInsufficientPermissions
       searchRegexStrings:
       - "current credentials insufficient for performing cluster installation"
       - "UnauthorizedOperation: You are not authorized to perform this operation. Encoded authorization failure message"
       installFailingReason: AWSInsufficientPermissions
       installFailingMessage: AWS credentials are insufficient for performing cluster installation
+      category: Credentials
     - name: AWSDeniedBySCP
       searchRegexStrings:
       - "AccessDenied: .* with an explicit deny in a service control policy"
       - "UnauthorizedOperation: .* with an explicit deny in a service control policy"
       installFailingReason: AWSDeniedBySCP
       installFailingMessage: "A service control policy (SCP) is too restrictive for performing cluster installation"
+      category: Credentials
     - name: VcpuLimitExceeded
       searchRegexStrings:
       - "VcpuLimitExceeded"
       installFailingReason: VcpuLimitExceeded
       installFailingMessage: The install requires more vCPU capacity than your current vCPU limit
+      category: Quota
     - name: Gp3VolumeLimitExceeded
       searchRegexStrings:
       - "VolumeLimitExceeded: You have exceeded your maximum gp3 storage limit"
       installFailingReason: Gp3VolumeLimitExceeded
       installFailingMessage: "The installation failed due to insufficient gp3 storage quota in the region (QuotaCode L-7A658B76)"
+      category: Quota
     - name: UserInitiatedShutdown
       searchRegexStrings:
       - "Error waiting for instance .* to become ready .* User initiated shutdown"
@@ -1848,22 +1994,26 @@ data:
       - "Error: Provider produced inconsistent result after apply"
       installFailingReason: InconsistentTerraformResult
       installFailingMessage: Inconsistent result after Terraform apply
+      category: CloudAPIOutage
     - name: AWSVPCDoesNotExist
       searchRegexStrings:
       - "The vpc ID .* does not exist"
       installFailingReason: AWSVPCDoesNotExist
       installFailingMessage: The AWS VPC does not exist
+      category: Network
     - name: TargetGroupNotFound
     # https://bugzilla.redhat.com/show_bug.cgi?id=1898265
       searchRegexStrings:
       - "TargetGroupNotFound"
       installFailingReason: TargetGroupNotFound
       installFailingMessage: Target Group cannot be found
+      category: CloudAPIOutage
     - name: ErrorCreatingNetworkLoadBalancer
       searchRegexStrings:
       - "Error creating network Load Balancer: InternalFailure: "
       installFailingReason: ErrorCreatingNetworkLoadBalancer
       installFailingMessage: AWS network load balancer creation encountered an error during cluster installation
+      category: CloudAPIOutage
     - name: TerraformFailedToDeleteResources
       searchRegexStrings:
         - "terraform destroy: failed to destroy using Terraform"
@@ -1875,6 +2025,7 @@ data:
         - "Blocked: This account is currently blocked and not recognized as a valid account."
       installFailingReason: AWSAccountIsBlocked
       installFailingMessage: "AWS account is currently blocked and not recognized as a valid account. Please contact aws-verification@amazon.com if you have questions."
+      category: Credentials
 
 
     # GCP Specific
@@ -1893,21 +2044,25 @@ data:
       - "googleapi: Error 412"
       installFailingReason: GCPPreconditionFailed
       installFailingMessage: GCP Precondition Failed
+      category: CloudAPIOutage
     - name: GCPQuotaSSDTotalGBExceeded
       searchRegexStrings:
       - "Quota \'SSD_TOTAL_GB\' exceeded"
       installFailingReason: GCPQuotaSSDTotalGBExceeded
       installFailingMessage: GCP quota SSD_TOTAL_GB exceeded
+      category: Quota
     - name: GCPComputeQuota
       searchRegexStrings:
       - "compute\\.googleapis\\.com/cpus is not available in [a-z0-9-]* because the required number of resources \\([0-9]*\\) is more than"
       installFailingReason: GCPComputeQuotaExceeded
       installFailingMessage: GCP CPUs quota exceeded
+      category: Quota
     - name: GCPServiceAccountQuota
       searchRegexStrings:
       - "iam\\.googleapis\\.com/quota/service-account-count is not available in global because the required number of resources \\([0-9]*\\) is more than remaining quota"
       installFailingReason: GCPServiceAccountQuotaExceeded
       installFailingMessage: GCP Service Account quota exceeded
+      category: Quota
 
 
     # Bare Metal
@@ -1916,11 +2071,13 @@ data:
       - "platform.baremetal.libvirtURI: Internal error: could not connect to libvirt: virError.Code=38, Domain=7, Message=.Cannot recv data: Permission denied"
       installFailingReason: LibvirtSSHKeyPermissionDenied
       installFailingMessage: "Permission denied connecting to libvirt host, check SSH key configuration and pass phrase"
+      category: Credentials
     - name: LibvirtConnectionFailed
       searchRegexStrings:
       - "could not connect to libvirt"
       installFailingReason: LibvirtConnectionFailed
       installFailingMessage: "Could not connect to libvirt host"
+      category: Network
 
 
     # Proxy-enabled clusters
@@ -1931,11 +2088,13 @@ data:
       - "error pinging docker registry .+ proxyconnect tcp: dial tcp [^ ]+: connect: no route to host"
       installFailingReason: ProxyTimeout
       installFailingMessage: The cluster is installing via a proxy, however the proxy server is refusing or timing out connections. Verify that the proxy is running and would be accessible from the cluster's private subnet(s).
+      category: Network
     - name: ProxyInvalidCABundle
       searchRegexStrings:
       - "error pinging docker registry .+ proxyconnect tcp: x509: certificate signed by unknown authority"
       installFailingReason: ProxyInvalidCABundle
       installFailingMessage: The cluster is installing via a proxy, but does not trust the signing certificate the proxy is presenting. Verify that the Certificate Authority certificate(s) to verify proxy communications have been supplied at installation time.
+      category: Network
 
 
     # Generic OpenShift Install
@@ -1945,61 +2104,73 @@ data:
       - "waiting for Kubernetes API: context deadline exceeded"
       installFailingReason: KubeAPIWaitTimeout
       installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+      category: Bootstrap
     - name: KubeAPIWaitFailed
       searchRegexStrings:
       - "Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane"
       installFailingReason: KubeAPIWaitFailed
       installFailingMessage: Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane
+      category: Bootstrap
     - name: BootstrapFailed
       searchRegexStrings:
       - "Failed to wait for bootstrapping to complete. This error usually happens when there is a problem with control plane hosts that prevents the control plane operators from creating the control plane."
       installFailingReason: BootstrapFailed
       installFailingMessage: Failed to wait for bootstrapping to complete. This error usually happens when there is a problem with control plane hosts that prevents the control plane operators from creating the control plane. Verify the networking configuration and account permissions and try again.
+      category: Bootstrap
     - name: GenericBootstrapFailed
       searchRegexStrings:
       - "Bootstrap failed to complete"
       installFailingReason: GenericBootstrapFailed
       installFailingMessage: Installation Bootstrap failed to complete. Verify the networking configuration and account permissions and try again.
+      category: Bootstrap
     - name: MonitoringOperatorStillUpdating
       searchRegexStrings:
       - "failed to initialize the cluster: Cluster operator monitoring is still updating"
       installFailingReason: MonitoringOperatorStillUpdating
       installFailingMessage: Timeout waiting for the monitoring operator to become ready
+      category: Bootstrap
     - name: NoWorkerNodesReady
       searchRegexStrings:
       - "Got 0 worker nodes, \\d+ master nodes.*none are schedulable or ready for ingress pods"
       installFailingReason: NoWorkerNodesReady
       installFailingMessage: 0 worker nodes have joined the cluster
+      category: Bootstrap
     - name: IngressOperatorDegraded 
       searchRegexStrings:
       - "Cluster operator ingress Degraded is True"
       installFailingReason: IngressOperatorDegraded
       installFailingMessage: Timeout waiting for the ingress operator to become ready
+      category: Bootstrap
     - name: AuthenticationOperatorDegraded
       searchRegexStrings:
       - "Cluster operator authentication Degraded is True"
       installFailingReason: AuthenticationOperatorDegraded
       installFailingMessage: Timeout waiting for the authentication operator to become ready
+      category: Bootstrap
     - name: GeneralOperatorDegraded
       searchRegexStrings:
       - "Cluster operator.*Degraded is True"
       installFailingReason: GeneralOperatorDegraded
       installFailingMessage: Timeout waiting for an operator to become ready
+      category: Bootstrap
     - name: GeneralClusterOperatorsStillUpdating
       searchRegexStrings:
       - "failed to initialize the cluster: Some cluster operators are still updating:"
       installFailingReason: GeneralClusterOperatorsStillUpdating
       installFailingMessage: Timeout waiting for all cluster operators to become ready
+      category: Bootstrap
     - name: InstallConfigNetworkAuthFail
       searchRegexStrings:
       - "failed to create install config: failed to create a network client: Authentication failed"
       installFailingReason: InstallConfigNetworkAuthFail
       installFailingMessage: Authentication failure attempting to create a network client - check credentials and certificates
+      category: Credentials
     - name: InstallConfigNetworkBadCACert
       searchRegexStrings:
       - "failed to create install config: failed to create a network client: Error parsing CA Cert from"
       installFailingReason: InstallConfigNetworkBadCACert
       installFailingMessage: Failure attempting to create a network client - invalid CA certificate
+      category: Network
 
     # Keep these at the bottom so that they're only hit if nothing above matches.
     # We don't want to show these to users unless it's a last resort. It's barely better than "unknown error".
@@ -2009,11 +2180,13 @@ data:
       - "Quota '[A-Z_]*' exceeded"
       installFailingReason: FallbackQuotaExceeded
       installFailingMessage: Unknown quota exceeded - couldn't parse a specific resource type
+      category: Quota
     - name: FallbackResourceLimitExceeded
       searchRegexStrings:
       - "LimitExceeded"
       installFailingReason: FallbackResourceLimitExceeded
       installFailingMessage: Unknown resource limit exceeded - couldn't parse a specific resource type
+      category: Quota
     - name: FallbackInvalidInstallConfig
       searchRegexStrings:
       - "failed to load asset \\\"Install Config\\\""
@@ -2024,6 +2197,7 @@ data:
       - "Error waiting for instance .* to become ready"
       installFailingReason: FallbackInstancesFailedToBecomeReady
       installFailingMessage: Unknown error - instances failed to become ready
+      category: Bootstrap
 `)
 
 func configConfigmapsInstallLogRegexesConfigmapYamlBytes() ([]byte, error) {
@@ -2096,6 +2270,7 @@ var _bindata = map[string]func() (*asset, error){
 	"config/sharded_controllers/service.yaml":                   configSharded_controllersServiceYaml,
 	"config/sharded_controllers/statefulset.yaml":               configSharded_controllersStatefulsetYaml,
 	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
+	"config/hiveadmission/clusterclaim-webhook.yaml":            configHiveadmissionClusterclaimWebhookYaml,
 	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
 	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
 	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
@@ -2103,6 +2278,7 @@ var _bindata = map[string]func() (*asset, error){
 	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
 	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
 	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/hubfederation-webhook.yaml":           configHiveadmissionHubfederationWebhookYaml,
 	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
 	"config/hiveadmission/sa-token-secret.yaml":                 configHiveadmissionSaTokenSecretYaml,
 	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
@@ -2181,6 +2357,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"hiveadmission": {nil, map[string]*bintree{
 			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
+			"clusterclaim-webhook.yaml":            {configHiveadmissionClusterclaimWebhookYaml, map[string]*bintree{}},
 			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
 			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
 			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
@@ -2188,6 +2365,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"hubfederation-webhook.yaml":           {configHiveadmissionHubfederationWebhookYaml, map[string]*bintree{}},
 			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
 			"sa-token-secret.yaml":                 {configHiveadmissionSaTokenSecretYaml, map[string]*bintree{}},
 			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
@@ -127,6 +127,28 @@ var metricsConfigConfigMapInfo = configMapInfo{
 	},
 }
 
+var certificateGenerationConfigMapInfo = configMapInfo{
+	name:                 "hive-certificate-generation-config",
+	nameKey:              "hive-certificate-generation-config",
+	mountPath:            "/data/certificate-generation-config",
+	envVar:               constants.CertificateGenerationConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (any, error) {
+		return instance.Spec.CertificateGeneration, nil
+	},
+}
+
+var dnsDelegationAuditConfigMapInfo = configMapInfo{
+	name:                 "hive-dns-delegation-audit-config",
+	nameKey:              "hive-dns-delegation-audit-config",
+	mountPath:            "/data/dns-delegation-audit-config",
+	envVar:               constants.DNSDelegationAuditConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (any, error) {
+		return instance.Spec.DNSDelegationAudit, nil
+	},
+}
+
 // allowedContracts is the list of operator whitelisted contracts that hive will accept
 // from CRDs.
 var allowedContracts = sets.NewString(
@@ -166,16 +166,27 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, privateLinkConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, failedProvisionConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, metricsConfigConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, certificateGenerationConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, dnsDelegationAuditConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, r.supportedContractsConfigMapInfo(hLog), hiveContainer)
 
 	// This triggers the clusterdeployment controller to copy the secret into the CD's namespace.
 	// It would be neat if it did that purely based on the FailedProvisionConfig ConfigMap, to
 	// which it does have access, but that code path is shared by other things that need the
 	// same copied secret.
-	if awsSpec := instance.Spec.FailedProvisionConfig.AWS; awsSpec != nil {
+	var installLogsCredentialsSecretRef *corev1.LocalObjectReference
+	switch fpConfig := instance.Spec.FailedProvisionConfig; {
+	case fpConfig.AWS != nil:
+		installLogsCredentialsSecretRef = &fpConfig.AWS.CredentialsSecretRef
+	case fpConfig.Azure != nil:
+		installLogsCredentialsSecretRef = &fpConfig.Azure.CredentialsSecretRef
+	case fpConfig.GCP != nil:
+		installLogsCredentialsSecretRef = &fpConfig.GCP.CredentialsSecretRef
+	}
+	if installLogsCredentialsSecretRef != nil {
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
 			Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
-			Value: awsSpec.CredentialsSecretRef.Name,
+			Value: installLogsCredentialsSecretRef.Name,
 		})
 	}
 
@@ -529,7 +540,7 @@ func (r *ReconcileHiveConfig) copyHiveImagePullSecret(hLog log.FieldLogger, h re
 	}
 
 	controllerSA := &corev1.ServiceAccount{}
-	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: destNS, Name: "hive-controllers"}, controllerSA); err != nil {
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: destNS, Name: constants.HiveControllersServiceAccountName}, controllerSA); err != nil {
 		hLog.WithError(err).Info("couldn't fetch hive-controllers service account, attempting to requeue...")
 		return err
 	}
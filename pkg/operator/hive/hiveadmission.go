@@ -39,9 +39,11 @@ const (
 )
 
 var webhookAssets = []string{
+	"config/hiveadmission/clusterclaim-webhook.yaml",
 	"config/hiveadmission/clusterdeployment-webhook.yaml",
 	"config/hiveadmission/clusterimageset-webhook.yaml",
 	"config/hiveadmission/clusterprovision-webhook.yaml",
+	"config/hiveadmission/hubfederation-webhook.yaml",
 	"config/hiveadmission/dnszones-webhook.yaml",
 	"config/hiveadmission/machinepool-webhook.yaml",
 	"config/hiveadmission/syncset-webhook.yaml",
@@ -128,6 +128,58 @@ func (r *helper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme)
 	return r.Create(data)
 }
 
+func (r *helper) ServerSideApply(obj []byte, fieldManager string, force bool) (ApplyResult, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		r.logger.WithError(err).Error("failed to obtain factory for apply")
+		return "", err
+	}
+	result, err := r.serverSideApply(factory, obj, fieldManager, force)
+	if err != nil {
+		r.logger.WithError(err).Warn("running the server-side apply failed")
+		return "", err
+	}
+	return result, nil
+}
+
+func (r *helper) serverSideApply(f cmdutil.Factory, obj []byte, fieldManager string, force bool) (ApplyResult, error) {
+	info, err := r.getResourceInternalInfo(f, obj)
+	if err != nil {
+		return "", err
+	}
+	c, err := f.DynamicClient()
+	if err != nil {
+		return "", err
+	}
+	applyObj := info.Object.(*unstructured.Unstructured).DeepCopy()
+	// The resource version of the existing object, if any, tells us whether the apply changed anything.
+	var resourceVersion string
+	switch err := info.Get(); {
+	case errors.IsNotFound(err):
+	case err != nil:
+		return "", err
+	default:
+		resourceVersion = info.ResourceVersion
+	}
+	gvr := info.ResourceMapping().Resource
+	applied, err := c.Resource(gvr).Namespace(info.Namespace).Apply(context.TODO(), info.Name, applyObj,
+		metav1.ApplyOptions{
+			FieldManager: fieldManager,
+			Force:        force,
+		})
+	if err != nil {
+		return "", err
+	}
+	switch resourceVersion {
+	case "":
+		return CreatedApplyResult, nil
+	case applied.GetResourceVersion():
+		return UnchangedApplyResult, nil
+	default:
+		return ConfiguredApplyResult, nil
+	}
+}
+
 func (r *helper) createOnly(f cmdutil.Factory, obj []byte) (ApplyResult, error) {
 	info, err := r.getResourceInternalInfo(f, obj)
 	if err != nil {
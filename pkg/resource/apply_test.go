@@ -0,0 +1,169 @@
+package resource
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	testNamespace     = "test-namespace"
+	testConfigMapName = "test-configmap"
+	testConfigMapPath = "/api/v1/namespaces/" + testNamespace + "/configmaps/" + testConfigMapName
+)
+
+// testAPIServer serves just enough of the API for server-side apply of a ConfigMap, recording each apply request.
+type testAPIServer struct {
+	// existingResourceVersion is the resource version of the existing ConfigMap, if there is one.
+	existingResourceVersion string
+	// appliedResourceVersion is the resource version of the ConfigMap after the apply.
+	appliedResourceVersion string
+	// applyErr is the error status returned by the apply, if set.
+	applyErr *apierrors.StatusError
+
+	applyContentType string
+	applyQuery       map[string][]string
+	applyBody        map[string]any
+}
+
+func (s *testAPIServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.URL.Path == "/api":
+		writeJSON(w, http.StatusOK, &metav1.APIVersions{Versions: []string{"v1"}})
+	case req.URL.Path == "/apis":
+		writeJSON(w, http.StatusOK, &metav1.APIGroupList{})
+	case req.URL.Path == "/api/v1":
+		writeJSON(w, http.StatusOK, &metav1.APIResourceList{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{
+				Name:       "configmaps",
+				Namespaced: true,
+				Kind:       "ConfigMap",
+				Verbs:      metav1.Verbs{"get", "patch"},
+			}},
+		})
+	case req.URL.Path == testConfigMapPath && req.Method == http.MethodGet:
+		if s.existingResourceVersion == "" {
+			writeStatus(w, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, testConfigMapName))
+			return
+		}
+		writeJSON(w, http.StatusOK, testConfigMap(s.existingResourceVersion))
+	case req.URL.Path == testConfigMapPath && req.Method == http.MethodPatch:
+		s.applyContentType = req.Header.Get("Content-Type")
+		s.applyQuery = req.URL.Query()
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &s.applyBody)
+		if s.applyErr != nil {
+			writeStatus(w, s.applyErr)
+			return
+		}
+		writeJSON(w, http.StatusOK, testConfigMap(s.appliedResourceVersion))
+	default:
+		writeStatus(w, apierrors.NewNotFound(schema.GroupResource{}, req.URL.Path))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(obj)
+}
+
+func writeStatus(w http.ResponseWriter, err *apierrors.StatusError) {
+	status := err.Status()
+	status.Kind, status.APIVersion = "Status", "v1"
+	writeJSON(w, int(status.Code), &status)
+}
+
+func testConfigMap(resourceVersion string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            testConfigMapName,
+			ResourceVersion: resourceVersion,
+		},
+		Data: map[string]string{"key": "value"},
+	}
+}
+
+func TestServerSideApply(t *testing.T) {
+	tests := []struct {
+		name           string
+		server         *testAPIServer
+		force          bool
+		expectedResult ApplyResult
+		expectedErr    func(error) bool
+	}{
+		{
+			name:           "created",
+			server:         &testAPIServer{appliedResourceVersion: "1"},
+			expectedResult: CreatedApplyResult,
+		},
+		{
+			name:           "unchanged",
+			server:         &testAPIServer{existingResourceVersion: "5", appliedResourceVersion: "5"},
+			expectedResult: UnchangedApplyResult,
+		},
+		{
+			name:           "configured",
+			server:         &testAPIServer{existingResourceVersion: "5", appliedResourceVersion: "6"},
+			expectedResult: ConfiguredApplyResult,
+		},
+		{
+			name:           "forced",
+			server:         &testAPIServer{existingResourceVersion: "5", appliedResourceVersion: "6"},
+			force:          true,
+			expectedResult: ConfiguredApplyResult,
+		},
+		{
+			name: "conflict",
+			server: &testAPIServer{
+				existingResourceVersion: "5",
+				applyErr: apierrors.NewApplyConflict(
+					[]metav1.StatusCause{{Type: metav1.CauseTypeFieldManagerConflict, Field: ".data.key"}},
+					`Apply failed with 1 conflict: conflict with "other-manager": .data.key`,
+				),
+			},
+			expectedErr: apierrors.IsConflict,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(test.server)
+			defer server.Close()
+			h := &helper{logger: log.WithField("test", test.name), cacheDir: t.TempDir()}
+			FromRESTConfig(&rest.Config{Host: server.URL})(h)
+
+			obj, err := json.Marshal(testConfigMap(""))
+			require.NoError(t, err)
+			result, err := h.ServerSideApply(obj, "test-manager", test.force)
+
+			if test.expectedErr != nil {
+				assert.True(t, test.expectedErr(err), "unexpected error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedResult, result, "unexpected apply result")
+
+			assert.Equal(t, string(types.ApplyPatchType), test.server.applyContentType, "expected a server-side apply patch")
+			assert.Equal(t, []string{"test-manager"}, test.server.applyQuery["fieldManager"], "unexpected field manager")
+			assert.Equal(t, []string{strconv.FormatBool(test.force)}, test.server.applyQuery["force"], "unexpected force")
+			assert.Equal(t, map[string]any{"key": "value"}, test.server.applyBody["data"], "unexpected applied object")
+			assert.NotContains(t, test.server.applyBody["metadata"], "resourceVersion", "expected no resource version in applied object")
+		})
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -66,6 +69,11 @@ func (r *fakeHelper) CreateRuntimeObject(obj runtime.Object, scheme *runtime.Sch
 	return ConfiguredApplyResult, nil
 }
 
+func (r *fakeHelper) ServerSideApply(obj []byte, fieldManager string, force bool) (ApplyResult, error) {
+	r.fakeApplySleep()
+	return ConfiguredApplyResult, nil
+}
+
 func (r *fakeHelper) Info(obj []byte) (*Info, error) {
 	// TODO: Do we need to fake this better?
 	return &Info{}, nil
@@ -78,3 +86,8 @@ func (fakeHelper) Patch(name types.NamespacedName, kind, apiVersion string, patc
 func (fakeHelper) Delete(apiVersion, kind, namespace, name string) error {
 	return nil
 }
+
+func (fakeHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	// A fake cluster holds no resources.
+	return nil, apierrors.NewNotFound(schema.GroupResource{Group: schema.FromAPIVersionAndKind(apiVersion, kind).Group, Resource: kind}, name)
+}
@@ -0,0 +1,38 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Get returns the resource from the target cluster. If the resource does not exist, the returned error satisfies
+// apierrors.IsNotFound.
+func (r *helper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	f, err := r.getFactory(namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get factory")
+	}
+	mapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get mapper")
+	}
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get mapping")
+	}
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create dynamic client")
+	}
+	obj, err := dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get resource")
+	}
+	return obj, nil
+}
@@ -87,6 +87,12 @@ func WithCluster(clusterName string) Option {
 	}
 }
 
+func WithPriority(priority int32) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Priority = priority
+	}
+}
+
 func WithSubjects(subjects []rbacv1.Subject) Option {
 	return func(clusterClaim *hivev1.ClusterClaim) {
 		clusterClaim.Spec.Subjects = subjects
@@ -111,3 +117,13 @@ func WithLifetime(lifetime time.Duration) Option {
 		clusterClaim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
 	}
 }
+
+// WithRenewal adds a renewal at the specified time to the ClusterClaim's status
+func WithRenewal(renewed time.Time, lifetime time.Duration) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Status.Renewals = append(clusterClaim.Status.Renewals, hivev1.ClusterClaimRenewal{
+			Time:           metav1.NewTime(renewed),
+			ExpirationTime: metav1.NewTime(renewed.Add(lifetime)),
+		})
+	}
+}
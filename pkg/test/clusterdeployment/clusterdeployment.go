@@ -12,6 +12,9 @@ import (
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	hivev1ibmcloud "github.com/openshift/hive/apis/hive/v1/ibmcloud"
+	hivev1nutanix "github.com/openshift/hive/apis/hive/v1/nutanix"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/test/generic"
 )
@@ -209,6 +212,12 @@ func WithHibernateAfter(dur time.Duration) Option {
 	}
 }
 
+func WithHibernationSchedule(schedule *hivev1.HibernationSchedule) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.HibernationSchedule = schedule
+	}
+}
+
 // WithAWSPlatform sets the specified aws platform on the supplied object.
 func WithAWSPlatform(platform *hivev1aws.Platform) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
@@ -237,6 +246,27 @@ func WithIBMCloudPlatform(platform *hivev1ibmcloud.Platform) Option {
 	}
 }
 
+// WithNutanixPlatform sets the specified Nutanix platform on the cd.
+func WithNutanixPlatform(platform *hivev1nutanix.Platform) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Platform.Nutanix = platform
+	}
+}
+
+// WithOpenStackPlatform sets the specified OpenStack platform on the cd.
+func WithOpenStackPlatform(platform *hivev1openstack.Platform) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Platform.OpenStack = platform
+	}
+}
+
+// WithVSpherePlatform sets the specified vSphere platform on the cd.
+func WithVSpherePlatform(platform *hivev1vsphere.Platform) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Platform.VSphere = platform
+	}
+}
+
 // WithAWSPlatformStatus sets the specified aws platform status on the supplied object.
 func WithEmptyPlatformStatus() Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
@@ -264,6 +294,16 @@ func WithGCPPlatformStatus(platformStatus *hivev1gcp.PlatformStatus) Option {
 	}
 }
 
+// WithAzurePlatformStatus sets the specified azure platform status on the supplied object.
+func WithAzurePlatformStatus(platformStatus *hivev1azure.PlatformStatus) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		if clusterDeployment.Status.Platform == nil {
+			clusterDeployment.Status.Platform = &hivev1.PlatformStatus{}
+		}
+		clusterDeployment.Status.Platform.Azure = platformStatus
+	}
+}
+
 // WithClusterMetadata sets the specified cluster metadata on the cd.
 func WithClusterMetadata(clusterMetadata *hivev1.ClusterMetadata) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
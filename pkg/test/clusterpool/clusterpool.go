@@ -182,6 +182,59 @@ func WithMaximumClaimLifetime(d time.Duration) Option {
 	}
 }
 
+func WithMaxClaimRenewals(maxRenewals int32) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		if clusterPool.Spec.ClaimLifetime == nil {
+			clusterPool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{}
+		}
+		clusterPool.Spec.ClaimLifetime.MaxRenewals = &maxRenewals
+	}
+}
+
+func WithClaimExpiryWarning(d time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		if clusterPool.Spec.ClaimLifetime == nil {
+			clusterPool.Spec.ClaimLifetime = &hivev1.ClusterPoolClaimLifetime{}
+		}
+		clusterPool.Spec.ClaimLifetime.ExpiryWarning = &metav1.Duration{Duration: d}
+	}
+}
+
+func WithRecycle(recycle *hivev1.ClusterPoolRecycle) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Recycle = recycle
+	}
+}
+
+func WithClaimFairShare(tenantLabelKey string, maxClaimedPerTenant *int32) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ClaimFairShare = &hivev1.ClusterPoolClaimFairShare{
+			TenantLabelKey:      tenantLabelKey,
+			MaxClaimedPerTenant: maxClaimedPerTenant,
+		}
+	}
+}
+
+func WithAutoscaling(minSize, maxSize, targetIdle int) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+			MinSize:    int32(minSize),
+			MaxSize:    int32(maxSize),
+			TargetIdle: int32(targetIdle),
+		}
+	}
+}
+
+// WithAutoscalingStatus sets the autoscaled target size of the ClusterPool, as last changed at lastScaleTime.
+func WithAutoscalingStatus(targetSize int, lastScaleTime time.Time) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Status.Autoscaling = &hivev1.ClusterPoolAutoscalingStatus{
+			TargetSize:    int32(targetSize),
+			LastScaleTime: &metav1.Time{Time: lastScaleTime},
+		}
+	}
+}
+
 // WithCondition adds the specified condition to the ClusterPool
 func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
@@ -182,3 +182,29 @@ func WithMetadata(md string) Option {
 		clusterProvision.Spec.MetadataJSON = ([]byte)(md)
 	}
 }
+
+func WithInstallLog(installLog string) Option {
+	return func(clusterProvision *hivev1.ClusterProvision) {
+		clusterProvision.Spec.InstallLog = &installLog
+	}
+}
+
+func WithFailure(reason string, time time.Time) Option {
+	return func(clusterProvision *hivev1.ClusterProvision) {
+		Failed()(clusterProvision)
+		clusterProvision.Status.Conditions = []hivev1.ClusterProvisionCondition{
+			{
+				Type:               hivev1.ClusterProvisionFailedCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             reason,
+				LastTransitionTime: metav1.NewTime(time),
+			},
+		}
+	}
+}
+
+func WithInstallFailures(failures ...hivev1.InstallFailure) Option {
+	return func(clusterProvision *hivev1.ClusterProvision) {
+		clusterProvision.Status.InstallFailures = failures
+	}
+}
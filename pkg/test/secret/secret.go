@@ -1,6 +1,15 @@
 package secret
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -97,3 +106,32 @@ func WithType(t corev1.SecretType) Option {
 		obj.Type = t
 	}
 }
+
+// WithTLSCertificate makes the secret a TLS secret holding a new self-signed certificate for the given DNS names,
+// which expires at notAfter, and its key.
+func WithTLSCertificate(notAfter time.Time, dnsNames ...string) Option {
+	return func(obj *corev1.Secret) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "test"},
+			DNSNames:     dnsNames,
+			NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		if err != nil {
+			panic(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			panic(err)
+		}
+		WithType(corev1.SecretTypeTLS)(obj)
+		WithDataKeyValue(corev1.TLSCertKey, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))(obj)
+		WithDataKeyValue(corev1.TLSPrivateKeyKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))(obj)
+	}
+}
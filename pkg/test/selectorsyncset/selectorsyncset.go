@@ -105,6 +105,12 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithWave(wave int32) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.Wave = wave
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
 		selectorSyncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
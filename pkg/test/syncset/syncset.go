@@ -108,6 +108,30 @@ func WithApplyBehavior(applyBehavior hivev1.SyncSetApplyBehavior) Option {
 	}
 }
 
+func WithServerSideApply(serverSideApply *hivev1.SyncSetServerSideApply) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ServerSideApply = serverSideApply
+	}
+}
+
+func WithWave(wave int32) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.Wave = wave
+	}
+}
+
+func WithReadinessChecks(checks ...hivev1.SyncResourceReadinessCheck) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ReadinessChecks = checks
+	}
+}
+
+func WithDriftPolicy(driftPolicy hivev1.SyncSetDriftPolicy) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.DriftPolicy = driftPolicy
+	}
+}
+
 func WithResources(objs ...hivev1.MetaRuntimeObject) Option {
 	return func(syncSet *hivev1.SyncSet) {
 		syncSet.Spec.Resources = make([]runtime.RawExtension, len(objs))
@@ -0,0 +1,274 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/clusterquota"
+)
+
+const (
+	clusterClaimGroup    = "hive.openshift.io"
+	clusterClaimVersion  = "v1"
+	clusterClaimResource = "clusterclaims"
+)
+
+// ClusterClaimValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type ClusterClaimValidatingAdmissionHook struct {
+	decoder admission.Decoder
+
+	// kubeClient is used to look up ClusterPools, in order to enforce their ClaimFairShare.EnforceTenantMembership,
+	// and to enforce ClusterQuotas. Neither is enforced if it is nil.
+	kubeClient client.Client
+}
+
+// NewClusterClaimValidatingAdmissionHook constructs a new ClusterClaimValidatingAdmissionHook
+func NewClusterClaimValidatingAdmissionHook(decoder admission.Decoder) *ClusterClaimValidatingAdmissionHook {
+	return &ClusterClaimValidatingAdmissionHook{decoder: decoder}
+}
+
+// ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterclaimvalidators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+func (a *ClusterClaimValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimvalidator",
+	}).Info("Registering validation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterClaim CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterclaimvalidators",
+		},
+		"clusterclaimvalidator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterClaimValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterclaimvalidator",
+	}).Info("Initializing validation REST resource")
+
+	c, err := newQuotaClient(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+	a.kubeClient = c
+	return nil
+}
+
+// Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission validation request.
+func (a *ClusterClaimValidatingAdmissionHook) Validate(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	logger := log.WithFields(log.Fields{
+		"operation": request.Operation,
+		"group":     request.Resource.Group,
+		"version":   request.Resource.Version,
+		"resource":  request.Resource.Resource,
+		"method":    "Validate",
+	})
+
+	if !a.shouldValidate(request, logger) {
+		logger.Info("Skipping validation for request")
+		// The request object isn't something that this validator should validate.
+		// Therefore, we say that it's allowed.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	logger.Info("Validating request")
+
+	switch request.Operation {
+	case admissionv1beta1.Create:
+		return a.validateCreateRequest(request, logger)
+	case admissionv1beta1.Update:
+		return a.validateUpdateRequest(request, logger)
+	default:
+		logger.Info("Successful validation")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+}
+
+// shouldValidate explicitly checks if the request should validated. For example, this webhook may have accidentally been registered to check
+// the validity of some other type of object with a different GVR.
+func (a *ClusterClaimValidatingAdmissionHook) shouldValidate(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) bool {
+	logger = logger.WithField("method", "shouldValidate")
+
+	if request.Resource.Group != clusterClaimGroup {
+		logger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if request.Resource.Version != clusterClaimVersion {
+		logger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if request.Resource.Resource != clusterClaimResource {
+		logger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	// If we get here, then we're supposed to validate the object.
+	logger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
+
+// validateCreateRequest specifically validates create operations for ClusterClaim objects.
+func (a *ClusterClaimValidatingAdmissionHook) validateCreateRequest(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	logger = logger.WithField("method", "validateCreateRequest")
+
+	claim := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(request.Object, claim); err != nil {
+		logger.WithError(err).Error("failed to decode")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	logger = logger.
+		WithField("object.Name", claim.Name).
+		WithField("object.Namespace", claim.Namespace)
+
+	if a.kubeClient != nil {
+		if claim.Namespace == "" {
+			claim.Namespace = request.Namespace
+		}
+		pool, resp := a.getEnforcingPool(claim, logger)
+		if resp != nil {
+			return resp
+		}
+		if pool != nil {
+			tenantLabelKey := pool.Spec.ClaimFairShare.TenantLabelKey
+			if tenant := claim.Labels[tenantLabelKey]; tenant == "" || !slices.Contains(request.UserInfo.Groups, tenant) {
+				return tenantMembershipResponse(request, logger, fmt.Errorf(
+					"ClusterPool %s requires the %s label of its claims to name a group to which the requesting user belongs",
+					pool.Name, tenantLabelKey))
+			}
+		}
+		if resp := quotaCheckResponse(clusterquota.CheckClusterClaim(a.kubeClient, claim), request, logger); resp != nil {
+			return resp
+		}
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	logger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// validateUpdateRequest specifically validates update operations for ClusterClaim objects.
+func (a *ClusterClaimValidatingAdmissionHook) validateUpdateRequest(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	logger = logger.WithField("method", "validateUpdateRequest")
+
+	newObject := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(request.Object, newObject); err != nil {
+		logger.WithError(err).Error("failed to decode")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+	oldObject := &hivev1.ClusterClaim{}
+	if err := a.decoder.DecodeRaw(request.OldObject, oldObject); err != nil {
+		logger.WithError(err).Error("failed to decode old object")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	logger = logger.
+		WithField("object.Name", newObject.Name).
+		WithField("object.Namespace", newObject.Namespace)
+
+	// Only a change of labels can change the tenant of the claim.
+	if a.kubeClient != nil && !maps.Equal(oldObject.Labels, newObject.Labels) {
+		if newObject.Namespace == "" {
+			newObject.Namespace = request.Namespace
+		}
+		pool, resp := a.getEnforcingPool(newObject, logger)
+		if resp != nil {
+			return resp
+		}
+		if pool != nil {
+			tenantLabelKey := pool.Spec.ClaimFairShare.TenantLabelKey
+			if oldObject.Labels[tenantLabelKey] != newObject.Labels[tenantLabelKey] {
+				return tenantMembershipResponse(request, logger, fmt.Errorf(
+					"ClusterPool %s does not permit changes to the %s label of its claims", pool.Name, tenantLabelKey))
+			}
+		}
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	logger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// getEnforcingPool returns the ClusterPool of the claim if it enforces the tenant membership of its claims, or nil if
+// it does not, or does not exist. If the pool cannot be looked up, it returns a response denying the request instead.
+func (a *ClusterClaimValidatingAdmissionHook) getEnforcingPool(claim *hivev1.ClusterClaim, logger log.FieldLogger) (*hivev1.ClusterPool, *admissionv1beta1.AdmissionResponse) {
+	pool := &hivev1.ClusterPool{}
+	switch err := a.kubeClient.Get(context.Background(), types.NamespacedName{Namespace: claim.Namespace, Name: claim.Spec.ClusterPoolName}, pool); {
+	case errors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		logger.WithError(err).Error("could not get ClusterPool")
+		status := errors.NewInternalError(err).Status()
+		return nil, &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+	if fairShare := pool.Spec.ClaimFairShare; fairShare == nil || !fairShare.EnforceTenantMembership {
+		return nil, nil
+	}
+	return pool, nil
+}
+
+// tenantMembershipResponse returns a response denying the request for failing a tenant membership check.
+func tenantMembershipResponse(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger, err error) *admissionv1beta1.AdmissionResponse {
+	logger.WithError(err).Info("failed tenant membership check")
+	gr := schema.GroupResource{Group: request.Resource.Group, Resource: request.Resource.Resource}
+	status := errors.NewForbidden(gr, request.Name, err).Status()
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
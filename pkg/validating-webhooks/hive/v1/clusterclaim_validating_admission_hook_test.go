@@ -0,0 +1,238 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+func Test_ClusterClaimAdmission_Validate_Kind(t *testing.T) {
+	cases := []struct {
+		name         string
+		group        string
+		version      string
+		resource     string
+		expectToSkip bool
+	}{
+		{
+			name:     "clusterclaim",
+			group:    clusterClaimGroup,
+			version:  clusterClaimVersion,
+			resource: clusterClaimResource,
+		},
+		{
+			name:         "different group",
+			group:        "other group",
+			version:      clusterClaimVersion,
+			resource:     clusterClaimResource,
+			expectToSkip: true,
+		},
+		{
+			name:         "different version",
+			group:        clusterClaimGroup,
+			version:      "other version",
+			resource:     clusterClaimResource,
+			expectToSkip: true,
+		},
+		{
+			name:         "different resource",
+			group:        clusterClaimGroup,
+			version:      clusterClaimVersion,
+			resource:     "other resource",
+			expectToSkip: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewClusterClaimValidatingAdmissionHook(*createDecoder())
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    tc.group,
+					Version:  tc.version,
+					Resource: tc.resource,
+				},
+				Operation: admissionv1beta1.Create,
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectToSkip, response.Allowed)
+		})
+	}
+}
+
+func Test_ClusterClaimAdmission_Validate_Quota(t *testing.T) {
+	quota := &hivev1.ClusterQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec:       hivev1.ClusterQuotaSpec{MaxClaimsPerPool: ptr.To(int32(1))},
+	}
+	claim := func(name string) *hivev1.ClusterClaim {
+		return &hivev1.ClusterClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: name},
+			Spec:       hivev1.ClusterClaimSpec{ClusterPoolName: "pool"},
+		}
+	}
+	cases := []struct {
+		name          string
+		existing      []runtime.Object
+		expectAllowed bool
+	}{
+		{
+			name:          "no quotas",
+			existing:      []runtime.Object{claim("existing")},
+			expectAllowed: true,
+		},
+		{
+			name:          "under limit",
+			existing:      []runtime.Object{quota},
+			expectAllowed: true,
+		},
+		{
+			name:     "at limit",
+			existing: []runtime.Object{quota, claim("existing")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewClusterClaimValidatingAdmissionHook(*createDecoder())
+			cut.kubeClient = testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.existing...).Build()
+			rawClaim, err := json.Marshal(claim("new"))
+			if !assert.NoError(t, err, "unexpected error marshalling claim") {
+				return
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    clusterClaimGroup,
+					Version:  clusterClaimVersion,
+					Resource: clusterClaimResource,
+				},
+				Operation: admissionv1beta1.Create,
+				Name:      "new",
+				Namespace: "tenant",
+				Object:    runtime.RawExtension{Raw: rawClaim},
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectAllowed, response.Allowed, "unexpected response: %#v", response.Result)
+			if !tc.expectAllowed {
+				assert.Equal(t, int32(http.StatusForbidden), response.Result.Code, "unexpected response code")
+			}
+		})
+	}
+}
+
+func Test_ClusterClaimAdmission_Validate_TenantMembership(t *testing.T) {
+	pool := func(enforce bool) *hivev1.ClusterPool {
+		return &hivev1.ClusterPool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "pool"},
+			Spec: hivev1.ClusterPoolSpec{
+				ClaimFairShare: &hivev1.ClusterPoolClaimFairShare{TenantLabelKey: "team", EnforceTenantMembership: enforce},
+			},
+		}
+	}
+	claim := func(labels map[string]string) *hivev1.ClusterClaim {
+		return &hivev1.ClusterClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "claim", Labels: labels},
+			Spec:       hivev1.ClusterClaimSpec{ClusterPoolName: "pool"},
+		}
+	}
+	cases := []struct {
+		name     string
+		existing []runtime.Object
+		// oldClaim is the claim being updated. If nil, claim is being created.
+		oldClaim      *hivev1.ClusterClaim
+		claim         *hivev1.ClusterClaim
+		expectAllowed bool
+	}{
+		{
+			name:          "no pool",
+			claim:         claim(map[string]string{"team": "team-b"}),
+			expectAllowed: true,
+		},
+		{
+			name:          "not enforced",
+			existing:      []runtime.Object{pool(false)},
+			claim:         claim(map[string]string{"team": "team-b"}),
+			expectAllowed: true,
+		},
+		{
+			name:          "member of tenant group",
+			existing:      []runtime.Object{pool(true)},
+			claim:         claim(map[string]string{"team": "team-a"}),
+			expectAllowed: true,
+		},
+		{
+			name:     "not member of tenant group",
+			existing: []runtime.Object{pool(true)},
+			claim:    claim(map[string]string{"team": "team-b"}),
+		},
+		{
+			name:     "no tenant label",
+			existing: []runtime.Object{pool(true)},
+			claim:    claim(nil),
+		},
+		{
+			name:     "tenant label changed",
+			existing: []runtime.Object{pool(true)},
+			oldClaim: claim(map[string]string{"team": "team-a"}),
+			claim:    claim(map[string]string{"team": "team-b"}),
+		},
+		{
+			name:          "tenant label changed when not enforced",
+			existing:      []runtime.Object{pool(false)},
+			oldClaim:      claim(map[string]string{"team": "team-a"}),
+			claim:         claim(map[string]string{"team": "team-b"}),
+			expectAllowed: true,
+		},
+		{
+			name:          "other label changed",
+			existing:      []runtime.Object{pool(true)},
+			oldClaim:      claim(map[string]string{"team": "team-a"}),
+			claim:         claim(map[string]string{"team": "team-a", "purpose": "ci"}),
+			expectAllowed: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewClusterClaimValidatingAdmissionHook(*createDecoder())
+			cut.kubeClient = testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.existing...).Build()
+			rawClaim, err := json.Marshal(tc.claim)
+			if !assert.NoError(t, err, "unexpected error marshalling claim") {
+				return
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    clusterClaimGroup,
+					Version:  clusterClaimVersion,
+					Resource: clusterClaimResource,
+				},
+				Operation: admissionv1beta1.Create,
+				Name:      tc.claim.Name,
+				Namespace: tc.claim.Namespace,
+				Object:    runtime.RawExtension{Raw: rawClaim},
+				UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"system:authenticated", "team-a"}},
+			}
+			if tc.oldClaim != nil {
+				rawOldClaim, err := json.Marshal(tc.oldClaim)
+				if !assert.NoError(t, err, "unexpected error marshalling old claim") {
+					return
+				}
+				request.Operation = admissionv1beta1.Update
+				request.OldObject = runtime.RawExtension{Raw: rawOldClaim}
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectAllowed, response.Allowed, "unexpected response: %#v", response.Result)
+			if !tc.expectAllowed {
+				assert.Equal(t, int32(http.StatusForbidden), response.Result.Code, "unexpected response code")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -20,8 +21,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	"k8s.io/client-go/rest"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -33,6 +36,8 @@ import (
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/clusterquota"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
@@ -57,6 +62,7 @@ var (
 		"ClusterPoolRef",
 		"PowerState",
 		"HibernateAfter",
+		"HibernationSchedule",
 		"InstallAttemptsLimit",
 		"Platform.AgentBareMetal.AgentSelector",
 		"Platform.AWS.PrivateLink.AdditionalAllowedPrincipals",
@@ -69,9 +75,13 @@ type ClusterDeploymentValidatingAdmissionHook struct {
 	decoder admission.Decoder
 
 	validManagedDomains  []string
+	managedDomains       []hivev1.ManageDNSConfig
 	fs                   *featureSet
 	awsPrivateLinkConfig *hivev1.AWSPrivateLinkConfig
 	supportedContracts   contracts.SupportedContractImplementationsList
+
+	// quotaClient is used to enforce ClusterQuotas. Quotas are not enforced if it is nil.
+	quotaClient client.Client
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
@@ -101,6 +111,7 @@ func NewClusterDeploymentValidatingAdmissionHook(decoder admission.Decoder) *Clu
 	return &ClusterDeploymentValidatingAdmissionHook{
 		decoder:              decoder,
 		validManagedDomains:  domains,
+		managedDomains:       managedDomains,
 		fs:                   newFeatureSet(),
 		awsPrivateLinkConfig: aplConfig,
 		supportedContracts:   supportContractsConfig,
@@ -134,7 +145,13 @@ func (a *ClusterDeploymentValidatingAdmissionHook) Initialize(kubeClientConfig *
 		"version":  clusterDeploymentAdmissionVersion,
 		"resource": "clusterdeploymentvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+
+	c, err := newQuotaClient(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+	a.quotaClient = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -320,7 +337,9 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, cd)...)
 
-	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec, a.managedDomains)...)
+
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
 
 	if cd.Spec.Platform.AWS != nil {
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
@@ -368,6 +387,16 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		}
 	}
 
+	// Don't hold up restoring ClusterDeployments from backup on quotas.
+	if a.quotaClient != nil && !dr {
+		if cd.Namespace == "" {
+			cd.Namespace = admissionSpec.Namespace
+		}
+		if resp := quotaCheckResponse(clusterquota.CheckClusterDeployment(a.quotaClient, cd), admissionSpec, contextLogger); resp != nil {
+			return resp
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -400,6 +429,41 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 	return allErrs
 }
 
+func validateHibernationSchedule(path *field.Path, schedule *hivev1.HibernationSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schedule == nil {
+		return allErrs
+	}
+	if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), schedule.TimeZone, err.Error()))
+	}
+	if len(schedule.RunningWindows) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("runningWindows"), "must specify at least one running window"))
+	}
+	for i, w := range schedule.RunningWindows {
+		windowPath := path.Child("runningWindows").Index(i)
+		if _, err := time.Parse(constants.HibernationScheduleTimeFormat, w.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("start"), w.Start, "must be a time of day in HH:MM format"))
+		}
+		if _, err := time.Parse(constants.HibernationScheduleTimeFormat, w.End); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("end"), w.End, "must be a time of day in HH:MM format"))
+		}
+	}
+	for i, e := range schedule.Exceptions {
+		exceptionPath := path.Child("exceptions").Index(i)
+		if !e.End.After(e.Start.Time) {
+			allErrs = append(allErrs, field.Invalid(exceptionPath.Child("end"), e.End, "must be after start"))
+		}
+		switch e.PowerState {
+		case hivev1.ClusterPowerStateRunning, hivev1.ClusterPowerStateHibernating:
+		default:
+			allErrs = append(allErrs, field.NotSupported(exceptionPath.Child("powerState"), e.PowerState,
+				[]string{string(hivev1.ClusterPowerStateRunning), string(hivev1.ClusterPowerStateHibernating)}))
+		}
+	}
+	return allErrs
+}
+
 /* TODO: move to explicit validation for AgentClusterInstall */
 /*
 func validateAgentInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeployment) field.ErrorList {
@@ -608,7 +672,7 @@ func validateClusterPoolPlatform(specPath *field.Path, cp *hivev1.ClusterPool) f
 	return validatePlatformConfiguration(platformPath, cp.Spec.Platform)
 }
 
-func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec) field.ErrorList {
+func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.ClusterDeploymentSpec, managedDomains []hivev1.ManageDNSConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	canManageDNS := false
 	if spec.Platform.AWS != nil {
@@ -620,12 +684,27 @@ func validateCanManageDNSForClusterPlatform(specPath *field.Path, spec hivev1.Cl
 	if spec.Platform.GCP != nil {
 		canManageDNS = true
 	}
+	md, _ := manageddns.FindManagedDomain(managedDomains, spec.BaseDomain)
+	// IBM Cloud zones are created in the CIS instance of the managed domain.
+	if spec.Platform.IBMCloud != nil && md != nil && md.IBMCloud != nil {
+		canManageDNS = true
+	}
+	// Other platforms have no DNS service of their own, but can use a name server accepting RFC2136 dynamic
+	// updates for the managed domain.
+	if md != nil && md.RFC2136 != nil {
+		canManageDNS = true
+	}
 	if !canManageDNS && spec.ManageDNS {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("manageDNS"), spec.ManageDNS, "cannot manage DNS for the selected platform"))
 	}
 	return allErrs
 }
 
+// isHiveControllers returns true if username is that of the service account as which the hive controllers run.
+func isHiveControllers(username string) bool {
+	return serviceaccount.MatchesUsername(controllerutils.GetHiveNamespace(), constants.HiveControllersServiceAccountName, username)
+}
+
 // validateUpdate specifically validates update operations for ClusterDeployment objects.
 func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
 	contextLogger := log.WithFields(log.Fields{
@@ -767,13 +846,16 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		}
 	}
 
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
+
 	// Validate the ClusterPoolRef:
 	switch oldPoolRef, newPoolRef := oldObject.Spec.ClusterPoolRef, cd.Spec.ClusterPoolRef; {
 	case oldPoolRef != nil && newPoolRef != nil:
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newPoolRef.Namespace, oldPoolRef.Namespace, specPath.Child("clusterPoolRef", "namespace"))...)
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newPoolRef.PoolName, oldPoolRef.PoolName, specPath.Child("clusterPoolRef", "poolName"))...)
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newPoolRef.CustomizationRef, oldPoolRef.CustomizationRef, specPath.Child("clusterPoolRef", "customizationRef"))...)
-		if oldClaim := oldPoolRef.ClaimName; oldClaim != "" {
+		// The clusterpool controller unclaims the clusters it recycles. No one else may unclaim a cluster.
+		if oldClaim := oldPoolRef.ClaimName; oldClaim != "" && !(newPoolRef.ClaimName == "" && isHiveControllers(admissionSpec.UserInfo.Username)) {
 			allErrs = append(allErrs, apivalidation.ValidateImmutableField(newPoolRef.ClaimName, oldClaim, specPath.Child("clusterPoolRef", "claimName"))...)
 		}
 	case oldPoolRef != nil && newPoolRef == nil:
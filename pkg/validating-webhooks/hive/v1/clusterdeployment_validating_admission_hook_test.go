@@ -8,10 +8,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -233,6 +235,7 @@ func TestClusterDeploymentValidate(t *testing.T) {
 		oldObject           *hivev1.ClusterDeployment
 		oldObjectRaw        []byte
 		operation           admissionv1beta1.Operation
+		username            string
 		expectedAllowed     bool
 		gvr                 *metav1.GroupVersionResource
 		enabledFeatureGates []string
@@ -378,6 +381,30 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test update with ClusterPoolReference unclaimed for recycling",
+			oldObject: validAWSClusterDeploymentFromPool("pool-ns", "mypool", "test-claim", "foo"),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeploymentFromPool("pool-ns", "mypool", "", "foo")
+				cd.Annotations = map[string]string{constants.RecyclePoolClusterAnnotation: "2026-01-01T00:00:00Z"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			username:        "system:serviceaccount:hive:hive-controllers",
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update with ClusterPoolReference unclaimed for recycling by a user",
+			oldObject: validAWSClusterDeploymentFromPool("pool-ns", "mypool", "test-claim", "foo"),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeploymentFromPool("pool-ns", "mypool", "", "foo")
+				cd.Annotations = map[string]string{constants.RecyclePoolClusterAnnotation: "2026-01-01T00:00:00Z"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			username:        "test-user",
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test update with changed claim",
 			oldObject:       validAWSClusterDeploymentFromPool("pool-ns", "mypool", "test-claim", ""),
@@ -1817,6 +1844,62 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test create with valid hibernationSchedule",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					TimeZone: "America/New_York",
+					RunningWindows: []hivev1.HibernationScheduleWindow{{
+						Days:  []hivev1.HibernationScheduleDay{"Monday", "Friday"},
+						Start: "08:00",
+						End:   "18:00",
+					}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with invalid hibernationSchedule time zone",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					TimeZone:       "Nowhere/Special",
+					RunningWindows: []hivev1.HibernationScheduleWindow{{Start: "08:00", End: "18:00"}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adding hibernationSchedule with invalid window",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					RunningWindows: []hivev1.HibernationScheduleWindow{{Start: "8am", End: "18:00"}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "cd.spec.hibernationSchedule is a mutable field",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+					RunningWindows: []hivev1.HibernationScheduleWindow{{Start: "22:00", End: "02:00"}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
 		{
 			name: "cd.spec.platform.aws.privateLink.additionalAllowedPrincipals is a mutable field",
 			oldObject: func() *hivev1.ClusterDeployment {
@@ -1884,6 +1967,7 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			request := &admissionv1beta1.AdmissionRequest{
 				Operation: tc.operation,
 				Resource:  *tc.gvr,
+				UserInfo:  authenticationv1.UserInfo{Username: tc.username},
 				Object: runtime.RawExtension{
 					Raw: tc.newObjectRaw,
 				},
@@ -1949,3 +2033,57 @@ func TestNewClusterDeploymentValidatingAdmissionHook(t *testing.T) {
 	webhook := NewClusterDeploymentValidatingAdmissionHook(*createDecoder())
 	assert.Equal(t, webhook.validManagedDomains, expectedDomains, "valid domains must match expected")
 }
+
+func TestValidateCanManageDNSForClusterPlatform(t *testing.T) {
+	managedDomains := []hivev1.ManageDNSConfig{
+		{
+			IBMCloud: &hivev1.ManageDNSIBMCloudConfig{CISInstanceCRN: "crn:v1:bluemix:public:internet-svcs:global:a/test::"},
+			Domains:  []string{"ibm.example.com"},
+		},
+		{
+			RFC2136: &hivev1.ManageDNSRFC2136Config{Server: "ns1.example.com"},
+			Domains: []string{"rfc2136.example.com"},
+		},
+		{
+			AWS:     &hivev1.ManageDNSAWSConfig{},
+			Domains: []string{"aws.example.com"},
+		},
+	}
+	cases := []struct {
+		name          string
+		cd            *hivev1.ClusterDeployment
+		baseDomain    string
+		expectAllowed bool
+	}{
+		{
+			name:          "IBM Cloud with IBM Cloud managed domain",
+			cd:            validIBMCloudClusterDeployment(),
+			baseDomain:    "test.ibm.example.com",
+			expectAllowed: true,
+		},
+		{
+			name:       "IBM Cloud without IBM Cloud managed domain",
+			cd:         validIBMCloudClusterDeployment(),
+			baseDomain: "test.aws.example.com",
+		},
+		{
+			name:          "vSphere with RFC2136 managed domain",
+			cd:            validVSphereClusterDeployment(),
+			baseDomain:    "test.rfc2136.example.com",
+			expectAllowed: true,
+		},
+		{
+			name:       "vSphere without RFC2136 managed domain",
+			cd:         validVSphereClusterDeployment(),
+			baseDomain: "test.aws.example.com",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cd.Spec.ManageDNS = true
+			tc.cd.Spec.BaseDomain = tc.baseDomain
+			errs := validateCanManageDNSForClusterPlatform(field.NewPath("spec"), tc.cd.Spec, managedDomains)
+			assert.Equal(t, tc.expectAllowed, len(errs) == 0, "unexpected errors: %v", errs)
+		})
+	}
+}
@@ -178,6 +178,9 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPoolPlatform(specPath, newObject)...)
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), newObject.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateClusterPoolAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateClusterPoolClaimFairShare(specPath.Child("claimFairShare"), newObject.Spec.ClaimFairShare)...)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -238,6 +241,9 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPoolPlatform(specPath, newObject)...)
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), newObject.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateClusterPoolAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
+	allErrs = append(allErrs, validateClusterPoolClaimFairShare(specPath.Child("claimFairShare"), newObject.Spec.ClaimFairShare)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -254,3 +260,34 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+func validateClusterPoolAutoscaling(path *field.Path, autoscaling *hivev1.ClusterPoolAutoscaling) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if autoscaling == nil {
+		return allErrs
+	}
+	if autoscaling.MaxSize < autoscaling.MinSize {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSize"), autoscaling.MaxSize, "must be greater than or equal to minSize"))
+	}
+	if d := autoscaling.DemandWindow; d != nil && d.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("demandWindow"), d.Duration.String(), "must be positive"))
+	}
+	if d := autoscaling.ScaleDownCooldown; d != nil && d.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("scaleDownCooldown"), d.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+func validateClusterPoolClaimFairShare(path *field.Path, fairShare *hivev1.ClusterPoolClaimFairShare) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if fairShare == nil {
+		return allErrs
+	}
+	for _, msg := range validation.IsQualifiedName(fairShare.TenantLabelKey) {
+		allErrs = append(allErrs, field.Invalid(path.Child("tenantLabelKey"), fairShare.TenantLabelKey, msg))
+	}
+	if limit := fairShare.MaxClaimedPerTenant; limit != nil && *limit < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxClaimedPerTenant"), *limit, "must be at least 1"))
+	}
+	return allErrs
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -10,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
@@ -216,6 +218,70 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "create with valid autoscaling",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MinSize:           1,
+					MaxSize:           5,
+					TargetIdle:        2,
+					DemandWindow:      &metav1.Duration{Duration: 2 * time.Hour},
+					ScaleDownCooldown: &metav1.Duration{Duration: 30 * time.Minute},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with autoscaling maxSize below minSize",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MinSize: 3, MaxSize: 2}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "update with zero autoscaling demandWindow",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{
+					MaxSize:      2,
+					DemandWindow: &metav1.Duration{},
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with valid claimFairShare",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ClaimFairShare = &hivev1.ClusterPoolClaimFairShare{
+					TenantLabelKey:      "example.com/team",
+					MaxClaimedPerTenant: ptr.To(int32(3)),
+				}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with invalid claimFairShare tenantLabelKey",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.ClaimFairShare = &hivev1.ClusterPoolClaimFairShare{TenantLabelKey: "not a label"}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test valid delete",
 			oldObject:       validAWSClusterPool(),
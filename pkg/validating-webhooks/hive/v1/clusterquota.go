@@ -0,0 +1,49 @@
+package v1
+
+import (
+	goerrors "errors"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/controller/clusterquota"
+	"github.com/openshift/hive/pkg/util/scheme"
+)
+
+// newQuotaClient returns a client with which to look up ClusterQuotas and the objects counted against them.
+// It reads directly from the API server so that objects created in quick succession are all counted.
+// Without a config there is no client, and quotas are not enforced.
+func newQuotaClient(kubeClientConfig *rest.Config) (client.Client, error) {
+	if kubeClientConfig == nil {
+		return nil, nil
+	}
+	return client.New(kubeClientConfig, client.Options{Scheme: scheme.GetScheme()})
+}
+
+// quotaCheckResponse converts the result of a ClusterQuota check of the request's object into a response denying
+// the request, or returns nil if the check passed.
+func quotaCheckResponse(err error, request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	if err == nil {
+		return nil
+	}
+	var exceededErr *clusterquota.ExceededError
+	var status metav1.Status
+	if goerrors.As(err, &exceededErr) {
+		logger.WithError(err).Info("failed ClusterQuota check")
+		gr := schema.GroupResource{Group: request.Resource.Group, Resource: request.Resource.Resource}
+		status = errors.NewForbidden(gr, request.Name, err).Status()
+	} else {
+		logger.WithError(err).Error("could not check ClusterQuotas")
+		status = errors.NewInternalError(err).Status()
+	}
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
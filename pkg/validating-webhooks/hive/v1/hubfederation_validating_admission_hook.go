@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	hubFederationGroup    = "hive.openshift.io"
+	hubFederationVersion  = "v1"
+	hubFederationResource = "hubfederations"
+)
+
+// HubFederationValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type HubFederationValidatingAdmissionHook struct {
+	decoder admission.Decoder
+
+	// kubeClient is used to look up existing HubFederations, in order to allow only one per hub. It is not enforced if
+	// kubeClient is nil.
+	kubeClient client.Client
+}
+
+// NewHubFederationValidatingAdmissionHook constructs a new HubFederationValidatingAdmissionHook
+func NewHubFederationValidatingAdmissionHook(decoder admission.Decoder) *HubFederationValidatingAdmissionHook {
+	return &HubFederationValidatingAdmissionHook{decoder: decoder}
+}
+
+// ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/hubfederationvalidators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+func (a *HubFederationValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "hubfederationvalidator",
+	}).Info("Registering validation REST resource")
+	// NOTE: This GVR is meant to be different than the HubFederation CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "hubfederationvalidators",
+		},
+		"hubfederationvalidator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *HubFederationValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "hubfederationvalidator",
+	}).Info("Initializing validation REST resource")
+
+	c, err := newQuotaClient(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+	a.kubeClient = c
+	return nil
+}
+
+// Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission validation request.
+func (a *HubFederationValidatingAdmissionHook) Validate(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	logger := log.WithFields(log.Fields{
+		"operation": request.Operation,
+		"group":     request.Resource.Group,
+		"version":   request.Resource.Version,
+		"resource":  request.Resource.Resource,
+		"method":    "Validate",
+	})
+
+	if !a.shouldValidate(request, logger) {
+		logger.Info("Skipping validation for request")
+		// The request object isn't something that this validator should validate.
+		// Therefore, we say that it's allowed.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	logger.Info("Validating request")
+
+	switch request.Operation {
+	case admissionv1beta1.Create:
+		return a.validateCreateRequest(request, logger)
+	default:
+		logger.Info("Successful validation")
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+}
+
+// shouldValidate explicitly checks if the request should validated. For example, this webhook may have accidentally been registered to check
+// the validity of some other type of object with a different GVR.
+func (a *HubFederationValidatingAdmissionHook) shouldValidate(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) bool {
+	logger = logger.WithField("method", "shouldValidate")
+
+	if request.Resource.Group != hubFederationGroup {
+		logger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if request.Resource.Version != hubFederationVersion {
+		logger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if request.Resource.Resource != hubFederationResource {
+		logger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	// If we get here, then we're supposed to validate the object.
+	logger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
+
+// validateCreateRequest specifically validates create operations for HubFederation objects. A hub has a single
+// HubFederation, so the creation of another is denied.
+func (a *HubFederationValidatingAdmissionHook) validateCreateRequest(request *admissionv1beta1.AdmissionRequest, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	logger = logger.WithField("method", "validateCreateRequest")
+
+	newObject := &hivev1.HubFederation{}
+	if err := a.decoder.DecodeRaw(request.Object, newObject); err != nil {
+		logger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	logger = logger.WithField("object.Name", newObject.Name)
+
+	if a.kubeClient != nil {
+		fedList := &hivev1.HubFederationList{}
+		if err := a.kubeClient.List(context.Background(), fedList); err != nil {
+			logger.WithError(err).Error("could not list HubFederations")
+			status := errors.NewInternalError(err).Status()
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result:  &status,
+			}
+		}
+		for _, fed := range fedList.Items {
+			if fed.Name == newObject.Name {
+				continue
+			}
+			err := fmt.Errorf("HubFederation %s already exists, and a hub may only have one", fed.Name)
+			logger.WithError(err).Info("failed validation")
+			gr := schema.GroupResource{Group: request.Resource.Group, Resource: request.Resource.Resource}
+			status := errors.NewForbidden(gr, request.Name, err).Status()
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result:  &status,
+			}
+		}
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	logger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
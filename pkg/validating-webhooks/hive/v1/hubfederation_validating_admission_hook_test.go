@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testfake "github.com/openshift/hive/pkg/test/fake"
+)
+
+func Test_HubFederationAdmission_Validate_Kind(t *testing.T) {
+	cases := []struct {
+		name         string
+		group        string
+		version      string
+		resource     string
+		expectToSkip bool
+	}{
+		{
+			name:     "hubfederation",
+			group:    hubFederationGroup,
+			version:  hubFederationVersion,
+			resource: hubFederationResource,
+		},
+		{
+			name:         "different group",
+			group:        "other group",
+			version:      hubFederationVersion,
+			resource:     hubFederationResource,
+			expectToSkip: true,
+		},
+		{
+			name:         "different version",
+			group:        hubFederationGroup,
+			version:      "other version",
+			resource:     hubFederationResource,
+			expectToSkip: true,
+		},
+		{
+			name:         "different resource",
+			group:        hubFederationGroup,
+			version:      hubFederationVersion,
+			resource:     "other resource",
+			expectToSkip: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewHubFederationValidatingAdmissionHook(*createDecoder())
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    tc.group,
+					Version:  tc.version,
+					Resource: tc.resource,
+				},
+				Operation: admissionv1beta1.Create,
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectToSkip, response.Allowed)
+		})
+	}
+}
+
+func Test_HubFederationAdmission_Validate_Create(t *testing.T) {
+	federation := func(name string) *hivev1.HubFederation {
+		return &hivev1.HubFederation{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       hivev1.HubFederationSpec{HubName: "hub-a"},
+		}
+	}
+	cases := []struct {
+		name          string
+		existing      []runtime.Object
+		expectAllowed bool
+	}{
+		{
+			name:          "first federation",
+			expectAllowed: true,
+		},
+		{
+			name:          "same federation",
+			existing:      []runtime.Object{federation("new")},
+			expectAllowed: true,
+		},
+		{
+			name:     "second federation",
+			existing: []runtime.Object{federation("existing")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cut := NewHubFederationValidatingAdmissionHook(*createDecoder())
+			cut.kubeClient = testfake.NewFakeClientBuilder().WithRuntimeObjects(tc.existing...).Build()
+			rawFederation, err := json.Marshal(federation("new"))
+			if !assert.NoError(t, err, "unexpected error marshalling federation") {
+				return
+			}
+			request := &admissionv1beta1.AdmissionRequest{
+				Resource: metav1.GroupVersionResource{
+					Group:    hubFederationGroup,
+					Version:  hubFederationVersion,
+					Resource: hubFederationResource,
+				},
+				Operation: admissionv1beta1.Create,
+				Name:      "new",
+				Object:    runtime.RawExtension{Raw: rawFederation},
+			}
+			response := cut.Validate(request)
+			assert.Equal(t, tc.expectAllowed, response.Allowed, "unexpected response: %#v", response.Result)
+			if !tc.expectAllowed {
+				assert.Equal(t, int32(http.StatusForbidden), response.Result.Code, "unexpected response code")
+			}
+		})
+	}
+}
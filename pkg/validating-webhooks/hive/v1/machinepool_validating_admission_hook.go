@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -27,6 +28,7 @@ import (
 	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
 	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/clusterquota"
 )
 
 const (
@@ -42,6 +44,9 @@ const (
 // MachinePoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type MachinePoolValidatingAdmissionHook struct {
 	decoder admission.Decoder
+
+	// quotaClient is used to enforce ClusterQuotas. Quotas are not enforced if it is nil.
+	quotaClient client.Client
 }
 
 // NewMachinePoolValidatingAdmissionHook constructs a new MachinePoolValidatingAdmissionHook
@@ -76,7 +81,12 @@ func (a *MachinePoolValidatingAdmissionHook) Initialize(kubeClientConfig *rest.C
 		"resource": "machinepoolvalidator",
 	}).Info("Initializing validation REST resource")
 
-	return nil // No initialization needed right now.
+	c, err := newQuotaClient(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+	a.quotaClient = c
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -161,6 +171,10 @@ func (a *MachinePoolValidatingAdmissionHook) validateCreateRequest(request *admi
 		}
 	}
 
+	if resp := a.checkQuotas(request, nil, newObject, logger); resp != nil {
+		return resp
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	logger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -195,6 +209,10 @@ func (a *MachinePoolValidatingAdmissionHook) validateUpdateRequest(request *admi
 		}
 	}
 
+	if resp := a.checkQuotas(request, oldObject, newObject, logger); resp != nil {
+		return resp
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	logger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
@@ -202,6 +220,18 @@ func (a *MachinePoolValidatingAdmissionHook) validateUpdateRequest(request *admi
 	}
 }
 
+// checkQuotas denies the request if it would take the namespace over the vCPU limit of a ClusterQuota. oldObject
+// is nil for create requests.
+func (a *MachinePoolValidatingAdmissionHook) checkQuotas(request *admissionv1beta1.AdmissionRequest, oldObject, newObject *hivev1.MachinePool, logger log.FieldLogger) *admissionv1beta1.AdmissionResponse {
+	if a.quotaClient == nil {
+		return nil
+	}
+	if newObject.Namespace == "" {
+		newObject.Namespace = request.Namespace
+	}
+	return quotaCheckResponse(clusterquota.CheckMachinePool(a.quotaClient, oldObject, newObject), request, logger)
+}
+
 func (a *MachinePoolValidatingAdmissionHook) decode(raw runtime.RawExtension, logger log.FieldLogger) (*hivev1.MachinePool, *admissionv1beta1.AdmissionResponse) {
 	obj := &hivev1.MachinePool{}
 	if err := a.decoder.DecodeRaw(raw, obj); err != nil {
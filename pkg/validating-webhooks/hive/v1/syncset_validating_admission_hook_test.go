@@ -258,6 +258,38 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid serverSideApply create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = hivev1.ServerSideApplySyncSetApplyBehavior
+				ss.Spec.ServerSideApply = &hivev1.SyncSetServerSideApply{FieldManager: "my-manager", ForceConflicts: true}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test serverSideApply without ServerSideApply applyBehavior create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ServerSideApply = &hivev1.SyncSetServerSideApply{ForceConflicts: true}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test serverSideApply without ServerSideApply applyBehavior update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = hivev1.CreateOrUpdateSyncSetApplyBehavior
+				ss.Spec.ServerSideApply = &hivev1.SyncSetServerSideApply{ForceConflicts: true}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable Resource create",
 			operation:       admissionv1beta1.Create,
@@ -0,0 +1,184 @@
+package vsphereclient
+
+import (
+	"context"
+	"crypto/x509"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// API represents the calls made to the vSphere API.
+type API interface {
+	// ListVirtualMachines returns the virtual machines attached to the given tag.
+	ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error)
+	// PowerOnVirtualMachines powers on the given virtual machines.
+	PowerOnVirtualMachines(ctx context.Context, vms []mo.VirtualMachine) error
+	// PowerOffVirtualMachines shuts down the given virtual machines. The guest OS is asked to shut down
+	// when VMware Tools is running, otherwise the virtual machine is powered off.
+	PowerOffVirtualMachines(ctx context.Context, vms []mo.VirtualMachine) error
+	// Logout ends the client's sessions with the vCenter.
+	Logout()
+}
+
+// Client makes calls to the vSphere API.
+type Client struct {
+	client     *vim25.Client
+	restClient *rest.Client
+}
+
+const defaultTimeout = 5 * time.Minute
+
+// virtualMachineProperties are the properties retrieved for each virtual machine.
+var virtualMachineProperties = []string{"name", "summary.runtime.powerState", "guest.toolsRunningStatus"}
+
+// NewClient creates a client logged in to the given vCenter. rootCAs is an optional PEM encoded
+// bundle of certificates to trust when connecting to the vCenter.
+// Logout() must be called when you are done with the client.
+func NewClient(vCenter, username, password string, rootCAs []byte) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	u, err := soap.ParseURL(vCenter)
+	if err != nil {
+		return nil, err
+	}
+	u.User = url.UserPassword(username, password)
+
+	soapClient := soap.NewClient(u, false)
+	if len(rootCAs) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(rootCAs) {
+			return nil, errors.New("failed to parse vSphere CA certificates")
+		}
+		soapClient.DefaultTransport().TLSClientConfig.RootCAs = pool
+	}
+
+	vimClient, err := vim25.NewClient(ctx, soapClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create vSphere client")
+	}
+	if err := session.NewManager(vimClient).Login(ctx, u.User); err != nil {
+		return nil, errors.Wrap(err, "failed to log in to vCenter")
+	}
+
+	restClient := rest.NewClient(vimClient)
+	if err := restClient.Login(ctx, u.User); err != nil {
+		session.NewManager(vimClient).Logout(ctx)
+		return nil, errors.Wrap(err, "failed to log in to vCenter REST API")
+	}
+
+	return &Client{
+		client:     vimClient,
+		restClient: restClient,
+	}, nil
+}
+
+// NewClientFromSecret creates a client for the given vCenter using the username and password in the
+// credentials secret and, if provided, the CA certificates in the certificates secret.
+func NewClientFromSecret(vCenter string, credsSecret, certsSecret *corev1.Secret) (*Client, error) {
+	username := strings.TrimSpace(string(credsSecret.Data[constants.UsernameSecretKey]))
+	password := strings.TrimSpace(string(credsSecret.Data[constants.PasswordSecretKey]))
+	if username == "" || password == "" {
+		return nil, errors.Errorf("creds secret does not contain %q and %q data", constants.UsernameSecretKey, constants.PasswordSecretKey)
+	}
+	var rootCAs []byte
+	if certsSecret != nil {
+		for _, cert := range certsSecret.Data {
+			rootCAs = append(rootCAs, cert...)
+			rootCAs = append(rootCAs, '\n')
+		}
+	}
+	return NewClient(vCenter, username, password, rootCAs)
+}
+
+// Logout ends the client's sessions with the vCenter.
+func (c *Client) Logout() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c.restClient.Logout(ctx)
+	session.NewManager(c.client).Logout(ctx)
+}
+
+// ListVirtualMachines returns the virtual machines attached to the given tag.
+func (c *Client) ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	attached, err := tags.NewManager(c.restClient).GetAttachedObjectsOnTags(ctx, []string{tag})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects attached to tag %s", tag)
+	}
+	var refs []types.ManagedObjectReference
+	for _, a := range attached {
+		for _, ref := range a.ObjectIDs {
+			if ref.Reference().Type == "VirtualMachine" {
+				refs = append(refs, ref.Reference())
+			}
+		}
+	}
+
+	var vms []mo.VirtualMachine
+	if len(refs) == 0 {
+		return vms, nil
+	}
+	if err := property.DefaultCollector(c.client).Retrieve(ctx, refs, virtualMachineProperties, &vms); err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve virtual machines")
+	}
+	return vms, nil
+}
+
+// PowerOnVirtualMachines powers on the given virtual machines.
+func (c *Client) PowerOnVirtualMachines(ctx context.Context, vms []mo.VirtualMachine) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, vmMO := range vms {
+		vm := object.NewVirtualMachine(c.client, vmMO.Reference())
+		if _, err := vm.PowerOn(ctx); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to power on virtual machine %s", vmMO.Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// PowerOffVirtualMachines shuts down the given virtual machines. The guest OS is asked to shut down
+// when VMware Tools is running, otherwise the virtual machine is powered off.
+func (c *Client) PowerOffVirtualMachines(ctx context.Context, vms []mo.VirtualMachine) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, vmMO := range vms {
+		vm := object.NewVirtualMachine(c.client, vmMO.Reference())
+		var err error
+		if vmMO.Guest != nil && vmMO.Guest.ToolsRunningStatus == string(types.VirtualMachineToolsRunningStatusGuestToolsRunning) {
+			err = vm.ShutdownGuest(ctx)
+		} else {
+			_, err = vm.PowerOff(ctx)
+		}
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to power off virtual machine %s", vmMO.Name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
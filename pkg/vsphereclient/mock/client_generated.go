@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	mo "github.com/vmware/govmomi/vim25/mo"
+)
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// ListVirtualMachines mocks base method.
+func (m *MockAPI) ListVirtualMachines(ctx context.Context, tag string) ([]mo.VirtualMachine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVirtualMachines", ctx, tag)
+	ret0, _ := ret[0].([]mo.VirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVirtualMachines indicates an expected call of ListVirtualMachines.
+func (mr *MockAPIMockRecorder) ListVirtualMachines(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVirtualMachines", reflect.TypeOf((*MockAPI)(nil).ListVirtualMachines), ctx, tag)
+}
+
+// Logout mocks base method.
+func (m *MockAPI) Logout() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Logout")
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAPIMockRecorder) Logout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAPI)(nil).Logout))
+}
+
+// PowerOffVirtualMachines mocks base method.
+func (m *MockAPI) PowerOffVirtualMachines(ctx context.Context, vms []mo.VirtualMachine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOffVirtualMachines", ctx, vms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOffVirtualMachines indicates an expected call of PowerOffVirtualMachines.
+func (mr *MockAPIMockRecorder) PowerOffVirtualMachines(ctx, vms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOffVirtualMachines", reflect.TypeOf((*MockAPI)(nil).PowerOffVirtualMachines), ctx, vms)
+}
+
+// PowerOnVirtualMachines mocks base method.
+func (m *MockAPI) PowerOnVirtualMachines(ctx context.Context, vms []mo.VirtualMachine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PowerOnVirtualMachines", ctx, vms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PowerOnVirtualMachines indicates an expected call of PowerOnVirtualMachines.
+func (mr *MockAPIMockRecorder) PowerOnVirtualMachines(ctx, vms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOnVirtualMachines", reflect.TypeOf((*MockAPI)(nil).PowerOnVirtualMachines), ctx, vms)
+}